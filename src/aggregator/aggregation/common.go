@@ -35,6 +35,12 @@ func stdev(count int64, sumSq, sum float64) float64 {
 	return math.Sqrt(num / float64(div))
 }
 
+// int64Bits carries the bits of an int64 value by a float64 value.
+func int64Bits(v int64) float64 { return math.Float64frombits(uint64(v)) }
+
+// bitsInt64 returns the int64 value carried by a float64 value.
+func bitsInt64(v float64) int64 { return int64(math.Float64bits(v)) }
+
 func isExpensive(aggTypes aggregation.Types) bool {
 	for _, aggType := range aggTypes {
 		if aggType == aggregation.SumSq || aggType == aggregation.Stdev {
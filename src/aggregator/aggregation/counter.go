@@ -21,12 +21,18 @@
 package aggregation
 
 import (
+	"errors"
 	"math"
 	"time"
 
 	"github.com/m3db/m3/src/metrics/aggregation"
 )
 
+// counterEncodedLen is the number of values of an encoded counter.
+const counterEncodedLen = 5
+
+var errInvalidEncodedCounter = errors.New("invalid encoded counter")
+
 // Counter aggregates counter values.
 type Counter struct {
 	Options
@@ -74,6 +80,42 @@ func (c *Counter) Update(timestamp time.Time, value int64) {
 	}
 }
 
+// AppendFloats appends an encoding of the counter as float64 values to dst,
+// so that the counter can be checkpointed and restored with MergeFloats. The
+// bits of the integer fields are carried by the values so they round-trip
+// losslessly, which means the values are only meaningful to MergeFloats.
+func (c *Counter) AppendFloats(dst []float64) []float64 {
+	return append(dst,
+		int64Bits(c.sum),
+		int64Bits(c.sumSq),
+		int64Bits(c.count),
+		int64Bits(c.max),
+		int64Bits(c.min),
+	)
+}
+
+// MergeFloats merges the counters encoded in the values into the counter.
+func (c *Counter) MergeFloats(timestamp time.Time, values []float64) error {
+	if len(values)%counterEncodedLen != 0 {
+		return errInvalidEncodedCounter
+	}
+	if len(values) > 0 && (c.lastAt.IsZero() || timestamp.After(c.lastAt)) {
+		c.lastAt = timestamp
+	}
+	for ; len(values) > 0; values = values[counterEncodedLen:] {
+		c.sum += bitsInt64(values[0])
+		c.sumSq += bitsInt64(values[1])
+		c.count += bitsInt64(values[2])
+		if max := bitsInt64(values[3]); c.max < max {
+			c.max = max
+		}
+		if min := bitsInt64(values[4]); c.min > min {
+			c.min = min
+		}
+	}
+	return nil
+}
+
 // LastAt returns the time of the last value received.
 func (c *Counter) LastAt() time.Time { return c.lastAt }
 
@@ -21,6 +21,7 @@
 package aggregation
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -76,3 +77,30 @@ func TestCounterCustomAggregationType(t *testing.T) {
 		}
 	}
 }
+
+func TestCounterAppendMergeFloats(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.HasExpensiveAggregations = true
+
+	now := time.Now()
+	c := NewCounter(opts)
+	c.Update(now, math.MaxInt64-10)
+	c.Update(now.Add(time.Second), -3)
+
+	restored := NewCounter(opts)
+	require.NoError(t, restored.MergeFloats(c.LastAt(), c.AppendFloats(nil)))
+	require.Equal(t, c.LastAt(), restored.LastAt())
+	require.Equal(t, c.Sum(), restored.Sum())
+	require.Equal(t, c.SumSq(), restored.SumSq())
+	require.Equal(t, c.Count(), restored.Count())
+	require.Equal(t, int64(math.MaxInt64-10), restored.Max())
+	require.Equal(t, int64(-3), restored.Min())
+
+	// Merging into a counter with values aggregates both.
+	restored.Update(now, 100)
+	require.NoError(t, restored.MergeFloats(now, c.AppendFloats(nil)))
+	require.Equal(t, int64(5), restored.Count())
+	require.Equal(t, int64(-3), restored.Min())
+
+	require.Error(t, restored.MergeFloats(now, []float64{1, 2}))
+}
@@ -21,12 +21,18 @@
 package aggregation
 
 import (
+	"errors"
 	"math"
 	"time"
 
 	"github.com/m3db/m3/src/metrics/aggregation"
 )
 
+// gaugeEncodedLen is the number of values of an encoded gauge.
+const gaugeEncodedLen = 6
+
+var errInvalidEncodedGauge = errors.New("invalid encoded gauge")
+
 // Gauge aggregates gauge values.
 type Gauge struct {
 	Options
@@ -80,6 +86,37 @@ func (g *Gauge) Update(timestamp time.Time, value float64) {
 	}
 }
 
+// AppendFloats appends an encoding of the gauge as float64 values to dst, so
+// that the gauge can be checkpointed and restored with MergeFloats.
+func (g *Gauge) AppendFloats(dst []float64) []float64 {
+	return append(dst, g.last, g.sum, g.sumSq, int64Bits(g.count), g.max, g.min)
+}
+
+// MergeFloats merges the gauges encoded in the values into the gauge, the
+// last value of an encoded gauge is kept if the timestamp is later than the
+// time of the last value received by the gauge.
+func (g *Gauge) MergeFloats(timestamp time.Time, values []float64) error {
+	if len(values)%gaugeEncodedLen != 0 {
+		return errInvalidEncodedGauge
+	}
+	for ; len(values) > 0; values = values[gaugeEncodedLen:] {
+		if g.lastAt.IsZero() || timestamp.After(g.lastAt) {
+			g.lastAt = timestamp
+			g.last = values[0]
+		}
+		g.sum += values[1]
+		g.sumSq += values[2]
+		g.count += bitsInt64(values[3])
+		if max := values[4]; math.IsNaN(g.max) || g.max < max {
+			g.max = max
+		}
+		if min := values[5]; math.IsNaN(g.min) || g.min > min {
+			g.min = min
+		}
+	}
+	return nil
+}
+
 // LastAt returns the time of the last value received.
 func (g *Gauge) LastAt() time.Time { return g.lastAt }
 
@@ -142,3 +142,39 @@ func TestGaugeLastOutOfOrderValues(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, int64(2), counter.Value())
 }
+
+func TestGaugeAppendMergeFloats(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.HasExpensiveAggregations = true
+
+	now := time.Now()
+	g := NewGauge(opts)
+	g.Update(now, 1.5)
+	g.Update(now.Add(time.Second), -2.5)
+
+	restored := NewGauge(opts)
+	require.NoError(t, restored.MergeFloats(g.LastAt(), g.AppendFloats(nil)))
+	require.Equal(t, g.LastAt(), restored.LastAt())
+	require.Equal(t, -2.5, restored.Last())
+	require.Equal(t, g.Sum(), restored.Sum())
+	require.Equal(t, g.SumSq(), restored.SumSq())
+	require.Equal(t, g.Count(), restored.Count())
+	require.Equal(t, 1.5, restored.Max())
+	require.Equal(t, -2.5, restored.Min())
+
+	// The last value received later than the encoded gauge is kept.
+	other := NewGauge(opts)
+	other.Update(now.Add(time.Minute), 42)
+	require.NoError(t, other.MergeFloats(g.LastAt(), g.AppendFloats(nil)))
+	require.Equal(t, 42.0, other.Last())
+	require.Equal(t, int64(3), other.Count())
+	require.Equal(t, 42.0, other.Max())
+
+	// Encoded gauges without values keep the gauge min and max unset.
+	empty := NewGauge(opts)
+	require.NoError(t, empty.MergeFloats(now, NewGauge(opts).AppendFloats(nil)))
+	require.True(t, math.IsNaN(empty.Max()))
+	require.True(t, math.IsNaN(empty.Min()))
+
+	require.Error(t, empty.MergeFloats(now, []float64{1}))
+}
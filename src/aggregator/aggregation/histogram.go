@@ -0,0 +1,181 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/histogram"
+)
+
+const (
+	defaultHistogramSchema     int32 = 3
+	defaultHistogramMaxBuckets       = 160
+)
+
+var errHistogramMaxBucketsNotPositive = errors.New("histogram max buckets must be positive")
+
+// HistogramOptions are the options for histogram aggregations.
+type HistogramOptions struct {
+	// Schema is the schema histograms are aggregated with, which is lowered
+	// as needed to keep the number of buckets within MaxBuckets.
+	Schema int32
+	// ZeroThreshold is the boundary of the zero bucket histograms are
+	// aggregated with, which is widened to that of incoming histograms.
+	ZeroThreshold float64
+	// MaxBuckets is the max number of buckets of an aggregated histogram.
+	MaxBuckets int
+}
+
+// NewHistogramOptions creates a new set of histogram options.
+func NewHistogramOptions() HistogramOptions {
+	return HistogramOptions{
+		Schema:     defaultHistogramSchema,
+		MaxBuckets: defaultHistogramMaxBuckets,
+	}
+}
+
+// Validate validates the histogram options.
+func (o HistogramOptions) Validate() error {
+	if o.Schema < histogram.MinSchema || o.Schema > histogram.MaxSchema {
+		return fmt.Errorf("histogram schema %d is not in [%d, %d]",
+			o.Schema, histogram.MinSchema, histogram.MaxSchema)
+	}
+	if o.ZeroThreshold < 0 {
+		return fmt.Errorf("histogram zero threshold %v is negative", o.ZeroThreshold)
+	}
+	if o.MaxBuckets <= 0 {
+		return errHistogramMaxBucketsNotPositive
+	}
+	return nil
+}
+
+// Histogram aggregates histograms and histogram values.
+type Histogram struct {
+	Options
+
+	maxBuckets int
+	lastAt     time.Time
+	histogram  histogram.Histogram
+}
+
+// NewHistogram creates a new histogram.
+func NewHistogram(histOpts HistogramOptions, opts Options) Histogram {
+	return Histogram{
+		Options:    opts,
+		maxBuckets: histOpts.MaxBuckets,
+		histogram: histogram.Histogram{
+			Schema:        histOpts.Schema,
+			ZeroThreshold: histOpts.ZeroThreshold,
+		},
+	}
+}
+
+// Add adds a value to the histogram.
+func (h *Histogram) Add(timestamp time.Time, value float64) {
+	h.updateLastAt(timestamp)
+	h.histogram.Add(value)
+	h.histogram.LimitBuckets(h.maxBuckets)
+}
+
+// Merge merges a histogram into the histogram.
+func (h *Histogram) Merge(timestamp time.Time, other histogram.Histogram) {
+	h.updateLastAt(timestamp)
+	h.histogram.Merge(other)
+	h.histogram.LimitBuckets(h.maxBuckets)
+}
+
+// MergeFloats merges the histograms encoded in the values, such as the values
+// of a forwarded histogram, into the histogram.
+func (h *Histogram) MergeFloats(timestamp time.Time, values []float64) error {
+	for len(values) > 0 {
+		other, rest, err := histogram.ReadFloats(values)
+		if err != nil {
+			h.Options.Metrics.Histogram.IncValuesInvalid()
+			return err
+		}
+		h.Merge(timestamp, other)
+		values = rest
+	}
+	return nil
+}
+
+func (h *Histogram) updateLastAt(timestamp time.Time) {
+	if h.lastAt.IsZero() || timestamp.After(h.lastAt) {
+		h.lastAt = timestamp
+	}
+}
+
+// LastAt returns the time of the last value received.
+func (h *Histogram) LastAt() time.Time { return h.lastAt }
+
+// Histogram returns the aggregated histogram.
+func (h *Histogram) Histogram() histogram.Histogram { return h.histogram }
+
+// Count returns the number of values received.
+func (h *Histogram) Count() uint64 { return h.histogram.Count }
+
+// Sum returns the sum of the values received.
+func (h *Histogram) Sum() float64 { return h.histogram.Sum }
+
+// Mean returns the mean of the values received.
+func (h *Histogram) Mean() float64 {
+	if h.histogram.Count == 0 {
+		return 0.0
+	}
+	return h.histogram.Sum / float64(h.histogram.Count)
+}
+
+// Quantile returns the quantile estimated from the buckets of the histogram.
+func (h *Histogram) Quantile(q float64) float64 {
+	return h.histogram.Quantile(q)
+}
+
+// AppendCumulativeBuckets appends the cumulative buckets of the histogram to dst.
+func (h *Histogram) AppendCumulativeBuckets(
+	dst []histogram.CumulativeBucket,
+) []histogram.CumulativeBucket {
+	return h.histogram.AppendCumulativeBuckets(dst)
+}
+
+// ValueOf returns the value for the aggregation type, the buckets of the
+// histogram are not a single value and are returned by AppendCumulativeBuckets
+// instead.
+func (h *Histogram) ValueOf(aggType aggregation.Type) float64 {
+	switch aggType {
+	case aggregation.Mean:
+		return h.Mean()
+	case aggregation.Count:
+		return float64(h.Count())
+	case aggregation.Sum:
+		return h.Sum()
+	}
+	if q, ok := aggType.Quantile(); ok {
+		return h.Quantile(q)
+	}
+	return 0
+}
+
+// Close closes the histogram.
+func (h *Histogram) Close() {}
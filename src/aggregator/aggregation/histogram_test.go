@@ -0,0 +1,113 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/histogram"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogramOptionsValidate(t *testing.T) {
+	require.NoError(t, NewHistogramOptions().Validate())
+
+	opts := NewHistogramOptions()
+	opts.Schema = histogram.MaxSchema + 1
+	require.Error(t, opts.Validate())
+
+	opts = NewHistogramOptions()
+	opts.ZeroThreshold = -1
+	require.Error(t, opts.Validate())
+
+	opts = NewHistogramOptions()
+	opts.MaxBuckets = 0
+	require.Error(t, opts.Validate())
+}
+
+func TestHistogramAddAndMerge(t *testing.T) {
+	h := NewHistogram(NewHistogramOptions(), NewOptions(instrument.NewOptions()))
+	require.Equal(t, 0.0, h.ValueOf(aggregation.Mean))
+	require.True(t, math.IsNaN(h.ValueOf(aggregation.P99)))
+
+	now := time.Now()
+	for i := 1; i <= 100; i++ {
+		h.Add(now, float64(i))
+	}
+	var other histogram.Histogram
+	other.Schema = 5
+	for i := 101; i <= 200; i++ {
+		other.Add(float64(i))
+	}
+	h.Merge(now.Add(time.Second), other)
+
+	require.Equal(t, now.Add(time.Second), h.LastAt())
+	require.Equal(t, 200.0, h.ValueOf(aggregation.Count))
+	require.Equal(t, 20100.0, h.ValueOf(aggregation.Sum))
+	require.Equal(t, 100.5, h.ValueOf(aggregation.Mean))
+	require.Equal(t, 0.0, h.ValueOf(aggregation.Buckets))
+
+	// Buckets of schema 3 are ~9% wide.
+	require.InDelta(t, 100.0, h.ValueOf(aggregation.P50), 9)
+	require.InDelta(t, 198.0, h.ValueOf(aggregation.P99), 18)
+}
+
+func TestHistogramLimitsBuckets(t *testing.T) {
+	histOpts := NewHistogramOptions()
+	histOpts.MaxBuckets = 10
+	h := NewHistogram(histOpts, NewOptions(instrument.NewOptions()))
+	for i := 1; i <= 1000; i++ {
+		h.Add(time.Now(), float64(i))
+	}
+	hist := h.Histogram()
+	require.True(t, len(hist.PositiveBuckets) <= 10)
+	require.True(t, hist.Schema < histOpts.Schema)
+	require.Equal(t, uint64(1000), hist.Count)
+}
+
+func TestHistogramMergeFloats(t *testing.T) {
+	var h1, h2 histogram.Histogram
+	h1.Schema = 3
+	h1.Add(1)
+	h1.Add(2)
+	h2.Schema = 3
+	h2.Add(-4)
+
+	values := h1.AppendFloats(nil)
+	values = h2.AppendFloats(values)
+
+	h := NewHistogram(NewHistogramOptions(), NewOptions(instrument.NewOptions()))
+	require.NoError(t, h.MergeFloats(time.Now(), values))
+	require.Equal(t, uint64(3), h.Count())
+	require.Equal(t, -1.0, h.Sum())
+
+	buckets := h.AppendCumulativeBuckets(nil)
+	require.Equal(t, uint64(3), buckets[len(buckets)-1].Count)
+	require.True(t, math.IsInf(buckets[len(buckets)-1].UpperBound, 1))
+
+	require.Error(t, h.MergeFloats(time.Now(), values[:3]))
+	require.Equal(t, uint64(3), h.Count())
+}
@@ -42,8 +42,10 @@ type Options struct {
 
 // Metrics is a set of metrics that can be used by elements.
 type Metrics struct {
-	Counter CounterMetrics
-	Gauge   GaugeMetrics
+	Counter   CounterMetrics
+	Timer     TimerMetrics
+	Gauge     GaugeMetrics
+	Histogram HistogramMetrics
 }
 
 // CounterMetrics is a set of counter metrics can be used by all counters.
@@ -51,17 +53,29 @@ type CounterMetrics struct {
 	valuesOutOfOrder tally.Counter
 }
 
+// TimerMetrics is a set of timer metrics can be used by all timers.
+type TimerMetrics struct {
+	valuesInvalid tally.Counter
+}
+
 // GaugeMetrics is a set of gauge metrics can be used by all gauges.
 type GaugeMetrics struct {
 	valuesOutOfOrder tally.Counter
 }
 
+// HistogramMetrics is a set of histogram metrics can be used by all histograms.
+type HistogramMetrics struct {
+	valuesInvalid tally.Counter
+}
+
 // NewMetrics is a set of aggregation metrics.
 func NewMetrics(scope tally.Scope) Metrics {
 	scope = scope.SubScope("aggregation")
 	return Metrics{
-		Counter: newCounterMetrics(scope.SubScope("counters")),
-		Gauge:   newGaugeMetrics(scope.SubScope("gauges")),
+		Counter:   newCounterMetrics(scope.SubScope("counters")),
+		Timer:     newTimerMetrics(scope.SubScope("timers")),
+		Gauge:     newGaugeMetrics(scope.SubScope("gauges")),
+		Histogram: newHistogramMetrics(scope.SubScope("histograms")),
 	}
 }
 
@@ -78,6 +92,19 @@ func (m CounterMetrics) IncValuesOutOfOrder() {
 	}
 }
 
+func newTimerMetrics(scope tally.Scope) TimerMetrics {
+	return TimerMetrics{
+		valuesInvalid: scope.Counter("values-invalid"),
+	}
+}
+
+// IncValuesInvalid increments value or if not initialized is a no-op.
+func (m TimerMetrics) IncValuesInvalid() {
+	if m.valuesInvalid != nil {
+		m.valuesInvalid.Inc(1)
+	}
+}
+
 func newGaugeMetrics(scope tally.Scope) GaugeMetrics {
 	return GaugeMetrics{
 		valuesOutOfOrder: scope.Counter("values-out-of-order"),
@@ -91,6 +118,19 @@ func (m GaugeMetrics) IncValuesOutOfOrder() {
 	}
 }
 
+func newHistogramMetrics(scope tally.Scope) HistogramMetrics {
+	return HistogramMetrics{
+		valuesInvalid: scope.Counter("values-invalid"),
+	}
+}
+
+// IncValuesInvalid increments value or if not initialized is a no-op.
+func (m HistogramMetrics) IncValuesInvalid() {
+	if m.valuesInvalid != nil {
+		m.valuesInvalid.Inc(1)
+	}
+}
+
 // NewOptions creates a new aggregation options.
 func NewOptions(instrumentOpts instrument.Options) Options {
 	return Options{
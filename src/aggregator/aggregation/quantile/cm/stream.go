@@ -21,6 +21,7 @@
 package cm
 
 import (
+	"errors"
 	"math"
 
 	"github.com/m3db/m3/src/x/pool"
@@ -28,10 +29,20 @@ import (
 
 const (
 	minSamplesToCompress = 3
+
+	// encodedSampleLen is the number of values encoding each sample of an
+	// encoded stream.
+	encodedSampleLen = 3
+
+	// maxExactFloat is the largest integer up to which all integers can be
+	// exactly represented by a float64.
+	maxExactFloat = 1 << 53
 )
 
 var (
 	nan = math.NaN()
+
+	errInvalidEncodedStream = errors.New("invalid encoded stream")
 )
 
 // acquireSampleFn acquires a new sample.
@@ -212,6 +223,154 @@ func (s *stream) Close() {
 	s.streamPool.Put(s)
 }
 
+// AppendFloats flushes the stream and appends an encoding of its samples as
+// float64 values to dst, so that the state of the stream can be checkpointed.
+// NB: ranks larger than 2^53 lose precision when encoded.
+func (s *stream) AppendFloats(dst []float64) []float64 {
+	s.Flush()
+	dst = append(dst, float64(s.samples.Len()))
+	for sample := s.samples.Front(); sample != nil; sample = sample.next {
+		dst = append(dst, sample.value, float64(sample.numRanks),
+			float64(sample.delta))
+	}
+	return dst
+}
+
+// MergeFloats merges a stream encoded by AppendFloats at the start of values
+// into the stream, returning the values following the encoded stream. The
+// stream is left unchanged if the encoded stream is invalid.
+func (s *stream) MergeFloats(values []float64) ([]float64, error) {
+	if len(values) == 0 {
+		return nil, errInvalidEncodedStream
+	}
+	numSamples, ok := readRank(values[0])
+	if !ok || numSamples > int64((len(values)-1)/encodedSampleLen) {
+		return nil, errInvalidEncodedStream
+	}
+
+	var (
+		end     = 1 + int(numSamples)*encodedSampleLen
+		encoded = values[1:end]
+		prev    = math.Inf(-1)
+	)
+	for i := 0; i < len(encoded); i += encodedSampleLen {
+		numRanks, numRanksOK := readRank(encoded[i+1])
+		_, deltaOK := readRank(encoded[i+2])
+		if !(encoded[i] >= prev) || !numRanksOK || numRanks == 0 || !deltaOK {
+			return nil, errInvalidEncodedStream
+		}
+		prev = encoded[i]
+	}
+
+	if numSamples > 0 {
+		s.Flush()
+		s.mergeSamples(encoded)
+	}
+	return values[end:], nil
+}
+
+// rankedSample is a sample along with the bounds of its rank.
+type rankedSample struct {
+	value   float64
+	minRank int64
+	maxRank int64
+	// sample is the sample of the stream to reuse, or nil for an encoded
+	// sample.
+	sample *Sample
+}
+
+// mergeSamples merges the encoded samples into the flushed samples of the
+// stream. The rank bounds of each merged sample are the sums of its rank
+// bounds within its own stream and the bounds of the samples of the other
+// stream around it, which preserves the error bounds of both streams.
+func (s *stream) mergeSamples(encoded []float64) {
+	var (
+		existing = make([]rankedSample, 0, s.samples.Len())
+		incoming = make([]rankedSample, 0, len(encoded)/encodedSampleLen)
+		minRank  int64
+	)
+	for sample := s.samples.Front(); sample != nil; sample = sample.next {
+		minRank += sample.numRanks
+		existing = append(existing, rankedSample{
+			value:   sample.value,
+			minRank: minRank,
+			maxRank: minRank + sample.delta,
+			sample:  sample,
+		})
+	}
+	numExisting := minRank
+
+	minRank = 0
+	for i := 0; i < len(encoded); i += encodedSampleLen {
+		minRank += int64(encoded[i+1])
+		incoming = append(incoming, rankedSample{
+			value:   encoded[i],
+			minRank: minRank,
+			maxRank: minRank + int64(encoded[i+2]),
+		})
+	}
+	numIncoming := minRank
+
+	// NB: samples of the stream are ordered before encoded samples of the
+	// same value.
+	var (
+		merged = make([]rankedSample, 0, len(existing)+len(incoming))
+		i, j   int
+	)
+	for i < len(existing) || j < len(incoming) {
+		if j == len(incoming) ||
+			(i < len(existing) && existing[i].value <= incoming[j].value) {
+			merged = append(merged, mergedRanks(existing[i], incoming, j, numIncoming))
+			i++
+		} else {
+			merged = append(merged, mergedRanks(incoming[j], existing, i, numExisting))
+			j++
+		}
+	}
+
+	s.samples.Reset()
+	minRank = 0
+	for _, r := range merged {
+		sample := r.sample
+		if sample == nil {
+			sample = s.acquireSampleFn()
+		}
+		sample.setData(r.value, r.minRank-minRank, r.maxRank-r.minRank)
+		s.samples.PushBack(sample)
+		minRank = r.minRank
+	}
+	s.numValues = numExisting + numIncoming
+	s.insertCursor = nil
+	s.compressCursor = nil
+	s.compressMinRank = 0
+}
+
+// mergedRanks returns the sample with its rank bounds merged with those of
+// the other samples, of which the first next samples precede it.
+func mergedRanks(
+	r rankedSample,
+	other []rankedSample,
+	next int,
+	numOther int64,
+) rankedSample {
+	if next > 0 {
+		r.minRank += other[next-1].minRank
+	}
+	if next < len(other) {
+		r.maxRank += other[next].maxRank - 1
+	} else {
+		r.maxRank += numOther
+	}
+	return r
+}
+
+func readRank(value float64) (int64, bool) {
+	if !(value >= 0) || value > maxExactFloat || value != math.Trunc(value) {
+		return 0, false
+	}
+	return int64(value), true
+}
+
 // addToBuffer adds a new sample to the buffer.
 func (s *stream) addToBuffer(value float64) {
 	if s.numValues > 0 && value < s.insertPointValue() {
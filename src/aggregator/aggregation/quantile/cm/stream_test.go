@@ -225,6 +225,91 @@ func TestStreamAddToMinHeap(t *testing.T) {
 	require.Equal(t, 2, cap(heap))
 }
 
+func TestStreamAppendMergeFloats(t *testing.T) {
+	opts := testStreamOptions()
+	s := NewStream(testQuantiles, opts)
+	for i := 0; i < 10000; i++ {
+		s.Add(rand.Float64())
+	}
+
+	encoded := s.AppendFloats(nil)
+	restored := NewStream(testQuantiles, opts)
+	rest, err := restored.MergeFloats(append(encoded, 42.0))
+	require.NoError(t, err)
+	require.Equal(t, []float64{42.0}, rest)
+
+	require.Equal(t, s.Min(), restored.Min())
+	require.Equal(t, s.Max(), restored.Max())
+	for _, q := range testQuantiles {
+		require.Equal(t, s.Quantile(q), restored.Quantile(q))
+	}
+	require.Equal(t, encoded, restored.AppendFloats(nil))
+}
+
+func TestStreamMergeFloatsWithExistingSamples(t *testing.T) {
+	var (
+		opts       = testStreamOptions().SetInsertAndCompressEvery(testInsertAndCompressEvery)
+		numSamples = 100000
+		values     = rand.Perm(numSamples)
+		s          = NewStream(testQuantiles, opts)
+		other      = NewStream(testQuantiles, opts)
+	)
+	for i, v := range values {
+		if i%2 == 0 {
+			s.Add(float64(v))
+		} else {
+			other.Add(float64(v))
+		}
+	}
+
+	rest, err := s.MergeFloats(other.AppendFloats(nil))
+	require.NoError(t, err)
+	require.Equal(t, 0, len(rest))
+
+	require.Equal(t, 0.0, s.Min())
+	require.Equal(t, float64(numSamples-1), s.Max())
+	margin := float64(numSamples) * opts.Eps()
+	for _, q := range testQuantiles {
+		val := s.Quantile(q)
+		require.True(t, val >= float64(numSamples)*q-margin && val <= float64(numSamples)*q+margin)
+	}
+
+	// Values added after the merge are tracked as usual.
+	for i := numSamples; i < 2*numSamples; i++ {
+		s.Add(float64(i))
+	}
+	s.Flush()
+	margin = float64(2*numSamples) * opts.Eps()
+	for _, q := range testQuantiles {
+		val := s.Quantile(q)
+		require.True(t, val >= float64(2*numSamples)*q-margin && val <= float64(2*numSamples)*q+margin)
+	}
+}
+
+func TestStreamMergeFloatsInvalid(t *testing.T) {
+	opts := testStreamOptions()
+	s := NewStream(testQuantiles, opts)
+	for _, val := range []float64{100.0, 200.0, 300.0} {
+		s.Add(val)
+	}
+	expected := s.AppendFloats(nil)
+
+	for _, values := range [][]float64{
+		nil,
+		{-1},
+		{1.5, 100, 1, 0},
+		{2, 100, 1, 0},
+		{2, 200, 1, 0, 100, 1, 0},
+		{1, math.NaN(), 1, 0},
+		{1, 100, 0, 0},
+		{1, 100, 1, -1},
+	} {
+		_, err := s.MergeFloats(values)
+		require.Error(t, err)
+		require.Equal(t, expected, s.AppendFloats(nil))
+	}
+}
+
 func testStreamWithIncreasingSamples(t *testing.T, opts Options) {
 	numSamples := 100000
 	s := NewStream(testQuantiles, opts)
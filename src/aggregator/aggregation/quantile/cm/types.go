@@ -65,6 +65,14 @@ type Stream interface {
 
 	// ResetSetData resets the stream and sets data.
 	ResetSetData(quantiles []float64)
+
+	// AppendFloats flushes the stream and appends an encoding of its samples
+	// as float64 values to dst.
+	AppendFloats(dst []float64) []float64
+
+	// MergeFloats merges a stream encoded by AppendFloats at the start of
+	// values into the stream, returning the values following it.
+	MergeFloats(values []float64) ([]float64, error)
 }
 
 // StreamAlloc allocates a stream.
@@ -0,0 +1,427 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ddsketch
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// minNormal is the smallest positive normal float64 value.
+	minNormal = 0x1p-1022
+	// maxExactFloat is the largest integer that can be represented exactly
+	// by a float64 value.
+	maxExactFloat = 1 << 53
+	// encodedHeaderLen is the number of values encoded before the buckets.
+	encodedHeaderLen = 5
+)
+
+var (
+	nan = math.NaN()
+
+	errMismatchedRelativeAccuracy = errors.New("sketches with different relative accuracies cannot be merged")
+	errInvalidEncodedSketch       = errors.New("invalid encoded sketch")
+)
+
+// Sketch is a DDSketch. Values are counted in buckets with exponentially
+// growing boundaries so that every value counted in a bucket is within the
+// relative accuracy of the value the bucket represents. Sketch APIs are not
+// thread-safe.
+type Sketch struct {
+	relativeAccuracy float64
+	gamma            float64
+	logGamma         float64
+	minIndexable     float64
+	maxNumBuckets    int
+
+	count     uint64  // Number of values counted.
+	zeroCount uint64  // Number of values too close to zero to be indexed.
+	min       float64 // Exact min of the values counted.
+	max       float64 // Exact max of the values counted.
+	positive  store   // Buckets of positive values.
+	negative  store   // Buckets of negative values by magnitude.
+}
+
+// NewSketch creates a new sketch.
+func NewSketch(opts Options) *Sketch {
+	var (
+		relativeAccuracy = opts.RelativeAccuracy()
+		gamma            = (1 + relativeAccuracy) / (1 - relativeAccuracy)
+	)
+	return &Sketch{
+		relativeAccuracy: relativeAccuracy,
+		gamma:            gamma,
+		logGamma:         math.Log(gamma),
+		minIndexable:     minNormal * gamma,
+		maxNumBuckets:    opts.MaxNumBuckets(),
+		min:              math.Inf(1),
+		max:              math.Inf(-1),
+	}
+}
+
+// RelativeAccuracy returns the relative accuracy of the sketch.
+func (s *Sketch) RelativeAccuracy() float64 { return s.relativeAccuracy }
+
+// Add adds a value. NaN and infinite values are ignored as they cannot be
+// counted in any bucket.
+func (s *Sketch) Add(value float64) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+
+	switch {
+	case value > s.minIndexable:
+		s.positive.add(s.index(value), 1, s.maxNumBuckets)
+	case value < -s.minIndexable:
+		s.negative.add(s.index(-value), 1, s.maxNumBuckets)
+	default:
+		s.zeroCount++
+	}
+	s.count++
+	s.min = math.Min(s.min, value)
+	s.max = math.Max(s.max, value)
+}
+
+// Merge merges another sketch with the same relative accuracy into the sketch.
+func (s *Sketch) Merge(other *Sketch) error {
+	if other.relativeAccuracy != s.relativeAccuracy {
+		return errMismatchedRelativeAccuracy
+	}
+	if other.count == 0 {
+		return nil
+	}
+
+	for i, count := range other.positive.bins {
+		if count > 0 {
+			s.positive.add(other.positive.offset+i, count, s.maxNumBuckets)
+		}
+	}
+	for i, count := range other.negative.bins {
+		if count > 0 {
+			s.negative.add(other.negative.offset+i, count, s.maxNumBuckets)
+		}
+	}
+	s.zeroCount += other.zeroCount
+	s.count += other.count
+	s.min = math.Min(s.min, other.min)
+	s.max = math.Max(s.max, other.max)
+	return nil
+}
+
+// Count returns the number of values added.
+func (s *Sketch) Count() uint64 { return s.count }
+
+// Min returns the minimum value.
+func (s *Sketch) Min() float64 { return s.Quantile(0.0) }
+
+// Max returns the maximum value.
+func (s *Sketch) Max() float64 { return s.Quantile(1.0) }
+
+// Quantile returns the quantile value.
+func (s *Sketch) Quantile(q float64) float64 {
+	if q < 0.0 || q > 1.0 {
+		return nan
+	}
+	if s.count == 0 {
+		return 0.0
+	}
+	if q == 0.0 {
+		return s.min
+	}
+	if q == 1.0 {
+		return s.max
+	}
+
+	var (
+		rank  = q * float64(s.count-1)
+		cum   float64
+		value = s.max
+	)
+	if v, ok := s.negative.findDescending(rank, &cum); ok {
+		value = -s.value(v)
+	} else if cum += float64(s.zeroCount); cum > rank {
+		value = 0
+	} else if v, ok := s.positive.findAscending(rank, &cum); ok {
+		value = s.value(v)
+	}
+
+	// NB: the value a bucket represents may lie outside of the range of the
+	// values counted in it.
+	return math.Max(s.min, math.Min(s.max, value))
+}
+
+// Reset resets the sketch, retaining the capacity of its buckets.
+func (s *Sketch) Reset() {
+	s.count = 0
+	s.zeroCount = 0
+	s.min = math.Inf(1)
+	s.max = math.Inf(-1)
+	s.positive.reset()
+	s.negative.reset()
+}
+
+// AppendFloats appends an encoding of the sketch as float64 values to dst,
+// so that sketches can be carried by the values of forwarded metrics.
+// NB: counts larger than 2^53 lose precision when encoded.
+func (s *Sketch) AppendFloats(dst []float64) []float64 {
+	dst = append(dst,
+		s.relativeAccuracy,
+		float64(s.count),
+		float64(s.zeroCount),
+		s.min,
+		s.max,
+	)
+	dst = s.positive.appendFloats(dst)
+	return s.negative.appendFloats(dst)
+}
+
+// MergeFloats merges a sketch encoded by AppendFloats at the start of values
+// into the sketch, returning the values following the encoded sketch. The
+// sketch is left unchanged if the encoded sketch is invalid.
+func (s *Sketch) MergeFloats(values []float64) ([]float64, error) {
+	rest, err := s.validateFloats(values)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		count     = uint64(values[1])
+		zeroCount = uint64(values[2])
+	)
+	if count > 0 {
+		s.min = math.Min(s.min, values[3])
+		s.max = math.Max(s.max, values[4])
+	}
+	s.count += count
+	s.zeroCount += zeroCount
+
+	buckets := values[encodedHeaderLen:]
+	buckets = s.positive.mergeFloats(buckets, s.maxNumBuckets)
+	s.negative.mergeFloats(buckets, s.maxNumBuckets)
+	return rest, nil
+}
+
+func (s *Sketch) validateFloats(values []float64) ([]float64, error) {
+	if len(values) < encodedHeaderLen {
+		return nil, errInvalidEncodedSketch
+	}
+	if values[0] != s.relativeAccuracy {
+		return nil, errMismatchedRelativeAccuracy
+	}
+	count, ok := readCount(values[1])
+	if !ok {
+		return nil, errInvalidEncodedSketch
+	}
+	zeroCount, ok := readCount(values[2])
+	if !ok {
+		return nil, errInvalidEncodedSketch
+	}
+
+	rest, positiveCount, ok := validateBucketFloats(values[encodedHeaderLen:])
+	if !ok {
+		return nil, errInvalidEncodedSketch
+	}
+	rest, negativeCount, ok := validateBucketFloats(rest)
+	if !ok {
+		return nil, errInvalidEncodedSketch
+	}
+	if zeroCount+positiveCount+negativeCount != count {
+		return nil, errInvalidEncodedSketch
+	}
+	return rest, nil
+}
+
+func (s *Sketch) index(value float64) int {
+	return int(math.Ceil(math.Log(value) / s.logGamma))
+}
+
+// value returns the value the bucket with the given index represents, which
+// is within the relative accuracy of every value in the bucket.
+func (s *Sketch) value(index int) float64 {
+	return math.Exp(float64(index)*s.logGamma) * 2 / (1 + s.gamma)
+}
+
+// store counts values in buckets with contiguous indexes.
+type store struct {
+	bins   []uint64
+	offset int // Index of the first bucket.
+}
+
+func (s *store) add(index int, count uint64, maxNumBuckets int) {
+	if len(s.bins) == 0 {
+		s.bins = append(s.bins[:0], count)
+		s.offset = index
+		return
+	}
+
+	high := s.offset + len(s.bins) - 1
+	switch {
+	case index < s.offset:
+		if high-index+1 > maxNumBuckets {
+			// NB: collapse the values with the smallest magnitude into the
+			// lowest bucket kept to bound the number of buckets.
+			index = high - maxNumBuckets + 1
+		}
+		s.growFront(s.offset - index)
+	case index > high:
+		if index-s.offset+1 > maxNumBuckets {
+			s.collapseBelow(index - maxNumBuckets + 1)
+		}
+		for s.offset+len(s.bins) <= index {
+			s.bins = append(s.bins, 0)
+		}
+	}
+	s.bins[index-s.offset] += count
+}
+
+func (s *store) growFront(n int) {
+	if n <= 0 {
+		return
+	}
+	size := len(s.bins)
+	if cap(s.bins) >= size+n {
+		s.bins = s.bins[:size+n]
+	} else {
+		bins := make([]uint64, size+n, 2*(size+n))
+		copy(bins, s.bins)
+		s.bins = bins
+	}
+	copy(s.bins[n:], s.bins[:size])
+	for i := 0; i < n; i++ {
+		s.bins[i] = 0
+	}
+	s.offset -= n
+}
+
+func (s *store) collapseBelow(low int) {
+	if low <= s.offset {
+		return
+	}
+	high := s.offset + len(s.bins) - 1
+	if low > high {
+		var total uint64
+		for _, count := range s.bins {
+			total += count
+		}
+		s.bins = append(s.bins[:0], total)
+		s.offset = low
+		return
+	}
+
+	n := low - s.offset
+	var collapsed uint64
+	for _, count := range s.bins[:n+1] {
+		collapsed += count
+	}
+	s.bins = s.bins[:copy(s.bins, s.bins[n:])]
+	s.bins[0] = collapsed
+	s.offset = low
+}
+
+// findAscending adds the counts of the buckets in increasing order of index
+// to cum, returning the index of the bucket at which cum exceeds the rank.
+func (s *store) findAscending(rank float64, cum *float64) (int, bool) {
+	for i, count := range s.bins {
+		*cum += float64(count)
+		if *cum > rank {
+			return s.offset + i, true
+		}
+	}
+	return 0, false
+}
+
+// findDescending adds the counts of the buckets in decreasing order of index
+// to cum, returning the index of the bucket at which cum exceeds the rank.
+func (s *store) findDescending(rank float64, cum *float64) (int, bool) {
+	for i := len(s.bins) - 1; i >= 0; i-- {
+		*cum += float64(s.bins[i])
+		if *cum > rank {
+			return s.offset + i, true
+		}
+	}
+	return 0, false
+}
+
+func (s *store) reset() {
+	s.bins = s.bins[:0]
+	s.offset = 0
+}
+
+// appendFloats appends the number of non empty buckets to dst followed by
+// the index and the count of each of them.
+func (s *store) appendFloats(dst []float64) []float64 {
+	var n int
+	for _, count := range s.bins {
+		if count > 0 {
+			n++
+		}
+	}
+	dst = append(dst, float64(n))
+	for i, count := range s.bins {
+		if count > 0 {
+			dst = append(dst, float64(s.offset+i), float64(count))
+		}
+	}
+	return dst
+}
+
+// mergeFloats merges buckets previously validated by validateBucketFloats.
+func (s *store) mergeFloats(values []float64, maxNumBuckets int) []float64 {
+	n := int(values[0])
+	values = values[1:]
+	for i := 0; i < n; i++ {
+		s.add(int(values[2*i]), uint64(values[2*i+1]), maxNumBuckets)
+	}
+	return values[2*n:]
+}
+
+func validateBucketFloats(values []float64) ([]float64, uint64, bool) {
+	if len(values) == 0 {
+		return nil, 0, false
+	}
+	n, ok := readCount(values[0])
+	if !ok || uint64(len(values)-1) < 2*n {
+		return nil, 0, false
+	}
+	values = values[1:]
+
+	var total uint64
+	for i := 0; i < int(n); i++ {
+		index := values[2*i]
+		if index != math.Trunc(index) || math.Abs(index) > maxExactFloat {
+			return nil, 0, false
+		}
+		count, ok := readCount(values[2*i+1])
+		if !ok {
+			return nil, 0, false
+		}
+		total += count
+	}
+	return values[2*n:], total, true
+}
+
+func readCount(value float64) (uint64, bool) {
+	if !(value >= 0) || value > maxExactFloat || value != math.Trunc(value) {
+		return 0, false
+	}
+	return uint64(value), true
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ddsketch
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testOpts = NewOptions()
+)
+
+func TestOptionsValidateNoError(t *testing.T) {
+	require.NoError(t, testOpts.Validate())
+}
+
+func TestOptionsValidateInvalidRelativeAccuracy(t *testing.T) {
+	opts := testOpts.SetRelativeAccuracy(minRelativeAccuracy)
+	require.Equal(t, errInvalidRelativeAccuracy, opts.Validate())
+
+	opts = testOpts.SetRelativeAccuracy(maxRelativeAccuracy)
+	require.Equal(t, errInvalidRelativeAccuracy, opts.Validate())
+}
+
+func TestOptionsValidateInvalidMaxNumBuckets(t *testing.T) {
+	opts := testOpts.SetMaxNumBuckets(0)
+	require.Equal(t, errInvalidMaxNumBuckets, opts.Validate())
+}
+
+func TestSketchEmpty(t *testing.T) {
+	s := NewSketch(testOpts)
+	require.Equal(t, uint64(0), s.Count())
+	require.Equal(t, 0.0, s.Min())
+	require.Equal(t, 0.0, s.Max())
+	require.Equal(t, 0.0, s.Quantile(0.5))
+	require.True(t, math.IsNaN(s.Quantile(-1)))
+	require.True(t, math.IsNaN(s.Quantile(2)))
+}
+
+func TestSketchQuantilesWithinRelativeAccuracy(t *testing.T) {
+	var (
+		s      = NewSketch(testOpts)
+		r      = rand.New(rand.NewSource(0))
+		values []float64
+	)
+	for i := 0; i < 10000; i++ {
+		v := r.ExpFloat64() * 100
+		if i%10 == 0 {
+			v = -v
+		}
+		values = append(values, v)
+		s.Add(v)
+	}
+	s.Add(0)
+	values = append(values, 0)
+	sort.Float64s(values)
+
+	require.Equal(t, uint64(len(values)), s.Count())
+	require.Equal(t, values[0], s.Min())
+	require.Equal(t, values[len(values)-1], s.Max())
+	for _, q := range []float64{0.01, 0.05, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 0.99, 0.999} {
+		expected := values[int(q*float64(len(values)-1))]
+		requireWithinRelativeAccuracy(t, expected, s.Quantile(q), testOpts.RelativeAccuracy())
+	}
+}
+
+func TestSketchIgnoresNaNAndInf(t *testing.T) {
+	s := NewSketch(testOpts)
+	s.Add(math.NaN())
+	s.Add(math.Inf(1))
+	s.Add(math.Inf(-1))
+	s.Add(1.0)
+	require.Equal(t, uint64(1), s.Count())
+	require.Equal(t, 1.0, s.Min())
+	require.Equal(t, 1.0, s.Max())
+}
+
+func TestSketchMerge(t *testing.T) {
+	var (
+		merged = NewSketch(testOpts)
+		all    = NewSketch(testOpts)
+		r      = rand.New(rand.NewSource(0))
+	)
+	for i := 0; i < 4; i++ {
+		s := NewSketch(testOpts)
+		for j := 0; j < 1000; j++ {
+			v := r.NormFloat64() * 50
+			s.Add(v)
+			all.Add(v)
+		}
+		require.NoError(t, merged.Merge(s))
+	}
+
+	// Merging is exact, the merged sketch is the sketch of all the values.
+	require.Equal(t, all.Count(), merged.Count())
+	require.Equal(t, all.Min(), merged.Min())
+	require.Equal(t, all.Max(), merged.Max())
+	for _, q := range []float64{0.1, 0.5, 0.9, 0.99} {
+		require.Equal(t, all.Quantile(q), merged.Quantile(q))
+	}
+}
+
+func TestSketchMergeMismatchedRelativeAccuracy(t *testing.T) {
+	s := NewSketch(testOpts)
+	other := NewSketch(testOpts.SetRelativeAccuracy(0.05))
+	other.Add(1.0)
+	require.Equal(t, errMismatchedRelativeAccuracy, s.Merge(other))
+	require.Equal(t, uint64(0), s.Count())
+}
+
+func TestSketchCollapsesLowestBuckets(t *testing.T) {
+	opts := testOpts.SetRelativeAccuracy(0.2).SetMaxNumBuckets(16)
+	s := NewSketch(opts)
+	for v := 1.0; v < 1e6; v *= 1.5 {
+		s.Add(v)
+	}
+	require.True(t, len(s.positive.bins) <= 16)
+
+	// High quantiles are unaffected by collapsing the lowest buckets.
+	requireWithinRelativeAccuracy(t, math.Pow(1.5, 33), s.Quantile(0.99), opts.RelativeAccuracy())
+
+	// Values added below the lowest bucket are collapsed into it.
+	offset := s.positive.offset
+	s.Add(0.5)
+	require.Equal(t, offset, s.positive.offset)
+	require.True(t, len(s.positive.bins) <= 16)
+}
+
+func TestSketchAppendMergeFloats(t *testing.T) {
+	var (
+		s1 = NewSketch(testOpts)
+		s2 = NewSketch(testOpts)
+	)
+	for _, v := range []float64{-3, -1, 0, 1, 2, 3, 100} {
+		s1.Add(v)
+	}
+	for _, v := range []float64{5, 6} {
+		s2.Add(v)
+	}
+	encoded := s2.AppendFloats(s1.AppendFloats(nil))
+
+	decoded := NewSketch(testOpts)
+	rest, err := decoded.MergeFloats(encoded)
+	require.NoError(t, err)
+	rest, err = decoded.MergeFloats(rest)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(rest))
+
+	require.NoError(t, s1.Merge(s2))
+	require.Equal(t, s1.Count(), decoded.Count())
+	require.Equal(t, s1.Min(), decoded.Min())
+	require.Equal(t, s1.Max(), decoded.Max())
+	for _, q := range []float64{0.1, 0.5, 0.9} {
+		require.Equal(t, s1.Quantile(q), decoded.Quantile(q))
+	}
+}
+
+func TestSketchMergeFloatsInvalid(t *testing.T) {
+	s := NewSketch(testOpts)
+	s.Add(1.0)
+	s.Add(-1.0)
+	encoded := s.AppendFloats(nil)
+
+	inputs := [][]float64{
+		nil,
+		encoded[:encodedHeaderLen],
+		encoded[:len(encoded)-1],
+		append([]float64{0.05}, encoded[1:]...),
+		append([]float64{encoded[0], 3}, encoded[2:]...),
+		append([]float64{encoded[0], -1}, encoded[2:]...),
+	}
+	for _, input := range inputs {
+		decoded := NewSketch(testOpts)
+		_, err := decoded.MergeFloats(input)
+		require.Error(t, err)
+		require.Equal(t, uint64(0), decoded.Count())
+	}
+}
+
+func TestSketchReset(t *testing.T) {
+	s := NewSketch(testOpts)
+	s.Add(1.0)
+	s.Add(-1.0)
+	s.Reset()
+	require.Equal(t, uint64(0), s.Count())
+	require.Equal(t, 0.0, s.Quantile(0.5))
+
+	s.Add(2.0)
+	require.Equal(t, 2.0, s.Min())
+	require.Equal(t, 2.0, s.Max())
+}
+
+func requireWithinRelativeAccuracy(t *testing.T, expected, actual, relativeAccuracy float64) {
+	require.True(t, math.Abs(actual-expected) <= relativeAccuracy*math.Abs(expected)+1e-12,
+		"expected %v to be within %v of %v", actual, relativeAccuracy, expected)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"fmt"
+)
+
+// QuantileSketchType is the type of sketch timers compute quantiles with.
+type QuantileSketchType int
+
+const (
+	// CMQuantileSketch computes quantiles with a Cormode-Muthukrishnan
+	// stream, the quantiles of different streams cannot be merged.
+	CMQuantileSketch QuantileSketchType = iota
+	// DDSketchQuantileSketch computes quantiles with a DDSketch, sketches can
+	// be merged across aggregators and forwarded pipelines.
+	DDSketchQuantileSketch
+)
+
+var (
+	validQuantileSketchTypes = []QuantileSketchType{
+		CMQuantileSketch,
+		DDSketchQuantileSketch,
+	}
+)
+
+func (t QuantileSketchType) String() string {
+	switch t {
+	case CMQuantileSketch:
+		return "cm"
+	case DDSketchQuantileSketch:
+		return "ddsketch"
+	default:
+		return "unknown"
+	}
+}
+
+// Validate validates the quantile sketch type.
+func (t QuantileSketchType) Validate() error {
+	for _, valid := range validQuantileSketchTypes {
+		if t == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid quantile sketch type: %d", int(t))
+}
+
+// UnmarshalYAML unmarshals a quantile sketch type from a string.
+func (t *QuantileSketchType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	if str == "" {
+		*t = CMQuantileSketch
+		return nil
+	}
+	for _, valid := range validQuantileSketchTypes {
+		if str == valid.String() {
+			*t = valid
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid quantile sketch type: %s, valid types are: %v",
+		str, validQuantileSketchTypes)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestQuantileSketchTypeUnmarshalYAML(t *testing.T) {
+	inputs := []struct {
+		str      string
+		expected QuantileSketchType
+	}{
+		{str: "cm", expected: CMQuantileSketch},
+		{str: "ddsketch", expected: DDSketchQuantileSketch},
+	}
+	for _, input := range inputs {
+		var sketchType QuantileSketchType
+		require.NoError(t, yaml.Unmarshal([]byte(input.str), &sketchType))
+		require.Equal(t, input.expected, sketchType)
+		require.NoError(t, sketchType.Validate())
+		require.Equal(t, input.str, sketchType.String())
+	}
+}
+
+func TestQuantileSketchTypeUnmarshalYAMLErrors(t *testing.T) {
+	var sketchType QuantileSketchType
+	err := yaml.Unmarshal([]byte("tdigest"), &sketchType)
+	require.Error(t, err)
+	require.Equal(t, "invalid quantile sketch type: tdigest, valid types are: [cm ddsketch]", err.Error())
+	require.Error(t, QuantileSketchType(2).Validate())
+}
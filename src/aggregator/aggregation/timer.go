@@ -21,21 +21,31 @@
 package aggregation
 
 import (
+	"errors"
+	"math"
 	"time"
 
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	"github.com/m3db/m3/src/aggregator/aggregation/quantile/ddsketch"
 	"github.com/m3db/m3/src/metrics/aggregation"
 )
 
+// timerEncodedHeaderLen is the number of values encoded before the sketch
+// of an encoded timer.
+const timerEncodedHeaderLen = 3
+
+var errInvalidEncodedTimer = errors.New("invalid encoded timer")
+
 // Timer aggregates timer values. Timer APIs are not thread-safe.
 type Timer struct {
 	Options
 
 	lastAt time.Time
-	count  int64     // Number of values received.
-	sum    float64   // Sum of the values.
-	sumSq  float64   // Sum of squared values.
-	stream cm.Stream // Stream of values received.
+	count  int64            // Number of values received.
+	sum    float64          // Sum of the values.
+	sumSq  float64          // Sum of squared values.
+	stream cm.Stream        // Stream of values received, nil for sketch timers.
+	sketch *ddsketch.Sketch // Sketch of values received, nil for stream timers.
 }
 
 // NewTimer creates a new timer
@@ -48,6 +58,16 @@ func NewTimer(quantiles []float64, streamOpts cm.Options, opts Options) Timer {
 	}
 }
 
+// NewSketchTimer creates a new timer that computes quantiles with a sketch,
+// which unlike the stream of a timer created by NewTimer can be merged with
+// the sketches of other timers.
+func NewSketchTimer(sketchOpts ddsketch.Options, opts Options) Timer {
+	return Timer{
+		Options: opts,
+		sketch:  ddsketch.NewSketch(sketchOpts),
+	}
+}
+
 // Add adds a timer value.
 func (t *Timer) Add(timestamp time.Time, value float64) {
 	t.recordLastAt(timestamp)
@@ -75,18 +95,72 @@ func (t *Timer) recordLastAt(timestamp time.Time) {
 func (t *Timer) addValue(value float64) {
 	t.count++
 	t.sum += value
-	t.stream.Add(value)
+	if t.sketch != nil {
+		t.sketch.Add(value)
+	} else {
+		t.stream.Add(value)
+	}
 
 	if t.HasExpensiveAggregations {
 		t.sumSq += value * value
 	}
 }
 
+// IsMergeable returns whether the timer computes quantiles with a sketch,
+// whose encodings can be forwarded and merged with those of other timers
+// without losing accuracy.
+func (t *Timer) IsMergeable() bool { return t.sketch != nil }
+
+// AppendFloats appends an encoding of the timer as float64 values to dst, so
+// that timers can be carried by the values of forwarded metrics or
+// checkpoints. Timers computing quantiles with a stream flush the stream.
+func (t *Timer) AppendFloats(dst []float64) []float64 {
+	dst = append(dst, float64(t.count), t.sum, t.sumSq)
+	if t.sketch != nil {
+		return t.sketch.AppendFloats(dst)
+	}
+	return t.stream.AppendFloats(dst)
+}
+
+// MergeFloats merges the timers encoded in the values, such as the values of
+// a forwarded timer, into the timer. Timers computing quantiles with a sketch
+// can only merge timers encoded with a sketch, and likewise for streams.
+func (t *Timer) MergeFloats(timestamp time.Time, values []float64) error {
+	t.recordLastAt(timestamp)
+	for len(values) > 0 {
+		if len(values) < timerEncodedHeaderLen ||
+			values[0] < 0 || values[0] != math.Trunc(values[0]) {
+			t.Options.Metrics.Timer.IncValuesInvalid()
+			return errInvalidEncodedTimer
+		}
+		rest, err := t.mergeQuantileFloats(values[timerEncodedHeaderLen:])
+		if err != nil {
+			t.Options.Metrics.Timer.IncValuesInvalid()
+			return err
+		}
+		t.count += int64(values[0])
+		t.sum += values[1]
+		t.sumSq += values[2]
+		values = rest
+	}
+	return nil
+}
+
+func (t *Timer) mergeQuantileFloats(values []float64) ([]float64, error) {
+	if t.sketch != nil {
+		return t.sketch.MergeFloats(values)
+	}
+	return t.stream.MergeFloats(values)
+}
+
 // LastAt returns the time of the last value received.
 func (t *Timer) LastAt() time.Time { return t.lastAt }
 
 // Quantile returns the value at a given quantile.
 func (t *Timer) Quantile(q float64) float64 {
+	if t.sketch != nil {
+		return t.sketch.Quantile(q)
+	}
 	t.stream.Flush()
 	return t.stream.Quantile(q)
 }
@@ -96,12 +170,18 @@ func (t *Timer) Count() int64 { return t.count }
 
 // Min returns the minimum timer value.
 func (t *Timer) Min() float64 {
+	if t.sketch != nil {
+		return t.sketch.Min()
+	}
 	t.stream.Flush()
 	return t.stream.Min()
 }
 
 // Max returns the maximum timer value.
 func (t *Timer) Max() float64 {
+	if t.sketch != nil {
+		return t.sketch.Max()
+	}
 	t.stream.Flush()
 	return t.stream.Max()
 }
@@ -151,4 +231,8 @@ func (t *Timer) ValueOf(aggType aggregation.Type) float64 {
 }
 
 // Close closes the timer.
-func (t *Timer) Close() { t.stream.Close() }
+func (t *Timer) Close() {
+	if t.stream != nil {
+		t.stream.Close()
+	}
+}
@@ -22,10 +22,13 @@ package aggregation
 
 import (
 	"math"
+	"math/rand"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	"github.com/m3db/m3/src/aggregator/aggregation/quantile/ddsketch"
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/x/instrument"
 	"github.com/m3db/m3/src/x/pool"
@@ -173,3 +176,166 @@ func TestTimerAggregationsNotExpensive(t *testing.T) {
 	// Closing the timer a second time should be a no op.
 	timer.Close()
 }
+
+func TestSketchTimerAggregations(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(testAggTypes)
+
+	sketchOpts := ddsketch.NewOptions()
+	timer := NewSketchTimer(sketchOpts, opts)
+	require.True(t, timer.IsMergeable())
+
+	// Assert the state of an empty timer.
+	require.Equal(t, int64(0), timer.Count())
+	require.Equal(t, 0.0, timer.Min())
+	require.Equal(t, 0.0, timer.Max())
+	require.Equal(t, 0.0, timer.Quantile(0.5))
+
+	// Add values.
+	at := time.Now()
+	for i := 1; i <= 100; i++ {
+		timer.Add(at, float64(i))
+	}
+
+	// Validate the timer values match expectations, quantiles are within the
+	// relative accuracy of the sketch.
+	require.Equal(t, int64(100), timer.Count())
+	require.Equal(t, 5050.0, timer.Sum())
+	require.Equal(t, 338350.0, timer.SumSq())
+	require.Equal(t, 1.0, timer.Min())
+	require.Equal(t, 100.0, timer.Max())
+	require.Equal(t, 50.5, timer.Mean())
+	for _, q := range testQuantiles {
+		expected := math.Ceil(q * 99)
+		require.InDelta(t, expected, timer.Quantile(q), expected*sketchOpts.RelativeAccuracy())
+	}
+
+	// Closing the timer is a no op.
+	timer.Close()
+	timer.Close()
+}
+
+func TestSketchTimerMergeFloats(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(testAggTypes)
+
+	var (
+		sketchOpts = ddsketch.NewOptions()
+		all        = NewSketchTimer(sketchOpts, opts)
+		encoded    []float64
+		at         = time.Now()
+	)
+	for i := 0; i < 3; i++ {
+		timer := NewSketchTimer(sketchOpts, opts)
+		for j := 1; j <= 100; j++ {
+			v := float64(i*100 + j)
+			timer.Add(at, v)
+			all.Add(at, v)
+		}
+		encoded = timer.AppendFloats(encoded)
+	}
+
+	merged := NewSketchTimer(sketchOpts, opts)
+	require.NoError(t, merged.MergeFloats(at, encoded))
+	require.Equal(t, at, merged.LastAt())
+	require.Equal(t, all.Count(), merged.Count())
+	require.Equal(t, all.Sum(), merged.Sum())
+	require.Equal(t, all.SumSq(), merged.SumSq())
+	require.Equal(t, all.Min(), merged.Min())
+	require.Equal(t, all.Max(), merged.Max())
+	for _, q := range testQuantiles {
+		require.Equal(t, all.Quantile(q), merged.Quantile(q))
+	}
+
+	// Invalid encodings are rejected.
+	invalid := NewSketchTimer(sketchOpts, opts)
+	require.Error(t, invalid.MergeFloats(at, encoded[:2]))
+	require.Error(t, invalid.MergeFloats(at, append([]float64{-1}, encoded[1:]...)))
+	require.Error(t, invalid.MergeFloats(at, encoded[:len(encoded)-1]))
+	other := NewSketchTimer(sketchOpts.SetRelativeAccuracy(0.05), opts)
+	require.Error(t, other.MergeFloats(at, encoded))
+}
+
+func TestStreamTimerAppendMergeFloats(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(testAggTypes)
+
+	var (
+		streamOpts = cm.NewOptions()
+		timer      = NewTimer(testQuantiles, streamOpts, opts)
+		at         = time.Now()
+	)
+	for i := 1; i <= 1000; i++ {
+		timer.Add(at, float64(i))
+	}
+	require.False(t, timer.IsMergeable())
+
+	encoded := timer.AppendFloats(nil)
+	restored := NewTimer(testQuantiles, streamOpts, opts)
+	require.NoError(t, restored.MergeFloats(at, encoded))
+	require.Equal(t, at, restored.LastAt())
+	require.Equal(t, timer.Count(), restored.Count())
+	require.Equal(t, timer.Sum(), restored.Sum())
+	require.Equal(t, timer.SumSq(), restored.SumSq())
+	require.Equal(t, timer.Min(), restored.Min())
+	require.Equal(t, timer.Max(), restored.Max())
+	for _, q := range testQuantiles {
+		require.Equal(t, timer.Quantile(q), restored.Quantile(q))
+	}
+	require.Equal(t, encoded, restored.AppendFloats(nil))
+
+	// Stream encodings cannot be merged into sketch timers.
+	sketch := NewSketchTimer(ddsketch.NewOptions(), opts)
+	require.Error(t, sketch.MergeFloats(at, encoded))
+	timer.Close()
+	restored.Close()
+}
+
+func TestSketchTimerAccuracyAgainstStreamTimer(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(testAggTypes)
+
+	var (
+		quantiles   = []float64{0.5, 0.75, 0.9, 0.95, 0.99}
+		sketchOpts  = ddsketch.NewOptions()
+		streamTimer = NewTimer(quantiles, cm.NewOptions(), opts)
+		merged      = NewSketchTimer(sketchOpts, opts)
+		r           = rand.New(rand.NewSource(0))
+		at          = time.Now()
+		values      []float64
+	)
+
+	// Latencies are added to the timers of multiple shards whose sketches
+	// are merged, while a single stream timer sees all the latencies.
+	for shard := 0; shard < 4; shard++ {
+		timer := NewSketchTimer(sketchOpts, opts)
+		for i := 0; i < 10000; i++ {
+			v := math.Exp(r.NormFloat64()) * 100
+			values = append(values, v)
+			timer.Add(at, v)
+			streamTimer.Add(at, v)
+		}
+		require.NoError(t, merged.MergeFloats(at, timer.AppendFloats(nil)))
+	}
+	sort.Float64s(values)
+
+	require.Equal(t, streamTimer.Count(), merged.Count())
+	require.Equal(t, streamTimer.Min(), merged.Min())
+	require.Equal(t, streamTimer.Max(), merged.Max())
+	require.InDelta(t, streamTimer.Sum(), merged.Sum(), 1e-6*streamTimer.Sum())
+	for _, q := range quantiles {
+		var (
+			exact  = values[int(q*float64(len(values)-1))]
+			sketch = merged.Quantile(q)
+			stream = streamTimer.Quantile(q)
+		)
+		// The merged sketch is within its relative accuracy of the exact
+		// quantile and agrees with the stream which saw every value.
+		require.InDelta(t, exact, sketch, exact*sketchOpts.RelativeAccuracy())
+		require.InDelta(t, stream, sketch, 2*stream*sketchOpts.RelativeAccuracy())
+	}
+
+	// The relative accuracy of the sketch also holds far in the tail.
+	exact := values[int(0.999*float64(len(values)-1))]
+	require.InDelta(t, exact, merged.Quantile(0.999), exact*sketchOpts.RelativeAccuracy())
+}
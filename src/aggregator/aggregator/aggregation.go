@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/aggregator/aggregation"
+	"github.com/m3db/m3/src/metrics/histogram"
 	"github.com/m3db/m3/src/metrics/metric/unaggregated"
 )
 
@@ -44,6 +45,30 @@ func (a *counterAggregation) AddUnion(t time.Time, mu unaggregated.MetricUnion)
 	a.Counter.Update(t, mu.CounterVal)
 }
 
+func (a *counterAggregation) AddValues(t time.Time, values []float64) {
+	for _, v := range values {
+		a.Add(t, v)
+	}
+}
+
+func (a *counterAggregation) AppendCumulativeBuckets(
+	dst []histogram.CumulativeBucket,
+) []histogram.CumulativeBucket {
+	return dst
+}
+
+func (a *counterAggregation) AppendForwardedValues(dst []float64) ([]float64, bool) {
+	return dst, false
+}
+
+func (a *counterAggregation) AppendState(dst []float64) []float64 {
+	return a.Counter.AppendFloats(dst)
+}
+
+func (a *counterAggregation) MergeState(t time.Time, state []float64) error {
+	return a.Counter.MergeFloats(t, state)
+}
+
 // timerAggregation is a timer aggregation.
 type timerAggregation struct {
 	aggregation.Timer
@@ -61,6 +86,39 @@ func (a *timerAggregation) AddUnion(timestamp time.Time, mu unaggregated.MetricU
 	a.Timer.AddBatch(timestamp, mu.BatchTimerVal)
 }
 
+// NB: the values forwarded by mergeable timers are encoded timers, values
+// that cannot be decoded are counted by the timer metrics and dropped.
+func (a *timerAggregation) AddValues(timestamp time.Time, values []float64) {
+	if a.Timer.IsMergeable() {
+		_ = a.Timer.MergeFloats(timestamp, values)
+		return
+	}
+	for _, v := range values {
+		a.Add(timestamp, v)
+	}
+}
+
+func (a *timerAggregation) AppendCumulativeBuckets(
+	dst []histogram.CumulativeBucket,
+) []histogram.CumulativeBucket {
+	return dst
+}
+
+func (a *timerAggregation) AppendForwardedValues(dst []float64) ([]float64, bool) {
+	if !a.Timer.IsMergeable() {
+		return dst, false
+	}
+	return a.Timer.AppendFloats(dst), true
+}
+
+func (a *timerAggregation) AppendState(dst []float64) []float64 {
+	return a.Timer.AppendFloats(dst)
+}
+
+func (a *timerAggregation) MergeState(timestamp time.Time, state []float64) error {
+	return a.Timer.MergeFloats(timestamp, state)
+}
+
 // gaugeAggregation is a gauge aggregation.
 type gaugeAggregation struct {
 	aggregation.Gauge
@@ -77,3 +135,62 @@ func (a *gaugeAggregation) Add(t time.Time, value float64) {
 func (a *gaugeAggregation) AddUnion(t time.Time, mu unaggregated.MetricUnion) {
 	a.Gauge.Update(t, mu.GaugeVal)
 }
+
+func (a *gaugeAggregation) AddValues(t time.Time, values []float64) {
+	for _, v := range values {
+		a.Add(t, v)
+	}
+}
+
+func (a *gaugeAggregation) AppendCumulativeBuckets(
+	dst []histogram.CumulativeBucket,
+) []histogram.CumulativeBucket {
+	return dst
+}
+
+func (a *gaugeAggregation) AppendForwardedValues(dst []float64) ([]float64, bool) {
+	return dst, false
+}
+
+func (a *gaugeAggregation) AppendState(dst []float64) []float64 {
+	return a.Gauge.AppendFloats(dst)
+}
+
+func (a *gaugeAggregation) MergeState(t time.Time, state []float64) error {
+	return a.Gauge.MergeFloats(t, state)
+}
+
+// histogramAggregation is a histogram aggregation.
+type histogramAggregation struct {
+	aggregation.Histogram
+}
+
+func newHistogramAggregation(h aggregation.Histogram) histogramAggregation {
+	return histogramAggregation{Histogram: h}
+}
+
+func (a *histogramAggregation) Add(t time.Time, value float64) {
+	a.Histogram.Add(t, value)
+}
+
+func (a *histogramAggregation) AddUnion(t time.Time, mu unaggregated.MetricUnion) {
+	a.Histogram.Merge(t, mu.HistogramVal)
+}
+
+// NB: the values of forwarded histograms are encoded histograms, values that
+// cannot be decoded are dropped and counted by the aggregation metrics.
+func (a *histogramAggregation) AddValues(t time.Time, values []float64) {
+	_ = a.Histogram.MergeFloats(t, values)
+}
+
+func (a *histogramAggregation) AppendForwardedValues(dst []float64) ([]float64, bool) {
+	return a.Histogram.Histogram().AppendFloats(dst), true
+}
+
+func (a *histogramAggregation) AppendState(dst []float64) []float64 {
+	return a.Histogram.Histogram().AppendFloats(dst)
+}
+
+func (a *histogramAggregation) MergeState(t time.Time, state []float64) error {
+	return a.Histogram.MergeFloats(t, state)
+}
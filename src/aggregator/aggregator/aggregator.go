@@ -62,6 +62,12 @@ var (
 	errShardNotOwned                 = errors.New("aggregator shard is not owned")
 )
 
+const (
+	// checkpointElectionCheckInterval is how often the election state is checked
+	// to restore the shards from a shared checkpoint store once elected leader.
+	checkpointElectionCheckInterval = time.Second
+)
+
 // Aggregator aggregates different types of metrics.
 type Aggregator interface {
 	// Open opens the aggregator.
@@ -94,7 +100,7 @@ type Aggregator interface {
 }
 
 // aggregator stores aggregations of different types of metrics (e.g., counter,
-// timer, gauges) and periodically flushes them out.
+// timer, gauges, histograms) and periodically flushes them out.
 type aggregator struct {
 	sync.RWMutex
 
@@ -109,6 +115,7 @@ type aggregator struct {
 	flushManager      FlushManager
 	flushHandler      handler.Handler
 	passthroughWriter writer.Writer
+	lateWriter        writer.Writer
 	adminClient       client.AdminClient
 	resignTimeout     time.Duration
 
@@ -123,6 +130,12 @@ type aggregator struct {
 	wg                  sync.WaitGroup
 	sleepFn             sleepFn
 	shardsPendingClose  int32
+	checkpointStore     CheckpointStore
+	checkpointInterval  time.Duration
+	checkpointMaxAge    time.Duration
+	checkpointLock      sync.Mutex
+	checkpointsClosed   bool
+	wasLeader           bool
 	metrics             aggregatorMetrics
 	logger              *zap.Logger
 }
@@ -133,23 +146,27 @@ func NewAggregator(opts Options) Aggregator {
 	scope := iOpts.MetricsScope()
 	timerOpts := iOpts.TimerOptions()
 	return &aggregator{
-		opts:              opts,
-		nowFn:             opts.ClockOptions().NowFn(),
-		shardFn:           opts.ShardFn(),
-		checkInterval:     opts.EntryCheckInterval(),
-		placementManager:  opts.PlacementManager(),
-		flushTimesManager: opts.FlushTimesManager(),
-		flushTimesChecker: newFlushTimesChecker(scope.SubScope("tick.shard-check")),
-		electionManager:   opts.ElectionManager(),
-		flushManager:      opts.FlushManager(),
-		flushHandler:      opts.FlushHandler(),
-		passthroughWriter: opts.PassthroughWriter(),
-		adminClient:       opts.AdminClient(),
-		resignTimeout:     opts.ResignTimeout(),
-		doneCh:            make(chan struct{}),
-		sleepFn:           time.Sleep,
-		metrics:           newAggregatorMetrics(scope, timerOpts, opts.MaxAllowedForwardingDelayFn()),
-		logger:            iOpts.Logger(),
+		opts:               opts,
+		nowFn:              opts.ClockOptions().NowFn(),
+		shardFn:            opts.ShardFn(),
+		checkInterval:      opts.EntryCheckInterval(),
+		placementManager:   opts.PlacementManager(),
+		flushTimesManager:  opts.FlushTimesManager(),
+		flushTimesChecker:  newFlushTimesChecker(scope.SubScope("tick.shard-check")),
+		electionManager:    opts.ElectionManager(),
+		flushManager:       opts.FlushManager(),
+		flushHandler:       opts.FlushHandler(),
+		passthroughWriter:  opts.PassthroughWriter(),
+		lateWriter:         opts.LateTimedMetricWriter(),
+		adminClient:        opts.AdminClient(),
+		resignTimeout:      opts.ResignTimeout(),
+		checkpointStore:    opts.CheckpointStore(),
+		checkpointInterval: opts.CheckpointInterval(),
+		checkpointMaxAge:   opts.CheckpointMaxAge(),
+		doneCh:             make(chan struct{}),
+		sleepFn:            time.Sleep,
+		metrics:            newAggregatorMetrics(scope, timerOpts, opts.MaxAllowedForwardingDelayFn()),
+		logger:             iOpts.Logger(),
 	}
 }
 
@@ -174,6 +191,10 @@ func (agg *aggregator) Open() error {
 		agg.wg.Add(1)
 		go agg.tick()
 	}
+	if agg.checkpointStore != nil && agg.checkpointInterval > 0 {
+		agg.wg.Add(1)
+		go agg.checkpoint()
+	}
 	agg.state = aggregatorOpen
 	return nil
 }
@@ -321,6 +342,15 @@ func (agg *aggregator) Close() error {
 		return errAggregatorNotOpenOrClosed
 	}
 	close(agg.doneCh)
+	if agg.checkpointStore != nil {
+		// Checkpoint the shards one last time before closing them so that
+		// the aggregation state is carried over when the aggregator restarts.
+		shards := make([]*aggregatorShard, 0, len(agg.shardIDs))
+		for _, shardID := range agg.shardIDs {
+			shards = append(shards, agg.shards[shardID])
+		}
+		agg.checkpointShards(shards, true)
+	}
 	for _, shardID := range agg.shardIDs {
 		agg.shards[shardID].Close()
 	}
@@ -329,6 +359,7 @@ func (agg *aggregator) Close() error {
 	}
 	agg.flushHandler.Close()
 	agg.passthroughWriter.Close()
+	agg.lateWriter.Close()
 	if agg.adminClient != nil {
 		agg.adminClient.Close()
 	}
@@ -570,6 +601,15 @@ func (agg *aggregator) updateShardsWithLock(
 		} else {
 			incoming[shardID] = newAggregatorShard(shardID, agg.opts)
 			agg.metrics.shards.add.Inc(1)
+			// NB: shards are fully restored from their checkpoints only while
+			// the aggregator opens, which is before it campaigns for leadership
+			// and accepts writes, so the restored aggregation windows are neither
+			// flushed early nor double counted. Once open, the aggregator only
+			// restores the windows it is missing when it is elected leader, and
+			// only if the checkpoints are shared with the previous leader.
+			if agg.checkpointStore != nil && agg.state == aggregatorNotOpen {
+				agg.restoreShard(incoming[shardID], false)
+			}
 		}
 		shardTimeRange := timeRange{
 			cutoverNanos: shard.CutoverNanos(),
@@ -597,6 +637,12 @@ func (agg *aggregator) checkMetricType(mu unaggregated.MetricUnion) error {
 	case metric.GaugeType:
 		agg.metrics.gauges.Inc(1)
 		return nil
+	case metric.HistogramType:
+		if err := mu.HistogramVal.Validate(); err != nil {
+			return err
+		}
+		agg.metrics.histograms.Inc(1)
+		return nil
 	default:
 		return errInvalidMetricType
 	}
@@ -701,6 +747,140 @@ func (agg *aggregator) tickInternal() {
 	}
 }
 
+func (agg *aggregator) checkpoint() {
+	defer agg.wg.Done()
+
+	ticker := time.NewTicker(agg.checkpointInterval)
+	defer ticker.Stop()
+
+	electionTicker := time.NewTicker(checkpointElectionCheckInterval)
+	defer electionTicker.Stop()
+
+	for {
+		select {
+		case <-agg.doneCh:
+			return
+		case <-ticker.C:
+			agg.checkpointShards(agg.ownedShards(), false)
+		case <-electionTicker.C:
+			agg.maybeRestoreShardsOnPromotion()
+		}
+	}
+}
+
+func (agg *aggregator) ownedShards() []*aggregatorShard {
+	agg.RLock()
+	defer agg.RUnlock()
+
+	shards := make([]*aggregatorShard, 0, len(agg.shardIDs))
+	for _, shardID := range agg.shardIDs {
+		shards = append(shards, agg.shards[shardID])
+	}
+	return shards
+}
+
+// maybeRestoreShardsOnPromotion restores the shards from the checkpoints
+// written by the previous leader when the aggregator is elected leader. Only
+// the aggregation windows the shards have neither received nor flushed yet are
+// restored, since the windows the aggregator has been aggregating as a follower
+// already contain the same writes as the previous leader.
+func (agg *aggregator) maybeRestoreShardsOnPromotion() {
+	if !agg.checkpointStore.Shared() {
+		return
+	}
+	isLeader := agg.electionManager.ElectionState() == LeaderState
+	wasLeader := agg.wasLeader
+	agg.wasLeader = isLeader
+	if !isLeader || wasLeader {
+		return
+	}
+	agg.metrics.checkpoint.promotions.Inc(1)
+	for _, shard := range agg.ownedShards() {
+		agg.restoreShard(shard, true)
+	}
+}
+
+// checkpointShards checkpoints the aggregation state of the shards, no more
+// checkpoints are written once the final checkpoints have been written so the
+// final checkpoints are never replaced by older ones.
+func (agg *aggregator) checkpointShards(shards []*aggregatorShard, final bool) {
+	agg.checkpointLock.Lock()
+	defer agg.checkpointLock.Unlock()
+
+	if agg.checkpointsClosed {
+		return
+	}
+	// NB: the leader and the followers aggregate the same writes, so only the
+	// leader writes the checkpoints to a shared store.
+	if agg.checkpointStore.Shared() && agg.electionManager.ElectionState() != LeaderState {
+		if final {
+			agg.checkpointsClosed = true
+		}
+		return
+	}
+	for _, shard := range shards {
+		start := agg.nowFn()
+		data, err := shard.Checkpoint()
+		if err == errAggregatorShardClosed {
+			continue
+		}
+		if err == nil {
+			err = agg.checkpointStore.Write(shard.ID(), data)
+		}
+		if err != nil {
+			agg.metrics.checkpoint.errors.Inc(1)
+			agg.logger.Error("could not checkpoint shard",
+				zap.Uint32("shard", shard.ID()), zap.Error(err))
+			continue
+		}
+		agg.metrics.checkpoint.success.Inc(1)
+		agg.metrics.checkpoint.bytes.RecordValue(float64(len(data)))
+		agg.metrics.checkpoint.latency.Record(agg.nowFn().Sub(start))
+	}
+	if final {
+		agg.checkpointsClosed = true
+	}
+}
+
+// restoreShard restores the aggregation state of a shard from its checkpoint,
+// restoring only the aggregation windows the shard is missing if missingOnly
+// is true. A checkpoint that cannot be restored is logged and the shard starts
+// with whatever state was restored, since failing to open the aggregator would
+// lose more data than a partially restored shard does.
+func (agg *aggregator) restoreShard(shard *aggregatorShard, missingOnly bool) {
+	data, err := agg.checkpointStore.Read(shard.ID())
+	if err == ErrCheckpointNotFound {
+		agg.metrics.checkpoint.notFound.Inc(1)
+		return
+	}
+	var dec *checkpointDecoder
+	if err == nil {
+		dec, err = newCheckpointDecoder(data)
+	}
+	if err != nil {
+		agg.metrics.checkpoint.restoreErrors.Inc(1)
+		agg.logger.Error("could not read shard checkpoint",
+			zap.Uint32("shard", shard.ID()), zap.Error(err))
+		return
+	}
+	createdAt := time.Unix(0, dec.CreatedAtNanos())
+	if agg.nowFn().Sub(createdAt) > agg.checkpointMaxAge {
+		agg.metrics.checkpoint.expired.Inc(1)
+		agg.logger.Info("skipped restoring expired shard checkpoint",
+			zap.Uint32("shard", shard.ID()), zap.Time("createdAt", createdAt))
+		return
+	}
+	numRestored, err := shard.Restore(dec, missingOnly)
+	agg.metrics.checkpoint.restoredElems.Inc(int64(numRestored))
+	if err != nil {
+		agg.metrics.checkpoint.restoreErrors.Inc(1)
+		agg.logger.Error("could not restore shard checkpoint",
+			zap.Uint32("shard", shard.ID()), zap.Int("numRestored", numRestored), zap.Error(err))
+		return
+	}
+	agg.metrics.checkpoint.restored.Inc(1)
+}
+
 type aggregatorAddMetricMetrics struct {
 	success                    tally.Counter
 	successLatency             tally.Timer
@@ -1012,11 +1192,40 @@ func newAggregatorShardSetIDMetrics(scope tally.Scope) aggregatorShardSetIDMetri
 	}
 }
 
+type aggregatorCheckpointMetrics struct {
+	success       tally.Counter
+	errors        tally.Counter
+	bytes         tally.Histogram
+	latency       tally.Timer
+	restored      tally.Counter
+	restoredElems tally.Counter
+	restoreErrors tally.Counter
+	notFound      tally.Counter
+	expired       tally.Counter
+	promotions    tally.Counter
+}
+
+func newAggregatorCheckpointMetrics(scope tally.Scope) aggregatorCheckpointMetrics {
+	return aggregatorCheckpointMetrics{
+		success:       scope.Counter("success"),
+		errors:        scope.Counter("errors"),
+		bytes:         scope.Histogram("bytes", tally.MustMakeExponentialValueBuckets(1024, 4, 12)),
+		latency:       scope.Timer("latency"),
+		restored:      scope.Counter("restored"),
+		restoredElems: scope.Counter("restored-elems"),
+		restoreErrors: scope.Counter("restore-errors"),
+		notFound:      scope.Counter("not-found"),
+		expired:       scope.Counter("expired"),
+		promotions:    scope.Counter("promotions"),
+	}
+}
+
 type aggregatorMetrics struct {
 	counters       tally.Counter
 	timers         tally.Counter
 	timerBatches   tally.Counter
 	gauges         tally.Counter
+	histograms     tally.Counter
 	forwarded      tally.Counter
 	timed          tally.Counter
 	passthrough    tally.Counter
@@ -1028,6 +1237,7 @@ type aggregatorMetrics struct {
 	shards         aggregatorShardsMetrics
 	shardSetID     aggregatorShardSetIDMetrics
 	tick           aggregatorTickMetrics
+	checkpoint     aggregatorCheckpointMetrics
 }
 
 func newAggregatorMetrics(
@@ -1043,11 +1253,13 @@ func newAggregatorMetrics(
 	shardsScope := scope.SubScope("shards")
 	shardSetIDScope := scope.SubScope("shard-set-id")
 	tickScope := scope.SubScope("tick")
+	checkpointScope := scope.SubScope("checkpoint")
 	return aggregatorMetrics{
 		counters:       scope.Counter("counters"),
 		timers:         scope.Counter("timers"),
 		timerBatches:   scope.Counter("timer-batches"),
 		gauges:         scope.Counter("gauges"),
+		histograms:     scope.Counter("histograms"),
 		forwarded:      scope.Counter("forwarded"),
 		timed:          scope.Counter("timed"),
 		passthrough:    scope.Counter("passthrough"),
@@ -1059,6 +1271,7 @@ func newAggregatorMetrics(
 		shards:         newAggregatorShardsMetrics(shardsScope),
 		shardSetID:     newAggregatorShardSetIDMetrics(shardSetIDScope),
 		tick:           newAggregatorTickMetrics(tickScope),
+		checkpoint:     newAggregatorCheckpointMetrics(checkpointScope),
 	}
 }
 
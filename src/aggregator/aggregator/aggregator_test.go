@@ -22,7 +22,9 @@ package aggregator
 
 import (
 	"errors"
+	"io/ioutil"
 	"math"
+	"os"
 	"sort"
 	"testing"
 	"time"
@@ -724,6 +726,91 @@ func TestAggregatorCloseSuccess(t *testing.T) {
 	require.Equal(t, aggregatorClosed, agg.state)
 }
 
+func TestAggregatorCheckpointRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "checkpoint")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	store, err := NewFileCheckpointStore(dir)
+	require.NoError(t, err)
+
+	// Shards are checkpointed when the aggregator is closed.
+	agg, _ := testAggregator(t, ctrl)
+	agg.checkpointStore = store
+	agg.checkpointInterval = 0
+	agg.shardFn = func([]byte, uint32) uint32 { return 1 }
+	require.NoError(t, agg.Open())
+	require.NoError(t, agg.AddUntimed(testUntimedMetric, testStagedMetadatas))
+	require.NoError(t, agg.Close())
+
+	// Shards are restored from their checkpoints when the aggregator is opened.
+	restarted, _ := testAggregator(t, ctrl)
+	restarted.checkpointStore = store
+	restarted.checkpointInterval = 0
+	require.NoError(t, restarted.Open())
+	require.Equal(t, 1, len(restarted.shards[1].metricMap.entries))
+	require.Equal(t, 0, len(restarted.shards[0].metricMap.entries))
+	require.NoError(t, restarted.Close())
+
+	// Checkpoints older than the max age are not restored.
+	expired, _ := testAggregator(t, ctrl)
+	expired.checkpointStore = store
+	expired.checkpointInterval = 0
+	expired.nowFn = func() time.Time { return time.Now().Add(time.Hour) }
+	require.NoError(t, expired.Open())
+	require.Equal(t, 0, len(expired.shards[1].metricMap.entries))
+	require.NoError(t, expired.Close())
+}
+
+func TestAggregatorCheckpointRestoreOnPromotion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := NewKVCheckpointStore(mem.NewStore(), "checkpoint/shard/%d", 0)
+
+	// The leader checkpoints the shards into the shared store.
+	leader, _ := testAggregator(t, ctrl)
+	leader.checkpointStore = store
+	leader.checkpointInterval = 0
+	leader.shardFn = func([]byte, uint32) uint32 { return 1 }
+	require.NoError(t, leader.Open())
+	require.NoError(t, leader.AddUntimed(testUntimedMetric, testStagedMetadatas))
+	require.NoError(t, leader.Close())
+	checkpoint, err := store.Read(1)
+	require.NoError(t, err)
+
+	electionState := FollowerState
+	electionMgr := NewMockElectionManager(ctrl)
+	electionMgr.EXPECT().Reset().Return(nil).AnyTimes()
+	electionMgr.EXPECT().Open(gomock.Any()).Return(nil).AnyTimes()
+	electionMgr.EXPECT().Close().Return(nil).AnyTimes()
+	electionMgr.EXPECT().ElectionState().DoAndReturn(func() ElectionState {
+		return electionState
+	}).AnyTimes()
+
+	// The follower does not restore the shards until it is elected leader.
+	follower, _ := testAggregator(t, ctrl)
+	follower.electionManager = electionMgr
+	follower.checkpointInterval = 0
+	require.NoError(t, follower.Open())
+	follower.checkpointStore = store
+	follower.maybeRestoreShardsOnPromotion()
+	require.Equal(t, 0, len(follower.shards[1].metricMap.entries))
+
+	// Only the leader writes checkpoints into the shared store.
+	follower.checkpointShards(follower.ownedShards(), false)
+	data, err := store.Read(1)
+	require.NoError(t, err)
+	require.Equal(t, checkpoint, data)
+
+	electionState = LeaderState
+	follower.maybeRestoreShardsOnPromotion()
+	require.Equal(t, 1, len(follower.shards[1].metricMap.entries))
+	require.NoError(t, follower.Close())
+}
+
 func TestAggregatorTick(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
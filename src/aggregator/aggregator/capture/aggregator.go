@@ -25,6 +25,7 @@ import (
 	"sync"
 
 	aggr "github.com/m3db/m3/src/aggregator/aggregator"
+	"github.com/m3db/m3/src/metrics/histogram"
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
@@ -43,6 +44,7 @@ type aggregator struct {
 	countersWithMetadatas          []unaggregated.CounterWithMetadatas
 	batchTimersWithMetadatas       []unaggregated.BatchTimerWithMetadatas
 	gaugesWithMetadatas            []unaggregated.GaugeWithMetadatas
+	histogramsWithMetadatas        []unaggregated.HistogramWithMetadatas
 	forwardedMetricsWithMetadata   []aggregated.ForwardedMetricWithMetadata
 	timedMetricsWithMetadata       []aggregated.TimedMetricWithMetadata
 	timedMetricsWithMetadatas      []aggregated.TimedMetricWithMetadatas
@@ -86,6 +88,12 @@ func (agg *aggregator) AddUntimed(
 			StagedMetadatas: sm,
 		}
 		agg.gaugesWithMetadatas = append(agg.gaugesWithMetadatas, gp)
+	case metric.HistogramType:
+		hp := unaggregated.HistogramWithMetadatas{
+			Histogram:       mu.Histogram(),
+			StagedMetadatas: sm,
+		}
+		agg.histogramsWithMetadatas = append(agg.histogramsWithMetadatas, hp)
 	default:
 		return fmt.Errorf("unrecognized metric type %v", mu.Type)
 	}
@@ -191,6 +199,7 @@ func (agg *aggregator) Snapshot() SnapshotResult {
 		CountersWithMetadatas:         agg.countersWithMetadatas,
 		BatchTimersWithMetadatas:      agg.batchTimersWithMetadatas,
 		GaugesWithMetadatas:           agg.gaugesWithMetadatas,
+		HistogramsWithMetadatas:       agg.histogramsWithMetadatas,
 		ForwardedMetricsWithMetadata:  agg.forwardedMetricsWithMetadata,
 		TimedMetricWithMetadata:       agg.timedMetricsWithMetadata,
 		PassthroughMetricWithMetadata: agg.passthroughMetricsWithMetadata,
@@ -198,6 +207,7 @@ func (agg *aggregator) Snapshot() SnapshotResult {
 	agg.countersWithMetadatas = nil
 	agg.batchTimersWithMetadatas = nil
 	agg.gaugesWithMetadatas = nil
+	agg.histogramsWithMetadatas = nil
 	agg.forwardedMetricsWithMetadata = nil
 	agg.timedMetricsWithMetadata = nil
 	agg.passthroughMetricsWithMetadata = nil
@@ -222,6 +232,14 @@ func cloneUntimedMetric(m unaggregated.MetricUnion) unaggregated.MetricUnion {
 		copy(clonedTimerVal, m.BatchTimerVal)
 		mu.BatchTimerVal = clonedTimerVal
 	}
+
+	// Clone histogram buckets.
+	if m.Type == metric.HistogramType {
+		mu.HistogramVal.PositiveBuckets = append([]histogram.Bucket(nil),
+			m.HistogramVal.PositiveBuckets...)
+		mu.HistogramVal.NegativeBuckets = append([]histogram.Bucket(nil),
+			m.HistogramVal.NegativeBuckets...)
+	}
 	return mu
 }
 
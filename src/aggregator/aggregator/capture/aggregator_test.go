@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/histogram"
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
@@ -55,6 +56,16 @@ var (
 		ID:       id.RawID("testGauge"),
 		GaugeVal: 123.456,
 	}
+	testHistogram = unaggregated.MetricUnion{
+		Type: metric.HistogramType,
+		ID:   id.RawID("testHistogram"),
+		HistogramVal: histogram.Histogram{
+			Schema:          3,
+			Count:           3,
+			Sum:             6.5,
+			PositiveBuckets: []histogram.Bucket{{Index: 1, Count: 2}, {Index: 8, Count: 1}},
+		},
+	}
 	testTimed = aggregated.Metric{
 		Type:      metric.CounterType,
 		ID:        []byte("testTimed"),
@@ -109,7 +120,7 @@ func TestAggregator(t *testing.T) {
 
 	// Add valid untimed metrics with policies.
 	var expected SnapshotResult
-	for _, mu := range []unaggregated.MetricUnion{testCounter, testBatchTimer, testGauge} {
+	for _, mu := range []unaggregated.MetricUnion{testCounter, testBatchTimer, testGauge, testHistogram} {
 		switch mu.Type {
 		case metric.CounterType:
 			expected.CountersWithMetadatas = append(
@@ -132,6 +143,13 @@ func TestAggregator(t *testing.T) {
 					Gauge:           mu.Gauge(),
 					StagedMetadatas: metadatas,
 				})
+		case metric.HistogramType:
+			expected.HistogramsWithMetadatas = append(
+				expected.HistogramsWithMetadatas,
+				unaggregated.HistogramWithMetadatas{
+					Histogram:       mu.Histogram(),
+					StagedMetadatas: metadatas,
+				})
 		default:
 			require.Fail(t, fmt.Sprintf("unknown metric type %v", mu.Type))
 		}
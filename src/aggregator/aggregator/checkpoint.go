@@ -0,0 +1,546 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregator
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/m3db/m3/src/cluster/generated/proto/commonpb"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/metrics/generated/proto/pipelinepb"
+	"github.com/m3db/m3/src/metrics/metric"
+	"github.com/m3db/m3/src/metrics/metric/id"
+
+	"github.com/willf/bitset"
+)
+
+const (
+	// checkpointMagic identifies the encoded checkpoint of a shard.
+	checkpointMagic   uint32 = 0x6d336163
+	checkpointVersion        = 1
+
+	// checkpointChecksumLen is the length of the checksum ending an encoded checkpoint.
+	checkpointChecksumLen = 4
+
+	checkpointFilePerm    = 0644
+	checkpointDirPerm     = 0755
+	checkpointFileSuffix  = ".checkpoint"
+	checkpointTempPattern = "tmp-"
+
+	// defaultKVCheckpointChunkSize is the default max size of the chunks the
+	// checkpoint of a shard is split into when it is kept in kv, which stays
+	// well within the request size limit of etcd once base64 encoded.
+	defaultKVCheckpointChunkSize = 256 << 10
+)
+
+var (
+	// ErrCheckpointNotFound is returned when there is no checkpoint for a shard.
+	ErrCheckpointNotFound = errors.New("checkpoint not found")
+
+	errInvalidCheckpoint          = errors.New("invalid checkpoint")
+	errCheckpointChecksumMismatch = errors.New("checkpoint checksum mismatch")
+)
+
+// CheckpointStore stores the checkpointed aggregation state of shards, so
+// that an aggregator can restore the state of its shards when it restarts,
+// or when it is elected leader if the store is shared.
+type CheckpointStore interface {
+	// Write writes the checkpoint of a shard, replacing its previous checkpoint.
+	Write(shard uint32, data []byte) error
+
+	// Read reads the checkpoint of a shard, returning ErrCheckpointNotFound
+	// if the shard has not been checkpointed.
+	Read(shard uint32) ([]byte, error)
+
+	// Shared returns true if the store is shared by the instances owning the
+	// same shards, in which case only the leader writes checkpoints.
+	Shared() bool
+}
+
+type fileCheckpointStore struct {
+	dir string
+}
+
+// NewFileCheckpointStore creates a checkpoint store keeping the checkpoint of
+// each shard in a file under the given directory.
+func NewFileCheckpointStore(dir string) (CheckpointStore, error) {
+	if err := os.MkdirAll(dir, checkpointDirPerm); err != nil {
+		return nil, err
+	}
+	return &fileCheckpointStore{dir: dir}, nil
+}
+
+func (s *fileCheckpointStore) Write(shard uint32, data []byte) error {
+	f, err := ioutil.TempFile(s.dir, checkpointTempPattern)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Chmod(f.Name(), checkpointFilePerm); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	// Rename the written file into place so a checkpoint is never observed
+	// partially written, even if the aggregator is killed while writing it.
+	return os.Rename(f.Name(), s.path(shard))
+}
+
+func (s *fileCheckpointStore) Read(shard uint32) ([]byte, error) {
+	data, err := ioutil.ReadFile(s.path(shard))
+	if os.IsNotExist(err) {
+		return nil, ErrCheckpointNotFound
+	}
+	return data, err
+}
+
+func (s *fileCheckpointStore) Shared() bool { return false }
+
+func (s *fileCheckpointStore) path(shard uint32) string {
+	return filepath.Join(s.dir, fmt.Sprintf("shard-%d%s", shard, checkpointFileSuffix))
+}
+
+type kvCheckpointStore struct {
+	store     kv.Store
+	keyFmt    string
+	chunkSize int
+}
+
+// NewKVCheckpointStore creates a checkpoint store keeping the checkpoint of
+// each shard in kv under the key formatted from the shard, so that it is
+// shared by the leader and followers owning the shard. The key format must
+// contain a single %d verb for the shard.
+//
+// The checkpoint of a shard is split into chunks of at most chunkSize bytes
+// kept under their own keys, and the key of the shard lists the keys of the
+// chunks, so that the checkpoints of large shards do not exceed the value
+// size limit of the store. The default chunk size is used if chunkSize is
+// not positive.
+func NewKVCheckpointStore(store kv.Store, keyFmt string, chunkSize int) CheckpointStore {
+	if chunkSize <= 0 {
+		chunkSize = defaultKVCheckpointChunkSize
+	}
+	return &kvCheckpointStore{store: store, keyFmt: keyFmt, chunkSize: chunkSize}
+}
+
+func (s *kvCheckpointStore) Write(shard uint32, data []byte) error {
+	key := s.key(shard)
+	prevChunkKeys, version, err := s.chunkKeys(key)
+	if err != nil && err != ErrCheckpointNotFound {
+		return err
+	}
+
+	// NB: the chunks are written under keys qualified by the next version of
+	// the shard key and the shard key is only updated once all the chunks are
+	// written, so that readers never observe a partially written checkpoint.
+	chunkKeys := make([]string, 0, (len(data)+s.chunkSize-1)/s.chunkSize)
+	for i := 0; len(data) > 0; i++ {
+		n := s.chunkSize
+		if n > len(data) {
+			n = len(data)
+		}
+		chunkKey := fmt.Sprintf("%s/%d/%d", key, version+1, i)
+		// NB: checkpoints are stored as base64 encoded strings since values
+		// in kv are protobuf messages.
+		value := &commonpb.StringProto{Value: base64.StdEncoding.EncodeToString(data[:n])}
+		if _, err := s.store.Set(chunkKey, value); err != nil {
+			return err
+		}
+		chunkKeys = append(chunkKeys, chunkKey)
+		data = data[n:]
+	}
+	if _, err := s.store.Set(key, &commonpb.StringArrayProto{Values: chunkKeys}); err != nil {
+		return err
+	}
+
+	for _, chunkKey := range prevChunkKeys {
+		if _, err := s.store.Delete(chunkKey); err != nil && err != kv.ErrNotFound {
+			return fmt.Errorf("could not delete previous checkpoint chunk %s: %v", chunkKey, err)
+		}
+	}
+	return nil
+}
+
+func (s *kvCheckpointStore) Read(shard uint32) ([]byte, error) {
+	chunkKeys, _, err := s.chunkKeys(s.key(shard))
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	for _, chunkKey := range chunkKeys {
+		value, err := s.store.Get(chunkKey)
+		if err == kv.ErrNotFound {
+			// The chunks of the checkpoint were deleted by a newer checkpoint
+			// written in the meantime.
+			return nil, errInvalidCheckpoint
+		}
+		if err != nil {
+			return nil, err
+		}
+		var encoded commonpb.StringProto
+		if err := value.Unmarshal(&encoded); err != nil {
+			return nil, err
+		}
+		chunk, err := base64.StdEncoding.DecodeString(encoded.Value)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, chunk...)
+	}
+	return data, nil
+}
+
+// chunkKeys returns the keys of the chunks of the checkpoint kept under the
+// shard key along with the version of the shard key.
+func (s *kvCheckpointStore) chunkKeys(key string) ([]string, int, error) {
+	value, err := s.store.Get(key)
+	if err == kv.ErrNotFound {
+		return nil, 0, ErrCheckpointNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	var chunkKeys commonpb.StringArrayProto
+	if err := value.Unmarshal(&chunkKeys); err != nil {
+		return nil, 0, err
+	}
+	return chunkKeys.Values, value.Version(), nil
+}
+
+func (s *kvCheckpointStore) Shared() bool { return true }
+
+func (s *kvCheckpointStore) key(shard uint32) string {
+	return fmt.Sprintf(s.keyFmt, shard)
+}
+
+// restoreOptions determine which checkpointed aggregation windows of an
+// element are restored.
+type restoreOptions struct {
+	// missingOnly restores only the windows the element does not have yet
+	// instead of merging the checkpointed windows into the existing ones.
+	missingOnly bool
+
+	// isFlushedFn returns true for windows that have already been flushed
+	// and as such are not restored, all windows are restored if nil.
+	isFlushedFn func(windowStartNanos int64) bool
+}
+
+// elemState is the checkpointed state of an aggregation element.
+type elemState struct {
+	windows []windowState
+}
+
+// windowState is the checkpointed state of an aggregation window.
+type windowState struct {
+	startAtNanos int64
+	lastAtNanos  int64
+	sourcesSeen  *bitset.BitSet
+	values       []float64
+}
+
+// elemCheckpoint is the checkpoint of an aggregation element along with what
+// is needed to recreate the element within the entry owning it.
+type elemCheckpoint struct {
+	metricCategory metricCategory
+	metricType     metric.Type
+	id             id.RawID
+	key            aggregationKey
+	listType       metricListType
+	state          elemState
+}
+
+func (c elemCheckpoint) listID() (metricListID, error) {
+	resolution := c.key.storagePolicy.Resolution().Window
+	switch c.listType {
+	case standardMetricListType:
+		return standardMetricListID{
+			resolution: resolution,
+		}.toMetricListID(), nil
+	case forwardedMetricListType:
+		return forwardedMetricListID{
+			resolution:        resolution,
+			numForwardedTimes: c.key.numForwardedTimes,
+		}.toMetricListID(), nil
+	case timedMetricListType:
+		return timedMetricListID{
+			resolution: resolution,
+		}.toMetricListID(), nil
+	default:
+		return metricListID{}, fmt.Errorf("unknown list type: %v", c.listType)
+	}
+}
+
+// checkpointEncoder encodes the checkpoint of a shard. The encoded checkpoint
+// starts with a header identifying the shard and when it was checkpointed,
+// followed by the checkpoint of each element, and ends with a checksum.
+type checkpointEncoder struct {
+	buf     []byte
+	scratch [binary.MaxVarintLen64]byte
+}
+
+func newCheckpointEncoder(shard uint32, createdAtNanos int64) *checkpointEncoder {
+	enc := &checkpointEncoder{}
+	binary.BigEndian.PutUint32(enc.scratch[:], checkpointMagic)
+	enc.buf = append(enc.buf, enc.scratch[:4]...)
+	enc.putUvarint(checkpointVersion)
+	enc.putUvarint(uint64(shard))
+	enc.putVarint(createdAtNanos)
+	return enc
+}
+
+// Encode encodes the checkpoint of an element.
+func (enc *checkpointEncoder) Encode(c elemCheckpoint) error {
+	storagePolicy, err := c.key.storagePolicy.MarshalText()
+	if err != nil {
+		return err
+	}
+	var pb pipelinepb.AppliedPipeline
+	if err := c.key.pipeline.ToProto(&pb); err != nil {
+		return err
+	}
+	pipeline, err := pb.Marshal()
+	if err != nil {
+		return err
+	}
+
+	start := len(enc.buf)
+	enc.putUvarint(uint64(c.metricCategory))
+	enc.putUvarint(uint64(c.metricType))
+	enc.putUvarint(uint64(c.listType))
+	enc.putBytes(c.id)
+	for _, v := range c.key.aggregationID {
+		enc.putUvarint(v)
+	}
+	enc.putBytes(storagePolicy)
+	enc.putBytes(pipeline)
+	enc.putVarint(int64(c.key.numForwardedTimes))
+	enc.putUvarint(uint64(c.key.idPrefixSuffixType))
+	enc.putUvarint(uint64(len(c.state.windows)))
+	for _, window := range c.state.windows {
+		var sourcesSeen []byte
+		if window.sourcesSeen != nil {
+			if sourcesSeen, err = window.sourcesSeen.MarshalBinary(); err != nil {
+				enc.buf = enc.buf[:start]
+				return err
+			}
+		}
+		enc.putVarint(window.startAtNanos)
+		enc.putVarint(window.lastAtNanos)
+		enc.putBytes(sourcesSeen)
+		enc.putUvarint(uint64(len(window.values)))
+		for _, v := range window.values {
+			binary.BigEndian.PutUint64(enc.scratch[:], math.Float64bits(v))
+			enc.buf = append(enc.buf, enc.scratch[:8]...)
+		}
+	}
+	return nil
+}
+
+// Bytes returns the encoded checkpoint.
+func (enc *checkpointEncoder) Bytes() []byte {
+	checksum := crc32.ChecksumIEEE(enc.buf)
+	binary.BigEndian.PutUint32(enc.scratch[:], checksum)
+	return append(enc.buf, enc.scratch[:checkpointChecksumLen]...)
+}
+
+func (enc *checkpointEncoder) putUvarint(v uint64) {
+	n := binary.PutUvarint(enc.scratch[:], v)
+	enc.buf = append(enc.buf, enc.scratch[:n]...)
+}
+
+func (enc *checkpointEncoder) putVarint(v int64) {
+	n := binary.PutVarint(enc.scratch[:], v)
+	enc.buf = append(enc.buf, enc.scratch[:n]...)
+}
+
+func (enc *checkpointEncoder) putBytes(b []byte) {
+	enc.putUvarint(uint64(len(b)))
+	enc.buf = append(enc.buf, b...)
+}
+
+// checkpointDecoder iterates over the element checkpoints of an encoded
+// shard checkpoint.
+type checkpointDecoder struct {
+	data           []byte
+	shard          uint32
+	createdAtNanos int64
+	curr           elemCheckpoint
+	err            error
+}
+
+func newCheckpointDecoder(data []byte) (*checkpointDecoder, error) {
+	if len(data) < 4+checkpointChecksumLen {
+		return nil, errInvalidCheckpoint
+	}
+	var (
+		payloadLen = len(data) - checkpointChecksumLen
+		checksum   = binary.BigEndian.Uint32(data[payloadLen:])
+	)
+	if crc32.ChecksumIEEE(data[:payloadLen]) != checksum {
+		return nil, errCheckpointChecksumMismatch
+	}
+	if binary.BigEndian.Uint32(data) != checkpointMagic {
+		return nil, errInvalidCheckpoint
+	}
+	dec := &checkpointDecoder{data: data[4:payloadLen]}
+	if version := dec.uvarint(); dec.err == nil && version != checkpointVersion {
+		return nil, fmt.Errorf("unsupported checkpoint version: %d", version)
+	}
+	dec.shard = uint32(dec.uvarint())
+	dec.createdAtNanos = dec.varint()
+	if dec.err != nil {
+		return nil, dec.err
+	}
+	return dec, nil
+}
+
+// Shard returns the shard of the checkpoint.
+func (dec *checkpointDecoder) Shard() uint32 { return dec.shard }
+
+// CreatedAtNanos returns when the checkpoint was created in Unix nanoseconds.
+func (dec *checkpointDecoder) CreatedAtNanos() int64 { return dec.createdAtNanos }
+
+// Next decodes the next element checkpoint, returning false when there are
+// no more element checkpoints or the checkpoint cannot be decoded.
+func (dec *checkpointDecoder) Next() bool {
+	if dec.err != nil || len(dec.data) == 0 {
+		return false
+	}
+	var c elemCheckpoint
+	c.metricCategory = metricCategory(dec.uvarint())
+	c.metricType = metric.Type(dec.uvarint())
+	c.listType = metricListType(dec.uvarint())
+	c.id = dec.bytes()
+	for i := range c.key.aggregationID {
+		c.key.aggregationID[i] = dec.uvarint()
+	}
+	if storagePolicy := dec.bytes(); dec.err == nil {
+		dec.err = c.key.storagePolicy.UnmarshalText(storagePolicy)
+	}
+	if pipeline := dec.bytes(); dec.err == nil {
+		var pb pipelinepb.AppliedPipeline
+		if dec.err = pb.Unmarshal(pipeline); dec.err == nil {
+			dec.err = c.key.pipeline.FromProto(pb)
+		}
+	}
+	c.key.numForwardedTimes = int(dec.varint())
+	c.key.idPrefixSuffixType = IDPrefixSuffixType(dec.uvarint())
+	numWindows := dec.uvarint()
+	if dec.err == nil && numWindows > uint64(len(dec.data)) {
+		dec.err = errInvalidCheckpoint
+	}
+	for i := uint64(0); i < numWindows && dec.err == nil; i++ {
+		var window windowState
+		window.startAtNanos = dec.varint()
+		window.lastAtNanos = dec.varint()
+		if sourcesSeen := dec.bytes(); len(sourcesSeen) > 0 {
+			window.sourcesSeen = &bitset.BitSet{}
+			if err := window.sourcesSeen.UnmarshalBinary(sourcesSeen); err != nil && dec.err == nil {
+				dec.err = err
+			}
+		}
+		numValues := dec.uvarint()
+		if dec.err == nil && numValues > uint64(len(dec.data)/8) {
+			dec.err = errInvalidCheckpoint
+		}
+		if dec.err != nil {
+			break
+		}
+		window.values = make([]float64, numValues)
+		for j := range window.values {
+			window.values[j] = math.Float64frombits(binary.BigEndian.Uint64(dec.data))
+			dec.data = dec.data[8:]
+		}
+		c.state.windows = append(c.state.windows, window)
+	}
+	if dec.err != nil {
+		return false
+	}
+	dec.curr = c
+	return true
+}
+
+// Current returns the current element checkpoint.
+func (dec *checkpointDecoder) Current() elemCheckpoint { return dec.curr }
+
+// Err returns the error decoding the checkpoint if any.
+func (dec *checkpointDecoder) Err() error { return dec.err }
+
+func (dec *checkpointDecoder) uvarint() uint64 {
+	if dec.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(dec.data)
+	if n <= 0 {
+		dec.err = errInvalidCheckpoint
+		return 0
+	}
+	dec.data = dec.data[n:]
+	return v
+}
+
+func (dec *checkpointDecoder) varint() int64 {
+	if dec.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(dec.data)
+	if n <= 0 {
+		dec.err = errInvalidCheckpoint
+		return 0
+	}
+	dec.data = dec.data[n:]
+	return v
+}
+
+func (dec *checkpointDecoder) bytes() []byte {
+	n := dec.uvarint()
+	if dec.err != nil {
+		return nil
+	}
+	if n > uint64(len(dec.data)) {
+		dec.err = errInvalidCheckpoint
+		return nil
+	}
+	b := dec.data[:n:n]
+	dec.data = dec.data[n:]
+	return b
+}
@@ -0,0 +1,342 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregator
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/aggregator/hash"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/metric"
+	"github.com/m3db/m3/src/metrics/pipeline/applied"
+	"github.com/m3db/m3/src/x/clock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/willf/bitset"
+)
+
+func TestCheckpointEncodeDecode(t *testing.T) {
+	checkpoints := []elemCheckpoint{
+		{
+			metricCategory: untimedMetric,
+			metricType:     metric.CounterType,
+			id:             testCounterID,
+			key: aggregationKey{
+				aggregationID: aggregation.DefaultID,
+				storagePolicy: testStoragePolicy,
+				pipeline:      applied.DefaultPipeline,
+			},
+			listType: standardMetricListType,
+			state: elemState{
+				windows: []windowState{
+					{startAtNanos: 10000, lastAtNanos: 12345, values: []float64{1, 2, 3}},
+					{startAtNanos: 20000, values: []float64{4.5}},
+				},
+			},
+		},
+		{
+			metricCategory: forwardedMetric,
+			metricType:     metric.GaugeType,
+			id:             testGaugeID,
+			key: aggregationKey{
+				aggregationID:     aggregation.MustCompressTypes(aggregation.Count),
+				storagePolicy:     testForwardMetadata.StoragePolicy,
+				pipeline:          testForwardMetadata.Pipeline,
+				numForwardedTimes: testForwardMetadata.NumForwardedTimes,
+			},
+			listType: forwardedMetricListType,
+			state: elemState{
+				windows: []windowState{
+					{startAtNanos: 60000, sourcesSeen: bitset.New(8).Set(3), values: []float64{-1.5, 0}},
+				},
+			},
+		},
+	}
+
+	enc := newCheckpointEncoder(testShard, 1234)
+	for _, c := range checkpoints {
+		require.NoError(t, enc.Encode(c))
+	}
+
+	dec, err := newCheckpointDecoder(enc.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, testShard, dec.Shard())
+	require.Equal(t, int64(1234), dec.CreatedAtNanos())
+	var decoded []elemCheckpoint
+	for dec.Next() {
+		decoded = append(decoded, dec.Current())
+	}
+	require.NoError(t, dec.Err())
+	requireEqualCheckpoints(t, checkpoints, decoded)
+}
+
+func TestCheckpointDecodeInvalid(t *testing.T) {
+	enc := newCheckpointEncoder(testShard, 1234)
+	require.NoError(t, enc.Encode(elemCheckpoint{
+		metricCategory: untimedMetric,
+		metricType:     metric.CounterType,
+		id:             testCounterID,
+		key: aggregationKey{
+			aggregationID: aggregation.DefaultID,
+			storagePolicy: testStoragePolicy,
+			pipeline:      applied.DefaultPipeline,
+		},
+		listType: standardMetricListType,
+		state: elemState{
+			windows: []windowState{{startAtNanos: 10000, values: []float64{1}}},
+		},
+	}))
+	data := enc.Bytes()
+
+	_, err := newCheckpointDecoder(data[:3])
+	require.Equal(t, errInvalidCheckpoint, err)
+
+	corrupted := append([]byte(nil), data...)
+	corrupted[len(corrupted)/2]++
+	_, err = newCheckpointDecoder(corrupted)
+	require.Equal(t, errCheckpointChecksumMismatch, err)
+
+	// Truncate the element checkpoint and fix up the checksum.
+	truncated := newCheckpointEncoder(testShard, 1234)
+	truncated.buf = append(truncated.buf, data[len(truncated.buf):len(data)-checkpointChecksumLen-4]...)
+	dec, err := newCheckpointDecoder(truncated.Bytes())
+	require.NoError(t, err)
+	require.False(t, dec.Next())
+	require.Equal(t, errInvalidCheckpoint, dec.Err())
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoint")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileCheckpointStore(dir)
+	require.NoError(t, err)
+
+	_, err = store.Read(testShard)
+	require.Equal(t, ErrCheckpointNotFound, err)
+
+	require.NoError(t, store.Write(testShard, []byte("foo")))
+	data, err := store.Read(testShard)
+	require.NoError(t, err)
+	require.Equal(t, []byte("foo"), data)
+
+	require.NoError(t, store.Write(testShard, []byte("barbaz")))
+	data, err = store.Read(testShard)
+	require.NoError(t, err)
+	require.Equal(t, []byte("barbaz"), data)
+
+	_, err = store.Read(testShard + 1)
+	require.Equal(t, ErrCheckpointNotFound, err)
+
+	// Only the checkpoint files are left behind.
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(files))
+}
+
+func TestKVCheckpointStore(t *testing.T) {
+	kvStore := mem.NewStore()
+	store := NewKVCheckpointStore(kvStore, "checkpoint/shard/%d", 4)
+	require.True(t, store.Shared())
+
+	_, err := store.Read(testShard)
+	require.Equal(t, ErrCheckpointNotFound, err)
+
+	require.NoError(t, store.Write(testShard, []byte{0, 1, 0xff}))
+	data, err := store.Read(testShard)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 1, 0xff}, data)
+
+	// Checkpoints larger than the chunk size are split across keys.
+	require.NoError(t, store.Write(testShard, []byte("foobarbaz")))
+	data, err = store.Read(testShard)
+	require.NoError(t, err)
+	require.Equal(t, []byte("foobarbaz"), data)
+
+	chunkKeys, _, err := store.(*kvCheckpointStore).chunkKeys("checkpoint/shard/0")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"checkpoint/shard/0/2/0",
+		"checkpoint/shard/0/2/1",
+		"checkpoint/shard/0/2/2",
+	}, chunkKeys)
+
+	// The chunks of previous checkpoints are deleted.
+	_, err = kvStore.Get("checkpoint/shard/0/1/0")
+	require.Equal(t, kv.ErrNotFound, err)
+
+	_, err = store.Read(testShard + 1)
+	require.Equal(t, ErrCheckpointNotFound, err)
+}
+
+func TestMetricMapCheckpointRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Unix(1000, 0)
+	opts := testOptions(ctrl).
+		SetClockOptions(clock.NewOptions().SetNowFn(func() time.Time { return now }))
+	m := newMetricMap(testShard, opts)
+	require.NoError(t, m.AddUntimed(testCounter, testDefaultStagedMetadatas))
+	require.NoError(t, m.AddForwarded(testForwardedMetric, testForwardMetadata))
+	require.NoError(t, m.AddUntimed(testBatchTimer, testDefaultStagedMetadatas))
+
+	enc := newCheckpointEncoder(testShard, now.UnixNano())
+	require.NoError(t, m.Checkpoint(enc))
+	dec, err := newCheckpointDecoder(enc.Bytes())
+	require.NoError(t, err)
+
+	restored := newMetricMap(testShard, opts)
+	numRestored, err := restored.Restore(dec, false)
+	require.NoError(t, err)
+	require.Equal(t, 5, numRestored)
+	require.Equal(t, 3, len(restored.entries))
+	requireEqualCheckpoints(t, metricMapCheckpoints(m), metricMapCheckpoints(restored))
+
+	// Subsequent writes are aggregated into the restored elements.
+	require.NoError(t, restored.AddUntimed(testCounter, testDefaultStagedMetadatas))
+	entry := restored.entries[entryKey{
+		metricCategory: untimedMetric,
+		metricType:     metric.CounterType,
+		idHash:         hash.Murmur3Hash128(testCounterID),
+	}].Value.(hashedEntry).entry
+	require.Equal(t, 2, len(entry.aggregations))
+	for _, val := range entry.aggregations {
+		elem := val.elem.Value.(*CounterElem)
+		require.Equal(t, 1, len(elem.values))
+		require.Equal(t, int64(2), elem.values[0].lockedAgg.aggregation.Count())
+	}
+
+	// Forwarded writes from sources already seen are deduplicated.
+	require.NoError(t, restored.AddForwarded(testForwardedMetric, testForwardMetadata))
+	entry = restored.entries[entryKey{
+		metricCategory: forwardedMetric,
+		metricType:     metric.CounterType,
+		idHash:         hash.Murmur3Hash128(testForwardedMetric.ID),
+	}].Value.(hashedEntry).entry
+	require.Equal(t, 1, len(entry.aggregations))
+	elem := entry.aggregations[0].elem.Value.(*CounterElem)
+	require.Equal(t, int64(2), elem.values[0].lockedAgg.aggregation.Count())
+}
+
+func metricMapCheckpoints(m *metricMap) []elemCheckpoint {
+	var checkpoints []elemCheckpoint
+	m.forEachEntry(func(entry hashedEntry) {
+		checkpoints = entry.entry.appendCheckpoints(checkpoints, entry.key.metricCategory)
+	})
+	return checkpoints
+}
+
+func requireEqualCheckpoints(t *testing.T, expected, actual []elemCheckpoint) {
+	require.Equal(t, len(expected), len(actual))
+	for i := range expected {
+		require.Equal(t, expected[i].metricCategory, actual[i].metricCategory)
+		require.Equal(t, expected[i].metricType, actual[i].metricType)
+		require.Equal(t, expected[i].id, actual[i].id)
+		require.True(t, expected[i].key.Equal(actual[i].key))
+		require.Equal(t, expected[i].listType, actual[i].listType)
+		require.Equal(t, len(expected[i].state.windows), len(actual[i].state.windows))
+		for j, window := range expected[i].state.windows {
+			other := actual[i].state.windows[j]
+			require.Equal(t, window.startAtNanos, other.startAtNanos)
+			require.Equal(t, window.lastAtNanos, other.lastAtNanos)
+			require.Equal(t, window.values, other.values)
+			if window.sourcesSeen == nil {
+				require.Nil(t, other.sourcesSeen)
+			} else {
+				require.True(t, window.sourcesSeen.Equal(other.sourcesSeen))
+			}
+		}
+	}
+}
+
+func TestMetricMapCheckpointRestoreMissingOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		start = time.Unix(1000, 0)
+		end   = start.Add(time.Hour)
+		now   = start
+	)
+	opts := testOptions(ctrl).
+		SetClockOptions(clock.NewOptions().SetNowFn(func() time.Time { return now }))
+
+	// The previous leader has aggregated the counter in two windows.
+	m := newMetricMap(testShard, opts)
+	require.NoError(t, m.AddUntimed(testCounter, testDefaultStagedMetadatas))
+	now = end
+	require.NoError(t, m.AddUntimed(testCounter, testDefaultStagedMetadatas))
+	enc := newCheckpointEncoder(testShard, now.UnixNano())
+	require.NoError(t, m.Checkpoint(enc))
+	data := enc.Bytes()
+
+	// The follower has only aggregated the counter in the later window.
+	follower := newMetricMap(testShard, opts)
+	require.NoError(t, follower.AddUntimed(testCounter, testDefaultStagedMetadatas))
+	dec, err := newCheckpointDecoder(data)
+	require.NoError(t, err)
+	_, err = follower.Restore(dec, true)
+	require.NoError(t, err)
+
+	// The missing window is restored and the existing one is not double counted.
+	entry := metricMapCounterEntry(follower)
+	require.True(t, len(entry.aggregations) > 0)
+	for _, val := range entry.aggregations {
+		elem := val.elem.Value.(*CounterElem)
+		require.Equal(t, 2, len(elem.values))
+		for _, v := range elem.values {
+			require.Equal(t, int64(1), v.lockedAgg.aggregation.Count())
+		}
+	}
+
+	// Windows the follower has already flushed are not restored.
+	flushed := newMetricMap(testShard, opts)
+	require.NoError(t, flushed.AddUntimed(testCounter, testDefaultStagedMetadatas))
+	for _, l := range flushed.metricLists.lists {
+		l.(*standardMetricList).lastFlushedNanos = end.UnixNano()
+	}
+	dec, err = newCheckpointDecoder(data)
+	require.NoError(t, err)
+	_, err = flushed.Restore(dec, true)
+	require.NoError(t, err)
+	entry = metricMapCounterEntry(flushed)
+	for _, val := range entry.aggregations {
+		elem := val.elem.Value.(*CounterElem)
+		require.Equal(t, 1, len(elem.values))
+		require.Equal(t, int64(1), elem.values[0].lockedAgg.aggregation.Count())
+	}
+}
+
+func metricMapCounterEntry(m *metricMap) *Entry {
+	return m.entries[entryKey{
+		metricCategory: untimedMetric,
+		metricType:     metric.CounterType,
+		idHash:         hash.Murmur3Hash128(testCounterID),
+	}].Value.(hashedEntry).entry
+}
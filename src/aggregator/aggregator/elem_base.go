@@ -61,6 +61,8 @@ var (
 	errElemClosed                = errors.New("element is closed")
 	errAggregationClosed         = errors.New("aggregation is closed")
 	errDuplicateForwardingSource = errors.New("duplicate forwarding source")
+
+	errLateAggregationNotRetained = errors.New("aggregation for late value is not retained")
 )
 
 // isEarlierThanFn determines whether the timestamps of the metrics in a given
@@ -129,6 +131,14 @@ type metricElem interface {
 	// same aggregation, the incoming value is discarded.
 	AddUnique(timestamp time.Time, values []float64, sourceID uint32) error
 
+	// SetAllowedLateness sets how long aggregations are retained after they
+	// are consumed so that late values can be added to them.
+	SetAllowedLateness(value time.Duration)
+
+	// AddLateValue adds a metric value at a given timestamp whose aggregation
+	// may have been consumed, in which case the aggregation is consumed again.
+	AddLateValue(timestamp time.Time, value float64) error
+
 	// Consume consumes values before a given time and removes
 	// them from the element after they are consumed, returning whether
 	// the element can be collected after the consumption is completed.
@@ -145,6 +155,13 @@ type metricElem interface {
 	// will be deleted once its aggregated values have been flushed.
 	MarkAsTombstoned()
 
+	// Checkpoint returns the state of the aggregation windows yet to be
+	// consumed, returning false if the element is closed or tombstoned.
+	Checkpoint() (elemState, bool)
+
+	// Restore restores a checkpointed state into the element.
+	Restore(state elemState, opts restoreOptions) error
+
 	// Close closes the element.
 	Close()
 }
@@ -314,6 +331,9 @@ func (e timerElemBase) TypeStringFor(aggTypesOpts maggregation.TypesOptions, agg
 func (e timerElemBase) ElemPool(opts Options) TimerElemPool { return opts.TimerElemPool() }
 
 func (e timerElemBase) NewAggregation(opts Options, aggOpts raggregation.Options) timerAggregation {
+	if opts.TimerQuantileSketchType() == raggregation.DDSketchQuantileSketch {
+		return newTimerAggregation(raggregation.NewSketchTimer(opts.DDSketchOptions(), aggOpts))
+	}
 	newTimer := raggregation.NewTimer(e.quantiles, opts.StreamOptions(), aggOpts)
 	return newTimerAggregation(newTimer)
 }
@@ -386,6 +406,39 @@ func (e *gaugeElemBase) ResetSetData(
 
 func (e *gaugeElemBase) Close() {}
 
+type histogramElemBase struct{}
+
+func (e histogramElemBase) Type() metric.Type { return metric.HistogramType }
+
+func (e histogramElemBase) FullPrefix(opts Options) []byte { return opts.FullHistogramPrefix() }
+
+func (e histogramElemBase) DefaultAggregationTypes(aggTypesOpts maggregation.TypesOptions) maggregation.Types {
+	return aggTypesOpts.DefaultHistogramAggregationTypes()
+}
+
+func (e histogramElemBase) TypeStringFor(aggTypesOpts maggregation.TypesOptions, aggType maggregation.Type) []byte {
+	return aggTypesOpts.TypeStringForHistogram(aggType)
+}
+
+func (e histogramElemBase) ElemPool(opts Options) HistogramElemPool { return opts.HistogramElemPool() }
+
+func (e histogramElemBase) NewAggregation(opts Options, aggOpts raggregation.Options) histogramAggregation {
+	return newHistogramAggregation(raggregation.NewHistogram(opts.HistogramOptions(), aggOpts))
+}
+
+func (e *histogramElemBase) ResetSetData(
+	_ maggregation.TypesOptions,
+	aggTypes maggregation.Types,
+	_ bool,
+) error {
+	if !aggTypes.IsValidForHistogram() {
+		return fmt.Errorf("invalid aggregation types %s for histogram", aggTypes.String())
+	}
+	return nil
+}
+
+func (e *histogramElemBase) Close() {}
+
 // nolint: maligned
 type parsedPipeline struct {
 	// Whether the source pipeline contains derivative transformations at its head.
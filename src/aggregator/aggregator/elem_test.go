@@ -28,6 +28,7 @@ import (
 	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
 	maggregation "github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/histogram"
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/metric/id"
 	"github.com/m3db/m3/src/metrics/metric/unaggregated"
@@ -46,6 +47,7 @@ var (
 	testCounterID                 = id.RawID("testCounter")
 	testBatchTimerID              = id.RawID("testBatchTimer")
 	testGaugeID                   = id.RawID("testGauge")
+	testHistogramID               = id.RawID("testHistogram")
 	testStoragePolicy             = policy.NewStoragePolicy(10*time.Second, xtime.Second, 6*time.Hour)
 	testAggregationTypes          = maggregation.Types{maggregation.Mean, maggregation.Sum}
 	testAggregationTypesExpensive = maggregation.Types{maggregation.SumSq}
@@ -65,6 +67,17 @@ var (
 		ID:       testGaugeID,
 		GaugeVal: 123.456,
 	}
+	testHistogram = unaggregated.MetricUnion{
+		Type: metric.HistogramType,
+		ID:   testHistogramID,
+		HistogramVal: histogram.Histogram{
+			Schema:          3,
+			ZeroCount:       1,
+			Count:           4,
+			Sum:             6.5,
+			PositiveBuckets: []histogram.Bucket{{Index: 1, Count: 2}, {Index: 8, Count: 1}},
+		},
+	}
 	testPipeline = applied.NewPipeline([]applied.OpUnion{
 		{
 			Type:           pipeline.TransformationOpType,
@@ -602,6 +615,79 @@ func TestCounterElemClose(t *testing.T) {
 	require.NotNil(t, e.values)
 }
 
+func TestCounterElemAddLateValue(t *testing.T) {
+	alignedstartAtNanos := []int64{
+		time.Unix(210, 0).UnixNano(),
+		time.Unix(220, 0).UnixNano(),
+		time.Unix(230, 0).UnixNano(),
+		time.Unix(240, 0).UnixNano(),
+	}
+	isEarlierThanFn := isStandardMetricEarlierThan
+	timestampNanosFn := standardMetricTimestampNanos
+	e := testCounterElem(alignedstartAtNanos[:2], []int64{5, 10}, maggregation.DefaultTypes, applied.DefaultPipeline, NewOptions())
+	e.SetAllowedLateness(time.Minute)
+
+	// Consuming the aggregations retains them for late values.
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, _ := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(alignedstartAtNanos[2], isEarlierThanFn, timestampNanosFn, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 2, len(*localRes))
+	require.Equal(t, 0, len(e.values))
+	require.Equal(t, 2, len(e.consumed))
+	require.Equal(t, alignedstartAtNanos[2], e.lateSinceNanos)
+
+	// Late values reopen retained aggregations, and only create aggregations
+	// that had no values when they were consumed.
+	require.NoError(t, e.AddLateValue(time.Unix(215, 0), 3))
+	require.NoError(t, e.AddLateValue(time.Unix(235, 0), 7))
+	require.Equal(t, errLateAggregationNotRetained, e.AddLateValue(time.Unix(205, 0), 1))
+	require.Equal(t, 2, len(e.values))
+	require.Equal(t, 1, len(e.consumed))
+
+	// The corrected aggregation is consumed again.
+	localFn, localRes = testFlushLocalMetricFn()
+	require.False(t, e.Consume(alignedstartAtNanos[3]+int64(10*time.Second), isEarlierThanFn, timestampNanosFn, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 2, len(*localRes))
+	require.Equal(t, alignedstartAtNanos[1], (*localRes)[0].timeNanos)
+	require.Equal(t, 8.0, (*localRes)[0].value)
+	require.Equal(t, alignedstartAtNanos[3], (*localRes)[1].timeNanos)
+	require.Equal(t, 7.0, (*localRes)[1].value)
+	require.Equal(t, 0, len(e.values))
+	require.Equal(t, 3, len(e.consumed))
+	for i := 1; i < len(e.consumed); i++ {
+		require.True(t, e.consumed[i-1].startAtNanos < e.consumed[i].startAtNanos)
+	}
+
+	// Aggregations are expired once they are past the allowed lateness.
+	localFn, localRes = testFlushLocalMetricFn()
+	require.False(t, e.Consume(time.Unix(300, 0).UnixNano(), isEarlierThanFn, timestampNanosFn, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 0, len(*localRes))
+	require.Equal(t, 0, len(e.consumed))
+	require.Equal(t, errLateAggregationNotRetained, e.AddLateValue(time.Unix(215, 0), 3))
+
+	e.Close()
+	require.Equal(t, 0, len(e.consumed))
+	require.Equal(t, errElemClosed, e.AddLateValue(time.Unix(295, 0), 3))
+}
+
+func TestCounterElemAddLateValueNotAllowed(t *testing.T) {
+	alignedstartAtNanos := []int64{
+		time.Unix(210, 0).UnixNano(),
+		time.Unix(220, 0).UnixNano(),
+	}
+	e := testCounterElem(alignedstartAtNanos[:1], []int64{5}, maggregation.DefaultTypes, applied.DefaultPipeline, NewOptions())
+
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, _ := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(alignedstartAtNanos[1], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 1, len(*localRes))
+	require.Equal(t, 0, len(e.consumed))
+	require.Equal(t, errLateAggregationNotRetained, e.AddLateValue(time.Unix(215, 0), 3))
+	require.Equal(t, errLateAggregationNotRetained, e.AddLateValue(time.Unix(225, 0), 3))
+}
+
 func TestCounterFindOrCreateNoSourceSet(t *testing.T) {
 	e, err := NewCounterElem(testCounterID, testStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes, NoPrefixNoSuffix, NewOptions())
 	require.NoError(t, err)
@@ -1106,6 +1192,49 @@ func TestTimerElemClose(t *testing.T) {
 	verifyStreamPoolSize(t, p, len(testAlignedStarts)-1, numAlloc)
 }
 
+func TestTimerElemSketchForwardsAndMergesTimers(t *testing.T) {
+	opts := NewOptions().SetTimerQuantileSketchType(raggregation.DDSketchQuantileSketch)
+	rollupPipeline := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type: pipeline.RollupOpType,
+			Rollup: applied.RollupOp{
+				ID:            []byte("foo.bar"),
+				AggregationID: maggregation.MustCompressTypes(maggregation.P99),
+			},
+		},
+	})
+	e := MustNewTimerElem(testBatchTimerID, testStoragePolicy, maggregation.DefaultTypes, rollupPipeline, testNumForwardedTimes, WithPrefixWithSuffix, opts)
+	require.NoError(t, e.AddUnion(testTimestamps[0], testBatchTimer))
+
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(testAlignedStarts[1], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 0, len(*localRes))
+
+	// The timer is forwarded as a whole so the next aggregation can merge it.
+	var forwarded []float64
+	for _, res := range *forwardRes {
+		require.Equal(t, testAlignedStarts[1], res.timeNanos)
+		forwarded = append(forwarded, res.value)
+	}
+	decoded := raggregation.NewSketchTimer(opts.DDSketchOptions(), raggregation.NewOptions(opts.InstrumentOptions()))
+	require.NoError(t, decoded.MergeFloats(testTimestamps[0], forwarded))
+	require.Equal(t, int64(len(testBatchTimer.BatchTimerVal)), decoded.Count())
+	require.Equal(t, 1.0, decoded.Min())
+	require.Equal(t, 6.5, decoded.Max())
+
+	// Timers forwarded by multiple sources are merged.
+	rollup := MustNewTimerElem(id.RawID("foo.bar"), testStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes+1, WithPrefixWithSuffix, opts)
+	require.NoError(t, rollup.AddUnique(testTimestamps[0], forwarded, 1))
+	require.NoError(t, rollup.AddUnique(testTimestamps[0], forwarded, 2))
+	require.Equal(t, 1, len(rollup.values))
+	timer := rollup.values[0].lockedAgg.aggregation
+	require.Equal(t, int64(2*len(testBatchTimer.BatchTimerVal)), timer.Count())
+	require.InEpsilon(t, 2*decoded.Sum(), timer.Sum(), 1e-10)
+	require.Equal(t, decoded.Quantile(0.99), timer.Quantile(0.99))
+}
+
 func TestTimerFindOrCreateNoSourceSet(t *testing.T) {
 	e, err := NewTimerElem(testBatchTimerID, testStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes, NoPrefixNoSuffix, NewOptions())
 	require.NoError(t, err)
@@ -1703,6 +1832,87 @@ func TestGaugeElemReset(t *testing.T) {
 	require.Equal(t, 0, len(e.values))
 }
 
+func TestGaugeElemCumulativeToDelta(t *testing.T) {
+	alignedstartAtNanos := []int64{
+		time.Unix(210, 0).UnixNano(),
+		time.Unix(220, 0).UnixNano(),
+		time.Unix(230, 0).UnixNano(),
+		time.Unix(240, 0).UnixNano(),
+		time.Unix(250, 0).UnixNano(),
+	}
+	gaugeVals := []float64{10.0, 25.0, 5.0, 12.0}
+	aggregationTypes := maggregation.Types{maggregation.Last}
+	isEarlierThanFn := isStandardMetricEarlierThan
+	timestampNanosFn := standardMetricTimestampNanos
+	opts := NewOptions()
+
+	testPipeline := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type:           pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{Type: transformation.CumulativeToDelta},
+		},
+	})
+
+	e := testGaugeElem(alignedstartAtNanos[:4], gaugeVals, aggregationTypes, testPipeline, opts)
+
+	// The first value has no delta, the third value is lower than the second
+	// value so the counter is treated as reset.
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, onForwardedFlushedRes := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(alignedstartAtNanos[4], isEarlierThanFn, timestampNanosFn, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 3, len(*localRes))
+	require.Equal(t, alignedstartAtNanos[2], (*localRes)[0].timeNanos)
+	require.Equal(t, 15.0, (*localRes)[0].value)
+	require.Equal(t, alignedstartAtNanos[3], (*localRes)[1].timeNanos)
+	require.Equal(t, 5.0, (*localRes)[1].value)
+	require.Equal(t, alignedstartAtNanos[4], (*localRes)[2].timeNanos)
+	require.Equal(t, 7.0, (*localRes)[2].value)
+	require.Equal(t, 0, len(*forwardRes))
+	require.Equal(t, 0, len(*onForwardedFlushedRes))
+	require.Equal(t, 0, len(e.values))
+	require.Equal(t, transformation.Datapoint{TimeNanos: alignedstartAtNanos[4], Value: 12.0}, e.lastConsumedValues[0])
+}
+
+func TestGaugeElemDeltaToCumulative(t *testing.T) {
+	alignedstartAtNanos := []int64{
+		time.Unix(210, 0).UnixNano(),
+		time.Unix(220, 0).UnixNano(),
+		time.Unix(230, 0).UnixNano(),
+		time.Unix(240, 0).UnixNano(),
+	}
+	gaugeVals := []float64{3.0, 4.0, 5.0}
+	aggregationTypes := maggregation.Types{maggregation.Sum}
+	isEarlierThanFn := isStandardMetricEarlierThan
+	timestampNanosFn := standardMetricTimestampNanos
+	// NB: unlike add, the conversion is not rewritten to a reset.
+	opts := NewOptions().SetAddToReset(true)
+
+	testPipeline := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type:           pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{Type: transformation.DeltaToCumulative},
+		},
+	})
+
+	e := testGaugeElem(alignedstartAtNanos[:3], gaugeVals, aggregationTypes, testPipeline, opts)
+
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, onForwardedFlushedRes := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(alignedstartAtNanos[3], isEarlierThanFn, timestampNanosFn, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 3, len(*localRes))
+	require.Equal(t, alignedstartAtNanos[1], (*localRes)[0].timeNanos)
+	require.Equal(t, 3.0, (*localRes)[0].value)
+	require.Equal(t, alignedstartAtNanos[2], (*localRes)[1].timeNanos)
+	require.Equal(t, 7.0, (*localRes)[1].value)
+	require.Equal(t, alignedstartAtNanos[3], (*localRes)[2].timeNanos)
+	require.Equal(t, 12.0, (*localRes)[2].value)
+	require.Equal(t, 0, len(*forwardRes))
+	require.Equal(t, 0, len(*onForwardedFlushedRes))
+	require.Equal(t, 0, len(e.values))
+}
+
 func TestGaugeElemClose(t *testing.T) {
 	e := testGaugeElem(testAlignedStarts[:len(testAlignedStarts)-1], testGaugeVals, maggregation.DefaultTypes, applied.DefaultPipeline, NewOptions())
 	require.False(t, e.closed)
@@ -1773,6 +1983,93 @@ func TestGaugeFindOrCreateWithSourceSet(t *testing.T) {
 	require.Equal(t, 0, len(e.cachedSourceSets))
 }
 
+func TestHistogramResetSetDataInvalidAggregationType(t *testing.T) {
+	opts := NewOptions()
+	e := MustNewHistogramElem(nil, policy.EmptyStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes, NoPrefixNoSuffix, opts)
+	err := e.ResetSetData(testHistogramID, testStoragePolicy, maggregation.Types{maggregation.Last}, applied.DefaultPipeline, 0, NoPrefixNoSuffix)
+	require.Error(t, err)
+}
+
+func TestHistogramElemAddUnion(t *testing.T) {
+	e, err := NewHistogramElem(testHistogramID, testStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes, NoPrefixNoSuffix, NewOptions())
+	require.NoError(t, err)
+
+	// Add a histogram metric.
+	require.NoError(t, e.AddUnion(testTimestamps[0], testHistogram))
+	require.Equal(t, 1, len(e.values))
+	require.Equal(t, testAlignedStarts[0], e.values[0].startAtNanos)
+	require.Equal(t, uint64(4), e.values[0].lockedAgg.aggregation.Count())
+	require.Equal(t, 6.5, e.values[0].lockedAgg.aggregation.Sum())
+
+	// Add the histogram metric at slightly different time
+	// but still within the same aggregation interval.
+	require.NoError(t, e.AddUnion(testTimestamps[1], testHistogram))
+	require.Equal(t, 1, len(e.values))
+	require.Equal(t, uint64(8), e.values[0].lockedAgg.aggregation.Count())
+	require.Equal(t, 13.0, e.values[0].lockedAgg.aggregation.Sum())
+	require.Equal(t, []histogram.Bucket{{Index: 1, Count: 4}, {Index: 8, Count: 2}},
+		e.values[0].lockedAgg.aggregation.Histogram().PositiveBuckets)
+
+	// Add the histogram metric in the next aggregation interval.
+	require.NoError(t, e.AddUnion(testTimestamps[2], testHistogram))
+	require.Equal(t, 2, len(e.values))
+	require.Equal(t, testAlignedStarts[1], e.values[1].startAtNanos)
+	require.Equal(t, uint64(4), e.values[1].lockedAgg.aggregation.Count())
+
+	// Adding the histogram metric to a closed element results in an error.
+	e.closed = true
+	require.Equal(t, errElemClosed, e.AddUnion(testTimestamps[2], testHistogram))
+}
+
+func TestHistogramElemConsumeDefaultAggregationDefaultPipeline(t *testing.T) {
+	isEarlierThanFn := isStandardMetricEarlierThan
+	timestampNanosFn := standardMetricTimestampNanos
+	opts := NewOptions()
+	e, err := NewHistogramElem(testHistogramID, testStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes, WithPrefixWithSuffix, opts)
+	require.NoError(t, err)
+	require.NoError(t, e.AddUnion(testTimestamps[0], testHistogram))
+
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, onForwardedFlushedRes := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(testAlignedStarts[1], isEarlierThanFn, timestampNanosFn, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 0, len(*forwardRes))
+	require.Equal(t, 0, len(*onForwardedFlushedRes))
+	require.Equal(t, 0, len(e.values))
+
+	// The count and the sum are followed by a series per cumulative bucket.
+	expected := []testLocalMetricWithMetadata{
+		{
+			idPrefix:  []byte("stats.histograms."),
+			id:        testHistogramID,
+			idSuffix:  []byte(".count"),
+			timeNanos: testAlignedStarts[1],
+			value:     4,
+			sp:        testStoragePolicy,
+		},
+		{
+			idPrefix:  []byte("stats.histograms."),
+			id:        testHistogramID,
+			idSuffix:  []byte(".sum"),
+			timeNanos: testAlignedStarts[1],
+			value:     6.5,
+			sp:        testStoragePolicy,
+		},
+	}
+	for _, b := range testHistogram.HistogramVal.AppendCumulativeBuckets(nil) {
+		expected = append(expected, testLocalMetricWithMetadata{
+			idPrefix:  []byte("stats.histograms."),
+			id:        testHistogramID,
+			idSuffix:  histogram.AppendBucketSuffix(nil, []byte(".bucket"), b.UpperBound),
+			timeNanos: testAlignedStarts[1],
+			value:     float64(b.Count),
+			sp:        testStoragePolicy,
+		})
+	}
+	require.Equal(t, expected, *localRes)
+	require.Equal(t, "+Inf", string((*localRes)[len(*localRes)-1].idSuffix[len(".bucket.le="):]))
+}
+
 type testIndexData struct {
 	index int
 	data  []int64
@@ -59,6 +59,7 @@ var (
 	errTooFarInTheFuture           = xerrors.NewInvalidParamsError(errors.New("too far in the future"))
 	errTooFarInThePast             = xerrors.NewInvalidParamsError(errors.New("too far in the past"))
 	errArrivedTooLate              = xerrors.NewInvalidParamsError(errors.New("arrived too late"))
+	errLateWindowNotRetained       = xerrors.NewInvalidParamsError(errors.New("late window is not retained"))
 	errTimestampFormat             = time.RFC822Z
 )
 
@@ -108,6 +109,9 @@ type timedEntryMetrics struct {
 	tombstonedMetadata    tally.Counter
 	metadataUpdates       tally.Counter
 	metadatasUpdates      tally.Counter
+	lateReaggregated      tally.Counter
+	lateSideOutput        tally.Counter
+	lateDropped           tally.Counter
 }
 
 func newTimedEntryMetrics(scope tally.Scope) timedEntryMetrics {
@@ -119,6 +123,9 @@ func newTimedEntryMetrics(scope tally.Scope) timedEntryMetrics {
 		tombstonedMetadata:    scope.Counter("tombstoned-metadata"),
 		metadataUpdates:       scope.Counter("metadata-updates"),
 		metadatasUpdates:      scope.Counter("metadatas-updates"),
+		lateReaggregated:      scope.Counter("late-reaggregated"),
+		lateSideOutput:        scope.Counter("late-side-output"),
+		lateDropped:           scope.Counter("late-dropped"),
 	}
 }
 
@@ -245,7 +252,7 @@ func (e *Entry) AddUntimed(
 		}
 		return err
 	default:
-		// For counters and gauges, there is a single value in the metric union.
+		// For counters, gauges and histograms, there is a single value in the metric union.
 		if err := e.applyValueRateLimit(1, e.metrics.untimed.rateLimit); err != nil {
 			return err
 		}
@@ -557,6 +564,8 @@ func (e *Entry) addNewAggregationKeyWithLock(
 		newElem = e.opts.TimerElemPool().Get()
 	case metric.GaugeType:
 		newElem = e.opts.GaugeElemPool().Get()
+	case metric.HistogramType:
+		newElem = e.opts.HistogramElemPool().Get()
 	default:
 		return nil, errInvalidMetricType
 	}
@@ -573,7 +582,7 @@ func (e *Entry) addNewAggregationKeyWithLock(
 	if err != nil {
 		return nil, err
 	}
-	newAggregations = append(newAggregations, aggregationValue{key: key, elem: newListElem})
+	newAggregations = append(newAggregations, aggregationValue{key: key, listID: listID, elem: newListElem})
 	return newAggregations, nil
 }
 
@@ -662,6 +671,15 @@ func (e *Entry) addTimed(
 		return errEntryClosed
 	}
 
+	// Datapoints with timed metadata that arrive late are handled according to
+	// the late timed metric policy as long as they are within the allowed lateness.
+	if len(stagedMetadatas) == 0 {
+		action, late := e.lateTimedMetricActionWithLock(metric, currTime.UnixNano(), metadata)
+		if late {
+			return e.addLateTimedWithLock(metric, metadata, action)
+		}
+	}
+
 	// Reject datapoints that arrive too late or too early.
 	if err := e.checkTimestampForTimedMetric(
 		metric,
@@ -826,6 +844,92 @@ func (e *Entry) checkTimestampForTimedMetric(
 	return nil
 }
 
+// lateTimedMetricActionWithLock returns the action for a datapoint with timed
+// metadata arriving after the buffer for past timed metrics, and whether the
+// datapoint is within the allowed lateness so that it should be handled by the
+// action rather than rejected.
+func (e *Entry) lateTimedMetricActionWithLock(
+	metric aggregated.Metric,
+	currNanos int64,
+	metadata metadata.TimedMetadata,
+) (LateTimedMetricAction, bool) {
+	bufferPastFn := e.opts.BufferForPastTimedMetricFn()
+	lateByNanos := currNanos - metric.TimeNanos - bufferPastFn(metadata.StoragePolicy.Resolution().Window).Nanoseconds()
+	if lateByNanos <= 0 {
+		return DropLateTimedMetric, false
+	}
+	key := aggregationKey{
+		aggregationID:      metadata.AggregationID,
+		storagePolicy:      metadata.StoragePolicy,
+		idPrefixSuffixType: NoPrefixNoSuffix,
+	}
+	latePolicy := e.lateTimedMetricPolicy(metric.ID, key)
+	if latePolicy.Action == DropLateTimedMetric || lateByNanos > latePolicy.AllowedLateness.Nanoseconds() {
+		return DropLateTimedMetric, false
+	}
+	return latePolicy.Action, true
+}
+
+// addLateTimedWithLock handles a late datapoint with the given action, releasing
+// the entry lock and the time lock before returning.
+func (e *Entry) addLateTimedWithLock(
+	metric aggregated.Metric,
+	metadata metadata.TimedMetadata,
+	action LateTimedMetricAction,
+) error {
+	timeLock := e.opts.TimeLock()
+	if action == SideOutputLateTimedMetric {
+		e.RUnlock()
+		timeLock.RUnlock()
+
+		// Only the leader writes late datapoints so they are not duplicated.
+		if e.opts.ElectionManager().ElectionState() == FollowerState {
+			return nil
+		}
+		mp := aggregated.ChunkedMetricWithStoragePolicy{
+			ChunkedMetric: aggregated.ChunkedMetric{
+				ChunkedID: id.ChunkedID{
+					Data: []byte(metric.ID),
+				},
+				TimeNanos: metric.TimeNanos,
+				Value:     metric.Value,
+			},
+			StoragePolicy: metadata.StoragePolicy,
+		}
+		if err := e.opts.LateTimedMetricWriter().Write(mp); err != nil {
+			return err
+		}
+		e.metrics.timed.lateSideOutput.Inc(1)
+		return nil
+	}
+
+	key := aggregationKey{
+		aggregationID:      metadata.AggregationID,
+		storagePolicy:      metadata.StoragePolicy,
+		idPrefixSuffixType: NoPrefixNoSuffix,
+	}
+	var err error
+	if idx := e.aggregations.index(key); idx >= 0 {
+		timestamp := time.Unix(0, metric.TimeNanos)
+		err = e.aggregations[idx].elem.Value.(metricElem).AddLateValue(timestamp, metric.Value)
+	} else {
+		// The aggregation window cannot have been retained without an element.
+		err = errLateAggregationNotRetained
+	}
+	e.RUnlock()
+	timeLock.RUnlock()
+
+	if err == errLateAggregationNotRetained {
+		e.metrics.timed.lateDropped.Inc(1)
+		return errLateWindowNotRetained
+	}
+	if err != nil {
+		return err
+	}
+	e.metrics.timed.lateReaggregated.Inc(1)
+	return nil
+}
+
 func (e *Entry) updateTimedMetadataWithLock(
 	metric aggregated.Metric,
 	metadata metadata.TimedMetadata,
@@ -850,10 +954,40 @@ func (e *Entry) updateTimedMetadataWithLock(
 	}
 
 	e.aggregations = newAggregations
+	e.setAllowedLatenessWithLock(metric.ID, key)
 	e.metrics.timed.metadataUpdates.Inc(1)
 	return nil
 }
 
+// setAllowedLatenessWithLock retains the consumed aggregation windows of the
+// element with the given aggregation key if late datapoints are reaggregated.
+func (e *Entry) setAllowedLatenessWithLock(metricID id.RawID, key aggregationKey) {
+	latePolicy := e.lateTimedMetricPolicy(metricID, key)
+	if latePolicy.Action != ReaggregateLateTimedMetric || latePolicy.AllowedLateness <= 0 {
+		return
+	}
+	idx := e.aggregations.index(key)
+	e.aggregations[idx].elem.Value.(metricElem).SetAllowedLateness(latePolicy.AllowedLateness)
+}
+
+// lateTimedMetricPolicy returns the late timed metric policy of the element
+// with the given aggregation key. Late datapoints are side output instead of
+// reaggregated if the pipeline of the element computes derivatives or rolls up,
+// since consuming a reopened aggregation window again would compute the
+// derivatives against the wrong previous value and forward the rolled up
+// value a second time.
+func (e *Entry) lateTimedMetricPolicy(metricID id.RawID, key aggregationKey) LateTimedMetricPolicy {
+	latePolicy := e.opts.LateTimedMetricPolicyFn()(metricID, key.storagePolicy)
+	if latePolicy.Action != ReaggregateLateTimedMetric {
+		return latePolicy
+	}
+	parsed, err := newParsedPipeline(key.pipeline)
+	if err != nil || parsed.HasDerivativeTransform || parsed.HasRollup {
+		latePolicy.Action = SideOutputLateTimedMetric
+	}
+	return latePolicy
+}
+
 func (e *Entry) addTimedWithLock(
 	value aggregationValue,
 	metric aggregated.Metric,
@@ -1032,6 +1166,78 @@ func (e *Entry) addForwardedWithLock(
 	return err
 }
 
+// appendCheckpoints appends the checkpoints of the aggregation elements of the
+// entry to dst.
+func (e *Entry) appendCheckpoints(
+	dst []elemCheckpoint,
+	category metricCategory,
+) []elemCheckpoint {
+	e.RLock()
+	defer e.RUnlock()
+
+	if e.closed {
+		return dst
+	}
+	for _, val := range e.aggregations {
+		elem := val.elem.Value.(metricElem)
+		state, ok := elem.Checkpoint()
+		if !ok || len(state.windows) == 0 {
+			continue
+		}
+		dst = append(dst, elemCheckpoint{
+			metricCategory: category,
+			metricType:     elem.Type(),
+			id:             elem.ID(),
+			key:            val.key,
+			listType:       val.listID.listType,
+			state:          state,
+		})
+	}
+	return dst
+}
+
+// restore restores a checkpointed aggregation element of the entry. The
+// element is added alongside the aggregation key it was checkpointed with, so
+// that subsequent writes with the same aggregation key reuse the element. If
+// missingOnly is true only the aggregation windows that the element has
+// neither received nor flushed yet are restored.
+func (e *Entry) restore(checkpoint elemCheckpoint, missingOnly bool) error {
+	listID, err := checkpoint.listID()
+	if err != nil {
+		return err
+	}
+
+	e.Lock()
+	defer e.Unlock()
+
+	if e.closed {
+		return errEntryClosed
+	}
+	elemID := e.maybeCopyIDWithLock(checkpoint.id)
+	newAggregations, err := e.addNewAggregationKeyWithLock(
+		checkpoint.metricType, elemID, checkpoint.key, listID, e.aggregations)
+	if err != nil {
+		return err
+	}
+	e.aggregations = newAggregations
+	if listID.listType == timedMetricListType {
+		e.setAllowedLatenessWithLock(checkpoint.id, checkpoint.key)
+	}
+	var opts restoreOptions
+	if missingOnly {
+		l, err := e.lists.FindOrCreate(listID)
+		if err != nil {
+			return err
+		}
+		opts = restoreOptions{
+			missingOnly: true,
+			isFlushedFn: l.IsFlushed,
+		}
+	}
+	idx := e.aggregations.index(checkpoint.key)
+	return e.aggregations[idx].elem.Value.(metricElem).Restore(checkpoint.state, opts)
+}
+
 func (e *Entry) writerCount() int        { return int(atomic.LoadInt32(&e.numWriters)) }
 func (e *Entry) lastAccessed() time.Time { return time.Unix(0, atomic.LoadInt64(&e.lastAccessNanos)) }
 
@@ -1075,8 +1281,9 @@ func (e *Entry) applyValueRateLimit(numValues int64, m rateLimitEntryMetrics) er
 }
 
 type aggregationValue struct {
-	key  aggregationKey
-	elem *list.Element
+	key    aggregationKey
+	listID metricListID
+	elem   *list.Element
 }
 
 // TODO(xichen): benchmark the performance of using a single slice
@@ -28,10 +28,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
 	"github.com/m3db/m3/src/aggregator/runtime"
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric"
+	"github.com/m3db/m3/src/metrics/metric/aggregated"
 	"github.com/m3db/m3/src/metrics/metric/id"
 	"github.com/m3db/m3/src/metrics/metric/unaggregated"
 	"github.com/m3db/m3/src/metrics/pipeline"
@@ -1313,6 +1315,181 @@ func TestEntryAddTimedMetricTooEarly(t *testing.T) {
 	}
 }
 
+func TestEntryAddTimedLateMetricReaggregate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e, _, now := testEntry(ctrl, testEntryOptions{})
+	*now = time.Unix(600, 0)
+	e.opts = e.opts.
+		SetBufferForPastTimedMetricFn(func(resolution time.Duration) time.Duration {
+			return resolution
+		}).
+		SetLateTimedMetricPolicyFn(func(id.RawID, policy.StoragePolicy) LateTimedMetricPolicy {
+			return LateTimedMetricPolicy{
+				AllowedLateness: time.Minute,
+				Action:          ReaggregateLateTimedMetric,
+			}
+		})
+
+	// Add a timed metric and consume its aggregation.
+	metric := testTimedMetric
+	metric.TimeNanos = time.Unix(570, 0).UnixNano()
+	require.NoError(t, e.AddTimed(metric, testTimedMetadata))
+	require.Equal(t, 1, len(e.aggregations))
+	counterElem := e.aggregations[0].elem.Value.(*CounterElem)
+	require.Equal(t, time.Minute, counterElem.allowedLateness)
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, _ := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	counterElem.Consume(now.UnixNano(), isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn)
+	require.Equal(t, 1, len(*localRes))
+	require.Equal(t, 0, len(counterElem.values))
+
+	// A late timed metric within the allowed lateness reopens the aggregation.
+	*now = time.Unix(650, 0)
+	require.NoError(t, e.AddTimed(metric, testTimedMetadata))
+	require.Equal(t, 1, len(counterElem.values))
+	require.Equal(t, int64(2), counterElem.values[0].lockedAgg.aggregation.Count())
+	require.Equal(t, int64(2000), counterElem.values[0].lockedAgg.aggregation.Sum())
+
+	// A late timed metric for an aggregation that is not retained is rejected.
+	metric.TimeNanos = time.Unix(530, 0).UnixNano()
+	err := e.AddTimed(metric, testTimedMetadata)
+	require.True(t, xerrors.IsInvalidParams(err))
+	require.Equal(t, errLateWindowNotRetained, err)
+
+	// A timed metric later than the allowed lateness is rejected.
+	metric.TimeNanos = time.Unix(520, 0).UnixNano()
+	err = e.AddTimed(metric, testTimedMetadata)
+	require.True(t, xerrors.IsInvalidParams(err))
+	require.Equal(t, errTooFarInThePast, err)
+}
+
+func TestEntryAddTimedLateMetricSideOutput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metric := testTimedMetric
+	metric.TimeNanos = time.Unix(530, 0).UnixNano()
+	w := writer.NewMockWriter(ctrl)
+	w.EXPECT().Write(aggregated.ChunkedMetricWithStoragePolicy{
+		ChunkedMetric: aggregated.ChunkedMetric{
+			ChunkedID: id.ChunkedID{Data: []byte(metric.ID)},
+			TimeNanos: metric.TimeNanos,
+			Value:     metric.Value,
+		},
+		StoragePolicy: testTimedMetadata.StoragePolicy,
+	}).Return(nil).Times(1)
+
+	e, _, now := testEntry(ctrl, testEntryOptions{})
+	*now = time.Unix(600, 0)
+	e.opts = e.opts.
+		SetBufferForPastTimedMetricFn(func(resolution time.Duration) time.Duration {
+			return resolution
+		}).
+		SetLateTimedMetricPolicyFn(func(id.RawID, policy.StoragePolicy) LateTimedMetricPolicy {
+			return LateTimedMetricPolicy{
+				AllowedLateness: time.Minute,
+				Action:          SideOutputLateTimedMetric,
+			}
+		}).
+		SetLateTimedMetricWriter(w)
+
+	// The late timed metric is written as is without being aggregated.
+	require.NoError(t, e.AddTimed(metric, testTimedMetadata))
+	require.Equal(t, 0, len(e.aggregations))
+
+	// Followers do not write late timed metrics.
+	electionMgr := NewMockElectionManager(ctrl)
+	electionMgr.EXPECT().ElectionState().Return(FollowerState).AnyTimes()
+	e.opts = e.opts.SetElectionManager(electionMgr)
+	require.NoError(t, e.AddTimed(metric, testTimedMetadata))
+	require.Equal(t, 0, len(e.aggregations))
+}
+
+func TestEntryLateTimedMetricPolicyPipelines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e, _, _ := testEntry(ctrl, testEntryOptions{})
+	e.opts = e.opts.
+		SetLateTimedMetricPolicyFn(func(id.RawID, policy.StoragePolicy) LateTimedMetricPolicy {
+			return LateTimedMetricPolicy{
+				AllowedLateness: time.Minute,
+				Action:          ReaggregateLateTimedMetric,
+			}
+		})
+
+	rollupOp := applied.OpUnion{
+		Type: pipeline.RollupOpType,
+		Rollup: applied.RollupOp{
+			ID:            []byte("foo.bar"),
+			AggregationID: aggregation.DefaultID,
+		},
+	}
+	inputs := []struct {
+		pipeline       applied.Pipeline
+		expectedAction LateTimedMetricAction
+	}{
+		{
+			pipeline:       applied.DefaultPipeline,
+			expectedAction: ReaggregateLateTimedMetric,
+		},
+		{
+			pipeline:       applied.NewPipeline([]applied.OpUnion{rollupOp}),
+			expectedAction: SideOutputLateTimedMetric,
+		},
+		{
+			pipeline: applied.NewPipeline([]applied.OpUnion{
+				{
+					Type:           pipeline.TransformationOpType,
+					Transformation: pipeline.TransformationOp{Type: transformation.Absolute},
+				},
+				rollupOp,
+			}),
+			expectedAction: SideOutputLateTimedMetric,
+		},
+		{
+			pipeline: applied.NewPipeline([]applied.OpUnion{
+				{
+					Type:           pipeline.TransformationOpType,
+					Transformation: pipeline.TransformationOp{Type: transformation.PerSecond},
+				},
+				rollupOp,
+			}),
+			expectedAction: SideOutputLateTimedMetric,
+		},
+	}
+	for _, input := range inputs {
+		key := aggregationKey{
+			aggregationID:      aggregation.DefaultID,
+			storagePolicy:      testTimedMetadata.StoragePolicy,
+			pipeline:           input.pipeline,
+			idPrefixSuffixType: NoPrefixNoSuffix,
+		}
+		latePolicy := e.lateTimedMetricPolicy(testTimedMetric.ID, key)
+		require.Equal(t, input.expectedAction, latePolicy.Action)
+		require.Equal(t, time.Minute, latePolicy.AllowedLateness)
+
+		// The consumed aggregation windows are only retained if late timed
+		// metrics are reaggregated.
+		require.NoError(t, e.restore(elemCheckpoint{
+			metricCategory: timedMetric,
+			metricType:     metric.CounterType,
+			id:             testTimedMetric.ID,
+			key:            key,
+			listType:       timedMetricListType,
+		}, false))
+		elem := e.aggregations[e.aggregations.index(key)].elem.Value.(*CounterElem)
+		if input.expectedAction == ReaggregateLateTimedMetric {
+			require.Equal(t, time.Minute, elem.allowedLateness)
+		} else {
+			require.Equal(t, time.Duration(0), elem.allowedLateness)
+		}
+	}
+}
+
 func TestEntryAddTimed(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -31,6 +31,7 @@ import (
 	"time"
 
 	maggregation "github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/histogram"
 	"github.com/m3db/m3/src/metrics/metric/id"
 	"github.com/m3db/m3/src/metrics/metric/unaggregated"
 	"github.com/m3db/m3/src/metrics/pipeline/applied"
@@ -65,6 +66,10 @@ type GaugeElem struct {
 
 	values              []timedGauge               // metric aggregations sorted by time in ascending order
 	toConsume           []timedGauge               // small buffer to avoid memory allocations during consumption
+	consumed            []timedGauge               // consumed aggregations retained for late values sorted by time in ascending order
+	toExpire            []timedGauge               // small buffer to avoid memory allocations when expiring consumed aggregations
+	allowedLateness     time.Duration              // how long consumed aggregations are retained for late values
+	lateSinceNanos      int64                      // late values may only create aggregations ending after this time
 	lastConsumedAtNanos int64                      // last consumed at in Unix nanoseconds
 	lastConsumedValues  []transformation.Datapoint // last consumed values
 }
@@ -125,6 +130,8 @@ func (e *GaugeElem) ResetSetData(
 	if err := e.gaugeElemBase.ResetSetData(e.aggTypesOpts, aggTypes, useDefaultAggregation); err != nil {
 		return err
 	}
+	e.allowedLateness = 0
+	e.lateSinceNanos = 0
 	// If the pipeline contains derivative transformations, we need to store past
 	// values in order to compute the derivatives.
 	if !e.parsedPipeline.HasDerivativeTransform {
@@ -195,9 +202,36 @@ func (e *GaugeElem) AddUnique(timestamp time.Time, values []float64, sourceID ui
 		return errDuplicateForwardingSource
 	}
 	lockedAgg.sourcesSeen.Set(source)
-	for _, v := range values {
-		lockedAgg.aggregation.Add(timestamp, v)
+	lockedAgg.aggregation.AddValues(timestamp, values)
+	lockedAgg.Unlock()
+	return nil
+}
+
+// SetAllowedLateness sets how long aggregations are retained after they are
+// consumed so that late values can be added to them.
+func (e *GaugeElem) SetAllowedLateness(value time.Duration) {
+	e.Lock()
+	e.allowedLateness = value
+	e.Unlock()
+}
+
+// AddLateValue adds a metric value arriving after the aggregation it belongs
+// to may have been consumed. If the aggregation has been consumed and retained,
+// it is consumed again with the value added. An aggregation is only created for
+// the value if there were no values to consume for it, so that a complete
+// aggregation is never replaced by a partial one.
+func (e *GaugeElem) AddLateValue(timestamp time.Time, value float64) error {
+	alignedStart := timestamp.Truncate(e.sp.Resolution().Window).UnixNano()
+	lockedAgg, err := e.findOrReopen(alignedStart)
+	if err != nil {
+		return err
+	}
+	lockedAgg.Lock()
+	if lockedAgg.closed {
+		lockedAgg.Unlock()
+		return errAggregationClosed
 	}
+	lockedAgg.aggregation.Add(timestamp, value)
 	lockedAgg.Unlock()
 	return nil
 }
@@ -241,6 +275,8 @@ func (e *GaugeElem) Consume(
 		}
 		e.values = e.values[:n]
 	}
+	retain := e.allowedLateness > 0
+	e.retainConsumedWithLock(targetNanos, resolution)
 	canCollect := len(e.values) == 0 && e.tombstoned
 	e.Unlock()
 
@@ -249,23 +285,23 @@ func (e *GaugeElem) Consume(
 		timeNanos := timestampNanosFn(e.toConsume[i].startAtNanos, resolution)
 		e.toConsume[i].lockedAgg.Lock()
 		e.processValueWithAggregationLock(timeNanos, e.toConsume[i].lockedAgg, flushLocalFn, flushForwardedFn)
-		// Closes the aggregation object after it's processed.
-		e.toConsume[i].lockedAgg.closed = true
-		e.toConsume[i].lockedAgg.aggregation.Close()
-		if e.toConsume[i].lockedAgg.sourcesSeen != nil {
-			e.cachedSourceSetsLock.Lock()
-			// This is to make sure there aren't too many cached source sets taking up
-			// too much space.
-			if len(e.cachedSourceSets) < e.opts.MaxNumCachedSourceSets() {
-				e.cachedSourceSets = append(e.cachedSourceSets, e.toConsume[i].lockedAgg.sourcesSeen)
-			}
-			e.cachedSourceSetsLock.Unlock()
-			e.toConsume[i].lockedAgg.sourcesSeen = nil
+		// Closes the aggregation object after it's processed unless it is
+		// retained for late values.
+		if !retain {
+			e.closeWithAggregationLock(e.toConsume[i].lockedAgg)
 		}
 		e.toConsume[i].lockedAgg.Unlock()
 		e.toConsume[i].Reset()
 	}
 
+	// Close the retained aggregations that can no longer receive late values.
+	for i := range e.toExpire {
+		e.toExpire[i].lockedAgg.Lock()
+		e.closeWithAggregationLock(e.toExpire[i].lockedAgg)
+		e.toExpire[i].lockedAgg.Unlock()
+		e.toExpire[i].Reset()
+	}
+
 	if e.parsedPipeline.HasRollup {
 		forwardedAggregationKey, _ := e.ForwardedAggregationKey()
 		onForwardedFlushedFn(e.onForwardedAggregationWrittenFn, forwardedAggregationKey)
@@ -274,6 +310,89 @@ func (e *GaugeElem) Consume(
 	return canCollect
 }
 
+// Checkpoint returns the state of the aggregation windows yet to be consumed,
+// returning false if the element is closed or tombstoned.
+func (e *GaugeElem) Checkpoint() (elemState, bool) {
+	e.RLock()
+	defer e.RUnlock()
+
+	if e.closed || e.tombstoned {
+		return elemState{}, false
+	}
+	state := elemState{
+		windows: make([]windowState, 0, len(e.values)),
+	}
+	for _, value := range e.values {
+		lockedAgg := value.lockedAgg
+		lockedAgg.Lock()
+		if lockedAgg.closed {
+			lockedAgg.Unlock()
+			continue
+		}
+		window := windowState{
+			startAtNanos: value.startAtNanos,
+			values:       lockedAgg.aggregation.AppendState(nil),
+		}
+		if lastAt := lockedAgg.aggregation.LastAt(); !lastAt.IsZero() {
+			window.lastAtNanos = lastAt.UnixNano()
+		}
+		if lockedAgg.sourcesSeen != nil {
+			window.sourcesSeen = lockedAgg.sourcesSeen.Clone()
+		}
+		lockedAgg.Unlock()
+		state.windows = append(state.windows, window)
+	}
+	return state, true
+}
+
+// Restore restores a checkpointed state into the element, merging the
+// checkpointed windows into the existing ones unless only the missing
+// windows are restored.
+func (e *GaugeElem) Restore(state elemState, opts restoreOptions) error {
+	for _, window := range state.windows {
+		if opts.isFlushedFn != nil && opts.isFlushedFn(window.startAtNanos) {
+			continue
+		}
+		var (
+			createOpts = createAggregationOptions{
+				initSourceSet: window.sourcesSeen != nil,
+			}
+			lockedAgg *lockedGaugeAggregation
+			created   = true
+			err       error
+		)
+		if opts.missingOnly {
+			lockedAgg, created, err = e.createIfNotFound(window.startAtNanos, createOpts)
+		} else {
+			lockedAgg, err = e.findOrCreate(window.startAtNanos, createOpts)
+		}
+		if err != nil {
+			return err
+		}
+		if !created {
+			continue
+		}
+		var lastAt time.Time
+		if window.lastAtNanos != 0 {
+			lastAt = time.Unix(0, window.lastAtNanos)
+		}
+		lockedAgg.Lock()
+		if lockedAgg.closed {
+			lockedAgg.Unlock()
+			return errAggregationClosed
+		}
+		err = lockedAgg.aggregation.MergeState(lastAt, window.values)
+		if err == nil && window.sourcesSeen != nil {
+			lockedAgg.sourcesSeen.InPlaceUnion(window.sourcesSeen)
+		}
+		lockedAgg.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Close closes the element.
 func (e *GaugeElem) Close() {
 	e.Lock()
@@ -297,7 +416,14 @@ func (e *GaugeElem) Close() {
 		e.values[idx].Reset()
 	}
 	e.values = e.values[:0]
+	for idx := range e.consumed {
+		e.consumed[idx].lockedAgg.sourcesSeen = nil
+		e.consumed[idx].lockedAgg.aggregation.Close()
+		e.consumed[idx].Reset()
+	}
+	e.consumed = e.consumed[:0]
 	e.toConsume = e.toConsume[:0]
+	e.toExpire = e.toExpire[:0]
 	e.lastConsumedValues = e.lastConsumedValues[:0]
 	e.gaugeElemBase.Close()
 	aggTypesPool := e.aggTypesOpts.TypesPool()
@@ -342,6 +468,36 @@ func (e *GaugeElem) findOrCreate(
 	}
 
 	// If not found, create a new aggregation.
+	agg := e.insertWithLock(idx, alignedStart, createOpts)
+	e.Unlock()
+	return agg, nil
+}
+
+// createIfNotFound creates the aggregation for a given time, returning false
+// if the aggregation already exists.
+func (e *GaugeElem) createIfNotFound(
+	alignedStart int64,
+	createOpts createAggregationOptions,
+) (*lockedGaugeAggregation, bool, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.closed {
+		return nil, false, errElemClosed
+	}
+	idx, found := e.indexOfWithLock(alignedStart)
+	if found {
+		return nil, false, nil
+	}
+	return e.insertWithLock(idx, alignedStart, createOpts), true, nil
+}
+
+// insertWithLock inserts a new aggregation for a given time at the index.
+func (e *GaugeElem) insertWithLock(
+	idx int,
+	alignedStart int64,
+	createOpts createAggregationOptions,
+) *lockedGaugeAggregation {
 	numValues := len(e.values)
 	e.values = append(e.values, timedGauge{})
 	copy(e.values[idx+1:numValues+1], e.values[idx:numValues])
@@ -366,9 +522,109 @@ func (e *GaugeElem) findOrCreate(
 			aggregation: e.NewAggregation(e.opts, e.aggOpts),
 		},
 	}
-	agg := e.values[idx].lockedAgg
-	e.Unlock()
-	return agg, nil
+	return e.values[idx].lockedAgg
+}
+
+// findOrReopen finds the aggregation for a late value at a given time,
+// reopening the aggregation if it has been consumed and retained.
+func (e *GaugeElem) findOrReopen(alignedStart int64) (*lockedGaugeAggregation, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.closed {
+		return nil, errElemClosed
+	}
+	idx, found := e.indexOfWithLock(alignedStart)
+	if found {
+		return e.values[idx].lockedAgg, nil
+	}
+
+	var lockedAgg *lockedGaugeAggregation
+	for i := range e.consumed {
+		if e.consumed[i].startAtNanos != alignedStart {
+			continue
+		}
+		lockedAgg = e.consumed[i].lockedAgg
+		n := copy(e.consumed[i:], e.consumed[i+1:])
+		e.consumed[i+n].Reset()
+		e.consumed = e.consumed[:i+n]
+		break
+	}
+	if lockedAgg == nil {
+		// NB: aggregations ending after the element started retaining consumed
+		// aggregations had no values to consume if they are not retained. Any
+		// other aggregation may have been consumed before this element existed.
+		resolution := e.sp.Resolution().Window.Nanoseconds()
+		if e.lateSinceNanos == 0 || alignedStart+resolution <= e.lateSinceNanos {
+			return nil, errLateAggregationNotRetained
+		}
+		lockedAgg = &lockedGaugeAggregation{
+			aggregation: e.NewAggregation(e.opts, e.aggOpts),
+		}
+	}
+
+	// Add the aggregation back so that it is consumed again.
+	numValues := len(e.values)
+	e.values = append(e.values, timedGauge{})
+	copy(e.values[idx+1:numValues+1], e.values[idx:numValues])
+	e.values[idx] = timedGauge{
+		startAtNanos: alignedStart,
+		lockedAgg:    lockedAgg,
+	}
+	return lockedAgg, nil
+}
+
+// retainConsumedWithLock retains the aggregations being consumed if late
+// values are allowed, and moves the retained aggregations that can no longer
+// receive late values to the list of aggregations to expire.
+func (e *GaugeElem) retainConsumedWithLock(targetNanos int64, resolution time.Duration) {
+	if e.allowedLateness > 0 {
+		if e.lateSinceNanos == 0 {
+			e.lateSinceNanos = targetNanos
+		}
+		for _, value := range e.toConsume {
+			// Reopened aggregations are usually earlier than the retained ones.
+			idx := len(e.consumed)
+			for idx > 0 && e.consumed[idx-1].startAtNanos > value.startAtNanos {
+				idx--
+			}
+			e.consumed = append(e.consumed, timedGauge{})
+			copy(e.consumed[idx+1:], e.consumed[idx:])
+			e.consumed[idx] = value
+		}
+	}
+
+	e.toExpire = e.toExpire[:0]
+	expireBeforeNanos := targetNanos - e.allowedLateness.Nanoseconds()
+	idx := 0
+	for idx < len(e.consumed) && e.consumed[idx].startAtNanos+resolution.Nanoseconds() <= expireBeforeNanos {
+		idx++
+	}
+	if idx > 0 {
+		e.toExpire = append(e.toExpire, e.consumed[:idx]...)
+		n := copy(e.consumed[0:], e.consumed[idx:])
+		for i := n; i < len(e.consumed); i++ {
+			e.consumed[i].Reset()
+		}
+		e.consumed = e.consumed[:n]
+	}
+}
+
+// closeWithAggregationLock closes a consumed aggregation.
+func (e *GaugeElem) closeWithAggregationLock(lockedAgg *lockedGaugeAggregation) {
+	lockedAgg.closed = true
+	lockedAgg.aggregation.Close()
+	if lockedAgg.sourcesSeen == nil {
+		return
+	}
+	e.cachedSourceSetsLock.Lock()
+	// This is to make sure there aren't too many cached source sets taking up
+	// too much space.
+	if len(e.cachedSourceSets) < e.opts.MaxNumCachedSourceSets() {
+		e.cachedSourceSets = append(e.cachedSourceSets, lockedAgg.sourcesSeen)
+	}
+	e.cachedSourceSetsLock.Unlock()
+	lockedAgg.sourcesSeen = nil
 }
 
 // indexOfWithLock finds the smallest element index whose timestamp
@@ -406,11 +662,31 @@ func (e *GaugeElem) processValueWithAggregationLock(
 	flushLocalFn flushLocalMetricFn,
 	flushForwardedFn flushForwardedMetricFn,
 ) {
+	if e.parsedPipeline.HasRollup && len(e.parsedPipeline.Transformations) == 0 {
+		// NB: aggregations such as histograms and mergeable timers are
+		// forwarded as a whole so they can be merged by the next aggregation
+		// in the pipeline. Transformations apply to the value of each
+		// aggregation type so they are forwarded as values otherwise.
+		if values, ok := lockedAgg.aggregation.AppendForwardedValues(nil); ok {
+			forwardedAggregationKey, _ := e.ForwardedAggregationKey()
+			for _, value := range values {
+				flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, value)
+			}
+			e.lastConsumedAtNanos = timeNanos
+			return
+		}
+	}
+
 	var (
 		transformations  = e.parsedPipeline.Transformations
 		discardNaNValues = e.opts.DiscardNaNAggregatedValues()
 	)
 	for aggTypeIdx, aggType := range e.aggTypes {
+		if aggType == maggregation.Buckets {
+			e.flushBucketsWithAggregationLock(timeNanos, lockedAgg, flushLocalFn)
+			continue
+		}
+
 		var extraDp transformation.Datapoint
 		value := lockedAgg.aggregation.ValueOf(aggType)
 		for _, transformOp := range transformations {
@@ -493,3 +769,22 @@ func (e *GaugeElem) processValueWithAggregationLock(
 	}
 	e.lastConsumedAtNanos = timeNanos
 }
+
+// flushBucketsWithAggregationLock flushes each cumulative bucket of the
+// aggregation as a separate series. The upper bound of the bucket is always
+// carried by the suffix of the series so the buckets can be told apart.
+func (e *GaugeElem) flushBucketsWithAggregationLock(
+	timeNanos int64,
+	lockedAgg *lockedGaugeAggregation,
+	flushLocalFn flushLocalMetricFn,
+) {
+	var prefix []byte
+	if e.idPrefixSuffixType == WithPrefixWithSuffix {
+		prefix = e.FullPrefix(e.opts)
+	}
+	typeString := e.TypeStringFor(e.aggTypesOpts, maggregation.Buckets)
+	for _, b := range lockedAgg.aggregation.AppendCumulativeBuckets(nil) {
+		suffix := histogram.AppendBucketSuffix(nil, typeString, b.UpperBound)
+		flushLocalFn(prefix, e.id, suffix, timeNanos, float64(b.Count), e.sp)
+	}
+}
@@ -0,0 +1,790 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// This file was automatically generated by genny.
+// Any changes will be lost if this file is regenerated.
+// see https://github.com/mauricelam/genny
+
+package aggregator
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	maggregation "github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/histogram"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/metrics/metric/unaggregated"
+	"github.com/m3db/m3/src/metrics/pipeline/applied"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/metrics/transformation"
+
+	"github.com/willf/bitset"
+)
+
+type lockedHistogramAggregation struct {
+	sync.Mutex
+
+	closed      bool
+	sourcesSeen *bitset.BitSet
+	aggregation histogramAggregation
+}
+
+type timedHistogram struct {
+	startAtNanos int64 // start time of an aggregation window
+	lockedAgg    *lockedHistogramAggregation
+}
+
+func (ta *timedHistogram) Reset() {
+	ta.startAtNanos = 0
+	ta.lockedAgg = nil
+}
+
+// HistogramElem is an element storing time-bucketed aggregations.
+type HistogramElem struct {
+	elemBase
+	histogramElemBase
+
+	values              []timedHistogram           // metric aggregations sorted by time in ascending order
+	toConsume           []timedHistogram           // small buffer to avoid memory allocations during consumption
+	consumed            []timedHistogram           // consumed aggregations retained for late values sorted by time in ascending order
+	toExpire            []timedHistogram           // small buffer to avoid memory allocations when expiring consumed aggregations
+	allowedLateness     time.Duration              // how long consumed aggregations are retained for late values
+	lateSinceNanos      int64                      // late values may only create aggregations ending after this time
+	lastConsumedAtNanos int64                      // last consumed at in Unix nanoseconds
+	lastConsumedValues  []transformation.Datapoint // last consumed values
+}
+
+// NewHistogramElem creates a new element for the given metric type.
+func NewHistogramElem(
+	id id.RawID,
+	sp policy.StoragePolicy,
+	aggTypes maggregation.Types,
+	pipeline applied.Pipeline,
+	numForwardedTimes int,
+	idPrefixSuffixType IDPrefixSuffixType,
+	opts Options,
+) (*HistogramElem, error) {
+	e := &HistogramElem{
+		elemBase: newElemBase(opts),
+		values:   make([]timedHistogram, 0, defaultNumAggregations), // in most cases values will have two entries
+	}
+	if err := e.ResetSetData(id, sp, aggTypes, pipeline, numForwardedTimes, idPrefixSuffixType); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// MustNewHistogramElem creates a new element, or panics if the input is invalid.
+func MustNewHistogramElem(
+	id id.RawID,
+	sp policy.StoragePolicy,
+	aggTypes maggregation.Types,
+	pipeline applied.Pipeline,
+	numForwardedTimes int,
+	idPrefixSuffixType IDPrefixSuffixType,
+	opts Options,
+) *HistogramElem {
+	elem, err := NewHistogramElem(id, sp, aggTypes, pipeline, numForwardedTimes, idPrefixSuffixType, opts)
+	if err != nil {
+		panic(fmt.Errorf("unable to create element: %v", err))
+	}
+	return elem
+}
+
+// ResetSetData resets the element and sets data.
+func (e *HistogramElem) ResetSetData(
+	id id.RawID,
+	sp policy.StoragePolicy,
+	aggTypes maggregation.Types,
+	pipeline applied.Pipeline,
+	numForwardedTimes int,
+	idPrefixSuffixType IDPrefixSuffixType,
+) error {
+	useDefaultAggregation := aggTypes.IsDefault()
+	if useDefaultAggregation {
+		aggTypes = e.DefaultAggregationTypes(e.aggTypesOpts)
+	}
+	if err := e.elemBase.resetSetData(id, sp, aggTypes, useDefaultAggregation, pipeline, numForwardedTimes, idPrefixSuffixType); err != nil {
+		return err
+	}
+	if err := e.histogramElemBase.ResetSetData(e.aggTypesOpts, aggTypes, useDefaultAggregation); err != nil {
+		return err
+	}
+	e.allowedLateness = 0
+	e.lateSinceNanos = 0
+	// If the pipeline contains derivative transformations, we need to store past
+	// values in order to compute the derivatives.
+	if !e.parsedPipeline.HasDerivativeTransform {
+		return nil
+	}
+	numAggTypes := len(e.aggTypes)
+	if cap(e.lastConsumedValues) < numAggTypes {
+		e.lastConsumedValues = make([]transformation.Datapoint, numAggTypes)
+	}
+	e.lastConsumedValues = e.lastConsumedValues[:numAggTypes]
+	for i := 0; i < len(e.lastConsumedValues); i++ {
+		e.lastConsumedValues[i] = transformation.Datapoint{Value: nan}
+	}
+	return nil
+}
+
+// AddUnion adds a metric value union at a given timestamp.
+func (e *HistogramElem) AddUnion(timestamp time.Time, mu unaggregated.MetricUnion) error {
+	alignedStart := timestamp.Truncate(e.sp.Resolution().Window).UnixNano()
+	lockedAgg, err := e.findOrCreate(alignedStart, createAggregationOptions{})
+	if err != nil {
+		return err
+	}
+	lockedAgg.Lock()
+	if lockedAgg.closed {
+		lockedAgg.Unlock()
+		return errAggregationClosed
+	}
+	lockedAgg.aggregation.AddUnion(timestamp, mu)
+	lockedAgg.Unlock()
+	return nil
+}
+
+// AddValue adds a metric value at a given timestamp.
+func (e *HistogramElem) AddValue(timestamp time.Time, value float64) error {
+	alignedStart := timestamp.Truncate(e.sp.Resolution().Window).UnixNano()
+	lockedAgg, err := e.findOrCreate(alignedStart, createAggregationOptions{})
+	if err != nil {
+		return err
+	}
+	lockedAgg.Lock()
+	if lockedAgg.closed {
+		lockedAgg.Unlock()
+		return errAggregationClosed
+	}
+	lockedAgg.aggregation.Add(timestamp, value)
+	lockedAgg.Unlock()
+	return nil
+}
+
+// AddUnique adds a metric value from a given source at a given timestamp.
+// If previous values from the same source have already been added to the
+// same aggregation, the incoming value is discarded.
+func (e *HistogramElem) AddUnique(timestamp time.Time, values []float64, sourceID uint32) error {
+	alignedStart := timestamp.Truncate(e.sp.Resolution().Window).UnixNano()
+	lockedAgg, err := e.findOrCreate(alignedStart, createAggregationOptions{initSourceSet: true})
+	if err != nil {
+		return err
+	}
+	lockedAgg.Lock()
+	if lockedAgg.closed {
+		lockedAgg.Unlock()
+		return errAggregationClosed
+	}
+	source := uint(sourceID)
+	if lockedAgg.sourcesSeen.Test(source) {
+		lockedAgg.Unlock()
+		return errDuplicateForwardingSource
+	}
+	lockedAgg.sourcesSeen.Set(source)
+	lockedAgg.aggregation.AddValues(timestamp, values)
+	lockedAgg.Unlock()
+	return nil
+}
+
+// SetAllowedLateness sets how long aggregations are retained after they are
+// consumed so that late values can be added to them.
+func (e *HistogramElem) SetAllowedLateness(value time.Duration) {
+	e.Lock()
+	e.allowedLateness = value
+	e.Unlock()
+}
+
+// AddLateValue adds a metric value arriving after the aggregation it belongs
+// to may have been consumed. If the aggregation has been consumed and retained,
+// it is consumed again with the value added. An aggregation is only created for
+// the value if there were no values to consume for it, so that a complete
+// aggregation is never replaced by a partial one.
+func (e *HistogramElem) AddLateValue(timestamp time.Time, value float64) error {
+	alignedStart := timestamp.Truncate(e.sp.Resolution().Window).UnixNano()
+	lockedAgg, err := e.findOrReopen(alignedStart)
+	if err != nil {
+		return err
+	}
+	lockedAgg.Lock()
+	if lockedAgg.closed {
+		lockedAgg.Unlock()
+		return errAggregationClosed
+	}
+	lockedAgg.aggregation.Add(timestamp, value)
+	lockedAgg.Unlock()
+	return nil
+}
+
+// Consume consumes values before a given time and removes them from the element
+// after they are consumed, returning whether the element can be collected after
+// the consumption is completed.
+// NB: Consume is not thread-safe and must be called within a single goroutine
+// to avoid race conditions.
+func (e *HistogramElem) Consume(
+	targetNanos int64,
+	isEarlierThanFn isEarlierThanFn,
+	timestampNanosFn timestampNanosFn,
+	flushLocalFn flushLocalMetricFn,
+	flushForwardedFn flushForwardedMetricFn,
+	onForwardedFlushedFn onForwardingElemFlushedFn,
+) bool {
+	resolution := e.sp.Resolution().Window
+	e.Lock()
+	if e.closed {
+		e.Unlock()
+		return false
+	}
+	idx := 0
+	for range e.values {
+		// Bail as soon as the timestamp is no later than the target time.
+		if !isEarlierThanFn(e.values[idx].startAtNanos, resolution, targetNanos) {
+			break
+		}
+		idx++
+	}
+	e.toConsume = e.toConsume[:0]
+	if idx > 0 {
+		// Shift remaining values to the left and shrink the values slice.
+		e.toConsume = append(e.toConsume, e.values[:idx]...)
+		n := copy(e.values[0:], e.values[idx:])
+		// Clear out the invalid items to avoid holding references to objects
+		// for reduced GC overhead..
+		for i := n; i < len(e.values); i++ {
+			e.values[i].Reset()
+		}
+		e.values = e.values[:n]
+	}
+	retain := e.allowedLateness > 0
+	e.retainConsumedWithLock(targetNanos, resolution)
+	canCollect := len(e.values) == 0 && e.tombstoned
+	e.Unlock()
+
+	// Process the aggregations that are ready for consumption.
+	for i := range e.toConsume {
+		timeNanos := timestampNanosFn(e.toConsume[i].startAtNanos, resolution)
+		e.toConsume[i].lockedAgg.Lock()
+		e.processValueWithAggregationLock(timeNanos, e.toConsume[i].lockedAgg, flushLocalFn, flushForwardedFn)
+		// Closes the aggregation object after it's processed unless it is
+		// retained for late values.
+		if !retain {
+			e.closeWithAggregationLock(e.toConsume[i].lockedAgg)
+		}
+		e.toConsume[i].lockedAgg.Unlock()
+		e.toConsume[i].Reset()
+	}
+
+	// Close the retained aggregations that can no longer receive late values.
+	for i := range e.toExpire {
+		e.toExpire[i].lockedAgg.Lock()
+		e.closeWithAggregationLock(e.toExpire[i].lockedAgg)
+		e.toExpire[i].lockedAgg.Unlock()
+		e.toExpire[i].Reset()
+	}
+
+	if e.parsedPipeline.HasRollup {
+		forwardedAggregationKey, _ := e.ForwardedAggregationKey()
+		onForwardedFlushedFn(e.onForwardedAggregationWrittenFn, forwardedAggregationKey)
+	}
+
+	return canCollect
+}
+
+// Checkpoint returns the state of the aggregation windows yet to be consumed,
+// returning false if the element is closed or tombstoned.
+func (e *HistogramElem) Checkpoint() (elemState, bool) {
+	e.RLock()
+	defer e.RUnlock()
+
+	if e.closed || e.tombstoned {
+		return elemState{}, false
+	}
+	state := elemState{
+		windows: make([]windowState, 0, len(e.values)),
+	}
+	for _, value := range e.values {
+		lockedAgg := value.lockedAgg
+		lockedAgg.Lock()
+		if lockedAgg.closed {
+			lockedAgg.Unlock()
+			continue
+		}
+		window := windowState{
+			startAtNanos: value.startAtNanos,
+			values:       lockedAgg.aggregation.AppendState(nil),
+		}
+		if lastAt := lockedAgg.aggregation.LastAt(); !lastAt.IsZero() {
+			window.lastAtNanos = lastAt.UnixNano()
+		}
+		if lockedAgg.sourcesSeen != nil {
+			window.sourcesSeen = lockedAgg.sourcesSeen.Clone()
+		}
+		lockedAgg.Unlock()
+		state.windows = append(state.windows, window)
+	}
+	return state, true
+}
+
+// Restore restores a checkpointed state into the element, merging the
+// checkpointed windows into the existing ones unless only the missing
+// windows are restored.
+func (e *HistogramElem) Restore(state elemState, opts restoreOptions) error {
+	for _, window := range state.windows {
+		if opts.isFlushedFn != nil && opts.isFlushedFn(window.startAtNanos) {
+			continue
+		}
+		var (
+			createOpts = createAggregationOptions{
+				initSourceSet: window.sourcesSeen != nil,
+			}
+			lockedAgg *lockedHistogramAggregation
+			created   = true
+			err       error
+		)
+		if opts.missingOnly {
+			lockedAgg, created, err = e.createIfNotFound(window.startAtNanos, createOpts)
+		} else {
+			lockedAgg, err = e.findOrCreate(window.startAtNanos, createOpts)
+		}
+		if err != nil {
+			return err
+		}
+		if !created {
+			continue
+		}
+		var lastAt time.Time
+		if window.lastAtNanos != 0 {
+			lastAt = time.Unix(0, window.lastAtNanos)
+		}
+		lockedAgg.Lock()
+		if lockedAgg.closed {
+			lockedAgg.Unlock()
+			return errAggregationClosed
+		}
+		err = lockedAgg.aggregation.MergeState(lastAt, window.values)
+		if err == nil && window.sourcesSeen != nil {
+			lockedAgg.sourcesSeen.InPlaceUnion(window.sourcesSeen)
+		}
+		lockedAgg.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the element.
+func (e *HistogramElem) Close() {
+	e.Lock()
+	if e.closed {
+		e.Unlock()
+		return
+	}
+	e.closed = true
+	e.id = nil
+	e.parsedPipeline = parsedPipeline{}
+	e.writeForwardedMetricFn = nil
+	e.onForwardedAggregationWrittenFn = nil
+	for idx := range e.cachedSourceSets {
+		e.cachedSourceSets[idx] = nil
+	}
+	e.cachedSourceSets = nil
+	for idx := range e.values {
+		// Close the underlying aggregation objects.
+		e.values[idx].lockedAgg.sourcesSeen = nil
+		e.values[idx].lockedAgg.aggregation.Close()
+		e.values[idx].Reset()
+	}
+	e.values = e.values[:0]
+	for idx := range e.consumed {
+		e.consumed[idx].lockedAgg.sourcesSeen = nil
+		e.consumed[idx].lockedAgg.aggregation.Close()
+		e.consumed[idx].Reset()
+	}
+	e.consumed = e.consumed[:0]
+	e.toConsume = e.toConsume[:0]
+	e.toExpire = e.toExpire[:0]
+	e.lastConsumedValues = e.lastConsumedValues[:0]
+	e.histogramElemBase.Close()
+	aggTypesPool := e.aggTypesOpts.TypesPool()
+	pool := e.ElemPool(e.opts)
+	e.Unlock()
+
+	if !e.useDefaultAggregation {
+		aggTypesPool.Put(e.aggTypes)
+	}
+	pool.Put(e)
+}
+
+// findOrCreate finds the aggregation for a given time, or creates one
+// if it doesn't exist.
+func (e *HistogramElem) findOrCreate(
+	alignedStart int64,
+	createOpts createAggregationOptions,
+) (*lockedHistogramAggregation, error) {
+	e.RLock()
+	if e.closed {
+		e.RUnlock()
+		return nil, errElemClosed
+	}
+	idx, found := e.indexOfWithLock(alignedStart)
+	if found {
+		agg := e.values[idx].lockedAgg
+		e.RUnlock()
+		return agg, nil
+	}
+	e.RUnlock()
+
+	e.Lock()
+	if e.closed {
+		e.Unlock()
+		return nil, errElemClosed
+	}
+	idx, found = e.indexOfWithLock(alignedStart)
+	if found {
+		agg := e.values[idx].lockedAgg
+		e.Unlock()
+		return agg, nil
+	}
+
+	// If not found, create a new aggregation.
+	agg := e.insertWithLock(idx, alignedStart, createOpts)
+	e.Unlock()
+	return agg, nil
+}
+
+// createIfNotFound creates the aggregation for a given time, returning false
+// if the aggregation already exists.
+func (e *HistogramElem) createIfNotFound(
+	alignedStart int64,
+	createOpts createAggregationOptions,
+) (*lockedHistogramAggregation, bool, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.closed {
+		return nil, false, errElemClosed
+	}
+	idx, found := e.indexOfWithLock(alignedStart)
+	if found {
+		return nil, false, nil
+	}
+	return e.insertWithLock(idx, alignedStart, createOpts), true, nil
+}
+
+// insertWithLock inserts a new aggregation for a given time at the index.
+func (e *HistogramElem) insertWithLock(
+	idx int,
+	alignedStart int64,
+	createOpts createAggregationOptions,
+) *lockedHistogramAggregation {
+	numValues := len(e.values)
+	e.values = append(e.values, timedHistogram{})
+	copy(e.values[idx+1:numValues+1], e.values[idx:numValues])
+
+	var sourcesSeen *bitset.BitSet
+	if createOpts.initSourceSet {
+		e.cachedSourceSetsLock.Lock()
+		if numCachedSourceSets := len(e.cachedSourceSets); numCachedSourceSets > 0 {
+			sourcesSeen = e.cachedSourceSets[numCachedSourceSets-1]
+			e.cachedSourceSets[numCachedSourceSets-1] = nil
+			e.cachedSourceSets = e.cachedSourceSets[:numCachedSourceSets-1]
+			sourcesSeen.ClearAll()
+		} else {
+			sourcesSeen = bitset.New(defaultNumSources)
+		}
+		e.cachedSourceSetsLock.Unlock()
+	}
+	e.values[idx] = timedHistogram{
+		startAtNanos: alignedStart,
+		lockedAgg: &lockedHistogramAggregation{
+			sourcesSeen: sourcesSeen,
+			aggregation: e.NewAggregation(e.opts, e.aggOpts),
+		},
+	}
+	return e.values[idx].lockedAgg
+}
+
+// findOrReopen finds the aggregation for a late value at a given time,
+// reopening the aggregation if it has been consumed and retained.
+func (e *HistogramElem) findOrReopen(alignedStart int64) (*lockedHistogramAggregation, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.closed {
+		return nil, errElemClosed
+	}
+	idx, found := e.indexOfWithLock(alignedStart)
+	if found {
+		return e.values[idx].lockedAgg, nil
+	}
+
+	var lockedAgg *lockedHistogramAggregation
+	for i := range e.consumed {
+		if e.consumed[i].startAtNanos != alignedStart {
+			continue
+		}
+		lockedAgg = e.consumed[i].lockedAgg
+		n := copy(e.consumed[i:], e.consumed[i+1:])
+		e.consumed[i+n].Reset()
+		e.consumed = e.consumed[:i+n]
+		break
+	}
+	if lockedAgg == nil {
+		// NB: aggregations ending after the element started retaining consumed
+		// aggregations had no values to consume if they are not retained. Any
+		// other aggregation may have been consumed before this element existed.
+		resolution := e.sp.Resolution().Window.Nanoseconds()
+		if e.lateSinceNanos == 0 || alignedStart+resolution <= e.lateSinceNanos {
+			return nil, errLateAggregationNotRetained
+		}
+		lockedAgg = &lockedHistogramAggregation{
+			aggregation: e.NewAggregation(e.opts, e.aggOpts),
+		}
+	}
+
+	// Add the aggregation back so that it is consumed again.
+	numValues := len(e.values)
+	e.values = append(e.values, timedHistogram{})
+	copy(e.values[idx+1:numValues+1], e.values[idx:numValues])
+	e.values[idx] = timedHistogram{
+		startAtNanos: alignedStart,
+		lockedAgg:    lockedAgg,
+	}
+	return lockedAgg, nil
+}
+
+// retainConsumedWithLock retains the aggregations being consumed if late
+// values are allowed, and moves the retained aggregations that can no longer
+// receive late values to the list of aggregations to expire.
+func (e *HistogramElem) retainConsumedWithLock(targetNanos int64, resolution time.Duration) {
+	if e.allowedLateness > 0 {
+		if e.lateSinceNanos == 0 {
+			e.lateSinceNanos = targetNanos
+		}
+		for _, value := range e.toConsume {
+			// Reopened aggregations are usually earlier than the retained ones.
+			idx := len(e.consumed)
+			for idx > 0 && e.consumed[idx-1].startAtNanos > value.startAtNanos {
+				idx--
+			}
+			e.consumed = append(e.consumed, timedHistogram{})
+			copy(e.consumed[idx+1:], e.consumed[idx:])
+			e.consumed[idx] = value
+		}
+	}
+
+	e.toExpire = e.toExpire[:0]
+	expireBeforeNanos := targetNanos - e.allowedLateness.Nanoseconds()
+	idx := 0
+	for idx < len(e.consumed) && e.consumed[idx].startAtNanos+resolution.Nanoseconds() <= expireBeforeNanos {
+		idx++
+	}
+	if idx > 0 {
+		e.toExpire = append(e.toExpire, e.consumed[:idx]...)
+		n := copy(e.consumed[0:], e.consumed[idx:])
+		for i := n; i < len(e.consumed); i++ {
+			e.consumed[i].Reset()
+		}
+		e.consumed = e.consumed[:n]
+	}
+}
+
+// closeWithAggregationLock closes a consumed aggregation.
+func (e *HistogramElem) closeWithAggregationLock(lockedAgg *lockedHistogramAggregation) {
+	lockedAgg.closed = true
+	lockedAgg.aggregation.Close()
+	if lockedAgg.sourcesSeen == nil {
+		return
+	}
+	e.cachedSourceSetsLock.Lock()
+	// This is to make sure there aren't too many cached source sets taking up
+	// too much space.
+	if len(e.cachedSourceSets) < e.opts.MaxNumCachedSourceSets() {
+		e.cachedSourceSets = append(e.cachedSourceSets, lockedAgg.sourcesSeen)
+	}
+	e.cachedSourceSetsLock.Unlock()
+	lockedAgg.sourcesSeen = nil
+}
+
+// indexOfWithLock finds the smallest element index whose timestamp
+// is no smaller than the start time passed in, and true if it's an
+// exact match, false otherwise.
+func (e *HistogramElem) indexOfWithLock(alignedStart int64) (int, bool) {
+	numValues := len(e.values)
+	// Optimize for the common case.
+	if numValues > 0 && e.values[numValues-1].startAtNanos == alignedStart {
+		return numValues - 1, true
+	}
+	// Binary search for the unusual case. We intentionally do not
+	// use the sort.Search() function because it requires passing
+	// in a closure.
+	left, right := 0, numValues
+	for left < right {
+		mid := left + (right-left)/2 // avoid overflow
+		if e.values[mid].startAtNanos < alignedStart {
+			left = mid + 1
+		} else {
+			right = mid
+		}
+	}
+	// If the current timestamp is equal to or larger than the target time,
+	// return the index as is.
+	if left < numValues && e.values[left].startAtNanos == alignedStart {
+		return left, true
+	}
+	return left, false
+}
+
+func (e *HistogramElem) processValueWithAggregationLock(
+	timeNanos int64,
+	lockedAgg *lockedHistogramAggregation,
+	flushLocalFn flushLocalMetricFn,
+	flushForwardedFn flushForwardedMetricFn,
+) {
+	if e.parsedPipeline.HasRollup && len(e.parsedPipeline.Transformations) == 0 {
+		// NB: aggregations such as histograms and mergeable timers are
+		// forwarded as a whole so they can be merged by the next aggregation
+		// in the pipeline. Transformations apply to the value of each
+		// aggregation type so they are forwarded as values otherwise.
+		if values, ok := lockedAgg.aggregation.AppendForwardedValues(nil); ok {
+			forwardedAggregationKey, _ := e.ForwardedAggregationKey()
+			for _, value := range values {
+				flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, value)
+			}
+			e.lastConsumedAtNanos = timeNanos
+			return
+		}
+	}
+
+	var (
+		transformations  = e.parsedPipeline.Transformations
+		discardNaNValues = e.opts.DiscardNaNAggregatedValues()
+	)
+	for aggTypeIdx, aggType := range e.aggTypes {
+		if aggType == maggregation.Buckets {
+			e.flushBucketsWithAggregationLock(timeNanos, lockedAgg, flushLocalFn)
+			continue
+		}
+
+		var extraDp transformation.Datapoint
+		value := lockedAgg.aggregation.ValueOf(aggType)
+		for _, transformOp := range transformations {
+			unaryOp, isUnaryOp := transformOp.UnaryTransform()
+			binaryOp, isBinaryOp := transformOp.BinaryTransform()
+			unaryMultiOp, isUnaryMultiOp := transformOp.UnaryMultiOutputTransform()
+			switch {
+			case isUnaryOp:
+				curr := transformation.Datapoint{
+					TimeNanos: timeNanos,
+					Value:     value,
+				}
+
+				res := unaryOp.Evaluate(curr)
+
+				value = res.Value
+
+			case isBinaryOp:
+				lastTimeNanos := e.lastConsumedAtNanos
+				prev := transformation.Datapoint{
+					TimeNanos: lastTimeNanos,
+					Value:     e.lastConsumedValues[aggTypeIdx].Value,
+				}
+
+				currTimeNanos := timeNanos
+				curr := transformation.Datapoint{
+					TimeNanos: currTimeNanos,
+					Value:     value,
+				}
+
+				res := binaryOp.Evaluate(prev, curr)
+
+				// NB: we only need to record the value needed for derivative transformations.
+				// We currently only support first-order derivative transformations so we only
+				// need to keep one value. In the future if we need to support higher-order
+				// derivative transformations, we need to store an array of values here.
+				if !math.IsNaN(curr.Value) {
+					e.lastConsumedValues[aggTypeIdx] = curr
+				}
+
+				value = res.Value
+			case isUnaryMultiOp:
+				curr := transformation.Datapoint{
+					TimeNanos: timeNanos,
+					Value:     value,
+				}
+
+				var res transformation.Datapoint
+				res, extraDp = unaryMultiOp.Evaluate(curr)
+				value = res.Value
+			}
+		}
+
+		if discardNaNValues && math.IsNaN(value) {
+			continue
+		}
+
+		if !e.parsedPipeline.HasRollup {
+			toFlush := make([]transformation.Datapoint, 0, 2)
+			toFlush = append(toFlush, transformation.Datapoint{
+				TimeNanos: timeNanos,
+				Value:     value,
+			})
+			if extraDp.TimeNanos != 0 {
+				toFlush = append(toFlush, extraDp)
+			}
+			for _, point := range toFlush {
+				switch e.idPrefixSuffixType {
+				case NoPrefixNoSuffix:
+					flushLocalFn(nil, e.id, nil, point.TimeNanos, point.Value, e.sp)
+				case WithPrefixWithSuffix:
+					flushLocalFn(e.FullPrefix(e.opts), e.id, e.TypeStringFor(e.aggTypesOpts, aggType),
+						point.TimeNanos, point.Value, e.sp)
+				}
+			}
+		} else {
+			forwardedAggregationKey, _ := e.ForwardedAggregationKey()
+			flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, value)
+		}
+	}
+	e.lastConsumedAtNanos = timeNanos
+}
+
+// flushBucketsWithAggregationLock flushes each cumulative bucket of the
+// aggregation as a separate series. The upper bound of the bucket is always
+// carried by the suffix of the series so the buckets can be told apart.
+func (e *HistogramElem) flushBucketsWithAggregationLock(
+	timeNanos int64,
+	lockedAgg *lockedHistogramAggregation,
+	flushLocalFn flushLocalMetricFn,
+) {
+	var prefix []byte
+	if e.idPrefixSuffixType == WithPrefixWithSuffix {
+		prefix = e.FullPrefix(e.opts)
+	}
+	typeString := e.TypeStringFor(e.aggTypesOpts, maggregation.Buckets)
+	for _, b := range lockedAgg.aggregation.AppendCumulativeBuckets(nil) {
+		suffix := histogram.AppendBucketSuffix(nil, typeString, b.UpperBound)
+		flushLocalFn(prefix, e.id, suffix, timeNanos, float64(b.Count), e.sp)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/metrics/policy"
+)
+
+// LateTimedMetricAction determines what happens to a timed metric arriving
+// later than the buffer for past timed metrics but within its allowed lateness.
+type LateTimedMetricAction int
+
+const (
+	// DropLateTimedMetric drops late timed metrics as if they arrived later
+	// than their allowed lateness.
+	DropLateTimedMetric LateTimedMetricAction = iota
+	// ReaggregateLateTimedMetric adds late timed metrics to the aggregation
+	// windows they belong to, flushing the corrected aggregations of windows
+	// that have already been flushed again. Late timed metrics of aggregations
+	// whose pipeline computes derivatives or rolls up are side output instead.
+	ReaggregateLateTimedMetric
+	// SideOutputLateTimedMetric writes late timed metrics as they are to the
+	// late timed metric writer without aggregating them.
+	SideOutputLateTimedMetric
+)
+
+var (
+	validLateTimedMetricActions = []LateTimedMetricAction{
+		DropLateTimedMetric,
+		ReaggregateLateTimedMetric,
+		SideOutputLateTimedMetric,
+	}
+)
+
+func (a LateTimedMetricAction) String() string {
+	switch a {
+	case DropLateTimedMetric:
+		return "drop"
+	case ReaggregateLateTimedMetric:
+		return "reaggregate"
+	case SideOutputLateTimedMetric:
+		return "sideOutput"
+	default:
+		return "unknown"
+	}
+}
+
+// UnmarshalYAML unmarshals a late timed metric action from a string.
+func (a *LateTimedMetricAction) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	if str == "" {
+		*a = DropLateTimedMetric
+		return nil
+	}
+	for _, valid := range validLateTimedMetricActions {
+		if str == valid.String() {
+			*a = valid
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid late timed metric action: %s, valid actions are: %v",
+		str, validLateTimedMetricActions)
+}
+
+// LateTimedMetricPolicy determines how timed metrics arriving later than the
+// buffer for past timed metrics are handled.
+type LateTimedMetricPolicy struct {
+	// AllowedLateness is how much later than the buffer for past timed metrics
+	// a timed metric may arrive and still be handled by the action.
+	AllowedLateness time.Duration
+
+	// Action is what happens to timed metrics arriving within the allowed lateness.
+	Action LateTimedMetricAction
+}
+
+// LateTimedMetricPolicyFn returns the late policy of timed metrics with the
+// given id and storage policy.
+type LateTimedMetricPolicyFn func(metricID id.RawID, sp policy.StoragePolicy) LateTimedMetricPolicy
+
+func defaultLateTimedMetricPolicyFn(id.RawID, policy.StoragePolicy) LateTimedMetricPolicy {
+	return LateTimedMetricPolicy{}
+}
@@ -54,6 +54,10 @@ type metricList interface {
 	// PushBack pushes a metric element to the back of the list.
 	PushBack(value metricElem) (*list.Element, error)
 
+	// IsFlushed returns true if the aggregation window starting at the given
+	// time has already been flushed or discarded.
+	IsFlushed(windowStartNanos int64) bool
+
 	// Close closes the metric list.
 	Close()
 }
@@ -232,6 +236,12 @@ func (l *baseMetricList) Resolution() time.Duration    { return l.resolution }
 func (l *baseMetricList) FlushInterval() time.Duration { return l.resolution }
 func (l *baseMetricList) LastFlushedNanos() int64      { return atomic.LoadInt64(&l.lastFlushedNanos) }
 
+// IsFlushed returns true if the aggregation window starting at the given
+// time has already been flushed or discarded.
+func (l *baseMetricList) IsFlushed(windowStartNanos int64) bool {
+	return l.isEarlierThanFn(windowStartNanos, l.resolution, l.LastFlushedNanos())
+}
+
 // Len returns the number of elements in the list.
 func (l *baseMetricList) Len() int {
 	l.RLock()
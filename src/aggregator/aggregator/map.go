@@ -35,6 +35,7 @@ import (
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
 	"github.com/m3db/m3/src/metrics/metric/unaggregated"
 	"github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
 	xresource "github.com/m3db/m3/src/x/resource"
 
 	"github.com/uber-go/tally"
@@ -243,6 +244,59 @@ func (m *metricMap) SetRuntimeOptions(opts runtime.Options) {
 	m.entryListDelLock.Unlock()
 }
 
+// Checkpoint encodes the checkpoints of the aggregation elements of the map.
+func (m *metricMap) Checkpoint(enc *checkpointEncoder) error {
+	var (
+		checkpoints []elemCheckpoint
+		multiErr    = xerrors.NewMultiError()
+	)
+	m.forEachEntry(func(entry hashedEntry) {
+		checkpoints = entry.entry.appendCheckpoints(checkpoints[:0], entry.key.metricCategory)
+		for i := range checkpoints {
+			if err := enc.Encode(checkpoints[i]); err != nil {
+				multiErr = multiErr.Add(err)
+			}
+			checkpoints[i] = elemCheckpoint{}
+		}
+	})
+	return multiErr.FinalError()
+}
+
+// Restore restores the checkpointed aggregation elements into the map,
+// returning the number of elements restored. If missingOnly is true only the
+// aggregation windows that the map has neither received nor flushed yet are
+// restored.
+func (m *metricMap) Restore(dec *checkpointDecoder, missingOnly bool) (int, error) {
+	var (
+		numRestored int
+		multiErr    = xerrors.NewMultiError()
+	)
+	for dec.Next() {
+		checkpoint := dec.Current()
+		key := entryKey{
+			metricCategory: checkpoint.metricCategory,
+			metricType:     checkpoint.metricType,
+			idHash:         hash.Murmur3Hash128(checkpoint.id),
+		}
+		entry, err := m.findOrCreate(key)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		err = entry.restore(checkpoint, missingOnly)
+		entry.DecWriter()
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		numRestored++
+	}
+	if err := dec.Err(); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+	return numRestored, multiErr.FinalError()
+}
+
 func (m *metricMap) Close() {
 	m.Lock()
 	defer m.Unlock()
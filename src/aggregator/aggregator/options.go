@@ -24,7 +24,9 @@ import (
 	"sync"
 	"time"
 
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	"github.com/m3db/m3/src/aggregator/aggregation/quantile/ddsketch"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
 	"github.com/m3db/m3/src/aggregator/client"
@@ -43,6 +45,7 @@ var (
 	defaultCounterPrefix              = []byte("counts.")
 	defaultTimerPrefix                = []byte("timers.")
 	defaultGaugePrefix                = []byte("gauges.")
+	defaultHistogramPrefix            = []byte("histograms.")
 	defaultEntryTTL                   = time.Hour
 	defaultEntryCheckInterval         = time.Hour
 	defaultEntryCheckBatchPercent     = 0.01
@@ -50,6 +53,8 @@ var (
 	defaultMaxNumCachedSourceSets     = 2
 	defaultDiscardNaNAggregatedValues = true
 	defaultResignTimeout              = 5 * time.Minute
+	defaultCheckpointInterval         = 10 * time.Second
+	defaultCheckpointMaxAge           = 10 * time.Minute
 	defaultDefaultStoragePolicies     = []policy.StoragePolicy{
 		policy.NewStoragePolicy(10*time.Second, xtime.Second, 2*24*time.Hour),
 		policy.NewStoragePolicy(time.Minute, xtime.Minute, 40*24*time.Hour),
@@ -110,6 +115,12 @@ type Options interface {
 	// GaugePrefix returns the prefix for gauges.
 	GaugePrefix() []byte
 
+	// SetHistogramPrefix sets the prefix for histograms.
+	SetHistogramPrefix(value []byte) Options
+
+	// HistogramPrefix returns the prefix for histograms.
+	HistogramPrefix() []byte
+
 	// SetTimeLock sets the time lock.
 	SetTimeLock(value *sync.RWMutex) Options
 
@@ -140,6 +151,28 @@ type Options interface {
 	// StreamOptions returns the stream options.
 	StreamOptions() cm.Options
 
+	// SetTimerQuantileSketchType sets the type of sketch timers compute
+	// quantiles with.
+	SetTimerQuantileSketchType(value raggregation.QuantileSketchType) Options
+
+	// TimerQuantileSketchType returns the type of sketch timers compute
+	// quantiles with.
+	TimerQuantileSketchType() raggregation.QuantileSketchType
+
+	// SetDDSketchOptions sets the options of timers that compute quantiles
+	// with DDSketch.
+	SetDDSketchOptions(value ddsketch.Options) Options
+
+	// DDSketchOptions returns the options of timers that compute quantiles
+	// with DDSketch.
+	DDSketchOptions() ddsketch.Options
+
+	// SetHistogramOptions sets the histogram aggregation options.
+	SetHistogramOptions(value raggregation.HistogramOptions) Options
+
+	// HistogramOptions returns the histogram aggregation options.
+	HistogramOptions() raggregation.HistogramOptions
+
 	// SetAdminClient sets the administrative client.
 	SetAdminClient(value client.AdminClient) Options
 
@@ -242,6 +275,30 @@ type Options interface {
 	// ResignTimeout returns the resign timeout.
 	ResignTimeout() time.Duration
 
+	// SetCheckpointStore sets the store the aggregation state of shards is
+	// checkpointed to, checkpointing is disabled if the store is nil.
+	SetCheckpointStore(value CheckpointStore) Options
+
+	// CheckpointStore returns the store the aggregation state of shards is
+	// checkpointed to, checkpointing is disabled if the store is nil.
+	CheckpointStore() CheckpointStore
+
+	// SetCheckpointInterval sets the interval between checkpoints of the
+	// aggregation state of shards.
+	SetCheckpointInterval(value time.Duration) Options
+
+	// CheckpointInterval returns the interval between checkpoints of the
+	// aggregation state of shards.
+	CheckpointInterval() time.Duration
+
+	// SetCheckpointMaxAge sets the max age of a checkpoint restored when the
+	// aggregator opens, older checkpoints are ignored.
+	SetCheckpointMaxAge(value time.Duration) Options
+
+	// CheckpointMaxAge returns the max age of a checkpoint restored when the
+	// aggregator opens, older checkpoints are ignored.
+	CheckpointMaxAge() time.Duration
+
 	// SetMaxAllowedForwardingDelayFn sets the function that determines the maximum forwarding
 	// delay for given metric resolution and number of times the metric has been forwarded.
 	SetMaxAllowedForwardingDelayFn(value MaxAllowedForwardingDelayFn) Options
@@ -262,6 +319,20 @@ type Options interface {
 	// BufferForFutureTimedMetric returns the size of the buffer for timed metrics in the future.
 	BufferForFutureTimedMetric() time.Duration
 
+	// SetLateTimedMetricPolicyFn sets the function determining how timed metrics
+	// arriving later than the buffer for past timed metrics are handled.
+	SetLateTimedMetricPolicyFn(value LateTimedMetricPolicyFn) Options
+
+	// LateTimedMetricPolicyFn returns the function determining how timed metrics
+	// arriving later than the buffer for past timed metrics are handled.
+	LateTimedMetricPolicyFn() LateTimedMetricPolicyFn
+
+	// SetLateTimedMetricWriter sets the writer for late timed metrics routed to the side output.
+	SetLateTimedMetricWriter(value writer.Writer) Options
+
+	// LateTimedMetricWriter returns the writer for late timed metrics routed to the side output.
+	LateTimedMetricWriter() writer.Writer
+
 	// SetMaxNumCachedSourceSets sets the maximum number of cached source sets.
 	SetMaxNumCachedSourceSets(value int) Options
 
@@ -298,6 +369,12 @@ type Options interface {
 	// GaugeElemPool returns the gauge element pool.
 	GaugeElemPool() GaugeElemPool
 
+	// SetHistogramElemPool sets the histogram element pool.
+	SetHistogramElemPool(value HistogramElemPool) Options
+
+	// HistogramElemPool returns the histogram element pool.
+	HistogramElemPool() HistogramElemPool
+
 	/// Read-only derived options.
 
 	// FullCounterPrefix returns the full prefix for counters.
@@ -309,6 +386,9 @@ type Options interface {
 	// FullGaugePrefix returns the full prefix for gauges.
 	FullGaugePrefix() []byte
 
+	// FullHistogramPrefix returns the full prefix for histograms.
+	FullHistogramPrefix() []byte
+
 	// SetVerboseErrors returns whether to return verbose errors or not.
 	SetVerboseErrors(value bool) Options
 
@@ -331,10 +411,14 @@ type options struct {
 	counterPrefix                    []byte
 	timerPrefix                      []byte
 	gaugePrefix                      []byte
+	histogramPrefix                  []byte
 	timeLock                         *sync.RWMutex
 	clockOpts                        clock.Options
 	instrumentOpts                   instrument.Options
 	streamOpts                       cm.Options
+	timerQuantileSketchType          raggregation.QuantileSketchType
+	ddsketchOpts                     ddsketch.Options
+	histogramOpts                    raggregation.HistogramOptions
 	adminClient                      client.AdminClient
 	runtimeOptsManager               runtime.OptionsManager
 	placementManager                 PlacementManager
@@ -352,23 +436,30 @@ type options struct {
 	flushTimesManager                FlushTimesManager
 	electionManager                  ElectionManager
 	resignTimeout                    time.Duration
+	checkpointStore                  CheckpointStore
+	checkpointInterval               time.Duration
+	checkpointMaxAge                 time.Duration
 	maxAllowedForwardingDelayFn      MaxAllowedForwardingDelayFn
 	bufferForPastTimedMetricFn       BufferForPastTimedMetricFn
 	bufferForFutureTimedMetric       time.Duration
+	lateTimedMetricPolicyFn          LateTimedMetricPolicyFn
+	lateTimedMetricWriter            writer.Writer
 	maxNumCachedSourceSets           int
 	discardNaNAggregatedValues       bool
 	entryPool                        EntryPool
 	counterElemPool                  CounterElemPool
 	timerElemPool                    TimerElemPool
 	gaugeElemPool                    GaugeElemPool
+	histogramElemPool                HistogramElemPool
 	verboseErrors                    bool
 	addToReset                       bool
 
 	// Derived options.
-	fullCounterPrefix []byte
-	fullTimerPrefix   []byte
-	fullGaugePrefix   []byte
-	timerQuantiles    []float64
+	fullCounterPrefix   []byte
+	fullTimerPrefix     []byte
+	fullGaugePrefix     []byte
+	fullHistogramPrefix []byte
+	timerQuantiles      []float64
 }
 
 // NewOptions create a new set of options.
@@ -376,17 +467,22 @@ func NewOptions() Options {
 	aggTypesOptions := aggregation.NewTypesOptions().
 		SetCounterTypeStringTransformFn(aggregation.EmptyTransform).
 		SetTimerTypeStringTransformFn(aggregation.SuffixTransform).
-		SetGaugeTypeStringTransformFn(aggregation.EmptyTransform)
+		SetGaugeTypeStringTransformFn(aggregation.EmptyTransform).
+		SetHistogramTypeStringTransformFn(aggregation.SuffixTransform)
 	o := &options{
 		aggTypesOptions:                  aggTypesOptions,
 		metricPrefix:                     defaultMetricPrefix,
 		counterPrefix:                    defaultCounterPrefix,
 		timerPrefix:                      defaultTimerPrefix,
 		gaugePrefix:                      defaultGaugePrefix,
+		histogramPrefix:                  defaultHistogramPrefix,
 		timeLock:                         &sync.RWMutex{},
 		clockOpts:                        clock.NewOptions(),
 		instrumentOpts:                   instrument.NewOptions(),
 		streamOpts:                       cm.NewOptions(),
+		timerQuantileSketchType:          raggregation.CMQuantileSketch,
+		ddsketchOpts:                     ddsketch.NewOptions(),
+		histogramOpts:                    raggregation.NewHistogramOptions(),
 		runtimeOptsManager:               runtime.NewOptionsManager(runtime.NewOptions()),
 		shardFn:                          sharding.Murmur32Hash.MustShardFn(),
 		bufferDurationBeforeShardCutover: defaultBufferDurationBeforeShardCutover,
@@ -398,9 +494,13 @@ func NewOptions() Options {
 		maxTimerBatchSizePerWrite:        defaultMaxTimerBatchSizePerWrite,
 		defaultStoragePolicies:           defaultDefaultStoragePolicies,
 		resignTimeout:                    defaultResignTimeout,
+		checkpointInterval:               defaultCheckpointInterval,
+		checkpointMaxAge:                 defaultCheckpointMaxAge,
 		maxAllowedForwardingDelayFn:      defaultMaxAllowedForwardingDelayFn,
 		bufferForPastTimedMetricFn:       defaultBufferForPastTimedMetricFn,
 		bufferForFutureTimedMetric:       defaultTimedMetricBuffer,
+		lateTimedMetricPolicyFn:          defaultLateTimedMetricPolicyFn,
+		lateTimedMetricWriter:            writer.NewBlackholeWriter(),
 		maxNumCachedSourceSets:           defaultMaxNumCachedSourceSets,
 		discardNaNAggregatedValues:       defaultDiscardNaNAggregatedValues,
 		verboseErrors:                    defaultVerboseErrors,
@@ -459,6 +559,17 @@ func (o *options) GaugePrefix() []byte {
 	return o.gaugePrefix
 }
 
+func (o *options) SetHistogramPrefix(value []byte) Options {
+	opts := *o
+	opts.histogramPrefix = value
+	opts.computeFullHistogramPrefix()
+	return &opts
+}
+
+func (o *options) HistogramPrefix() []byte {
+	return o.histogramPrefix
+}
+
 func (o *options) SetTimeLock(value *sync.RWMutex) Options {
 	opts := *o
 	opts.timeLock = value
@@ -509,6 +620,36 @@ func (o *options) StreamOptions() cm.Options {
 	return o.streamOpts
 }
 
+func (o *options) SetTimerQuantileSketchType(value raggregation.QuantileSketchType) Options {
+	opts := *o
+	opts.timerQuantileSketchType = value
+	return &opts
+}
+
+func (o *options) TimerQuantileSketchType() raggregation.QuantileSketchType {
+	return o.timerQuantileSketchType
+}
+
+func (o *options) SetDDSketchOptions(value ddsketch.Options) Options {
+	opts := *o
+	opts.ddsketchOpts = value
+	return &opts
+}
+
+func (o *options) DDSketchOptions() ddsketch.Options {
+	return o.ddsketchOpts
+}
+
+func (o *options) SetHistogramOptions(value raggregation.HistogramOptions) Options {
+	opts := *o
+	opts.histogramOpts = value
+	return &opts
+}
+
+func (o *options) HistogramOptions() raggregation.HistogramOptions {
+	return o.histogramOpts
+}
+
 func (o *options) SetAdminClient(value client.AdminClient) Options {
 	opts := *o
 	opts.adminClient = value
@@ -679,6 +820,36 @@ func (o *options) ResignTimeout() time.Duration {
 	return o.resignTimeout
 }
 
+func (o *options) SetCheckpointStore(value CheckpointStore) Options {
+	opts := *o
+	opts.checkpointStore = value
+	return &opts
+}
+
+func (o *options) CheckpointStore() CheckpointStore {
+	return o.checkpointStore
+}
+
+func (o *options) SetCheckpointInterval(value time.Duration) Options {
+	opts := *o
+	opts.checkpointInterval = value
+	return &opts
+}
+
+func (o *options) CheckpointInterval() time.Duration {
+	return o.checkpointInterval
+}
+
+func (o *options) SetCheckpointMaxAge(value time.Duration) Options {
+	opts := *o
+	opts.checkpointMaxAge = value
+	return &opts
+}
+
+func (o *options) CheckpointMaxAge() time.Duration {
+	return o.checkpointMaxAge
+}
+
 func (o *options) SetMaxAllowedForwardingDelayFn(value MaxAllowedForwardingDelayFn) Options {
 	opts := *o
 	opts.maxAllowedForwardingDelayFn = value
@@ -709,6 +880,26 @@ func (o *options) BufferForFutureTimedMetric() time.Duration {
 	return o.bufferForFutureTimedMetric
 }
 
+func (o *options) SetLateTimedMetricPolicyFn(value LateTimedMetricPolicyFn) Options {
+	opts := *o
+	opts.lateTimedMetricPolicyFn = value
+	return &opts
+}
+
+func (o *options) LateTimedMetricPolicyFn() LateTimedMetricPolicyFn {
+	return o.lateTimedMetricPolicyFn
+}
+
+func (o *options) SetLateTimedMetricWriter(value writer.Writer) Options {
+	opts := *o
+	opts.lateTimedMetricWriter = value
+	return &opts
+}
+
+func (o *options) LateTimedMetricWriter() writer.Writer {
+	return o.lateTimedMetricWriter
+}
+
 func (o *options) SetMaxNumCachedSourceSets(value int) Options {
 	opts := *o
 	opts.maxNumCachedSourceSets = value
@@ -769,6 +960,16 @@ func (o *options) GaugeElemPool() GaugeElemPool {
 	return o.gaugeElemPool
 }
 
+func (o *options) SetHistogramElemPool(value HistogramElemPool) Options {
+	opts := *o
+	opts.histogramElemPool = value
+	return &opts
+}
+
+func (o *options) HistogramElemPool() HistogramElemPool {
+	return o.histogramElemPool
+}
+
 func (o *options) SetVerboseErrors(value bool) Options {
 	opts := *o
 	opts.verboseErrors = value
@@ -791,6 +992,10 @@ func (o *options) FullGaugePrefix() []byte {
 	return o.fullGaugePrefix
 }
 
+func (o *options) FullHistogramPrefix() []byte {
+	return o.fullHistogramPrefix
+}
+
 func (o *options) TimerQuantiles() []float64 {
 	return o.timerQuantiles
 }
@@ -816,6 +1021,11 @@ func (o *options) initPools() {
 	o.gaugeElemPool.Init(func() *GaugeElem {
 		return MustNewGaugeElem(nil, policy.EmptyStoragePolicy, aggregation.DefaultTypes, applied.DefaultPipeline, 0, WithPrefixWithSuffix, o)
 	})
+
+	o.histogramElemPool = NewHistogramElemPool(nil)
+	o.histogramElemPool.Init(func() *HistogramElem {
+		return MustNewHistogramElem(nil, policy.EmptyStoragePolicy, aggregation.DefaultTypes, applied.DefaultPipeline, 0, WithPrefixWithSuffix, o)
+	})
 }
 
 func (o *options) computeAllDerived() {
@@ -826,6 +1036,7 @@ func (o *options) computeFullPrefixes() {
 	o.computeFullCounterPrefix()
 	o.computeFullTimerPrefix()
 	o.computeFullGaugePrefix()
+	o.computeFullHistogramPrefix()
 }
 
 func (o *options) computeFullCounterPrefix() {
@@ -849,6 +1060,13 @@ func (o *options) computeFullGaugePrefix() {
 	o.fullGaugePrefix = fullGaugePrefix
 }
 
+func (o *options) computeFullHistogramPrefix() {
+	fullHistogramPrefix := make([]byte, len(o.metricPrefix)+len(o.histogramPrefix))
+	n := copy(fullHistogramPrefix, o.metricPrefix)
+	copy(fullHistogramPrefix[n:], o.histogramPrefix)
+	o.fullHistogramPrefix = fullHistogramPrefix
+}
+
 func (o *options) AddToReset() bool {
 	return o.addToReset
 }
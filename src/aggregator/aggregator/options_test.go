@@ -25,11 +25,15 @@ import (
 	"testing"
 	"time"
 
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	"github.com/m3db/m3/src/aggregator/aggregation/quantile/ddsketch"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
 	"github.com/m3db/m3/src/aggregator/client"
 	"github.com/m3db/m3/src/aggregator/runtime"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/instrument"
 
@@ -123,6 +127,18 @@ func TestSetStreamOptions(t *testing.T) {
 	require.Equal(t, value, o.StreamOptions())
 }
 
+func TestSetTimerQuantileSketchType(t *testing.T) {
+	require.Equal(t, raggregation.CMQuantileSketch, NewOptions().TimerQuantileSketchType())
+	o := NewOptions().SetTimerQuantileSketchType(raggregation.DDSketchQuantileSketch)
+	require.Equal(t, raggregation.DDSketchQuantileSketch, o.TimerQuantileSketchType())
+}
+
+func TestSetDDSketchOptions(t *testing.T) {
+	value := ddsketch.NewOptions().SetRelativeAccuracy(0.05)
+	o := NewOptions().SetDDSketchOptions(value)
+	require.Equal(t, value, o.DDSketchOptions())
+}
+
 func TestSetAdminClient(t *testing.T) {
 	c, err := client.NewClient(client.NewOptions())
 	require.NoError(t, err)
@@ -191,12 +207,54 @@ func TestSetTimedAggregationBufferFutureFn(t *testing.T) {
 	require.Equal(t, 3*time.Minute, o.BufferForFutureTimedMetric())
 }
 
+func TestSetLateTimedMetricPolicyFn(t *testing.T) {
+	value := func(id.RawID, policy.StoragePolicy) LateTimedMetricPolicy {
+		return LateTimedMetricPolicy{
+			AllowedLateness: time.Hour,
+			Action:          ReaggregateLateTimedMetric,
+		}
+	}
+	o := NewOptions().SetLateTimedMetricPolicyFn(value)
+	fn := o.LateTimedMetricPolicyFn()
+	require.Equal(t, LateTimedMetricPolicy{
+		AllowedLateness: time.Hour,
+		Action:          ReaggregateLateTimedMetric,
+	}, fn(id.RawID("foo"), policy.EmptyStoragePolicy))
+}
+
+func TestSetLateTimedMetricWriter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := writer.NewMockWriter(ctrl)
+	o := NewOptions().SetLateTimedMetricWriter(w)
+	require.Equal(t, w, o.LateTimedMetricWriter())
+}
+
 func TestSetEntryCheckInterval(t *testing.T) {
 	value := time.Minute
 	o := NewOptions().SetEntryCheckInterval(value)
 	require.Equal(t, value, o.EntryCheckInterval())
 }
 
+func TestSetCheckpointStore(t *testing.T) {
+	store := &fileCheckpointStore{dir: "/var/lib/m3aggregator"}
+	o := NewOptions().SetCheckpointStore(store)
+	require.Equal(t, store, o.CheckpointStore())
+}
+
+func TestSetCheckpointInterval(t *testing.T) {
+	value := time.Minute
+	o := NewOptions().SetCheckpointInterval(value)
+	require.Equal(t, value, o.CheckpointInterval())
+}
+
+func TestSetCheckpointMaxAge(t *testing.T) {
+	value := time.Hour
+	o := NewOptions().SetCheckpointMaxAge(value)
+	require.Equal(t, value, o.CheckpointMaxAge())
+}
+
 func TestSetEntryCheckBatchPercent(t *testing.T) {
 	value := 0.05
 	o := NewOptions().SetEntryCheckBatchPercent(value)
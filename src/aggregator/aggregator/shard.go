@@ -22,6 +22,7 @@ package aggregator
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"strconv"
 	"sync"
@@ -254,6 +255,39 @@ func (s *aggregatorShard) AddForwarded(
 	return nil
 }
 
+// Checkpoint returns the encoded checkpoint of the aggregation state of the shard.
+func (s *aggregatorShard) Checkpoint() ([]byte, error) {
+	s.RLock()
+	closed := s.closed
+	s.RUnlock()
+	if closed {
+		return nil, errAggregatorShardClosed
+	}
+	enc := newCheckpointEncoder(s.shard, s.nowFn().UnixNano())
+	if err := s.metricMap.Checkpoint(enc); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
+
+// Restore restores the aggregation state of the shard from a checkpoint,
+// returning the number of aggregation elements restored. If missingOnly is
+// true only the aggregation windows that the shard has neither received nor
+// flushed yet are restored.
+func (s *aggregatorShard) Restore(dec *checkpointDecoder, missingOnly bool) (int, error) {
+	if dec.Shard() != s.shard {
+		return 0, fmt.Errorf("checkpoint of shard %d cannot be restored into shard %d",
+			dec.Shard(), s.shard)
+	}
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.closed {
+		return 0, errAggregatorShardClosed
+	}
+	return s.metricMap.Restore(dec, missingOnly)
+}
+
 func (s *aggregatorShard) Tick(target time.Duration) tickResult {
 	return s.metricMap.Tick(target)
 }
@@ -73,6 +73,12 @@ type Client interface {
 		metadatas metadata.StagedMetadatas,
 	) error
 
+	// WriteUntimedHistogram writes untimed histogram metrics.
+	WriteUntimedHistogram(
+		histogram unaggregated.Histogram,
+		metadatas metadata.StagedMetadatas,
+	) error
+
 	// WriteTimed writes timed metrics.
 	WriteTimed(
 		metric aggregated.Metric,
@@ -123,6 +129,7 @@ type clientMetrics struct {
 	writeUntimedCounter    instrument.MethodMetrics
 	writeUntimedBatchTimer instrument.MethodMetrics
 	writeUntimedGauge      instrument.MethodMetrics
+	writeUntimedHistogram  instrument.MethodMetrics
 	writePassthrough       instrument.MethodMetrics
 	writeForwarded         instrument.MethodMetrics
 	flush                  instrument.MethodMetrics
@@ -138,6 +145,7 @@ func newClientMetrics(
 		writeUntimedCounter:    instrument.NewMethodMetrics(scope, "writeUntimedCounter", opts),
 		writeUntimedBatchTimer: instrument.NewMethodMetrics(scope, "writeUntimedBatchTimer", opts),
 		writeUntimedGauge:      instrument.NewMethodMetrics(scope, "writeUntimedGauge", opts),
+		writeUntimedHistogram:  instrument.NewMethodMetrics(scope, "writeUntimedHistogram", opts),
 		writePassthrough:       instrument.NewMethodMetrics(scope, "writePassthrough", opts),
 		writeForwarded:         instrument.NewMethodMetrics(scope, "writeForwarded", opts),
 		flush:                  instrument.NewMethodMetrics(scope, "flush", opts),
@@ -316,6 +324,23 @@ func (c *client) WriteUntimedGauge(
 	return err
 }
 
+func (c *client) WriteUntimedHistogram(
+	histogram unaggregated.Histogram,
+	metadatas metadata.StagedMetadatas,
+) error {
+	callStart := c.nowFn()
+	payload := payloadUnion{
+		payloadType: untimedType,
+		untimed: untimedPayload{
+			metric:    histogram.ToUnion(),
+			metadatas: metadatas,
+		},
+	}
+	err := c.write(histogram.ID, c.nowNanos(), payload)
+	c.metrics.writeUntimedHistogram.ReportSuccessOrError(err, c.nowFn().Sub(callStart))
+	return err
+}
+
 func (c *client) WriteTimed(
 	metric aggregated.Metric,
 	metadata metadata.TimedMetadata,
@@ -559,6 +584,7 @@ type message struct {
 	cm     metricpb.CounterWithMetadatas
 	bm     metricpb.BatchTimerWithMetadatas
 	gm     metricpb.GaugeWithMetadatas
+	hm     metricpb.HistogramWithMetadatas
 	fm     metricpb.ForwardedMetricWithMetadata
 	tm     metricpb.TimedMetricWithMetadata
 	tms    metricpb.TimedMetricWithMetadatas
@@ -620,6 +646,19 @@ func (m *message) Encode(
 				Type:               metricpb.MetricWithMetadatas_GAUGE_WITH_METADATAS,
 				GaugeWithMetadatas: &m.gm,
 			}
+		case metric.HistogramType:
+			value := unaggregated.HistogramWithMetadatas{
+				Histogram:       payload.untimed.metric.Histogram(),
+				StagedMetadatas: payload.untimed.metadatas,
+			}
+			if err := value.ToProto(&m.hm); err != nil {
+				return err
+			}
+
+			m.metric = metricpb.MetricWithMetadatas{
+				Type:                   metricpb.MetricWithMetadatas_HISTOGRAM_WITH_METADATAS,
+				HistogramWithMetadatas: &m.hm,
+			}
 		default:
 			return fmt.Errorf("unrecognized metric type: %v",
 				payload.untimed.metric.Type)
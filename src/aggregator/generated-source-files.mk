@@ -7,7 +7,7 @@ m3db_package_path    := $(gopath_prefix)/$(m3db_package)
 
 # Generation rule for all generated types
 .PHONY: genny-all
-genny-all: genny-aggregator-counter-elem genny-aggregator-timer-elem genny-aggregator-gauge-elem
+genny-all: genny-aggregator-counter-elem genny-aggregator-timer-elem genny-aggregator-gauge-elem genny-aggregator-histogram-elem
 
 .PHONY: genny-aggregator-counter-elem
 genny-aggregator-counter-elem:
@@ -29,3 +29,10 @@ genny-aggregator-gauge-elem:
 		| awk '/^package/{i++}i'                                                                          \
 		| genny -out=$(m3db_package_path)/src/aggregator/aggregator/gauge_elem_gen.go -pkg=aggregator gen \
 		"timedAggregation=timedGauge lockedAggregation=lockedGaugeAggregation typeSpecificAggregation=gaugeAggregation typeSpecificElemBase=gaugeElemBase genericElemPool=GaugeElemPool GenericElem=GaugeElem"
+
+.PHONY: genny-aggregator-histogram-elem
+genny-aggregator-histogram-elem:
+	cat $(m3db_package_path)/src/aggregator/aggregator/generic_elem.go                                      \
+		| awk '/^package/{i++}i'                                                                              \
+		| genny -out=$(m3db_package_path)/src/aggregator/aggregator/histogram_elem_gen.go -pkg=aggregator gen \
+		"timedAggregation=timedHistogram lockedAggregation=lockedHistogramAggregation typeSpecificAggregation=histogramAggregation typeSpecificElemBase=histogramElemBase genericElemPool=HistogramElemPool GenericElem=HistogramElem"
@@ -0,0 +1,192 @@
+// +build integration
+
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package integration
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/aggregator/aggregator"
+	"github.com/m3db/m3/src/cluster/placement"
+	maggregation "github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/metadata"
+	"github.com/m3db/m3/src/metrics/metric"
+	"github.com/m3db/m3/src/metrics/metric/aggregated"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/x/clock"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOneClientLateTimedMetrics(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	var (
+		reaggregateID = id.RawID("late.reaggregate.id")
+		sideOutputID  = id.RawID("late.sideoutput.id")
+	)
+	serverOpts := newTestServerOptions().
+		SetLateTimedMetricPolicyFn(func(metricID id.RawID, _ policy.StoragePolicy) aggregator.LateTimedMetricPolicy {
+			action := aggregator.SideOutputLateTimedMetric
+			if bytes.Equal(metricID, reaggregateID) {
+				action = aggregator.ReaggregateLateTimedMetric
+			}
+			return aggregator.LateTimedMetricPolicy{
+				AllowedLateness: 10 * time.Second,
+				Action:          action,
+			}
+		})
+
+	// Clock setup.
+	var lock sync.RWMutex
+	now := time.Now().Truncate(time.Hour)
+	getNowFn := func() time.Time {
+		lock.RLock()
+		t := now
+		lock.RUnlock()
+		return t
+	}
+	setNowFn := func(t time.Time) {
+		lock.Lock()
+		now = t
+		lock.Unlock()
+	}
+	clockOpts := clock.NewOptions().SetNowFn(getNowFn)
+	serverOpts = serverOpts.SetClockOptions(clockOpts)
+
+	// Placement setup.
+	numShards := 1024
+	cfg := placementInstanceConfig{
+		instanceID:          serverOpts.InstanceID(),
+		shardSetID:          serverOpts.ShardSetID(),
+		shardStartInclusive: 0,
+		shardEndExclusive:   uint32(numShards),
+	}
+	instance := cfg.newPlacementInstance()
+	placement := newPlacement(numShards, []placement.Instance{instance})
+	placementKey := serverOpts.PlacementKVKey()
+	placementStore := serverOpts.KVStore()
+	require.NoError(t, setPlacement(placementKey, placementStore, placement))
+
+	// Create server.
+	testServer := newTestServerSetup(t, serverOpts)
+	defer testServer.close()
+
+	// Start the server.
+	log := testServer.aggregatorOpts.InstrumentOptions().Logger()
+	log.Info("test one client sending late timed metrics")
+	require.NoError(t, testServer.startServer())
+	log.Info("server is now up")
+	require.NoError(t, testServer.waitUntilLeader())
+	log.Info("server is now the leader")
+
+	client := testServer.newClient()
+	require.NoError(t, client.connect())
+	defer client.close()
+
+	var (
+		start      = getNowFn()
+		resolution = 2 * time.Second
+		sp         = policy.NewStoragePolicy(resolution, xtime.Second, time.Hour)
+		md         = metadata.TimedMetadata{
+			AggregationID: maggregation.MustCompressTypes(maggregation.Sum),
+			StoragePolicy: sp,
+		}
+		bufferPast = testServer.aggregatorOpts.BufferForPastTimedMetricFn()(resolution)
+	)
+	writeFn := func(metricID id.RawID, timestamp time.Time, value float64) {
+		require.NoError(t, client.writeTimedMetricWithMetadata(aggregated.Metric{
+			Type:      metric.CounterType,
+			ID:        metricID,
+			TimeNanos: timestamp.UnixNano(),
+			Value:     value,
+		}, md))
+		require.NoError(t, client.flush())
+
+		// Give server some time to process the incoming packets.
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// Write timed metrics on time.
+	writeFn(reaggregateID, start, 10)
+	writeFn(sideOutputID, start, 5)
+
+	// Move time forward and wait for the aggregation window to be flushed.
+	setNowFn(start.Add(resolution + bufferPast + time.Second))
+	time.Sleep(2 * time.Second)
+
+	// Write timed metrics for the flushed aggregation window within the
+	// allowed lateness.
+	lateTimestamp := start.Add(time.Second)
+	writeFn(reaggregateID, lateTimestamp, 3)
+	writeFn(sideOutputID, lateTimestamp, 4)
+
+	// Move time forward and wait for the corrected aggregation to be flushed.
+	setNowFn(start.Add(resolution + bufferPast + 10*time.Second))
+	time.Sleep(2 * time.Second)
+
+	// Stop the server.
+	require.NoError(t, testServer.stopServer())
+	log.Info("server is now down")
+
+	// Validate results, the reaggregated window is flushed again with the
+	// corrected value while the late timed metric with the side output action
+	// is written as is.
+	flushedAt := start.Add(resolution).UnixNano()
+	expected := map[string][]aggregated.MetricWithStoragePolicy{
+		string(reaggregateID): {
+			{
+				Metric:        aggregated.Metric{ID: reaggregateID, TimeNanos: flushedAt, Value: 10},
+				StoragePolicy: sp,
+			},
+			{
+				Metric:        aggregated.Metric{ID: reaggregateID, TimeNanos: flushedAt, Value: 13},
+				StoragePolicy: sp,
+			},
+		},
+		string(sideOutputID): {
+			{
+				Metric:        aggregated.Metric{ID: sideOutputID, TimeNanos: flushedAt, Value: 5},
+				StoragePolicy: sp,
+			},
+			{
+				Metric:        aggregated.Metric{ID: sideOutputID, TimeNanos: lateTimestamp.UnixNano(), Value: 4},
+				StoragePolicy: sp,
+			},
+		},
+	}
+	actual := make(map[string][]aggregated.MetricWithStoragePolicy)
+	testServer.resultLock.Lock()
+	for _, result := range *testServer.results {
+		key := string(result.ID)
+		actual[key] = append(actual[key], result)
+	}
+	testServer.resultLock.Unlock()
+	require.Equal(t, expected, actual)
+}
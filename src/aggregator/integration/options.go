@@ -29,6 +29,8 @@ import (
 	"github.com/m3db/m3/src/cluster/kv"
 	"github.com/m3db/m3/src/cluster/kv/mem"
 	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/instrument"
 )
@@ -188,6 +190,12 @@ type testServerOptions interface {
 
 	// DiscardNaNAggregatedValues determines whether NaN aggregated values are discarded.
 	DiscardNaNAggregatedValues() bool
+
+	// SetLateTimedMetricPolicyFn sets the late timed metric policy function.
+	SetLateTimedMetricPolicyFn(value aggregator.LateTimedMetricPolicyFn) testServerOptions
+
+	// LateTimedMetricPolicyFn returns the late timed metric policy function.
+	LateTimedMetricPolicyFn() aggregator.LateTimedMetricPolicyFn
 }
 
 // nolint: maligned
@@ -215,6 +223,7 @@ type serverOptions struct {
 	maxJitterFn                 aggregator.FlushJitterFn
 	maxAllowedForwardingDelayFn aggregator.MaxAllowedForwardingDelayFn
 	discardNaNAggregatedValues  bool
+	lateTimedMetricPolicyFn     aggregator.LateTimedMetricPolicyFn
 }
 
 func newTestServerOptions() testServerOptions {
@@ -245,6 +254,7 @@ func newTestServerOptions() testServerOptions {
 		maxJitterFn:                 defaultMaxJitterFn,
 		maxAllowedForwardingDelayFn: defaultMaxAllowedForwardingDelayFn,
 		discardNaNAggregatedValues:  defaultDiscardNaNAggregatedValues,
+		lateTimedMetricPolicyFn:     defaultLateTimedMetricPolicyFn,
 	}
 }
 
@@ -478,6 +488,16 @@ func (o *serverOptions) DiscardNaNAggregatedValues() bool {
 	return o.discardNaNAggregatedValues
 }
 
+func (o *serverOptions) SetLateTimedMetricPolicyFn(value aggregator.LateTimedMetricPolicyFn) testServerOptions {
+	opts := *o
+	opts.lateTimedMetricPolicyFn = value
+	return &opts
+}
+
+func (o *serverOptions) LateTimedMetricPolicyFn() aggregator.LateTimedMetricPolicyFn {
+	return o.lateTimedMetricPolicyFn
+}
+
 func defaultMaxJitterFn(interval time.Duration) time.Duration {
 	return time.Duration(0.75 * float64(interval))
 }
@@ -488,3 +508,7 @@ func defaultMaxAllowedForwardingDelayFn(
 ) time.Duration {
 	return resolution + time.Second*time.Duration(numForwardedTimes)
 }
+
+func defaultLateTimedMetricPolicyFn(id.RawID, policy.StoragePolicy) aggregator.LateTimedMetricPolicy {
+	return aggregator.LateTimedMetricPolicy{}
+}
@@ -111,7 +111,8 @@ func newTestServerSetup(t *testing.T, opts testServerOptions) *testServerSetup {
 		SetAggregationTypesOptions(opts.AggregationTypesOptions()).
 		SetEntryCheckInterval(opts.EntryCheckInterval()).
 		SetMaxAllowedForwardingDelayFn(opts.MaxAllowedForwardingDelayFn()).
-		SetDiscardNaNAggregatedValues(opts.DiscardNaNAggregatedValues())
+		SetDiscardNaNAggregatedValues(opts.DiscardNaNAggregatedValues()).
+		SetLateTimedMetricPolicyFn(opts.LateTimedMetricPolicyFn())
 
 	// Set up placement manager.
 	placementWatcherOpts := placement.NewStagedPlacementWatcherOptions().
@@ -192,7 +193,10 @@ func newTestServerSetup(t *testing.T, opts testServerOptions) *testServerSetup {
 	if err != nil {
 		panic(err.Error())
 	}
-	aggregatorOpts = aggregatorOpts.SetFlushHandler(handler).SetPassthroughWriter(pw)
+	aggregatorOpts = aggregatorOpts.
+		SetFlushHandler(handler).
+		SetPassthroughWriter(pw).
+		SetLateTimedMetricWriter(pw)
 
 	// Set up entry pool.
 	runtimeOpts := runtime.NewOptions()
@@ -221,6 +225,12 @@ func newTestServerSetup(t *testing.T, opts testServerOptions) *testServerSetup {
 		return aggregator.MustNewGaugeElem(nil, policy.EmptyStoragePolicy, aggregation.DefaultTypes, applied.DefaultPipeline, 0, aggregator.NoPrefixNoSuffix, aggregatorOpts)
 	})
 
+	histogramElemPool := aggregator.NewHistogramElemPool(nil)
+	aggregatorOpts = aggregatorOpts.SetHistogramElemPool(histogramElemPool)
+	histogramElemPool.Init(func() *aggregator.HistogramElem {
+		return aggregator.MustNewHistogramElem(nil, policy.EmptyStoragePolicy, aggregation.DefaultTypes, applied.DefaultPipeline, 0, aggregator.NoPrefixNoSuffix, aggregatorOpts)
+	})
+
 	return &testServerSetup{
 		opts:             opts,
 		rawTCPAddr:       opts.RawTCPAddr(),
@@ -122,6 +122,14 @@ func (s *server) handleMessage(
 		return s.aggregator.AddUntimed(
 			union.GaugeWithMetadatas.ToUnion(),
 			union.GaugeWithMetadatas.StagedMetadatas)
+	case metricpb.MetricWithMetadatas_HISTOGRAM_WITH_METADATAS:
+		err := union.HistogramWithMetadatas.FromProto(pb.HistogramWithMetadatas)
+		if err != nil {
+			return err
+		}
+		return s.aggregator.AddUntimed(
+			union.HistogramWithMetadatas.ToUnion(),
+			union.HistogramWithMetadatas.StagedMetadatas)
 	case metricpb.MetricWithMetadatas_FORWARDED_METRIC_WITH_METADATA:
 		err := union.ForwardedMetricWithMetadata.FromProto(pb.ForwardedMetricWithMetadata)
 		if err != nil {
@@ -31,7 +31,9 @@ import (
 	"strings"
 	"time"
 
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	"github.com/m3db/m3/src/aggregator/aggregation/quantile/ddsketch"
 	"github.com/m3db/m3/src/aggregator/aggregator"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
@@ -44,6 +46,8 @@ import (
 	"github.com/m3db/m3/src/cluster/services"
 	"github.com/m3db/m3/src/cmd/services/m3aggregator/serve"
 	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/filters"
+	"github.com/m3db/m3/src/metrics/metric/id"
 	"github.com/m3db/m3/src/metrics/pipeline/applied"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/x/clock"
@@ -58,6 +62,7 @@ import (
 var (
 	errNoKVClientConfiguration = errors.New("no kv client configuration")
 	errEmptyJitterBucketList   = errors.New("empty jitter bucket list")
+	errNoCheckpointStore       = errors.New("no checkpoint directory or kv configuration")
 )
 
 var (
@@ -91,9 +96,22 @@ type AggregatorConfiguration struct {
 	// Gauge metric prefix.
 	GaugePrefix *string `yaml:"gaugePrefix"`
 
+	// Histogram metric prefix.
+	HistogramPrefix *string `yaml:"histogramPrefix"`
+
 	// Stream configuration for computing quantiles.
 	Stream streamConfiguration `yaml:"stream"`
 
+	// TimerQuantileSketch is the type of sketch timers compute quantiles
+	// with, either cm streams or mergeable ddsketch sketches.
+	TimerQuantileSketch raggregation.QuantileSketchType `yaml:"timerQuantileSketch"`
+
+	// DDSketch configuration for computing quantiles with ddsketch.
+	DDSketch ddsketchConfiguration `yaml:"ddsketch"`
+
+	// Histogram configuration for aggregating histograms.
+	Histogram histogramConfiguration `yaml:"histogram"`
+
 	// Client configuration.
 	Client aggclient.Configuration `yaml:"client"`
 
@@ -118,6 +136,15 @@ type AggregatorConfiguration struct {
 	// Resign timeout.
 	ResignTimeout time.Duration `yaml:"resignTimeout"`
 
+	// Checkpoint configuration for checkpointing the aggregation state of
+	// shards so it can be restored when the aggregator restarts or, if the
+	// checkpoints are kept in kv, when a follower is elected leader.
+	Checkpoint *checkpointConfiguration `yaml:"checkpoint"`
+
+	// LateTimedMetrics configures how timed metrics arriving later than the
+	// buffer for past timed metrics are handled, they are dropped by default.
+	LateTimedMetrics *lateTimedMetricsConfiguration `yaml:"lateTimedMetrics"`
+
 	// ShutdownWaitTimeout if non-zero will be how long the aggregator waits from
 	// receiving a shutdown signal to exit. This can make coordinating graceful
 	// shutdowns between two replicas safer.
@@ -171,6 +198,9 @@ type AggregatorConfiguration struct {
 	// Pool of gauge elements.
 	GaugeElemPool pool.ObjectPoolConfiguration `yaml:"gaugeElemPool"`
 
+	// Pool of histogram elements.
+	HistogramElemPool pool.ObjectPoolConfiguration `yaml:"histogramElemPool"`
+
 	// Pool of entries.
 	EntryPool pool.ObjectPoolConfiguration `yaml:"entryPool"`
 
@@ -280,6 +310,7 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 	opts = setMetricPrefix(opts, c.CounterPrefix, opts.SetCounterPrefix)
 	opts = setMetricPrefix(opts, c.TimerPrefix, opts.SetTimerPrefix)
 	opts = setMetricPrefix(opts, c.GaugePrefix, opts.SetGaugePrefix)
+	opts = setMetricPrefix(opts, c.HistogramPrefix, opts.SetHistogramPrefix)
 
 	// Set stream options.
 	scope := instrumentOpts.MetricsScope()
@@ -290,6 +321,25 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 	}
 	opts = opts.SetStreamOptions(streamOpts)
 
+	// Set quantile sketch options.
+	if err := c.TimerQuantileSketch.Validate(); err != nil {
+		return nil, err
+	}
+	ddsketchOpts, err := c.DDSketch.NewDDSketchOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts = opts.
+		SetTimerQuantileSketchType(c.TimerQuantileSketch).
+		SetDDSketchOptions(ddsketchOpts)
+
+	// Set histogram options.
+	histogramOpts, err := c.Histogram.NewHistogramOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts = opts.SetHistogramOptions(histogramOpts)
+
 	// Set administrative client.
 	// TODO(xichen): client retry threshold likely needs to be low for faster retries.
 	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("client"))
@@ -347,6 +397,21 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 		opts = opts.SetResignTimeout(c.ResignTimeout)
 	}
 
+	// Set checkpoint options.
+	if c.Checkpoint != nil {
+		checkpointStore, err := c.Checkpoint.NewCheckpointStore(client)
+		if err != nil {
+			return nil, err
+		}
+		opts = opts.SetCheckpointStore(checkpointStore)
+		if c.Checkpoint.Interval != 0 {
+			opts = opts.SetCheckpointInterval(c.Checkpoint.Interval)
+		}
+		if c.Checkpoint.MaxAge != 0 {
+			opts = opts.SetCheckpointMaxAge(c.Checkpoint.MaxAge)
+		}
+	}
+
 	// Set flush times manager.
 	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("flush-times-manager"))
 	flushTimesManager, err := c.FlushTimesManager.NewFlushTimesManager(client, iOpts)
@@ -405,6 +470,24 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 	}
 	opts = opts.SetPassthroughWriter(passthroughWriter)
 
+	// Set late timed metric policy and writer.
+	if c.LateTimedMetrics != nil {
+		lateTimedMetricPolicyFn, err := c.LateTimedMetrics.NewLateTimedMetricPolicyFn()
+		if err != nil {
+			return nil, err
+		}
+		opts = opts.SetLateTimedMetricPolicyFn(lateTimedMetricPolicyFn)
+		if c.LateTimedMetrics.SideOutput != nil {
+			iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("late-timed-metric-writer"))
+			lateTimedMetricWriter, err := c.LateTimedMetrics.SideOutput.NewWriter(
+				client, flushHandler, iOpts, rwOpts, aggShardFn)
+			if err != nil {
+				return nil, err
+			}
+			opts = opts.SetLateTimedMetricWriter(lateTimedMetricWriter)
+		}
+	}
+
 	// Set max allowed forwarding delay function.
 	jitterEnabled := flushManagerOpts.JitterEnabled()
 	maxJitterFn := flushManagerOpts.MaxJitterFn()
@@ -467,6 +550,15 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 		return aggregator.MustNewGaugeElem(nil, policy.EmptyStoragePolicy, aggregation.DefaultTypes, applied.DefaultPipeline, 0, aggregator.NoPrefixNoSuffix, opts)
 	})
 
+	// Set histogram elem pool.
+	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("histogram-elem-pool"))
+	histogramElemPoolOpts := c.HistogramElemPool.NewObjectPoolOptions(iOpts)
+	histogramElemPool := aggregator.NewHistogramElemPool(histogramElemPoolOpts)
+	opts = opts.SetHistogramElemPool(histogramElemPool)
+	histogramElemPool.Init(func() *aggregator.HistogramElem {
+		return aggregator.MustNewHistogramElem(nil, policy.EmptyStoragePolicy, aggregation.DefaultTypes, applied.DefaultPipeline, 0, aggregator.NoPrefixNoSuffix, opts)
+	})
+
 	// Set entry pool.
 	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("entry-pool"))
 	entryPoolOpts := c.EntryPool.NewObjectPoolOptions(iOpts)
@@ -575,6 +667,261 @@ func (c *streamConfiguration) NewStreamOptions(instrumentOpts instrument.Options
 	return opts, nil
 }
 
+// ddsketchConfiguration contains configuration for computing quantiles with
+// ddsketch.
+type ddsketchConfiguration struct {
+	// Relative accuracy of the quantiles computed.
+	RelativeAccuracy *float64 `yaml:"relativeAccuracy"`
+
+	// Max number of buckets of each sign kept by a sketch.
+	MaxNumBuckets *int `yaml:"maxNumBuckets"`
+}
+
+func (c ddsketchConfiguration) NewDDSketchOptions() (ddsketch.Options, error) {
+	opts := ddsketch.NewOptions()
+	if c.RelativeAccuracy != nil {
+		opts = opts.SetRelativeAccuracy(*c.RelativeAccuracy)
+	}
+	if c.MaxNumBuckets != nil {
+		opts = opts.SetMaxNumBuckets(*c.MaxNumBuckets)
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// histogramConfiguration contains configuration for aggregating histograms.
+type histogramConfiguration struct {
+	// Schema histograms are aggregated with, lowered as needed to stay within
+	// the max number of buckets.
+	Schema *int32 `yaml:"schema"`
+
+	// Boundary of the zero bucket histograms are aggregated with.
+	ZeroThreshold *float64 `yaml:"zeroThreshold"`
+
+	// Max number of buckets of an aggregated histogram.
+	MaxBuckets *int `yaml:"maxBuckets"`
+}
+
+func (c histogramConfiguration) NewHistogramOptions() (raggregation.HistogramOptions, error) {
+	opts := raggregation.NewHistogramOptions()
+	if c.Schema != nil {
+		opts.Schema = *c.Schema
+	}
+	if c.ZeroThreshold != nil {
+		opts.ZeroThreshold = *c.ZeroThreshold
+	}
+	if c.MaxBuckets != nil {
+		opts.MaxBuckets = *c.MaxBuckets
+	}
+	if err := opts.Validate(); err != nil {
+		return raggregation.HistogramOptions{}, err
+	}
+	return opts, nil
+}
+
+type checkpointConfiguration struct {
+	// Directory the checkpoint of each shard is kept in, ignored if the
+	// checkpoints are kept in kv.
+	Directory string `yaml:"directory"`
+
+	// KV configuration for keeping the checkpoint of each shard in kv, where
+	// they are shared by the leader and followers.
+	KV *checkpointKVConfiguration `yaml:"kv"`
+
+	// Interval between checkpoints of the aggregation state of shards.
+	Interval time.Duration `yaml:"interval"`
+
+	// MaxAge of a checkpoint restored, older checkpoints are ignored.
+	MaxAge time.Duration `yaml:"maxAge"`
+}
+
+func (c checkpointConfiguration) NewCheckpointStore(
+	client client.Client,
+) (aggregator.CheckpointStore, error) {
+	if c.KV != nil {
+		kvOpts, err := c.KV.KVConfig.NewOverrideOptions()
+		if err != nil {
+			return nil, err
+		}
+		store, err := client.Store(kvOpts)
+		if err != nil {
+			return nil, err
+		}
+		return aggregator.NewKVCheckpointStore(store, c.KV.CheckpointKeyFmt, c.KV.ChunkSize), nil
+	}
+	if c.Directory == "" {
+		return nil, errNoCheckpointStore
+	}
+	return aggregator.NewFileCheckpointStore(c.Directory)
+}
+
+type checkpointKVConfiguration struct {
+	// KV Configuration.
+	KVConfig kv.OverrideConfiguration `yaml:"kvConfig"`
+
+	// Checkpoint key format, formatted with the shard.
+	CheckpointKeyFmt string `yaml:"checkpointKeyFmt" validate:"nonzero"`
+
+	// ChunkSize is the max size in bytes of the chunks checkpoints are split
+	// into, each kept under its own key, defaults to 256KiB.
+	ChunkSize int `yaml:"chunkSize"`
+}
+
+type lateTimedMetricsConfiguration struct {
+	// AllowedLateness of timed metrics not matching any rule.
+	AllowedLateness time.Duration `yaml:"allowedLateness"`
+
+	// Action for late timed metrics not matching any rule.
+	Action aggregator.LateTimedMetricAction `yaml:"action"`
+
+	// Rules override the allowed lateness and action of the timed metrics
+	// they match, the first matching rule applies.
+	Rules []lateTimedMetricRuleConfiguration `yaml:"rules"`
+
+	// SideOutput configures where timed metrics with the sideOutput action
+	// are written to.
+	SideOutput *lateTimedMetricSideOutputConfiguration `yaml:"sideOutput"`
+}
+
+func (c lateTimedMetricsConfiguration) NewLateTimedMetricPolicyFn() (aggregator.LateTimedMetricPolicyFn, error) {
+	rules := make([]lateTimedMetricRule, 0, len(c.Rules))
+	for _, ruleConfig := range c.Rules {
+		rule, err := ruleConfig.newRule()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	defaultPolicy := aggregator.LateTimedMetricPolicy{
+		AllowedLateness: c.AllowedLateness,
+		Action:          c.Action,
+	}
+	return func(metricID id.RawID, sp policy.StoragePolicy) aggregator.LateTimedMetricPolicy {
+		for _, rule := range rules {
+			if rule.matches(metricID, sp) {
+				return rule.policy
+			}
+		}
+		return defaultPolicy
+	}, nil
+}
+
+type lateTimedMetricRuleConfiguration struct {
+	// Filter is a glob pattern matched against metric IDs, an empty filter
+	// matches all metrics.
+	Filter string `yaml:"filter"`
+
+	// StoragePolicies the rule applies to, the rule applies to all storage
+	// policies if empty.
+	StoragePolicies []policy.StoragePolicy `yaml:"storagePolicies"`
+
+	// AllowedLateness of the matching timed metrics.
+	AllowedLateness time.Duration `yaml:"allowedLateness"`
+
+	// Action for the matching late timed metrics.
+	Action aggregator.LateTimedMetricAction `yaml:"action"`
+}
+
+func (c lateTimedMetricRuleConfiguration) newRule() (lateTimedMetricRule, error) {
+	rule := lateTimedMetricRule{
+		storagePolicies: c.StoragePolicies,
+		policy: aggregator.LateTimedMetricPolicy{
+			AllowedLateness: c.AllowedLateness,
+			Action:          c.Action,
+		},
+	}
+	if c.Filter != "" {
+		filter, err := filters.NewFilter([]byte(c.Filter))
+		if err != nil {
+			return lateTimedMetricRule{}, fmt.Errorf("invalid late timed metric filter %s: %v", c.Filter, err)
+		}
+		rule.filter = filter
+	}
+	return rule, nil
+}
+
+type lateTimedMetricRule struct {
+	filter          filters.Filter
+	storagePolicies []policy.StoragePolicy
+	policy          aggregator.LateTimedMetricPolicy
+}
+
+func (r lateTimedMetricRule) matches(metricID id.RawID, sp policy.StoragePolicy) bool {
+	if r.filter != nil && !r.filter.Matches(metricID) {
+		return false
+	}
+	if len(r.storagePolicies) == 0 {
+		return true
+	}
+	for _, storagePolicy := range r.storagePolicies {
+		if storagePolicy.Equivalent(sp) {
+			return true
+		}
+	}
+	return false
+}
+
+type lateTimedMetricSideOutputConfiguration struct {
+	// Handler late timed metrics are written to, defaults to the flush handler.
+	Handler *handler.FlushHandlerConfiguration `yaml:"handler"`
+
+	// NumWriters controls the number of late timed metric writers used.
+	NumWriters int `yaml:"numWriters"`
+}
+
+func (c lateTimedMetricSideOutputConfiguration) NewWriter(
+	client client.Client,
+	flushHandler handler.Handler,
+	iOpts instrument.Options,
+	rwOpts xio.Options,
+	shardFn sharding.AggregatedShardFn,
+) (writer.Writer, error) {
+	h := flushHandler
+	if c.Handler != nil {
+		sideOutputHandler, err := c.Handler.NewHandler(client, iOpts, rwOpts)
+		if err != nil {
+			return nil, err
+		}
+		h = sideOutputHandler
+	}
+
+	count := defaultNumPassthroughWriters
+	if c.NumWriters != 0 {
+		count = c.NumWriters
+	}
+	writers := make([]writer.Writer, 0, count)
+	for i := 0; i < count; i++ {
+		w, err := h.NewWriter(iOpts.MetricsScope())
+		if err != nil {
+			return nil, err
+		}
+		writers = append(writers, w)
+	}
+	shardedWriter, err := writer.NewShardedWriter(writers, shardFn, iOpts)
+	if err != nil {
+		return nil, err
+	}
+	if c.Handler == nil {
+		return shardedWriter, nil
+	}
+	// The side output handler is owned by the writer and closed along with it.
+	return handlerClosingWriter{Writer: shardedWriter, handler: h}, nil
+}
+
+type handlerClosingWriter struct {
+	writer.Writer
+
+	handler handler.Handler
+}
+
+func (w handlerClosingWriter) Close() error {
+	err := w.Writer.Close()
+	w.handler.Close()
+	return err
+}
+
 type placementManagerConfiguration struct {
 	KVConfig         kv.OverrideConfiguration       `yaml:"kvConfig"`
 	PlacementWatcher placement.WatcherConfiguration `yaml:"placementWatcher"`
@@ -24,6 +24,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/aggregator/aggregator"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/metrics/policy"
+
 	"github.com/stretchr/testify/require"
 	yaml "gopkg.in/yaml.v2"
 )
@@ -118,3 +122,64 @@ func TestMaxAllowedForwardingDelayFnJitterDisabled(t *testing.T) {
 		require.Equal(t, input.expected, fn(input.resolution, input.numForwardedTimes))
 	}
 }
+
+func TestLateTimedMetricPolicyFn(t *testing.T) {
+	config := `
+allowedLateness: 5m
+action: sideOutput
+rules:
+  - filter: foo.*
+    storagePolicies:
+      - 10s:2d
+    allowedLateness: 1h
+    action: reaggregate
+  - filter: foo.*
+    action: drop
+`
+
+	var c lateTimedMetricsConfiguration
+	require.NoError(t, yaml.Unmarshal([]byte(config), &c))
+
+	fn, err := c.NewLateTimedMetricPolicyFn()
+	require.NoError(t, err)
+
+	tenSeconds := policy.MustParseStoragePolicy("10s:2d")
+	oneMinute := policy.MustParseStoragePolicy("1m:40d")
+	inputs := []struct {
+		id       id.RawID
+		sp       policy.StoragePolicy
+		expected aggregator.LateTimedMetricPolicy
+	}{
+		{
+			id: id.RawID("foo.bar"),
+			sp: tenSeconds,
+			expected: aggregator.LateTimedMetricPolicy{
+				AllowedLateness: time.Hour,
+				Action:          aggregator.ReaggregateLateTimedMetric,
+			},
+		},
+		{
+			id: id.RawID("foo.bar"),
+			sp: oneMinute,
+			expected: aggregator.LateTimedMetricPolicy{
+				Action: aggregator.DropLateTimedMetric,
+			},
+		},
+		{
+			id: id.RawID("baz"),
+			sp: tenSeconds,
+			expected: aggregator.LateTimedMetricPolicy{
+				AllowedLateness: 5 * time.Minute,
+				Action:          aggregator.SideOutputLateTimedMetric,
+			},
+		},
+	}
+	for _, input := range inputs {
+		require.Equal(t, input.expected, fn(input.id, input.sp))
+	}
+}
+
+func TestLateTimedMetricPolicyInvalidAction(t *testing.T) {
+	var c lateTimedMetricsConfiguration
+	require.Error(t, yaml.Unmarshal([]byte("action: foo"), &c))
+}
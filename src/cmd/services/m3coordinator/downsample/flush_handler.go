@@ -28,6 +28,7 @@ import (
 
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
+	"github.com/m3db/m3/src/metrics/histogram"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
@@ -53,6 +54,7 @@ var (
 
 var (
 	aggregationSuffixTag = []byte("agg")
+	bucketUpperBoundTag  = []byte("le")
 )
 
 type downsamplerFlushHandler struct {
@@ -131,9 +133,13 @@ func (w *downsamplerFlushHandlerWriter) Write(
 
 		expected := iter.NumTags()
 		chunkSuffix := mp.ChunkedID.Suffix
+		bucketAgg, bucketUpperBound, isBucket := histogram.ParseBucketSuffix(chunkSuffix)
 		if len(chunkSuffix) != 0 {
 			expected++
 		}
+		if isBucket {
+			expected++
+		}
 
 		tags := models.NewTags(expected, w.tagOptions)
 		for iter.Next() {
@@ -160,7 +166,15 @@ func (w *downsamplerFlushHandlerWriter) Write(
 			tags = tags.AddTag(models.Tag{Name: name, Value: value}.Clone())
 		}
 
-		if len(chunkSuffix) != 0 {
+		switch {
+		case isBucket:
+			// NB: the cumulative buckets of histograms are written with the
+			// upper bound of the bucket as the le tag, as Prometheus does.
+			if len(bucketAgg) != 0 {
+				tags = tags.AddTag(models.Tag{Name: aggregationSuffixTag, Value: bucketAgg}.Clone())
+			}
+			tags = tags.AddTag(models.Tag{Name: bucketUpperBoundTag, Value: bucketUpperBound}.Clone())
+		case len(chunkSuffix) != 0:
 			tags = tags.AddTag(models.Tag{Name: aggregationSuffixTag, Value: chunkSuffix}.Clone())
 		}
 
@@ -25,6 +25,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/m3db/m3/src/metrics/histogram"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
 	"github.com/m3db/m3/src/metrics/metric/id"
 	"github.com/m3db/m3/src/metrics/policy"
@@ -108,6 +109,72 @@ func TestDownsamplerFlushHandlerCopiesTags(t *testing.T) {
 	assert.False(t, xtest.ByteSlicesBackedBySameData(tagValue, tag.Value))
 }
 
+func TestDownsamplerFlushHandlerWritesHistogramBucketTags(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock.NewMockStorage()
+	pool := serialize.NewMockMetricTagsIteratorPool(ctrl)
+
+	workers := xsync.NewWorkerPool(1)
+	workers.Init()
+
+	instrumentOpts := instrument.NewOptions()
+
+	handler := newDownsamplerFlushHandler(store, pool,
+		workers, models.NewTagOptions(), instrumentOpts)
+	writer, err := handler.NewWriter(tally.NoopScope)
+	require.NoError(t, err)
+
+	var (
+		expectedID = []byte("foo")
+		tagName    = []byte("name")
+		tagValue   = []byte("value")
+	)
+	iter := serialize.NewMockMetricTagsIterator(ctrl)
+	gomock.InOrder(
+		iter.EXPECT().Reset(expectedID),
+		iter.EXPECT().NumTags().Return(1),
+		iter.EXPECT().Next().Return(true),
+		iter.EXPECT().Current().Return(tagName, tagValue),
+		iter.EXPECT().Next().Return(false),
+		iter.EXPECT().Err().Return(nil),
+		iter.EXPECT().Close(),
+	)
+
+	pool.EXPECT().Get().Return(iter)
+
+	err = writer.Write(aggregated.ChunkedMetricWithStoragePolicy{
+		ChunkedMetric: aggregated.ChunkedMetric{
+			ChunkedID: id.ChunkedID{
+				Data:   expectedID,
+				Suffix: histogram.AppendBucketSuffix(nil, []byte("bucket"), 0.25),
+			},
+			TimeNanos: 123,
+			Value:     3,
+		},
+		StoragePolicy: policy.MustParseStoragePolicy("1s:1d"),
+	})
+	require.NoError(t, err)
+
+	err = writer.Flush()
+	require.NoError(t, err)
+
+	writes := store.Writes()
+	require.Equal(t, 1, len(writes))
+
+	tags := writes[0].Tags()
+	value, ok := tags.Get(tagName)
+	require.True(t, ok)
+	assert.Equal(t, tagValue, value)
+	value, ok = tags.Get(aggregationSuffixTag)
+	require.True(t, ok)
+	assert.Equal(t, []byte("bucket"), value)
+	value, ok = tags.Get(bucketUpperBoundTag)
+	require.True(t, ok)
+	assert.Equal(t, []byte("0.25"), value)
+}
+
 func graphiteTags(
 	t *testing.T, first string, encPool serialize.TagEncoderPool) []byte {
 	enc := encPool.Get()
@@ -254,6 +254,9 @@ type Configuration struct {
 	// Pool of gauge elements.
 	GaugeElemPool pool.ObjectPoolConfiguration `yaml:"gaugeElemPool"`
 
+	// Pool of histogram elements.
+	HistogramElemPool pool.ObjectPoolConfiguration `yaml:"histogramElemPool"`
+
 	// BufferPastLimits specifies the buffer past limits.
 	BufferPastLimits []BufferPastLimitConfiguration `yaml:"bufferPastLimits"`
 
@@ -842,6 +845,7 @@ func (cfg Configuration) newAggregator(o DownsamplerOptions) (agg, error) {
 		SetCounterPrefix(nil).
 		SetGaugePrefix(nil).
 		SetTimerPrefix(nil).
+		SetHistogramPrefix(nil).
 		SetPlacementManager(placementManager).
 		SetFlushTimesManager(flushTimesManager).
 		SetElectionManager(electionManager).
@@ -862,6 +866,12 @@ func (cfg Configuration) newAggregator(o DownsamplerOptions) (agg, error) {
 			return agg{}, err
 		}
 		aggregatorOpts = aggregatorOpts.SetAggregationTypesOptions(aggTypeOpts)
+	} else {
+		// NB: the type string of histogram buckets is written to the
+		// aggregation tag and should not be prefixed with a separator.
+		aggTypeOpts := aggregatorOpts.AggregationTypesOptions().
+			SetHistogramTypeStringTransformFn(aggregation.NoOpTransform)
+		aggregatorOpts = aggregatorOpts.SetAggregationTypesOptions(aggTypeOpts)
 	}
 
 	// Set counter elem pool.
@@ -918,6 +928,24 @@ func (cfg Configuration) newAggregator(o DownsamplerOptions) (agg, error) {
 		)
 	})
 
+	// Set histogram elem pool.
+	histogramElemPoolOpts := cfg.HistogramElemPool.NewObjectPoolOptions(
+		instrumentOpts.SetMetricsScope(scope.SubScope("histogram-elem-pool")),
+	)
+	histogramElemPool := aggregator.NewHistogramElemPool(histogramElemPoolOpts)
+	aggregatorOpts = aggregatorOpts.SetHistogramElemPool(histogramElemPool)
+	histogramElemPool.Init(func() *aggregator.HistogramElem {
+		return aggregator.MustNewHistogramElem(
+			nil,
+			policy.EmptyStoragePolicy,
+			aggregation.DefaultTypes,
+			applied.DefaultPipeline,
+			0,
+			aggregator.WithPrefixWithSuffix,
+			aggregatorOpts,
+		)
+	})
+
 	adminAggClient := newAggregatorLocalAdminClient()
 	aggregatorOpts = aggregatorOpts.SetAdminClient(adminAggClient)
 
@@ -1172,6 +1200,14 @@ func (c *aggregatorLocalAdminClient) WriteUntimedGauge(
 	return c.agg.AddUntimed(gauge.ToUnion(), metadatas)
 }
 
+// WriteUntimedHistogram writes untimed histogram metrics.
+func (c *aggregatorLocalAdminClient) WriteUntimedHistogram(
+	histogram unaggregated.Histogram,
+	metadatas metadata.StagedMetadatas,
+) error {
+	return c.agg.AddUntimed(histogram.ToUnion(), metadatas)
+}
+
 // WriteTimed writes timed metrics.
 func (c *aggregatorLocalAdminClient) WriteTimed(
 	metric aggregated.Metric,
@@ -440,6 +440,11 @@ type carbonIngesterMetrics struct {
 //      __g0__:foo
 //      __g1__:bar
 //      __g2__:baz
+// while a Graphite tagged series name like:
+//      foo.bar;dc=east
+// becomes
+//      dc:east
+//      name:foo.bar
 func GenerateTagsFromName(
 	name []byte,
 	opts models.TagOptions,
@@ -466,6 +471,10 @@ func generateTagsFromName(
 		return models.EmptyTags(), errCannotGenerateTagsFromEmptyName
 	}
 
+	if graphite.IsTaggedName(string(name)) {
+		return generateTagsFromTaggedName(name, opts, tags)
+	}
+
 	numTags := bytes.Count(name, carbonSeparatorBytes) + 1
 
 	if cap(tags) >= numTags {
@@ -511,6 +520,36 @@ func generateTagsFromName(
 	return models.Tags{Opts: opts, Tags: tags}, nil
 }
 
+func generateTagsFromTaggedName(
+	name []byte,
+	opts models.TagOptions,
+	tags []models.Tag,
+) (models.Tags, error) {
+	path, parsed, err := graphite.ParseTaggedName(string(name))
+	if err != nil {
+		return models.EmptyTags(), fmt.Errorf("carbon metric: %v", err)
+	}
+
+	if cap(tags) >= len(parsed)+1 {
+		tags = tags[:0]
+	} else {
+		tags = make([]models.Tag, 0, len(parsed)+1)
+	}
+
+	tags = append(tags, models.Tag{
+		Name:  []byte(graphite.TaggedNameTag),
+		Value: []byte(path),
+	})
+	for _, tag := range parsed {
+		tags = append(tags, models.Tag{
+			Name:  []byte(tag.Name),
+			Value: []byte(tag.Value),
+		})
+	}
+
+	return models.Tags{Opts: opts, Tags: tags}.Normalize(), nil
+}
+
 // Compile all the carbon ingestion rules into regexp so that we can
 // perform matching. Also, generate all the mapping rules and storage
 // policies that we will need to pass to the DownsamplerAndWriter upfront
@@ -516,6 +516,15 @@ func TestGenerateTagsFromName(t *testing.T) {
 				{Name: graphite.TagName(2), Value: []byte("baz")},
 			},
 		},
+		{
+			name: "foo.bar;host=a;dc=east",
+			id:   "foo.bar;dc=east;host=a",
+			expectedTags: []models.Tag{
+				{Name: []byte("dc"), Value: []byte("east")},
+				{Name: []byte("host"), Value: []byte("a")},
+				{Name: []byte("name"), Value: []byte("foo.bar")},
+			},
+		},
 		{
 			name:         "foo..bar..baz..",
 			expectedErr:  fmt.Errorf("carbon metric: foo..bar..baz.. has duplicate separator"),
@@ -539,6 +548,11 @@ func TestGenerateTagsFromName(t *testing.T) {
 		}
 		require.Equal(t, tc.expectedTags, tags.Tags)
 	}
+
+	for _, invalid := range []string{"foo;dc", "foo;dc=", ";dc=east"} {
+		_, err := GenerateTagsFromName([]byte(invalid), opts)
+		require.Error(t, err, invalid)
+	}
 }
 
 func newTestOpts(rules CarbonIngesterRules) Options {
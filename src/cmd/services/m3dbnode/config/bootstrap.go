@@ -25,6 +25,7 @@ import (
 	"fmt"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/migration"
 	"github.com/m3db/m3/src/dbnode/storage"
@@ -39,6 +40,8 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
 )
 
 var (
@@ -216,6 +219,10 @@ type BootstrapMigrationConfiguration struct {
 
 	// Concurrency sets the number of concurrent workers performing migrations.
 	Concurrency int `yaml:"concurrency"`
+
+	// Reencode specifies namespaces whose filesets should be re-split and
+	// re-encoded into another namespace on startup.
+	Reencode []BootstrapReencodeConfiguration `yaml:"reencode"`
 }
 
 // NewOptions generates migration.Options from the configuration.
@@ -229,6 +236,61 @@ func (m BootstrapMigrationConfiguration) NewOptions() migration.Options {
 	return opts
 }
 
+// BootstrapReencodeConfiguration specifies the re-encoding of the filesets of
+// a source namespace into the block size, compression and encoding of a target
+// namespace.
+type BootstrapReencodeConfiguration struct {
+	// SourceNamespace is the namespace whose filesets are read.
+	SourceNamespace string `yaml:"sourceNamespace" validate:"nonzero"`
+
+	// SourceEncoding is the encoding of the source filesets, defaults to m3tsz.
+	SourceEncoding migration.Encoding `yaml:"sourceEncoding"`
+
+	// TargetNamespace is the namespace that the re-encoded filesets are
+	// written to.
+	TargetNamespace string `yaml:"targetNamespace" validate:"nonzero"`
+
+	// TargetEncoding is the encoding of the target filesets, defaults to m3tsz.
+	TargetEncoding migration.Encoding `yaml:"targetEncoding"`
+
+	// Shards restricts the re-encoding to the given shards, all shards found
+	// on disk are re-encoded if not set.
+	Shards []uint32 `yaml:"shards"`
+
+	// Concurrency sets the number of shards re-encoded concurrently.
+	Concurrency int `yaml:"concurrency"`
+}
+
+// NewOptions generates migration.ReencodeOptions from the configuration.
+func (c BootstrapReencodeConfiguration) NewOptions(
+	nsMap namespace.Map,
+	fsOpts fs.Options,
+	iOpts instrument.Options,
+) (migration.ReencodeOptions, error) {
+	source, err := nsMap.Get(ident.StringID(c.SourceNamespace))
+	if err != nil {
+		return nil, err
+	}
+	target, err := nsMap.Get(ident.StringID(c.TargetNamespace))
+	if err != nil {
+		return nil, err
+	}
+
+	opts := migration.NewReencodeOptions().
+		SetSourceNamespace(source).
+		SetSourceEncoding(c.SourceEncoding).
+		SetTargetNamespace(target).
+		SetTargetEncoding(c.TargetEncoding).
+		SetShards(c.Shards).
+		SetFilesystemOptions(fsOpts).
+		SetInstrumentOptions(iOpts)
+	if c.Concurrency > 0 {
+		opts = opts.SetConcurrency(c.Concurrency)
+	}
+
+	return opts, opts.Validate()
+}
+
 // BootstrapCommitlogConfiguration specifies config for the commitlog bootstrapper.
 type BootstrapCommitlogConfiguration struct {
 	// ReturnUnfulfilledForCorruptCommitLogFiles controls whether the commitlog bootstrapper
@@ -398,6 +460,11 @@ func (bsc BootstrapConfiguration) New(
 	return bootstrap.NewProcessProvider(bs, providerOpts, rsOpts, fsOpts)
 }
 
+// Reencodes returns the fileset re-encodings to run on startup.
+func (bsc BootstrapConfiguration) Reencodes() []BootstrapReencodeConfiguration {
+	return bsc.filesystemConfig().migration().Reencode
+}
+
 func (bsc BootstrapConfiguration) filesystemConfig() BootstrapFilesystemConfiguration {
 	if cfg := bsc.Filesystem; cfg != nil {
 		return *cfg
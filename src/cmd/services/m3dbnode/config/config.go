@@ -33,6 +33,8 @@ import (
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/discovery"
 	"github.com/m3db/m3/src/dbnode/environment"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/x/config/hostid"
 	"github.com/m3db/m3/src/x/debug/config"
@@ -496,6 +498,45 @@ type CommitLogPolicy struct {
 	// works in most cases because the default size of the QueueChannel should be large
 	// enough for almost all workloads assuming a reasonable batch size is used.
 	QueueChannel *CommitLogQueuePolicy `yaml:"queueChannel"`
+
+	// The strategy the commit log uses to acknowledge writes, defaults to
+	// write_behind which acknowledges writes before they are flushed to disk.
+	Strategy *commitlog.Strategy `yaml:"strategy"`
+
+	// The group commit policy used by the group_commit strategy.
+	GroupCommit *CommitLogGroupCommitPolicy `yaml:"groupCommit"`
+
+	// The compression applied to commit log chunks, defaults to none.
+	Compression *compression.Type `yaml:"compression"`
+}
+
+// StrategyOrDefault returns the commit log strategy or default.
+func (p CommitLogPolicy) StrategyOrDefault() commitlog.Strategy {
+	if p.Strategy == nil {
+		return commitlog.StrategyWriteBehind
+	}
+
+	return *p.Strategy
+}
+
+// CompressionOrDefault returns the commit log compression or default.
+func (p CommitLogPolicy) CompressionOrDefault() compression.Type {
+	if p.Compression == nil {
+		return compression.NoneType
+	}
+
+	return *p.Compression
+}
+
+// CommitLogGroupCommitPolicy is the commit log group commit policy, pending
+// writes are fsync'd and acknowledged once there are batch size writes
+// pending or max latency has elapsed.
+type CommitLogGroupCommitPolicy struct {
+	// The number of pending writes that triggers a group commit.
+	BatchSize int `yaml:"batchSize"`
+
+	// The max time a write waits for a group commit.
+	MaxLatency time.Duration `yaml:"maxLatency"`
 }
 
 // CalculationType is a type of configuration parameter.
@@ -534,6 +575,24 @@ type RepairPolicy struct {
 	// If enabled, what percentage of metadata should perform a detailed debug
 	// shadow comparison.
 	DebugShadowComparisonsPercentage float64 `yaml:"debugShadowComparisonsPercentage"`
+
+	// HashTree configures repairs that compare block hash trees with peers
+	// before comparing series metadata.
+	HashTree *RepairHashTreePolicy `yaml:"hashTree"`
+}
+
+// RepairHashTreePolicy is the hash tree repair policy.
+type RepairHashTreePolicy struct {
+	// Enabled or disabled.
+	Enabled bool `yaml:"enabled"`
+
+	// The number of children of each node of the hash trees, zero uses the
+	// default fanout.
+	Fanout int `yaml:"fanout"`
+
+	// The number of levels below the root of the hash trees, zero uses the
+	// default depth.
+	Depth int `yaml:"depth"`
 }
 
 // ReplicationPolicy is the replication policy.
@@ -443,6 +443,7 @@ func TestConfiguration(t *testing.T) {
     force_index_summaries_mmap_memory: true
     force_bloom_filter_mmap_memory: true
     bloomFilterFalsePositivePercent: null
+    tiering: null
   commitlog:
     flushMaxBytes: 524288
     flushEvery: 1s
@@ -450,12 +451,16 @@ func TestConfiguration(t *testing.T) {
       calculationType: fixed
       size: 2097152
     queueChannel: null
+    strategy: null
+    groupCommit: null
+    compression: null
   repair:
     enabled: false
     throttle: 2m0s
     checkInterval: 1m0s
     debugShadowComparisonsEnabled: false
     debugShadowComparisonsPercentage: 0
+    hashTree: null
   replication: null
   pooling:
     blockAllocSize: 16
@@ -724,6 +729,7 @@ func TestConfiguration(t *testing.T) {
     maxOutstandingRepairedBytes: 0
     maxEncodersPerBlock: 0
     writeNewSeriesPerSecond: 0
+    tenantLimits: null
   wide: null
   tchannel: null
   debug:
@@ -21,8 +21,11 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
 )
 
 const (
@@ -43,6 +46,9 @@ const (
 	defaultBloomFilterFalsePositivePercent = 0.02
 )
 
+var errTieringTargetNotSet = errors.New(
+	"fs tiering requires exactly one of directory or s3 to be set")
+
 // DefaultMmapConfiguration is the default mmap configuration.
 func DefaultMmapConfiguration() MmapConfiguration {
 	return MmapConfiguration{
@@ -98,6 +104,10 @@ type FilesystemConfiguration struct {
 	// BloomFilterFalsePositivePercent controls the target false positive percentage
 	// for the bloom filters for the fileset files.
 	BloomFilterFalsePositivePercent *float64 `yaml:"bloomFilterFalsePositivePercent"`
+
+	// Tiering is the secondary tier that filesets of namespaces with a
+	// tiering policy are moved to, tiering is disabled if not set.
+	Tiering *TieringConfiguration `yaml:"tiering"`
 }
 
 // Validate validates the Filesystem configuration. We use this method to validate
@@ -144,6 +154,11 @@ func (f FilesystemConfiguration) Validate() error {
 			"fs bloomFilterFalsePositivePercent is set to: %f, but must be between 0.0 and 1.0",
 			*f.BloomFilterFalsePositivePercent)
 	}
+	if f.Tiering != nil {
+		if err := f.Tiering.Validate(); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -257,6 +272,51 @@ func (f FilesystemConfiguration) BloomFilterFalsePositivePercentOrDefault() floa
 	return defaultBloomFilterFalsePositivePercent
 }
 
+// TieringConfiguration is the configuration of the secondary tier that cold
+// filesets are moved to, exactly one of a directory or an S3 compatible
+// object store must be set.
+type TieringConfiguration struct {
+	// Directory is a directory, such as the mount of a cheaper volume, that
+	// filesets are moved to.
+	Directory string `yaml:"directory"`
+
+	// S3 is an S3 compatible object store that filesets are moved to.
+	S3 *backup.S3Options `yaml:"s3"`
+
+	// CacheSizeBytes is the max size of the tiered files kept locally once
+	// fetched back for reads.
+	CacheSizeBytes int64 `yaml:"cacheSizeBytes"`
+}
+
+// Validate validates the tiering configuration.
+func (c TieringConfiguration) Validate() error {
+	if (c.Directory == "") == (c.S3 == nil) {
+		return errTieringTargetNotSet
+	}
+	if c.S3 != nil {
+		if err := c.S3.Validate(); err != nil {
+			return fmt.Errorf("fs tiering s3 is invalid: %v", err)
+		}
+	}
+	if c.CacheSizeBytes < 0 {
+		return fmt.Errorf(
+			"fs tiering cacheSizeBytes is set to: %d, but must not be negative",
+			c.CacheSizeBytes)
+	}
+	return nil
+}
+
+// NewStore returns the store that filesets are moved to.
+func (c TieringConfiguration) NewStore() (backup.Store, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.S3 != nil {
+		return backup.NewS3Store(*c.S3, nil)
+	}
+	return backup.NewDirectoryStore(c.Directory), nil
+}
+
 // MmapConfiguration is the mmap configuration.
 type MmapConfiguration struct {
 	// HugeTLB is the huge pages configuration which will only take affect
@@ -24,6 +24,8 @@ import (
 	"os"
 	"testing"
 
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -47,3 +49,19 @@ func TestFilesystemConfigurationParseNewDirectoryMode(t *testing.T) {
 
 	assert.Equal(t, os.FileMode(0775)|os.ModeDir, v)
 }
+
+func TestTieringConfigurationValidate(t *testing.T) {
+	require.Error(t, TieringConfiguration{}.Validate())
+	require.Error(t, TieringConfiguration{
+		Directory: "/mnt/cold",
+		S3:        &backup.S3Options{Endpoint: "http://minio:9000", Bucket: "m3db"},
+	}.Validate())
+	require.Error(t, TieringConfiguration{S3: &backup.S3Options{Bucket: "m3db"}}.Validate())
+	require.Error(t, TieringConfiguration{Directory: "/mnt/cold", CacheSizeBytes: -1}.Validate())
+
+	require.NoError(t, TieringConfiguration{Directory: "/mnt/cold"}.Validate())
+	require.NoError(t, TieringConfiguration{
+		S3:             &backup.S3Options{Endpoint: "http://minio:9000", Bucket: "m3db"},
+		CacheSizeBytes: 1 << 30,
+	}.Validate())
+}
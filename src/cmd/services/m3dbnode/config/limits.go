@@ -20,7 +20,11 @@
 
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/limits"
+)
 
 // LimitsConfiguration contains configuration for configurable limits that can be applied to M3DB.
 type LimitsConfiguration struct {
@@ -61,6 +65,26 @@ type LimitsConfiguration struct {
 
 	// Write new series limit per second to limit overwhelming during new ID bursts.
 	WriteNewSeriesPerSecond int `yaml:"writeNewSeriesPerSecond" validate:"min=0"`
+
+	// TenantLimits sets upper limits on the resources consumed by each tenant
+	// of a shared cluster. The quotas of tenants can be updated dynamically
+	// through KV, in which case the quotas set here are only used while no
+	// quotas are set in KV.
+	TenantLimits *TenantLimitsConfiguration `yaml:"tenantLimits"`
+}
+
+// TenantLimitsConfiguration sets upper limits on the series created,
+// datapoints written, docs matched and bytes read by each tenant within a
+// dbnode per some lookback period of time. Once exceeded, writes and queries
+// of the tenant within that period of time will be rejected.
+type TenantLimitsConfiguration struct {
+	// TenantTag is the name of the tag that identifies the tenant of written
+	// series, queries are attributed to tenants by their source.
+	TenantTag string `yaml:"tenantTag"`
+	// Lookback is the period in which the tenant limits are enforced.
+	Lookback time.Duration `yaml:"lookback" validate:"min=0"`
+	// Quotas are the quotas of tenants.
+	Quotas limits.TenantQuotas `yaml:"quotas"`
 }
 
 // MaxRecentQueryResourceLimitConfiguration sets an upper limit on resources consumed by all queries
@@ -64,6 +64,17 @@ const (
 	defaultQueryTimeout = 30 * time.Second
 
 	defaultPrometheusMaxSamplesPerQuery = 100000000
+
+	defaultStreamingChunkSteps = 720
+
+	// MemoryResultCacheStorageType caches query results in memory.
+	MemoryResultCacheStorageType ResultCacheStorageType = "memory"
+	// DiskResultCacheStorageType caches query results on local disk.
+	DiskResultCacheStorageType ResultCacheStorageType = "disk"
+
+	defaultResultCacheSplitInterval = 24 * time.Hour
+	defaultResultCacheBufferPast    = 10 * time.Minute
+	defaultResultCacheTTL           = 24 * time.Hour
 )
 
 var (
@@ -270,6 +281,11 @@ type QueryConfiguration struct {
 	// RestrictTags is an optional configuration that can be set to restrict
 	// all queries with certain tags by.
 	RestrictTags *RestrictTagsConfiguration `yaml:"restrictTags"`
+	// Streaming is the configuration for streaming fetched blocks through
+	// queries in time chunks.
+	Streaming StreamingQueryConfiguration `yaml:"streaming"`
+	// Cache is the configuration for caching range query results.
+	Cache ResultCacheConfiguration `yaml:"cache"`
 }
 
 // TimeoutOrDefault returns the configured timeout or default value.
@@ -341,6 +357,103 @@ func (c PrometheusQueryConfiguration) MaxSamplesPerQueryOrDefault() int {
 	return defaultPrometheusMaxSamplesPerQuery
 }
 
+// StreamingQueryConfiguration is the configuration for streaming fetched
+// blocks through the transforms of a query one time chunk at a time, bounding
+// the memory used by the transforms to the chunk size rather than the full
+// range of the query.
+type StreamingQueryConfiguration struct {
+	// Enabled enables streaming fetched blocks through queries in chunks.
+	Enabled bool `yaml:"enabled"`
+	// ChunkSteps is the number of steps streamed per chunk.
+	ChunkSteps *int `yaml:"chunkSteps"`
+}
+
+// ChunkStepsOrDefault returns the configured steps per chunk or default
+// value, or zero if streaming evaluation is disabled.
+func (c StreamingQueryConfiguration) ChunkStepsOrDefault() int {
+	if !c.Enabled {
+		return 0
+	}
+	if v := c.ChunkSteps; v != nil && *v > 0 {
+		return *v
+	}
+
+	return defaultStreamingChunkSteps
+}
+
+// ResultCacheStorageType is the storage used to cache query results.
+type ResultCacheStorageType string
+
+// ResultCacheConfiguration is the configuration for caching range query
+// results. Range queries are split into extents, and extents which can no
+// longer receive writes are cached so only the most recent extent of a
+// repeated query is evaluated. Cached extents are only invalidated by series
+// deleted through the same coordinator, and otherwise are served until they
+// expire after the TTL.
+type ResultCacheConfiguration struct {
+	// Enabled enables caching of range query results.
+	Enabled bool `yaml:"enabled"`
+	// Storage is the storage used for cached extents, either memory or disk.
+	Storage ResultCacheStorageType `yaml:"storage"`
+	// Directory is the directory cached extents are stored in when using
+	// disk storage.
+	Directory string `yaml:"directory"`
+	// MaxEntries is the maximum number of extents cached in memory.
+	MaxEntries int `yaml:"maxEntries"`
+	// TTL is how long cached extents are kept.
+	TTL *time.Duration `yaml:"ttl"`
+	// SplitInterval is the interval queries are split into extents by.
+	SplitInterval *time.Duration `yaml:"splitInterval"`
+	// SplitSteps if set splits queries into extents of this many steps
+	// instead of by the split interval.
+	SplitSteps int `yaml:"splitSteps"`
+	// BufferPast is how far in the past writes are accepted by storage
+	// namespaces whose buffer past is not known, such as statically
+	// configured namespaces. Namespaces discovered dynamically use their own
+	// buffer past, and are not cached if cold writes are enabled.
+	BufferPast *time.Duration `yaml:"bufferPast"`
+}
+
+// StorageOrDefault returns the configured storage type or default value.
+func (c ResultCacheConfiguration) StorageOrDefault() ResultCacheStorageType {
+	if c.Storage == "" {
+		return MemoryResultCacheStorageType
+	}
+
+	return c.Storage
+}
+
+// TTLOrDefault returns the configured TTL or default value.
+func (c ResultCacheConfiguration) TTLOrDefault() time.Duration {
+	if v := c.TTL; v != nil && *v > 0 {
+		return *v
+	}
+
+	return defaultResultCacheTTL
+}
+
+// SplitIntervalOrDefault returns the extent size for a query with the given
+// step, using the configured steps or interval or default value.
+func (c ResultCacheConfiguration) SplitIntervalOrDefault(step time.Duration) time.Duration {
+	if c.SplitSteps > 0 {
+		return time.Duration(c.SplitSteps) * step
+	}
+	if v := c.SplitInterval; v != nil && *v > 0 {
+		return *v
+	}
+
+	return defaultResultCacheSplitInterval
+}
+
+// BufferPastOrDefault returns the configured buffer past or default value.
+func (c ResultCacheConfiguration) BufferPastOrDefault() time.Duration {
+	if v := c.BufferPast; v != nil {
+		return *v
+	}
+
+	return defaultResultCacheBufferPast
+}
+
 // LimitsConfiguration represents limitations on resource usage in the query
 // instance. Limits are split between per-query and global limits.
 type LimitsConfiguration struct {
@@ -0,0 +1,185 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+
+	"github.com/pborman/getopt"
+	"go.uber.org/zap"
+)
+
+const (
+	backupAction  = "backup"
+	restoreAction = "restore"
+)
+
+func main() {
+	var (
+		optAction      = getopt.StringLong("action", 'a', "", fmt.Sprintf("%s|%s", backupAction, restoreAction))
+		optNode        = getopt.StringLong("node", 'H', "", "Debug address of the node to backup [e.g. http://localhost:9004]")
+		optPathPrefix  = getopt.StringLong("path-prefix", 'p', "", "Path prefix to restore to [e.g. /var/lib/m3db]")
+		optNamespaces  = getopt.StringLong("namespaces", 'n', "", "Comma separated namespaces, all namespaces if not set")
+		optShards      = getopt.StringLong("shards", 's', "", "Comma separated shards to restore, all shards if not set")
+		optDirectory   = getopt.StringLong("directory", 'd', "", "Backup directory [e.g. /backups/2020-01-01]")
+		optS3Endpoint  = getopt.StringLong("s3-endpoint", 'e', "", "S3 compatible endpoint [e.g. http://minio:9000]")
+		optS3Bucket    = getopt.StringLong("s3-bucket", 'b', "", "S3 bucket")
+		optS3Prefix    = getopt.StringLong("s3-prefix", 'x', "", "S3 key prefix [e.g. backups/2020-01-01]")
+		optS3Region    = getopt.StringLong("s3-region", 'r', "", "S3 region, defaults to us-east-1")
+		optHTTPTimeout = getopt.DurationLong("http-timeout", 'T', time.Hour, "Timeout of the backup request to the node")
+	)
+	getopt.Parse()
+
+	rawLogger, err := zap.NewDevelopment()
+	if err != nil {
+		log.Fatalf("unable to create logger: %+v", err)
+	}
+	log := rawLogger.Sugar()
+
+	var s3Opts *backup.S3Options
+	if *optS3Endpoint != "" {
+		// Credentials are read from the environment so they do not end up in
+		// shell history.
+		s3Opts = &backup.S3Options{
+			Endpoint:        *optS3Endpoint,
+			Bucket:          *optS3Bucket,
+			Prefix:          *optS3Prefix,
+			Region:          *optS3Region,
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		}
+	}
+	if (*optDirectory == "") == (s3Opts == nil) {
+		getopt.Usage()
+		os.Exit(1)
+	}
+
+	namespaces := splitList(*optNamespaces)
+
+	switch *optAction {
+	case backupAction:
+		if *optNode == "" {
+			getopt.Usage()
+			os.Exit(1)
+		}
+
+		body, err := json.Marshal(map[string]interface{}{
+			"namespaces": namespaces,
+			"directory":  *optDirectory,
+			"s3":         s3Opts,
+		})
+		if err != nil {
+			log.Fatalf("unable to encode backup request: %v", err)
+		}
+
+		client := &http.Client{Timeout: *optHTTPTimeout}
+		url := strings.TrimSuffix(*optNode, "/") + "/backup"
+		resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			log.Fatalf("backup request failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		respBody, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			log.Fatalf("unable to read backup response: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			log.Fatalf("backup failed with status %d: %s", resp.StatusCode,
+				strings.TrimSpace(string(respBody)))
+		}
+		log.Infof("backup complete: %s", strings.TrimSpace(string(respBody)))
+
+	case restoreAction:
+		if *optPathPrefix == "" {
+			getopt.Usage()
+			os.Exit(1)
+		}
+
+		var store backup.Store
+		if s3Opts != nil {
+			store, err = backup.NewS3Store(*s3Opts, nil)
+			if err != nil {
+				log.Fatalf("unable to create s3 store: %v", err)
+			}
+		} else {
+			store = backup.NewDirectoryStore(*optDirectory)
+		}
+
+		var shards []uint32
+		for _, str := range splitList(*optShards) {
+			shard, err := strconv.ParseUint(str, 10, 32)
+			if err != nil {
+				log.Fatalf("invalid shard %s: %v", str, err)
+			}
+			shards = append(shards, uint32(shard))
+		}
+
+		manifest, err := backup.Restore(backup.RestoreOptions{
+			FilesystemOptions: fs.NewOptions().SetFilePathPrefix(*optPathPrefix),
+			Store:             store,
+			Namespaces:        namespaces,
+			Shards:            shards,
+		})
+		if err != nil {
+			log.Fatalf("restore failed: %v", err)
+		}
+
+		// The namespaces must exist in the cluster for the restored filesets
+		// to be bootstrapped, output them so they can be created.
+		log.Infof("restored backup created at %s", manifest.CreatedAt)
+		if manifest.HasSnapshots() {
+			log.Warnf("backup contains snapshots of unflushed blocks, start the node " +
+				"with the commitlog bootstrapper enabled after the filesystem " +
+				"bootstrapper to load them")
+		}
+		fmt.Println(string(manifest.Namespaces))
+
+	default:
+		getopt.Usage()
+		os.Exit(1)
+	}
+}
+
+func splitList(str string) []string {
+	if str == "" {
+		return nil
+	}
+	var result []string
+	for _, s := range strings.Split(str, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/migration"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/pborman/getopt"
+	"go.uber.org/zap"
+)
+
+func main() {
+	var (
+		optPathPrefix      = getopt.StringLong("path-prefix", 'p', "", "Path prefix [e.g. /var/lib/m3db]")
+		optSrcNamespace    = getopt.StringLong("src-namespace", 'n', "", "Source namespace [e.g. metrics]")
+		optSrcBlockSize    = getopt.DurationLong("src-block-size", 'b', 2*time.Hour, "Source block size [e.g. 2h]")
+		optSrcEncoding     = getopt.StringLong("src-encoding", 'e', "m3tsz", "Source encoding [m3tsz|proto]")
+		optDestNamespace   = getopt.StringLong("dest-namespace", 'N', "", "Destination namespace [e.g. metrics_4h]")
+		optDestBlockSize   = getopt.DurationLong("dest-block-size", 'B', 2*time.Hour, "Destination block size [e.g. 4h]")
+		optDestEncoding    = getopt.StringLong("dest-encoding", 'E', "m3tsz", "Destination encoding [m3tsz|proto]")
+		optDestCompression = getopt.StringLong("dest-compression", 'c', "none", "Destination compression [none|snappy|deflate]")
+		optShards          = getopt.StringLong("shards", 's', "", "Comma separated shards, all shards if not set [e.g. 1,2,3]")
+		optConcurrency     = getopt.IntLong("concurrency", 'C', 1, "Number of shards re-encoded concurrently")
+	)
+	getopt.Parse()
+
+	rawLogger, err := zap.NewDevelopment()
+	if err != nil {
+		log.Fatalf("unable to create logger: %+v", err)
+	}
+	log := rawLogger.Sugar()
+
+	if *optPathPrefix == "" ||
+		*optSrcNamespace == "" ||
+		*optDestNamespace == "" ||
+		*optSrcBlockSize <= 0 ||
+		*optDestBlockSize <= 0 ||
+		*optConcurrency < 1 {
+		getopt.Usage()
+		os.Exit(1)
+	}
+
+	srcEncoding, err := migration.ParseEncoding(*optSrcEncoding)
+	if err != nil {
+		log.Fatalf("invalid source encoding: %v", err)
+	}
+	destEncoding, err := migration.ParseEncoding(*optDestEncoding)
+	if err != nil {
+		log.Fatalf("invalid destination encoding: %v", err)
+	}
+	destCompression, err := compression.ParseType(*optDestCompression)
+	if err != nil {
+		log.Fatalf("invalid destination compression: %v", err)
+	}
+
+	var shards []uint32
+	if *optShards != "" {
+		for _, str := range strings.Split(*optShards, ",") {
+			shard, err := strconv.ParseUint(strings.TrimSpace(str), 10, 32)
+			if err != nil {
+				log.Fatalf("invalid shard %s: %v", str, err)
+			}
+			shards = append(shards, uint32(shard))
+		}
+	}
+
+	srcMd, err := namespace.NewMetadata(ident.StringID(*optSrcNamespace),
+		namespace.NewOptions().
+			SetRetentionOptions(retention.NewOptions().SetBlockSize(*optSrcBlockSize)))
+	if err != nil {
+		log.Fatalf("unable to create source namespace metadata: %v", err)
+	}
+	destMd, err := namespace.NewMetadata(ident.StringID(*optDestNamespace),
+		namespace.NewOptions().
+			SetRetentionOptions(retention.NewOptions().SetBlockSize(*optDestBlockSize)).
+			SetCompressionType(destCompression))
+	if err != nil {
+		log.Fatalf("unable to create destination namespace metadata: %v", err)
+	}
+
+	opts := migration.NewReencodeOptions().
+		SetSourceNamespace(srcMd).
+		SetSourceEncoding(srcEncoding).
+		SetTargetNamespace(destMd).
+		SetTargetEncoding(destEncoding).
+		SetShards(shards).
+		SetConcurrency(*optConcurrency).
+		SetFilesystemOptions(fs.NewOptions().SetFilePathPrefix(*optPathPrefix)).
+		SetInstrumentOptions(instrument.NewOptions().SetLogger(rawLogger))
+
+	reencoder, err := migration.NewReencoder(opts)
+	if err != nil {
+		log.Fatalf("unable to create re-encoder: %v", err)
+	}
+	if err := reencoder.Run(); err != nil {
+		log.Fatalf("unable to re-encode filesets: %v", err)
+	}
+}
@@ -965,6 +965,21 @@ func (mr *MockAdminSessionMockRecorder) Truncate(namespace interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Truncate", reflect.TypeOf((*MockAdminSession)(nil).Truncate), namespace)
 }
 
+// DeleteSeries mocks base method
+func (m *MockAdminSession) DeleteSeries(namespace ident.ID, ids []ident.ID, start, end time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", namespace, ids, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries
+func (mr *MockAdminSessionMockRecorder) DeleteSeries(namespace, ids, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockAdminSession)(nil).DeleteSeries), namespace, ids, start, end)
+}
+
 // FetchBootstrapBlocksFromPeers mocks base method
 func (m *MockAdminSession) FetchBootstrapBlocksFromPeers(namespace namespace.Metadata, shard uint32, start, end time.Time, opts result.Options) (result.ShardResult, error) {
 	m.ctrl.T.Helper()
@@ -1025,6 +1040,21 @@ func (mr *MockAdminSessionMockRecorder) FetchBlocksFromPeers(namespace, shard, c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlocksFromPeers", reflect.TypeOf((*MockAdminSession)(nil).FetchBlocksFromPeers), namespace, shard, consistencyLevel, metadatas, opts)
 }
 
+// FetchBlockHashTreeFromPeer mocks base method
+func (m *MockAdminSession) FetchBlockHashTreeFromPeer(peer topology.Host, namespace ident.ID, shard uint32, blockStart time.Time, req BlockHashTreeRequest) (BlockHashTreeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBlockHashTreeFromPeer", peer, namespace, shard, blockStart, req)
+	ret0, _ := ret[0].(BlockHashTreeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBlockHashTreeFromPeer indicates an expected call of FetchBlockHashTreeFromPeer
+func (mr *MockAdminSessionMockRecorder) FetchBlockHashTreeFromPeer(peer, namespace, shard, blockStart, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlockHashTreeFromPeer", reflect.TypeOf((*MockAdminSession)(nil).FetchBlockHashTreeFromPeer), peer, namespace, shard, blockStart, req)
+}
+
 // MockOptions is a mock of Options interface
 type MockOptions struct {
 	ctrl     *gomock.Controller
@@ -1552,6 +1582,34 @@ func (mr *MockOptionsMockRecorder) TruncateRequestTimeout() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TruncateRequestTimeout", reflect.TypeOf((*MockOptions)(nil).TruncateRequestTimeout))
 }
 
+// SetDeleteSeriesRequestTimeout mocks base method
+func (m *MockOptions) SetDeleteSeriesRequestTimeout(value time.Duration) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeleteSeriesRequestTimeout", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetDeleteSeriesRequestTimeout indicates an expected call of SetDeleteSeriesRequestTimeout
+func (mr *MockOptionsMockRecorder) SetDeleteSeriesRequestTimeout(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeleteSeriesRequestTimeout", reflect.TypeOf((*MockOptions)(nil).SetDeleteSeriesRequestTimeout), value)
+}
+
+// DeleteSeriesRequestTimeout mocks base method
+func (m *MockOptions) DeleteSeriesRequestTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeriesRequestTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// DeleteSeriesRequestTimeout indicates an expected call of DeleteSeriesRequestTimeout
+func (mr *MockOptionsMockRecorder) DeleteSeriesRequestTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeriesRequestTimeout", reflect.TypeOf((*MockOptions)(nil).DeleteSeriesRequestTimeout))
+}
+
 // SetBackgroundConnectInterval mocks base method
 func (m *MockOptions) SetBackgroundConnectInterval(value time.Duration) Options {
 	m.ctrl.T.Helper()
@@ -3143,6 +3201,34 @@ func (mr *MockAdminOptionsMockRecorder) TruncateRequestTimeout() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TruncateRequestTimeout", reflect.TypeOf((*MockAdminOptions)(nil).TruncateRequestTimeout))
 }
 
+// SetDeleteSeriesRequestTimeout mocks base method
+func (m *MockAdminOptions) SetDeleteSeriesRequestTimeout(value time.Duration) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeleteSeriesRequestTimeout", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetDeleteSeriesRequestTimeout indicates an expected call of SetDeleteSeriesRequestTimeout
+func (mr *MockAdminOptionsMockRecorder) SetDeleteSeriesRequestTimeout(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeleteSeriesRequestTimeout", reflect.TypeOf((*MockAdminOptions)(nil).SetDeleteSeriesRequestTimeout), value)
+}
+
+// DeleteSeriesRequestTimeout mocks base method
+func (m *MockAdminOptions) DeleteSeriesRequestTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeriesRequestTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// DeleteSeriesRequestTimeout indicates an expected call of DeleteSeriesRequestTimeout
+func (mr *MockAdminOptionsMockRecorder) DeleteSeriesRequestTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeriesRequestTimeout", reflect.TypeOf((*MockAdminOptions)(nil).DeleteSeriesRequestTimeout))
+}
+
 // SetBackgroundConnectInterval mocks base method
 func (m *MockAdminOptions) SetBackgroundConnectInterval(value time.Duration) Options {
 	m.ctrl.T.Helper()
@@ -4662,6 +4748,21 @@ func (mr *MockclientSessionMockRecorder) Truncate(namespace interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Truncate", reflect.TypeOf((*MockclientSession)(nil).Truncate), namespace)
 }
 
+// DeleteSeries mocks base method
+func (m *MockclientSession) DeleteSeries(namespace ident.ID, ids []ident.ID, start, end time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", namespace, ids, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries
+func (mr *MockclientSessionMockRecorder) DeleteSeries(namespace, ids, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockclientSession)(nil).DeleteSeries), namespace, ids, start, end)
+}
+
 // FetchBootstrapBlocksFromPeers mocks base method
 func (m *MockclientSession) FetchBootstrapBlocksFromPeers(namespace namespace.Metadata, shard uint32, start, end time.Time, opts result.Options) (result.ShardResult, error) {
 	m.ctrl.T.Helper()
@@ -4722,6 +4823,21 @@ func (mr *MockclientSessionMockRecorder) FetchBlocksFromPeers(namespace, shard,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlocksFromPeers", reflect.TypeOf((*MockclientSession)(nil).FetchBlocksFromPeers), namespace, shard, consistencyLevel, metadatas, opts)
 }
 
+// FetchBlockHashTreeFromPeer mocks base method
+func (m *MockclientSession) FetchBlockHashTreeFromPeer(peer topology.Host, namespace ident.ID, shard uint32, blockStart time.Time, req BlockHashTreeRequest) (BlockHashTreeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBlockHashTreeFromPeer", peer, namespace, shard, blockStart, req)
+	ret0, _ := ret[0].(BlockHashTreeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBlockHashTreeFromPeer indicates an expected call of FetchBlockHashTreeFromPeer
+func (mr *MockclientSessionMockRecorder) FetchBlockHashTreeFromPeer(peer, namespace, shard, blockStart, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlockHashTreeFromPeer", reflect.TypeOf((*MockclientSession)(nil).FetchBlockHashTreeFromPeer), peer, namespace, shard, blockStart, req)
+}
+
 // Open mocks base method
 func (m *MockclientSession) Open() error {
 	m.ctrl.T.Helper()
@@ -54,6 +54,18 @@ func IsBadRequestError(err error) bool {
 	return false
 }
 
+// IsTenantLimitExceededError determines if the error was caused by a tenant
+// exceeding one of its limits.
+func IsTenantLimitExceededError(err error) bool {
+	for err != nil {
+		if e, ok := err.(*rpc.Error); ok && tterrors.IsTenantLimitExceededError(e) {
+			return true
+		}
+		err = xerrors.InnerError(err)
+	}
+	return false
+}
+
 // IsConsistencyResultError determines if the error is a consistency result error.
 func IsConsistencyResultError(err error) bool {
 	_, ok := err.(consistencyResultErr)
@@ -26,6 +26,7 @@ import (
 	"testing"
 
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
 	"github.com/m3db/m3/src/dbnode/topology"
 	xerrors "github.com/m3db/m3/src/x/errors"
 
@@ -52,3 +53,22 @@ func TestConsistencyResultError(t *testing.T) {
 	assert.Equal(t, 1, NumSuccess(err))
 	assert.Equal(t, 2, NumError(err))
 }
+
+func TestTenantLimitExceededError(t *testing.T) {
+	limitErr := xerrors.NewRenamedError(
+		tterrors.NewTenantLimitExceededError(fmt.Errorf("tenant limit exceeded")),
+		fmt.Errorf("renamed error"))
+
+	level := topology.ReadConsistencyLevelMajority
+	errs := []error{limitErr, fmt.Errorf("another error")}
+
+	err := error(newConsistencyResultError(level, 3, 3, errs))
+	assert.True(t, IsTenantLimitExceededError(err))
+	assert.True(t, IsBadRequestError(err))
+
+	err = error(newConsistencyResultError(level, 3, 3, []error{
+		tterrors.NewBadRequestError(fmt.Errorf("bad request")),
+	}))
+	assert.False(t, IsTenantLimitExceededError(err))
+	assert.True(t, IsBadRequestError(err))
+}
@@ -89,6 +89,9 @@ const (
 	// defaultTruncateRequestTimeout is the default truncate request timeout
 	defaultTruncateRequestTimeout = 60 * time.Second
 
+	// defaultDeleteSeriesRequestTimeout is the default delete series request timeout
+	defaultDeleteSeriesRequestTimeout = 30 * time.Second
+
 	// defaultWriteShardsInitializing is the default write to shards intializing value
 	defaultWriteShardsInitializing = true
 
@@ -242,6 +245,7 @@ type options struct {
 	writeRequestTimeout                     time.Duration
 	fetchRequestTimeout                     time.Duration
 	truncateRequestTimeout                  time.Duration
+	deleteSeriesRequestTimeout              time.Duration
 	backgroundConnectInterval               time.Duration
 	backgroundConnectStutter                time.Duration
 	backgroundHealthCheckInterval           time.Duration
@@ -373,6 +377,7 @@ func newOptions() *options {
 		writeRequestTimeout:                     defaultWriteRequestTimeout,
 		fetchRequestTimeout:                     defaultFetchRequestTimeout,
 		truncateRequestTimeout:                  defaultTruncateRequestTimeout,
+		deleteSeriesRequestTimeout:              defaultDeleteSeriesRequestTimeout,
 		backgroundConnectInterval:               defaultBackgroundConnectInterval,
 		backgroundConnectStutter:                defaultBackgroundConnectStutter,
 		backgroundHealthCheckInterval:           defaultBackgroundHealthCheckInterval,
@@ -642,6 +647,16 @@ func (o *options) TruncateRequestTimeout() time.Duration {
 	return o.truncateRequestTimeout
 }
 
+func (o *options) SetDeleteSeriesRequestTimeout(value time.Duration) Options {
+	opts := *o
+	opts.deleteSeriesRequestTimeout = value
+	return &opts
+}
+
+func (o *options) DeleteSeriesRequestTimeout() time.Duration {
+	return o.deleteSeriesRequestTimeout
+}
+
 func (o *options) SetBackgroundConnectInterval(value time.Duration) Options {
 	opts := *o
 	opts.backgroundConnectInterval = value
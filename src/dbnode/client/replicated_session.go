@@ -289,6 +289,14 @@ func (s replicatedSession) Truncate(namespace ident.ID) (int64, error) {
 	return s.session.Truncate(namespace)
 }
 
+func (s replicatedSession) DeleteSeries(
+	namespace ident.ID,
+	ids []ident.ID,
+	start, end time.Time,
+) (int64, error) {
+	return s.session.DeleteSeries(namespace, ids, start, end)
+}
+
 // FetchBootstrapBlocksFromPeers will fetch the most fulfilled block
 // for each series using the runtime configurable bootstrap level consistency.
 func (s replicatedSession) FetchBootstrapBlocksFromPeers(
@@ -335,6 +343,16 @@ func (s replicatedSession) FetchBlocksFromPeers(
 	return s.session.FetchBlocksFromPeers(namespace, shard, consistencyLevel, metadatas, opts)
 }
 
+func (s replicatedSession) FetchBlockHashTreeFromPeer(
+	peer topology.Host,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+	req BlockHashTreeRequest,
+) (BlockHashTreeResult, error) {
+	return s.session.FetchBlockHashTreeFromPeer(peer, namespace, shard, blockStart, req)
+}
+
 // Open the client session.
 func (s replicatedSession) Open() error {
 	if err := s.session.Open(); err != nil {
@@ -1939,6 +1939,72 @@ func (s *session) Truncate(namespace ident.ID) (int64, error) {
 	return truncated, resultErr.FinalError()
 }
 
+func (s *session) DeleteSeries(
+	namespace ident.ID,
+	ids []ident.ID,
+	start, end time.Time,
+) (int64, error) {
+	rangeStart, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	if err != nil {
+		return 0, err
+	}
+	rangeEnd, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg            sync.WaitGroup
+		enqueueErr    xerrors.MultiError
+		resultErrLock sync.Mutex
+		resultErr     xerrors.MultiError
+		deleted       int64
+	)
+
+	d := &deleteSeriesOp{}
+	d.request.NameSpace = namespace.Bytes()
+	d.request.Ids = make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		d.request.Ids = append(d.request.Ids, id.Bytes())
+	}
+	d.request.RangeStart = rangeStart
+	d.request.RangeEnd = rangeEnd
+	d.request.RangeTimeType = rpc.TimeType_UNIX_NANOSECONDS
+	d.completionFn = func(result interface{}, err error) {
+		if err != nil {
+			resultErrLock.Lock()
+			resultErr = resultErr.Add(err)
+			resultErrLock.Unlock()
+		} else {
+			res := result.(*rpc.DeleteSeriesResult_)
+			atomic.AddInt64(&deleted, res.NumSeries)
+		}
+		wg.Done()
+	}
+
+	// Deletes are sent to every host, each host only deletes the series
+	// that belong to shards it owns.
+	s.state.RLock()
+	for idx := range s.state.queues {
+		wg.Add(1)
+		if err := s.state.queues[idx].Enqueue(d); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Error("failed to enqueue request", zap.Error(err))
+		return 0, err
+	}
+
+	// Wait for the series to be deleted on all replicas
+	wg.Wait()
+
+	return deleted, resultErr.FinalError()
+}
+
 // NB(r): Excluding maligned struct check here as we can
 // live with a few extra bytes since this struct is only
 // ever passed by stack, its much more readable not optimized
@@ -2209,6 +2275,61 @@ func (s *session) FetchBlocksFromPeers(
 	return pbi, nil
 }
 
+func (s *session) FetchBlockHashTreeFromPeer(
+	peer topology.Host,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+	req BlockHashTreeRequest,
+) (BlockHashTreeResult, error) {
+	nodes := make([]int32, 0, len(req.Nodes))
+	for _, node := range req.Nodes {
+		nodes = append(nodes, int32(node))
+	}
+
+	var (
+		result BlockHashTreeResult
+		resErr error
+	)
+	err := s.BorrowConnection(peer.ID(), func(client rpc.TChanNode) {
+		tctx, _ := thrift.NewContext(s.streamBlocksMetadataBatchTimeout)
+		res, err := client.FetchBlockHashTree(tctx, &rpc.FetchBlockHashTreeRequest{
+			NameSpace:      namespace.Bytes(),
+			Shard:          int32(shard),
+			BlockStart:     blockStart.UnixNano(),
+			Fanout:         int32(req.Fanout),
+			Depth:          int32(req.Depth),
+			Level:          int32(req.Level),
+			Nodes:          nodes,
+			IncludeEntries: req.IncludeEntries,
+		})
+		if err != nil {
+			resErr = err
+			return
+		}
+
+		result.Hashes = make([]uint64, 0, len(res.Hashes))
+		for _, hash := range res.Hashes {
+			result.Hashes = append(result.Hashes, uint64(hash))
+		}
+		result.Entries = make([]BlockHashTreeEntry, 0, len(res.Entries))
+		for _, entry := range res.Entries {
+			result.Entries = append(result.Entries, BlockHashTreeEntry{
+				ID:       ident.BytesID(entry.ID),
+				Size:     entry.Size,
+				Checksum: uint32(entry.Checksum),
+			})
+		}
+	})
+	if err != nil {
+		return BlockHashTreeResult{}, err
+	}
+	if resErr != nil {
+		return BlockHashTreeResult{}, resErr
+	}
+	return result, nil
+}
+
 func (s *session) streamBlocksMetadataFromPeers(
 	namespace ident.ID,
 	shardID uint32,
@@ -202,6 +202,15 @@ type AdminSession interface {
 	// Truncate will truncate the namespace for a given shard.
 	Truncate(namespace ident.ID) (int64, error)
 
+	// DeleteSeries will delete the data within [start, end] for the given
+	// series on every replica, returning the sum of series deleted on each
+	// replica.
+	DeleteSeries(
+		namespace ident.ID,
+		ids []ident.ID,
+		start, end time.Time,
+	) (int64, error)
+
 	// FetchBootstrapBlocksFromPeers will fetch the most fulfilled block
 	// for each series using the runtime configurable bootstrap level consistency.
 	FetchBootstrapBlocksFromPeers(
@@ -239,6 +248,42 @@ type AdminSession interface {
 		metadatas []block.ReplicaMetadata,
 		opts result.Options,
 	) (PeerBlocksIter, error)
+
+	// FetchBlockHashTreeFromPeer will fetch the hashes of the requested
+	// nodes at a level of the hash tree of a block from a peer, along with
+	// the series checksums that make up the nodes when they are leaves and
+	// entries are requested.
+	FetchBlockHashTreeFromPeer(
+		peer topology.Host,
+		namespace ident.ID,
+		shard uint32,
+		blockStart time.Time,
+		req BlockHashTreeRequest,
+	) (BlockHashTreeResult, error)
+}
+
+// BlockHashTreeRequest is a request for the hashes of nodes at a level of
+// the hash tree of a block.
+type BlockHashTreeRequest struct {
+	Fanout         int
+	Depth          int
+	Level          int
+	Nodes          []int
+	IncludeEntries bool
+}
+
+// BlockHashTreeResult is the result of a block hash tree request.
+type BlockHashTreeResult struct {
+	Hashes  []uint64
+	Entries []BlockHashTreeEntry
+}
+
+// BlockHashTreeEntry is the checksum and size of a series that makes up a
+// leaf of a block hash tree.
+type BlockHashTreeEntry struct {
+	ID       ident.ID
+	Size     int64
+	Checksum uint32
 }
 
 // Options is a set of client options.
@@ -351,6 +396,12 @@ type Options interface {
 	// TruncateRequestTimeout returns the truncateRequestTimeout.
 	TruncateRequestTimeout() time.Duration
 
+	// SetDeleteSeriesRequestTimeout sets the deleteSeriesRequestTimeout.
+	SetDeleteSeriesRequestTimeout(value time.Duration) Options
+
+	// DeleteSeriesRequestTimeout returns the deleteSeriesRequestTimeout.
+	DeleteSeriesRequestTimeout() time.Duration
+
 	// SetBackgroundConnectInterval sets the backgroundConnectInterval.
 	SetBackgroundConnectInterval(value time.Duration) Options
 
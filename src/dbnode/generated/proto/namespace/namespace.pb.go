@@ -39,6 +39,10 @@
 		StagingState
 		Registry
 		NamespaceRuntimeOptions
+		RetentionRule
+		TileAggregationOptions
+		CardinalityLimits
+		TieringPolicy
 		SchemaOptions
 		SchemaHistory
 		FileDescriptorSet
@@ -195,6 +199,10 @@ type NamespaceOptions struct {
 	CacheBlocksOnRetrieve *google_protobuf1.BoolValue `protobuf:"bytes,12,opt,name=cacheBlocksOnRetrieve" json:"cacheBlocksOnRetrieve,omitempty"`
 	AggregationOptions    *AggregationOptions         `protobuf:"bytes,13,opt,name=aggregationOptions" json:"aggregationOptions,omitempty"`
 	StagingState          *StagingState               `protobuf:"bytes,14,opt,name=stagingState" json:"stagingState,omitempty"`
+	Compression           string                      `protobuf:"bytes,15,opt,name=compression,proto3" json:"compression,omitempty"`
+	RetentionRules        []*RetentionRule            `protobuf:"bytes,16,rep,name=retentionRules" json:"retentionRules,omitempty"`
+	CardinalityLimits     *CardinalityLimits          `protobuf:"bytes,17,opt,name=cardinalityLimits" json:"cardinalityLimits,omitempty"`
+	TieringPolicy         *TieringPolicy              `protobuf:"bytes,18,opt,name=tieringPolicy" json:"tieringPolicy,omitempty"`
 	// Use larger field ID to ensure new fields are always added before extended options.
 	ExtendedOptions *google_protobuf.Any `protobuf:"bytes,1000,opt,name=extendedOptions" json:"extendedOptions,omitempty"`
 }
@@ -302,6 +310,34 @@ func (m *NamespaceOptions) GetStagingState() *StagingState {
 	return nil
 }
 
+func (m *NamespaceOptions) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *NamespaceOptions) GetRetentionRules() []*RetentionRule {
+	if m != nil {
+		return m.RetentionRules
+	}
+	return nil
+}
+
+func (m *NamespaceOptions) GetCardinalityLimits() *CardinalityLimits {
+	if m != nil {
+		return m.CardinalityLimits
+	}
+	return nil
+}
+
+func (m *NamespaceOptions) GetTieringPolicy() *TieringPolicy {
+	if m != nil {
+		return m.TieringPolicy
+	}
+	return nil
+}
+
 func (m *NamespaceOptions) GetExtendedOptions() *google_protobuf.Any {
 	if m != nil {
 		return m.ExtendedOptions
@@ -362,8 +398,9 @@ func (m *Aggregation) GetAttributes() *AggregatedAttributes {
 // AggregatedAttributes describe how to aggregate data.
 type AggregatedAttributes struct {
 	// resolutionNanos is the time range to aggregate data across.
-	ResolutionNanos   int64              `protobuf:"varint,1,opt,name=resolutionNanos,proto3" json:"resolutionNanos,omitempty"`
-	DownsampleOptions *DownsampleOptions `protobuf:"bytes,2,opt,name=downsampleOptions" json:"downsampleOptions,omitempty"`
+	ResolutionNanos        int64                   `protobuf:"varint,1,opt,name=resolutionNanos,proto3" json:"resolutionNanos,omitempty"`
+	DownsampleOptions      *DownsampleOptions      `protobuf:"bytes,2,opt,name=downsampleOptions" json:"downsampleOptions,omitempty"`
+	TileAggregationOptions *TileAggregationOptions `protobuf:"bytes,3,opt,name=tileAggregationOptions" json:"tileAggregationOptions,omitempty"`
 }
 
 func (m *AggregatedAttributes) Reset()                    { *m = AggregatedAttributes{} }
@@ -385,6 +422,13 @@ func (m *AggregatedAttributes) GetDownsampleOptions() *DownsampleOptions {
 	return nil
 }
 
+func (m *AggregatedAttributes) GetTileAggregationOptions() *TileAggregationOptions {
+	if m != nil {
+		return m.TileAggregationOptions
+	}
+	return nil
+}
+
 // DownsampleOptions is a set of options related to downsampling data.
 type DownsampleOptions struct {
 	// all indicates whether to send data points to this namespace. If false,
@@ -462,6 +506,121 @@ func (m *NamespaceRuntimeOptions) GetFlushIndexingPerCPUConcurrency() *google_pr
 	return nil
 }
 
+// RetentionRule overrides the namespace retention period for series
+// whose tags match the filter.
+type RetentionRule struct {
+	Filter               string `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	RetentionPeriodNanos int64  `protobuf:"varint,2,opt,name=retentionPeriodNanos,proto3" json:"retentionPeriodNanos,omitempty"`
+}
+
+func (m *RetentionRule) Reset()                    { *m = RetentionRule{} }
+func (m *RetentionRule) String() string            { return proto.CompactTextString(m) }
+func (*RetentionRule) ProtoMessage()               {}
+func (*RetentionRule) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{10} }
+
+func (m *RetentionRule) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *RetentionRule) GetRetentionPeriodNanos() int64 {
+	if m != nil {
+		return m.RetentionPeriodNanos
+	}
+	return 0
+}
+
+// TileAggregationOptions enable automatic aggregation of tiles into the
+// namespace from a source namespace once source blocks are flushed.
+type TileAggregationOptions struct {
+	// sourceNamespace is the namespace to aggregate tiles from.
+	SourceNamespace string `protobuf:"bytes,1,opt,name=sourceNamespace,proto3" json:"sourceNamespace,omitempty"`
+	// downsampleType is how datapoints within a resolution step are combined,
+	// one of "last", "sum", "min", "max" or "mean", defaults to "last".
+	DownsampleType string `protobuf:"bytes,2,opt,name=downsampleType,proto3" json:"downsampleType,omitempty"`
+}
+
+func (m *TileAggregationOptions) Reset()                    { *m = TileAggregationOptions{} }
+func (m *TileAggregationOptions) String() string            { return proto.CompactTextString(m) }
+func (*TileAggregationOptions) ProtoMessage()               {}
+func (*TileAggregationOptions) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{11} }
+
+func (m *TileAggregationOptions) GetSourceNamespace() string {
+	if m != nil {
+		return m.SourceNamespace
+	}
+	return ""
+}
+
+func (m *TileAggregationOptions) GetDownsampleType() string {
+	if m != nil {
+		return m.DownsampleType
+	}
+	return ""
+}
+
+// CardinalityLimits cap the number of active series in a namespace, new
+// series are rejected once a limit is reached.
+type CardinalityLimits struct {
+	// maxSeries is the max number of active series in the namespace,
+	// zero means unlimited.
+	MaxSeries int64 `protobuf:"varint,1,opt,name=maxSeries,proto3" json:"maxSeries,omitempty"`
+	// maxSeriesPerMetricName is the max number of active series in the
+	// namespace sharing a metric name, zero means unlimited.
+	MaxSeriesPerMetricName int64 `protobuf:"varint,2,opt,name=maxSeriesPerMetricName,proto3" json:"maxSeriesPerMetricName,omitempty"`
+	// metricNameTag is the tag holding the metric name of a series,
+	// defaults to "__name__".
+	MetricNameTag string `protobuf:"bytes,3,opt,name=metricNameTag,proto3" json:"metricNameTag,omitempty"`
+}
+
+func (m *CardinalityLimits) Reset()                    { *m = CardinalityLimits{} }
+func (m *CardinalityLimits) String() string            { return proto.CompactTextString(m) }
+func (*CardinalityLimits) ProtoMessage()               {}
+func (*CardinalityLimits) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{12} }
+
+func (m *CardinalityLimits) GetMaxSeries() int64 {
+	if m != nil {
+		return m.MaxSeries
+	}
+	return 0
+}
+
+func (m *CardinalityLimits) GetMaxSeriesPerMetricName() int64 {
+	if m != nil {
+		return m.MaxSeriesPerMetricName
+	}
+	return 0
+}
+
+func (m *CardinalityLimits) GetMetricNameTag() string {
+	if m != nil {
+		return m.MetricNameTag
+	}
+	return ""
+}
+
+// TieringPolicy moves flushed filesets of a namespace to a secondary tier
+// once they are older than a configured age.
+type TieringPolicy struct {
+	// afterNanos is the age after the end of a block that its filesets are
+	// moved to the secondary tier, zero disables tiering.
+	AfterNanos int64 `protobuf:"varint,1,opt,name=afterNanos,proto3" json:"afterNanos,omitempty"`
+}
+
+func (m *TieringPolicy) Reset()                    { *m = TieringPolicy{} }
+func (m *TieringPolicy) String() string            { return proto.CompactTextString(m) }
+func (*TieringPolicy) ProtoMessage()               {}
+func (*TieringPolicy) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{13} }
+
+func (m *TieringPolicy) GetAfterNanos() int64 {
+	if m != nil {
+		return m.AfterNanos
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*RetentionOptions)(nil), "namespace.RetentionOptions")
 	proto.RegisterType((*IndexOptions)(nil), "namespace.IndexOptions")
@@ -473,6 +632,10 @@ func init() {
 	proto.RegisterType((*StagingState)(nil), "namespace.StagingState")
 	proto.RegisterType((*Registry)(nil), "namespace.Registry")
 	proto.RegisterType((*NamespaceRuntimeOptions)(nil), "namespace.NamespaceRuntimeOptions")
+	proto.RegisterType((*RetentionRule)(nil), "namespace.RetentionRule")
+	proto.RegisterType((*TileAggregationOptions)(nil), "namespace.TileAggregationOptions")
+	proto.RegisterType((*CardinalityLimits)(nil), "namespace.CardinalityLimits")
+	proto.RegisterType((*TieringPolicy)(nil), "namespace.TieringPolicy")
 	proto.RegisterEnum("namespace.StagingStatus", StagingStatus_name, StagingStatus_value)
 }
 func (m *RetentionOptions) Marshal() (dAtA []byte, err error) {
@@ -721,6 +884,50 @@ func (m *NamespaceOptions) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n7
 	}
+	if len(m.Compression) > 0 {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.Compression)))
+		i += copy(dAtA[i:], m.Compression)
+	}
+	if len(m.RetentionRules) > 0 {
+		for _, msg := range m.RetentionRules {
+			dAtA[i] = 0x82
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintNamespace(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.CardinalityLimits != nil {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.CardinalityLimits.Size()))
+		n, err := m.CardinalityLimits.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n
+	}
+	if m.TieringPolicy != nil {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.TieringPolicy.Size()))
+		n, err := m.TieringPolicy.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n
+	}
 	if m.ExtendedOptions != nil {
 		dAtA[i] = 0xc2
 		i++
@@ -834,6 +1041,16 @@ func (m *AggregatedAttributes) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n10
 	}
+	if m.TileAggregationOptions != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.TileAggregationOptions.Size()))
+		n, err := m.TileAggregationOptions.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n
+	}
 	return i, nil
 }
 
@@ -972,6 +1189,122 @@ func (m *NamespaceRuntimeOptions) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *RetentionRule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RetentionRule) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Filter) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.Filter)))
+		i += copy(dAtA[i:], m.Filter)
+	}
+	if m.RetentionPeriodNanos != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.RetentionPeriodNanos))
+	}
+	return i, nil
+}
+
+func (m *TileAggregationOptions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TileAggregationOptions) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.SourceNamespace) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.SourceNamespace)))
+		i += copy(dAtA[i:], m.SourceNamespace)
+	}
+	if len(m.DownsampleType) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.DownsampleType)))
+		i += copy(dAtA[i:], m.DownsampleType)
+	}
+	return i, nil
+}
+
+func (m *CardinalityLimits) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CardinalityLimits) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxSeries != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.MaxSeries))
+	}
+	if m.MaxSeriesPerMetricName != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.MaxSeriesPerMetricName))
+	}
+	if len(m.MetricNameTag) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.MetricNameTag)))
+		i += copy(dAtA[i:], m.MetricNameTag)
+	}
+	return i, nil
+}
+
+func (m *TieringPolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TieringPolicy) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.AfterNanos != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.AfterNanos))
+	}
+	return i, nil
+}
+
 func encodeVarintNamespace(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -1072,6 +1405,24 @@ func (m *NamespaceOptions) Size() (n int) {
 		l = m.StagingState.Size()
 		n += 1 + l + sovNamespace(uint64(l))
 	}
+	l = len(m.Compression)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	if len(m.RetentionRules) > 0 {
+		for _, e := range m.RetentionRules {
+			l = e.Size()
+			n += 2 + l + sovNamespace(uint64(l))
+		}
+	}
+	if m.CardinalityLimits != nil {
+		l = m.CardinalityLimits.Size()
+		n += 2 + l + sovNamespace(uint64(l))
+	}
+	if m.TieringPolicy != nil {
+		l = m.TieringPolicy.Size()
+		n += 2 + l + sovNamespace(uint64(l))
+	}
 	if m.ExtendedOptions != nil {
 		l = m.ExtendedOptions.Size()
 		n += 2 + l + sovNamespace(uint64(l))
@@ -1114,6 +1465,10 @@ func (m *AggregatedAttributes) Size() (n int) {
 		l = m.DownsampleOptions.Size()
 		n += 1 + l + sovNamespace(uint64(l))
 	}
+	if m.TileAggregationOptions != nil {
+		l = m.TileAggregationOptions.Size()
+		n += 1 + l + sovNamespace(uint64(l))
+	}
 	return n
 }
 
@@ -1168,6 +1523,58 @@ func (m *NamespaceRuntimeOptions) Size() (n int) {
 	return n
 }
 
+func (m *RetentionRule) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Filter)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	if m.RetentionPeriodNanos != 0 {
+		n += 1 + sovNamespace(uint64(m.RetentionPeriodNanos))
+	}
+	return n
+}
+
+func (m *TileAggregationOptions) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.SourceNamespace)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	l = len(m.DownsampleType)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	return n
+}
+
+func (m *CardinalityLimits) Size() (n int) {
+	var l int
+	_ = l
+	if m.MaxSeries != 0 {
+		n += 1 + sovNamespace(uint64(m.MaxSeries))
+	}
+	if m.MaxSeriesPerMetricName != 0 {
+		n += 1 + sovNamespace(uint64(m.MaxSeriesPerMetricName))
+	}
+	l = len(m.MetricNameTag)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	return n
+}
+
+func (m *TieringPolicy) Size() (n int) {
+	var l int
+	_ = l
+	if m.AfterNanos != 0 {
+		n += 1 + sovNamespace(uint64(m.AfterNanos))
+	}
+	return n
+}
+
 func sovNamespace(x uint64) (n int) {
 	for {
 		n++
@@ -1854,11 +2261,11 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 1000:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExtendedOptions", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNamespace
@@ -1868,33 +2275,159 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthNamespace
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ExtendedOptions == nil {
-				m.ExtendedOptions = &google_protobuf.Any{}
-			}
-			if err := m.ExtendedOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Compression = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipNamespace(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetentionRules", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthNamespace
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RetentionRules = append(m.RetentionRules, &RetentionRule{})
+			if err := m.RetentionRules[len(m.RetentionRules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CardinalityLimits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CardinalityLimits == nil {
+				m.CardinalityLimits = &CardinalityLimits{}
+			}
+			if err := m.CardinalityLimits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TieringPolicy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TieringPolicy == nil {
+				m.TieringPolicy = &TieringPolicy{}
+			}
+			if err := m.TieringPolicy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1000:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtendedOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExtendedOptions == nil {
+				m.ExtendedOptions = &google_protobuf.Any{}
+			}
+			if err := m.ExtendedOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
 			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
@@ -2173,6 +2706,39 @@ func (m *AggregatedAttributes) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TileAggregationOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TileAggregationOptions == nil {
+				m.TileAggregationOptions = &TileAggregationOptions{}
+			}
+			if err := m.TileAggregationOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNamespace(dAtA[iNdEx:])
@@ -2622,6 +3188,398 @@ func (m *NamespaceRuntimeOptions) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RetentionRule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RetentionRule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RetentionRule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Filter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Filter = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetentionPeriodNanos", wireType)
+			}
+			m.RetentionPeriodNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetentionPeriodNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TileAggregationOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TileAggregationOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TileAggregationOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceNamespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceNamespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DownsampleType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DownsampleType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CardinalityLimits) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CardinalityLimits: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CardinalityLimits: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSeries", wireType)
+			}
+			m.MaxSeries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSeries |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSeriesPerMetricName", wireType)
+			}
+			m.MaxSeriesPerMetricName = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSeriesPerMetricName |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MetricNameTag", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MetricNameTag = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TieringPolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TieringPolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TieringPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AfterNanos", wireType)
+			}
+			m.AfterNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AfterNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipNamespace(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -35,6 +35,7 @@ enum ErrorType {
 exception Error {
 	1: required ErrorType type = ErrorType.INTERNAL_ERROR
 	2: required string message
+	3: optional i64 flags = 0
 }
 
 exception WriteBatchRawErrors {
@@ -62,6 +63,9 @@ service Node {
 	void                           writeTaggedBatchRawV2(1: WriteTaggedBatchRawV2Request req) throws (1: WriteBatchRawErrors err)
 	void                           repair() throws (1: Error err)
 	TruncateResult                 truncate(1: TruncateRequest req) throws (1: Error err)
+	DeleteSeriesResult             deleteSeries(1: DeleteSeriesRequest req) throws (1: Error err)
+	FetchBlockHashTreeResult       fetchBlockHashTree(1: FetchBlockHashTreeRequest req) throws (1: Error err)
+	SeriesCardinalityResult        seriesCardinality(1: SeriesCardinalityRequest req) throws (1: Error err)
 
 	AggregateTilesResult aggregateTiles(1: AggregateTilesRequest req) throws (1: Error err)
 
@@ -312,6 +316,59 @@ struct TruncateResult {
 	1: required i64 numSeries
 }
 
+struct DeleteSeriesRequest {
+	1: required i64 rangeStart
+	2: required i64 rangeEnd
+	3: required binary nameSpace
+	4: required list<binary> ids
+	5: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+}
+
+struct DeleteSeriesResult {
+	1: required i64 numSeries
+}
+
+struct FetchBlockHashTreeRequest {
+	1: required binary nameSpace
+	2: required i32 shard
+	3: required i64 blockStart
+	4: required i32 fanout
+	5: required i32 depth
+	6: required i32 level
+	7: required list<i32> nodes
+	8: required bool includeEntries
+}
+
+struct FetchBlockHashTreeResult {
+	1: required list<i64> hashes
+	2: required list<BlockHashTreeEntry> entries
+}
+
+struct BlockHashTreeEntry {
+	1: required binary id
+	2: required i64 size
+	3: required i64 checksum
+}
+
+struct SeriesCardinalityRequest {
+	1: required binary nameSpace
+	// Number of metric names with the most series to return, zero
+	// returns the default number of metric names.
+	2: required i32 maxMetricNames
+}
+
+struct SeriesCardinalityResult {
+	1: required i64 numSeries
+	2: required i64 maxSeries
+	3: required i64 maxSeriesPerMetricName
+	4: required list<MetricNameSeriesCardinality> metricNames
+}
+
+struct MetricNameSeriesCardinality {
+	1: required binary metricName
+	2: required i64 numSeries
+}
+
 struct NodeHealthResult {
 	1: required bool ok
 	2: required string status
@@ -300,6 +300,21 @@ func (mr *MockTChanNodeMockRecorder) DebugProfileStop(ctx, req interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebugProfileStop", reflect.TypeOf((*MockTChanNode)(nil).DebugProfileStop), ctx, req)
 }
 
+// DeleteSeries mocks base method
+func (m *MockTChanNode) DeleteSeries(ctx thrift.Context, req *DeleteSeriesRequest) (*DeleteSeriesResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, req)
+	ret0, _ := ret[0].(*DeleteSeriesResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries
+func (mr *MockTChanNodeMockRecorder) DeleteSeries(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockTChanNode)(nil).DeleteSeries), ctx, req)
+}
+
 // Fetch mocks base method
 func (m *MockTChanNode) Fetch(ctx thrift.Context, req *FetchRequest) (*FetchResult_, error) {
 	m.ctrl.T.Helper()
@@ -345,6 +360,21 @@ func (mr *MockTChanNodeMockRecorder) FetchBatchRawV2(ctx, req interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBatchRawV2", reflect.TypeOf((*MockTChanNode)(nil).FetchBatchRawV2), ctx, req)
 }
 
+// FetchBlockHashTree mocks base method
+func (m *MockTChanNode) FetchBlockHashTree(ctx thrift.Context, req *FetchBlockHashTreeRequest) (*FetchBlockHashTreeResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBlockHashTree", ctx, req)
+	ret0, _ := ret[0].(*FetchBlockHashTreeResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBlockHashTree indicates an expected call of FetchBlockHashTree
+func (mr *MockTChanNodeMockRecorder) FetchBlockHashTree(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlockHashTree", reflect.TypeOf((*MockTChanNode)(nil).FetchBlockHashTree), ctx, req)
+}
+
 // FetchBlocksMetadataRawV2 mocks base method
 func (m *MockTChanNode) FetchBlocksMetadataRawV2(ctx thrift.Context, req *FetchBlocksMetadataRawV2Request) (*FetchBlocksMetadataRawV2Result_, error) {
 	m.ctrl.T.Helper()
@@ -494,6 +524,21 @@ func (mr *MockTChanNodeMockRecorder) Repair(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repair", reflect.TypeOf((*MockTChanNode)(nil).Repair), ctx)
 }
 
+// SeriesCardinality mocks base method
+func (m *MockTChanNode) SeriesCardinality(ctx thrift.Context, req *SeriesCardinalityRequest) (*SeriesCardinalityResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeriesCardinality", ctx, req)
+	ret0, _ := ret[0].(*SeriesCardinalityResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SeriesCardinality indicates an expected call of SeriesCardinality
+func (mr *MockTChanNodeMockRecorder) SeriesCardinality(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeriesCardinality", reflect.TypeOf((*MockTChanNode)(nil).SeriesCardinality), ctx, req)
+}
+
 // SetPersistRateLimit mocks base method
 func (m *MockTChanNode) SetPersistRateLimit(ctx thrift.Context, req *NodeSetPersistRateLimitRequest) (*NodePersistRateLimitResult_, error) {
 	m.ctrl.T.Helper()
@@ -53,9 +53,11 @@ type TChanNode interface {
 	DebugIndexMemorySegments(ctx thrift.Context, req *DebugIndexMemorySegmentsRequest) (*DebugIndexMemorySegmentsResult_, error)
 	DebugProfileStart(ctx thrift.Context, req *DebugProfileStartRequest) (*DebugProfileStartResult_, error)
 	DebugProfileStop(ctx thrift.Context, req *DebugProfileStopRequest) (*DebugProfileStopResult_, error)
+	DeleteSeries(ctx thrift.Context, req *DeleteSeriesRequest) (*DeleteSeriesResult_, error)
 	Fetch(ctx thrift.Context, req *FetchRequest) (*FetchResult_, error)
 	FetchBatchRaw(ctx thrift.Context, req *FetchBatchRawRequest) (*FetchBatchRawResult_, error)
 	FetchBatchRawV2(ctx thrift.Context, req *FetchBatchRawV2Request) (*FetchBatchRawResult_, error)
+	FetchBlockHashTree(ctx thrift.Context, req *FetchBlockHashTreeRequest) (*FetchBlockHashTreeResult_, error)
 	FetchBlocksMetadataRawV2(ctx thrift.Context, req *FetchBlocksMetadataRawV2Request) (*FetchBlocksMetadataRawV2Result_, error)
 	FetchBlocksRaw(ctx thrift.Context, req *FetchBlocksRawRequest) (*FetchBlocksRawResult_, error)
 	FetchTagged(ctx thrift.Context, req *FetchTaggedRequest) (*FetchTaggedResult_, error)
@@ -66,6 +68,7 @@ type TChanNode interface {
 	Health(ctx thrift.Context) (*NodeHealthResult_, error)
 	Query(ctx thrift.Context, req *QueryRequest) (*QueryResult_, error)
 	Repair(ctx thrift.Context) error
+	SeriesCardinality(ctx thrift.Context, req *SeriesCardinalityRequest) (*SeriesCardinalityResult_, error)
 	SetPersistRateLimit(ctx thrift.Context, req *NodeSetPersistRateLimitRequest) (*NodePersistRateLimitResult_, error)
 	SetWriteNewSeriesAsync(ctx thrift.Context, req *NodeSetWriteNewSeriesAsyncRequest) (*NodeWriteNewSeriesAsyncResult_, error)
 	SetWriteNewSeriesBackoffDuration(ctx thrift.Context, req *NodeSetWriteNewSeriesBackoffDurationRequest) (*NodeWriteNewSeriesBackoffDurationResult_, error)
@@ -623,6 +626,24 @@ func (c *tchanNodeClient) DebugProfileStop(ctx thrift.Context, req *DebugProfile
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanNodeClient) DeleteSeries(ctx thrift.Context, req *DeleteSeriesRequest) (*DeleteSeriesResult_, error) {
+	var resp NodeDeleteSeriesResult
+	args := NodeDeleteSeriesArgs{
+		Req: req,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "deleteSeries", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.Err != nil:
+			err = resp.Err
+		default:
+			err = fmt.Errorf("received no result or unknown exception for deleteSeries")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanNodeClient) Fetch(ctx thrift.Context, req *FetchRequest) (*FetchResult_, error) {
 	var resp NodeFetchResult
 	args := NodeFetchArgs{
@@ -677,6 +698,24 @@ func (c *tchanNodeClient) FetchBatchRawV2(ctx thrift.Context, req *FetchBatchRaw
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanNodeClient) FetchBlockHashTree(ctx thrift.Context, req *FetchBlockHashTreeRequest) (*FetchBlockHashTreeResult_, error) {
+	var resp NodeFetchBlockHashTreeResult
+	args := NodeFetchBlockHashTreeArgs{
+		Req: req,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "fetchBlockHashTree", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.Err != nil:
+			err = resp.Err
+		default:
+			err = fmt.Errorf("received no result or unknown exception for fetchBlockHashTree")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanNodeClient) FetchBlocksMetadataRawV2(ctx thrift.Context, req *FetchBlocksMetadataRawV2Request) (*FetchBlocksMetadataRawV2Result_, error) {
 	var resp NodeFetchBlocksMetadataRawV2Result
 	args := NodeFetchBlocksMetadataRawV2Args{
@@ -845,6 +884,24 @@ func (c *tchanNodeClient) Repair(ctx thrift.Context) error {
 	return err
 }
 
+func (c *tchanNodeClient) SeriesCardinality(ctx thrift.Context, req *SeriesCardinalityRequest) (*SeriesCardinalityResult_, error) {
+	var resp NodeSeriesCardinalityResult
+	args := NodeSeriesCardinalityArgs{
+		Req: req,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "seriesCardinality", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.Err != nil:
+			err = resp.Err
+		default:
+			err = fmt.Errorf("received no result or unknown exception for seriesCardinality")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanNodeClient) SetPersistRateLimit(ctx thrift.Context, req *NodeSetPersistRateLimitRequest) (*NodePersistRateLimitResult_, error) {
 	var resp NodeSetPersistRateLimitResult
 	args := NodeSetPersistRateLimitArgs{
@@ -1069,9 +1126,11 @@ func (s *tchanNodeServer) Methods() []string {
 		"debugIndexMemorySegments",
 		"debugProfileStart",
 		"debugProfileStop",
+		"deleteSeries",
 		"fetch",
 		"fetchBatchRaw",
 		"fetchBatchRawV2",
+		"fetchBlockHashTree",
 		"fetchBlocksMetadataRawV2",
 		"fetchBlocksRaw",
 		"fetchTagged",
@@ -1082,6 +1141,7 @@ func (s *tchanNodeServer) Methods() []string {
 		"health",
 		"query",
 		"repair",
+		"seriesCardinality",
 		"setPersistRateLimit",
 		"setWriteNewSeriesAsync",
 		"setWriteNewSeriesBackoffDuration",
@@ -1114,12 +1174,16 @@ func (s *tchanNodeServer) Handle(ctx thrift.Context, methodName string, protocol
 		return s.handleDebugProfileStart(ctx, protocol)
 	case "debugProfileStop":
 		return s.handleDebugProfileStop(ctx, protocol)
+	case "deleteSeries":
+		return s.handleDeleteSeries(ctx, protocol)
 	case "fetch":
 		return s.handleFetch(ctx, protocol)
 	case "fetchBatchRaw":
 		return s.handleFetchBatchRaw(ctx, protocol)
 	case "fetchBatchRawV2":
 		return s.handleFetchBatchRawV2(ctx, protocol)
+	case "fetchBlockHashTree":
+		return s.handleFetchBlockHashTree(ctx, protocol)
 	case "fetchBlocksMetadataRawV2":
 		return s.handleFetchBlocksMetadataRawV2(ctx, protocol)
 	case "fetchBlocksRaw":
@@ -1140,6 +1204,8 @@ func (s *tchanNodeServer) Handle(ctx thrift.Context, methodName string, protocol
 		return s.handleQuery(ctx, protocol)
 	case "repair":
 		return s.handleRepair(ctx, protocol)
+	case "seriesCardinality":
+		return s.handleSeriesCardinality(ctx, protocol)
 	case "setPersistRateLimit":
 		return s.handleSetPersistRateLimit(ctx, protocol)
 	case "setWriteNewSeriesAsync":
@@ -1392,6 +1458,34 @@ func (s *tchanNodeServer) handleDebugProfileStop(ctx thrift.Context, protocol at
 	return err == nil, &res, nil
 }
 
+func (s *tchanNodeServer) handleDeleteSeries(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req NodeDeleteSeriesArgs
+	var res NodeDeleteSeriesResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.DeleteSeries(ctx, req.Req)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *Error:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for err returned non-nil error type *Error but nil value")
+			}
+			res.Err = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
 func (s *tchanNodeServer) handleFetch(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	var req NodeFetchArgs
 	var res NodeFetchResult
@@ -1476,6 +1570,34 @@ func (s *tchanNodeServer) handleFetchBatchRawV2(ctx thrift.Context, protocol ath
 	return err == nil, &res, nil
 }
 
+func (s *tchanNodeServer) handleFetchBlockHashTree(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req NodeFetchBlockHashTreeArgs
+	var res NodeFetchBlockHashTreeResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.FetchBlockHashTree(ctx, req.Req)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *Error:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for err returned non-nil error type *Error but nil value")
+			}
+			res.Err = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
 func (s *tchanNodeServer) handleFetchBlocksMetadataRawV2(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	var req NodeFetchBlocksMetadataRawV2Args
 	var res NodeFetchBlocksMetadataRawV2Result
@@ -1755,6 +1877,34 @@ func (s *tchanNodeServer) handleRepair(ctx thrift.Context, protocol athrift.TPro
 	return err == nil, &res, nil
 }
 
+func (s *tchanNodeServer) handleSeriesCardinality(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req NodeSeriesCardinalityArgs
+	var res NodeSeriesCardinalityResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.SeriesCardinality(ctx, req.Req)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *Error:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for err returned non-nil error type *Error but nil value")
+			}
+			res.Err = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
 func (s *tchanNodeServer) handleSetPersistRateLimit(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	var req NodeSetPersistRateLimitArgs
 	var res NodeSetPersistRateLimitResult
@@ -38,6 +38,10 @@ const (
 	// per block.
 	EncodersPerBlockLimitKey = "m3db.node.encoders-per-block-limit"
 
+	// TenantLimitsQuotasKey is the KV config key for the runtime
+	// configuration specifying the quotas of tenants as a YAML document.
+	TenantLimitsQuotasKey = "m3db.node.tenant-limits-quotas"
+
 	// ClientBootstrapConsistencyLevel is the KV config key for the runtime
 	// configuration specifying the client bootstrap consistency level
 	ClientBootstrapConsistencyLevel = "m3db.client.bootstrap-consistency-level"
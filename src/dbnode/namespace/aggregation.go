@@ -21,10 +21,41 @@
 package namespace
 
 import (
+	"errors"
 	"fmt"
 	"time"
 )
 
+// DownsampleType is how data points within each resolution step are combined
+// when aggregating tiles.
+type DownsampleType int
+
+const (
+	// LastDownsampleType keeps the last data point of each step.
+	LastDownsampleType DownsampleType = iota
+	// SumDownsampleType sums the data points of each step.
+	SumDownsampleType
+	// MinDownsampleType keeps the minimum data point of each step.
+	MinDownsampleType
+	// MaxDownsampleType keeps the maximum data point of each step.
+	MaxDownsampleType
+	// MeanDownsampleType averages the data points of each step.
+	MeanDownsampleType
+)
+
+var (
+	validDownsampleTypes = []DownsampleType{
+		LastDownsampleType,
+		SumDownsampleType,
+		MinDownsampleType,
+		MaxDownsampleType,
+		MeanDownsampleType,
+	}
+
+	errMultipleTileAggregations     = errors.New("at most one aggregation may aggregate tiles")
+	errTileAggregationNotAggregated = errors.New("tile aggregation requires an aggregated aggregation")
+)
+
 type aggregationOptions struct {
 	aggregations []Aggregation
 }
@@ -88,3 +119,110 @@ func NewAggregatedAttributes(resolution time.Duration, downsampleOptions Downsam
 func NewDownsampleOptions(all bool) DownsampleOptions {
 	return DownsampleOptions{All: all}
 }
+
+// NewTileAggregationOptions creates new TileAggregationOptions.
+func NewTileAggregationOptions(
+	sourceNamespace string,
+	downsampleType DownsampleType,
+) TileAggregationOptions {
+	return TileAggregationOptions{
+		SourceNamespace: sourceNamespace,
+		DownsampleType:  downsampleType,
+	}
+}
+
+// Enabled returns whether tiles are aggregated from a source namespace.
+func (o TileAggregationOptions) Enabled() bool {
+	return o.SourceNamespace != ""
+}
+
+// TileAggregation returns the attributes of the aggregation that aggregates
+// tiles from a source namespace, and false if there is none.
+func TileAggregation(opts AggregationOptions) (AggregatedAttributes, bool) {
+	if opts == nil {
+		return AggregatedAttributes{}, false
+	}
+
+	for _, agg := range opts.Aggregations() {
+		if agg.Aggregated && agg.Attributes.TileAggregation.Enabled() {
+			return agg.Attributes, true
+		}
+	}
+
+	return AggregatedAttributes{}, false
+}
+
+func validateTileAggregation(opts AggregationOptions) error {
+	if opts == nil {
+		return nil
+	}
+
+	var found bool
+	for _, agg := range opts.Aggregations() {
+		if !agg.Attributes.TileAggregation.Enabled() {
+			continue
+		}
+		if !agg.Aggregated {
+			return errTileAggregationNotAggregated
+		}
+		if found {
+			return errMultipleTileAggregations
+		}
+		if err := agg.Attributes.TileAggregation.DownsampleType.Validate(); err != nil {
+			return err
+		}
+		found = true
+	}
+
+	return nil
+}
+
+// ValidDownsampleTypes returns the valid downsample types.
+func ValidDownsampleTypes() []DownsampleType {
+	return validDownsampleTypes
+}
+
+func (t DownsampleType) String() string {
+	switch t {
+	case LastDownsampleType:
+		return "last"
+	case SumDownsampleType:
+		return "sum"
+	case MinDownsampleType:
+		return "min"
+	case MaxDownsampleType:
+		return "max"
+	case MeanDownsampleType:
+		return "mean"
+	default:
+		return "unknown"
+	}
+}
+
+// Validate validates the downsample type.
+func (t DownsampleType) Validate() error {
+	for _, valid := range validDownsampleTypes {
+		if t == valid {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid downsample type: %d", int(t))
+}
+
+// ParseDownsampleType parses a downsample type, an empty string is parsed as
+// last.
+func ParseDownsampleType(str string) (DownsampleType, error) {
+	if str == "" {
+		return LastDownsampleType, nil
+	}
+
+	for _, valid := range validDownsampleTypes {
+		if str == valid.String() {
+			return valid, nil
+		}
+	}
+
+	return LastDownsampleType, fmt.Errorf(
+		"invalid downsample type: %s, valid types are: %v", str, validDownsampleTypes)
+}
@@ -57,3 +57,52 @@ func TestAggregationAttributesValidation(t *testing.T) {
 	_, err = NewAggregatedAttributes(-5*time.Minute, NewDownsampleOptions(true))
 	require.Error(t, err)
 }
+
+func TestTileAggregationValidation(t *testing.T) {
+	attrs, err := NewAggregatedAttributes(5*time.Minute, NewDownsampleOptions(false))
+	require.NoError(t, err)
+	attrs.TileAggregation = NewTileAggregationOptions("source", SumDownsampleType)
+
+	opts := NewOptions().SetAggregationOptions(NewAggregationOptions().
+		SetAggregations([]Aggregation{NewAggregatedAggregation(attrs)}))
+	require.NoError(t, opts.Validate())
+
+	result, ok := TileAggregation(opts.AggregationOptions())
+	require.True(t, ok)
+	require.Equal(t, attrs, result)
+
+	opts = opts.SetAggregationOptions(NewAggregationOptions().
+		SetAggregations([]Aggregation{
+			NewAggregatedAggregation(attrs),
+			NewAggregatedAggregation(attrs),
+		}))
+	require.Equal(t, errMultipleTileAggregations, opts.Validate())
+
+	opts = opts.SetAggregationOptions(NewAggregationOptions().
+		SetAggregations([]Aggregation{{Attributes: attrs}}))
+	require.Equal(t, errTileAggregationNotAggregated, opts.Validate())
+
+	attrs.TileAggregation.DownsampleType = DownsampleType(100)
+	opts = opts.SetAggregationOptions(NewAggregationOptions().
+		SetAggregations([]Aggregation{NewAggregatedAggregation(attrs)}))
+	require.Error(t, opts.Validate())
+
+	_, ok = TileAggregation(NewAggregationOptions().
+		SetAggregations([]Aggregation{NewUnaggregatedAggregation()}))
+	require.False(t, ok)
+}
+
+func TestParseDownsampleType(t *testing.T) {
+	for _, valid := range ValidDownsampleTypes() {
+		parsed, err := ParseDownsampleType(valid.String())
+		require.NoError(t, err)
+		require.Equal(t, valid, parsed)
+	}
+
+	parsed, err := ParseDownsampleType("")
+	require.NoError(t, err)
+	require.Equal(t, LastDownsampleType, parsed)
+
+	_, err = ParseDownsampleType("median")
+	require.Error(t, err)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"errors"
+)
+
+const (
+	// DefaultMetricNameTag is the tag holding the metric name of a series
+	// that cardinality limits per metric name apply to by default.
+	DefaultMetricNameTag = "__name__"
+)
+
+var (
+	errCardinalityLimitNegative = errors.New("cardinality limits must not be negative")
+)
+
+// CardinalityLimits cap the number of active series in a namespace, new
+// series are rejected once a limit is reached while writes to existing
+// series continue to succeed. A zero limit is unlimited.
+type CardinalityLimits struct {
+	// MaxSeries is the max number of active series in the namespace.
+	MaxSeries int64 `yaml:"maxSeries"`
+
+	// MaxSeriesPerMetricName is the max number of active series in the
+	// namespace sharing the same metric name.
+	MaxSeriesPerMetricName int64 `yaml:"maxSeriesPerMetricName"`
+
+	// MetricNameTag is the tag holding the metric name of a series,
+	// defaults to DefaultMetricNameTag.
+	MetricNameTag string `yaml:"metricNameTag"`
+}
+
+// Enabled returns whether any cardinality limit is set.
+func (l CardinalityLimits) Enabled() bool {
+	return l.MaxSeries > 0 || l.MaxSeriesPerMetricName > 0
+}
+
+// MetricNameTagOrDefault returns the metric name tag or the default if
+// not set.
+func (l CardinalityLimits) MetricNameTagOrDefault() string {
+	if l.MetricNameTag == "" {
+		return DefaultMetricNameTag
+	}
+	return l.MetricNameTag
+}
+
+// Validate validates the cardinality limits.
+func (l CardinalityLimits) Validate() error {
+	if l.MaxSeries < 0 || l.MaxSeriesPerMetricName < 0 {
+		return errCardinalityLimitNegative
+	}
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/x/ident"
 )
@@ -58,6 +59,10 @@ type MetadataConfiguration struct {
 	CacheBlocksOnRetrieve *bool                   `yaml:"cacheBlocksOnRetrieve"`
 	Retention             retention.Configuration `yaml:"retention" validate:"nonzero"`
 	Index                 IndexConfiguration      `yaml:"index"`
+	Compression           compression.Type        `yaml:"compression"`
+	RetentionRules        []RetentionRule         `yaml:"retentionRules"`
+	CardinalityLimits     CardinalityLimits       `yaml:"cardinalityLimits"`
+	TieringPolicy         TieringPolicy           `yaml:"tieringPolicy"`
 }
 
 // Metadata returns a Metadata corresponding to the receiver struct
@@ -66,7 +71,11 @@ func (mc *MetadataConfiguration) Metadata() (Metadata, error) {
 	ropts := mc.Retention.Options()
 	opts := NewOptions().
 		SetRetentionOptions(ropts).
-		SetIndexOptions(iopts)
+		SetIndexOptions(iopts).
+		SetCompressionType(mc.Compression).
+		SetRetentionRules(mc.RetentionRules).
+		SetCardinalityLimits(mc.CardinalityLimits).
+		SetTieringPolicy(mc.TieringPolicy)
 	if v := mc.BootstrapEnabled; v != nil {
 		opts = opts.SetBootstrapEnabled(*v)
 	}
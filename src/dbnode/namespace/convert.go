@@ -28,6 +28,7 @@ import (
 	"time"
 
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/x/ident"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -197,6 +198,11 @@ func ToMetadata(
 		return nil, err
 	}
 
+	compressionType, err := compression.ParseType(opts.Compression)
+	if err != nil {
+		return nil, err
+	}
+
 	mOpts := NewOptions().
 		SetBootstrapEnabled(opts.BootstrapEnabled).
 		SetFlushEnabled(opts.FlushEnabled).
@@ -211,7 +217,11 @@ func ToMetadata(
 		SetRuntimeOptions(runtimeOpts).
 		SetExtendedOptions(extendedOpts).
 		SetAggregationOptions(aggOpts).
-		SetStagingState(stagingState)
+		SetStagingState(stagingState).
+		SetCompressionType(compressionType).
+		SetRetentionRules(ToRetentionRules(opts.RetentionRules)).
+		SetCardinalityLimits(ToCardinalityLimits(opts.CardinalityLimits)).
+		SetTieringPolicy(ToTieringPolicy(opts.TieringPolicy))
 
 	if opts.CacheBlocksOnRetrieve != nil {
 		mOpts = mOpts.SetCacheBlocksOnRetrieve(opts.CacheBlocksOnRetrieve.Value)
@@ -224,6 +234,46 @@ func ToMetadata(
 	return NewMetadata(ident.StringID(id), mOpts)
 }
 
+// ToRetentionRules converts nsproto.RetentionRule to RetentionRule.
+func ToRetentionRules(rules []*nsproto.RetentionRule) []RetentionRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]RetentionRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, RetentionRule{
+			Filter:          rule.Filter,
+			RetentionPeriod: time.Duration(rule.RetentionPeriodNanos),
+		})
+	}
+	return result
+}
+
+// ToCardinalityLimits converts nsproto.CardinalityLimits to CardinalityLimits.
+func ToCardinalityLimits(limits *nsproto.CardinalityLimits) CardinalityLimits {
+	if limits == nil {
+		return CardinalityLimits{}
+	}
+
+	return CardinalityLimits{
+		MaxSeries:              limits.MaxSeries,
+		MaxSeriesPerMetricName: limits.MaxSeriesPerMetricName,
+		MetricNameTag:          limits.MetricNameTag,
+	}
+}
+
+// ToTieringPolicy converts nsproto.TieringPolicy to TieringPolicy.
+func ToTieringPolicy(policy *nsproto.TieringPolicy) TieringPolicy {
+	if policy == nil {
+		return TieringPolicy{}
+	}
+
+	return TieringPolicy{
+		After: time.Duration(policy.AfterNanos),
+	}
+}
+
 // ToStagingState converts nsproto.StagingState to StagingState.
 func ToStagingState(state *nsproto.StagingState) (StagingState, error) {
 	if state == nil {
@@ -257,6 +307,14 @@ func ToAggregationOptions(opts *nsproto.AggregationOptions) (AggregationOptions,
 			if err != nil {
 				return nil, err
 			}
+
+			if tileOpts := agg.Attributes.TileAggregationOptions; tileOpts != nil {
+				downsampleType, err := ParseDownsampleType(tileOpts.DownsampleType)
+				if err != nil {
+					return nil, err
+				}
+				attrs.TileAggregation = NewTileAggregationOptions(tileOpts.SourceNamespace, downsampleType)
+			}
 			aggregations = append(aggregations, NewAggregatedAggregation(attrs))
 		} else {
 			aggregations = append(aggregations, NewUnaggregatedAggregation())
@@ -337,11 +395,61 @@ func OptionsToProto(opts Options) (*nsproto.NamespaceOptions, error) {
 		ExtendedOptions:       extendedOpts,
 		AggregationOptions:    toProtoAggregationOptions(opts.AggregationOptions()),
 		StagingState:          stagingState,
+		Compression:           protoCompression(opts.CompressionType()),
+		RetentionRules:        toProtoRetentionRules(opts.RetentionRules()),
+		CardinalityLimits:     toProtoCardinalityLimits(opts.CardinalityLimits()),
+		TieringPolicy:         toProtoTieringPolicy(opts.TieringPolicy()),
 	}
 
 	return nsOpts, nil
 }
 
+func protoCompression(t compression.Type) string {
+	// Omit the default so that namespaces without compression are encoded
+	// identically to those registered before compression was added.
+	if t == compression.NoneType {
+		return ""
+	}
+	return t.String()
+}
+
+func toProtoRetentionRules(rules []RetentionRule) []*nsproto.RetentionRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]*nsproto.RetentionRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, &nsproto.RetentionRule{
+			Filter:               rule.Filter,
+			RetentionPeriodNanos: rule.RetentionPeriod.Nanoseconds(),
+		})
+	}
+	return result
+}
+
+func toProtoCardinalityLimits(limits CardinalityLimits) *nsproto.CardinalityLimits {
+	if limits == (CardinalityLimits{}) {
+		return nil
+	}
+
+	return &nsproto.CardinalityLimits{
+		MaxSeries:              limits.MaxSeries,
+		MaxSeriesPerMetricName: limits.MaxSeriesPerMetricName,
+		MetricNameTag:          limits.MetricNameTag,
+	}
+}
+
+func toProtoTieringPolicy(policy TieringPolicy) *nsproto.TieringPolicy {
+	if !policy.Enabled() {
+		return nil
+	}
+
+	return &nsproto.TieringPolicy{
+		AfterNanos: policy.After.Nanoseconds(),
+	}
+}
+
 func toProtoStagingState(state StagingState) (*nsproto.StagingState, error) {
 	var protoStatus nsproto.StagingStatus
 	switch state.Status() {
@@ -370,6 +478,12 @@ func toProtoAggregationOptions(aggOpts AggregationOptions) *nsproto.AggregationO
 				ResolutionNanos:   agg.Attributes.Resolution.Nanoseconds(),
 				DownsampleOptions: &nsproto.DownsampleOptions{All: agg.Attributes.DownsampleOptions.All},
 			}
+			if tileOpts := agg.Attributes.TileAggregation; tileOpts.Enabled() {
+				protoAgg.Attributes.TileAggregationOptions = &nsproto.TileAggregationOptions{
+					SourceNamespace: tileOpts.SourceNamespace,
+					DownsampleType:  tileOpts.DownsampleType.String(),
+				}
+			}
 		}
 		protoAggs = append(protoAggs, &protoAgg)
 	}
@@ -26,6 +26,7 @@ import (
 
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	m3test "github.com/m3db/m3/src/x/generated/proto/test"
 	"github.com/m3db/m3/src/x/ident"
@@ -325,6 +326,116 @@ func TestFromProtoSnapshotEnabled(t *testing.T) {
 	require.Equal(t, !namespace.NewOptions().SnapshotEnabled(), md.Options().SnapshotEnabled())
 }
 
+func TestCompressionProtoRoundTrip(t *testing.T) {
+	md, err := namespace.NewMetadata(
+		ident.StringID("ns1"),
+		namespace.NewOptions().SetCompressionType(compression.SnappyType),
+	)
+	require.NoError(t, err)
+	nsMap, err := namespace.NewMap([]namespace.Metadata{md})
+	require.NoError(t, err)
+
+	reg, err := namespace.ToProto(nsMap)
+	require.NoError(t, err)
+	require.Equal(t, "snappy", reg.Namespaces["ns1"].Compression)
+
+	nsMap, err = namespace.FromProto(*reg)
+	require.NoError(t, err)
+	md, err = nsMap.Get(ident.StringID("ns1"))
+	require.NoError(t, err)
+	require.Equal(t, compression.SnappyType, md.Options().CompressionType())
+}
+
+func TestRetentionRulesProtoRoundTrip(t *testing.T) {
+	rules := []namespace.RetentionRule{
+		{Filter: "env:dev", RetentionPeriod: 2 * time.Hour},
+		{Filter: "slo:true service:api*", RetentionPeriod: 24 * time.Hour},
+	}
+	md, err := namespace.NewMetadata(
+		ident.StringID("ns1"),
+		namespace.NewOptions().SetRetentionRules(rules),
+	)
+	require.NoError(t, err)
+	nsMap, err := namespace.NewMap([]namespace.Metadata{md})
+	require.NoError(t, err)
+
+	reg, err := namespace.ToProto(nsMap)
+	require.NoError(t, err)
+	require.Len(t, reg.Namespaces["ns1"].RetentionRules, 2)
+
+	nsMap, err = namespace.FromProto(*reg)
+	require.NoError(t, err)
+	md, err = nsMap.Get(ident.StringID("ns1"))
+	require.NoError(t, err)
+	require.Equal(t, rules, md.Options().RetentionRules())
+}
+
+func TestCardinalityLimitsProtoRoundTrip(t *testing.T) {
+	limits := namespace.CardinalityLimits{
+		MaxSeries:              1000000,
+		MaxSeriesPerMetricName: 10000,
+		MetricNameTag:          "name",
+	}
+	md, err := namespace.NewMetadata(
+		ident.StringID("ns1"),
+		namespace.NewOptions().SetCardinalityLimits(limits),
+	)
+	require.NoError(t, err)
+	nsMap, err := namespace.NewMap([]namespace.Metadata{md})
+	require.NoError(t, err)
+
+	reg, err := namespace.ToProto(nsMap)
+	require.NoError(t, err)
+
+	bytes, err := reg.Marshal()
+	require.NoError(t, err)
+	var decoded nsproto.Registry
+	require.NoError(t, decoded.Unmarshal(bytes))
+
+	nsMap, err = namespace.FromProto(decoded)
+	require.NoError(t, err)
+	md, err = nsMap.Get(ident.StringID("ns1"))
+	require.NoError(t, err)
+	require.Equal(t, limits, md.Options().CardinalityLimits())
+}
+
+func TestTieringPolicyProtoRoundTrip(t *testing.T) {
+	policy := namespace.TieringPolicy{After: 30 * 24 * time.Hour}
+	md, err := namespace.NewMetadata(
+		ident.StringID("ns1"),
+		namespace.NewOptions().SetTieringPolicy(policy),
+	)
+	require.NoError(t, err)
+	nsMap, err := namespace.NewMap([]namespace.Metadata{md})
+	require.NoError(t, err)
+
+	reg, err := namespace.ToProto(nsMap)
+	require.NoError(t, err)
+
+	bytes, err := reg.Marshal()
+	require.NoError(t, err)
+	var decoded nsproto.Registry
+	require.NoError(t, decoded.Unmarshal(bytes))
+
+	nsMap, err = namespace.FromProto(decoded)
+	require.NoError(t, err)
+	md, err = nsMap.Get(ident.StringID("ns1"))
+	require.NoError(t, err)
+	require.Equal(t, policy, md.Options().TieringPolicy())
+}
+
+func TestFromProtoInvalidCompression(t *testing.T) {
+	_, err := namespace.FromProto(nsproto.Registry{
+		Namespaces: map[string]*nsproto.NamespaceOptions{
+			"testns1": &nsproto.NamespaceOptions{
+				RetentionOptions: &validRetentionOpts,
+				Compression:      "lz4",
+			},
+		},
+	})
+	require.Error(t, err)
+}
+
 func TestInvalidExtendedOptions(t *testing.T) {
 	invalidExtendedOptsBadValue, err := xtest.NewExtendedOptionsProto("foo")
 	require.NoError(t, err)
@@ -385,6 +496,45 @@ func TestAggregationOptsToProto(t *testing.T) {
 	require.Equal(t, validAggregationOpts, *nsOpts.AggregationOptions)
 }
 
+func TestTileAggregationOptsRoundTrip(t *testing.T) {
+	protoOpts := nsproto.AggregationOptions{
+		Aggregations: []*nsproto.Aggregation{
+			{
+				Aggregated: true,
+				Attributes: &nsproto.AggregatedAttributes{
+					ResolutionNanos:   time.Minute.Nanoseconds(),
+					DownsampleOptions: &nsproto.DownsampleOptions{All: false},
+					TileAggregationOptions: &nsproto.TileAggregationOptions{
+						SourceNamespace: "unaggregated",
+						DownsampleType:  "max",
+					},
+				},
+			},
+		},
+	}
+
+	aggOpts, err := namespace.ToAggregationOptions(&protoOpts)
+	require.NoError(t, err)
+
+	attrs, ok := namespace.TileAggregation(aggOpts)
+	require.True(t, ok)
+	require.Equal(t, time.Minute, attrs.Resolution)
+	require.Equal(t, namespace.NewTileAggregationOptions("unaggregated",
+		namespace.MaxDownsampleType), attrs.TileAggregation)
+
+	md, err := namespace.NewMetadata(ident.StringID("ns1"),
+		namespace.NewOptions().SetAggregationOptions(aggOpts))
+	require.NoError(t, err)
+
+	nsOpts, err := namespace.OptionsToProto(md.Options())
+	require.NoError(t, err)
+	require.Equal(t, protoOpts, *nsOpts.AggregationOptions)
+
+	protoOpts.Aggregations[0].Attributes.TileAggregationOptions.DownsampleType = "median"
+	_, err = namespace.ToAggregationOptions(&protoOpts)
+	require.Error(t, err)
+}
+
 func assertEqualMetadata(t *testing.T, name string, expected nsproto.NamespaceOptions, observed namespace.Metadata) {
 	require.Equal(t, name, observed.ID().String())
 	opts := observed.Options()
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
@@ -509,6 +510,118 @@ func (mr *MockOptionsMockRecorder) StagingState() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StagingState", reflect.TypeOf((*MockOptions)(nil).StagingState))
 }
 
+// SetCompressionType mocks base method
+func (m *MockOptions) SetCompressionType(value compression.Type) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCompressionType", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetCompressionType indicates an expected call of SetCompressionType
+func (mr *MockOptionsMockRecorder) SetCompressionType(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCompressionType", reflect.TypeOf((*MockOptions)(nil).SetCompressionType), value)
+}
+
+// CompressionType mocks base method
+func (m *MockOptions) CompressionType() compression.Type {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompressionType")
+	ret0, _ := ret[0].(compression.Type)
+	return ret0
+}
+
+// CompressionType indicates an expected call of CompressionType
+func (mr *MockOptionsMockRecorder) CompressionType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompressionType", reflect.TypeOf((*MockOptions)(nil).CompressionType))
+}
+
+// SetRetentionRules mocks base method
+func (m *MockOptions) SetRetentionRules(value []RetentionRule) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRetentionRules", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetRetentionRules indicates an expected call of SetRetentionRules
+func (mr *MockOptionsMockRecorder) SetRetentionRules(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRetentionRules", reflect.TypeOf((*MockOptions)(nil).SetRetentionRules), value)
+}
+
+// RetentionRules mocks base method
+func (m *MockOptions) RetentionRules() []RetentionRule {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetentionRules")
+	ret0, _ := ret[0].([]RetentionRule)
+	return ret0
+}
+
+// RetentionRules indicates an expected call of RetentionRules
+func (mr *MockOptionsMockRecorder) RetentionRules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetentionRules", reflect.TypeOf((*MockOptions)(nil).RetentionRules))
+}
+
+// SetCardinalityLimits mocks base method
+func (m *MockOptions) SetCardinalityLimits(value CardinalityLimits) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCardinalityLimits", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetCardinalityLimits indicates an expected call of SetCardinalityLimits
+func (mr *MockOptionsMockRecorder) SetCardinalityLimits(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCardinalityLimits", reflect.TypeOf((*MockOptions)(nil).SetCardinalityLimits), value)
+}
+
+// CardinalityLimits mocks base method
+func (m *MockOptions) CardinalityLimits() CardinalityLimits {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CardinalityLimits")
+	ret0, _ := ret[0].(CardinalityLimits)
+	return ret0
+}
+
+// CardinalityLimits indicates an expected call of CardinalityLimits
+func (mr *MockOptionsMockRecorder) CardinalityLimits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CardinalityLimits", reflect.TypeOf((*MockOptions)(nil).CardinalityLimits))
+}
+
+// SetTieringPolicy mocks base method
+func (m *MockOptions) SetTieringPolicy(value TieringPolicy) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTieringPolicy", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetTieringPolicy indicates an expected call of SetTieringPolicy
+func (mr *MockOptionsMockRecorder) SetTieringPolicy(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTieringPolicy", reflect.TypeOf((*MockOptions)(nil).SetTieringPolicy), value)
+}
+
+// TieringPolicy mocks base method
+func (m *MockOptions) TieringPolicy() TieringPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TieringPolicy")
+	ret0, _ := ret[0].(TieringPolicy)
+	return ret0
+}
+
+// TieringPolicy indicates an expected call of TieringPolicy
+func (mr *MockOptionsMockRecorder) TieringPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TieringPolicy", reflect.TypeOf((*MockOptions)(nil).TieringPolicy))
+}
+
 // MockIndexOptions is a mock of IndexOptions interface
 type MockIndexOptions struct {
 	ctrl     *gomock.Controller
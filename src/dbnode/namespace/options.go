@@ -23,6 +23,7 @@ package namespace
 import (
 	"errors"
 
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 )
 
@@ -77,6 +78,10 @@ type options struct {
 	extendedOpts          ExtendedOptions
 	aggregationOpts       AggregationOptions
 	stagingState          StagingState
+	compressionType       compression.Type
+	retentionRules        []RetentionRule
+	cardinalityLimits     CardinalityLimits
+	tieringPolicy         TieringPolicy
 }
 
 // NewSchemaHistory returns an empty schema history.
@@ -118,6 +123,27 @@ func (o *options) Validate() error {
 		return err
 	}
 
+	if err := o.compressionType.Validate(); err != nil {
+		return err
+	}
+
+	if err := validateRetentionRules(o.retentionRules,
+		o.retentionOpts.RetentionPeriod()); err != nil {
+		return err
+	}
+
+	if err := validateTileAggregation(o.aggregationOpts); err != nil {
+		return err
+	}
+
+	if err := o.cardinalityLimits.Validate(); err != nil {
+		return err
+	}
+
+	if err := o.tieringPolicy.Validate(o.retentionOpts); err != nil {
+		return err
+	}
+
 	if !o.indexOpts.Enabled() {
 		return nil
 	}
@@ -160,7 +186,11 @@ func (o *options) Equal(value Options) bool {
 		o.schemaHis.Equal(value.SchemaHistory()) &&
 		o.runtimeOpts.Equal(value.RuntimeOptions()) &&
 		o.aggregationOpts.Equal(value.AggregationOptions()) &&
-		o.stagingState == value.StagingState()
+		o.stagingState == value.StagingState() &&
+		o.compressionType == value.CompressionType() &&
+		retentionRulesEqual(o.retentionRules, value.RetentionRules()) &&
+		o.cardinalityLimits == value.CardinalityLimits() &&
+		o.tieringPolicy == value.TieringPolicy()
 }
 
 func (o *options) SetBootstrapEnabled(value bool) Options {
@@ -312,3 +342,43 @@ func (o *options) SetStagingState(value StagingState) Options {
 func (o *options) StagingState() StagingState {
 	return o.stagingState
 }
+
+func (o *options) SetCompressionType(value compression.Type) Options {
+	opts := *o
+	opts.compressionType = value
+	return &opts
+}
+
+func (o *options) CompressionType() compression.Type {
+	return o.compressionType
+}
+
+func (o *options) SetRetentionRules(value []RetentionRule) Options {
+	opts := *o
+	opts.retentionRules = value
+	return &opts
+}
+
+func (o *options) RetentionRules() []RetentionRule {
+	return o.retentionRules
+}
+
+func (o *options) SetCardinalityLimits(value CardinalityLimits) Options {
+	opts := *o
+	opts.cardinalityLimits = value
+	return &opts
+}
+
+func (o *options) CardinalityLimits() CardinalityLimits {
+	return o.cardinalityLimits
+}
+
+func (o *options) SetTieringPolicy(value TieringPolicy) Options {
+	opts := *o
+	opts.tieringPolicy = value
+	return &opts
+}
+
+func (o *options) TieringPolicy() TieringPolicy {
+	return o.tieringPolicy
+}
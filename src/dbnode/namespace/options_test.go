@@ -133,6 +133,42 @@ func TestOptionsValidateWithExtendedOptions(t *testing.T) {
 	require.Error(t, opts.Validate())
 }
 
+func TestOptionsValidateCardinalityLimits(t *testing.T) {
+	opts := NewOptions().SetCardinalityLimits(CardinalityLimits{
+		MaxSeries:              100,
+		MaxSeriesPerMetricName: 10,
+	})
+	require.NoError(t, opts.Validate())
+
+	opts = opts.SetCardinalityLimits(CardinalityLimits{MaxSeries: -1})
+	require.Error(t, opts.Validate())
+}
+
+func TestOptionsValidateTieringPolicy(t *testing.T) {
+	opts := NewOptions().SetTieringPolicy(TieringPolicy{After: 24 * time.Hour})
+	require.NoError(t, opts.Validate())
+
+	opts = opts.SetTieringPolicy(TieringPolicy{After: -time.Hour})
+	require.Error(t, opts.Validate())
+
+	bufferPast := opts.RetentionOptions().BufferPast()
+	opts = opts.SetTieringPolicy(TieringPolicy{After: bufferPast / 2})
+	require.Error(t, opts.Validate())
+}
+
+func TestTieringPolicyTierBefore(t *testing.T) {
+	var (
+		blockSize = 2 * time.Hour
+		policy    = TieringPolicy{After: 24 * time.Hour}
+		start     = time.Unix(0, 0).Add(100 * blockSize)
+	)
+	// The block ending exactly policy.After ago is eligible.
+	now := start.Add(blockSize).Add(policy.After)
+	require.Equal(t, start.Add(blockSize), policy.TierBefore(now, blockSize))
+	require.Equal(t, start.Add(blockSize), policy.TierBefore(now.Add(time.Minute), blockSize))
+	require.Equal(t, start, policy.TierBefore(now.Add(-time.Minute), blockSize))
+}
+
 func TestOptionsValidateBlockSizeMustBeMultiple(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,205 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/metrics/filters"
+	"github.com/m3db/m3/src/x/ident"
+)
+
+var (
+	errRetentionRuleFilterEmpty     = errors.New("retention rule filter must not be empty")
+	errRetentionRulePeriodPositive  = errors.New("retention rule period must be positive")
+	errRetentionRulePeriodTooLarge  = errors.New("retention rule period must not exceed the namespace retention period")
+	errRetentionRuleFilterDuplicate = errors.New("retention rule filter is duplicated")
+)
+
+// RetentionRule overrides the namespace retention period for the series
+// whose tags match a filter.
+type RetentionRule struct {
+	// Filter is a space separated list of tag name and glob pattern pairs,
+	// e.g. "env:dev service:foo*", all of which must match a series' tags.
+	Filter string `yaml:"filter" validate:"nonzero"`
+
+	// RetentionPeriod is the retention period of the matching series.
+	RetentionPeriod time.Duration `yaml:"retentionPeriod" validate:"nonzero"`
+}
+
+// RetentionRulesMatcher resolves the retention period of series from a
+// list of retention rules.
+type RetentionRulesMatcher interface {
+	// RetentionPeriod returns the retention period of the first rule that
+	// matches the tags and true, or false if no rule matches. The tags
+	// iterator is consumed.
+	RetentionPeriod(tags ident.TagIterator) (time.Duration, bool)
+
+	// ExpiredRetentionPeriod returns the longest rule retention period for
+	// which data in a block has expired at the given time and true, or false
+	// if the block has not expired for any rule. Series matching a rule with
+	// a retention period no longer than the returned period have expired.
+	ExpiredRetentionPeriod(
+		blockStart time.Time,
+		blockSize time.Duration,
+		now time.Time,
+	) (time.Duration, bool)
+}
+
+type retentionRuleMatcher struct {
+	filters         map[string]filters.Filter
+	retentionPeriod time.Duration
+}
+
+type retentionRulesMatcher struct {
+	rules []retentionRuleMatcher
+}
+
+// NewRetentionRulesMatcher returns a new retention rules matcher, rules
+// are matched in order.
+func NewRetentionRulesMatcher(rules []RetentionRule) (RetentionRulesMatcher, error) {
+	matcher := &retentionRulesMatcher{
+		rules: make([]retentionRuleMatcher, 0, len(rules)),
+	}
+	seen := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		if rule.RetentionPeriod <= 0 {
+			return nil, errRetentionRulePeriodPositive
+		}
+		if _, ok := seen[rule.Filter]; ok {
+			return nil, fmt.Errorf("%v: %s", errRetentionRuleFilterDuplicate, rule.Filter)
+		}
+		seen[rule.Filter] = struct{}{}
+
+		values, err := filters.ParseTagFilterValueMap(rule.Filter)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == 0 {
+			return nil, errRetentionRuleFilterEmpty
+		}
+
+		ruleFilters := make(map[string]filters.Filter, len(values))
+		for name, value := range values {
+			f, err := filters.NewFilterFromFilterValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid retention rule filter %s: %v",
+					rule.Filter, err)
+			}
+			ruleFilters[name] = f
+		}
+
+		matcher.rules = append(matcher.rules, retentionRuleMatcher{
+			filters:         ruleFilters,
+			retentionPeriod: rule.RetentionPeriod,
+		})
+	}
+	return matcher, nil
+}
+
+func (m *retentionRulesMatcher) RetentionPeriod(
+	tags ident.TagIterator,
+) (time.Duration, bool) {
+	if len(m.rules) == 0 {
+		return 0, false
+	}
+
+	// NB: count the matched tags of every rule in a single pass since tag
+	// iterators cannot be rewound.
+	var matchedArr [8]int
+	matched := matchedArr[:]
+	if len(m.rules) > len(matchedArr) {
+		matched = make([]int, len(m.rules))
+	}
+
+	for tags.Next() {
+		tag := tags.Current()
+		name, value := tag.Name.Bytes(), tag.Value.Bytes()
+		for i, rule := range m.rules {
+			f, ok := rule.filters[string(name)]
+			if ok && f.Matches(value) {
+				matched[i]++
+			}
+		}
+	}
+	if tags.Err() != nil {
+		return 0, false
+	}
+
+	for i, rule := range m.rules {
+		if matched[i] == len(rule.filters) {
+			return rule.retentionPeriod, true
+		}
+	}
+	return 0, false
+}
+
+func (m *retentionRulesMatcher) ExpiredRetentionPeriod(
+	blockStart time.Time,
+	blockSize time.Duration,
+	now time.Time,
+) (time.Duration, bool) {
+	var (
+		expired    time.Duration
+		hasExpired bool
+	)
+	for _, rule := range m.rules {
+		retentionStart := retention.FlushTimeStartForRetentionPeriod(
+			rule.retentionPeriod, blockSize, now)
+		if blockStart.Before(retentionStart) && rule.retentionPeriod > expired {
+			expired = rule.retentionPeriod
+			hasExpired = true
+		}
+	}
+	return expired, hasExpired
+}
+
+func validateRetentionRules(
+	rules []RetentionRule,
+	retentionPeriod time.Duration,
+) error {
+	if _, err := NewRetentionRulesMatcher(rules); err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if rule.RetentionPeriod > retentionPeriod {
+			return fmt.Errorf("%v: filter=%s, period=%v, namespace period=%v",
+				errRetentionRulePeriodTooLarge, rule.Filter, rule.RetentionPeriod,
+				retentionPeriod)
+		}
+	}
+	return nil
+}
+
+func retentionRulesEqual(a, b []RetentionRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
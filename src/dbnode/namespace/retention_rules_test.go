@@ -0,0 +1,162 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionRulesMatcher(t *testing.T) {
+	matcher, err := NewRetentionRulesMatcher([]RetentionRule{
+		{Filter: "env:dev service:foo*", RetentionPeriod: time.Hour},
+		{Filter: "env:dev", RetentionPeriod: 2 * time.Hour},
+		{Filter: "slo:!false", RetentionPeriod: 3 * time.Hour},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		tags     ident.Tags
+		period   time.Duration
+		matching bool
+	}{
+		{
+			tags:     ident.NewTags(ident.StringTag("env", "dev"), ident.StringTag("service", "foobar")),
+			period:   time.Hour,
+			matching: true,
+		},
+		{
+			tags:     ident.NewTags(ident.StringTag("env", "dev"), ident.StringTag("service", "bar")),
+			period:   2 * time.Hour,
+			matching: true,
+		},
+		{
+			tags:     ident.NewTags(ident.StringTag("env", "prod"), ident.StringTag("slo", "true")),
+			period:   3 * time.Hour,
+			matching: true,
+		},
+		{
+			tags: ident.NewTags(ident.StringTag("env", "prod"), ident.StringTag("slo", "false")),
+		},
+		{
+			tags: ident.NewTags(ident.StringTag("service", "foobar")),
+		},
+	}
+
+	for _, test := range tests {
+		period, ok := matcher.RetentionPeriod(ident.NewTagsIterator(test.tags))
+		require.Equal(t, test.matching, ok)
+		require.Equal(t, test.period, period)
+	}
+}
+
+func TestRetentionRulesMatcherExpiredRetentionPeriod(t *testing.T) {
+	matcher, err := NewRetentionRulesMatcher([]RetentionRule{
+		{Filter: "env:dev", RetentionPeriod: 2 * time.Hour},
+		{Filter: "env:staging", RetentionPeriod: 6 * time.Hour},
+	})
+	require.NoError(t, err)
+
+	var (
+		blockSize = time.Hour
+		now       = time.Unix(0, 0).Add(24 * time.Hour).Add(30 * time.Minute)
+	)
+	tests := []struct {
+		blockStart time.Time
+		expired    time.Duration
+		hasExpired bool
+	}{
+		{blockStart: now.Truncate(blockSize)},
+		{blockStart: now.Truncate(blockSize).Add(-2 * blockSize)},
+		{
+			blockStart: now.Truncate(blockSize).Add(-3 * blockSize),
+			expired:    2 * time.Hour,
+			hasExpired: true,
+		},
+		{
+			blockStart: now.Truncate(blockSize).Add(-7 * blockSize),
+			expired:    6 * time.Hour,
+			hasExpired: true,
+		},
+	}
+	for _, test := range tests {
+		expired, ok := matcher.ExpiredRetentionPeriod(test.blockStart, blockSize, now)
+		require.Equal(t, test.hasExpired, ok)
+		require.Equal(t, test.expired, expired)
+	}
+}
+
+func TestRetentionRulesMatcherManyRules(t *testing.T) {
+	var rules []RetentionRule
+	for i := 0; i < 10; i++ {
+		rules = append(rules, RetentionRule{
+			Filter:          "idx:" + string(rune('a'+i)),
+			RetentionPeriod: time.Duration(i+1) * time.Hour,
+		})
+	}
+	matcher, err := NewRetentionRulesMatcher(rules)
+	require.NoError(t, err)
+
+	period, ok := matcher.RetentionPeriod(ident.NewTagsIterator(
+		ident.NewTags(ident.StringTag("idx", "j"))))
+	require.True(t, ok)
+	require.Equal(t, 10*time.Hour, period)
+}
+
+func TestRetentionRulesMatcherInvalid(t *testing.T) {
+	invalid := [][]RetentionRule{
+		{{Filter: "", RetentionPeriod: time.Hour}},
+		{{Filter: "env", RetentionPeriod: time.Hour}},
+		{{Filter: "env:dev", RetentionPeriod: 0}},
+		{
+			{Filter: "env:dev", RetentionPeriod: time.Hour},
+			{Filter: "env:dev", RetentionPeriod: 2 * time.Hour},
+		},
+	}
+	for _, rules := range invalid {
+		_, err := NewRetentionRulesMatcher(rules)
+		require.Error(t, err)
+	}
+}
+
+func TestOptionsValidateRetentionRules(t *testing.T) {
+	opts := NewOptions().
+		SetRetentionOptions(retention.NewOptions().SetRetentionPeriod(48 * time.Hour))
+
+	valid := opts.SetRetentionRules([]RetentionRule{
+		{Filter: "env:dev", RetentionPeriod: 2 * time.Hour},
+	})
+	require.NoError(t, valid.Validate())
+
+	tooLong := opts.SetRetentionRules([]RetentionRule{
+		{Filter: "env:dev", RetentionPeriod: 72 * time.Hour},
+	})
+	require.Error(t, tooLong.Validate())
+
+	require.True(t, valid.Equal(valid))
+	require.False(t, valid.Equal(opts))
+	require.False(t, valid.Equal(tooLong))
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/retention"
+)
+
+var (
+	errTieringAfterNegative = errors.New("tiering after must not be negative")
+	errTieringAfterTooSmall = errors.New("tiering after must be at least the buffer past")
+)
+
+// TieringPolicy moves the flushed filesets of a namespace to a secondary tier,
+// such as a cheaper volume or an object store, once they are old enough that
+// they are rarely read. Tiered filesets are fetched back and cached locally
+// when they are read.
+type TieringPolicy struct {
+	// After is how long after the end of a block its filesets are moved to
+	// the secondary tier, zero disables tiering.
+	After time.Duration `yaml:"after"`
+}
+
+// Enabled returns whether tiering is enabled.
+func (p TieringPolicy) Enabled() bool {
+	return p.After > 0
+}
+
+// TierBefore returns the block start before which blocks are old enough to
+// be moved to the secondary tier at the given time.
+func (p TieringPolicy) TierBefore(now time.Time, blockSize time.Duration) time.Time {
+	return now.Add(-p.After).Add(-blockSize).Truncate(blockSize).Add(blockSize)
+}
+
+// Validate validates the tiering policy against the retention options of
+// the namespace, blocks must no longer accept warm writes once tiered.
+func (p TieringPolicy) Validate(ropts retention.Options) error {
+	if p.After < 0 {
+		return errTieringAfterNegative
+	}
+	if p.Enabled() && p.After < ropts.BufferPast() {
+		return errTieringAfterTooSmall
+	}
+	return nil
+}
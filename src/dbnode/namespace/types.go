@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
@@ -131,6 +132,38 @@ type Options interface {
 
 	// StagingState returns the state related to a namespace's availability for use.
 	StagingState() StagingState
+
+	// SetCompressionType sets the compression applied to the data of each
+	// series in fileset data files.
+	SetCompressionType(value compression.Type) Options
+
+	// CompressionType returns the compression applied to the data of each
+	// series in fileset data files.
+	CompressionType() compression.Type
+
+	// SetRetentionRules sets the rules overriding the retention period of
+	// series by their tags, rules are matched in order.
+	SetRetentionRules(value []RetentionRule) Options
+
+	// RetentionRules returns the rules overriding the retention period of
+	// series by their tags, rules are matched in order.
+	RetentionRules() []RetentionRule
+
+	// SetCardinalityLimits sets the limits on the number of active series
+	// in the namespace.
+	SetCardinalityLimits(value CardinalityLimits) Options
+
+	// CardinalityLimits returns the limits on the number of active series
+	// in the namespace.
+	CardinalityLimits() CardinalityLimits
+
+	// SetTieringPolicy sets the policy for moving flushed filesets to a
+	// secondary tier.
+	SetTieringPolicy(value TieringPolicy) Options
+
+	// TieringPolicy returns the policy for moving flushed filesets to a
+	// secondary tier.
+	TieringPolicy() TieringPolicy
 }
 
 // IndexOptions controls the indexing options for a namespace.
@@ -359,6 +392,10 @@ type AggregatedAttributes struct {
 
 	// DownsampleOptions stores options around how data points are downsampled.
 	DownsampleOptions DownsampleOptions
+
+	// TileAggregation stores options for automatically aggregating tiles into
+	// the namespace from a source namespace.
+	TileAggregation TileAggregationOptions
 }
 
 // DownsampleOptions is a set of options related to downsampling data.
@@ -370,6 +407,19 @@ type DownsampleOptions struct {
 	All bool
 }
 
+// TileAggregationOptions are options for automatically aggregating tiles of
+// a source namespace into an aggregated namespace once source blocks are
+// flushed.
+type TileAggregationOptions struct {
+	// SourceNamespace is the namespace to aggregate tiles from, tile
+	// aggregation is disabled if empty.
+	SourceNamespace string
+
+	// DownsampleType is how data points within each resolution step are
+	// combined.
+	DownsampleType DownsampleType
+}
+
 // StagingStatus is the status of the namespace.
 type StagingStatus uint8
 
@@ -26,6 +26,14 @@ import (
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 )
 
+const (
+	// ErrorFlagNone indicates that an error has no flags.
+	ErrorFlagNone int64 = 0
+	// ErrorFlagTenantLimitExceeded indicates that an error was caused by a
+	// tenant exceeding one of its limits.
+	ErrorFlagTenantLimitExceeded int64 = 1 << 0
+)
+
 func newError(errType rpc.ErrorType, err error) *rpc.Error {
 	rpcErr := rpc.NewError()
 	rpcErr.Type = errType
@@ -43,6 +51,12 @@ func IsBadRequestError(err *rpc.Error) bool {
 	return err != nil && err.Type == rpc.ErrorType_BAD_REQUEST
 }
 
+// IsTenantLimitExceededError returns whether the error is a tenant limit
+// exceeded error
+func IsTenantLimitExceededError(err *rpc.Error) bool {
+	return err != nil && err.Flags&ErrorFlagTenantLimitExceeded != 0
+}
+
 // NewInternalError creates a new internal error
 func NewInternalError(err error) *rpc.Error {
 	return newError(rpc.ErrorType_INTERNAL_ERROR, err)
@@ -53,6 +67,14 @@ func NewBadRequestError(err error) *rpc.Error {
 	return newError(rpc.ErrorType_BAD_REQUEST, err)
 }
 
+// NewTenantLimitExceededError creates a new tenant limit exceeded error,
+// which is also a bad request error
+func NewTenantLimitExceededError(err error) *rpc.Error {
+	rpcErr := newError(rpc.ErrorType_BAD_REQUEST, err)
+	rpcErr.Flags |= ErrorFlagTenantLimitExceeded
+	return rpcErr
+}
+
 // NewWriteBatchRawError creates a new write batch error
 func NewWriteBatchRawError(index int, err error) *rpc.WriteBatchRawError {
 	batchErr := rpc.NewWriteBatchRawError()
@@ -68,3 +90,12 @@ func NewBadRequestWriteBatchRawError(index int, err error) *rpc.WriteBatchRawErr
 	batchErr.Err = NewBadRequestError(err)
 	return batchErr
 }
+
+// NewTenantLimitExceededWriteBatchRawError creates a new tenant limit
+// exceeded write batch error
+func NewTenantLimitExceededWriteBatchRawError(index int, err error) *rpc.WriteBatchRawError {
+	batchErr := rpc.NewWriteBatchRawError()
+	batchErr.Index = int64(index)
+	batchErr.Err = NewTenantLimitExceededError(err)
+	return batchErr
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/limits"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/dbnode/ts/writes"
 	"github.com/m3db/m3/src/dbnode/x/xio"
@@ -113,6 +114,9 @@ type serviceMetrics struct {
 	fetchBlocksMetadata     instrument.MethodMetrics
 	repair                  instrument.MethodMetrics
 	truncate                instrument.MethodMetrics
+	deleteSeries            instrument.MethodMetrics
+	fetchBlockHashTree      instrument.MethodMetrics
+	seriesCardinality       instrument.MethodMetrics
 	fetchBatchRawRPCS       tally.Counter
 	fetchBatchRaw           instrument.BatchMethodMetrics
 	writeBatchRawRPCs       tally.Counter
@@ -133,6 +137,9 @@ func newServiceMetrics(scope tally.Scope, opts instrument.TimerOptions) serviceM
 		fetchBlocksMetadata:     instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", opts),
 		repair:                  instrument.NewMethodMetrics(scope, "repair", opts),
 		truncate:                instrument.NewMethodMetrics(scope, "truncate", opts),
+		deleteSeries:            instrument.NewMethodMetrics(scope, "deleteSeries", opts),
+		fetchBlockHashTree:      instrument.NewMethodMetrics(scope, "fetchBlockHashTree", opts),
+		seriesCardinality:       instrument.NewMethodMetrics(scope, "seriesCardinality", opts),
 		fetchBatchRawRPCS:       scope.Counter("fetchBatchRaw-rpcs"),
 		fetchBatchRaw:           instrument.NewBatchMethodMetrics(scope, "fetchBatchRaw", opts),
 		writeBatchRawRPCs:       scope.Counter("writeBatchRaw-rpcs"),
@@ -1894,6 +1901,149 @@ func (s *service) Truncate(tctx thrift.Context, req *rpc.TruncateRequest) (r *rp
 	return res, nil
 }
 
+func (s *service) DeleteSeries(tctx thrift.Context, req *rpc.DeleteSeriesRequest) (*rpc.DeleteSeriesResult_, error) {
+	db, err := s.startRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+
+	start, rangeStartErr := convert.ToTime(req.RangeStart, req.RangeTimeType)
+	end, rangeEndErr := convert.ToTime(req.RangeEnd, req.RangeTimeType)
+	if rangeStartErr != nil || rangeEndErr != nil {
+		s.metrics.deleteSeries.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(xerrors.FirstError(rangeStartErr, rangeEndErr))
+	}
+	if end.Before(start) {
+		s.metrics.deleteSeries.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(errors.New("range end is before range start"))
+	}
+
+	ids := make([]ident.ID, 0, len(req.Ids))
+	for _, id := range req.Ids {
+		ids = append(ids, s.newID(ctx, id))
+	}
+
+	deleted, err := db.DeleteSeries(ctx, s.newID(ctx, req.NameSpace), ids, start, end)
+	if err != nil {
+		s.metrics.deleteSeries.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewDeleteSeriesResult_()
+	res.NumSeries = deleted
+
+	s.metrics.deleteSeries.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func (s *service) FetchBlockHashTree(tctx thrift.Context, req *rpc.FetchBlockHashTreeRequest) (*rpc.FetchBlockHashTreeResult_, error) {
+	db, err := s.startReadRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+	defer s.readRPCCompleted()
+
+	callStart := s.nowFn()
+	defer func() {
+		// No need to report metric anywhere else as we capture all cases here
+		s.metrics.fetchBlockHashTree.ReportSuccessOrError(err, s.nowFn().Sub(callStart))
+	}()
+
+	var (
+		ctx        = tchannelthrift.Context(tctx)
+		nsID       = s.newID(ctx, req.NameSpace)
+		shard      = uint32(req.Shard)
+		blockStart = time.Unix(0, req.BlockStart)
+		opts       = repair.HashTreeOptions{
+			Fanout: int(req.Fanout),
+			Depth:  int(req.Depth),
+		}
+		nodes = make([]int, 0, len(req.Nodes))
+	)
+	if err = opts.Validate(); err != nil {
+		return nil, tterrors.NewBadRequestError(err)
+	}
+	for _, node := range req.Nodes {
+		nodes = append(nodes, int(node))
+	}
+
+	tree, err := db.FetchBlockHashTree(ctx, nsID, shard, blockStart, opts)
+	if err != nil {
+		return nil, convert.ToRPCError(err)
+	}
+	hashes, err := tree.Level(int(req.Level), nodes)
+	if err != nil {
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	result := rpc.NewFetchBlockHashTreeResult_()
+	result.Hashes = make([]int64, 0, len(hashes))
+	for _, hash := range hashes {
+		result.Hashes = append(result.Hashes, int64(hash))
+	}
+	result.Entries = []*rpc.BlockHashTreeEntry{}
+	if !req.IncludeEntries {
+		return result, nil
+	}
+
+	if int(req.Level) != opts.Depth {
+		err = errors.New("hash tree entries can only be fetched for leaves")
+		return nil, tterrors.NewBadRequestError(err)
+	}
+	entries, err := db.FetchBlockHashTreeEntries(ctx, nsID, shard, blockStart, opts, nodes)
+	if err != nil {
+		return nil, convert.ToRPCError(err)
+	}
+	for _, entry := range entries {
+		result.Entries = append(result.Entries, &rpc.BlockHashTreeEntry{
+			ID:       entry.ID.Bytes(),
+			Size:     entry.Size,
+			Checksum: int64(entry.Checksum),
+		})
+	}
+	return result, nil
+}
+
+func (s *service) SeriesCardinality(tctx thrift.Context, req *rpc.SeriesCardinalityRequest) (*rpc.SeriesCardinalityResult_, error) {
+	db, err := s.startReadRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+	defer s.readRPCCompleted()
+
+	callStart := s.nowFn()
+	defer func() {
+		// No need to report metric anywhere else as we capture all cases here
+		s.metrics.seriesCardinality.ReportSuccessOrError(err, s.nowFn().Sub(callStart))
+	}()
+
+	ctx := tchannelthrift.Context(tctx)
+	cardinality, err := db.SeriesCardinality(s.newID(ctx, req.NameSpace),
+		int(req.MaxMetricNames))
+	if err != nil {
+		return nil, convert.ToRPCError(err)
+	}
+
+	result := &rpc.SeriesCardinalityResult_{
+		NumSeries:              cardinality.NumSeries,
+		MaxSeries:              cardinality.Limits.MaxSeries,
+		MaxSeriesPerMetricName: cardinality.Limits.MaxSeriesPerMetricName,
+		MetricNames: make([]*rpc.MetricNameSeriesCardinality, 0,
+			len(cardinality.MetricNames)),
+	}
+	for _, metricName := range cardinality.MetricNames {
+		result.MetricNames = append(result.MetricNames, &rpc.MetricNameSeriesCardinality{
+			MetricName: metricName.MetricName,
+			NumSeries:  metricName.NumSeries,
+		})
+	}
+	return result, nil
+}
+
 func (s *service) GetPersistRateLimit(
 	ctx thrift.Context,
 ) (*rpc.NodePersistRateLimitResult_, error) {
@@ -2530,6 +2680,14 @@ func (r *writeBatchPooledReq) HandleError(index int, err error) {
 		return
 	}
 
+	if limits.IsTenantLimitExceededError(err) {
+		r.nonRetryableErrors++
+		r.errs = append(
+			r.errs,
+			tterrors.NewTenantLimitExceededWriteBatchRawError(index, err))
+		return
+	}
+
 	if xerrors.IsInvalidParams(err) {
 		r.nonRetryableErrors++
 		r.errs = append(
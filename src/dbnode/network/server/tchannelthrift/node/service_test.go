@@ -2974,6 +2974,60 @@ func TestServiceTruncate(t *testing.T) {
 	assert.Equal(t, truncated, r.NumSeries)
 }
 
+func TestServiceDeleteSeries(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).AnyTimes()
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	var (
+		nsID    = "metrics"
+		start   = time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+		end     = start.Add(time.Hour)
+		deleted = int64(2)
+	)
+	mockDB.EXPECT().
+		DeleteSeries(gomock.Any(), ident.NewIDMatcher(nsID), gomock.Any(), start, end).
+		DoAndReturn(func(
+			_ interface{},
+			_ ident.ID,
+			ids []ident.ID,
+			_, _ time.Time,
+		) (int64, error) {
+			require.Equal(t, 2, len(ids))
+			assert.Equal(t, "foo", ids[0].String())
+			assert.Equal(t, "bar", ids[1].String())
+			return deleted, nil
+		})
+
+	r, err := service.DeleteSeries(tctx, &rpc.DeleteSeriesRequest{
+		NameSpace:  []byte(nsID),
+		Ids:        [][]byte{[]byte("foo"), []byte("bar")},
+		RangeStart: start.Unix(),
+		RangeEnd:   end.Unix(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, deleted, r.NumSeries)
+
+	// Ranges that end before they start are rejected.
+	_, err = service.DeleteSeries(tctx, &rpc.DeleteSeriesRequest{
+		NameSpace:  []byte(nsID),
+		Ids:        [][]byte{[]byte("foo")},
+		RangeStart: end.Unix(),
+		RangeEnd:   start.Unix(),
+	})
+	require.Error(t, err)
+	require.True(t, tterrors.IsBadRequestError(err.(*rpc.Error)))
+}
+
 func TestServiceSetPersistRateLimit(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
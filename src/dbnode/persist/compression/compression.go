@@ -0,0 +1,237 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package compression provides codecs applied to fileset data on top of the
+// time series encoding.
+package compression
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+)
+
+// Type is a compression codec applied to fileset data.
+type Type int
+
+const (
+	// NoneType applies no compression.
+	NoneType Type = iota
+	// SnappyType compresses with snappy, favouring speed over ratio.
+	SnappyType
+	// DeflateType compresses with deflate, favouring ratio over speed.
+	DeflateType
+)
+
+var (
+	validTypes = []Type{
+		NoneType,
+		SnappyType,
+		DeflateType,
+	}
+
+	errInvalidHeader        = errors.New("invalid compressed data header")
+	errDecompressBufferSize = errors.New("decompress buffer too small")
+	errDecompressedSize     = errors.New("decompressed size does not match header")
+
+	flateWriterPool = sync.Pool{
+		New: func() interface{} {
+			// NB: only errors for an invalid compression level.
+			w, _ := flate.NewWriter(nil, flate.DefaultCompression)
+			return w
+		},
+	}
+	flateReaderPool = sync.Pool{
+		New: func() interface{} {
+			return flate.NewReader(bytes.NewReader(nil))
+		},
+	}
+)
+
+// ValidTypes returns the valid compression types.
+func ValidTypes() []Type {
+	return validTypes
+}
+
+func (t Type) String() string {
+	switch t {
+	case NoneType:
+		return "none"
+	case SnappyType:
+		return "snappy"
+	case DeflateType:
+		return "deflate"
+	default:
+		return "unknown"
+	}
+}
+
+// Validate validates the compression type.
+func (t Type) Validate() error {
+	for _, valid := range validTypes {
+		if t == valid {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid compression type: %d", int(t))
+}
+
+// ParseType parses a compression type, an empty string is parsed as none.
+func ParseType(str string) (Type, error) {
+	if str == "" {
+		return NoneType, nil
+	}
+
+	for _, valid := range validTypes {
+		if str == valid.String() {
+			return valid, nil
+		}
+	}
+
+	return NoneType, fmt.Errorf("invalid compression type: %s, valid types are: %v",
+		str, validTypes)
+}
+
+// UnmarshalYAML unmarshals a compression type from a string.
+func (t *Type) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+
+	parsed, err := ParseType(str)
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
+
+// Compress compresses src into dst, reusing the capacity of dst. Compressed
+// data is prefixed with the length of the uncompressed data so that callers
+// can size buffers for decompression.
+func (t Type) Compress(dst, src []byte) ([]byte, error) {
+	var header [binary.MaxVarintLen64]byte
+	headerLen := binary.PutUvarint(header[:], uint64(len(src)))
+
+	switch t {
+	case NoneType:
+		return append(dst[:0], src...), nil
+	case SnappyType:
+		maxLen := headerLen + snappy.MaxEncodedLen(len(src))
+		if cap(dst) < maxLen {
+			dst = make([]byte, maxLen)
+		}
+		dst = dst[:maxLen]
+		copy(dst, header[:headerLen])
+		encoded := snappy.Encode(dst[headerLen:], src)
+		return dst[:headerLen+len(encoded)], nil
+	case DeflateType:
+		buf := bytes.NewBuffer(dst[:0])
+		buf.Write(header[:headerLen])
+
+		w := flateWriterPool.Get().(*flate.Writer)
+		defer flateWriterPool.Put(w)
+
+		w.Reset(buf)
+		if _, err := w.Write(src); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	default:
+		return nil, t.Validate()
+	}
+}
+
+// DecompressedLen returns the length of compressed data once decompressed.
+func (t Type) DecompressedLen(src []byte) (int, error) {
+	if t == NoneType {
+		return len(src), nil
+	}
+
+	n, headerLen := binary.Uvarint(src)
+	if headerLen <= 0 {
+		return 0, errInvalidHeader
+	}
+
+	return int(n), nil
+}
+
+// Decompress decompresses src into dst, which must be at least as long as
+// the decompressed length of src, returning the decompressed data.
+func (t Type) Decompress(dst, src []byte) ([]byte, error) {
+	if t == NoneType {
+		if len(dst) < len(src) {
+			return nil, errDecompressBufferSize
+		}
+		return dst[:copy(dst, src)], nil
+	}
+
+	n, headerLen := binary.Uvarint(src)
+	if headerLen <= 0 {
+		return nil, errInvalidHeader
+	}
+	if uint64(len(dst)) < n {
+		return nil, errDecompressBufferSize
+	}
+
+	var (
+		payload = src[headerLen:]
+		result  = dst[:n]
+	)
+	switch t {
+	case SnappyType:
+		decodedLen, err := snappy.DecodedLen(payload)
+		if err != nil {
+			return nil, err
+		}
+		if uint64(decodedLen) != n {
+			return nil, errDecompressedSize
+		}
+
+		return snappy.Decode(result, payload)
+	case DeflateType:
+		r := flateReaderPool.Get().(io.ReadCloser)
+		defer flateReaderPool.Put(r)
+
+		if err := r.(flate.Resetter).Reset(bytes.NewReader(payload), nil); err != nil {
+			return nil, err
+		}
+		if _, err := io.ReadFull(r, result); err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	default:
+		return nil, t.Validate()
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compression
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressRoundTrip(t *testing.T) {
+	inputs := [][]byte{
+		nil,
+		[]byte("a"),
+		bytes.Repeat([]byte("abcdefgh"), 1024),
+	}
+
+	for _, typ := range ValidTypes() {
+		t.Run(typ.String(), func(t *testing.T) {
+			var buf []byte
+			for _, input := range inputs {
+				compressed, err := typ.Compress(buf, input)
+				require.NoError(t, err)
+				buf = compressed
+
+				n, err := typ.DecompressedLen(compressed)
+				require.NoError(t, err)
+				require.Equal(t, len(input), n)
+
+				decompressed, err := typ.Decompress(make([]byte, n), compressed)
+				require.NoError(t, err)
+				require.Equal(t, len(input), len(decompressed))
+				require.True(t, bytes.Equal(input, decompressed))
+			}
+		})
+	}
+}
+
+func TestCompressReducesRepetitiveData(t *testing.T) {
+	input := bytes.Repeat([]byte("abcdefgh"), 1024)
+	for _, typ := range []Type{SnappyType, DeflateType} {
+		compressed, err := typ.Compress(nil, input)
+		require.NoError(t, err)
+		assert.True(t, len(compressed) < len(input)/4, typ.String())
+	}
+}
+
+func TestDecompressBufferTooSmall(t *testing.T) {
+	compressed, err := SnappyType.Compress(nil, []byte("foobar"))
+	require.NoError(t, err)
+
+	_, err = SnappyType.Decompress(make([]byte, 3), compressed)
+	require.Equal(t, errDecompressBufferSize, err)
+}
+
+func TestParseType(t *testing.T) {
+	for _, typ := range ValidTypes() {
+		parsed, err := ParseType(typ.String())
+		require.NoError(t, err)
+		assert.Equal(t, typ, parsed)
+	}
+
+	parsed, err := ParseType("")
+	require.NoError(t, err)
+	assert.Equal(t, NoneType, parsed)
+
+	_, err = ParseType("zip")
+	require.Error(t, err)
+	require.Error(t, Type(100).Validate())
+}
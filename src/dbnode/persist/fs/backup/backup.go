@@ -0,0 +1,511 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"hash/adler32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/pborman/uuid"
+)
+
+const (
+	checkpointFileSuffix = "checkpoint.db"
+	restoreDirPerm       = 0755
+	restoreFilePerm      = 0666
+)
+
+var (
+	errFilesystemOptionsNotSet = errors.New("filesystem options not set")
+	errStoreNotSet             = errors.New("backup store not set")
+	errNoNamespaces            = errors.New("no namespaces to backup")
+)
+
+// BackupOptions are the options for taking a backup.
+type BackupOptions struct {
+	// FilesystemOptions are the options of the filesystem being backed up.
+	FilesystemOptions fs.Options
+	// Namespaces are the namespaces to backup.
+	Namespaces []namespace.Metadata
+	// Topology is the topology recorded in the manifest, optional.
+	Topology topology.Map
+	// Store is the store that the backup is written to.
+	Store Store
+}
+
+// Backup copies the complete flushed data and index filesets and the most
+// recent snapshot of each namespace to the store, followed by a manifest with
+// the size and checksum of each file.
+//
+// Backup does not coordinate with the database, callers taking a backup of
+// a running node must disable file operations so that no filesets are
+// written or cleaned up while the backup is in progress.
+func Backup(opts BackupOptions) (Manifest, error) {
+	if opts.FilesystemOptions == nil {
+		return Manifest{}, errFilesystemOptionsNotSet
+	}
+	if opts.Store == nil {
+		return Manifest{}, errStoreNotSet
+	}
+	if len(opts.Namespaces) == 0 {
+		return Manifest{}, errNoNamespaces
+	}
+
+	manifest := Manifest{
+		Version:   manifestVersion,
+		CreatedAt: opts.FilesystemOptions.ClockOptions().NowFn()(),
+	}
+	if err := manifest.setNamespaces(opts.Namespaces); err != nil {
+		return Manifest{}, err
+	}
+	if opts.Topology != nil {
+		manifest.Topology = NewTopology(opts.Topology)
+	}
+
+	files, err := backupFiles(opts.FilesystemOptions, opts.Namespaces)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	var (
+		tieredFileSet *fs.FileSetFileIdentifier
+		release       = func() {}
+	)
+	defer func() { release() }()
+	for _, f := range files {
+		if f.tieredFileSet != nil && f.tieredFileSet != tieredFileSet {
+			// Tiered files are fetched as the backup reaches their fileset
+			// and only pinned until copied so that the backup does not
+			// require the tier cache to fit every tiered fileset.
+			release()
+			release, err = fetchTieredFileSet(opts.FilesystemOptions, *f.tieredFileSet)
+			if err != nil {
+				release = func() {}
+				return Manifest{}, err
+			}
+			tieredFileSet = f.tieredFileSet
+		}
+
+		file, err := backupFile(opts.Store, f)
+		if err != nil {
+			return Manifest{}, fmt.Errorf("error backing up %s: %v", f.absolutePath, err)
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	if err := writeManifest(opts.Store, manifest); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+type backupSourceFile struct {
+	File
+	absolutePath string
+	// tieredFileSet is set for the files of a fileset that has been moved
+	// to a secondary tier, shared by all the files of the fileset.
+	tieredFileSet *fs.FileSetFileIdentifier
+}
+
+func backupFiles(
+	fsOpts fs.Options,
+	namespaces []namespace.Metadata,
+) ([]backupSourceFile, error) {
+	var (
+		prefix = fsOpts.FilePathPrefix()
+		files  []backupSourceFile
+		add    = func(
+			ns ident.ID,
+			shard *uint32,
+			paths []string,
+			tieredFileSet *fs.FileSetFileIdentifier,
+		) error {
+			for _, path := range orderCheckpointLast(paths) {
+				relPath, err := filepath.Rel(prefix, path)
+				if err != nil {
+					return err
+				}
+				file := backupSourceFile{
+					File: File{
+						Path:  filepath.ToSlash(relPath),
+						Shard: shard,
+					},
+					absolutePath:  path,
+					tieredFileSet: tieredFileSet,
+				}
+				if ns != nil {
+					file.Namespace = ns.String()
+				}
+				files = append(files, file)
+			}
+			return nil
+		}
+	)
+
+	// Only the most recent snapshot is required to bootstrap.
+	var latestSnapshot *fs.SnapshotMetadata
+	snapshots, _, err := fs.SortedSnapshotMetadataFiles(fsOpts)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) > 0 {
+		latestSnapshot = &snapshots[len(snapshots)-1]
+	}
+
+	for _, md := range namespaces {
+		ns := md.ID()
+		shards, err := namespaceShards(prefix, ns)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, shard := range shards {
+			shard := shard
+			dataFiles, err := fs.DataFiles(prefix, ns, shard)
+			if err != nil {
+				return nil, err
+			}
+			for _, fileSet := range dataFiles {
+				if !fileSet.HasCompleteCheckpointFile() {
+					continue
+				}
+				paths, tiered, err := untieredFileSetFilePaths(fsOpts, fileSet)
+				if err != nil {
+					return nil, err
+				}
+				var tieredFileSet *fs.FileSetFileIdentifier
+				if tiered {
+					id := fileSet.ID
+					tieredFileSet = &id
+				}
+				if err := add(ns, &shard, paths, tieredFileSet); err != nil {
+					return nil, err
+				}
+			}
+
+			if latestSnapshot == nil {
+				continue
+			}
+			snapshotFiles, err := fs.SnapshotFiles(prefix, ns, shard)
+			if err != nil {
+				return nil, err
+			}
+			for _, fileSet := range snapshotFiles {
+				if !fileSet.HasCompleteCheckpointFile() {
+					continue
+				}
+				_, snapshotID, err := fileSet.SnapshotTimeAndID()
+				if err != nil || !uuid.Equal(snapshotID, latestSnapshot.ID.UUID) {
+					continue
+				}
+				if err := add(ns, &shard, fileSet.AbsoluteFilePaths, nil); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		indexFiles, err := fs.IndexFiles(prefix, ns)
+		if err != nil {
+			return nil, err
+		}
+		for _, fileSet := range indexFiles {
+			if !fileSet.HasCompleteCheckpointFile() {
+				continue
+			}
+			if err := add(ns, nil, fileSet.AbsoluteFilePaths, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if latestSnapshot != nil {
+		// The metadata references the snapshot filesets so is added last.
+		if err := add(nil, nil, latestSnapshot.AbsoluteFilePaths(), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// untieredFileSetFilePaths returns the paths of the files of a data fileset,
+// replacing the marker of a fileset that has been moved to a secondary tier
+// with its tiered files so that the backup does not depend on the tier, and
+// whether the fileset is tiered so its files must be fetched before reading.
+func untieredFileSetFilePaths(fsOpts fs.Options, fileSet fs.FileSetFile) ([]string, bool, error) {
+	paths := make([]string, 0, len(fileSet.AbsoluteFilePaths))
+	tiered := false
+	for _, path := range fileSet.AbsoluteFilePaths {
+		if fs.IsTieredFileSetFile(path) {
+			tiered = true
+			continue
+		}
+		paths = append(paths, path)
+	}
+	if !tiered {
+		return paths, false, nil
+	}
+
+	if fsOpts.TieredFileSetCache() == nil {
+		return nil, false, fmt.Errorf("fileset %s is tiered but no tiered fileset cache is set",
+			fs.TieredFileSetFilePath(fsOpts.FilePathPrefix(), fileSet.ID))
+	}
+	for _, path := range fs.TieredFileSetFilePaths(fsOpts.FilePathPrefix(), fileSet.ID) {
+		if !containsString(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths, true, nil
+}
+
+// fetchTieredFileSet fetches the files of a tiered fileset into the tier
+// cache, pinning them until the returned function is called.
+func fetchTieredFileSet(fsOpts fs.Options, id fs.FileSetFileIdentifier) (func(), error) {
+	return fsOpts.TieredFileSetCache().Fetch(fsOpts.FilePathPrefix(), id)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceShards returns the shards of a namespace that have data or
+// snapshot directories.
+func namespaceShards(prefix string, ns ident.ID) ([]uint32, error) {
+	var (
+		shards = make(map[uint32]struct{})
+		result []uint32
+	)
+	for _, dir := range []string{
+		fs.NamespaceDataDirPath(prefix, ns),
+		fs.NamespaceSnapshotsDirPath(prefix, ns),
+	} {
+		entries, err := ioutil.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			shard, err := strconv.ParseUint(entry.Name(), 10, 32)
+			if err != nil {
+				continue
+			}
+			if _, ok := shards[uint32(shard)]; !ok {
+				shards[uint32(shard)] = struct{}{}
+				result = append(result, uint32(shard))
+			}
+		}
+	}
+	return result, nil
+}
+
+// orderCheckpointLast moves checkpoint files to the end so that a fileset is
+// never restored with a checkpoint file before the files it covers.
+func orderCheckpointLast(paths []string) []string {
+	ordered := make([]string, 0, len(paths))
+	var checkpoints []string
+	for _, path := range paths {
+		if strings.HasSuffix(path, checkpointFileSuffix) {
+			checkpoints = append(checkpoints, path)
+			continue
+		}
+		ordered = append(ordered, path)
+	}
+	return append(ordered, checkpoints...)
+}
+
+func backupFile(store Store, src backupSourceFile) (File, error) {
+	f, err := os.Open(src.absolutePath)
+	if err != nil {
+		return File{}, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return File{}, err
+	}
+
+	var (
+		file   = src.File
+		hash   = adler32.New()
+		linked = false
+	)
+	file.Size = stat.Size()
+	if linker, ok := store.(Linker); ok {
+		linked = linker.Link(file.Path, src.absolutePath) == nil
+	}
+	if linked {
+		_, err = io.Copy(hash, f)
+	} else {
+		err = store.Put(file.Path, io.TeeReader(f, hash), file.Size)
+	}
+	if err != nil {
+		return File{}, err
+	}
+
+	file.Checksum = hash.Sum32()
+	return file, nil
+}
+
+// RestoreOptions are the options for restoring a backup.
+type RestoreOptions struct {
+	// FilesystemOptions are the options of the filesystem being restored to.
+	FilesystemOptions fs.Options
+	// Store is the store that the backup is read from.
+	Store Store
+	// Namespaces restricts the restore to the given namespaces, all
+	// namespaces are restored if not set.
+	Namespaces []string
+	// Shards restricts the restore to the given shards, all shards are
+	// restored if not set.
+	Shards []uint32
+}
+
+// Restore copies the files of a backup to the filesystem so that they are
+// loaded by the filesystem bootstrapper when the node starts, verifying the
+// checksum of each file. Files that already exist with a matching checksum are
+// skipped so that an interrupted restore can be resumed.
+func Restore(opts RestoreOptions) (Manifest, error) {
+	if opts.FilesystemOptions == nil {
+		return Manifest{}, errFilesystemOptionsNotSet
+	}
+	if opts.Store == nil {
+		return Manifest{}, errStoreNotSet
+	}
+
+	manifest, err := ReadManifest(opts.Store)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("error reading backup manifest: %v", err)
+	}
+
+	var (
+		prefix     = opts.FilesystemOptions.FilePathPrefix()
+		namespaces = make(map[string]struct{}, len(opts.Namespaces))
+		shards     = make(map[uint32]struct{}, len(opts.Shards))
+	)
+	for _, ns := range opts.Namespaces {
+		namespaces[ns] = struct{}{}
+	}
+	for _, shard := range opts.Shards {
+		shards[shard] = struct{}{}
+	}
+
+	for _, file := range manifest.Files {
+		if _, ok := namespaces[file.Namespace]; len(namespaces) > 0 && file.Namespace != "" && !ok {
+			continue
+		}
+		if file.Shard != nil && len(shards) > 0 {
+			if _, ok := shards[*file.Shard]; !ok {
+				continue
+			}
+		}
+		if err := restoreFile(opts.Store, prefix, file); err != nil {
+			return Manifest{}, fmt.Errorf("error restoring %s: %v", file.Path, err)
+		}
+	}
+
+	return manifest, nil
+}
+
+func restoreFile(store Store, prefix string, file File) error {
+	path := filepath.Join(prefix, filepath.FromSlash(file.Path))
+	if checksum, err := fileChecksum(path); err == nil {
+		if checksum == file.Checksum {
+			return nil
+		}
+		return fmt.Errorf("file exists with checksum %d, expected %d",
+			checksum, file.Checksum)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	r, err := store.Get(file.Path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(filepath.Dir(path), restoreDirPerm); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, restoreFilePerm)
+	if err != nil {
+		return err
+	}
+
+	hash := adler32.New()
+	n, err := io.Copy(io.MultiWriter(f, hash), r)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && n != file.Size {
+		err = fmt.Errorf("restored %d bytes, expected %d", n, file.Size)
+	}
+	if err == nil && hash.Sum32() != file.Checksum {
+		err = fmt.Errorf("restored checksum %d, expected %d",
+			hash.Sum32(), file.Checksum)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func fileChecksum(path string) (uint32, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	hash := adler32.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return 0, err
+	}
+	return hash.Sum32(), nil
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testBlockStart = time.Unix(0, 0).Add(100 * 2 * time.Hour)
+
+func newTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	return dir
+}
+
+func newTestNamespace(t *testing.T) namespace.Metadata {
+	md, err := namespace.NewMetadata(ident.StringID("testns"), namespace.NewOptions())
+	require.NoError(t, err)
+	return md
+}
+
+func writeTestFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	md namespace.Metadata,
+	shard uint32,
+	series map[string][]byte,
+) {
+	writer, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  md.ID(),
+			Shard:      shard,
+			BlockStart: testBlockStart,
+		},
+		BlockSize:   md.Options().RetentionOptions().BlockSize(),
+		FileSetType: persist.FileSetFlushType,
+	}))
+	for id, data := range series {
+		bytes := checked.NewBytes(data, nil)
+		bytes.IncRef()
+		metadata := persist.NewMetadataFromIDAndTags(ident.StringID(id),
+			ident.Tags{}, persist.MetadataOptions{})
+		require.NoError(t, writer.Write(metadata, bytes, 0))
+		bytes.DecRef()
+	}
+	require.NoError(t, writer.Close())
+}
+
+func readTestFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	md namespace.Metadata,
+	shard uint32,
+) map[string][]byte {
+	reader, err := fs.NewReader(nil, fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  md.ID(),
+			Shard:      shard,
+			BlockStart: testBlockStart,
+		},
+		FileSetType: persist.FileSetFlushType,
+	}))
+	defer reader.Close()
+
+	results := make(map[string][]byte)
+	for {
+		id, _, data, _, err := reader.Read()
+		if err == io.EOF {
+			return results
+		}
+		require.NoError(t, err)
+		data.IncRef()
+		results[id.String()] = append([]byte(nil), data.Bytes()...)
+		data.DecRef()
+	}
+}
+
+func TestBackupAndRestoreDirectory(t *testing.T) {
+	var (
+		srcDir     = newTestDir(t)
+		backupDir  = newTestDir(t)
+		restoreDir = newTestDir(t)
+		md         = newTestNamespace(t)
+		srcOpts    = fs.NewOptions().SetFilePathPrefix(srcDir)
+	)
+	defer os.RemoveAll(srcDir)
+	defer os.RemoveAll(backupDir)
+	defer os.RemoveAll(restoreDir)
+
+	writeTestFileSet(t, srcOpts, md, 1, map[string][]byte{"foo": {1, 2, 3}})
+	writeTestFileSet(t, srcOpts, md, 2, map[string][]byte{"bar": {4, 5}})
+
+	store := NewDirectoryStore(backupDir)
+	manifest, err := Backup(BackupOptions{
+		FilesystemOptions: srcOpts,
+		Namespaces:        []namespace.Metadata{md},
+		Store:             store,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, manifest.Files)
+	require.False(t, manifest.HasSnapshots())
+
+	nsMap, err := manifest.NamespaceMap()
+	require.NoError(t, err)
+	restoredMd, err := nsMap.Get(md.ID())
+	require.NoError(t, err)
+	require.Equal(t, md.Options().RetentionOptions().BlockSize(),
+		restoredMd.Options().RetentionOptions().BlockSize())
+
+	// Only restore the first shard.
+	restoreOpts := fs.NewOptions().SetFilePathPrefix(restoreDir)
+	restored, err := Restore(RestoreOptions{
+		FilesystemOptions: restoreOpts,
+		Store:             store,
+		Shards:            []uint32{1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(manifest.Files), len(restored.Files))
+
+	require.Equal(t, map[string][]byte{"foo": {1, 2, 3}},
+		readTestFileSet(t, restoreOpts, md, 1))
+	exists, err := fs.DataFileSetExists(restoreDir, md.ID(), 2, testBlockStart, 0)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// Restoring again skips the files that already exist.
+	_, err = Restore(RestoreOptions{
+		FilesystemOptions: restoreOpts,
+		Store:             store,
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"bar": {4, 5}},
+		readTestFileSet(t, restoreOpts, md, 2))
+}
+
+func TestRestoreVerifiesChecksums(t *testing.T) {
+	var (
+		srcDir     = newTestDir(t)
+		backupDir  = newTestDir(t)
+		restoreDir = newTestDir(t)
+		md         = newTestNamespace(t)
+		srcOpts    = fs.NewOptions().SetFilePathPrefix(srcDir)
+	)
+	defer os.RemoveAll(srcDir)
+	defer os.RemoveAll(backupDir)
+	defer os.RemoveAll(restoreDir)
+
+	writeTestFileSet(t, srcOpts, md, 1, map[string][]byte{"foo": {1, 2, 3}})
+
+	store := NewDirectoryStore(backupDir)
+	manifest, err := Backup(BackupOptions{
+		FilesystemOptions: srcOpts,
+		Namespaces:        []namespace.Metadata{md},
+		Store:             store,
+	})
+	require.NoError(t, err)
+
+	// Replace rather than modify the file since it may be hard linked to
+	// the source.
+	corrupt := filepath.Join(backupDir, filepath.FromSlash(manifest.Files[0].Path))
+	require.NoError(t, os.Remove(corrupt))
+	require.NoError(t, ioutil.WriteFile(corrupt, []byte("corrupt"), 0666))
+
+	_, err = Restore(RestoreOptions{
+		FilesystemOptions: fs.NewOptions().SetFilePathPrefix(restoreDir),
+		Store:             store,
+	})
+	require.Error(t, err)
+
+	_, err = os.Stat(filepath.Join(restoreDir, filepath.FromSlash(manifest.Files[0].Path)))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestRestoreWithoutManifest(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	_, err := Restore(RestoreOptions{
+		FilesystemOptions: fs.NewOptions().SetFilePathPrefix(dir),
+		Store:             NewDirectoryStore(dir),
+	})
+	require.Error(t, err)
+}
+
+func TestDirectoryStorePutGet(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	store := NewDirectoryStore(dir)
+	_, err := store.Get("a/b")
+	require.Equal(t, ErrNotFound, err)
+
+	require.NoError(t, store.Put("a/b", bytes.NewReader([]byte("data")), 4))
+	r, err := store.Get("a/b")
+	require.NoError(t, err)
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "data", string(data))
+}
+
+func TestDirectoryStoreDelete(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	store := NewDirectoryStore(dir)
+	require.NoError(t, store.Put("a/b", bytes.NewReader([]byte("data")), 4))
+	require.NoError(t, store.(Deleter).Delete("a/b"))
+
+	_, err := store.Get("a/b")
+	require.Equal(t, ErrNotFound, err)
+	require.NoError(t, store.(Deleter).Delete("a/b"))
+}
+
+func TestManifestHasSnapshots(t *testing.T) {
+	manifest := Manifest{Files: []File{{Path: "data/testns/1/fileset-0-0-data.db"}}}
+	require.False(t, manifest.HasSnapshots())
+
+	manifest.Files = append(manifest.Files,
+		File{Path: "snapshots/testns/1/fileset-0-0-data.db"})
+	require.True(t, manifest.HasSnapshots())
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/topology"
+
+	"github.com/gogo/protobuf/jsonpb"
+)
+
+const (
+	// ManifestKey is the key of the manifest in a backup store.
+	ManifestKey = "manifest.json"
+
+	manifestVersion = 1
+)
+
+// Manifest describes the contents of a backup, it is written last so that a
+// backup without a manifest is known to be incomplete.
+type Manifest struct {
+	Version    int             `json:"version"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	Namespaces json.RawMessage `json:"namespaces"`
+	Topology   *Topology       `json:"topology,omitempty"`
+	Files      []File          `json:"files"`
+}
+
+// File is a file included in a backup.
+type File struct {
+	// Path is the path of the file relative to the filesystem path prefix,
+	// it is also the key of the file in the backup store.
+	Path string `json:"path"`
+	// Namespace is the namespace of the file, empty for files that are
+	// shared between namespaces such as snapshot metadata.
+	Namespace string `json:"namespace,omitempty"`
+	// Shard is the shard of the file, nil for files that are not sharded.
+	Shard    *uint32 `json:"shard,omitempty"`
+	Size     int64   `json:"size"`
+	Checksum uint32  `json:"checksum"`
+}
+
+// Topology is the placement of shards at the time of a backup.
+type Topology struct {
+	Replicas  int    `json:"replicas"`
+	NumShards int    `json:"numShards"`
+	Hosts     []Host `json:"hosts"`
+}
+
+// Host is a host and the shards it owned at the time of a backup.
+type Host struct {
+	ID      string   `json:"id"`
+	Address string   `json:"address"`
+	Shards  []uint32 `json:"shards"`
+}
+
+// NewTopology returns the backup topology of a topology map.
+func NewTopology(m topology.Map) *Topology {
+	t := &Topology{
+		Replicas:  m.Replicas(),
+		NumShards: len(m.ShardSet().AllIDs()),
+	}
+	for _, hostShardSet := range m.HostShardSets() {
+		host := hostShardSet.Host()
+		t.Hosts = append(t.Hosts, Host{
+			ID:      host.ID(),
+			Address: host.Address(),
+			Shards:  hostShardSet.ShardSet().AllIDs(),
+		})
+	}
+	return t
+}
+
+// NamespaceMap returns the namespaces included in the backup.
+func (m Manifest) NamespaceMap() (namespace.Map, error) {
+	var registry nsproto.Registry
+	if err := jsonpb.Unmarshal(bytes.NewReader(m.Namespaces), &registry); err != nil {
+		return nil, err
+	}
+	return namespace.FromProto(registry)
+}
+
+// HasSnapshots returns true if the backup contains snapshot filesets, which
+// hold the data of blocks that were not yet flushed when the backup was taken
+// and are only loaded by the commitlog bootstrapper.
+func (m Manifest) HasSnapshots() bool {
+	snapshotsDir := fs.SnapshotDirPath("") + "/"
+	for _, f := range m.Files {
+		if strings.HasPrefix(f.Path, snapshotsDir) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Manifest) setNamespaces(metadatas []namespace.Metadata) error {
+	nsMap, err := namespace.NewMap(metadatas)
+	if err != nil {
+		return err
+	}
+	registry, err := namespace.ToProto(nsMap)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{}).Marshal(&buf, registry); err != nil {
+		return err
+	}
+	m.Namespaces = buf.Bytes()
+	return nil
+}
+
+// ReadManifest reads the manifest of a backup.
+func ReadManifest(store Store) (Manifest, error) {
+	r, err := store.Get(ManifestKey)
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Manifest{}, err
+	}
+	if m.Version != manifestVersion {
+		return Manifest{}, fmt.Errorf("unsupported backup manifest version: %d", m.Version)
+	}
+	return m, nil
+}
+
+func writeManifest(store Store, m Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return store.Put(ManifestKey, bytes.NewReader(data), int64(len(data)))
+}
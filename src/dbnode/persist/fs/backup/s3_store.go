@@ -0,0 +1,265 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3DefaultRegion    = "us-east-1"
+	s3SigningAlgorithm = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload  = "UNSIGNED-PAYLOAD"
+	s3DateFormat       = "20060102"
+	s3TimeFormat       = "20060102T150405Z"
+)
+
+var (
+	errS3EndpointNotSet = errors.New("s3 endpoint not set")
+	errS3BucketNotSet   = errors.New("s3 bucket not set")
+)
+
+// S3Options are the options for a store backed by an S3 compatible object
+// store, such as AWS S3 or MinIO.
+type S3Options struct {
+	// Endpoint is the base URL of the object store, e.g. http://minio:9000.
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+
+	// Bucket is the bucket that backups are written to.
+	Bucket string `json:"bucket" yaml:"bucket"`
+
+	// Prefix is prepended to the keys of all objects.
+	Prefix string `json:"prefix" yaml:"prefix"`
+
+	// Region is the region used to sign requests, defaults to us-east-1.
+	Region string `json:"region" yaml:"region"`
+
+	// AccessKeyID is the access key used to sign requests, requests are
+	// sent unsigned if not set.
+	AccessKeyID string `json:"accessKeyID" yaml:"accessKeyID"`
+
+	// SecretAccessKey is the secret key used to sign requests.
+	SecretAccessKey string `json:"secretAccessKey" yaml:"secretAccessKey"`
+}
+
+// Validate validates the options.
+func (o S3Options) Validate() error {
+	if o.Endpoint == "" {
+		return errS3EndpointNotSet
+	}
+	if o.Bucket == "" {
+		return errS3BucketNotSet
+	}
+	_, err := url.Parse(o.Endpoint)
+	return err
+}
+
+type s3Store struct {
+	opts     S3Options
+	endpoint *url.URL
+	client   *http.Client
+	nowFn    func() time.Time
+}
+
+// NewS3Store returns a Store that writes objects to an S3 compatible object
+// store using path style requests signed with AWS signature version 4.
+func NewS3Store(opts S3Options, client *http.Client) (Store, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	endpoint, err := url.Parse(opts.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Region == "" {
+		opts.Region = s3DefaultRegion
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &s3Store{
+		opts:     opts,
+		endpoint: endpoint,
+		client:   client,
+		nowFn:    time.Now,
+	}, nil
+}
+
+func (s *s3Store) Put(key string, r io.Reader, size int64) error {
+	req, err := s.newRequest(http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return s3ResponseError(resp, key)
+	}
+	_, err = io.Copy(ioutil.Discard, resp.Body)
+	return err
+}
+
+func (s *s3Store) Get(key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		return nil, s3ResponseError(resp, key)
+	}
+	return resp.Body, nil
+}
+
+func (s *s3Store) Delete(key string) error {
+	req, err := s.newRequest(http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound && resp.StatusCode/100 != 2 {
+		return s3ResponseError(resp, key)
+	}
+	_, err = io.Copy(ioutil.Discard, resp.Body)
+	return err
+}
+
+func (s *s3Store) newRequest(method, key string, body io.Reader) (*http.Request, error) {
+	u := *s.endpoint
+	u.Path = "/" + path.Join(strings.Trim(u.Path, "/"), s.opts.Bucket,
+		s.opts.Prefix, key)
+	u.Path = path.Clean(u.Path)
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req)
+	return req, nil
+}
+
+// sign signs the request with AWS signature version 4, the payload is left
+// unsigned so that files can be streamed without reading them twice.
+func (s *s3Store) sign(req *http.Request) {
+	var (
+		now       = s.nowFn().UTC()
+		amzDate   = now.Format(s3TimeFormat)
+		shortDate = now.Format(s3DateFormat)
+	)
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", s3UnsignedPayload)
+	if s.opts.AccessKeyID == "" {
+		return
+	}
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": s3UnsignedPayload,
+		"x-amz-date":           amzDate,
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name)
+		canonicalHeaders.WriteString(":")
+		canonicalHeaders.WriteString(headers[name])
+		canonicalHeaders.WriteString("\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	scope := strings.Join([]string{shortDate, s.opts.Region, "s3", "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3SigningAlgorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.opts.SecretAccessKey), shortDate)
+	key = hmacSHA256(key, s.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigningAlgorithm, s.opts.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func s3ResponseError(resp *http.Response, key string) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("s3 request for %s failed with status %d: %s",
+		key, resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeObjectStore is a minimal in memory stand in for an S3 compatible
+// object store that supports path style PUT, GET and DELETE requests.
+type fakeObjectStore struct {
+	sync.Mutex
+	objects map[string][]byte
+	auth    []string
+}
+
+func newFakeObjectStore() *fakeObjectStore {
+	return &fakeObjectStore{objects: make(map[string][]byte)}
+}
+
+func (s *fakeObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.auth = append(s.auth, r.Header.Get("Authorization"))
+	switch r.Method {
+	case http.MethodPut:
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.objects[r.URL.Path] = data
+	case http.MethodGet:
+		data, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestS3Store(t *testing.T) (Store, *fakeObjectStore, func()) {
+	fake := newFakeObjectStore()
+	server := httptest.NewServer(fake)
+
+	store, err := NewS3Store(S3Options{
+		Endpoint:        server.URL,
+		Bucket:          "backups",
+		Prefix:          "cluster-a",
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+	}, server.Client())
+	require.NoError(t, err)
+	return store, fake, server.Close
+}
+
+func TestS3StorePutGet(t *testing.T) {
+	store, fake, closer := newTestS3Store(t)
+	defer closer()
+
+	_, err := store.Get("missing")
+	require.Equal(t, ErrNotFound, err)
+
+	require.NoError(t, store.Put("data/ns/1/file", bytes.NewReader([]byte("data")), 4))
+	require.Contains(t, fake.objects, "/backups/cluster-a/data/ns/1/file")
+
+	r, err := store.Get("data/ns/1/file")
+	require.NoError(t, err)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "data", string(data))
+
+	for _, auth := range fake.auth {
+		require.True(t, strings.HasPrefix(auth,
+			"AWS4-HMAC-SHA256 Credential=access/"), auth)
+		require.Contains(t, auth, "/us-east-1/s3/aws4_request")
+	}
+}
+
+func TestS3StoreDelete(t *testing.T) {
+	store, fake, closer := newTestS3Store(t)
+	defer closer()
+
+	require.NoError(t, store.Put("data/ns/1/file", bytes.NewReader([]byte("data")), 4))
+	require.NoError(t, store.(Deleter).Delete("data/ns/1/file"))
+	require.NotContains(t, fake.objects, "/backups/cluster-a/data/ns/1/file")
+
+	_, err := store.Get("data/ns/1/file")
+	require.Equal(t, ErrNotFound, err)
+
+	// Deleting a key that no longer exists is not an error.
+	require.NoError(t, store.(Deleter).Delete("data/ns/1/file"))
+}
+
+func TestS3StoreValidate(t *testing.T) {
+	_, err := NewS3Store(S3Options{Bucket: "backups"}, nil)
+	require.Error(t, err)
+	_, err = NewS3Store(S3Options{Endpoint: "http://localhost:9000"}, nil)
+	require.Error(t, err)
+}
+
+func TestBackupAndRestoreS3(t *testing.T) {
+	var (
+		srcDir     = newTestDir(t)
+		restoreDir = newTestDir(t)
+		md         = newTestNamespace(t)
+		srcOpts    = fs.NewOptions().SetFilePathPrefix(srcDir)
+	)
+	defer os.RemoveAll(srcDir)
+	defer os.RemoveAll(restoreDir)
+
+	store, fake, closer := newTestS3Store(t)
+	defer closer()
+
+	writeTestFileSet(t, srcOpts, md, 1, map[string][]byte{"foo": {1, 2, 3}})
+
+	manifest, err := Backup(BackupOptions{
+		FilesystemOptions: srcOpts,
+		Namespaces:        []namespace.Metadata{md},
+		Store:             store,
+	})
+	require.NoError(t, err)
+	require.Contains(t, fake.objects, "/backups/cluster-a/"+ManifestKey)
+	require.Len(t, fake.objects, len(manifest.Files)+1)
+
+	restoreOpts := fs.NewOptions().SetFilePathPrefix(restoreDir)
+	_, err = Restore(RestoreOptions{
+		FilesystemOptions: restoreOpts,
+		Store:             store,
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"foo": {1, 2, 3}},
+		readTestFileSet(t, restoreOpts, md, 1))
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package backup provides point in time backups of namespace filesets and
+// restoring them to the filesystem of a node.
+package backup
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	storeDirPerm  = 0755
+	storeFilePerm = 0666
+)
+
+// ErrNotFound is returned by a Store when a key does not exist.
+var ErrNotFound = errors.New("backup: key not found")
+
+// Store is a destination that backups are written to and restored from, keys
+// are slash separated paths relative to the root of the backup.
+type Store interface {
+	// Put writes the contents of the reader to the key.
+	Put(key string, r io.Reader, size int64) error
+
+	// Get returns a reader for the contents of the key, ErrNotFound is
+	// returned if the key does not exist.
+	Get(key string) (io.ReadCloser, error)
+}
+
+// Linker is implemented by stores that can hard link local files rather than
+// copying them.
+type Linker interface {
+	// Link hard links the file at path to the key, an error is returned if
+	// the file cannot be linked in which case callers fall back to Put.
+	Link(key string, path string) error
+}
+
+// Deleter is implemented by stores that can delete keys.
+type Deleter interface {
+	// Delete deletes the key, deleting a key that does not exist is not an
+	// error.
+	Delete(key string) error
+}
+
+type directoryStore struct {
+	dir string
+}
+
+// NewDirectoryStore returns a Store that writes to a local directory. Files
+// on the same device are hard linked instead of copied.
+func NewDirectoryStore(dir string) Store {
+	return &directoryStore{dir: dir}
+}
+
+func (s *directoryStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
+
+func (s *directoryStore) Put(key string, r io.Reader, _ int64) error {
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), storeDirPerm); err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename so that an interrupted backup
+	// never leaves a partially written file at the key.
+	tmpPath := path + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, storeFilePerm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func (s *directoryStore) Get(key string) (io.ReadCloser, error) {
+	f, err := os.Open(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *directoryStore) Link(key string, path string) error {
+	target := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(target), storeDirPerm); err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Link(path, target)
+}
+
+func (s *directoryStore) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
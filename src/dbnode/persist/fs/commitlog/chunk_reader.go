@@ -22,10 +22,12 @@ package commitlog
 
 import (
 	"bufio"
+	"errors"
 	"io"
 	"os"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 )
 
 const (
@@ -37,19 +39,23 @@ const (
 	checksumDataEnd   = checksumDataStart + chunkHeaderChecksumDataLen
 )
 
+var errCommitLogReaderCompressedChunkEmpty = errors.New("commit log reader encountered empty compressed chunk")
+
 type chunkReader struct {
-	fd                 *os.File
-	buffer             *bufio.Reader
-	chunkData          []byte
-	chunkDataRemaining int
-	charBuff           []byte
+	fd                  *os.File
+	buffer              *bufio.Reader
+	chunkData           []byte
+	chunkDataRemaining  int
+	compressedChunkData []byte
+	charBuff            []byte
 }
 
 func newChunkReader(bufferLen int) *chunkReader {
 	return &chunkReader{
-		buffer:    bufio.NewReaderSize(nil, bufferLen),
-		chunkData: make([]byte, bufferLen),
-		charBuff:  make([]byte, 1),
+		buffer:              bufio.NewReaderSize(nil, bufferLen),
+		chunkData:           make([]byte, bufferLen),
+		compressedChunkData: make([]byte, bufferLen),
+		charBuff:            make([]byte, 1),
 	}
 }
 
@@ -83,40 +89,88 @@ func (r *chunkReader) readHeader() error {
 		return err
 	}
 
+	// Chunks written with compression enabled are flagged in their size and
+	// are loaded into a separate buffer to be decompressed into chunk data,
+	// which allows reading commit logs written with or without compression.
+	compressed := size&chunkHeaderCompressedFlag != 0
+	size &^= chunkHeaderCompressedFlag
+
 	// Setup a chunk data buffer so that chunk data can be loaded into it.
 	chunkDataSize := int(size)
-	if chunkDataSize > cap(r.chunkData) {
-		// Increase chunkData capacity so that it can fit the new chunkData.
-		chunkDataCap := cap(r.chunkData)
-		for chunkDataCap < chunkDataSize {
-			chunkDataCap *= 2
-		}
-		r.chunkData = make([]byte, chunkDataSize, chunkDataCap)
+	if compressed {
+		r.compressedChunkData = resizeChunkBuffer(r.compressedChunkData, chunkDataSize)
 	} else {
-		// Reuse existing chunk data buffer if possible.
-		r.chunkData = r.chunkData[:chunkDataSize]
+		r.chunkData = resizeChunkBuffer(r.chunkData, chunkDataSize)
+	}
+	readData := r.chunkData
+	if compressed {
+		readData = r.compressedChunkData
 	}
 
 	// To validate checksum of chunk data all the chunk data needs to be loaded into memory at once. Chunk data size is // not bounded to the flush size so peeking chunk data in order to compute checksum may result in bufio's buffer
 	// full error. To circumnavigate this issue load the chunk data into chunk reader's buffer to compute checksum
 	// instead of trying to compute checksum off of fixed size r.buffer by peeking.
 	// See https://github.com/m3db/m3/pull/2148 for details.
-	_, err = io.ReadFull(r.buffer, r.chunkData)
+	_, err = io.ReadFull(r.buffer, readData)
 	if err != nil {
 		return err
 	}
 
 	// Verify data checksum
-	if digest.Checksum(r.chunkData) != checksumData {
+	if digest.Checksum(readData) != checksumData {
 		return errCommitLogReaderChunkSizeChecksumMismatch
 	}
 
+	if compressed {
+		if err := r.decompressChunkData(); err != nil {
+			return err
+		}
+	}
+
 	// Set remaining data to be consumed
-	r.chunkDataRemaining = int(size)
+	r.chunkDataRemaining = len(r.chunkData)
 
 	return nil
 }
 
+func (r *chunkReader) decompressChunkData() error {
+	if len(r.compressedChunkData) == 0 {
+		return errCommitLogReaderCompressedChunkEmpty
+	}
+
+	compressionType := compression.Type(r.compressedChunkData[0])
+	if err := compressionType.Validate(); err != nil {
+		return err
+	}
+
+	compressed := r.compressedChunkData[1:]
+	decompressedLen, err := compressionType.DecompressedLen(compressed)
+	if err != nil {
+		return err
+	}
+
+	r.chunkData = resizeChunkBuffer(r.chunkData, decompressedLen)
+	r.chunkData, err = compressionType.Decompress(r.chunkData, compressed)
+	return err
+}
+
+func resizeChunkBuffer(buff []byte, size int) []byte {
+	if size <= cap(buff) {
+		// Reuse existing chunk data buffer if possible.
+		return buff[:size]
+	}
+
+	// Increase capacity so that it can fit the new chunk data.
+	newCap := cap(buff)
+	if newCap == 0 {
+		newCap = size
+	}
+	for newCap < size {
+		newCap *= 2
+	}
+	return make([]byte, size, newCap)
+}
+
 func (r *chunkReader) Read(p []byte) (int, error) {
 	size := len(p)
 	read := 0
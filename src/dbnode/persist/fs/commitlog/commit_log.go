@@ -182,6 +182,7 @@ type commitLogMetrics struct {
 	closeErrors      tally.Counter
 	flushErrors      tally.Counter
 	flushDone        tally.Counter
+	groupCommits     tally.Counter
 }
 
 type eventType int
@@ -192,6 +193,7 @@ const (
 	flushEventType
 	activeLogsEventType
 	rotateLogsEventType
+	groupCommitEventType
 )
 
 type callbackFn func(callbackResult)
@@ -280,6 +282,7 @@ func NewCommitLog(opts Options) (CommitLog, error) {
 			closeErrors:      scope.Counter("writes.close-errors"),
 			flushErrors:      scope.Counter("writes.flush-errors"),
 			flushDone:        scope.Counter("writes.flush-done"),
+			groupCommits:     scope.Counter("writes.group-commits"),
 		},
 	}
 	// Setup backreferences for onFlush().
@@ -287,7 +290,7 @@ func NewCommitLog(opts Options) (CommitLog, error) {
 	commitLog.writerState.secondary.commitlog = commitLog
 
 	switch opts.Strategy() {
-	case StrategyWriteWait:
+	case StrategyWriteWait, StrategyGroupCommit:
 		commitLog.writeFn = commitLog.writeWait
 	default:
 		commitLog.writeFn = commitLog.writeBehind
@@ -325,6 +328,11 @@ func (l *commitLog) Open() error {
 		go l.flushEvery(flushInterval)
 	}
 
+	if l.opts.Strategy() == StrategyGroupCommit {
+		// Group commit pending writes at least as often as the max latency
+		go l.groupCommitEvery(l.opts.GroupCommitMaxLatency())
+	}
+
 	return nil
 }
 
@@ -446,6 +454,34 @@ func (l *commitLog) flushEvery(interval time.Duration) {
 	}
 }
 
+func (l *commitLog) groupCommitEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		l.closedState.RLock()
+		if l.closedState.closed {
+			l.closedState.RUnlock()
+			return
+		}
+
+		l.writes <- commitLogWrite{eventType: groupCommitEventType}
+		l.closedState.RUnlock()
+	}
+}
+
+// groupCommit flushes and fsyncs the primary writer if it has writes pending
+// acknowledgement, which are acknowledged once the writer is fsync'd.
+func (l *commitLog) groupCommit() {
+	if len(l.writerState.primary.pendingFlushFns) == 0 {
+		return
+	}
+
+	// Errors are handled by the flush callback of the writer.
+	l.writerState.primary.writer.Flush(true)
+	l.metrics.groupCommits.Inc(1)
+}
+
 func (l *commitLog) write() {
 	// We use these to make the batch and non-batched write paths the same
 	// by turning non-batched writes into a batch of size one while avoiding
@@ -453,12 +489,22 @@ func (l *commitLog) write() {
 	var singleBatch = make([]writes.BatchWrite, 1)
 	var batch []writes.BatchWrite
 
+	var groupCommitBatchSize int
+	if l.opts.Strategy() == StrategyGroupCommit {
+		groupCommitBatchSize = l.opts.GroupCommitBatchSize()
+	}
+
 	for write := range l.writes {
 		if write.eventType == flushEventType {
 			l.writerState.primary.writer.Flush(false)
 			continue
 		}
 
+		if write.eventType == groupCommitEventType {
+			l.groupCommit()
+			continue
+		}
+
 		if write.eventType == activeLogsEventType {
 			write.callbackFn(callbackResult{
 				eventType: write.eventType,
@@ -547,6 +593,11 @@ func (l *commitLog) write() {
 
 		atomic.AddInt64(&l.numWritesInQueue, int64(-numDequeued))
 		l.metrics.success.Inc(numWritesSuccess)
+
+		if groupCommitBatchSize > 0 &&
+			len(l.writerState.primary.pendingFlushFns) >= groupCommitBatchSize {
+			l.groupCommit()
+		}
 	}
 
 	// Ensure that there is no active background goroutine in the middle of reseting
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/ts/writes"
@@ -447,6 +448,90 @@ func (mr *MockOptionsMockRecorder) Strategy() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Strategy", reflect.TypeOf((*MockOptions)(nil).Strategy))
 }
 
+// SetGroupCommitBatchSize mocks base method
+func (m *MockOptions) SetGroupCommitBatchSize(value int) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGroupCommitBatchSize", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetGroupCommitBatchSize indicates an expected call of SetGroupCommitBatchSize
+func (mr *MockOptionsMockRecorder) SetGroupCommitBatchSize(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGroupCommitBatchSize", reflect.TypeOf((*MockOptions)(nil).SetGroupCommitBatchSize), value)
+}
+
+// GroupCommitBatchSize mocks base method
+func (m *MockOptions) GroupCommitBatchSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupCommitBatchSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GroupCommitBatchSize indicates an expected call of GroupCommitBatchSize
+func (mr *MockOptionsMockRecorder) GroupCommitBatchSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupCommitBatchSize", reflect.TypeOf((*MockOptions)(nil).GroupCommitBatchSize))
+}
+
+// SetGroupCommitMaxLatency mocks base method
+func (m *MockOptions) SetGroupCommitMaxLatency(value time.Duration) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGroupCommitMaxLatency", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetGroupCommitMaxLatency indicates an expected call of SetGroupCommitMaxLatency
+func (mr *MockOptionsMockRecorder) SetGroupCommitMaxLatency(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGroupCommitMaxLatency", reflect.TypeOf((*MockOptions)(nil).SetGroupCommitMaxLatency), value)
+}
+
+// GroupCommitMaxLatency mocks base method
+func (m *MockOptions) GroupCommitMaxLatency() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupCommitMaxLatency")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GroupCommitMaxLatency indicates an expected call of GroupCommitMaxLatency
+func (mr *MockOptionsMockRecorder) GroupCommitMaxLatency() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupCommitMaxLatency", reflect.TypeOf((*MockOptions)(nil).GroupCommitMaxLatency))
+}
+
+// SetCompression mocks base method
+func (m *MockOptions) SetCompression(value compression.Type) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCompression", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetCompression indicates an expected call of SetCompression
+func (mr *MockOptionsMockRecorder) SetCompression(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCompression", reflect.TypeOf((*MockOptions)(nil).SetCompression), value)
+}
+
+// Compression mocks base method
+func (m *MockOptions) Compression() compression.Type {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compression")
+	ret0, _ := ret[0].(compression.Type)
+	return ret0
+}
+
+// Compression indicates an expected call of Compression
+func (mr *MockOptionsMockRecorder) Compression() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compression", reflect.TypeOf((*MockOptions)(nil).Compression))
+}
+
 // SetFlushInterval mocks base method
 func (m *MockOptions) SetFlushInterval(value time.Duration) Options {
 	m.ctrl.T.Helper()
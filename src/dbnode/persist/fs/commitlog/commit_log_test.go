@@ -35,6 +35,7 @@ import (
 
 	"github.com/m3db/bitset"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/ts/writes"
@@ -1132,3 +1133,104 @@ func TestCommitLogBatchWriteDoesNotAddErroredOrSkippedSeries(t *testing.T) {
 	assertCommitLogWritesByIterating(t, commitLog, expected)
 	require.Equal(t, 1, finalized)
 }
+
+func countCommitLogEntries(t *testing.T, opts Options) int {
+	iter, corruptFiles, err := NewIterator(IteratorOpts{
+		CommitLogOptions:    opts,
+		FileFilterPredicate: ReadAllPredicate(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(corruptFiles))
+	defer iter.Close()
+
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	require.NoError(t, iter.Err())
+	return count
+}
+
+func TestCommitLogCompression(t *testing.T) {
+	for _, compressionType := range []compression.Type{
+		compression.SnappyType,
+		compression.DeflateType,
+	} {
+		t.Run(compressionType.String(), func(t *testing.T) {
+			opts, scope := newTestOptions(t, overrides{
+				strategy: StrategyWriteWait,
+			})
+			defer cleanup(t, opts)
+
+			// Write an uncompressed commit log first to ensure that commit logs
+			// written before compression was enabled are still readable.
+			uncompressedWrites := []testWrite{
+				{testSeries(t, opts, 0, "foo.bar", testTags1, 127), time.Now(), 123.456, xtime.Second, nil, nil},
+				{testSeries(t, opts, 1, "foo.baz", testTags2, 150), time.Now(), 456.789, xtime.Second, []byte{1, 2, 3}, nil},
+			}
+			commitLog := newTestCommitLog(t, opts)
+			writeCommitLogs(t, scope, commitLog, uncompressedWrites).Wait()
+			require.NoError(t, commitLog.Close())
+
+			opts = opts.SetCompression(compressionType)
+			compressedWrites := []testWrite{
+				{testSeries(t, opts, 0, "foo.bar", testTags1, 127), time.Now(), 789.123, xtime.Second, nil, nil},
+				{testSeries(t, opts, 1, "foo.qux", testTags3, 291), time.Now(), 321.654, xtime.Second, bytes.Repeat([]byte{4}, 2*opts.FlushSize()), nil},
+			}
+			commitLog = newTestCommitLog(t, opts)
+			writeCommitLogs(t, scope, commitLog, compressedWrites).Wait()
+			require.NoError(t, commitLog.Close())
+
+			// Ensure the repetitive annotation was compressed.
+			compressedFile := commitLog.writerState.activeFiles[0]
+			info, err := os.Stat(compressedFile.FilePath)
+			require.NoError(t, err)
+			require.True(t, info.Size() < int64(opts.FlushSize()))
+
+			allWrites := append(uncompressedWrites, compressedWrites...)
+			assertCommitLogWritesByIterating(t, commitLog, allWrites)
+			require.Equal(t, len(allWrites), countCommitLogEntries(t, opts))
+		})
+	}
+}
+
+func TestCommitLogGroupCommit(t *testing.T) {
+	flushInterval := time.Hour
+	opts, scope := newTestOptions(t, overrides{
+		strategy:      StrategyGroupCommit,
+		flushInterval: &flushInterval,
+	})
+	opts = opts.
+		SetGroupCommitBatchSize(2).
+		SetGroupCommitMaxLatency(10 * time.Millisecond)
+	defer cleanup(t, opts)
+
+	commitLog := newTestCommitLog(t, opts)
+
+	writes := []testWrite{
+		{testSeries(t, opts, 0, "foo.bar", testTags1, 127), time.Now(), 123.456, xtime.Millisecond, nil, nil},
+		{testSeries(t, opts, 1, "foo.baz", testTags2, 150), time.Now(), 456.789, xtime.Millisecond, nil, nil},
+		{testSeries(t, opts, 2, "foo.qux", testTags3, 291), time.Now(), 789.123, xtime.Millisecond, nil, nil},
+	}
+
+	// Writes are acknowledged once group committed, the last write once the
+	// max latency elapses since it does not fill a batch.
+	writeCommitLogs(t, scope, commitLog, writes).Wait()
+
+	groupCommits, ok := snapshotCounterValue(scope, "commitlog.writes.group-commits")
+	require.True(t, ok)
+	require.True(t, groupCommits.Value() >= 1)
+
+	require.NoError(t, commitLog.Close())
+
+	assertCommitLogWritesByIterating(t, commitLog, writes)
+	require.Equal(t, len(writes), countCommitLogEntries(t, opts))
+}
+
+func TestCommitLogOptionsValidateGroupCommit(t *testing.T) {
+	opts := NewOptions().SetStrategy(StrategyGroupCommit)
+	require.NoError(t, opts.Validate())
+	require.Error(t, opts.SetGroupCommitBatchSize(0).Validate())
+	require.Error(t, opts.SetGroupCommitMaxLatency(0).Validate())
+	require.Error(t, opts.SetCompression(compression.Type(-1)).Validate())
+}
@@ -26,6 +26,7 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/ident"
@@ -37,6 +38,14 @@ const (
 	// defaultStrategy is the default commit log write strategy
 	defaultStrategy = StrategyWriteBehind
 
+	// defaultGroupCommitBatchSize is the default number of pending writes
+	// that triggers a group commit
+	defaultGroupCommitBatchSize = 1024
+
+	// defaultGroupCommitMaxLatency is the default max time a write waits
+	// for a group commit
+	defaultGroupCommitMaxLatency = 10 * time.Millisecond
+
 	// defaultFlushInterval is the default commit log flush interval
 	defaultFlushInterval = time.Second
 
@@ -66,6 +75,9 @@ var (
 	errFlushIntervalNonNegative = errors.New("flush interval must be non-negative")
 	errBlockSizePositive        = errors.New("block size must be a positive duration")
 	errReadConcurrencyPositive  = errors.New("read concurrency must be a positive integer")
+
+	errGroupCommitBatchSizePositive  = errors.New("group commit batch size must be a positive integer")
+	errGroupCommitMaxLatencyPositive = errors.New("group commit max latency must be a positive duration")
 )
 
 type options struct {
@@ -74,6 +86,9 @@ type options struct {
 	blockSize               time.Duration
 	fsOpts                  fs.Options
 	strategy                Strategy
+	groupCommitBatchSize    int
+	groupCommitMaxLatency   time.Duration
+	compression             compression.Type
 	flushSize               int
 	flushInterval           time.Duration
 	backlogQueueSize        int
@@ -91,6 +106,8 @@ func NewOptions() Options {
 		blockSize:               defaultBlockSize,
 		fsOpts:                  fs.NewOptions(),
 		strategy:                defaultStrategy,
+		groupCommitBatchSize:    defaultGroupCommitBatchSize,
+		groupCommitMaxLatency:   defaultGroupCommitMaxLatency,
 		flushSize:               defaultFlushSize,
 		flushInterval:           defaultFlushInterval,
 		backlogQueueSize:        defaultBacklogQueueSize,
@@ -118,6 +135,19 @@ func (o *options) Validate() error {
 		return errReadConcurrencyPositive
 	}
 
+	if o.Strategy() == StrategyGroupCommit {
+		if o.GroupCommitBatchSize() <= 0 {
+			return errGroupCommitBatchSizePositive
+		}
+		if o.GroupCommitMaxLatency() <= 0 {
+			return errGroupCommitMaxLatencyPositive
+		}
+	}
+
+	if err := o.Compression().Validate(); err != nil {
+		return err
+	}
+
 	if float64(o.BacklogQueueSize())/float64(o.BacklogQueueChannelSize()) > MaximumQueueSizeQueueChannelSizeRatio {
 		return fmt.Errorf(
 			"BacklogQueueSize / BacklogQueueChannelSize ratio must be at most: %f, but was: %f",
@@ -177,6 +207,36 @@ func (o *options) Strategy() Strategy {
 	return o.strategy
 }
 
+func (o *options) SetGroupCommitBatchSize(value int) Options {
+	opts := *o
+	opts.groupCommitBatchSize = value
+	return &opts
+}
+
+func (o *options) GroupCommitBatchSize() int {
+	return o.groupCommitBatchSize
+}
+
+func (o *options) SetGroupCommitMaxLatency(value time.Duration) Options {
+	opts := *o
+	opts.groupCommitMaxLatency = value
+	return &opts
+}
+
+func (o *options) GroupCommitMaxLatency() time.Duration {
+	return o.groupCommitMaxLatency
+}
+
+func (o *options) SetCompression(value compression.Type) Options {
+	opts := *o
+	opts.compression = value
+	return &opts
+}
+
+func (o *options) Compression() compression.Type {
+	return o.compression
+}
+
 func (o *options) SetFlushSize(value int) Options {
 	opts := *o
 	opts.flushSize = value
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/ts/writes"
@@ -48,6 +49,13 @@ const (
 	// for the buffered commit log chunk that contains a write to flush
 	// before acknowledging a write
 	StrategyWriteBehind
+
+	// StrategyGroupCommit describes the strategy that waits for the
+	// commit log chunk that contains a write to be fsync'd before
+	// acknowledging a write, fsyncing the pending writes as a group once
+	// there are group commit batch size writes pending or the group commit
+	// max latency has elapsed
+	StrategyGroupCommit
 )
 
 // CommitLog provides a synchronized commit log
@@ -178,6 +186,28 @@ type Options interface {
 	// Strategy returns the strategy.
 	Strategy() Strategy
 
+	// SetGroupCommitBatchSize sets the number of pending writes that
+	// triggers a group commit when using the group commit strategy.
+	SetGroupCommitBatchSize(value int) Options
+
+	// GroupCommitBatchSize returns the number of pending writes that
+	// triggers a group commit when using the group commit strategy.
+	GroupCommitBatchSize() int
+
+	// SetGroupCommitMaxLatency sets the max time a write waits for a group
+	// commit when using the group commit strategy.
+	SetGroupCommitMaxLatency(value time.Duration) Options
+
+	// GroupCommitMaxLatency returns the max time a write waits for a group
+	// commit when using the group commit strategy.
+	GroupCommitMaxLatency() time.Duration
+
+	// SetCompression sets the compression applied to commit log chunks.
+	SetCompression(value compression.Type) Options
+
+	// Compression returns the compression applied to commit log chunks.
+	Compression() compression.Type
+
 	// SetFlushInterval sets the flush interval.
 	SetFlushInterval(value time.Duration) Options
 
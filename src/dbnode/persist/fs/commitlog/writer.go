@@ -30,6 +30,7 @@ import (
 	"github.com/m3db/bitset"
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
@@ -51,6 +52,10 @@ const (
 		chunkHeaderChecksumSizeLen +
 		chunkHeaderChecksumDataLen
 
+	// chunkHeaderCompressedFlag is set in the size of compressed chunks, the
+	// data of which is prefixed with a single byte of the compression type.
+	chunkHeaderCompressedFlag = 1 << 31
+
 	defaultBitSetLength = 65536
 
 	defaultEncoderBuffSize = 16384
@@ -114,14 +119,12 @@ func newCommitLogWriter(
 	flushFn flushFn,
 	opts Options,
 ) commitLogWriter {
-	shouldFsync := opts.Strategy() == StrategyWriteWait
-
 	return &writer{
 		filePathPrefix:      opts.FilesystemOptions().FilePathPrefix(),
 		newFileMode:         opts.FilesystemOptions().NewFileMode(),
 		newDirectoryMode:    opts.FilesystemOptions().NewDirectoryMode(),
 		nowFn:               opts.ClockOptions().NowFn(),
-		chunkWriter:         newChunkWriter(flushFn, shouldFsync),
+		chunkWriter:         newChunkWriter(flushFn, opts),
 		chunkReserveHeader:  make([]byte, chunkHeaderLen),
 		buffer:              bufio.NewWriterSize(nil, opts.FlushSize()),
 		sizeBuffer:          make([]byte, binary.MaxVarintLen64),
@@ -290,17 +293,25 @@ func (w *writer) write(data []byte) error {
 }
 
 type fsChunkWriter struct {
-	fd      xos.File
-	flushFn flushFn
-	buff    []byte
-	fsync   bool
+	fd           xos.File
+	flushFn      flushFn
+	buff         []byte
+	compressBuff []byte
+	compression  compression.Type
+	// fsync is set when each chunk is fsync'd as it is written.
+	fsync bool
+	// flushOnSync is set when the flush callback is fired once chunks are
+	// fsync'd rather than when they are written.
+	flushOnSync bool
 }
 
-func newChunkWriter(flushFn flushFn, fsync bool) chunkWriter {
+func newChunkWriter(flushFn flushFn, opts Options) chunkWriter {
 	return &fsChunkWriter{
-		flushFn: flushFn,
-		buff:    make([]byte, chunkHeaderLen),
-		fsync:   fsync,
+		flushFn:     flushFn,
+		buff:        make([]byte, chunkHeaderLen),
+		compression: opts.Compression(),
+		fsync:       opts.Strategy() == StrategyWriteWait,
+		flushOnSync: opts.Strategy() == StrategyGroupCommit,
 	}
 }
 
@@ -319,14 +330,38 @@ func (w *fsChunkWriter) isOpen() bool {
 }
 
 func (w *fsChunkWriter) sync() error {
-	return w.fd.Sync()
+	err := w.fd.Sync()
+	if w.flushOnSync {
+		w.flushFn(err)
+	}
+	return err
 }
 
 // Writes a custom header in front of p to a file and returns number of bytes of p successfully written to the file.
 // If the header or p is not fully written to the file, then this method returns number of bytes of p actually written
 // to the file and an error explaining the reason of failure to write fully to the file.
 func (w *fsChunkWriter) Write(p []byte) (int, error) {
-	size := len(p)
+	// Combine buffers to reduce to a single syscall
+	w.buff = w.buff[:chunkHeaderLen]
+	compressed := w.compression != compression.NoneType
+	if compressed {
+		var err error
+		w.compressBuff, err = w.compression.Compress(w.compressBuff, p)
+		if err != nil {
+			w.flushFn(err)
+			return 0, err
+		}
+		w.buff = append(w.buff, byte(w.compression))
+		w.buff = append(w.buff, w.compressBuff...)
+	} else {
+		w.buff = append(w.buff, p...)
+	}
+
+	data := w.buff[chunkHeaderLen:]
+	size := uint32(len(data))
+	if compressed {
+		size |= chunkHeaderCompressedFlag
+	}
 
 	sizeStart, sizeEnd :=
 		0, chunkHeaderSizeLen
@@ -336,11 +371,11 @@ func (w *fsChunkWriter) Write(p []byte) (int, error) {
 		checksumSizeEnd, checksumSizeEnd+chunkHeaderChecksumDataLen
 
 	// Write size
-	endianness.PutUint32(w.buff[sizeStart:sizeEnd], uint32(size))
+	endianness.PutUint32(w.buff[sizeStart:sizeEnd], size)
 
 	// Calculate checksums
 	checksumSize := digest.Checksum(w.buff[sizeStart:sizeEnd])
-	checksumData := digest.Checksum(p)
+	checksumData := digest.Checksum(data)
 
 	// Write checksums
 	digest.
@@ -350,15 +385,16 @@ func (w *fsChunkWriter) Write(p []byte) (int, error) {
 		Buffer(w.buff[checksumDataStart:checksumDataEnd]).
 		WriteDigest(checksumData)
 
-	// Combine buffers to reduce to a single syscall
-	w.buff = append(w.buff[:chunkHeaderLen], p...)
-
 	// Write contents to file descriptor
 	n, err := w.fd.Write(w.buff)
-	// Count bytes successfully written from slice p
-	pBytesWritten := n - chunkHeaderLen
-	if pBytesWritten < 0 {
+	// Count bytes successfully written from slice p, compressed chunks are
+	// only readable once written fully
+	pBytesWritten := len(p)
+	if n < len(w.buff) {
 		pBytesWritten = 0
+		if !compressed && n > chunkHeaderLen {
+			pBytesWritten = n - chunkHeaderLen
+		}
 	}
 
 	if err != nil {
@@ -366,9 +402,14 @@ func (w *fsChunkWriter) Write(p []byte) (int, error) {
 		return pBytesWritten, err
 	}
 
+	if w.flushOnSync {
+		// The flush callback is fired once the chunk is fsync'd
+		return pBytesWritten, nil
+	}
+
 	// Fsync if required to
 	if w.fsync {
-		err = w.sync()
+		err = w.fd.Sync()
 	}
 
 	// Fire flush callback
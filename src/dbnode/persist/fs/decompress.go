@@ -0,0 +1,64 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"github.com/m3db/m3/src/dbnode/persist/compression"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/pool"
+)
+
+// newDataBytes returns checked bytes of the given size for the data of a
+// series, taken from the bytes pool when one is provided. The returned bytes
+// hold a single reference which the caller is responsible for releasing.
+func newDataBytes(size int, bytesPool pool.CheckedBytesPool) checked.Bytes {
+	if bytesPool == nil {
+		data := checked.NewBytes(make([]byte, size), nil)
+		data.IncRef()
+		return data
+	}
+
+	data := bytesPool.Get(size)
+	data.IncRef()
+	data.Resize(size)
+	return data
+}
+
+// decompressData decompresses the stored data of a series into new checked
+// bytes, taken from the bytes pool when one is provided.
+func decompressData(
+	t compression.Type,
+	data []byte,
+	bytesPool pool.CheckedBytesPool,
+) (checked.Bytes, error) {
+	size, err := t.DecompressedLen(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newDataBytes(size, bytesPool)
+	defer result.DecRef()
+
+	if _, err := t.Decompress(result.Bytes(), data); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -31,6 +31,7 @@ const (
 	digestFileSuffix         = "digest"
 	checkpointFileSuffix     = "checkpoint"
 	metadataFileSuffix       = "metadata"
+	tieredFileSuffix         = "tiered"
 	filesetFilePrefix        = "fileset"
 	commitLogFilePrefix      = "commitlog"
 	segmentFileSetFilePrefix = "segment"
@@ -0,0 +1,97 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package migration
+
+import "fmt"
+
+// Encoding is the time series encoding of the data in a fileset.
+type Encoding uint
+
+const (
+	// M3TSZEncoding encodes data points with m3tsz.
+	M3TSZEncoding Encoding = iota
+	// ProtoEncoding encodes data points as protobuf messages of the
+	// namespace schema.
+	ProtoEncoding
+)
+
+var (
+	validEncodings = []Encoding{
+		M3TSZEncoding,
+		ProtoEncoding,
+	}
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case M3TSZEncoding:
+		return "m3tsz"
+	case ProtoEncoding:
+		return "proto"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseEncoding parses a string for an Encoding, an empty string is parsed
+// as m3tsz.
+func ParseEncoding(str string) (Encoding, error) {
+	if str == "" {
+		return M3TSZEncoding, nil
+	}
+
+	for _, valid := range validEncodings {
+		if str == valid.String() {
+			return valid, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unrecognized encoding: %v", str)
+}
+
+// ValidateEncoding validates an encoding.
+func ValidateEncoding(e Encoding) error {
+	for _, valid := range validEncodings {
+		if valid == e {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid encoding '%v': should be one of %v",
+		e, validEncodings)
+}
+
+// UnmarshalYAML unmarshals an encoding.
+func (e *Encoding) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+
+	value, err := ParseEncoding(str)
+	if err != nil {
+		return fmt.Errorf("invalid Encoding '%s' valid types are: %v",
+			str, validEncodings)
+	}
+
+	*e = value
+	return nil
+}
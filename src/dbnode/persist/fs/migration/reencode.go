@@ -0,0 +1,597 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package migration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/proto"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/x/checked"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"go.uber.org/zap"
+)
+
+const reencodeProgressFileName = "reencode_progress.json"
+
+// Reencoder re-splits the filesets of a source namespace into the block size
+// of a target namespace, re-encoding the data points if the encodings differ
+// and compressing the new filesets with the compression type of the target
+// namespace.
+//
+// Progress is tracked per shard in a file in the target shard data directory
+// so that a re-encoding that is interrupted resumes with the first target
+// block that has not been written yet.
+type Reencoder struct {
+	opts ReencodeOptions
+	log  *zap.Logger
+}
+
+// NewReencoder creates a new Reencoder.
+func NewReencoder(opts ReencodeOptions) (*Reencoder, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return &Reencoder{
+		opts: opts,
+		log:  opts.InstrumentOptions().Logger(),
+	}, nil
+}
+
+// Run re-encodes the filesets of each shard.
+func (r *Reencoder) Run() error {
+	shards := r.opts.Shards()
+	if len(shards) == 0 {
+		var err error
+		if shards, err = r.sourceShards(); err != nil {
+			return err
+		}
+	}
+
+	var (
+		sourceID = r.opts.SourceNamespace().ID()
+		targetID = r.opts.TargetNamespace().ID()
+		nowFn    = r.opts.FilesystemOptions().ClockOptions().NowFn()
+		begin    = nowFn()
+		wg       sync.WaitGroup
+		errLock  sync.Mutex
+		multiErr xerrors.MultiError
+		shardCh  = make(chan uint32, len(shards))
+	)
+	r.log.Info("starting fileset re-encoding",
+		zap.Stringer("sourceNamespace", sourceID),
+		zap.Stringer("targetNamespace", targetID),
+		zap.Int("shards", len(shards)))
+
+	for _, shard := range shards {
+		shardCh <- shard
+	}
+	close(shardCh)
+
+	for i := 0; i < r.opts.Concurrency(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for shard := range shardCh {
+				if err := r.reencodeShard(shard); err != nil {
+					errLock.Lock()
+					multiErr = multiErr.Add(fmt.Errorf(
+						"error re-encoding shard %d: %v", shard, err))
+					errLock.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := multiErr.FinalError(); err != nil {
+		return err
+	}
+
+	r.log.Info("fileset re-encoding finished",
+		zap.Stringer("sourceNamespace", sourceID),
+		zap.Stringer("targetNamespace", targetID),
+		zap.Duration("took", nowFn().Sub(begin)))
+	return nil
+}
+
+func (r *Reencoder) sourceShards() ([]uint32, error) {
+	dirPath := fs.NamespaceDataDirPath(r.opts.FilesystemOptions().FilePathPrefix(),
+		r.opts.SourceNamespace().ID())
+	entries, err := ioutil.ReadDir(dirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	shards := make([]uint32, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		shard, err := strconv.ParseUint(entry.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		shards = append(shards, uint32(shard))
+	}
+	return shards, nil
+}
+
+// sourceFileSet is the latest volume of a source block.
+type sourceFileSet struct {
+	blockStart time.Time
+	blockSize  time.Duration
+	volume     int
+}
+
+func (r *Reencoder) sourceFileSets(shard uint32) ([]sourceFileSet, error) {
+	fsOpts := r.opts.FilesystemOptions()
+	results := fs.ReadInfoFiles(fsOpts.FilePathPrefix(), r.opts.SourceNamespace().ID(),
+		shard, fsOpts.InfoReaderBufferSize(), msgpack.NewDecodingOptions(),
+		persist.FileSetFlushType)
+
+	latest := make(map[int64]sourceFileSet, len(results))
+	for _, result := range results {
+		if err := result.Err.Error(); err != nil {
+			return nil, err
+		}
+		info := result.Info
+		if curr, ok := latest[info.BlockStart]; ok && curr.volume > info.VolumeIndex {
+			continue
+		}
+		latest[info.BlockStart] = sourceFileSet{
+			blockStart: xtime.FromNanoseconds(info.BlockStart),
+			blockSize:  time.Duration(info.BlockSize),
+			volume:     info.VolumeIndex,
+		}
+	}
+
+	fileSets := make([]sourceFileSet, 0, len(latest))
+	for _, fileSet := range latest {
+		fileSets = append(fileSets, fileSet)
+	}
+	sort.Slice(fileSets, func(i, j int) bool {
+		return fileSets[i].blockStart.Before(fileSets[j].blockStart)
+	})
+	return fileSets, nil
+}
+
+func (r *Reencoder) reencodeShard(shard uint32) error {
+	fileSets, err := r.sourceFileSets(shard)
+	if err != nil {
+		return err
+	}
+
+	var (
+		fsOpts          = r.opts.FilesystemOptions()
+		sourceID        = r.opts.SourceNamespace().ID()
+		targetID        = r.opts.TargetNamespace().ID()
+		targetBlockSize = r.opts.TargetNamespace().Options().RetentionOptions().BlockSize()
+		progress        = newReencodeProgress(
+			reencodeProgressFilePath(fsOpts.FilePathPrefix(), targetID, shard),
+			sourceID.String())
+	)
+	if err := progress.Load(); err != nil {
+		return err
+	}
+
+	// Group the source filesets by each target block that they overlap.
+	var (
+		targetBlockStarts []time.Time
+		sourcesByTarget   = make(map[xtime.UnixNano][]sourceFileSet)
+	)
+	for _, fileSet := range fileSets {
+		end := fileSet.blockStart.Add(fileSet.blockSize)
+		for blockStart := fileSet.blockStart.Truncate(targetBlockSize); blockStart.Before(end); blockStart = blockStart.Add(targetBlockSize) {
+			key := xtime.ToUnixNano(blockStart)
+			if _, ok := sourcesByTarget[key]; !ok {
+				targetBlockStarts = append(targetBlockStarts, blockStart)
+			}
+			sourcesByTarget[key] = append(sourcesByTarget[key], fileSet)
+		}
+	}
+	sort.Slice(targetBlockStarts, func(i, j int) bool {
+		return targetBlockStarts[i].Before(targetBlockStarts[j])
+	})
+
+	for _, blockStart := range targetBlockStarts {
+		if progress.Done(blockStart) {
+			continue
+		}
+
+		sources := sourcesByTarget[xtime.ToUnixNano(blockStart)]
+		if err := r.reencodeBlock(shard, blockStart, sources); err != nil {
+			return fmt.Errorf("error re-encoding block %s: %v", blockStart, err)
+		}
+		if err := progress.MarkDone(blockStart); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reencodeSource is a source fileset opened for streaming reads, which
+// returns the series of the fileset ordered by ID.
+type reencodeSource struct {
+	reader fs.DataFileSetReader
+	id     ident.BytesID
+	tags   ts.EncodedTags
+	data   []byte
+	done   bool
+}
+
+// next reads the next series of the source, the ID, tags and data of the
+// previous series are invalidated.
+func (s *reencodeSource) next() error {
+	id, tags, data, _, err := s.reader.StreamingRead()
+	if err == io.EOF {
+		s.done = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.id, s.tags, s.data = id, tags, data
+	return nil
+}
+
+// reencodeBlock writes the target block from the source filesets that it
+// overlaps. The source filesets are read in ID order and merged one series
+// at a time, so that only the data points of the series being re-encoded are
+// held in memory regardless of the number of series in the block.
+func (r *Reencoder) reencodeBlock(
+	shard uint32,
+	blockStart time.Time,
+	sourceFileSets []sourceFileSet,
+) error {
+	var (
+		fsOpts          = r.opts.FilesystemOptions()
+		targetMd        = r.opts.TargetNamespace()
+		targetBlockSize = targetMd.Options().RetentionOptions().BlockSize()
+		blockEnd        = blockStart.Add(targetBlockSize)
+		sourceSchema    = namespaceSchema(r.opts.SourceNamespace())
+		targetSchema    = namespaceSchema(targetMd)
+		sources         = make([]*reencodeSource, 0, len(sourceFileSets))
+		numEntries      int
+	)
+	defer func() {
+		for _, source := range sources {
+			source.reader.Close()
+		}
+	}()
+
+	for _, fileSet := range sourceFileSets {
+		reader, err := fs.NewReader(nil, fsOpts)
+		if err != nil {
+			return err
+		}
+		err = reader.Open(fs.DataReaderOpenOptions{
+			Identifier: fs.FileSetFileIdentifier{
+				Namespace:   r.opts.SourceNamespace().ID(),
+				Shard:       shard,
+				BlockStart:  fileSet.blockStart,
+				VolumeIndex: fileSet.volume,
+			},
+			FileSetType:      persist.FileSetFlushType,
+			StreamingEnabled: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		source := &reencodeSource{reader: reader}
+		sources = append(sources, source)
+		if err := source.next(); err != nil {
+			return err
+		}
+		numEntries += reader.Entries()
+	}
+
+	var (
+		encoder = r.newEncoder(blockStart, targetSchema)
+		writer  fs.StreamingWriter
+		id      ident.BytesID
+		tags    ts.EncodedTags
+	)
+	defer encoder.Close()
+
+	abort := func(err error) error {
+		if writer != nil {
+			writer.Abort()
+		}
+		return err
+	}
+
+	for {
+		// Find the lowest ID amongst the sources, the sources are ordered by
+		// block start so the data points of a series are encoded in order.
+		var minID ident.BytesID
+		for _, source := range sources {
+			if !source.done && (minID == nil || bytes.Compare(source.id, minID) < 0) {
+				minID = source.id
+			}
+		}
+		if minID == nil {
+			break
+		}
+
+		// NB: the ID and tags of the sources are invalidated once they are
+		// advanced, so they are copied first.
+		id = append(id[:0], minID...)
+		tags = tags[:0]
+		for _, source := range sources {
+			if source.done || !bytes.Equal(source.id, id) {
+				continue
+			}
+			if len(tags) == 0 {
+				tags = append(tags, source.tags...)
+			}
+			err := r.reencodeSeriesData(encoder, source.data, blockStart, blockEnd,
+				sourceSchema)
+			if err == nil {
+				err = source.next()
+			}
+			if err != nil {
+				return abort(fmt.Errorf("error re-encoding series %s: %v", id.String(), err))
+			}
+		}
+
+		if encoder.NumEncoded() == 0 {
+			continue
+		}
+		// NB: the target fileset is only written once a series has data
+		// points within the target block.
+		if writer == nil {
+			var err error
+			if writer, err = r.openTargetWriter(shard, blockStart, numEntries); err != nil {
+				return err
+			}
+		}
+		segment := encoder.DiscardReset(blockStart, 0, targetSchema)
+		err := writer.WriteAll(id, tags, [][]byte{
+			segmentBytes(segment.Head),
+			segmentBytes(segment.Tail),
+		}, segment.CalculateChecksum())
+		segment.Finalize()
+		if err != nil {
+			return abort(err)
+		}
+	}
+
+	if writer == nil {
+		return nil
+	}
+	return writer.Close()
+}
+
+// openTargetWriter opens a writer for the next volume of the target block,
+// the number of series planned sizes the bloom filter and index summaries.
+func (r *Reencoder) openTargetWriter(
+	shard uint32,
+	blockStart time.Time,
+	numSeries int,
+) (fs.StreamingWriter, error) {
+	var (
+		fsOpts   = r.opts.FilesystemOptions()
+		targetMd = r.opts.TargetNamespace()
+		volume   = 0
+	)
+	files, err := fs.DataFiles(fsOpts.FilePathPrefix(), targetMd.ID(), shard)
+	if err != nil {
+		return nil, err
+	}
+	if latest, ok := files.LatestVolumeForBlock(blockStart); ok {
+		volume = latest.ID.VolumeIndex + 1
+	}
+
+	writer, err := fs.NewStreamingWriter(fsOpts)
+	if err != nil {
+		return nil, err
+	}
+	err = writer.Open(fs.StreamingWriterOpenOptions{
+		NamespaceID:         targetMd.ID(),
+		ShardID:             shard,
+		BlockStart:          blockStart,
+		BlockSize:           targetMd.Options().RetentionOptions().BlockSize(),
+		VolumeIndex:         volume,
+		PlannedRecordsCount: uint(numSeries),
+		Compression:         targetMd.Options().CompressionType(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// reencodeSeriesData decodes the data points of a series that fall within
+// the target block and encodes them into the target encoding.
+func (r *Reencoder) reencodeSeriesData(
+	encoder encoding.Encoder,
+	data []byte,
+	blockStart, blockEnd time.Time,
+	sourceSchema namespace.SchemaDescr,
+) error {
+	iter := r.newIterator(data, sourceSchema)
+	defer iter.Close()
+
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if dp.Timestamp.Before(blockStart) || !dp.Timestamp.Before(blockEnd) {
+			continue
+		}
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+func segmentBytes(b checked.Bytes) []byte {
+	if b == nil {
+		return nil
+	}
+	return b.Bytes()
+}
+
+func (r *Reencoder) newIterator(
+	data []byte,
+	schema namespace.SchemaDescr,
+) encoding.ReaderIterator {
+	reader := bytes.NewReader(data)
+	if r.opts.SourceEncoding() == ProtoEncoding {
+		return proto.NewIterator(reader, schema, r.opts.EncodingOptions())
+	}
+	return m3tsz.NewReaderIterator(reader, m3tsz.DefaultIntOptimizationEnabled,
+		r.opts.EncodingOptions())
+}
+
+func (r *Reencoder) newEncoder(
+	start time.Time,
+	schema namespace.SchemaDescr,
+) encoding.Encoder {
+	encodingOpts := r.opts.EncodingOptions()
+	if r.opts.TargetEncoding() == ProtoEncoding {
+		encoder := proto.NewEncoder(start, encodingOpts)
+		encoder.SetSchema(schema)
+		return encoder
+	}
+	return m3tsz.NewEncoder(start, nil, m3tsz.DefaultIntOptimizationEnabled,
+		encodingOpts)
+}
+
+func namespaceSchema(md namespace.Metadata) namespace.SchemaDescr {
+	schema, _ := md.Options().SchemaHistory().GetLatest()
+	return schema
+}
+
+// reencodeProgress tracks the target blocks of a shard that have been
+// written, it is persisted after each block so that an interrupted
+// re-encoding can resume.
+type reencodeProgress struct {
+	filePath        string
+	sourceNamespace string
+	done            map[xtime.UnixNano]struct{}
+}
+
+type reencodeProgressFile struct {
+	SourceNamespace string  `json:"sourceNamespace"`
+	BlockStarts     []int64 `json:"blockStarts"`
+}
+
+func newReencodeProgress(filePath, sourceNamespace string) *reencodeProgress {
+	return &reencodeProgress{
+		filePath:        filePath,
+		sourceNamespace: sourceNamespace,
+		done:            make(map[xtime.UnixNano]struct{}),
+	}
+}
+
+func reencodeProgressFilePath(
+	filePathPrefix string,
+	namespace ident.ID,
+	shard uint32,
+) string {
+	return path.Join(fs.ShardDataDirPath(filePathPrefix, namespace, shard),
+		reencodeProgressFileName)
+}
+
+// Load reads any previously persisted progress for the same source namespace.
+func (p *reencodeProgress) Load() error {
+	data, err := ioutil.ReadFile(p.filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var file reencodeProgressFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("unable to decode re-encode progress file %s: %v",
+			p.filePath, err)
+	}
+	if file.SourceNamespace != p.sourceNamespace {
+		return nil
+	}
+
+	for _, blockStart := range file.BlockStarts {
+		p.done[xtime.UnixNano(blockStart)] = struct{}{}
+	}
+	return nil
+}
+
+// Done returns whether the target block has been written.
+func (p *reencodeProgress) Done(blockStart time.Time) bool {
+	_, ok := p.done[xtime.ToUnixNano(blockStart)]
+	return ok
+}
+
+// MarkDone records that the target block has been written.
+func (p *reencodeProgress) MarkDone(blockStart time.Time) error {
+	p.done[xtime.ToUnixNano(blockStart)] = struct{}{}
+
+	file := reencodeProgressFile{
+		SourceNamespace: p.sourceNamespace,
+		BlockStarts:     make([]int64, 0, len(p.done)),
+	}
+	for blockStart := range p.done {
+		file.BlockStarts = append(file.BlockStarts, int64(blockStart))
+	}
+	sort.Slice(file.BlockStarts, func(i, j int) bool {
+		return file.BlockStarts[i] < file.BlockStarts[j]
+	})
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(path.Dir(p.filePath), os.ModePerm); err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename so that a crash midway through
+	// never leaves a partially written progress file behind.
+	tmpFilePath := p.filePath + ".tmp"
+	if err := ioutil.WriteFile(tmpFilePath, data, 0666); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilePath, p.filePath)
+}
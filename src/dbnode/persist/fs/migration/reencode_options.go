@@ -0,0 +1,197 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package migration
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+var (
+	errSourceNamespaceNotSet   = errors.New("sourceNamespace not set")
+	errTargetNamespaceNotSet   = errors.New("targetNamespace not set")
+	errSameSourceAndTarget     = errors.New("sourceNamespace and targetNamespace must differ")
+	errEncodingOptionsNotSet   = errors.New("encodingOptions not set")
+	errInstrumentOptionsNotSet = errors.New("instrumentOptions not set")
+)
+
+type reencodeOptions struct {
+	sourceNamespace namespace.Metadata
+	sourceEncoding  Encoding
+	targetNamespace namespace.Metadata
+	targetEncoding  Encoding
+	shards          []uint32
+	concurrency     int
+	fsOpts          fs.Options
+	encodingOpts    encoding.Options
+	instrumentOpts  instrument.Options
+}
+
+// NewReencodeOptions creates new ReencodeOptions.
+func NewReencodeOptions() ReencodeOptions {
+	return &reencodeOptions{
+		concurrency:    defaultMigrationConcurrency,
+		encodingOpts:   encoding.NewOptions(),
+		instrumentOpts: instrument.NewOptions(),
+	}
+}
+
+func (o *reencodeOptions) Validate() error {
+	if o.sourceNamespace == nil {
+		return errSourceNamespaceNotSet
+	}
+	if o.targetNamespace == nil {
+		return errTargetNamespaceNotSet
+	}
+	if o.sourceNamespace.ID().Equal(o.targetNamespace.ID()) {
+		return errSameSourceAndTarget
+	}
+	if err := validateNamespaceEncoding(o.sourceNamespace, o.sourceEncoding); err != nil {
+		return err
+	}
+	if err := validateNamespaceEncoding(o.targetNamespace, o.targetEncoding); err != nil {
+		return err
+	}
+	if o.concurrency < 1 {
+		return fmt.Errorf("concurrency value %d must be >= 1", o.concurrency)
+	}
+	if o.fsOpts == nil {
+		return errFilesystemOptionsNotSet
+	}
+	if err := o.fsOpts.Validate(); err != nil {
+		return err
+	}
+	if o.encodingOpts == nil {
+		return errEncodingOptionsNotSet
+	}
+	if o.instrumentOpts == nil {
+		return errInstrumentOptionsNotSet
+	}
+	return nil
+}
+
+func validateNamespaceEncoding(md namespace.Metadata, e Encoding) error {
+	if err := ValidateEncoding(e); err != nil {
+		return err
+	}
+	if e != ProtoEncoding {
+		return nil
+	}
+	if _, ok := md.Options().SchemaHistory().GetLatest(); !ok {
+		return fmt.Errorf("proto encoding requires a schema for namespace %s", md.ID())
+	}
+	return nil
+}
+
+func (o *reencodeOptions) SetSourceNamespace(value namespace.Metadata) ReencodeOptions {
+	opts := *o
+	opts.sourceNamespace = value
+	return &opts
+}
+
+func (o *reencodeOptions) SourceNamespace() namespace.Metadata {
+	return o.sourceNamespace
+}
+
+func (o *reencodeOptions) SetSourceEncoding(value Encoding) ReencodeOptions {
+	opts := *o
+	opts.sourceEncoding = value
+	return &opts
+}
+
+func (o *reencodeOptions) SourceEncoding() Encoding {
+	return o.sourceEncoding
+}
+
+func (o *reencodeOptions) SetTargetNamespace(value namespace.Metadata) ReencodeOptions {
+	opts := *o
+	opts.targetNamespace = value
+	return &opts
+}
+
+func (o *reencodeOptions) TargetNamespace() namespace.Metadata {
+	return o.targetNamespace
+}
+
+func (o *reencodeOptions) SetTargetEncoding(value Encoding) ReencodeOptions {
+	opts := *o
+	opts.targetEncoding = value
+	return &opts
+}
+
+func (o *reencodeOptions) TargetEncoding() Encoding {
+	return o.targetEncoding
+}
+
+func (o *reencodeOptions) SetShards(value []uint32) ReencodeOptions {
+	opts := *o
+	opts.shards = value
+	return &opts
+}
+
+func (o *reencodeOptions) Shards() []uint32 {
+	return o.shards
+}
+
+func (o *reencodeOptions) SetConcurrency(value int) ReencodeOptions {
+	opts := *o
+	opts.concurrency = value
+	return &opts
+}
+
+func (o *reencodeOptions) Concurrency() int {
+	return o.concurrency
+}
+
+func (o *reencodeOptions) SetFilesystemOptions(value fs.Options) ReencodeOptions {
+	opts := *o
+	opts.fsOpts = value
+	return &opts
+}
+
+func (o *reencodeOptions) FilesystemOptions() fs.Options {
+	return o.fsOpts
+}
+
+func (o *reencodeOptions) SetEncodingOptions(value encoding.Options) ReencodeOptions {
+	opts := *o
+	opts.encodingOpts = value
+	return &opts
+}
+
+func (o *reencodeOptions) EncodingOptions() encoding.Options {
+	return o.encodingOpts
+}
+
+func (o *reencodeOptions) SetInstrumentOptions(value instrument.Options) ReencodeOptions {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *reencodeOptions) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
@@ -0,0 +1,315 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package migration
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newReencodeTestNamespace(
+	t *testing.T,
+	id string,
+	blockSize time.Duration,
+	compressionType compression.Type,
+) namespace.Metadata {
+	opts := namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().SetBlockSize(blockSize)).
+		SetCompressionType(compressionType)
+	md, err := namespace.NewMetadata(ident.StringID(id), opts)
+	require.NoError(t, err)
+	return md
+}
+
+func writeReencodeTestFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	md namespace.Metadata,
+	shard uint32,
+	blockStart time.Time,
+	series map[string][]ts.Datapoint,
+) {
+	writer, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  md.ID(),
+			Shard:      shard,
+			BlockStart: blockStart,
+		},
+		BlockSize:   md.Options().RetentionOptions().BlockSize(),
+		FileSetType: persist.FileSetFlushType,
+	}))
+
+	for id, dps := range series {
+		encoder := m3tsz.NewEncoder(blockStart, nil,
+			m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
+		for _, dp := range dps {
+			require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+		}
+
+		segment := encoder.Discard()
+		metadata := persist.NewMetadataFromIDAndTags(ident.StringID(id),
+			ident.NewTags(ident.StringTag("name", id)), persist.MetadataOptions{})
+		require.NoError(t, writer.WriteAll(metadata,
+			[]checked.Bytes{segment.Head, segment.Tail}, segment.CalculateChecksum()))
+	}
+	require.NoError(t, writer.Close())
+}
+
+func readReencodeTestFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	md namespace.Metadata,
+	shard uint32,
+	blockStart time.Time,
+) map[string][]ts.Datapoint {
+	reader, err := fs.NewReader(nil, fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  md.ID(),
+			Shard:      shard,
+			BlockStart: blockStart,
+		},
+		FileSetType: persist.FileSetFlushType,
+	}))
+	defer reader.Close()
+
+	results := make(map[string][]ts.Datapoint)
+	for {
+		id, tags, data, _, err := reader.Read()
+		if err == io.EOF {
+			return results
+		}
+		require.NoError(t, err)
+		require.True(t, tags.Next())
+		require.Equal(t, id.String(), tags.Current().Value.String())
+
+		data.IncRef()
+		iter := m3tsz.NewReaderIterator(bytes.NewReader(data.Bytes()),
+			m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
+		for iter.Next() {
+			dp, _, _ := iter.Current()
+			results[id.String()] = append(results[id.String()],
+				ts.Datapoint{Timestamp: dp.Timestamp, Value: dp.Value})
+		}
+		require.NoError(t, iter.Err())
+		iter.Close()
+		data.DecRef()
+	}
+}
+
+func TestReencoderResplitsBlocks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reencode")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		fsOpts = fs.NewOptions().SetFilePathPrefix(dir)
+		source = newReencodeTestNamespace(t, "source", time.Hour,
+			compression.NoneType)
+		target = newReencodeTestNamespace(t, "target", 2*time.Hour,
+			compression.SnappyType)
+		start = time.Unix(0, 0).Add(100 * 2 * time.Hour)
+		shard = uint32(3)
+	)
+
+	writeReencodeTestFileSet(t, fsOpts, source, shard, start, map[string][]ts.Datapoint{
+		"foo": {
+			{Timestamp: start.Add(time.Minute), Value: 1},
+			{Timestamp: start.Add(2 * time.Minute), Value: 2},
+		},
+		"bar": {
+			{Timestamp: start.Add(time.Minute), Value: 3},
+		},
+	})
+	writeReencodeTestFileSet(t, fsOpts, source, shard, start.Add(time.Hour), map[string][]ts.Datapoint{
+		"foo": {
+			{Timestamp: start.Add(time.Hour + time.Minute), Value: 4},
+		},
+	})
+
+	reencoder, err := NewReencoder(NewReencodeOptions().
+		SetSourceNamespace(source).
+		SetTargetNamespace(target).
+		SetFilesystemOptions(fsOpts))
+	require.NoError(t, err)
+	require.NoError(t, reencoder.Run())
+
+	results := readReencodeTestFileSet(t, fsOpts, target, shard, start)
+	require.Equal(t, map[string][]ts.Datapoint{
+		"foo": {
+			{Timestamp: start.Add(time.Minute), Value: 1},
+			{Timestamp: start.Add(2 * time.Minute), Value: 2},
+			{Timestamp: start.Add(time.Hour + time.Minute), Value: 4},
+		},
+		"bar": {
+			{Timestamp: start.Add(time.Minute), Value: 3},
+		},
+	}, results)
+
+	infoFiles := fs.ReadInfoFiles(dir, target.ID(), shard,
+		fsOpts.InfoReaderBufferSize(), nil, persist.FileSetFlushType)
+	require.Len(t, infoFiles, 1)
+	require.NoError(t, infoFiles[0].Err.Error())
+	require.Equal(t, int64(2*time.Hour), infoFiles[0].Info.BlockSize)
+}
+
+func TestReencoderMergesSourcesByID(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reencode")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		fsOpts = fs.NewOptions().SetFilePathPrefix(dir)
+		source = newReencodeTestNamespace(t, "source", time.Hour,
+			compression.NoneType)
+		target = newReencodeTestNamespace(t, "target", 3*time.Hour,
+			compression.NoneType)
+		start = time.Unix(0, 0).Add(100 * 3 * time.Hour)
+		shard = uint32(0)
+	)
+
+	// Each source fileset holds a different subset of the series.
+	sources := []map[string]float64{
+		{"a": 1, "c": 2},
+		{"b": 3, "c": 4},
+		{"a": 5, "d": 6},
+	}
+	expected := make(map[string][]ts.Datapoint)
+	for i, values := range sources {
+		blockStart := start.Add(time.Duration(i) * time.Hour)
+		series := make(map[string][]ts.Datapoint, len(values))
+		for id, value := range values {
+			dp := ts.Datapoint{Timestamp: blockStart.Add(time.Minute), Value: value}
+			series[id] = []ts.Datapoint{dp}
+			expected[id] = append(expected[id], dp)
+		}
+		writeReencodeTestFileSet(t, fsOpts, source, shard, blockStart, series)
+	}
+
+	reencoder, err := NewReencoder(NewReencodeOptions().
+		SetSourceNamespace(source).
+		SetTargetNamespace(target).
+		SetFilesystemOptions(fsOpts))
+	require.NoError(t, err)
+	require.NoError(t, reencoder.Run())
+
+	results := readReencodeTestFileSet(t, fsOpts, target, shard, start)
+	require.Equal(t, expected, results)
+}
+
+func TestReencoderResumesFromProgress(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reencode")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		fsOpts = fs.NewOptions().SetFilePathPrefix(dir)
+		source = newReencodeTestNamespace(t, "source", 2*time.Hour,
+			compression.NoneType)
+		target = newReencodeTestNamespace(t, "target", time.Hour,
+			compression.NoneType)
+		start = time.Unix(0, 0).Add(100 * 2 * time.Hour)
+		shard = uint32(0)
+	)
+
+	writeReencodeTestFileSet(t, fsOpts, source, shard, start, map[string][]ts.Datapoint{
+		"foo": {
+			{Timestamp: start.Add(time.Minute), Value: 1},
+			{Timestamp: start.Add(time.Hour + time.Minute), Value: 2},
+		},
+	})
+
+	// Mark the first target block as already written.
+	progress := newReencodeProgress(
+		reencodeProgressFilePath(dir, target.ID(), shard), source.ID().String())
+	require.NoError(t, progress.MarkDone(start))
+
+	opts := NewReencodeOptions().
+		SetSourceNamespace(source).
+		SetTargetNamespace(target).
+		SetShards([]uint32{shard}).
+		SetFilesystemOptions(fsOpts)
+	reencoder, err := NewReencoder(opts)
+	require.NoError(t, err)
+	require.NoError(t, reencoder.Run())
+
+	files, err := fs.DataFiles(dir, target.ID(), shard)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.True(t, files[0].ID.BlockStart.Equal(start.Add(time.Hour)))
+
+	results := readReencodeTestFileSet(t, fsOpts, target, shard, start.Add(time.Hour))
+	require.Equal(t, map[string][]ts.Datapoint{
+		"foo": {{Timestamp: start.Add(time.Hour + time.Minute), Value: 2}},
+	}, results)
+
+	// Both blocks are now recorded so a rerun writes nothing new.
+	progress = newReencodeProgress(
+		reencodeProgressFilePath(dir, target.ID(), shard), source.ID().String())
+	require.NoError(t, progress.Load())
+	require.True(t, progress.Done(start))
+	require.True(t, progress.Done(start.Add(time.Hour)))
+
+	reencoder, err = NewReencoder(opts)
+	require.NoError(t, err)
+	require.NoError(t, reencoder.Run())
+
+	files, err = fs.DataFiles(dir, target.ID(), shard)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
+
+func TestReencodeOptionsValidate(t *testing.T) {
+	source := newReencodeTestNamespace(t, "source", time.Hour, compression.NoneType)
+	target := newReencodeTestNamespace(t, "target", time.Hour, compression.NoneType)
+
+	opts := NewReencodeOptions().
+		SetSourceNamespace(source).
+		SetTargetNamespace(target).
+		SetFilesystemOptions(fs.NewOptions())
+	require.NoError(t, opts.Validate())
+
+	require.Error(t, opts.SetTargetNamespace(source).Validate())
+	require.Error(t, opts.SetConcurrency(0).Validate())
+	// Proto encoding requires a schema on the namespace.
+	require.Error(t, opts.SetTargetEncoding(ProtoEncoding).Validate())
+}
@@ -21,10 +21,12 @@
 package migration
 
 import (
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/storage"
+	"github.com/m3db/m3/src/x/instrument"
 )
 
 // Options represents the options for migrations.
@@ -95,3 +97,66 @@ type TaskOptions interface {
 	// FilesystemOptions returns the filesystem options.
 	FilesystemOptions() fs.Options
 }
+
+// ReencodeOptions represents the options for re-encoding the filesets of a
+// source namespace into a target namespace.
+type ReencodeOptions interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetSourceNamespace sets the metadata of the namespace to read filesets from.
+	SetSourceNamespace(value namespace.Metadata) ReencodeOptions
+
+	// SourceNamespace gets the metadata of the namespace to read filesets from.
+	SourceNamespace() namespace.Metadata
+
+	// SetSourceEncoding sets the encoding of the source filesets.
+	SetSourceEncoding(value Encoding) ReencodeOptions
+
+	// SourceEncoding gets the encoding of the source filesets.
+	SourceEncoding() Encoding
+
+	// SetTargetNamespace sets the metadata of the namespace to write filesets
+	// to, its block size and compression type are used for the new filesets.
+	SetTargetNamespace(value namespace.Metadata) ReencodeOptions
+
+	// TargetNamespace gets the metadata of the namespace to write filesets to.
+	TargetNamespace() namespace.Metadata
+
+	// SetTargetEncoding sets the encoding of the target filesets.
+	SetTargetEncoding(value Encoding) ReencodeOptions
+
+	// TargetEncoding gets the encoding of the target filesets.
+	TargetEncoding() Encoding
+
+	// SetShards sets the shards to re-encode, all shards of the source
+	// namespace found on disk are re-encoded if empty.
+	SetShards(value []uint32) ReencodeOptions
+
+	// Shards gets the shards to re-encode.
+	Shards() []uint32
+
+	// SetConcurrency sets the number of shards re-encoded concurrently.
+	SetConcurrency(value int) ReencodeOptions
+
+	// Concurrency gets the number of shards re-encoded concurrently.
+	Concurrency() int
+
+	// SetFilesystemOptions sets the filesystem options.
+	SetFilesystemOptions(value fs.Options) ReencodeOptions
+
+	// FilesystemOptions returns the filesystem options.
+	FilesystemOptions() fs.Options
+
+	// SetEncodingOptions sets the encoding options.
+	SetEncodingOptions(value encoding.Options) ReencodeOptions
+
+	// EncodingOptions returns the encoding options.
+	EncodingOptions() encoding.Options
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) ReencodeOptions
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+}
@@ -97,6 +97,7 @@ func testExpectedResultForIndexInfo(t *testing.T, indexInfo schema.IndexInfo) []
 		indexInfo.SnapshotID,
 		int64(indexInfo.VolumeIndex),
 		indexInfo.MinorVersion,
+		int64(indexInfo.Compression),
 	}
 }
 
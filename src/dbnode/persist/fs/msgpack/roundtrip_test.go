@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/x/pool"
 	xtest "github.com/m3db/m3/src/x/test"
@@ -52,6 +53,7 @@ var (
 		SnapshotID:   []byte("some_bytes"),
 		VolumeIndex:  1,
 		MinorVersion: schema.MinorVersion,
+		Compression:  compression.SnappyType,
 	}
 
 	testIndexEntryChecksum = int64(2611877657)
@@ -121,7 +123,7 @@ func TestIndexInfoRoundtrip(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V5 decoding code can handle the V1 file format.
+// Make sure the V6 decoding code can handle the V1 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV1}
@@ -140,18 +142,21 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV1(t *testing.T) {
 		currSnapshotID   = testIndexInfo.SnapshotID
 		currVolumeIndex  = testIndexInfo.VolumeIndex
 		currMinorVersion = testIndexInfo.MinorVersion
+		currCompression  = testIndexInfo.Compression
 	)
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -161,7 +166,7 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V1 decoder code can handle the V5 file format.
+// Make sure the V1 decoder code can handle the V6 file format.
 func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{DecodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV1}
@@ -178,6 +183,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 		currSnapshotID   = testIndexInfo.SnapshotID
 		currVolumeIndex  = testIndexInfo.VolumeIndex
 		currMinorVersion = testIndexInfo.MinorVersion
+		currCompression  = testIndexInfo.Compression
 	)
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -189,12 +195,14 @@ func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -203,7 +211,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V5 decoding code can handle the V2 file format.
+// Make sure the V6 decoding code can handle the V2 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV2(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV2}
@@ -221,18 +229,21 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV2(t *testing.T) {
 		currSnapshotID   = testIndexInfo.SnapshotID
 		currVolumeIndex  = testIndexInfo.VolumeIndex
 		currMinorVersion = testIndexInfo.MinorVersion
+		currCompression  = testIndexInfo.Compression
 	)
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -242,7 +253,7 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV2(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V2 decoder code can handle the V5 file format.
+// Make sure the V2 decoder code can handle the V6 file format.
 func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{DecodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV2}
@@ -256,6 +267,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	currSnapshotID := testIndexInfo.SnapshotID
 	currVolumeIndex := testIndexInfo.VolumeIndex
 	currMinorVersion := testIndexInfo.MinorVersion
+	currCompression := testIndexInfo.Compression
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
@@ -264,10 +276,12 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -276,7 +290,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V5 decoding code can handle the V3 file format.
+// Make sure the V6 decoding code can handle the V3 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV3(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV3}
@@ -291,12 +305,15 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV3(t *testing.T) {
 	var (
 		currVolumeIndex  = testIndexInfo.VolumeIndex
 		currMinorVersion = testIndexInfo.MinorVersion
+		currCompression  = testIndexInfo.Compression
 	)
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -306,7 +323,7 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV3(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V3 decoder code can handle the V5 file format.
+// Make sure the V3 decoder code can handle the V6 file format.
 func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{DecodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV3}
@@ -319,6 +336,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	// because the old decoder won't read the new fields.
 	currVolumeIndex := testIndexInfo.VolumeIndex
 	currMinorVersion := testIndexInfo.MinorVersion
+	currCompression := testIndexInfo.Compression
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
@@ -326,9 +344,11 @@ func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	// encoded the data.
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -337,7 +357,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V5 decoding code can handle the V4 file format.
+// Make sure the V6 decoding code can handle the V4 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV4(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV4}
@@ -350,10 +370,13 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV4(t *testing.T) {
 	// because the new decoder won't try and read the new fields from
 	// the old file format.
 	currMinorVersion := testIndexInfo.MinorVersion
+	currCompression := testIndexInfo.Compression
 
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -363,7 +386,7 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV4(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V4 decoder code can handle the V5 file format.
+// Make sure the V4 decoder code can handle the V6 file format.
 func TestIndexInfoRoundTripForwardsCompatibilityV4(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{DecodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV4}
@@ -375,14 +398,71 @@ func TestIndexInfoRoundTripForwardsCompatibilityV4(t *testing.T) {
 	// and then restore them at the end of the test - This is required
 	// because the old decoder won't read the new fields.
 	currMinorVersion := testIndexInfo.MinorVersion
+	currCompression := testIndexInfo.Compression
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
 	// Make sure to zero them before we compare, but after we have
 	// encoded the data.
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
+	}()
+
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V6 decoding code can handle the V5 file format.
+func TestIndexInfoRoundTripBackwardsCompatibilityV5(t *testing.T) {
+	var (
+		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV5}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V5,
+	// and then restore them at the end of the test - This is required
+	// because the new decoder won't try and read the new fields from
+	// the old file format.
+	currCompression := testIndexInfo.Compression
+
+	testIndexInfo.Compression = 0
+	defer func() {
+		testIndexInfo.Compression = currCompression
+	}()
+
+	enc.EncodeIndexInfo(testIndexInfo)
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V5 decoder code can handle the V6 file format.
+func TestIndexInfoRoundTripForwardsCompatibilityV5(t *testing.T) {
+	var (
+		opts = LegacyEncodingOptions{DecodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV5}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V5
+	// and then restore them at the end of the test - This is required
+	// because the old decoder won't read the new fields.
+	currCompression := testIndexInfo.Compression
+
+	enc.EncodeIndexInfo(testIndexInfo)
+
+	// Make sure to zero them before we compare, but after we have
+	// encoded the data.
+	testIndexInfo.Compression = 0
+	defer func() {
+		testIndexInfo.Compression = currCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -109,6 +109,7 @@ type options struct {
 	mmapReporter                         mmap.Reporter
 	indexReaderAutovalidateIndexSegments bool
 	encodingOptions                      msgpack.LegacyEncodingOptions
+	tieredFileSetCache                   TieredFileSetCache
 }
 
 // NewOptions creates a new set of fs options
@@ -408,3 +409,13 @@ func (o *options) SetEncodingOptions(value msgpack.LegacyEncodingOptions) Option
 func (o *options) EncodingOptions() msgpack.LegacyEncodingOptions {
 	return o.encodingOptions
 }
+
+func (o *options) SetTieredFileSetCache(value TieredFileSetCache) Options {
+	opts := *o
+	opts.tieredFileSetCache = value
+	return &opts
+}
+
+func (o *options) TieredFileSetCache() TieredFileSetCache {
+	return o.tieredFileSetCache
+}
@@ -479,6 +479,7 @@ func (pm *persistManager) PrepareData(opts persist.DataPrepareOptions) (persist.
 			BlockStart:  blockStart,
 			VolumeIndex: volumeIndex,
 		},
+		Compression: nsMetadata.Options().CompressionType(),
 	}
 	if err := pm.dataPM.writer.Open(dataWriterOpts); err != nil {
 		return prepared, err
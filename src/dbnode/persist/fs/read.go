@@ -31,6 +31,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -91,7 +92,10 @@ type reader struct {
 
 	bloomFilterFd *os.File
 
+	releaseTieredFileSet func()
+
 	entries         int
+	compression     compression.Type
 	bloomFilterInfo schema.IndexBloomFilterInfo
 	entriesRead     int
 	metadataRead    int
@@ -104,6 +108,8 @@ type reader struct {
 	streamingTags []byte
 	streamingData []byte
 
+	compressedBuf []byte
+
 	expectedInfoDigest        uint32
 	expectedIndexDigest       uint32
 	expectedDataDigest        uint32
@@ -150,13 +156,18 @@ func NewReader(
 	}, nil
 }
 
-func (r *reader) Open(opts DataReaderOpenOptions) error {
+func (r *reader) Open(opts DataReaderOpenOptions) (err error) {
+	defer func() {
+		if err != nil {
+			r.releaseTieredFileSetIfPinned()
+		}
+	}()
+
 	var (
 		namespace   = opts.Identifier.Namespace
 		shard       = opts.Identifier.Shard
 		blockStart  = opts.Identifier.BlockStart
 		volumeIndex = opts.Identifier.VolumeIndex
-		err         error
 	)
 
 	var (
@@ -191,6 +202,15 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 			}
 		}
 
+		if !isLegacy {
+			release, err := fetchTieredFileSet(r.opts, r.filePathPrefix, opts.Identifier)
+			if err != nil {
+				return err
+			}
+			// The files are pinned until the reader is closed.
+			r.releaseTieredFileSet = release
+		}
+
 		checkpointFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, checkpointFileSuffix, isLegacy)
 		infoFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, infoFileSuffix, isLegacy)
 		digestFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, digestFileSuffix, isLegacy)
@@ -345,6 +365,7 @@ func (r *reader) readInfo(size int) error {
 	r.volume = info.VolumeIndex
 	r.blockSize = time.Duration(info.BlockSize)
 	r.entries = int(info.Entries)
+	r.compression = info.Compression
 	r.entriesRead = 0
 	r.metadataRead = 0
 	r.bloomFilterInfo = info.BloomFilter
@@ -394,13 +415,28 @@ func (r *reader) StreamingRead() (ident.BytesID, ts.EncodedTags, []byte, uint32,
 	}
 	data := r.dataMmap.Bytes[entry.Offset : entry.Offset+entry.Size]
 
+	if r.compression == compression.NoneType {
+		r.streamingData = append(r.streamingData[:0], data...)
+	} else {
+		size, err := r.compression.DecompressedLen(data)
+		if err != nil {
+			return nil, nil, nil, 0, err
+		}
+		if cap(r.streamingData) < size {
+			r.streamingData = make([]byte, size)
+		}
+		r.streamingData, err = r.compression.Decompress(r.streamingData[:size], data)
+		if err != nil {
+			return nil, nil, nil, 0, err
+		}
+	}
+
 	// NB(r): _must_ check the checksum against known checksum as the data
 	// file might not have been verified if we haven't read through the file yet.
-	if entry.DataChecksum != int64(digest.Checksum(data)) {
+	if entry.DataChecksum != int64(digest.Checksum(r.streamingData)) {
 		return nil, nil, nil, 0, errSeekChecksumMismatch
 	}
 
-	r.streamingData = append(r.streamingData[:0], data...)
 	r.streamingID = append(r.streamingID[:0], entry.ID...)
 	r.streamingTags = append(r.streamingTags[:0], entry.EncodedTags...)
 
@@ -432,6 +468,10 @@ func (r *reader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, err
 
 	entry := r.indexEntriesByOffsetAsc[r.entriesRead]
 
+	if r.compression != compression.NoneType {
+		return r.readCompressed(entry)
+	}
+
 	var data checked.Bytes
 	if r.bytesPool != nil {
 		data = r.bytesPool.Get(int(entry.Size))
@@ -459,6 +499,36 @@ func (r *reader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, err
 	return id, tags, data, uint32(entry.DataChecksum), nil
 }
 
+func (r *reader) readCompressed(
+	entry schema.IndexEntry,
+) (ident.ID, ident.TagIterator, checked.Bytes, uint32, error) {
+	// Compressed data is read into a buffer owned by the reader since only
+	// the decompressed data is handed back to the caller.
+	if cap(r.compressedBuf) < int(entry.Size) {
+		r.compressedBuf = make([]byte, entry.Size)
+	}
+	r.compressedBuf = r.compressedBuf[:entry.Size]
+
+	n, err := r.dataReader.Read(r.compressedBuf)
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+	if n != int(entry.Size) {
+		return nil, nil, nil, 0, errReadNotExpectedSize
+	}
+
+	data, err := decompressData(r.compression, r.compressedBuf, r.bytesPool)
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+
+	id := r.entryClonedID(entry.ID)
+	tags := r.entryClonedEncodedTagsIter(entry.EncodedTags)
+
+	r.entriesRead++
+	return id, tags, data, uint32(entry.DataChecksum), nil
+}
+
 func (r *reader) ReadMetadata() (ident.ID, ident.TagIterator, int, uint32, error) {
 	if r.streamingEnabled {
 		return nil, nil, 0, 0, errStreamingUnsupported
@@ -469,11 +539,29 @@ func (r *reader) ReadMetadata() (ident.ID, ident.TagIterator, int, uint32, error
 	}
 
 	entry := r.indexEntriesByOffsetAsc[r.metadataRead]
-	id := r.entryClonedID(entry.ID)
-	tags := r.entryClonedEncodedTagsIter(entry.EncodedTags)
 	length := int(entry.Size)
 	checksum := uint32(entry.DataChecksum)
 
+	if r.compression != compression.NoneType {
+		// Report the length of the series once decompressed so that sizes
+		// are comparable regardless of how the fileset was written, the
+		// header of the compressed data records it without decompressing.
+		if entry.Offset+entry.Size > int64(len(r.dataMmap.Bytes)) {
+			return nil, nil, 0, 0, fmt.Errorf(
+				"attempt to read beyond data file size (offset=%d, size=%d, file size=%d)",
+				entry.Offset, entry.Size, len(r.dataMmap.Bytes))
+		}
+		data := r.dataMmap.Bytes[entry.Offset : entry.Offset+entry.Size]
+		decompressedLen, err := r.compression.DecompressedLen(data)
+		if err != nil {
+			return nil, nil, 0, 0, err
+		}
+		length = decompressedLen
+	}
+
+	id := r.entryClonedID(entry.ID)
+	tags := r.entryClonedEncodedTagsIter(entry.EncodedTags)
+
 	r.metadataRead++
 	return id, tags, length, checksum, nil
 }
@@ -582,6 +670,7 @@ func (r *reader) Close() error {
 		r.indexEntriesByOffsetAsc[i].ID = nil
 	}
 	r.indexEntriesByOffsetAsc = r.indexEntriesByOffsetAsc[:0]
+	r.releaseTieredFileSetIfPinned()
 
 	// Save fields we want to reassign after resetting struct
 	opts := r.opts
@@ -619,6 +708,13 @@ func (r *reader) Close() error {
 	return multiErr.FinalError()
 }
 
+func (r *reader) releaseTieredFileSetIfPinned() {
+	if r.releaseTieredFileSet != nil {
+		r.releaseTieredFileSet()
+		r.releaseTieredFileSet = nil
+	}
+}
+
 // indexEntriesByOffsetAsc implements sort.Sort
 type indexEntriesByOffsetAsc []schema.IndexEntry
 
@@ -31,6 +31,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/x/checked"
 	"github.com/m3db/m3/src/x/ident"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -571,6 +572,99 @@ func TestWriterOnlyWritesNonNilBytes(t *testing.T) {
 	})
 }
 
+func TestCompressedReadWrite(t *testing.T) {
+	for _, compressionType := range []compression.Type{
+		compression.SnappyType,
+		compression.DeflateType,
+	} {
+		t.Run(compressionType.String(), func(t *testing.T) {
+			dir := createTempDir(t)
+			filePathPrefix := filepath.Join(dir, "")
+			defer os.RemoveAll(dir)
+
+			entries := []testEntry{
+				{"foo", nil, []byte{1, 2, 3}},
+				{"bar", nil, []byte{4, 5, 6}},
+				{"baz", nil, make([]byte, 65536)},
+				{"cat", nil, bytes.Repeat([]byte{1, 2, 3, 4}, 25000)},
+				{"foo+bar=baz,qux=qaz", map[string]string{
+					"bar": "baz",
+					"qux": "qaz",
+				}, []byte{7, 8, 9}},
+			}
+
+			w := newTestWriter(t, filePathPrefix)
+			writerOpts := DataWriterOpenOptions{
+				BlockSize: testBlockSize,
+				Identifier: FileSetFileIdentifier{
+					Namespace:  testNs1ID,
+					Shard:      0,
+					BlockStart: testWriterStart,
+				},
+				FileSetType: persist.FileSetFlushType,
+				Compression: compressionType,
+			}
+			require.NoError(t, w.Open(writerOpts))
+			for _, entry := range entries {
+				metadata := persist.NewMetadataFromIDAndTags(entry.ID(), entry.Tags(),
+					persist.MetadataOptions{})
+				require.NoError(t, w.Write(metadata, bytesRefd(entry.data),
+					digest.Checksum(entry.data)))
+			}
+			require.NoError(t, w.Close())
+
+			readInfoFileResults := ReadInfoFiles(filePathPrefix, testNs1ID, 0, 16, nil, persist.FileSetFlushType)
+			require.Equal(t, 1, len(readInfoFileResults))
+			require.NoError(t, readInfoFileResults[0].Err.Error())
+			require.Equal(t, compressionType, readInfoFileResults[0].Info.Compression)
+
+			// Compressed data should take up less space on disk than the raw data.
+			var rawSize int64
+			for _, entry := range entries {
+				rawSize += int64(len(entry.data))
+			}
+			dataFilePath := dataFilesetPathFromTimeAndIndex(
+				ShardDataDirPath(filePathPrefix, testNs1ID, 0), testWriterStart, 0, dataFileSuffix, false)
+			stat, err := os.Stat(dataFilePath)
+			require.NoError(t, err)
+			require.True(t, stat.Size() < rawSize)
+
+			r := newTestReader(t, filePathPrefix)
+			readTestData(t, r, 0, testWriterStart, entries)
+
+			resources := newTestReusableSeekerResources()
+			s := newTestSeeker(filePathPrefix)
+			require.NoError(t, s.Open(testNs1ID, 0, testWriterStart, 0, resources))
+			for _, entry := range entries {
+				data, err := s.SeekByID(entry.ID(), resources)
+				require.NoError(t, err)
+
+				data.IncRef()
+				require.True(t, bytes.Equal(entry.data, data.Bytes()))
+				data.DecRef()
+				data.Finalize()
+			}
+			require.NoError(t, s.Close())
+		})
+	}
+}
+
+func TestUncompressedFilesetInfoCompression(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, []testEntry{
+		{"foo", nil, []byte{1, 2, 3}},
+	}, persist.FileSetFlushType)
+
+	readInfoFileResults := ReadInfoFiles(filePathPrefix, testNs1ID, 0, 16, nil, persist.FileSetFlushType)
+	require.Equal(t, 1, len(readInfoFileResults))
+	require.NoError(t, readInfoFileResults[0].Err.Error())
+	require.Equal(t, compression.NoneType, readInfoFileResults[0].Info.Compression)
+}
+
 func readData(t *testing.T, reader DataFileSetReader) (id ident.ID, tags ident.TagIterator, data checked.Bytes, checksum uint32, err error) {
 	if reader.StreamingEnabled() {
 		id, encodedTags, data, checksum, err := reader.StreamingRead()
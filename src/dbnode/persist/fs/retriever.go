@@ -49,6 +49,7 @@ import (
 	"github.com/m3db/m3/src/x/context"
 	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/pool"
+	"github.com/m3db/m3/src/x/serialize"
 
 	"go.uber.org/zap"
 )
@@ -103,6 +104,7 @@ type blockRetriever struct {
 
 	blockSize               time.Duration
 	nsCacheBlocksOnRetrieve bool
+	retentionRules          namespace.RetentionRulesMatcher
 
 	status                     blockRetrieverStatus
 	reqsByShardIdx             []*shardRetrieveRequests
@@ -164,6 +166,14 @@ func (r *blockRetriever) Open(
 	r.blockSize = ns.Options().RetentionOptions().BlockSize()
 	r.nsCacheBlocksOnRetrieve = ns.Options().CacheBlocksOnRetrieve()
 
+	if rules := ns.Options().RetentionRules(); len(rules) > 0 {
+		matcher, err := namespace.NewRetentionRulesMatcher(rules)
+		if err != nil {
+			return err
+		}
+		r.retentionRules = matcher
+	}
+
 	for i := 0; i < r.opts.FetchConcurrency(); i++ {
 		go r.fetchLoop(seekerMgr)
 	}
@@ -437,6 +447,11 @@ func (r *blockRetriever) fetchBatch(
 		return
 	}
 
+	var (
+		tagDecoderPool      = r.fsOpts.TagDecoderPool()
+		expired, hasExpired = r.expiredRetentionPeriod(blockStart)
+	)
+
 	for _, req := range reqs {
 		if limitErr != nil {
 			req.err = limitErr
@@ -459,11 +474,27 @@ func (r *blockRetriever) fetchBatch(
 			req.notFound = true
 		}
 
+		if hasExpired && !req.notFound {
+			seriesExpired, err := r.seriesExpired(entry, expired, tagDecoderPool)
+			if err != nil {
+				req.err = err
+				continue
+			}
+			if seriesExpired {
+				// Data past the retention of the series is not returned.
+				if tags := entry.EncodedTags; tags != nil {
+					tags.DecRef()
+					tags.Finalize()
+				}
+				entry = IndexEntry{}
+				req.notFound = true
+			}
+		}
+
 		req.indexEntry = entry
 	}
 
 	sort.Sort(retrieveRequestByIndexEntryOffsetAsc(reqs))
-	tagDecoderPool := r.fsOpts.TagDecoderPool()
 
 	blockCachingEnabled := r.opts.CacheBlocksOnRetrieve() && r.nsCacheBlocksOnRetrieve
 
@@ -555,6 +586,41 @@ func (r *blockRetriever) fetchBatch(
 	}
 }
 
+// expiredRetentionPeriod returns the longest retention rule period for which
+// data in the block has expired.
+func (r *blockRetriever) expiredRetentionPeriod(
+	blockStart time.Time,
+) (time.Duration, bool) {
+	if r.retentionRules == nil {
+		return 0, false
+	}
+	now := r.fsOpts.ClockOptions().NowFn()()
+	return r.retentionRules.ExpiredRetentionPeriod(blockStart, r.blockSize, now)
+}
+
+// seriesExpired returns whether the series of an index entry matches a
+// retention rule with a period no longer than the expired period.
+func (r *blockRetriever) seriesExpired(
+	entry IndexEntry,
+	expired time.Duration,
+	tagDecoderPool serialize.TagDecoderPool,
+) (bool, error) {
+	tags := entry.EncodedTags
+	if tags == nil || tags.Len() == 0 {
+		return false, nil
+	}
+
+	decoder := tagDecoderPool.Get()
+	decoder.Reset(tags)
+	period, ok := r.retentionRules.RetentionPeriod(decoder)
+	err := decoder.Err()
+	decoder.Close()
+	if err != nil {
+		return false, err
+	}
+	return ok && period <= expired, nil
+}
+
 // streamRequest returns a bool indicating if the ID was found, and any errors.
 func (r *blockRetriever) streamRequest(
 	ctx context.Context,
@@ -581,6 +581,62 @@ func TestBlockRetrieverIDDoesNotExist(t *testing.T) {
 	assert.Equal(t, nil, segment.Tail)
 }
 
+func TestBlockRetrieverFiltersSeriesExpiredByRetentionRules(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdb")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filePathPrefix := filepath.Join(dir, "")
+
+	fsOpts := testDefaultOpts.SetFilePathPrefix(filePathPrefix)
+	nsMeta, err := namespace.NewMetadata(testNs1ID, testNs1Metadata(t).Options().
+		SetRetentionRules([]namespace.RetentionRule{
+			{Filter: "env:dev", RetentionPeriod: testBlockSize},
+		}))
+	require.NoError(t, err)
+	nsCtx := namespace.NewContextFrom(nsMeta)
+	shard := uint32(0)
+	// The block is older than the retention of the dev series.
+	blockStart := time.Now().Truncate(testBlockSize).Add(-2 * testBlockSize)
+
+	opts := testBlockRetrieverOptions{
+		retrieverOpts: defaultTestBlockRetrieverOptions,
+		fsOpts:        fsOpts,
+		shards:        []uint32{shard},
+	}
+	retriever, cleanup := newOpenTestBlockRetriever(t, nsMeta, opts)
+	defer cleanup()
+
+	w, closer := newOpenTestWriter(t, fsOpts, shard, blockStart, 0)
+	for _, env := range []string{"dev", "prod"} {
+		data := checked.NewBytes([]byte("Hello world!"), nil)
+		data.IncRef()
+		defer data.DecRef()
+
+		metadata := persist.NewMetadataFromIDAndTags(ident.StringID(env),
+			ident.NewTags(ident.StringTag("env", env)), persist.MetadataOptions{})
+		err = w.Write(metadata, data, digest.Checksum(data.Bytes()))
+		require.NoError(t, err)
+	}
+	closer()
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	segmentReader, err := retriever.Stream(ctx, shard,
+		ident.StringID("dev"), blockStart, nil, nsCtx)
+	require.NoError(t, err)
+	segment, err := segmentReader.Segment()
+	require.NoError(t, err)
+	require.Nil(t, segment.Head)
+
+	segmentReader, err = retriever.Stream(ctx, shard,
+		ident.StringID("prod"), blockStart, nil, nsCtx)
+	require.NoError(t, err)
+	segment, err = segmentReader.Segment()
+	require.NoError(t, err)
+	require.Equal(t, []byte("Hello world!"), segment.Head.Bytes())
+}
+
 // TestBlockRetrieverOnlyCreatesTagItersIfTagsExists verifies that the block retriever
 // only creates a tag iterator in the OnRetrieve pathway if the series has tags.
 func TestBlockRetrieverOnlyCreatesTagItersIfTagsExists(t *testing.T) {
@@ -30,6 +30,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	xmsgpack "github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/x/xio"
@@ -72,6 +73,7 @@ type seeker struct {
 	start          xtime.UnixNano
 	blockSize      time.Duration
 	versionChecker schema.VersionChecker
+	compression    compression.Type
 
 	dataFd        *os.File
 	indexFd       *os.File
@@ -172,6 +174,18 @@ func (s *seeker) Open(
 		}
 	}
 
+	if !isLegacy {
+		id := NewFileSetFileIdentifier(namespace, blockStart, shard, volumeIndex)
+		release, err := fetchTieredFileSet(s.opts.opts, s.opts.filePathPrefix, id)
+		if err != nil {
+			return err
+		}
+		// NB: Unlike readers, seekers are long lived so the files are only
+		// pinned until opened, the seeker manager closes the seekers of
+		// filesets that have since been evicted.
+		defer release()
+	}
+
 	// Open necessary files
 	if err := openFiles(os.Open, map[string]**os.File{
 		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, infoFileSuffix, isLegacy):        &infoFd,
@@ -230,6 +244,7 @@ func (s *seeker) Open(
 	s.start = xtime.UnixNano(info.BlockStart)
 	s.blockSize = time.Duration(info.BlockSize)
 	s.versionChecker = schema.NewVersionChecker(int(info.MajorVersion), int(info.MinorVersion))
+	s.compression = info.Compression
 
 	err = s.validateIndexFileDigest(
 		indexFdWithDigest, expectedDigests.indexDigest)
@@ -341,6 +356,10 @@ func (s *seeker) SeekByIndexEntry(
 ) (checked.Bytes, error) {
 	resources.offsetFileReader.reset(s.dataFd, entry.Offset)
 
+	if s.compression != compression.NoneType {
+		return s.seekCompressedByIndexEntry(entry, resources)
+	}
+
 	// Obtain an appropriately sized buffer.
 	var buffer checked.Bytes
 	if s.opts.bytesPool != nil {
@@ -376,6 +395,38 @@ func (s *seeker) SeekByIndexEntry(
 	return buffer, nil
 }
 
+func (s *seeker) seekCompressedByIndexEntry(
+	entry IndexEntry,
+	resources ReusableSeekerResources,
+) (checked.Bytes, error) {
+	// The compressed data is only needed until it has been decompressed so
+	// release it back to the pool as soon as we're done with it.
+	compressed := newDataBytes(int(entry.Size), s.opts.bytesPool)
+	defer func() {
+		compressed.DecRef()
+		compressed.Finalize()
+	}()
+
+	if _, err := io.ReadFull(resources.offsetFileReader, compressed.Bytes()); err != nil {
+		return nil, err
+	}
+
+	buffer, err := decompressData(s.compression, compressed.Bytes(), s.opts.bytesPool)
+	if err != nil {
+		return nil, err
+	}
+
+	// NB: the checksum is of the uncompressed data so can only be validated
+	// once the data has been decompressed.
+	buffer.IncRef()
+	defer buffer.DecRef()
+	if entry.DataChecksum != digest.Checksum(buffer.Bytes()) {
+		return nil, errSeekChecksumMismatch
+	}
+
+	return buffer, nil
+}
+
 // SeekIndexEntry performs the following steps:
 //
 //     1. Go to the indexLookup and it will give us an offset that is a good starting
@@ -616,6 +667,7 @@ func (s *seeker) ConcurrentClone() (ConcurrentDataFileSetSeeker, error) {
 		dataFd:  s.dataFd,
 
 		versionChecker: s.versionChecker,
+		compression:    s.compression,
 	}
 
 	return seeker, nil
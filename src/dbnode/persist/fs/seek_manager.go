@@ -758,9 +758,16 @@ func (m *seekerManager) openAnyUnopenSeekers(byTime *seekersByTime) error {
 	start := m.earliestSeekableBlockStart()
 	end := m.latestSeekableBlockStart()
 	blockSize := m.namespaceMetadata.Options().RetentionOptions().BlockSize()
+	tierBefore := m.tierBefore()
 	multiErr := xerrors.NewMultiError()
 
 	for t := start; !t.After(end); t = t.Add(blockSize) {
+		if t.Before(tierBefore) {
+			// Seekers for tiered blocks are opened lazily when read so
+			// that they are not fetched from the secondary tier eagerly.
+			continue
+		}
+
 		byTime.Lock()
 		_, err := m.getOrOpenSeekersWithLock(xtime.ToUnixNano(t), byTime)
 		byTime.Unlock()
@@ -923,6 +930,34 @@ func (m *seekerManager) latestSeekableBlockStart() time.Time {
 	return now.Truncate(ropts.BlockSize())
 }
 
+// tierBefore returns the block start before which blocks may have been moved
+// to a secondary tier, or the zero time if tiering is disabled.
+func (m *seekerManager) tierBefore() time.Time {
+	policy := m.namespaceMetadata.Options().TieringPolicy()
+	if !policy.Enabled() || m.opts.TieredFileSetCache() == nil {
+		return time.Time{}
+	}
+	nowFn := m.opts.ClockOptions().NowFn()
+	blockSize := m.namespaceMetadata.Options().RetentionOptions().BlockSize()
+	return policy.TierBefore(nowFn(), blockSize)
+}
+
+// evictedFromTierCache returns whether the fileset of the seekers has been
+// moved to a secondary tier and evicted from the local cache, in which case
+// the seekers are closed to release the space held by the evicted files.
+func (m *seekerManager) evictedFromTierCache(
+	shard uint32,
+	blockStart time.Time,
+	tierBefore time.Time,
+	seekers rotatableSeekers,
+) bool {
+	if !blockStart.Before(tierBefore) || seekers.active.wg != nil {
+		return false
+	}
+	id := NewFileSetFileIdentifier(m.namespace, blockStart, shard, seekers.active.volume)
+	return !m.opts.TieredFileSetCache().Cached(m.filePathPrefix, id)
+}
+
 // openCloseLoop ensures to keep seekers open for those times where they are
 // available and closes them when they fall out of retention and expire.
 func (m *seekerManager) openCloseLoop() {
@@ -949,6 +984,7 @@ func (m *seekerManager) openCloseLoop() {
 	for {
 		earliestSeekableBlockStart :=
 			m.earliestSeekableBlockStart()
+		tierBefore := m.tierBefore()
 
 		m.RLock()
 		if m.status != seekerManagerOpen {
@@ -975,12 +1011,13 @@ func (m *seekerManager) openCloseLoop() {
 		m.RLock()
 		for shard, byTime := range m.seekersByShardIdx {
 			byTime.RLock()
-			for blockStartNano := range byTime.seekers {
+			for blockStartNano, seekers := range byTime.seekers {
 				blockStart := blockStartNano.ToTime()
 				if blockStart.Before(earliestSeekableBlockStart) ||
 					// Close seekers for shards that are no longer available. This
 					// ensure that seekers are eventually consistent w/ shard state.
-					!m.shardExistsWithLock(uint32(shard)) {
+					!m.shardExistsWithLock(uint32(shard)) ||
+					m.evictedFromTierCache(uint32(shard), blockStart, tierBefore, seekers) {
 					shouldClose = append(shouldClose, seekerManagerPendingClose{
 						shard:      uint32(shard),
 						blockStart: blockStart,
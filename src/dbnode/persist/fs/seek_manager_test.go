@@ -26,8 +26,11 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/ident"
 	xtest "github.com/m3db/m3/src/x/test"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -653,3 +656,92 @@ func TestSeekerManagerAssignShardSet(t *testing.T) {
 
 	require.NoError(t, m.Close())
 }
+
+type testTieredFileSetCache struct {
+	sync.Mutex
+	evicted map[xtime.UnixNano]struct{}
+}
+
+func (c *testTieredFileSetCache) Fetch(_ string, _ FileSetFileIdentifier) (func(), error) {
+	return func() {}, nil
+}
+
+func (c *testTieredFileSetCache) Cached(_ string, id FileSetFileIdentifier) bool {
+	c.Lock()
+	defer c.Unlock()
+	_, ok := c.evicted[xtime.ToUnixNano(id.BlockStart)]
+	return !ok
+}
+
+func TestSeekerManagerTieredBlocks(t *testing.T) {
+	defer leaktest.CheckTimeout(t, 1*time.Minute)()
+
+	var (
+		now    = time.Now().Truncate(testBlockSize)
+		policy = namespace.TieringPolicy{After: 4 * testBlockSize}
+		cache  = &testTieredFileSetCache{evicted: make(map[xtime.UnixNano]struct{})}
+		opts   = testDefaultOpts.
+			SetTieredFileSetCache(cache).
+			SetClockOptions(clock.NewOptions().SetNowFn(func() time.Time {
+				return now
+			}))
+		m = NewSeekerManager(nil, opts, defaultTestBlockRetrieverOptions).(*seekerManager)
+	)
+	md, err := namespace.NewMetadata(testNs1ID, namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().
+			SetBlockSize(testBlockSize).
+			SetRetentionPeriod(24*time.Hour)).
+		SetTieringPolicy(policy))
+	require.NoError(t, err)
+
+	var (
+		openedLock sync.Mutex
+		opened     []time.Time
+	)
+	m.newOpenSeekerFn = func(
+		shard uint32,
+		blockStart time.Time,
+		volume int,
+	) (DataFileSetSeeker, error) {
+		openedLock.Lock()
+		opened = append(opened, blockStart)
+		openedLock.Unlock()
+		return nil, errSeekerManagerFileSetNotFound
+	}
+
+	shardSet, err := sharding.NewShardSet(
+		sharding.NewShards([]uint32{1}, shard.Available),
+		sharding.DefaultHashFn(1),
+	)
+	require.NoError(t, err)
+	require.NoError(t, m.Open(md, shardSet))
+
+	byTime, ok := m.seekersByTime(1)
+	require.True(t, ok)
+	require.NoError(t, m.openAnyUnopenSeekers(byTime))
+
+	// Tiered blocks are not opened eagerly.
+	tierBefore := policy.TierBefore(now, testBlockSize)
+	require.Equal(t, now.Add(-4*testBlockSize), tierBefore)
+	openedLock.Lock()
+	require.NotEmpty(t, opened)
+	for _, blockStart := range opened {
+		require.False(t, blockStart.Before(tierBefore), blockStart.String())
+	}
+	openedLock.Unlock()
+
+	// Seekers are closed once their tiered fileset is evicted.
+	var (
+		tieredStart = tierBefore.Add(-testBlockSize)
+		seekers     = rotatableSeekers{}
+	)
+	require.False(t, m.evictedFromTierCache(1, tieredStart, tierBefore, seekers))
+	cache.Lock()
+	cache.evicted[xtime.ToUnixNano(tieredStart)] = struct{}{}
+	cache.evicted[xtime.ToUnixNano(tierBefore)] = struct{}{}
+	cache.Unlock()
+	require.True(t, m.evictedFromTierCache(1, tieredStart, tierBefore, seekers))
+	require.False(t, m.evictedFromTierCache(1, tierBefore, tierBefore, seekers))
+
+	require.NoError(t, m.Close())
+}
@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/x/ident"
 
@@ -58,6 +59,8 @@ type StreamingWriterOpenOptions struct {
 	BlockSize           time.Duration
 	VolumeIndex         int
 	PlannedRecordsCount uint
+	// Compression is the codec applied to the data of each series.
+	Compression compression.Type
 }
 
 type streamingWriter struct {
@@ -92,6 +95,7 @@ func (w *streamingWriter) Open(opts StreamingWriterOpenOptions) error {
 			VolumeIndex: opts.VolumeIndex,
 		},
 		FileSetType: persist.FileSetFlushType,
+		Compression: opts.Compression,
 	}
 
 	plannedRecordsCount := opts.PlannedRecordsCount
@@ -155,17 +159,18 @@ func (w *streamingWriter) writeData(
 		return indexEntry{}, false, nil
 	}
 
+	offset := w.writer.currOffset
+	size, err := w.writer.writeEntryData(data)
+	if err != nil {
+		return indexEntry{}, false, err
+	}
+
 	entry := indexEntry{
 		index:          w.currIdx,
-		dataFileOffset: w.writer.currOffset,
+		dataFileOffset: offset,
 		size:           uint32(size),
 		dataChecksum:   dataChecksum,
 	}
-	for _, d := range data {
-		if err := w.writer.writeData(d); err != nil {
-			return indexEntry{}, false, err
-		}
-	}
 
 	w.currIdx++
 
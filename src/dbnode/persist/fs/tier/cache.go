@@ -0,0 +1,262 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tier
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+
+	"go.uber.org/zap"
+)
+
+func noopRelease() {}
+
+func (m *manager) Fetch(filePathPrefix string, id fs.FileSetFileIdentifier) (func(), error) {
+	markerPath := fs.TieredFileSetFilePath(filePathPrefix, id)
+
+	m.Lock()
+	entry, ok := m.entries[markerPath]
+	for ok && entry.fetchWg != nil {
+		// Another reader is fetching the fileset, wait for it to finish.
+		wg := entry.fetchWg
+		m.Unlock()
+		wg.Wait()
+		m.Lock()
+		entry, ok = m.entries[markerPath]
+	}
+	if ok && entry.elem != nil {
+		m.lru.MoveToFront(entry.elem)
+		release := m.pinWithLock(entry)
+		m.Unlock()
+		m.metrics.cacheHits.Inc(1)
+		return release, nil
+	}
+	if !ok {
+		entry = &cacheEntry{}
+		m.entries[markerPath] = entry
+	}
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	entry.fetchWg = wg
+	m.Unlock()
+
+	mk, tiered, err := m.fetchFileSet(markerPath)
+
+	release := noopRelease
+	m.Lock()
+	entry.fetchWg = nil
+	if m.entries[markerPath] == entry {
+		switch {
+		case err == nil && tiered:
+			entry.paths = mk.localPaths(markerPath)
+			entry.size = markerSize(mk)
+			release = m.pinWithLock(entry)
+			m.addToCacheWithLock(entry)
+		case entry.size == 0:
+			// Only filesets that have been cached before are tracked once
+			// evicted, so that Cached reports them as evicted.
+			delete(m.entries, markerPath)
+		}
+	}
+	m.Unlock()
+	wg.Done()
+
+	if err != nil {
+		m.metrics.fetchErrors.Inc(1)
+		return nil, fmt.Errorf("error fetching tiered fileset %s: %v", markerPath, err)
+	}
+	return release, nil
+}
+
+// pinWithLock prevents the entry from being evicted until the returned
+// function is called, so that fetched files are not removed before the
+// caller has opened them.
+func (m *manager) pinWithLock(entry *cacheEntry) func() {
+	entry.pins++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			m.Lock()
+			entry.pins--
+			if entry.pins == 0 && entry.elem != nil {
+				// The cache may have grown past its size while the entry
+				// was pinned.
+				m.evictToCacheSizeWithLock()
+			}
+			m.Unlock()
+		})
+	}
+}
+
+func (m *manager) Cached(filePathPrefix string, id fs.FileSetFileIdentifier) bool {
+	markerPath := fs.TieredFileSetFilePath(filePathPrefix, id)
+
+	m.Lock()
+	defer m.Unlock()
+
+	entry, ok := m.entries[markerPath]
+	return !ok || entry.elem != nil || entry.fetchWg != nil
+}
+
+// fetchFileSet copies the tiered files of a fileset from the store, files
+// that are already present locally are not fetched again.
+func (m *manager) fetchFileSet(markerPath string) (marker, bool, error) {
+	mk, err := readMarker(markerPath)
+	if os.IsNotExist(err) {
+		return marker{}, false, nil
+	}
+	if err != nil {
+		return marker{}, false, err
+	}
+
+	localPaths := mk.localPaths(markerPath)
+	for i, f := range mk.Files {
+		if localFileSize(localPaths[i]) == f.Size {
+			continue
+		}
+		if err := m.getFile(f, localPaths[i]); err != nil {
+			return marker{}, false, err
+		}
+		m.metrics.fetchBytes.Inc(f.Size)
+	}
+
+	m.metrics.fetched.Inc(1)
+	return mk, true, nil
+}
+
+func (m *manager) getFile(f markerFile, localPath string) error {
+	r, err := m.opts.Store.Get(f.Key)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return writeFileAtomic(localPath, m.newFileMode, func(file *os.File) error {
+		n, err := io.Copy(file, r)
+		if err != nil {
+			return err
+		}
+		if n != f.Size {
+			return fmt.Errorf("fetched %d bytes for %s, expected %d", n, f.Key, f.Size)
+		}
+		return nil
+	})
+}
+
+// adopt adds the local copies of the tiered files of a fileset to the cache
+// if they are not already tracked, such as after a restart.
+func (m *manager) adopt(markerPath string) error {
+	m.Lock()
+	_, ok := m.entries[markerPath]
+	m.Unlock()
+	if ok {
+		return nil
+	}
+
+	mk, err := readMarker(markerPath)
+	if err != nil {
+		return err
+	}
+
+	entry := &cacheEntry{}
+	for i, localPath := range mk.localPaths(markerPath) {
+		if size := localFileSize(localPath); size >= 0 {
+			entry.paths = append(entry.paths, localPath)
+			entry.size += mk.Files[i].Size
+		}
+	}
+	if len(entry.paths) == 0 {
+		return nil
+	}
+
+	m.Lock()
+	if _, ok := m.entries[markerPath]; !ok {
+		m.addToCacheWithLock(entry)
+		m.entries[markerPath] = entry
+	}
+	m.Unlock()
+	return nil
+}
+
+// addToCacheWithLock adds the entry to the front of the LRU and evicts the
+// least recently read entries until the cache is within its size.
+func (m *manager) addToCacheWithLock(entry *cacheEntry) {
+	entry.elem = m.lru.PushFront(entry)
+	m.cachedBytes += entry.size
+	m.evictToCacheSizeWithLock()
+	m.metrics.cachedBytes.Update(float64(m.cachedBytes))
+}
+
+// evictToCacheSizeWithLock evicts the least recently read entries until the
+// cache is within its size, the most recently read entry and pinned entries
+// are never evicted.
+func (m *manager) evictToCacheSizeWithLock() {
+	elem := m.lru.Back()
+	for elem != nil && elem != m.lru.Front() && m.cachedBytes > m.opts.CacheSizeBytes {
+		prev := elem.Prev()
+		if entry := elem.Value.(*cacheEntry); entry.pins == 0 {
+			m.evictWithLock(entry)
+		}
+		elem = prev
+	}
+}
+
+func (m *manager) evictWithLock(entry *cacheEntry) {
+	m.removeFromCacheWithLock(entry)
+	for _, localPath := range entry.paths {
+		if err := os.Remove(localPath); err != nil && !os.IsNotExist(err) {
+			m.logger.Error("error removing evicted tiered file",
+				zap.String("path", localPath), zap.Error(err))
+		}
+	}
+	m.metrics.evicted.Inc(1)
+}
+
+func (m *manager) removeFromCacheWithLock(entry *cacheEntry) {
+	if entry.elem == nil {
+		return
+	}
+	m.lru.Remove(entry.elem)
+	entry.elem = nil
+	m.cachedBytes -= entry.size
+	m.metrics.cachedBytes.Update(float64(m.cachedBytes))
+}
+
+func markerSize(mk marker) int64 {
+	var size int64
+	for _, f := range mk.Files {
+		size += f.Size
+	}
+	return size
+}
+
+// localFileSize returns the size of a local file or -1 if it does not exist.
+func localFileSize(filePath string) int64 {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return -1
+	}
+	return info.Size()
+}
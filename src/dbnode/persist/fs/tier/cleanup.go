@@ -0,0 +1,145 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tier
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	xerrors "github.com/m3db/m3/src/x/errors"
+)
+
+func (m *manager) DeleteFiles(filePaths []string) error {
+	var (
+		multiErr = xerrors.NewMultiError()
+		skip     = make(map[string]struct{})
+	)
+	for _, filePath := range filePaths {
+		if !fs.IsTieredFileSetFile(filePath) {
+			continue
+		}
+		localPaths, err := m.deleteTieredFiles(filePath)
+		if err != nil {
+			// Keep the marker so that deleting the tiered files is retried.
+			multiErr = multiErr.Add(fmt.Errorf(
+				"failed to delete tiered files of %s: %v", filePath, err))
+			skip[filePath] = struct{}{}
+			continue
+		}
+		for _, localPath := range localPaths {
+			skip[localPath] = struct{}{}
+		}
+	}
+
+	// Skip markers that must be kept and local copies already removed.
+	toDelete := make([]string, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		if _, ok := skip[filePath]; !ok {
+			toDelete = append(toDelete, filePath)
+		}
+	}
+	multiErr = multiErr.Add(fs.DeleteFiles(toDelete))
+	return multiErr.FinalError()
+}
+
+func (m *manager) DeleteInactiveDirectories(
+	parentDirectoryPath string,
+	activeDirectories []string,
+) error {
+	entries, err := ioutil.ReadDir(parentDirectoryPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	active := make(map[string]struct{}, len(activeDirectories))
+	for _, dir := range activeDirectories {
+		active[dir] = struct{}{}
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, entry := range entries {
+		if _, ok := active[entry.Name()]; ok || !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(parentDirectoryPath, entry.Name())
+		multiErr = multiErr.Add(m.deleteTieredFilesInDirectory(dir))
+	}
+	if err := multiErr.FinalError(); err != nil {
+		// Keep the directories so that deleting the tiered files is retried.
+		return err
+	}
+
+	return fs.DeleteInactiveDirectories(parentDirectoryPath, activeDirectories)
+}
+
+func (m *manager) deleteTieredFilesInDirectory(dir string) error {
+	return filepath.Walk(dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !fs.IsTieredFileSetFile(filePath) {
+			return nil
+		}
+		_, err = m.deleteTieredFiles(filePath)
+		return err
+	})
+}
+
+// deleteTieredFiles deletes the tiered files of a fileset from the store and
+// the local cache, returning the local paths of the tiered files.
+func (m *manager) deleteTieredFiles(markerPath string) ([]string, error) {
+	mk, err := readMarker(markerPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range mk.Files {
+		if err := m.deleter.Delete(f.Key); err != nil {
+			return nil, err
+		}
+	}
+
+	m.Lock()
+	if entry, ok := m.entries[markerPath]; ok {
+		m.removeFromCacheWithLock(entry)
+		delete(m.entries, markerPath)
+	}
+	m.Unlock()
+
+	localPaths := mk.localPaths(markerPath)
+	for _, localPath := range localPaths {
+		if err := os.Remove(localPath); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	m.metrics.deleted.Inc(1)
+	return localPaths, nil
+}
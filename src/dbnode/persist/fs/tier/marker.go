@@ -0,0 +1,102 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tier
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// marker is the contents of the marker file of a tiered fileset.
+type marker struct {
+	Files []markerFile `json:"files"`
+}
+
+// markerFile is a file of a fileset that has been moved to the store.
+type markerFile struct {
+	// Name is the name of the file in the fileset directory.
+	Name string `json:"name"`
+	// Key is the key of the file in the store.
+	Key string `json:"key"`
+	// Size is the size of the file in bytes.
+	Size int64 `json:"size"`
+}
+
+// localPaths returns the local paths of the tiered files of the marker.
+func (m marker) localPaths(markerPath string) []string {
+	dir := filepath.Dir(markerPath)
+	paths := make([]string, 0, len(m.Files))
+	for _, f := range m.Files {
+		paths = append(paths, filepath.Join(dir, f.Name))
+	}
+	return paths
+}
+
+func readMarker(markerPath string) (marker, error) {
+	data, err := ioutil.ReadFile(markerPath)
+	if err != nil {
+		return marker{}, err
+	}
+
+	var m marker
+	if err := json.Unmarshal(data, &m); err != nil {
+		return marker{}, err
+	}
+	return m, nil
+}
+
+func writeMarker(markerPath string, m marker, perm os.FileMode) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(markerPath, perm, func(f *os.File) error {
+		_, err := f.Write(data)
+		return err
+	})
+}
+
+// writeFileAtomic writes a file to a temporary path and renames it into place
+// so that readers never observe a partially written file.
+func writeFileAtomic(filePath string, perm os.FileMode, writeFn func(f *os.File) error) error {
+	tmpPath := filePath + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if err := writeFn(f); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package tier moves flushed data filesets to a secondary tier, such as a
+// cheaper volume or an object store, and fetches them back into a bounded
+// local cache when they are read.
+//
+// Only the index and data files of a fileset are tiered, the info, summaries,
+// bloom filter, digest and checkpoint files are small and kept locally so that
+// filesets can still be discovered, bootstrapped from and tested for series
+// without fetching them. A marker file recording the keys of the tiered files
+// is written alongside the remaining files.
+package tier
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+var (
+	errFilesystemOptionsNotSet = errors.New("filesystem options not set")
+	errStoreNotSet             = errors.New("tier store not set")
+	errStoreNotDeleter         = errors.New("tier store does not support deletes")
+	errCacheSizeNegative       = errors.New("tier cache size must not be negative")
+)
+
+// Options are the options for tiering filesets.
+type Options struct {
+	// FilesystemOptions are the options of the filesystem filesets are
+	// tiered from.
+	FilesystemOptions fs.Options
+	// Store is the secondary tier that filesets are moved to, it must also
+	// implement backup.Deleter so that tiered files can be cleaned up.
+	Store backup.Store
+	// KeyPrefix is prepended to the keys of tiered files, nodes sharing a
+	// store must use distinct prefixes.
+	KeyPrefix string
+	// CacheSizeBytes is the max size of the tiered files kept on the local
+	// filesystem once fetched, the least recently read filesets are evicted
+	// first.
+	CacheSizeBytes int64
+}
+
+// Validate validates the options.
+func (o Options) Validate() error {
+	if o.FilesystemOptions == nil {
+		return errFilesystemOptionsNotSet
+	}
+	if o.Store == nil {
+		return errStoreNotSet
+	}
+	if _, ok := o.Store.(backup.Deleter); !ok {
+		return errStoreNotDeleter
+	}
+	if o.CacheSizeBytes < 0 {
+		return errCacheSizeNegative
+	}
+	return nil
+}
+
+// Manager moves filesets to a secondary tier and fetches them back on read.
+type Manager interface {
+	fs.TieredFileSetCache
+
+	// TierFileSets moves the complete data filesets of a shard with block
+	// starts before the given time to the secondary tier.
+	TierFileSets(namespace ident.ID, shard uint32, tierBefore time.Time) error
+
+	// DeleteFiles deletes a set of files, the tiered files of any tiered
+	// fileset markers in the set are deleted from the secondary tier first.
+	DeleteFiles(filePaths []string) error
+
+	// DeleteInactiveDirectories deletes the directories in the parent
+	// directory that are not active, deleting the tiered files of any tiered
+	// fileset markers in them from the secondary tier first.
+	DeleteInactiveDirectories(parentDirectoryPath string, activeDirectories []string) error
+}
+
+type managerMetrics struct {
+	tiered      tally.Counter
+	tieredBytes tally.Counter
+	tierErrors  tally.Counter
+	fetched     tally.Counter
+	fetchBytes  tally.Counter
+	fetchErrors tally.Counter
+	cacheHits   tally.Counter
+	evicted     tally.Counter
+	deleted     tally.Counter
+	cachedBytes tally.Gauge
+}
+
+func newManagerMetrics(scope tally.Scope) managerMetrics {
+	return managerMetrics{
+		tiered:      scope.Counter("tiered"),
+		tieredBytes: scope.Counter("tiered-bytes"),
+		tierErrors:  scope.Counter("tier-errors"),
+		fetched:     scope.Counter("fetched"),
+		fetchBytes:  scope.Counter("fetched-bytes"),
+		fetchErrors: scope.Counter("fetch-errors"),
+		cacheHits:   scope.Counter("cache-hits"),
+		evicted:     scope.Counter("evicted"),
+		deleted:     scope.Counter("deleted"),
+		cachedBytes: scope.Gauge("cached-bytes"),
+	}
+}
+
+// cacheEntry tracks the local copies of the tiered files of a fileset, it is
+// in one of three states: being fetched, cached or evicted. Cached entries
+// with pins are not evicted.
+type cacheEntry struct {
+	paths   []string
+	size    int64
+	pins    int
+	elem    *list.Element
+	fetchWg *sync.WaitGroup
+}
+
+type manager struct {
+	sync.Mutex
+
+	opts           Options
+	filePathPrefix string
+	newFileMode    os.FileMode
+	deleter        backup.Deleter
+	logger         *zap.Logger
+	metrics        managerMetrics
+
+	// lru orders the cached entries from most to least recently read.
+	lru         *list.List
+	entries     map[string]*cacheEntry
+	cachedBytes int64
+}
+
+// NewManager returns a new tiering manager.
+func NewManager(opts Options) (Manager, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	iOpts := opts.FilesystemOptions.InstrumentOptions()
+	return &manager{
+		opts:           opts,
+		filePathPrefix: opts.FilesystemOptions.FilePathPrefix(),
+		newFileMode:    opts.FilesystemOptions.NewFileMode(),
+		deleter:        opts.Store.(backup.Deleter),
+		logger:         iOpts.Logger(),
+		metrics:        newManagerMetrics(iOpts.MetricsScope().SubScope("tier")),
+		lru:            list.New(),
+		entries:        make(map[string]*cacheEntry),
+	}, nil
+}
+
+func (m *manager) TierFileSets(
+	namespace ident.ID,
+	shard uint32,
+	tierBefore time.Time,
+) error {
+	fileSets, err := fs.DataFiles(m.filePathPrefix, namespace, shard)
+	if err != nil {
+		return err
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for i, fileSet := range fileSets {
+		if !fileSet.ID.BlockStart.Before(tierBefore) {
+			// Filesets are sorted by block start.
+			break
+		}
+		if !fileSet.HasCompleteCheckpointFile() {
+			continue
+		}
+		if i+1 < len(fileSets) && fileSets[i+1].ID.BlockStart.Equal(fileSet.ID.BlockStart) {
+			// Only the latest volume of a block is tiered, earlier volumes
+			// are about to be cleaned up.
+			continue
+		}
+
+		markerPath := fs.TieredFileSetFilePath(m.filePathPrefix, fileSet.ID)
+		if containsString(fileSet.AbsoluteFilePaths, markerPath) {
+			// Already tiered, track any local copies left over from before
+			// a restart so that they are evicted.
+			if err := m.adopt(markerPath); err != nil {
+				multiErr = multiErr.Add(err)
+			}
+			continue
+		}
+
+		paths := fs.TieredFileSetFilePaths(m.filePathPrefix, fileSet.ID)
+		if !containsAllStrings(fileSet.AbsoluteFilePaths, paths) {
+			// Legacy filesets without a volume index are not tiered.
+			continue
+		}
+		if err := m.tierFileSet(markerPath, paths); err != nil {
+			m.metrics.tierErrors.Inc(1)
+			multiErr = multiErr.Add(fmt.Errorf(
+				"error tiering fileset %s: %v", markerPath, err))
+		}
+	}
+
+	return multiErr.FinalError()
+}
+
+func (m *manager) tierFileSet(markerPath string, paths []string) error {
+	var (
+		mk   marker
+		size int64
+	)
+	for _, filePath := range paths {
+		file, err := m.putFile(filePath)
+		if err != nil {
+			return err
+		}
+		mk.Files = append(mk.Files, file)
+		size += file.Size
+	}
+
+	// The marker is only written once all files are in the store, a fileset
+	// interrupted before then is tiered again from scratch.
+	if err := writeMarker(markerPath, mk, m.newFileMode); err != nil {
+		return err
+	}
+	m.metrics.tiered.Inc(1)
+	m.metrics.tieredBytes.Inc(size)
+
+	// The local files become the cached copy and are removed once evicted.
+	m.Lock()
+	if _, ok := m.entries[markerPath]; !ok {
+		entry := &cacheEntry{paths: paths, size: size}
+		m.addToCacheWithLock(entry)
+		m.entries[markerPath] = entry
+	}
+	m.Unlock()
+	return nil
+}
+
+func (m *manager) putFile(filePath string) (markerFile, error) {
+	relPath, err := filepath.Rel(m.filePathPrefix, filePath)
+	if err != nil {
+		return markerFile{}, err
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return markerFile{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return markerFile{}, err
+	}
+
+	key := path.Join(m.opts.KeyPrefix, filepath.ToSlash(relPath))
+	if err := m.opts.Store.Put(key, f, info.Size()); err != nil {
+		return markerFile{}, err
+	}
+	return markerFile{
+		Name: filepath.Base(filePath),
+		Key:  key,
+		Size: info.Size(),
+	}, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAllStrings(values []string, required []string) bool {
+	for _, r := range required {
+		if !containsString(values, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,325 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tier
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testShard = uint32(1)
+
+var testBlockStart = time.Unix(0, 0).Add(100 * 2 * time.Hour)
+
+type testSetup struct {
+	fsOpts  fs.Options
+	md      namespace.Metadata
+	store   backup.Store
+	manager Manager
+}
+
+func newTestSetup(t *testing.T, cacheSizeBytes int64) (testSetup, func()) {
+	dir, err := ioutil.TempDir("", "tier")
+	require.NoError(t, err)
+
+	md, err := namespace.NewMetadata(ident.StringID("testns"), namespace.NewOptions())
+	require.NoError(t, err)
+
+	var (
+		fsOpts   = fs.NewOptions().SetFilePathPrefix(filepath.Join(dir, "local"))
+		storeDir = filepath.Join(dir, "store")
+		store    = backup.NewDirectoryStore(storeDir)
+	)
+	manager, err := NewManager(Options{
+		FilesystemOptions: fsOpts,
+		Store:             store,
+		KeyPrefix:         "host-a",
+		CacheSizeBytes:    cacheSizeBytes,
+	})
+	require.NoError(t, err)
+
+	return testSetup{
+		fsOpts:  fsOpts.SetTieredFileSetCache(manager),
+		md:      md,
+		store:   store,
+		manager: manager,
+	}, func() { os.RemoveAll(dir) }
+}
+
+func (s testSetup) id(blockStart time.Time) fs.FileSetFileIdentifier {
+	return fs.NewFileSetFileIdentifier(s.md.ID(), blockStart, testShard, 0)
+}
+
+func (s testSetup) blockSize() time.Duration {
+	return s.md.Options().RetentionOptions().BlockSize()
+}
+
+func writeTestFileSet(
+	t *testing.T,
+	s testSetup,
+	blockStart time.Time,
+	series map[string][]byte,
+) {
+	writer, err := fs.NewWriter(s.fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+		Identifier:  s.id(blockStart),
+		BlockSize:   s.blockSize(),
+		FileSetType: persist.FileSetFlushType,
+	}))
+	for id, data := range series {
+		bytes := checked.NewBytes(data, nil)
+		bytes.IncRef()
+		metadata := persist.NewMetadataFromIDAndTags(ident.StringID(id),
+			ident.Tags{}, persist.MetadataOptions{})
+		require.NoError(t, writer.Write(metadata, bytes, 0))
+		bytes.DecRef()
+	}
+	require.NoError(t, writer.Close())
+}
+
+func readTestFileSet(
+	t *testing.T,
+	s testSetup,
+	blockStart time.Time,
+) map[string][]byte {
+	reader, err := fs.NewReader(nil, s.fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{
+		Identifier:  s.id(blockStart),
+		FileSetType: persist.FileSetFlushType,
+	}))
+	defer reader.Close()
+
+	results := make(map[string][]byte)
+	for {
+		id, _, data, _, err := reader.Read()
+		if err == io.EOF {
+			return results
+		}
+		require.NoError(t, err)
+		data.IncRef()
+		results[id.String()] = append([]byte(nil), data.Bytes()...)
+		data.DecRef()
+	}
+}
+
+func requireLocalFiles(t *testing.T, s testSetup, blockStart time.Time, exist bool) {
+	prefix := s.fsOpts.FilePathPrefix()
+	for _, path := range fs.TieredFileSetFilePaths(prefix, s.id(blockStart)) {
+		_, err := os.Stat(path)
+		if exist {
+			require.NoError(t, err, path)
+		} else {
+			require.True(t, os.IsNotExist(err), path)
+		}
+	}
+}
+
+func TestTierFileSetsAndFetch(t *testing.T) {
+	s, closer := newTestSetup(t, 0)
+	defer closer()
+
+	var (
+		prefix      = s.fsOpts.FilePathPrefix()
+		secondStart = testBlockStart.Add(s.blockSize())
+		series      = map[string][]byte{"foo": []byte("foo data"), "bar": []byte("bar data")}
+	)
+	writeTestFileSet(t, s, testBlockStart, series)
+	writeTestFileSet(t, s, secondStart, series)
+
+	// Only blocks before the given time are tiered.
+	require.NoError(t, s.manager.TierFileSets(s.md.ID(), testShard, secondStart))
+	exists, err := fs.FileExists(fs.TieredFileSetFilePath(prefix, s.id(testBlockStart)))
+	require.NoError(t, err)
+	require.True(t, exists)
+	exists, err = fs.FileExists(fs.TieredFileSetFilePath(prefix, s.id(secondStart)))
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	r, err := s.store.Get("host-a/data/testns/1/" +
+		filepath.Base(fs.TieredFileSetFilePaths(prefix, s.id(testBlockStart))[1]))
+	require.NoError(t, err)
+	r.Close()
+
+	// The local files of the most recently tiered fileset are kept as its
+	// cached copy, tiering the second evicts the first as the cache is empty.
+	requireLocalFiles(t, s, testBlockStart, true)
+	require.NoError(t, s.manager.TierFileSets(s.md.ID(), testShard,
+		secondStart.Add(s.blockSize())))
+	requireLocalFiles(t, s, testBlockStart, false)
+	requireLocalFiles(t, s, secondStart, true)
+	require.False(t, s.manager.Cached(prefix, s.id(testBlockStart)))
+	require.True(t, s.manager.Cached(prefix, s.id(secondStart)))
+
+	// Reading the evicted fileset fetches it back.
+	require.Equal(t, series, readTestFileSet(t, s, testBlockStart))
+	requireLocalFiles(t, s, testBlockStart, true)
+	requireLocalFiles(t, s, secondStart, false)
+	require.True(t, s.manager.Cached(prefix, s.id(testBlockStart)))
+	require.False(t, s.manager.Cached(prefix, s.id(secondStart)))
+}
+
+func TestTierFileSetsKeepsCachedFileSetsWithinSize(t *testing.T) {
+	s, closer := newTestSetup(t, 1<<20)
+	defer closer()
+
+	secondStart := testBlockStart.Add(s.blockSize())
+	writeTestFileSet(t, s, testBlockStart, map[string][]byte{"foo": []byte("foo data")})
+	writeTestFileSet(t, s, secondStart, map[string][]byte{"foo": []byte("foo data")})
+
+	require.NoError(t, s.manager.TierFileSets(s.md.ID(), testShard,
+		secondStart.Add(s.blockSize())))
+	requireLocalFiles(t, s, testBlockStart, true)
+	requireLocalFiles(t, s, secondStart, true)
+}
+
+func TestFetchNotTieredFileSet(t *testing.T) {
+	s, closer := newTestSetup(t, 0)
+	defer closer()
+
+	series := map[string][]byte{"foo": []byte("foo data")}
+	writeTestFileSet(t, s, testBlockStart, series)
+
+	prefix := s.fsOpts.FilePathPrefix()
+	release, err := s.manager.Fetch(prefix, s.id(testBlockStart))
+	require.NoError(t, err)
+	release()
+	require.True(t, s.manager.Cached(prefix, s.id(testBlockStart)))
+	require.Equal(t, series, readTestFileSet(t, s, testBlockStart))
+}
+
+func TestFetchPinsFileSetUntilReleased(t *testing.T) {
+	s, closer := newTestSetup(t, 0)
+	defer closer()
+
+	var (
+		prefix      = s.fsOpts.FilePathPrefix()
+		secondStart = testBlockStart.Add(s.blockSize())
+	)
+	writeTestFileSet(t, s, testBlockStart, map[string][]byte{"foo": []byte("foo data")})
+	writeTestFileSet(t, s, secondStart, map[string][]byte{"foo": []byte("foo data")})
+	require.NoError(t, s.manager.TierFileSets(s.md.ID(), testShard,
+		secondStart.Add(s.blockSize())))
+	requireLocalFiles(t, s, testBlockStart, false)
+
+	releaseFirst, err := s.manager.Fetch(prefix, s.id(testBlockStart))
+	require.NoError(t, err)
+	requireLocalFiles(t, s, testBlockStart, true)
+	requireLocalFiles(t, s, secondStart, false)
+
+	// Fetching another fileset does not evict the pinned fileset even though
+	// the cache is over its size.
+	releaseSecond, err := s.manager.Fetch(prefix, s.id(secondStart))
+	require.NoError(t, err)
+	requireLocalFiles(t, s, testBlockStart, true)
+	requireLocalFiles(t, s, secondStart, true)
+
+	// Releasing the pin evicts the fileset, releasing twice is a no-op.
+	releaseFirst()
+	releaseFirst()
+	requireLocalFiles(t, s, testBlockStart, false)
+	require.False(t, s.manager.Cached(prefix, s.id(testBlockStart)))
+
+	// The most recently read fileset is kept once released.
+	releaseSecond()
+	requireLocalFiles(t, s, secondStart, true)
+}
+
+func TestDeleteFilesDeletesTieredFiles(t *testing.T) {
+	s, closer := newTestSetup(t, 0)
+	defer closer()
+
+	var (
+		prefix      = s.fsOpts.FilePathPrefix()
+		secondStart = testBlockStart.Add(s.blockSize())
+	)
+	writeTestFileSet(t, s, testBlockStart, map[string][]byte{"foo": []byte("foo data")})
+	writeTestFileSet(t, s, secondStart, map[string][]byte{"foo": []byte("foo data")})
+	require.NoError(t, s.manager.TierFileSets(s.md.ID(), testShard,
+		secondStart.Add(s.blockSize())))
+
+	fileSets, err := fs.DataFiles(prefix, s.md.ID(), testShard)
+	require.NoError(t, err)
+	require.Len(t, fileSets, 2)
+	for _, fileSet := range fileSets {
+		require.NoError(t, s.manager.DeleteFiles(fileSet.AbsoluteFilePaths))
+	}
+
+	fileSets, err = fs.DataFiles(prefix, s.md.ID(), testShard)
+	require.NoError(t, err)
+	require.Len(t, fileSets, 0)
+
+	for _, blockStart := range []time.Time{testBlockStart, secondStart} {
+		for _, path := range fs.TieredFileSetFilePaths(prefix, s.id(blockStart)) {
+			_, err := s.store.Get("host-a/data/testns/1/" + filepath.Base(path))
+			require.Equal(t, backup.ErrNotFound, err)
+		}
+	}
+}
+
+func TestDeleteInactiveDirectoriesDeletesTieredFiles(t *testing.T) {
+	s, closer := newTestSetup(t, 0)
+	defer closer()
+
+	prefix := s.fsOpts.FilePathPrefix()
+	writeTestFileSet(t, s, testBlockStart, map[string][]byte{"foo": []byte("foo data")})
+	require.NoError(t, s.manager.TierFileSets(s.md.ID(), testShard,
+		testBlockStart.Add(s.blockSize())))
+
+	nsDir := fs.NamespaceDataDirPath(prefix, s.md.ID())
+	require.NoError(t, s.manager.DeleteInactiveDirectories(nsDir, []string{"2"}))
+
+	_, err := os.Stat(fs.ShardDataDirPath(prefix, s.md.ID(), testShard))
+	require.True(t, os.IsNotExist(err))
+	for _, path := range fs.TieredFileSetFilePaths(prefix, s.id(testBlockStart)) {
+		_, err := s.store.Get("host-a/data/testns/1/" + filepath.Base(path))
+		require.Equal(t, backup.ErrNotFound, err)
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	fsOpts := fs.NewOptions()
+	require.Error(t, Options{Store: backup.NewDirectoryStore("/tmp")}.Validate())
+	require.Error(t, Options{FilesystemOptions: fsOpts}.Validate())
+	require.Error(t, Options{
+		FilesystemOptions: fsOpts,
+		Store:             backup.NewDirectoryStore("/tmp"),
+		CacheSizeBytes:    -1,
+	}.Validate())
+	require.NoError(t, Options{
+		FilesystemOptions: fsOpts,
+		Store:             backup.NewDirectoryStore("/tmp"),
+	}.Validate())
+}
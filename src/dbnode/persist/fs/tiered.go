@@ -0,0 +1,66 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+var tieredFileSetFileSuffixes = []string{indexFileSuffix, dataFileSuffix}
+
+// TieredFileSetFilePath returns the path of the marker file that records a
+// data fileset has been moved to a secondary tier. The marker shares the name
+// of the other files of the fileset so that it is cleaned up along with them.
+func TieredFileSetFilePath(filePathPrefix string, id FileSetFileIdentifier) string {
+	shardDir := ShardDataDirPath(filePathPrefix, id.Namespace, id.Shard)
+	return filesetPathFromTimeAndIndex(shardDir, id.BlockStart, id.VolumeIndex, tieredFileSuffix)
+}
+
+// TieredFileSetFilePaths returns the paths of the files of a data fileset that
+// are moved to a secondary tier, the remaining files are small enough to
+// always be kept on the local filesystem.
+func TieredFileSetFilePaths(filePathPrefix string, id FileSetFileIdentifier) []string {
+	shardDir := ShardDataDirPath(filePathPrefix, id.Namespace, id.Shard)
+	paths := make([]string, 0, len(tieredFileSetFileSuffixes))
+	for _, suffix := range tieredFileSetFileSuffixes {
+		paths = append(paths, filesetPathFromTimeAndIndex(shardDir,
+			id.BlockStart, id.VolumeIndex, suffix))
+	}
+	return paths
+}
+
+// IsTieredFileSetFile returns whether the path is the marker file of a data
+// fileset that has been moved to a secondary tier.
+func IsTieredFileSetFile(filePath string) bool {
+	return strings.HasSuffix(filepath.Base(filePath), separator+tieredFileSuffix+fileSuffix)
+}
+
+func fetchTieredFileSet(
+	opts Options,
+	filePathPrefix string,
+	id FileSetFileIdentifier,
+) (func(), error) {
+	if opts == nil || opts.TieredFileSetCache() == nil {
+		return func() {}, nil
+	}
+	return opts.TieredFileSetCache().Fetch(filePathPrefix, id)
+}
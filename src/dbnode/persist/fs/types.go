@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/runtime"
@@ -70,6 +71,9 @@ type DataWriterOpenOptions struct {
 	BlockSize          time.Duration
 	// Only used when writing snapshot files
 	Snapshot DataWriterSnapshotOptions
+	// Compression is the codec applied to the data of each series, the
+	// default leaves data files uncompressed.
+	Compression compression.Type
 }
 
 // DataWriterSnapshotOptions is the options struct for Open method on the DataFileSetWriter
@@ -545,6 +549,29 @@ type Options interface {
 
 	// EncodingOptions returns the encoder options used by the encoder.
 	EncodingOptions() msgpack.LegacyEncodingOptions
+
+	// SetTieredFileSetCache sets the cache used to fetch the files of
+	// filesets that have been moved to a secondary tier, nil disables
+	// fetching tiered filesets.
+	SetTieredFileSetCache(value TieredFileSetCache) Options
+
+	// TieredFileSetCache returns the cache used to fetch the files of
+	// filesets that have been moved to a secondary tier.
+	TieredFileSetCache() TieredFileSetCache
+}
+
+// TieredFileSetCache fetches the files of flushed data filesets that have been
+// moved to a secondary tier back to the local filesystem before they are read.
+type TieredFileSetCache interface {
+	// Fetch ensures the files of the fileset are present locally, filesets
+	// that have not been tiered are left untouched. The files are not evicted
+	// until the returned release function is called, which callers must do
+	// once they have opened the files.
+	Fetch(filePathPrefix string, id FileSetFileIdentifier) (func(), error)
+
+	// Cached returns false if the fileset has been tiered and its files have
+	// since been evicted from the local filesystem.
+	Cached(filePathPrefix string, id FileSetFileIdentifier) bool
 }
 
 // BlockRetrieverOptions represents the options for block retrieval.
@@ -31,6 +31,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -77,6 +78,7 @@ type writer struct {
 	volumeIndex  int
 	snapshotTime time.Time
 	snapshotID   uuid.UUID
+	compression  compression.Type
 
 	currIdx            int64
 	currOffset         int64
@@ -85,6 +87,9 @@ type writer struct {
 	singleCheckedBytes []checked.Bytes
 	tagsIterator       ident.TagsIterator
 	tagEncoderPool     serialize.TagEncoderPool
+	dataSlices         [][]byte
+	compressBuf        []byte
+	compressedBuf      []byte
 	err                error
 }
 
@@ -240,6 +245,7 @@ func (w *writer) reset(opts DataWriterOpenOptions) {
 	w.volumeIndex = opts.Identifier.VolumeIndex
 	w.snapshotTime = opts.Snapshot.SnapshotTime
 	w.snapshotID = opts.Snapshot.SnapshotID
+	w.compression = opts.Compression
 	w.currIdx = 0
 	w.currOffset = 0
 	w.err = nil
@@ -262,6 +268,39 @@ func (w *writer) writeData(data []byte) error {
 	return nil
 }
 
+// writeEntryData writes the data of a single series, compressing it first if
+// the fileset has a compression codec, and returns the number of bytes that
+// were written to the data file.
+func (w *writer) writeEntryData(data [][]byte) (int64, error) {
+	if w.compression == compression.NoneType {
+		var size int64
+		for _, d := range data {
+			if err := w.writeData(d); err != nil {
+				return 0, err
+			}
+			size += int64(len(d))
+		}
+		return size, nil
+	}
+
+	// Compress the series as a whole rather than each segment so that the
+	// codec can make use of redundancy across segments.
+	w.compressBuf = w.compressBuf[:0]
+	for _, d := range data {
+		w.compressBuf = append(w.compressBuf, d...)
+	}
+	compressed, err := w.compression.Compress(w.compressedBuf, w.compressBuf)
+	if err != nil {
+		return 0, err
+	}
+	w.compressedBuf = compressed
+
+	if err := w.writeData(compressed); err != nil {
+		return 0, err
+	}
+	return int64(len(compressed)), nil
+}
+
 func (w *writer) Write(
 	metadata persist.Metadata,
 	data checked.Bytes,
@@ -303,24 +342,33 @@ func (w *writer) writeAll(
 		return nil
 	}
 
+	w.dataSlices = w.dataSlices[:0]
+	for _, d := range data {
+		if d == nil {
+			continue
+		}
+		w.dataSlices = append(w.dataSlices, d.Bytes())
+	}
+
+	offset := w.currOffset
+	size, err := w.writeEntryData(w.dataSlices)
+	// Release references to the data now that it's been written.
+	for i := range w.dataSlices {
+		w.dataSlices[i] = nil
+	}
+	if err != nil {
+		return err
+	}
+
 	entry := indexEntryWithMetadata{
 		entry: indexEntry{
 			index:          w.currIdx,
-			dataFileOffset: w.currOffset,
+			dataFileOffset: offset,
 			size:           uint32(size),
 			dataChecksum:   dataChecksum,
 		},
 		metadata: metadata,
 	}
-	for _, d := range data {
-		if d == nil {
-			continue
-		}
-		if err := w.writeData(d.Bytes()); err != nil {
-			return err
-		}
-	}
-
 	w.indexEntries = append(w.indexEntries, entry)
 	w.currIdx++
 
@@ -619,6 +667,7 @@ func (w *writer) writeInfoFileContents(
 		Entries:      entriesCount,
 		MajorVersion: schema.MajorVersion,
 		MinorVersion: schema.MinorVersion,
+		Compression:  w.compression,
 		Summaries: schema.IndexSummariesInfo{
 			Summaries: int64(summaries),
 		},
@@ -22,6 +22,7 @@ package schema
 
 import (
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 )
 
 // MajorVersion is the major schema version for a set of fileset files,
@@ -47,6 +48,9 @@ type IndexInfo struct {
 	SnapshotID   []byte
 	VolumeIndex  int
 	MinorVersion int64
+	// Compression is the codec applied to each series in the data file,
+	// filesets written before V6 of the info file are uncompressed.
+	Compression compression.Type
 }
 
 // IndexSummariesInfo stores metadata about the summaries.
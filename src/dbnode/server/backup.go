@@ -0,0 +1,135 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	"github.com/m3db/m3/src/dbnode/storage"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/x/ident"
+
+	"go.uber.org/zap"
+)
+
+// backupURL is the URL of the backup endpoint on the debug listen address.
+const backupURL = "/backup"
+
+var errBackupTargetNotSet = errors.New("exactly one of directory or s3 must be set")
+
+// backupRequest is the request body of the backup endpoint.
+type backupRequest struct {
+	// Namespaces are the namespaces to backup, all namespaces if empty.
+	Namespaces []string `json:"namespaces"`
+	// Directory is a local directory to write the backup to.
+	Directory string `json:"directory"`
+	// S3 is an S3 compatible object store to write the backup to.
+	S3 *backup.S3Options `json:"s3"`
+}
+
+// backupResponse is the response body of the backup endpoint.
+type backupResponse struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Files     int       `json:"files"`
+	Bytes     int64     `json:"bytes"`
+}
+
+type backupHandler struct {
+	db     storage.Database
+	topo   topology.Topology
+	logger *zap.Logger
+}
+
+func newBackupHandler(
+	db storage.Database,
+	topo topology.Topology,
+	logger *zap.Logger,
+) http.Handler {
+	return &backupHandler{db: db, topo: topo, logger: logger}
+}
+
+func (h *backupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req backupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	opts, err := h.backupOptions(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	manifest, err := h.db.Backup(opts)
+	if err != nil {
+		h.logger.Error("backup request failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := backupResponse{
+		CreatedAt: manifest.CreatedAt,
+		Files:     len(manifest.Files),
+	}
+	for _, f := range manifest.Files {
+		resp.Bytes += f.Size
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("unable to write backup response", zap.Error(err))
+	}
+}
+
+func (h *backupHandler) backupOptions(req backupRequest) (storage.BackupOptions, error) {
+	var opts storage.BackupOptions
+	switch {
+	case req.Directory != "" && req.S3 == nil:
+		opts.Store = backup.NewDirectoryStore(req.Directory)
+	case req.Directory == "" && req.S3 != nil:
+		store, err := backup.NewS3Store(*req.S3, nil)
+		if err != nil {
+			return opts, err
+		}
+		opts.Store = store
+	default:
+		return opts, errBackupTargetNotSet
+	}
+
+	for _, ns := range req.Namespaces {
+		opts.Namespaces = append(opts.Namespaces, ident.StringID(ns))
+	}
+
+	if h.topo != nil {
+		opts.Topology = h.topo.Get()
+	}
+	return opts, nil
+}
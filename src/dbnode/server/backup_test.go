@@ -0,0 +1,82 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	"github.com/m3db/m3/src/dbnode/storage"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBackupHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createdAt := time.Unix(1600000000, 0).UTC()
+	db := storage.NewMockDatabase(ctrl)
+	db.EXPECT().Backup(gomock.Any()).DoAndReturn(
+		func(opts storage.BackupOptions) (backup.Manifest, error) {
+			require.Equal(t, []ident.ID{ident.StringID("metrics")}, opts.Namespaces)
+			require.NotNil(t, opts.Store)
+			return backup.Manifest{
+				CreatedAt: createdAt,
+				Files:     []backup.File{{Size: 10}, {Size: 5}},
+			}, nil
+		})
+
+	handler := newBackupHandler(db, nil, zap.NewNop())
+	body := []byte(`{"namespaces":["metrics"],"directory":"/tmp/backup"}`)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, backupURL, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp backupResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, backupResponse{CreatedAt: createdAt, Files: 2, Bytes: 15}, resp)
+}
+
+func TestBackupHandlerInvalidTarget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := newBackupHandler(storage.NewMockDatabase(ctrl), nil, zap.NewNop())
+	for _, body := range []string{
+		`{}`,
+		`{"directory":"/tmp/backup","s3":{"endpoint":"http://localhost:9000","bucket":"b"}}`,
+		`{"s3":{"bucket":"b"}}`,
+	} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, backupURL,
+			bytes.NewReader([]byte(body))))
+		require.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
@@ -56,6 +56,8 @@ import (
 	ttnode "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/node"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/migration"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tier"
 	"github.com/m3db/m3/src/dbnode/ratelimit"
 	"github.com/m3db/m3/src/dbnode/retention"
 	m3dbruntime "github.com/m3db/m3/src/dbnode/runtime"
@@ -99,6 +101,7 @@ import (
 	"github.com/uber/tchannel-go"
 	"go.etcd.io/etcd/embed"
 	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -458,9 +461,25 @@ func Run(runOpts RunOptions) {
 		bytesReadLimit.Limit = limitConfig.Value
 		bytesReadLimit.Lookback = limitConfig.Lookback
 	}
+	tenantLimits := limits.NoOpTenantLimits()
+	if limitConfig := runOpts.Config.Limits.TenantLimits; limitConfig != nil {
+		tenantLimitsOpts := limits.DefaultTenantLimitsOptions()
+		tenantLimitsOpts.TenantTag = limitConfig.TenantTag
+		tenantLimitsOpts.Quotas = limitConfig.Quotas
+		if limitConfig.Lookback > 0 {
+			tenantLimitsOpts.Lookback = limitConfig.Lookback
+		}
+		tenantLimits, err = limits.NewTenantLimits(tenantLimitsOpts, iOpts)
+		if err != nil {
+			logger.Fatal("could not construct tenant limits from config", zap.Error(err))
+		}
+		tenantLimits.Start()
+		defer tenantLimits.Stop()
+	}
 	limitOpts := limits.NewOptions().
 		SetDocsLimitOpts(docsLimit).
 		SetBytesReadLimitOpts(bytesReadLimit).
+		SetTenantLimits(tenantLimits).
 		SetInstrumentOptions(iOpts)
 	if builder := opts.SourceLoggerBuilder(); builder != nil {
 		limitOpts = limitOpts.SetSourceLoggerBuilder(builder)
@@ -549,6 +568,28 @@ func Run(runOpts RunOptions) {
 		SetIndexBloomFilterFalsePositivePercent(cfg.Filesystem.BloomFilterFalsePositivePercentOrDefault()).
 		SetMmapReporter(mmapReporter)
 
+	if tieringCfg := cfg.Filesystem.Tiering; tieringCfg != nil {
+		store, err := tieringCfg.NewStore()
+		if err != nil {
+			logger.Fatal("could not create fs tiering store", zap.Error(err))
+		}
+
+		// NB: prefix tiered files with the host ID so that nodes can share
+		// a store without colliding on the filesets of replicated shards.
+		tieringMgr, err := tier.NewManager(tier.Options{
+			FilesystemOptions: fsopts,
+			Store:             store,
+			KeyPrefix:         hostID,
+			CacheSizeBytes:    tieringCfg.CacheSizeBytes,
+		})
+		if err != nil {
+			logger.Fatal("could not create fs tiering manager", zap.Error(err))
+		}
+
+		fsopts = fsopts.SetTieredFileSetCache(tieringMgr)
+		opts = opts.SetTieringManager(tieringMgr)
+	}
+
 	var commitLogQueueSize int
 	cfgCommitLog := cfg.CommitLogOrDefault()
 	specified := cfgCommitLog.Queue.Size
@@ -589,14 +630,24 @@ func Run(runOpts RunOptions) {
 	}
 
 	opts = withEncodingAndPoolingOptions(cfg, logger, opts, poolingPolicy)
-	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().
+	commitLogOpts := opts.CommitLogOptions().
 		SetInstrumentOptions(opts.InstrumentOptions()).
 		SetFilesystemOptions(fsopts).
-		SetStrategy(commitlog.StrategyWriteBehind).
+		SetStrategy(cfgCommitLog.StrategyOrDefault()).
+		SetCompression(cfgCommitLog.CompressionOrDefault()).
 		SetFlushSize(cfgCommitLog.FlushMaxBytes).
 		SetFlushInterval(cfgCommitLog.FlushEvery).
 		SetBacklogQueueSize(commitLogQueueSize).
-		SetBacklogQueueChannelSize(commitLogQueueChannelSize))
+		SetBacklogQueueChannelSize(commitLogQueueChannelSize)
+	if groupCommit := cfgCommitLog.GroupCommit; groupCommit != nil {
+		if groupCommit.BatchSize > 0 {
+			commitLogOpts = commitLogOpts.SetGroupCommitBatchSize(groupCommit.BatchSize)
+		}
+		if groupCommit.MaxLatency > 0 {
+			commitLogOpts = commitLogOpts.SetGroupCommitMaxLatency(groupCommit.MaxLatency)
+		}
+	}
+	opts = opts.SetCommitLogOptions(commitLogOpts)
 
 	// Setup the block retriever
 	switch seriesCachePolicy {
@@ -884,6 +935,19 @@ func Run(runOpts RunOptions) {
 				// Set conditionally to avoid stomping on the default value of 1.0.
 				repairOpts = repairOpts.SetDebugShadowComparisonsPercentage(cfg.Repair.DebugShadowComparisonsPercentage)
 			}
+
+			if hashTree := cfg.Repair.HashTree; hashTree != nil {
+				hashTreeOpts := repairOpts.HashTreeOptions()
+				if hashTree.Fanout > 0 {
+					hashTreeOpts.Fanout = hashTree.Fanout
+				}
+				if hashTree.Depth > 0 {
+					hashTreeOpts.Depth = hashTree.Depth
+				}
+				repairOpts = repairOpts.
+					SetHashTreeRepairEnabled(hashTree.Enabled).
+					SetHashTreeOptions(hashTreeOpts)
+			}
 		}
 
 		opts = opts.
@@ -943,6 +1007,10 @@ func Run(runOpts RunOptions) {
 		opts = opts.SetWideBatchSize(cfg.WideConfig.BatchSize)
 	}
 
+	if reencodes := cfg.Bootstrap.Reencodes(); len(reencodes) > 0 {
+		runReencodes(reencodes, syncCfg.NamespaceInitializer, fsopts, iOpts, logger)
+	}
+
 	db, err := cluster.NewDatabase(hostID, topo, clusterTopoWatch, opts)
 	if err != nil {
 		logger.Fatal("could not construct database", zap.Error(err))
@@ -960,6 +1028,11 @@ func Run(runOpts RunOptions) {
 	// Now that we've initialized the database we can set it on the service.
 	service.SetDatabase(db)
 
+	if debugListenAddress != "" {
+		// Served by the debug listener which uses the default mux.
+		http.DefaultServeMux.Handle(backupURL, newBackupHandler(db, topo, logger))
+	}
+
 	go func() {
 		if runOpts.BootstrapCh != nil {
 			// Notify on bootstrap chan if specified.
@@ -979,6 +1052,10 @@ func Run(runOpts RunOptions) {
 			runtimeOptsMgr, cfg.Limits.WriteNewSeriesPerSecond)
 		kvWatchEncodersPerBlockLimit(syncCfg.KVStore, logger,
 			runtimeOptsMgr, cfg.Limits.MaxEncodersPerBlock)
+		if limitConfig := cfg.Limits.TenantLimits; limitConfig != nil {
+			kvWatchTenantLimitsQuotas(syncCfg.KVStore, logger,
+				tenantLimits, limitConfig.Quotas)
+		}
 	}()
 
 	// Wait for process interrupt.
@@ -1151,6 +1228,26 @@ func kvWatchEncodersPerBlockLimit(
 	}()
 }
 
+func kvWatchTenantLimitsQuotas(
+	store kv.Store,
+	logger *zap.Logger,
+	tenantLimits limits.TenantLimits,
+	defaultQuotas limits.TenantQuotas,
+) {
+	kvWatchStringValue(store, logger,
+		kvconfig.TenantLimitsQuotasKey,
+		func(value string) error {
+			var quotas limits.TenantQuotas
+			if err := yaml.UnmarshalStrict([]byte(value), &quotas); err != nil {
+				return err
+			}
+			return tenantLimits.UpdateQuotas(quotas)
+		},
+		func() error {
+			return tenantLimits.UpdateQuotas(defaultQuotas)
+		})
+}
+
 func kvWatchClientConsistencyLevels(
 	store kv.Store,
 	logger *zap.Logger,
@@ -1967,3 +2064,47 @@ func (r *mmapReporter) ReportUnmap(ctx mmap.Context) error {
 
 	return nil
 }
+
+// runReencodes re-encodes filesets before the database is opened so that the
+// re-encoded filesets are picked up by bootstrapping. Failures are logged
+// rather than being fatal since progress is persisted and the re-encoding
+// resumes on the next start.
+func runReencodes(
+	reencodes []config.BootstrapReencodeConfiguration,
+	nsInitializer namespace.Initializer,
+	fsOpts fs.Options,
+	iOpts instrument.Options,
+	logger *zap.Logger,
+) {
+	registry, err := nsInitializer.Init()
+	if err != nil {
+		logger.Error("could not init namespace registry for re-encoding", zap.Error(err))
+		return
+	}
+	defer registry.Close()
+
+	watch, err := registry.Watch()
+	if err != nil {
+		logger.Error("could not watch namespaces for re-encoding", zap.Error(err))
+		return
+	}
+	defer watch.Close()
+
+	<-watch.C()
+	nsMap := watch.Get()
+	for _, reencodeCfg := range reencodes {
+		reencodeOpts, err := reencodeCfg.NewOptions(nsMap, fsOpts, iOpts)
+		if err == nil {
+			var reencoder *migration.Reencoder
+			if reencoder, err = migration.NewReencoder(reencodeOpts); err == nil {
+				err = reencoder.Run()
+			}
+		}
+		if err != nil {
+			logger.Error("could not re-encode filesets",
+				zap.String("sourceNamespace", reencodeCfg.SourceNamespace),
+				zap.String("targetNamespace", reencodeCfg.TargetNamespace),
+				zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+
+	"go.uber.org/zap"
+)
+
+var (
+	errBackupInProgress = errors.New("backup already in progress")
+
+	backupsInProgress int32
+)
+
+func (d *db) Backup(opts BackupOptions) (backup.Manifest, error) {
+	if !atomic.CompareAndSwapInt32(&backupsInProgress, 0, 1) {
+		return backup.Manifest{}, errBackupInProgress
+	}
+	defer atomic.StoreInt32(&backupsInProgress, 0)
+
+	var metadatas []namespace.Metadata
+	if len(opts.Namespaces) == 0 {
+		namespaces, err := d.OwnedNamespaces()
+		if err != nil {
+			return backup.Manifest{}, err
+		}
+		for _, ns := range namespaces {
+			metadatas = append(metadatas, ns.Metadata())
+		}
+	}
+	for _, id := range opts.Namespaces {
+		ns, err := d.namespaceFor(id)
+		if err != nil {
+			return backup.Manifest{}, err
+		}
+		metadatas = append(metadatas, ns.Metadata())
+	}
+
+	// Disable file operations so that no filesets are written or cleaned up
+	// while they are being copied, the filesets on disk are then a consistent
+	// point in time.
+	d.mediator.DisableFileOpsAndWait()
+	defer d.mediator.EnableFileOps()
+
+	// Flush and snapshot so that all data written before the backup was
+	// requested is in the flushed or snapshot filesets being copied.
+	start := d.nowFn()
+	if err := d.mediator.WarmFlushAndSnapshot(); err != nil {
+		d.log.Error("backup flush and snapshot failed", zap.Error(err))
+		return backup.Manifest{}, err
+	}
+
+	manifest, err := backup.Backup(backup.BackupOptions{
+		FilesystemOptions: d.opts.CommitLogOptions().FilesystemOptions(),
+		Namespaces:        metadatas,
+		Topology:          opts.Topology,
+		Store:             opts.Store,
+	})
+	if err != nil {
+		d.log.Error("backup failed", zap.Error(err))
+		return backup.Manifest{}, err
+	}
+
+	d.log.Info("backup complete",
+		zap.Int("namespaces", len(metadatas)),
+		zap.Int("files", len(manifest.Files)),
+		zap.Duration("took", d.nowFn().Sub(start)))
+	return manifest, nil
+}
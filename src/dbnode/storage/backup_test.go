@@ -0,0 +1,109 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newBackupTestDatabase(t *testing.T, ctrl *gomock.Controller, dir string) *db {
+	opts := DefaultTestOptions()
+	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().
+		SetFilesystemOptions(fs.NewOptions().SetFilePathPrefix(dir)))
+	d, _, _ := newTestDatabase(t, ctrl, newTestDatabaseOpt{
+		bs:    Bootstrapped,
+		nsMap: testNamespaceMap(t),
+		dbOpt: opts,
+	})
+	return d
+}
+
+func TestDatabaseBackupFlushesAndSnapshots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	d := newBackupTestDatabase(t, ctrl, dir)
+
+	mediator := NewMockdatabaseMediator(ctrl)
+	gomock.InOrder(
+		mediator.EXPECT().DisableFileOpsAndWait(),
+		mediator.EXPECT().WarmFlushAndSnapshot().Return(nil),
+		mediator.EXPECT().EnableFileOps(),
+	)
+	d.mediator = mediator
+
+	backupDir, err := ioutil.TempDir("", "backup-store")
+	require.NoError(t, err)
+	defer os.RemoveAll(backupDir)
+
+	store := backup.NewDirectoryStore(backupDir)
+	_, err = d.Backup(BackupOptions{
+		Namespaces: []ident.ID{ident.StringID("testns1")},
+		Store:      store,
+	})
+	require.NoError(t, err)
+
+	manifest, err := backup.ReadManifest(store)
+	require.NoError(t, err)
+	nsMap, err := manifest.NamespaceMap()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(nsMap.IDs()))
+	require.Equal(t, "testns1", nsMap.IDs()[0].String())
+}
+
+func TestDatabaseBackupFlushError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	d := newBackupTestDatabase(t, ctrl, dir)
+
+	flushErr := errors.New("flush failed")
+	mediator := NewMockdatabaseMediator(ctrl)
+	gomock.InOrder(
+		mediator.EXPECT().DisableFileOpsAndWait(),
+		mediator.EXPECT().WarmFlushAndSnapshot().Return(flushErr),
+		mediator.EXPECT().EnableFileOps(),
+	)
+	d.mediator = mediator
+
+	_, err = d.Backup(BackupOptions{
+		Store: backup.NewDirectoryStore(dir),
+	})
+	require.Equal(t, flushErr, err)
+}
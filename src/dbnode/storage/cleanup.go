@@ -109,6 +109,12 @@ func newCleanupManager(
 	filePathPrefix := opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
 	commitLogsDir := fs.CommitLogsDirPath(filePathPrefix)
 
+	var deleteInactiveDirsFn deleteInactiveDirectoriesFn = fs.DeleteInactiveDirectories
+	if tieringMgr := opts.TieringManager(); tieringMgr != nil {
+		// Tiered filesets must also be deleted from the secondary tier.
+		deleteInactiveDirsFn = tieringMgr.DeleteInactiveDirectories
+	}
+
 	return &cleanupManager{
 		database:         database,
 		activeCommitlogs: activeLogs,
@@ -121,7 +127,7 @@ func newCleanupManager(
 		snapshotMetadataFilesFn:     fs.SortedSnapshotMetadataFiles,
 		snapshotFilesFn:             fs.SnapshotFiles,
 		deleteFilesFn:               fs.DeleteFiles,
-		deleteInactiveDirectoriesFn: fs.DeleteInactiveDirectories,
+		deleteInactiveDirectoriesFn: deleteInactiveDirsFn,
 		metrics:                     newCleanupManagerMetrics(scope),
 		logger:                      opts.InstrumentOptions().Logger(),
 	}
@@ -211,6 +217,11 @@ func (m *cleanupManager) ColdFlushCleanup(t time.Time, isBootstrapped bool) erro
 			"encountered errors when deleting inactive data files for %v: %v", t, err))
 	}
 
+	if err := m.tierDataFiles(t, namespaces); err != nil {
+		multiErr = multiErr.Add(fmt.Errorf(
+			"encountered errors when tiering data files for %v: %v", t, err))
+	}
+
 	return multiErr.FinalError()
 }
 func (m *cleanupManager) Report() {
@@ -286,6 +297,30 @@ func (m *cleanupManager) cleanupDataFiles(t time.Time, namespaces []databaseName
 	return multiErr.FinalError()
 }
 
+// tierDataFiles moves the flushed data filesets of namespaces with a tiering
+// policy to the secondary tier once they are old enough, this runs after
+// cleanup so that filesets about to be deleted are not tiered.
+func (m *cleanupManager) tierDataFiles(t time.Time, namespaces []databaseNamespace) error {
+	tieringMgr := m.opts.TieringManager()
+	if tieringMgr == nil {
+		return nil
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, n := range namespaces {
+		policy := n.Options().TieringPolicy()
+		if !policy.Enabled() {
+			continue
+		}
+		blockSize := n.Options().RetentionOptions().BlockSize()
+		tierBefore := policy.TierBefore(t, blockSize)
+		for _, s := range n.OwnedShards() {
+			multiErr = multiErr.Add(tieringMgr.TierFileSets(n.ID(), s.ID(), tierBefore))
+		}
+	}
+	return multiErr.FinalError()
+}
+
 func (m *cleanupManager) cleanupExpiredIndexFiles(t time.Time, namespaces []databaseNamespace) error {
 	multiErr := xerrors.NewMultiError()
 	for _, n := range namespaces {
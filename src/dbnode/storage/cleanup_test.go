@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tier"
 	"github.com/m3db/m3/src/dbnode/retention"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/ident"
@@ -393,6 +394,69 @@ func TestCleanupManagerNamespaceCleanupNotBootstrapped(t *testing.T) {
 	require.NoError(t, cleanup(mgr, ts, false))
 }
 
+type fakeTieringManager struct {
+	tier.Manager
+
+	tiered []fakeTieredShard
+}
+
+type fakeTieredShard struct {
+	namespace  string
+	shard      uint32
+	tierBefore time.Time
+}
+
+func (m *fakeTieringManager) TierFileSets(
+	namespace ident.ID,
+	shard uint32,
+	tierBefore time.Time,
+) error {
+	m.tiered = append(m.tiered, fakeTieredShard{
+		namespace:  namespace.String(),
+		shard:      shard,
+		tierBefore: tierBefore,
+	})
+	return nil
+}
+
+func TestCleanupManagerTierDataFiles(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{T: t})
+	defer ctrl.Finish()
+
+	ts := timeFor(36000)
+	rOpts := retentionOptions.
+		SetRetentionPeriod(21600 * time.Second).
+		SetBlockSize(3600 * time.Second)
+
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().ID().Return(uint32(3)).AnyTimes()
+
+	tieredNs := NewMockdatabaseNamespace(ctrl)
+	tieredNs.EXPECT().ID().Return(ident.StringID("tiered")).AnyTimes()
+	tieredNs.EXPECT().Options().Return(namespaceOptions.
+		SetRetentionOptions(rOpts).
+		SetTieringPolicy(namespace.TieringPolicy{After: 2 * time.Hour})).AnyTimes()
+	tieredNs.EXPECT().OwnedShards().Return([]databaseShard{shard}).AnyTimes()
+
+	untieredNs := NewMockdatabaseNamespace(ctrl)
+	untieredNs.EXPECT().ID().Return(ident.StringID("untiered")).AnyTimes()
+	untieredNs.EXPECT().Options().Return(namespaceOptions.
+		SetRetentionOptions(rOpts)).AnyTimes()
+
+	nses := []databaseNamespace{tieredNs, untieredNs}
+	db := newMockdatabase(ctrl, nses...)
+
+	tieringMgr := &fakeTieringManager{}
+	mgr := newCleanupManager(db, newNoopFakeActiveLogs(), tally.NoopScope).(*cleanupManager)
+	mgr.opts = mgr.opts.SetTieringManager(tieringMgr)
+	require.NoError(t, mgr.tierDataFiles(ts, nses))
+
+	// Blocks ending at least two hours ago are tiered.
+	require.Equal(t, []fakeTieredShard{
+		{namespace: "tiered", shard: 3, tierBefore: ts.Add(-2 * time.Hour)},
+	}, tieringMgr.tiered)
+}
+
 // Test NS doesn't cleanup when flag is present
 func TestCleanupManagerDoesntNeedCleanup(t *testing.T) {
 	ctrl := gomock.NewController(t)
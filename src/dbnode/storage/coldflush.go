@@ -45,6 +45,8 @@ type coldFlushManager struct {
 	status         fileOpStatus
 	isColdFlushing tally.Gauge
 	enabled        bool
+
+	tileAggregation *tileAggregationManager
 }
 
 func newColdFlushManager(
@@ -66,6 +68,7 @@ func newColdFlushManager(
 		status:                 fileOpNotStarted,
 		isColdFlushing:         scope.Gauge("cold-flush"),
 		enabled:                true,
+		tileAggregation:        newTileAggregationManager(database, opts),
 	}
 }
 
@@ -118,6 +121,12 @@ func (m *coldFlushManager) Run(t time.Time) bool {
 				l.Error("error when cold flushing data", zap.Time("time", t), zap.Error(err))
 			})
 	}
+	// NB: aggregate tiles after cold flushing so that aggregated namespaces
+	// never have cold flushes and tile aggregations writing volumes for the
+	// same block concurrently.
+	if err := m.tileAggregation.Run(t); err != nil {
+		m.log.Error("error when aggregating tiles", zap.Time("time", t), zap.Error(err))
+	}
 	m.Lock()
 	m.status = fileOpNotStarted
 	m.Unlock()
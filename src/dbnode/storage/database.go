@@ -35,6 +35,7 @@ import (
 	dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/limits"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/ts/writes"
@@ -119,7 +120,8 @@ type db struct {
 
 	writeBatchPool *writes.WriteBatchPool
 
-	queryLimits limits.QueryLimits
+	queryLimits  limits.QueryLimits
+	tenantLimits limits.TenantLimits
 }
 
 type databaseMetrics struct {
@@ -193,6 +195,7 @@ func NewDatabase(
 		log:                    logger,
 		writeBatchPool:         opts.WriteBatchPool(),
 		queryLimits:            opts.IndexOptions().QueryLimits(),
+		tenantLimits:           opts.IndexOptions().QueryLimits().TenantLimits(),
 	}
 
 	databaseIOpts := iopts.SetMetricsScope(scope)
@@ -655,6 +658,11 @@ func (d *db) Write(
 		return err
 	}
 
+	// NB: untagged writes do not identify a tenant.
+	if err := d.tenantLimits.DatapointsWrittenLimit().Inc(nil, 1); err != nil {
+		return err
+	}
+
 	seriesWrite, err := n.Write(ctx, id, timestamp, value, unit, annotation)
 	if err != nil {
 		return err
@@ -689,6 +697,11 @@ func (d *db) WriteTagged(
 		return err
 	}
 
+	tenant := d.tenantLimits.Tenant(tags)
+	if err := d.tenantLimits.DatapointsWrittenLimit().Inc(tenant, 1); err != nil {
+		return err
+	}
+
 	seriesWrite, err := n.WriteTagged(ctx, id, tags, timestamp, value, unit, annotation)
 	if err != nil {
 		return err
@@ -771,13 +784,26 @@ func (d *db) writeBatch(
 		return errWriterDoesNotImplementWriteBatch
 	}
 
-	iter := writes.Iter()
+	var (
+		iter              = writes.Iter()
+		datapointsWritten = d.tenantLimits.DatapointsWrittenLimit()
+	)
 	for i, write := range iter {
 		var (
 			seriesWrite SeriesWrite
+			tenant      []byte
 			err         error
 		)
 
+		if tagged {
+			tenant = d.tenantLimits.Tenant(write.TagIter)
+		}
+		if err := datapointsWritten.Inc(tenant, 1); err != nil {
+			errHandler.HandleError(write.OriginalIndex, err)
+			writes.SetError(i, err)
+			continue
+		}
+
 		if tagged {
 			seriesWrite, err = n.WriteTagged(
 				ctx,
@@ -1113,6 +1139,46 @@ func (d *db) FetchBlocksMetadataV2(
 		pageToken, opts)
 }
 
+func (d *db) FetchBlockHashTree(
+	ctx context.Context,
+	namespace ident.ID,
+	shardID uint32,
+	blockStart time.Time,
+	opts repair.HashTreeOptions,
+) (*repair.HashTree, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return nil, xerrors.NewInvalidParamsError(err)
+	}
+	return n.FetchBlockHashTree(ctx, shardID, blockStart, opts)
+}
+
+func (d *db) FetchBlockHashTreeEntries(
+	ctx context.Context,
+	namespace ident.ID,
+	shardID uint32,
+	blockStart time.Time,
+	opts repair.HashTreeOptions,
+	leaves []int,
+) ([]repair.HashTreeEntry, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return nil, xerrors.NewInvalidParamsError(err)
+	}
+	return n.FetchBlockHashTreeEntries(ctx, shardID, blockStart, opts, leaves)
+}
+
+func (d *db) SeriesCardinality(
+	namespace ident.ID,
+	maxMetricNames int,
+) (SeriesCardinality, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return SeriesCardinality{}, xerrors.NewInvalidParamsError(err)
+	}
+	return n.SeriesCardinality(maxMetricNames), nil
+}
+
 func (d *db) Bootstrap() error {
 	d.Lock()
 	d.bootstraps++
@@ -1191,6 +1257,19 @@ func (d *db) Truncate(namespace ident.ID) (int64, error) {
 	return n.Truncate()
 }
 
+func (d *db) DeleteSeries(
+	ctx context.Context,
+	namespace ident.ID,
+	ids []ident.ID,
+	start, end time.Time,
+) (int64, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return 0, err
+	}
+	return n.DeleteSeries(ids, start, end)
+}
+
 func (d *db) IsOverloaded() bool {
 	queueSize := float64(d.commitLog.QueueLength())
 	queueCapacity := float64(d.opts.CommitLogOptions().BacklogQueueSize())
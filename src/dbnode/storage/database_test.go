@@ -38,6 +38,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/dbnode/tracepoint"
@@ -1073,6 +1074,52 @@ func TestDatabaseWrite(t *testing.T) {
 	}
 }
 
+func TestDatabaseWriteTaggedTenantLimitExceeded(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	d, mapCh, _ := defaultTestDatabase(t, ctrl, Bootstrapped)
+	defer func() {
+		close(mapCh)
+	}()
+
+	tenantOpts := limits.DefaultTenantLimitsOptions()
+	tenantOpts.TenantTag = "tenant"
+	tenantOpts.Quotas.Tenants = map[string]limits.TenantQuota{
+		"a": {DatapointsWritten: 1},
+	}
+	tenantLimits, err := limits.NewTenantLimits(tenantOpts, instrument.NewOptions())
+	require.NoError(t, err)
+	d.tenantLimits = tenantLimits
+
+	var (
+		ns      = ident.StringID("testns")
+		id      = ident.StringID("foo")
+		now     = time.Now()
+		tagsFor = func(tenant string) ident.TagIterator {
+			return ident.NewTagsIterator(ident.NewTags(ident.StringTag("tenant", tenant)))
+		}
+	)
+	mockNamespace := dbAddNewMockNamespace(ctrl, d, ns.String())
+	mockNamespace.EXPECT().Options().Return(namespace.NewOptions()).AnyTimes()
+	mockNamespace.EXPECT().
+		WriteTagged(ctx, id, gomock.Any(), now, 1.0, xtime.Second, nil).
+		Return(SeriesWrite{}, nil).
+		Times(2)
+
+	require.NoError(t, d.WriteTagged(ctx, ns, id, tagsFor("a"), now, 1.0, xtime.Second, nil))
+
+	err = d.WriteTagged(ctx, ns, id, tagsFor("a"), now, 1.0, xtime.Second, nil)
+	require.Error(t, err)
+	require.True(t, limits.IsTenantLimitExceededError(err))
+
+	// Other tenants are not limited.
+	require.NoError(t, d.WriteTagged(ctx, ns, id, tagsFor("b"), now, 1.0, xtime.Second, nil))
+}
+
 type fakeIndexedErrorHandler struct {
 	errs []indexedErr
 }
@@ -20,15 +20,27 @@
 
 package limits
 
+import (
+	"github.com/m3db/m3/src/x/ident"
+)
+
 type noOpQueryLimits struct {
 }
 
 type noOpLookbackLimit struct {
 }
 
+type noOpTenantLimits struct {
+}
+
+type noOpTenantLimit struct {
+}
+
 var (
 	_ QueryLimits   = (*noOpQueryLimits)(nil)
 	_ LookbackLimit = (*noOpLookbackLimit)(nil)
+	_ TenantLimits  = (*noOpTenantLimits)(nil)
+	_ TenantLimit   = (*noOpTenantLimit)(nil)
 )
 
 // NoOpQueryLimits returns inactive query limits.
@@ -44,6 +56,10 @@ func (q *noOpQueryLimits) BytesReadLimit() LookbackLimit {
 	return &noOpLookbackLimit{}
 }
 
+func (q *noOpQueryLimits) TenantLimits() TenantLimits {
+	return &noOpTenantLimits{}
+}
+
 func (q *noOpQueryLimits) AnyExceeded() error {
 	return nil
 }
@@ -57,3 +73,46 @@ func (q *noOpQueryLimits) Start() {
 func (q *noOpLookbackLimit) Inc(int, []byte) error {
 	return nil
 }
+
+// NoOpTenantLimits returns inactive tenant limits.
+func NoOpTenantLimits() TenantLimits {
+	return &noOpTenantLimits{}
+}
+
+func (q *noOpTenantLimits) SeriesCreatedLimit() TenantLimit {
+	return &noOpTenantLimit{}
+}
+
+func (q *noOpTenantLimits) DatapointsWrittenLimit() TenantLimit {
+	return &noOpTenantLimit{}
+}
+
+func (q *noOpTenantLimits) DocsMatchedLimit() TenantLimit {
+	return &noOpTenantLimit{}
+}
+
+func (q *noOpTenantLimits) BytesReadLimit() TenantLimit {
+	return &noOpTenantLimit{}
+}
+
+func (q *noOpTenantLimits) Tenant(ident.TagIterator) []byte {
+	return nil
+}
+
+func (q *noOpTenantLimits) Quotas() TenantQuotas {
+	return TenantQuotas{}
+}
+
+func (q *noOpTenantLimits) UpdateQuotas(TenantQuotas) error {
+	return nil
+}
+
+func (q *noOpTenantLimits) Start() {
+}
+
+func (q *noOpTenantLimits) Stop() {
+}
+
+func (q *noOpTenantLimit) Inc([]byte, int) error {
+	return nil
+}
@@ -32,12 +32,14 @@ type limitOpts struct {
 	docsLimitOpts       LookbackLimitOptions
 	bytesReadLimitOpts  LookbackLimitOptions
 	sourceLoggerBuilder SourceLoggerBuilder
+	tenantLimits        TenantLimits
 }
 
 // NewOptions creates limit options with default values.
 func NewOptions() Options {
 	return &limitOpts{
 		sourceLoggerBuilder: &sourceLoggerBuilder{},
+		tenantLimits:        NoOpTenantLimits(),
 	}
 }
 
@@ -55,6 +57,10 @@ func (o *limitOpts) Validate() error {
 		return fmt.Errorf("bytes limit options invalid: %w", err)
 	}
 
+	if o.tenantLimits == nil {
+		return errors.New("limit options invalid: no tenant limits")
+	}
+
 	return nil
 }
 
@@ -105,3 +111,15 @@ func (o *limitOpts) SetSourceLoggerBuilder(value SourceLoggerBuilder) Options {
 func (o *limitOpts) SourceLoggerBuilder() SourceLoggerBuilder {
 	return o.sourceLoggerBuilder
 }
+
+// SetTenantLimits sets the tenant limits.
+func (o *limitOpts) SetTenantLimits(value TenantLimits) Options {
+	opts := *o
+	opts.tenantLimits = value
+	return &opts
+}
+
+// TenantLimits returns the tenant limits.
+func (o *limitOpts) TenantLimits() TenantLimits {
+	return o.tenantLimits
+}
@@ -38,14 +38,16 @@ const (
 type queryLimits struct {
 	docsLimit      *lookbackLimit
 	bytesReadLimit *lookbackLimit
+	tenantLimits   TenantLimits
 }
 
 type lookbackLimit struct {
-	name    string
-	options LookbackLimitOptions
-	metrics lookbackLimitMetrics
-	recent  *atomic.Int64
-	stopCh  chan struct{}
+	name        string
+	options     LookbackLimitOptions
+	metrics     lookbackLimitMetrics
+	recent      *atomic.Int64
+	stopCh      chan struct{}
+	tenantLimit TenantLimit
 }
 
 type lookbackLimitMetrics struct {
@@ -87,6 +89,7 @@ func NewQueryLimits(
 		docsLimitOpts       = options.DocsLimitOpts()
 		bytesReadLimitOpts  = options.BytesReadLimitOpts()
 		sourceLoggerBuilder = options.SourceLoggerBuilder()
+		tenantLimits        = options.TenantLimits()
 
 		docsLimit = newLookbackLimit(
 			iOpts, docsLimitOpts, "docs-matched", sourceLoggerBuilder)
@@ -94,9 +97,14 @@ func NewQueryLimits(
 			iOpts, bytesReadLimitOpts, "disk-bytes-read", sourceLoggerBuilder)
 	)
 
+	// NB: the source of queries identifies their tenant.
+	docsLimit.tenantLimit = tenantLimits.DocsMatchedLimit()
+	bytesReadLimit.tenantLimit = tenantLimits.BytesReadLimit()
+
 	return &queryLimits{
 		docsLimit:      docsLimit,
 		bytesReadLimit: bytesReadLimit,
+		tenantLimits:   tenantLimits,
 	}, nil
 }
 
@@ -112,6 +120,8 @@ func newLookbackLimit(
 		metrics: newLookbackLimitMetrics(instrumentOpts, name, sourceLoggerBuilder),
 		recent:  atomic.NewInt64(0),
 		stopCh:  make(chan struct{}),
+
+		tenantLimit: &noOpTenantLimit{},
 	}
 }
 
@@ -145,6 +155,10 @@ func (q *queryLimits) BytesReadLimit() LookbackLimit {
 	return q.bytesReadLimit
 }
 
+func (q *queryLimits) TenantLimits() TenantLimits {
+	return q.tenantLimits
+}
+
 func (q *queryLimits) Start() {
 	q.docsLimit.start()
 	q.bytesReadLimit.start()
@@ -182,7 +196,12 @@ func (q *lookbackLimit) Inc(val int, source []byte) error {
 	q.metrics.sourceLogger.LogSourceValue(valI64, source)
 
 	// Enforce limit (if specified).
-	return q.checkLimit(recent)
+	if err := q.checkLimit(recent); err != nil {
+		return err
+	}
+
+	// Enforce limit of the tenant that issued the query (if specified).
+	return q.tenantLimit.Inc(source, val)
 }
 
 func (q *lookbackLimit) exceeded() error {
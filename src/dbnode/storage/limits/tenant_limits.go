@@ -0,0 +1,403 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package limits
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/atomic"
+)
+
+const (
+	// DefaultTenant is the tenant that writes and queries which do not
+	// identify a tenant are attributed to.
+	DefaultTenant = "default"
+
+	defaultTenantLimitsLookback = time.Second
+)
+
+var (
+	defaultTenantBytes = []byte(DefaultTenant)
+
+	errTenantLimitsLookbackInvalid = errors.New("tenant limits require lookback > 0")
+)
+
+type tenantLimits struct {
+	sync.RWMutex
+
+	tenantTag []byte
+	lookback  time.Duration
+	quotas    TenantQuotas
+
+	seriesCreatedLimit     *tenantLimit
+	datapointsWrittenLimit *tenantLimit
+	docsMatchedLimit       *tenantLimit
+	bytesReadLimit         *tenantLimit
+
+	stopCh chan struct{}
+}
+
+type tenantLimit struct {
+	sync.RWMutex
+
+	name    string
+	parent  *tenantLimits
+	quotaFn func(q TenantQuota) int64
+	scope   tally.Scope
+	states  map[string]*tenantLimitState
+}
+
+type tenantLimitState struct {
+	tenant  string
+	limit   int64
+	recent  *atomic.Int64
+	metrics lookbackLimitMetrics
+}
+
+var (
+	_ TenantLimits = (*tenantLimits)(nil)
+	_ TenantLimit  = (*tenantLimit)(nil)
+)
+
+// DefaultTenantLimitsOptions returns the default tenant limits options.
+func DefaultTenantLimitsOptions() TenantLimitsOptions {
+	return TenantLimitsOptions{
+		// Default to no limits.
+		Lookback: defaultTenantLimitsLookback,
+	}
+}
+
+// NewTenantLimits returns a new per-tenant limits manager.
+func NewTenantLimits(
+	opts TenantLimitsOptions,
+	instrumentOpts instrument.Options,
+) (TenantLimits, error) {
+	if opts.Lookback <= 0 {
+		return nil, errTenantLimitsLookbackInvalid
+	}
+	if err := opts.Quotas.validate(); err != nil {
+		return nil, err
+	}
+
+	scope := instrumentOpts.
+		MetricsScope().
+		SubScope("tenant-limit")
+	l := &tenantLimits{
+		tenantTag: []byte(opts.TenantTag),
+		lookback:  opts.Lookback,
+		quotas:    opts.Quotas,
+		stopCh:    make(chan struct{}),
+	}
+	l.seriesCreatedLimit = l.newTenantLimit(scope, "series-created",
+		func(q TenantQuota) int64 { return q.SeriesCreated })
+	l.datapointsWrittenLimit = l.newTenantLimit(scope, "datapoints-written",
+		func(q TenantQuota) int64 { return q.DatapointsWritten })
+	l.docsMatchedLimit = l.newTenantLimit(scope, "docs-matched",
+		func(q TenantQuota) int64 { return q.DocsMatched })
+	l.bytesReadLimit = l.newTenantLimit(scope, "disk-bytes-read",
+		func(q TenantQuota) int64 { return q.BytesRead })
+	return l, nil
+}
+
+func (l *tenantLimits) newTenantLimit(
+	scope tally.Scope,
+	name string,
+	quotaFn func(q TenantQuota) int64,
+) *tenantLimit {
+	return &tenantLimit{
+		name:    name,
+		parent:  l,
+		quotaFn: quotaFn,
+		scope:   scope,
+		states:  make(map[string]*tenantLimitState),
+	}
+}
+
+func (l *tenantLimits) SeriesCreatedLimit() TenantLimit {
+	return l.seriesCreatedLimit
+}
+
+func (l *tenantLimits) DatapointsWrittenLimit() TenantLimit {
+	return l.datapointsWrittenLimit
+}
+
+func (l *tenantLimits) DocsMatchedLimit() TenantLimit {
+	return l.docsMatchedLimit
+}
+
+func (l *tenantLimits) BytesReadLimit() TenantLimit {
+	return l.bytesReadLimit
+}
+
+func (l *tenantLimits) Tenant(tags ident.TagIterator) []byte {
+	if len(l.tenantTag) == 0 || tags == nil {
+		return nil
+	}
+
+	// NB: writes resolve their tenant before consuming their tags, so the
+	// tags are iterated in place and rewound instead of being duplicated,
+	// which would allocate an iterator per write. Tags that have already been
+	// advanced are duplicated so that their position is kept.
+	iter := tags
+	if tags.Remaining() == tags.Len() {
+		defer tags.Rewind()
+	} else {
+		iter = tags.Duplicate()
+		defer iter.Close()
+	}
+
+	for iter.Next() {
+		tag := iter.Current()
+		if bytes.Equal(tag.Name.Bytes(), l.tenantTag) {
+			return tag.Value.Bytes()
+		}
+	}
+	return nil
+}
+
+func (l *tenantLimits) Quotas() TenantQuotas {
+	l.RLock()
+	quotas := l.quotas
+	l.RUnlock()
+	return quotas
+}
+
+func (l *tenantLimits) UpdateQuotas(value TenantQuotas) error {
+	if err := value.validate(); err != nil {
+		return err
+	}
+
+	l.Lock()
+	l.quotas = value
+	l.Unlock()
+
+	// NB: drop the state of all tenants so that it is created again with
+	// the new quotas, this also resets the recent values of tenants.
+	for _, limit := range l.all() {
+		limit.Lock()
+		limit.states = make(map[string]*tenantLimitState)
+		limit.Unlock()
+	}
+	return nil
+}
+
+func (l *tenantLimits) quota(tenant string) (TenantQuota, bool) {
+	l.RLock()
+	quota, ok := l.quotas.Tenants[tenant]
+	if !ok {
+		quota = l.quotas.Default
+	}
+	l.RUnlock()
+	return quota, ok
+}
+
+func (l *tenantLimits) all() []*tenantLimit {
+	return []*tenantLimit{
+		l.seriesCreatedLimit,
+		l.datapointsWrittenLimit,
+		l.docsMatchedLimit,
+		l.bytesReadLimit,
+	}
+}
+
+func (l *tenantLimits) Start() {
+	ticker := time.NewTicker(l.lookback)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				for _, limit := range l.all() {
+					limit.reset()
+				}
+			case <-l.stopCh:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+func (l *tenantLimits) Stop() {
+	close(l.stopCh)
+}
+
+// Inc increments the current value of a tenant and returns an error if
+// above the limit of the tenant.
+func (l *tenantLimit) Inc(tenant []byte, val int) error {
+	if val < 0 {
+		return fmt.Errorf("invalid negative tenant limit inc %d", val)
+	}
+	if len(tenant) == 0 {
+		tenant = defaultTenantBytes
+	}
+
+	state := l.state(tenant)
+	if val == 0 {
+		return l.checkLimit(state, state.recent.Load())
+	}
+
+	valI64 := int64(val)
+	recent := state.recent.Add(valI64)
+
+	// Update metrics.
+	state.metrics.recentCount.Update(float64(recent))
+	state.metrics.total.Inc(valI64)
+
+	// Enforce limit (if specified).
+	return l.checkLimit(state, recent)
+}
+
+func (l *tenantLimit) state(tenant []byte) *tenantLimitState {
+	l.RLock()
+	state, ok := l.states[string(tenant)]
+	l.RUnlock()
+	if ok {
+		return state
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	state, ok = l.states[string(tenant)]
+	if ok {
+		return state
+	}
+
+	var (
+		name               = string(tenant)
+		quota, hasOwnQuota = l.parent.quota(name)
+		metricsTenant      = name
+	)
+	if !hasOwnQuota {
+		// NB: tenants without a quota of their own are reported as the default
+		// tenant to bound the cardinality of the metrics.
+		metricsTenant = DefaultTenant
+	}
+
+	scope := l.scope.Tagged(map[string]string{"tenant": metricsTenant})
+	state = &tenantLimitState{
+		tenant: name,
+		limit:  l.quotaFn(quota),
+		recent: atomic.NewInt64(0),
+		metrics: lookbackLimitMetrics{
+			recentCount: scope.Gauge(fmt.Sprintf("recent-count-%s", l.name)),
+			recentMax:   scope.Gauge(fmt.Sprintf("recent-max-%s", l.name)),
+			total:       scope.Counter(fmt.Sprintf("total-%s", l.name)),
+			exceeded:    scope.Tagged(map[string]string{"limit": l.name}).Counter("exceeded"),
+		},
+	}
+	l.states[name] = state
+	return state
+}
+
+func (l *tenantLimit) checkLimit(state *tenantLimitState, recent int64) error {
+	if state.limit > 0 && recent > state.limit {
+		state.metrics.exceeded.Inc(1)
+		return xerrors.NewInvalidParamsError(tenantLimitExceededError{
+			tenant:   state.tenant,
+			name:     l.name,
+			limit:    state.limit,
+			current:  recent,
+			lookback: l.parent.lookback,
+		})
+	}
+	return nil
+}
+
+func (l *tenantLimit) reset() {
+	l.Lock()
+	defer l.Unlock()
+
+	for tenant, state := range l.states {
+		// Update peak gauge only on resets so it only tracks
+		// the peak values for each lookback period.
+		state.metrics.recentMax.Update(float64(state.recent.Load()))
+		state.metrics.recentCount.Update(0)
+		state.recent.Store(0)
+
+		if _, ok := l.parent.quota(tenant); !ok {
+			// NB: drop the state of tenants without a quota of their own so
+			// that the state of tenants that are no longer active is released.
+			delete(l.states, tenant)
+		}
+	}
+}
+
+func (q TenantQuotas) validate() error {
+	if err := q.Default.validate(); err != nil {
+		return fmt.Errorf("default tenant quota invalid: %w", err)
+	}
+	for tenant, quota := range q.Tenants {
+		if err := quota.validate(); err != nil {
+			return fmt.Errorf("tenant quota invalid: tenant=%s, %w", tenant, err)
+		}
+	}
+	return nil
+}
+
+func (q TenantQuota) validate() error {
+	for _, limit := range []int64{
+		q.SeriesCreated,
+		q.DatapointsWritten,
+		q.DocsMatched,
+		q.BytesRead,
+	} {
+		if limit < 0 {
+			return fmt.Errorf("tenant limit requires limit >= 0 (%d)", limit)
+		}
+	}
+	return nil
+}
+
+type tenantLimitExceededError struct {
+	tenant   string
+	name     string
+	limit    int64
+	current  int64
+	lookback time.Duration
+}
+
+func (e tenantLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"tenant limit exceeded: tenant=%s, name=%s, limit=%d, current=%d, within=%s",
+		e.tenant, e.name, e.limit, e.current, e.lookback)
+}
+
+// IsTenantLimitExceededError returns true if the error was caused by a
+// tenant exceeding one of its limits.
+func IsTenantLimitExceededError(err error) bool {
+	for err != nil {
+		if _, ok := err.(tenantLimitExceededError); ok {
+			return true
+		}
+		err = xerrors.InnerError(err)
+	}
+	return false
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package limits
+
+import (
+	"testing"
+	"time"
+
+	xclock "github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func testTenantLimits(t *testing.T, scope tally.Scope) *tenantLimits {
+	opts := DefaultTenantLimitsOptions()
+	opts.TenantTag = "tenant"
+	opts.Quotas = TenantQuotas{
+		Default: TenantQuota{DatapointsWritten: 1},
+		Tenants: map[string]TenantQuota{
+			"a": {SeriesCreated: 2},
+		},
+	}
+
+	limits, err := NewTenantLimits(opts, instrument.NewOptions().SetMetricsScope(scope))
+	require.NoError(t, err)
+	return limits.(*tenantLimits)
+}
+
+func requireTenantLimitExceeded(t *testing.T, err error) {
+	require.Error(t, err)
+	require.True(t, IsTenantLimitExceededError(err))
+	require.True(t, xerrors.IsInvalidParams(err))
+}
+
+func TestTenantLimits(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	limits := testTenantLimits(t, scope)
+
+	// Tenants with a quota of their own.
+	seriesCreated := limits.SeriesCreatedLimit()
+	require.NoError(t, seriesCreated.Inc([]byte("a"), 2))
+	requireTenantLimitExceeded(t, seriesCreated.Inc([]byte("a"), 1))
+	requireTenantLimitExceeded(t, seriesCreated.Inc([]byte("a"), 0))
+
+	// Tenants with the default quota are limited independently.
+	datapointsWritten := limits.DatapointsWrittenLimit()
+	require.NoError(t, datapointsWritten.Inc([]byte("b"), 1))
+	require.NoError(t, datapointsWritten.Inc([]byte("c"), 1))
+	requireTenantLimitExceeded(t, datapointsWritten.Inc([]byte("b"), 1))
+
+	// Values without a tenant are attributed to the default tenant.
+	require.NoError(t, datapointsWritten.Inc(nil, 1))
+	requireTenantLimitExceeded(t, datapointsWritten.Inc([]byte(DefaultTenant), 1))
+
+	// Limits that are zero are not enforced.
+	require.NoError(t, limits.DocsMatchedLimit().Inc([]byte("a"), 100))
+	require.NoError(t, limits.BytesReadLimit().Inc([]byte("b"), 100))
+	require.NoError(t, datapointsWritten.Inc([]byte("a"), 100))
+
+	require.Error(t, seriesCreated.Inc([]byte("a"), -1))
+	require.False(t, IsTenantLimitExceededError(seriesCreated.Inc([]byte("a"), -1)))
+
+	snapshot := scope.Snapshot()
+	exceeded, ok := snapshot.Counters()["tenant-limit.exceeded+limit=series-created,tenant=a"]
+	require.True(t, ok)
+	require.Equal(t, int64(2), exceeded.Value())
+
+	// Tenants without a quota of their own are reported as the default tenant.
+	exceeded, ok = snapshot.Counters()["tenant-limit.exceeded+limit=datapoints-written,tenant=default"]
+	require.True(t, ok)
+	require.Equal(t, int64(2), exceeded.Value())
+
+	total, ok := snapshot.Counters()["tenant-limit.total-datapoints-written+tenant=default"]
+	require.True(t, ok)
+	require.Equal(t, int64(5), total.Value())
+
+	// Validate reset.
+	for _, limit := range limits.all() {
+		limit.reset()
+	}
+	require.NoError(t, seriesCreated.Inc([]byte("a"), 2))
+	require.NoError(t, datapointsWritten.Inc([]byte("b"), 1))
+}
+
+func TestTenantLimitsUpdateQuotas(t *testing.T) {
+	limits := testTenantLimits(t, tally.NoopScope)
+
+	require.NoError(t, limits.SeriesCreatedLimit().Inc([]byte("a"), 2))
+	require.NoError(t, limits.DocsMatchedLimit().Inc([]byte("b"), 10))
+
+	quotas := TenantQuotas{
+		Tenants: map[string]TenantQuota{
+			"a": {SeriesCreated: 3},
+			"b": {DocsMatched: 5},
+		},
+	}
+	require.NoError(t, limits.UpdateQuotas(quotas))
+	require.Equal(t, quotas, limits.Quotas())
+
+	require.NoError(t, limits.SeriesCreatedLimit().Inc([]byte("a"), 3))
+	requireTenantLimitExceeded(t, limits.SeriesCreatedLimit().Inc([]byte("a"), 1))
+	requireTenantLimitExceeded(t, limits.DocsMatchedLimit().Inc([]byte("b"), 6))
+
+	// Default quota was removed.
+	require.NoError(t, limits.DatapointsWrittenLimit().Inc([]byte("c"), 10))
+
+	require.Error(t, limits.UpdateQuotas(TenantQuotas{
+		Default: TenantQuota{BytesRead: -1},
+	}))
+	require.Equal(t, quotas, limits.Quotas())
+}
+
+func TestTenantLimitsReset(t *testing.T) {
+	opts := DefaultTenantLimitsOptions()
+	opts.Lookback = 100 * time.Millisecond
+	opts.Quotas.Default.SeriesCreated = 1
+
+	limits, err := NewTenantLimits(opts, instrument.NewOptions())
+	require.NoError(t, err)
+
+	require.NoError(t, limits.SeriesCreatedLimit().Inc(nil, 1))
+	requireTenantLimitExceeded(t, limits.SeriesCreatedLimit().Inc(nil, 1))
+
+	limits.Start()
+	defer limits.Stop()
+
+	success := xclock.WaitUntil(func() bool {
+		return limits.SeriesCreatedLimit().Inc(nil, 0) == nil
+	}, 5*time.Second)
+	require.True(t, success, "did not eventually reset")
+}
+
+func TestTenantLimitsTenant(t *testing.T) {
+	limits := testTenantLimits(t, tally.NoopScope)
+
+	tags := ident.NewTagsIterator(ident.NewTags(
+		ident.StringTag("foo", "bar"),
+		ident.StringTag("tenant", "a"),
+	))
+	require.Equal(t, []byte("a"), limits.Tenant(tags))
+
+	// Tags are not consumed.
+	require.Equal(t, 2, tags.Remaining())
+
+	// Tags that have been advanced keep their position.
+	require.True(t, tags.Next())
+	require.Equal(t, []byte("a"), limits.Tenant(tags))
+	require.Equal(t, 1, tags.Remaining())
+	require.Equal(t, "foo", tags.Current().Name.String())
+
+	tags = ident.NewTagsIterator(ident.NewTags(ident.StringTag("foo", "bar")))
+	require.Nil(t, limits.Tenant(tags))
+	require.Nil(t, limits.Tenant(nil))
+
+	require.Nil(t, NoOpTenantLimits().Tenant(tags))
+}
+
+func BenchmarkTenantLimitsTenant(b *testing.B) {
+	opts := DefaultTenantLimitsOptions()
+	opts.TenantTag = "tenant"
+	limits, err := NewTenantLimits(opts, instrument.NewOptions())
+	require.NoError(b, err)
+
+	tags := ident.NewTagsIterator(ident.NewTags(
+		ident.StringTag("__name__", "http_requests_total"),
+		ident.StringTag("service", "api"),
+		ident.StringTag("tenant", "a"),
+	))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if limits.Tenant(tags) == nil {
+			b.Fatal("tenant not found")
+		}
+	}
+}
+
+func TestNewTenantLimitsInvalid(t *testing.T) {
+	_, err := NewTenantLimits(TenantLimitsOptions{}, instrument.NewOptions())
+	require.Error(t, err)
+
+	opts := DefaultTenantLimitsOptions()
+	opts.Quotas.Tenants = map[string]TenantQuota{"a": {DocsMatched: -1}}
+	_, err = NewTenantLimits(opts, instrument.NewOptions())
+	require.Error(t, err)
+}
+
+func TestQueryLimitsTenantLimits(t *testing.T) {
+	tenantOpts := DefaultTenantLimitsOptions()
+	tenantOpts.Quotas.Tenants = map[string]TenantQuota{
+		"a": {DocsMatched: 1, BytesRead: 1},
+	}
+	tenantLimits, err := NewTenantLimits(tenantOpts, instrument.NewOptions())
+	require.NoError(t, err)
+
+	noLimit := DefaultLookbackLimitOptions()
+	opts := testQueryLimitOptions(noLimit, noLimit, instrument.NewOptions()).
+		SetTenantLimits(tenantLimits)
+	queryLimits, err := NewQueryLimits(opts)
+	require.NoError(t, err)
+	require.Equal(t, tenantLimits, queryLimits.TenantLimits())
+
+	// The source of queries identifies their tenant.
+	require.NoError(t, queryLimits.DocsLimit().Inc(10, []byte("b")))
+	requireTenantLimitExceeded(t, queryLimits.DocsLimit().Inc(2, []byte("a")))
+	requireTenantLimitExceeded(t, queryLimits.BytesReadLimit().Inc(2, []byte("a")))
+}
@@ -24,6 +24,7 @@ package limits
 import (
 	"time"
 
+	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
 )
 
@@ -39,6 +40,8 @@ type QueryLimits interface {
 	DocsLimit() LookbackLimit
 	// BytesReadLimit limits queries by a global concurrent count of bytes read from disk.
 	BytesReadLimit() LookbackLimit
+	// TenantLimits limits writes and queries by per-tenant counts.
+	TenantLimits() TenantLimits
 
 	// AnyExceeded returns an error if any of the query limits are exceeded.
 	AnyExceeded() error
@@ -62,6 +65,71 @@ type LookbackLimitOptions struct {
 	Lookback time.Duration
 }
 
+// TenantLimits provides an interface for managing per-tenant limits.
+type TenantLimits interface {
+	// SeriesCreatedLimit limits the count of series created by a tenant.
+	SeriesCreatedLimit() TenantLimit
+	// DatapointsWrittenLimit limits the count of datapoints written by a tenant.
+	DatapointsWrittenLimit() TenantLimit
+	// DocsMatchedLimit limits the count of index docs matched by the queries of a tenant.
+	DocsMatchedLimit() TenantLimit
+	// BytesReadLimit limits the count of bytes read from disk by the queries of a tenant.
+	BytesReadLimit() TenantLimit
+
+	// Tenant returns the tenant of a series given its tags, or nil if the
+	// tags do not identify a tenant. The returned bytes are only valid for
+	// as long as the tags are, and the position of the tags is unchanged.
+	Tenant(tags ident.TagIterator) []byte
+
+	// Quotas returns the current quotas of tenants.
+	Quotas() TenantQuotas
+	// UpdateQuotas updates the quotas of tenants.
+	UpdateQuotas(value TenantQuotas) error
+
+	// Start begins background resetting of the tenant limits.
+	Start()
+	// Stop end background resetting of the tenant limits.
+	Stop()
+}
+
+// TenantLimit provides an interface for a specific per-tenant limit.
+type TenantLimit interface {
+	// Inc increments the recent value for the limit of a tenant, values
+	// without a tenant are attributed to the default tenant.
+	Inc(tenant []byte, val int) error
+}
+
+// TenantLimitsOptions holds options for per-tenant limits to be enforced.
+type TenantLimitsOptions struct {
+	// TenantTag is the name of the tag that identifies the tenant of a series.
+	TenantTag string
+	// Lookback is the period over which the limits are enforced.
+	Lookback time.Duration
+	// Quotas are the initial quotas of tenants.
+	Quotas TenantQuotas
+}
+
+// TenantQuotas are the quotas of tenants.
+type TenantQuotas struct {
+	// Default is the quota of each tenant without a quota of its own.
+	Default TenantQuota `yaml:"default"`
+	// Tenants are the quotas of specific tenants.
+	Tenants map[string]TenantQuota `yaml:"tenants"`
+}
+
+// TenantQuota is the quota of a tenant within the lookback period, limits
+// that are zero are not enforced.
+type TenantQuota struct {
+	// SeriesCreated is the limit of series created.
+	SeriesCreated int64 `yaml:"seriesCreated"`
+	// DatapointsWritten is the limit of datapoints written.
+	DatapointsWritten int64 `yaml:"datapointsWritten"`
+	// DocsMatched is the limit of index docs matched by queries.
+	DocsMatched int64 `yaml:"docsMatched"`
+	// BytesRead is the limit of bytes read from disk by queries.
+	BytesRead int64 `yaml:"bytesRead"`
+}
+
 // SourceLoggerBuilder builds a SourceLogger given instrument options.
 type SourceLoggerBuilder interface {
 	// NewSourceLogger builds a source logger.
@@ -102,4 +170,10 @@ type Options interface {
 
 	// SourceLogger sets the source logger.
 	SourceLoggerBuilder() SourceLoggerBuilder
+
+	// SetTenantLimits sets the tenant limits.
+	SetTenantLimits(value TenantLimits) Options
+
+	// TenantLimits returns the tenant limits.
+	TenantLimits() TenantLimits
 }
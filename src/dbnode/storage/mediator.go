@@ -173,6 +173,12 @@ func (m *mediator) DisableFileOpsAndWait() {
 	}
 }
 
+func (m *mediator) WarmFlushAndSnapshot() error {
+	// NB: flush with the same time as the ongoing tick, see the comment over
+	// mediatorTimeBarrier for why the times must be in sync.
+	return m.databaseFileSystemManager.Flush(m.mediatorTimeBarrier.currentMediatorTime())
+}
+
 func (m *mediator) EnableFileOps() {
 	m.databaseFileSystemManager.Enable()
 	// Even though the cold flush runs separately, its still
@@ -428,6 +434,13 @@ func (b *mediatorTimeBarrier) initialMediatorTime() time.Time {
 	return b.mediatorTime
 }
 
+// currentMediatorTime returns the time of the ongoing tick.
+func (b *mediatorTimeBarrier) currentMediatorTime() time.Time {
+	b.Lock()
+	defer b.Unlock()
+	return b.mediatorTime
+}
+
 func (b *mediatorTimeBarrier) fsProcessesWait() (time.Time, error) {
 	b.Lock()
 	b.numFsProcessesWaiting++
@@ -116,3 +116,30 @@ func TestDatabaseMediatorDisableFileOpsAndWait(t *testing.T) {
 	m.DisableFileOpsAndWait()
 	require.Equal(t, 3, len(slept))
 }
+
+func TestDatabaseMediatorWarmFlushAndSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := DefaultTestOptions().SetRepairEnabled(false)
+	now := time.Now()
+	opts = opts.
+		SetBootstrapProcessProvider(nil).
+		SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+			return now
+		}))
+
+	db := NewMockdatabase(ctrl)
+	db.EXPECT().Options().Return(opts).AnyTimes()
+	med, err := newMediator(db, nil, opts)
+	require.NoError(t, err)
+
+	m := med.(*mediator)
+	fsm := NewMockdatabaseFileSystemManager(ctrl)
+	m.databaseFileSystemManager = fsm
+
+	// The flush uses the time of the ongoing tick.
+	fsm.EXPECT().Flush(now).Return(nil)
+	now = now.Add(time.Minute)
+	require.NoError(t, m.WarmFlushAndSnapshot())
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -134,10 +135,19 @@ type dbNamespace struct {
 	commitLogWriter commitLogWriter
 	reverseIndex    NamespaceIndex
 
+	// cardinalityLimiter is shared by all shards to enforce the cardinality
+	// limits across the whole namespace.
+	cardinalityLimiter *seriesCardinalityLimiter
+
 	tickWorkers            xsync.WorkerPool
 	tickWorkersConcurrency int
 	statsLastTick          databaseNamespaceStatsLastTick
 
+	// tileAggregationProgress tracks scheduled tile aggregation into each
+	// shard, it is lazily loaded by the tile aggregation manager.
+	tileAggregationLock     sync.Mutex
+	tileAggregationProgress map[uint32]*shardTileAggregationProgress
+
 	metrics databaseNamespaceMetrics
 }
 
@@ -368,7 +378,9 @@ func newDatabaseNamespace(
 		reverseIndex:           index,
 		tickWorkers:            tickWorkers,
 		tickWorkersConcurrency: tickWorkersConcurrency,
-		metrics:                newDatabaseNamespaceMetrics(scope, iops.TimerOptions()),
+		cardinalityLimiter: newSeriesCardinalityLimiter(id,
+			nopts.CardinalityLimits(), scope),
+		metrics: newDatabaseNamespaceMetrics(scope, iops.TimerOptions()),
 	}
 
 	sl, err := opts.SchemaRegistry().RegisterListener(id, n)
@@ -525,7 +537,7 @@ func (n *dbNamespace) assignShardSet(
 		// shard created for this shard ID.
 		n.shards[shard] = newDatabaseShard(metadata, shard, n.blockRetriever,
 			n.namespaceReaderMgr, n.increasingIndex, n.reverseIndex,
-			opts.needsBootstrap, n.opts, n.seriesOpts)
+			n.cardinalityLimiter, opts.needsBootstrap, n.opts, n.seriesOpts)
 		// NB(bodu): We only record shard add metrics for shards created in non
 		// initial assignments.
 		if !opts.initialAssignment {
@@ -978,6 +990,37 @@ func (n *dbNamespace) FetchBlocksMetadataV2(
 	return res, nextPageToken, err
 }
 
+func (n *dbNamespace) FetchBlockHashTree(
+	ctx context.Context,
+	shardID uint32,
+	blockStart time.Time,
+	opts repair.HashTreeOptions,
+) (*repair.HashTree, error) {
+	shard, _, err := n.ReadableShardAt(shardID)
+	if err != nil {
+		return nil, err
+	}
+	return shard.FetchBlockHashTree(ctx, blockStart, opts)
+}
+
+func (n *dbNamespace) FetchBlockHashTreeEntries(
+	ctx context.Context,
+	shardID uint32,
+	blockStart time.Time,
+	opts repair.HashTreeOptions,
+	leaves []int,
+) ([]repair.HashTreeEntry, error) {
+	shard, _, err := n.ReadableShardAt(shardID)
+	if err != nil {
+		return nil, err
+	}
+	return shard.FetchBlockHashTreeEntries(ctx, blockStart, opts, leaves)
+}
+
+func (n *dbNamespace) SeriesCardinality(maxMetricNames int) SeriesCardinality {
+	return n.cardinalityLimiter.Cardinality(maxMetricNames)
+}
+
 func (n *dbNamespace) Bootstrap(
 	ctx context.Context,
 	bootstrapResult bootstrap.NamespaceResult,
@@ -1253,8 +1296,11 @@ func (n *dbNamespace) ColdFlush(flushPersist persist.FlushPreparer) error {
 	n.RUnlock()
 
 	// If repair is enabled we still need cold flush regardless of whether cold writes is
-	// enabled since repairs are dependent on the cold flushing logic.
-	enabled := n.nopts.ColdWritesEnabled() || n.nopts.RepairEnabled()
+	// enabled since repairs are dependent on the cold flushing logic. The same
+	// applies to deleted series and series expired by retention rules since
+	// cold flushes remove their data from disk.
+	enabled := n.nopts.ColdWritesEnabled() || n.nopts.RepairEnabled() ||
+		n.hasTombstones() || len(n.nopts.RetentionRules()) > 0
 	if n.ReadOnly() || !enabled {
 		n.metrics.flushColdData.ReportSuccess(n.nowFn().Sub(callStart))
 		return nil
@@ -1461,6 +1507,51 @@ func (n *dbNamespace) Truncate() (int64, error) {
 	return totalNumSeries, nil
 }
 
+func (n *dbNamespace) DeleteSeries(
+	ids []ident.ID,
+	start, end time.Time,
+) (int64, error) {
+	byShard := make(map[uint32][]ident.ID)
+	n.RLock()
+	shards := make(map[uint32]databaseShard)
+	for _, id := range ids {
+		shardID := n.shardSet.Lookup(id)
+		shard, _, err := n.shardAtWithRLock(shardID)
+		if err != nil {
+			// Deletes are sent to every node so skip series this node does
+			// not own.
+			continue
+		}
+		shards[shardID] = shard
+		byShard[shardID] = append(byShard[shardID], id)
+	}
+	n.RUnlock()
+
+	var (
+		numSeries int64
+		multiErr  xerrors.MultiError
+	)
+	for shardID, shardIDs := range byShard {
+		deleted, err := shards[shardID].DeleteSeries(shardIDs, start, end)
+		if err != nil {
+			detailedErr := fmt.Errorf("shard %d failed to delete series: %v", shardID, err)
+			multiErr = multiErr.Add(detailedErr)
+			continue
+		}
+		numSeries += deleted
+	}
+	return numSeries, multiErr.FinalError()
+}
+
+func (n *dbNamespace) hasTombstones() bool {
+	for _, shard := range n.OwnedShards() {
+		if shard.HasTombstones() {
+			return true
+		}
+	}
+	return false
+}
+
 func (n *dbNamespace) Repair(
 	repairer databaseShardRepairer,
 	tr xtime.Range,
@@ -1734,21 +1825,14 @@ func (n *dbNamespace) aggregateTiles(
 	}
 
 	var (
-		processedShards   = opts.InsOptions.MetricsScope().Counter("processed-shards")
-		targetShards      = n.OwnedShards()
-		bytesPool         = sourceNs.StorageOptions().BytesPool()
-		fsOptions         = sourceNs.StorageOptions().CommitLogOptions().FilesystemOptions()
-		blockReaders      []fs.DataFileSetReader
-		sourceBlockStarts []time.Time
+		processedShards = opts.InsOptions.MetricsScope().Counter("processed-shards")
+		targetShards    = n.OwnedShards()
 	)
 
-	for sourceBlockStart := targetBlockStart; sourceBlockStart.Before(lastSourceBlockEnd); sourceBlockStart = sourceBlockStart.Add(sourceBlockSize) {
-		reader, err := fs.NewReader(bytesPool, fsOptions)
-		if err != nil {
-			return 0, err
-		}
-		sourceBlockStarts = append(sourceBlockStarts, sourceBlockStart)
-		blockReaders = append(blockReaders, reader)
+	blockReaders, sourceBlockStarts, err := newSourceBlockReaders(sourceNs,
+		targetBlockStart, lastSourceBlockEnd)
+	if err != nil {
+		return 0, err
 	}
 
 	onColdFlushNs, err := n.opts.OnColdFlush().ColdFlushNamespace(n)
@@ -1763,30 +1847,13 @@ func (n *dbNamespace) aggregateTiles(
 			return 0, fmt.Errorf("no matching shard in source namespace %s: %v", sourceNs.ID(), err)
 		}
 
-		sourceBlockVolumes := make([]shardBlockVolume, 0, len(sourceBlockStarts))
-		for _, sourceBlockStart := range sourceBlockStarts {
-			latestVolume, err := sourceShard.LatestVolume(sourceBlockStart)
-			if err != nil {
-				n.log.Error("error getting shards latest volume",
-					zap.Error(err), zap.Uint32("shard", sourceShard.ID()), zap.Time("blockStart", sourceBlockStart))
-				return 0, err
-			}
-			sourceBlockVolumes = append(sourceBlockVolumes, shardBlockVolume{sourceBlockStart, latestVolume})
-		}
-
-		writer, err := fs.NewStreamingWriter(n.opts.CommitLogOptions().FilesystemOptions())
-		if err != nil {
-			return 0, err
-		}
-
-		shardProcessedTileCount, err := targetShard.AggregateTiles(
-			ctx, sourceNs, n, sourceShard.ID(), blockReaders, writer, sourceBlockVolumes,
-			onColdFlushNs, opts)
+		shardProcessedTileCount, err := n.aggregateShardTiles(ctx, sourceNs,
+			sourceShard, targetShard, blockReaders, sourceBlockStarts, onColdFlushNs, opts)
 
 		processedTileCount += shardProcessedTileCount
 		processedShards.Inc(1)
 		if err != nil {
-			return 0, fmt.Errorf("shard %d aggregation failed: %v", targetShard.ID(), err)
+			return 0, err
 		}
 	}
 
@@ -1805,6 +1872,67 @@ func (n *dbNamespace) aggregateTiles(
 	return processedTileCount, nil
 }
 
+// newSourceBlockReaders creates a reader for each source block from start up
+// to end.
+func newSourceBlockReaders(
+	sourceNs databaseNamespace,
+	start, end time.Time,
+) ([]fs.DataFileSetReader, []time.Time, error) {
+	var (
+		sourceBlockSize   = sourceNs.Options().RetentionOptions().BlockSize()
+		bytesPool         = sourceNs.StorageOptions().BytesPool()
+		fsOptions         = sourceNs.StorageOptions().CommitLogOptions().FilesystemOptions()
+		blockReaders      []fs.DataFileSetReader
+		sourceBlockStarts []time.Time
+	)
+
+	for sourceBlockStart := start; sourceBlockStart.Before(end); sourceBlockStart = sourceBlockStart.Add(sourceBlockSize) {
+		reader, err := fs.NewReader(bytesPool, fsOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		sourceBlockStarts = append(sourceBlockStarts, sourceBlockStart)
+		blockReaders = append(blockReaders, reader)
+	}
+
+	return blockReaders, sourceBlockStarts, nil
+}
+
+func (n *dbNamespace) aggregateShardTiles(
+	ctx context.Context,
+	sourceNs databaseNamespace,
+	sourceShard, targetShard databaseShard,
+	blockReaders []fs.DataFileSetReader,
+	sourceBlockStarts []time.Time,
+	onColdFlushNs OnColdFlushNamespace,
+	opts AggregateTilesOptions,
+) (int64, error) {
+	sourceBlockVolumes := make([]shardBlockVolume, 0, len(sourceBlockStarts))
+	for _, sourceBlockStart := range sourceBlockStarts {
+		latestVolume, err := sourceShard.LatestVolume(sourceBlockStart)
+		if err != nil {
+			n.log.Error("error getting shards latest volume",
+				zap.Error(err), zap.Uint32("shard", sourceShard.ID()), zap.Time("blockStart", sourceBlockStart))
+			return 0, err
+		}
+		sourceBlockVolumes = append(sourceBlockVolumes, shardBlockVolume{sourceBlockStart, latestVolume})
+	}
+
+	writer, err := fs.NewStreamingWriter(n.opts.CommitLogOptions().FilesystemOptions())
+	if err != nil {
+		return 0, err
+	}
+
+	processedTileCount, err := targetShard.AggregateTiles(
+		ctx, sourceNs, n, sourceShard.ID(), blockReaders, writer, sourceBlockVolumes,
+		onColdFlushNs, opts)
+	if err != nil {
+		return processedTileCount, fmt.Errorf("shard %d aggregation failed: %v", targetShard.ID(), err)
+	}
+
+	return processedTileCount, nil
+}
+
 func (n *dbNamespace) DocRef(id ident.ID) (doc.Document, bool, error) {
 	shard, _, err := n.readableShardFor(id)
 	if err != nil {
@@ -35,6 +35,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tier"
 	"github.com/m3db/m3/src/dbnode/retention"
 	m3dbruntime "github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -145,6 +146,7 @@ type options struct {
 	bootstrapProcessProvider        bootstrap.ProcessProvider
 	persistManager                  persist.Manager
 	indexClaimsManager              fs.IndexClaimsManager
+	tieringManager                  tier.Manager
 	blockRetrieverManager           block.DatabaseBlockRetrieverManager
 	poolOpts                        pool.ObjectPoolOptions
 	contextPool                     context.Pool
@@ -573,6 +575,16 @@ func (o *options) IndexClaimsManager() fs.IndexClaimsManager {
 	return o.indexClaimsManager
 }
 
+func (o *options) SetTieringManager(value tier.Manager) Options {
+	opts := *o
+	opts.tieringManager = value
+	return &opts
+}
+
+func (o *options) TieringManager() tier.Manager {
+	return o.tieringManager
+}
+
 func (o *options) SetDatabaseBlockRetrieverManager(value block.DatabaseBlockRetrieverManager) Options {
 	opts := *o
 	opts.blockRetrieverManager = value
@@ -181,26 +181,47 @@ func (r shardRepairer) Repair(
 		}
 	}
 
-	localIter := block.NewFilteredBlocksMetadataIter(accumLocalMetadata)
-	err = metadata.AddLocalMetadata(localIter)
-	if err != nil {
-		return repair.MetadataComparisonResult{}, err
-	}
-
 	var (
 		rsOpts = r.opts.RepairOptions().ResultOptions()
 		level  = r.rpopts.RepairConsistencyLevel()
 	)
-	for _, sesTopo := range sessions {
-		// Add peer metadata.
-		peerIter, err := sesTopo.session.FetchBlocksMetadataFromPeers(nsCtx.ID, shard.ID(), start, end,
-			level, rsOpts)
-		if err != nil {
+	compareHashTrees := r.rpopts.HashTreeRepairEnabled()
+	if compareHashTrees {
+		// Compare block hash trees with peers first so that only the metadata
+		// of series in differing ranges of the trees is exchanged and compared.
+		err := r.addHashTreeDifferences(ctx, nsCtx.ID, nsMeta, tr, shard,
+			sessions, origin, accumLocalMetadata, metadata)
+		if err == errHashTreePeersUnavailable {
+			// Peers may not support hash trees yet, e.g. during a rolling
+			// upgrade, so fall back to comparing all the metadata.
+			r.scope.Counter("hash-tree-fallbacks").Inc(1)
+			r.logger.Warn("falling back to metadata comparison for shard repair",
+				zap.String("namespace", nsCtx.ID.String()),
+				zap.Uint32("shard", shard.ID()),
+				zap.Error(err))
+			compareHashTrees = false
+		} else if err != nil {
 			return repair.MetadataComparisonResult{}, err
 		}
-		if err := metadata.AddPeerMetadata(peerIter); err != nil {
+	}
+	if !compareHashTrees {
+		localIter := block.NewFilteredBlocksMetadataIter(accumLocalMetadata)
+		err = metadata.AddLocalMetadata(localIter)
+		if err != nil {
 			return repair.MetadataComparisonResult{}, err
 		}
+
+		for _, sesTopo := range sessions {
+			// Add peer metadata.
+			peerIter, err := sesTopo.session.FetchBlocksMetadataFromPeers(nsCtx.ID, shard.ID(), start, end,
+				level, rsOpts)
+			if err != nil {
+				return repair.MetadataComparisonResult{}, err
+			}
+			if err := metadata.AddPeerMetadata(peerIter); err != nil {
+				return repair.MetadataComparisonResult{}, err
+			}
+		}
 	}
 
 	var (
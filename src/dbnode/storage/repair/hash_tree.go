@@ -0,0 +1,249 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package repair
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+const (
+	defaultHashTreeFanout = 16
+	defaultHashTreeDepth  = 3
+
+	// maxHashTreeLeaves bounds the memory used by a single tree, a tree
+	// with this many leaves takes 8MiB for its leaf level alone.
+	maxHashTreeLeaves = 1 << 20
+)
+
+var (
+	errInvalidHashTreeFanout = errors.New("hash tree fanout must be at least 2")
+	errInvalidHashTreeDepth  = errors.New("hash tree depth must be at least 1")
+	errHashTreeTooManyLeaves = fmt.Errorf("hash tree must have at most %d leaves", maxHashTreeLeaves)
+)
+
+// HashTreeOptions describe the shape of a block hash tree. Replicas can only
+// compare trees built with the same options.
+type HashTreeOptions struct {
+	// Fanout is the number of children of each interior node.
+	Fanout int
+	// Depth is the number of levels below the root, a tree has
+	// Fanout^Depth leaves.
+	Depth int
+}
+
+// NewHashTreeOptions returns the default hash tree options.
+func NewHashTreeOptions() HashTreeOptions {
+	return HashTreeOptions{
+		Fanout: defaultHashTreeFanout,
+		Depth:  defaultHashTreeDepth,
+	}
+}
+
+// Validate validates the hash tree options.
+func (o HashTreeOptions) Validate() error {
+	if o.Fanout < 2 {
+		return errInvalidHashTreeFanout
+	}
+	if o.Depth < 1 {
+		return errInvalidHashTreeDepth
+	}
+	if o.NumNodes(o.Depth) > maxHashTreeLeaves {
+		return errHashTreeTooManyLeaves
+	}
+	return nil
+}
+
+// NumNodes returns the number of nodes at a level of the tree, the root is
+// at level zero and the leaves are at level Depth.
+func (o HashTreeOptions) NumNodes(level int) int {
+	n := 1
+	for i := 0; i < level && n <= maxHashTreeLeaves; i++ {
+		n *= o.Fanout
+	}
+	return n
+}
+
+// Leaf returns the leaf of the tree that a series belongs to.
+func (o HashTreeOptions) Leaf(id ident.ID) int {
+	return int(xxhash.Sum64(id.Bytes()) % uint64(o.NumNodes(o.Depth)))
+}
+
+// HashTreeEntry is the checksum of a series that contributes to a leaf
+// of a hash tree, along with the size of its data.
+type HashTreeEntry struct {
+	ID       ident.ID
+	Size     int64
+	Checksum uint32
+}
+
+// HashTree is a tree of hashes over the checksums of the series in a block
+// of a shard. Series are assigned to leaves by the hash of their ID, a leaf
+// hash is an order independent combination of the hashes of its series and
+// an interior node hash is the hash of its children. Replicas compare trees
+// from the root downwards and only descend into nodes that differ, so the
+// cost of a comparison is proportional to the number of differing series
+// rather than to the number of series in the block.
+type HashTree struct {
+	opts      HashTreeOptions
+	levels    [][]uint64
+	numSeries int
+	finalized bool
+}
+
+// NewHashTree returns a new empty hash tree.
+func NewHashTree(opts HashTreeOptions) (*HashTree, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	levels := make([][]uint64, opts.Depth+1)
+	for i := range levels {
+		levels[i] = make([]uint64, opts.NumNodes(i))
+	}
+	return &HashTree{
+		opts:   opts,
+		levels: levels,
+	}, nil
+}
+
+// Options returns the options the tree was built with.
+func (t *HashTree) Options() HashTreeOptions {
+	return t.opts
+}
+
+// NumSeries returns the number of series added to the tree.
+func (t *HashTree) NumSeries() int {
+	return t.numSeries
+}
+
+// Add adds the checksum of a series to the tree, it must not be called once
+// the tree has been finalized.
+func (t *HashTree) Add(id ident.ID, checksum uint32) {
+	var (
+		idHash = xxhash.Sum64(id.Bytes())
+		leaves = t.levels[t.opts.Depth]
+		buf    [12]byte
+	)
+	binary.LittleEndian.PutUint64(buf[:8], idHash)
+	binary.LittleEndian.PutUint32(buf[8:], checksum)
+
+	// Summing the entry hashes makes the leaf hash independent of the order
+	// that series are added in.
+	leaves[idHash%uint64(len(leaves))] += xxhash.Sum64(buf[:])
+	t.numSeries++
+}
+
+// Finalize computes the hashes of the interior nodes of the tree, it must be
+// called before the tree is read with Root or Level.
+func (t *HashTree) Finalize() {
+	if t.finalized {
+		return
+	}
+
+	buf := make([]byte, 8*t.opts.Fanout)
+	for level := t.opts.Depth - 1; level >= 0; level-- {
+		children := t.levels[level+1]
+		for node := range t.levels[level] {
+			for i := 0; i < t.opts.Fanout; i++ {
+				child := children[node*t.opts.Fanout+i]
+				binary.LittleEndian.PutUint64(buf[i*8:], child)
+			}
+			t.levels[level][node] = xxhash.Sum64(buf)
+		}
+	}
+	t.finalized = true
+}
+
+// Root returns the hash of the root of the tree.
+func (t *HashTree) Root() uint64 {
+	return t.levels[0][0]
+}
+
+// Level returns the hashes of the requested nodes at a level of the tree.
+func (t *HashTree) Level(level int, nodes []int) ([]uint64, error) {
+	if level < 0 || level > t.opts.Depth {
+		return nil, fmt.Errorf("hash tree level %d out of range [0, %d]",
+			level, t.opts.Depth)
+	}
+
+	hashes := t.levels[level]
+	result := make([]uint64, 0, len(nodes))
+	for _, node := range nodes {
+		if node < 0 || node >= len(hashes) {
+			return nil, fmt.Errorf("hash tree node %d out of range for level %d",
+				node, level)
+		}
+		result = append(result, hashes[node])
+	}
+	return result, nil
+}
+
+// HashTreeLevelFetcher fetches the hashes of the requested nodes at a level
+// of a remote hash tree.
+type HashTreeLevelFetcher func(level int, nodes []int) ([]uint64, error)
+
+// DiffHashTrees compares a local hash tree with a remote hash tree with the
+// same options, descending only into the nodes whose hashes differ, and
+// returns the leaves that differ.
+func DiffHashTrees(local *HashTree, fetch HashTreeLevelFetcher) ([]int, error) {
+	var (
+		opts  = local.Options()
+		nodes = []int{0}
+	)
+	for level := 0; ; level++ {
+		localHashes, err := local.Level(level, nodes)
+		if err != nil {
+			return nil, err
+		}
+		remoteHashes, err := fetch(level, nodes)
+		if err != nil {
+			return nil, err
+		}
+		if len(remoteHashes) != len(nodes) {
+			return nil, fmt.Errorf(
+				"hash tree level %d fetch returned %d hashes, expected %d",
+				level, len(remoteHashes), len(nodes))
+		}
+
+		var mismatched []int
+		for i, node := range nodes {
+			if localHashes[i] != remoteHashes[i] {
+				mismatched = append(mismatched, node)
+			}
+		}
+		if len(mismatched) == 0 || level == opts.Depth {
+			return mismatched, nil
+		}
+
+		nodes = make([]int, 0, len(mismatched)*opts.Fanout)
+		for _, node := range mismatched {
+			for i := 0; i < opts.Fanout; i++ {
+				nodes = append(nodes, node*opts.Fanout+i)
+			}
+		}
+	}
+}
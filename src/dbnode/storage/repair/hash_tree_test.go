@@ -0,0 +1,147 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package repair
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testHashTree(t *testing.T, opts HashTreeOptions, entries []HashTreeEntry) *HashTree {
+	tree, err := NewHashTree(opts)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		tree.Add(entry.ID, entry.Checksum)
+	}
+	tree.Finalize()
+	return tree
+}
+
+func testHashTreeEntries(n int) []HashTreeEntry {
+	entries := make([]HashTreeEntry, 0, n)
+	for i := 0; i < n; i++ {
+		entries = append(entries, HashTreeEntry{
+			ID:       ident.StringID(fmt.Sprintf("series-%d", i)),
+			Checksum: uint32(i),
+		})
+	}
+	return entries
+}
+
+type testHashTreeFetcher struct {
+	tree           *HashTree
+	nodesRequested int
+}
+
+func (f *testHashTreeFetcher) fetch(level int, nodes []int) ([]uint64, error) {
+	f.nodesRequested += len(nodes)
+	return f.tree.Level(level, nodes)
+}
+
+func TestHashTreeOptionsValidate(t *testing.T) {
+	require.NoError(t, NewHashTreeOptions().Validate())
+	require.Equal(t, errInvalidHashTreeFanout,
+		HashTreeOptions{Fanout: 1, Depth: 3}.Validate())
+	require.Equal(t, errInvalidHashTreeDepth,
+		HashTreeOptions{Fanout: 16, Depth: 0}.Validate())
+	require.Equal(t, errHashTreeTooManyLeaves,
+		HashTreeOptions{Fanout: 16, Depth: 6}.Validate())
+}
+
+func TestHashTreeOrderIndependent(t *testing.T) {
+	var (
+		opts     = HashTreeOptions{Fanout: 4, Depth: 2}
+		entries  = testHashTreeEntries(100)
+		reversed = make([]HashTreeEntry, 0, len(entries))
+	)
+	for i := len(entries) - 1; i >= 0; i-- {
+		reversed = append(reversed, entries[i])
+	}
+
+	a := testHashTree(t, opts, entries)
+	b := testHashTree(t, opts, reversed)
+	require.Equal(t, a.Root(), b.Root())
+	require.Equal(t, len(entries), a.NumSeries())
+}
+
+func TestDiffHashTreesEqual(t *testing.T) {
+	var (
+		opts    = HashTreeOptions{Fanout: 4, Depth: 3}
+		entries = testHashTreeEntries(1000)
+		local   = testHashTree(t, opts, entries)
+		remote  = &testHashTreeFetcher{tree: testHashTree(t, opts, entries)}
+	)
+
+	leaves, err := DiffHashTrees(local, remote.fetch)
+	require.NoError(t, err)
+	require.Empty(t, leaves)
+	// Only the root should be exchanged when the trees match.
+	require.Equal(t, 1, remote.nodesRequested)
+}
+
+func TestDiffHashTreesChecksumMismatch(t *testing.T) {
+	var (
+		opts          = HashTreeOptions{Fanout: 4, Depth: 3}
+		entries       = testHashTreeEntries(1000)
+		remoteEntries = append([]HashTreeEntry(nil), entries...)
+	)
+	remoteEntries[42].Checksum++
+
+	var (
+		local  = testHashTree(t, opts, entries)
+		remote = &testHashTreeFetcher{tree: testHashTree(t, opts, remoteEntries)}
+	)
+
+	leaves, err := DiffHashTrees(local, remote.fetch)
+	require.NoError(t, err)
+	require.Equal(t, []int{opts.Leaf(entries[42].ID)}, leaves)
+	// The root and then the children of a single mismatched node per level.
+	require.Equal(t, 1+opts.Depth*opts.Fanout, remote.nodesRequested)
+}
+
+func TestDiffHashTreesMissingSeries(t *testing.T) {
+	var (
+		opts    = HashTreeOptions{Fanout: 4, Depth: 2}
+		entries = testHashTreeEntries(100)
+		local   = testHashTree(t, opts, entries[:99])
+		remote  = &testHashTreeFetcher{tree: testHashTree(t, opts, entries)}
+	)
+
+	leaves, err := DiffHashTrees(local, remote.fetch)
+	require.NoError(t, err)
+	require.Equal(t, []int{opts.Leaf(entries[99].ID)}, leaves)
+}
+
+func TestDiffHashTreesFetchError(t *testing.T) {
+	var (
+		opts  = HashTreeOptions{Fanout: 4, Depth: 2}
+		local = testHashTree(t, opts, testHashTreeEntries(10))
+	)
+
+	_, err := DiffHashTrees(local, func(level int, nodes []int) ([]uint64, error) {
+		return []uint64{}, nil
+	})
+	require.Error(t, err)
+}
@@ -39,6 +39,7 @@ const (
 	defaultRepairShardConcurrency           = 1
 	defaultDebugShadowComparisonsEnabled    = false
 	defaultDebugShadowComparisonsPercentage = 1.0
+	defaultHashTreeRepairEnabled            = false
 )
 
 var (
@@ -60,6 +61,8 @@ type options struct {
 	resultOptions                    result.Options
 	debugShadowComparisonsEnabled    bool
 	debugShadowComparisonsPercentage float64
+	hashTreeRepairEnabled            bool
+	hashTreeOptions                  HashTreeOptions
 }
 
 // NewOptions creates new bootstrap options
@@ -73,6 +76,8 @@ func NewOptions() Options {
 		resultOptions:                    result.NewOptions(),
 		debugShadowComparisonsEnabled:    defaultDebugShadowComparisonsEnabled,
 		debugShadowComparisonsPercentage: defaultDebugShadowComparisonsPercentage,
+		hashTreeRepairEnabled:            defaultHashTreeRepairEnabled,
+		hashTreeOptions:                  NewHashTreeOptions(),
 	}
 }
 
@@ -166,6 +171,26 @@ func (o *options) DebugShadowComparisonsPercentage() float64 {
 	return o.debugShadowComparisonsPercentage
 }
 
+func (o *options) SetHashTreeRepairEnabled(value bool) Options {
+	opts := *o
+	opts.hashTreeRepairEnabled = value
+	return &opts
+}
+
+func (o *options) HashTreeRepairEnabled() bool {
+	return o.hashTreeRepairEnabled
+}
+
+func (o *options) SetHashTreeOptions(value HashTreeOptions) Options {
+	opts := *o
+	opts.hashTreeOptions = value
+	return &opts
+}
+
+func (o *options) HashTreeOptions() HashTreeOptions {
+	return o.hashTreeOptions
+}
+
 func (o *options) Validate() error {
 	if len(o.adminClients) == 0 {
 		return errNoAdminClient
@@ -202,5 +227,8 @@ func (o *options) Validate() error {
 		o.debugShadowComparisonsPercentage < 0 {
 		return errInvalidDebugShadowComparisonsPercentage
 	}
+	if err := o.hashTreeOptions.Validate(); err != nil {
+		return fmt.Errorf("invalid hash tree options in repair options: %v", err)
+	}
 	return nil
 }
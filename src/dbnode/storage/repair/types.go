@@ -200,6 +200,20 @@ type Options interface {
 	// DebugShadowComparisonsPercentage returns the debug shadow comparisons percentage.
 	DebugShadowComparisonsPercentage() float64
 
+	// SetHashTreeRepairEnabled sets whether repairs compare block hash trees
+	// with peers rather than the full blocks metadata.
+	SetHashTreeRepairEnabled(value bool) Options
+
+	// HashTreeRepairEnabled returns whether repairs compare block hash trees
+	// with peers rather than the full blocks metadata.
+	HashTreeRepairEnabled() bool
+
+	// SetHashTreeOptions sets the options of the block hash trees.
+	SetHashTreeOptions(value HashTreeOptions) Options
+
+	// HashTreeOptions returns the options of the block hash trees.
+	HashTreeOptions() HashTreeOptions
+
 	// Validate checks if the options are valid.
 	Validate() error
 }
@@ -0,0 +1,270 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"go.uber.org/zap"
+)
+
+// errHashTreePeersUnavailable is returned when no peer that owns a shard
+// could compare hash trees, e.g. since the peers run a version that does
+// not support hash trees yet.
+var errHashTreePeersUnavailable = errors.New("could not compare block hash tree with any peer")
+
+type hashTreePeer struct {
+	session client.AdminSession
+	host    topology.Host
+}
+
+// addHashTreeDifferences compares the hash tree of each block in the range
+// with the hash trees of the peers that own the shard, descending only into
+// the nodes that differ. The local and peer metadata of the series in the
+// leaves that differ from any peer is then added to the metadata comparer,
+// so the metadata exchanged with peers is proportional to the divergence
+// between replicas rather than to the number of series in the shard.
+// If no peer could compare the hash tree of a block then
+// errHashTreePeersUnavailable is returned before any metadata is added.
+func (r shardRepairer) addHashTreeDifferences(
+	ctx context.Context,
+	nsID ident.ID,
+	nsMeta namespace.Metadata,
+	tr xtime.Range,
+	shard databaseShard,
+	sessions []sessionAndTopo,
+	origin topology.Host,
+	localMetadata block.FetchBlocksMetadataResults,
+	metadata repair.ReplicaMetadataComparer,
+) error {
+	var (
+		treeOpts         = r.rpopts.HashTreeOptions()
+		blockSize        = nsMeta.Options().RetentionOptions().BlockSize()
+		leavesByBlock    = make(map[xtime.UnixNano]map[int]struct{})
+		peerMetadata     []block.ReplicaMetadata
+		mismatchedLeaves = r.scope.Counter("hash-tree-mismatched-leaves")
+		peerErrors       = r.scope.Counter("hash-tree-peer-errors")
+	)
+	for blockStart := tr.Start.Truncate(blockSize); blockStart.Before(tr.End); blockStart = blockStart.Add(blockSize) {
+		localTree, err := shard.FetchBlockHashTree(ctx, blockStart, treeOpts)
+		if err != nil {
+			return err
+		}
+
+		var (
+			peers         []hashTreePeer
+			numCandidates int
+			leaves        = make(map[int]struct{})
+		)
+		for _, sesTopo := range sessions {
+			hosts, err := hashTreePeersForShard(sesTopo.topo, shard.ID(), origin)
+			if err != nil {
+				return err
+			}
+
+			numCandidates += len(hosts)
+			for _, host := range hosts {
+				peer := hashTreePeer{session: sesTopo.session, host: host}
+				diff, err := repair.DiffHashTrees(localTree, func(level int, nodes []int) ([]uint64, error) {
+					res, err := peer.session.FetchBlockHashTreeFromPeer(peer.host, nsID, shard.ID(),
+						blockStart, client.BlockHashTreeRequest{
+							Fanout: treeOpts.Fanout,
+							Depth:  treeOpts.Depth,
+							Level:  level,
+							Nodes:  nodes,
+						})
+					if err != nil {
+						return nil, err
+					}
+					return res.Hashes, nil
+				})
+				if err != nil {
+					peerErrors.Inc(1)
+					r.logger.Warn("could not compare block hash tree with peer",
+						zap.String("peer", host.ID()),
+						zap.Uint32("shard", shard.ID()),
+						zap.Time("blockStart", blockStart),
+						zap.Error(err))
+					continue
+				}
+
+				peers = append(peers, peer)
+				for _, leaf := range diff {
+					leaves[leaf] = struct{}{}
+				}
+			}
+		}
+		if numCandidates > 0 && len(peers) == 0 {
+			// Nothing has been added to the comparer yet so the caller can
+			// fall back to comparing the metadata of every series instead.
+			return errHashTreePeersUnavailable
+		}
+		if len(leaves) == 0 {
+			continue
+		}
+
+		mismatchedLeaves.Inc(int64(len(leaves)))
+		leavesByBlock[xtime.ToUnixNano(blockStart)] = leaves
+
+		nodes := make([]int, 0, len(leaves))
+		for leaf := range leaves {
+			nodes = append(nodes, leaf)
+		}
+		sort.Ints(nodes)
+
+		// Fetch the entries of leaves that differ from any peer from every
+		// peer so that the comparison sees the metadata of all replicas.
+		for _, peer := range peers {
+			res, err := peer.session.FetchBlockHashTreeFromPeer(peer.host, nsID, shard.ID(),
+				blockStart, client.BlockHashTreeRequest{
+					Fanout:         treeOpts.Fanout,
+					Depth:          treeOpts.Depth,
+					Level:          treeOpts.Depth,
+					Nodes:          nodes,
+					IncludeEntries: true,
+				})
+			if err != nil {
+				return fmt.Errorf("could not fetch block hash tree entries from peer %s: %v",
+					peer.host.ID(), err)
+			}
+
+			for _, entry := range res.Entries {
+				checksum := entry.Checksum
+				peerMetadata = append(peerMetadata, block.ReplicaMetadata{
+					Host: peer.host,
+					Metadata: block.NewMetadata(entry.ID, ident.Tags{}, blockStart,
+						entry.Size, &checksum, time.Time{}),
+				})
+			}
+		}
+	}
+
+	var localDiffMetadata []block.ReplicaMetadata
+	if len(leavesByBlock) > 0 {
+		for _, result := range localMetadata.Results() {
+			if result.Blocks == nil {
+				continue
+			}
+
+			leaf := treeOpts.Leaf(result.ID)
+			for _, b := range result.Blocks.Results() {
+				leaves, ok := leavesByBlock[xtime.ToUnixNano(b.Start)]
+				if !ok {
+					continue
+				}
+				if _, ok := leaves[leaf]; !ok {
+					continue
+				}
+				localDiffMetadata = append(localDiffMetadata, block.ReplicaMetadata{
+					Host: origin,
+					Metadata: block.NewMetadata(result.ID, ident.Tags{}, b.Start,
+						b.Size, b.Checksum, b.LastRead),
+				})
+			}
+		}
+	}
+
+	localIter := localReplicaMetadataIter{newReplicaMetadataIter(localDiffMetadata)}
+	if err := metadata.AddLocalMetadata(localIter); err != nil {
+		return err
+	}
+	peerIter := peerReplicaMetadataIter{newReplicaMetadataIter(peerMetadata)}
+	return metadata.AddPeerMetadata(peerIter)
+}
+
+// hashTreePeersForShard returns the hosts other than the origin that own a
+// shard, excluding hosts that are still initializing the shard since they
+// are not expected to have all its data.
+func hashTreePeersForShard(
+	topo topology.Map,
+	shardID uint32,
+	origin topology.Host,
+) ([]topology.Host, error) {
+	var peers []topology.Host
+	err := topo.RouteShardForEach(shardID, func(
+		_ int,
+		s shard.Shard,
+		host topology.Host,
+	) {
+		if host.ID() == origin.ID() || s.State() == shard.Initializing {
+			return
+		}
+		peers = append(peers, host)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return peers, nil
+}
+
+type replicaMetadataIter struct {
+	metadata []block.ReplicaMetadata
+	idx      int
+}
+
+func newReplicaMetadataIter(metadata []block.ReplicaMetadata) *replicaMetadataIter {
+	return &replicaMetadataIter{metadata: metadata, idx: -1}
+}
+
+func (it *replicaMetadataIter) Next() bool {
+	if it.idx+1 >= len(it.metadata) {
+		return false
+	}
+	it.idx++
+	return true
+}
+
+func (it *replicaMetadataIter) Err() error {
+	return nil
+}
+
+// localReplicaMetadataIter iterates over replica metadata as local metadata.
+type localReplicaMetadataIter struct {
+	*replicaMetadataIter
+}
+
+func (it localReplicaMetadataIter) Current() (ident.ID, block.Metadata) {
+	m := it.metadata[it.idx]
+	return m.ID, m.Metadata
+}
+
+// peerReplicaMetadataIter iterates over replica metadata as peer metadata.
+type peerReplicaMetadataIter struct {
+	*replicaMetadataIter
+}
+
+func (it peerReplicaMetadataIter) Current() (topology.Host, block.Metadata) {
+	m := it.metadata[it.idx]
+	return m.Host, m.Metadata
+}
@@ -0,0 +1,352 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func TestDatabaseShardRepairerRepairHashTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		origin   = topology.NewHost("0", "addr0")
+		peer     = topology.NewHost("1", "addr1")
+		treeOpts = repair.HashTreeOptions{Fanout: 4, Depth: 2}
+		any      = gomock.Any()
+	)
+
+	topoMap := topology.NewMockMap(ctrl)
+	topoMap.EXPECT().RouteShardForEach(uint32(0), any).DoAndReturn(
+		func(id uint32, fn topology.RouteForEachFn) error {
+			fn(0, shard.NewShard(id).SetState(shard.Available), origin)
+			fn(1, shard.NewShard(id).SetState(shard.Available), peer)
+			return nil
+		}).AnyTimes()
+
+	session := client.NewMockAdminSession(ctrl)
+	session.EXPECT().Origin().Return(origin).AnyTimes()
+	session.EXPECT().TopologyMap().Return(topoMap, nil).AnyTimes()
+
+	mockClient := client.NewMockAdminClient(ctrl)
+	mockClient.EXPECT().DefaultAdminSession().Return(session, nil).AnyTimes()
+
+	var (
+		rpOpts = testRepairOptions(ctrl).
+			SetAdminClients([]client.AdminClient{mockClient}).
+			SetHashTreeRepairEnabled(true).
+			SetHashTreeOptions(treeOpts)
+		rtopts = defaultTestRetentionOpts
+		now    = time.Now()
+		opts   = DefaultTestOptions()
+	)
+	opts = opts.
+		SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time { return now })).
+		SetInstrumentOptions(opts.InstrumentOptions().SetMetricsScope(tally.NoopScope))
+
+	var (
+		namespaceID     = ident.StringID("testNamespace")
+		start           = now.Truncate(rtopts.BlockSize())
+		end             = start.Add(rtopts.BlockSize())
+		repairTimeRange = xtime.Range{Start: start, End: end}
+		fetchOpts       = block.FetchBlocksMetadataOptions{
+			IncludeSizes:     true,
+			IncludeChecksums: true,
+		}
+
+		ids            = []string{"foo", "bar", "baz"}
+		sizes          = []int64{1, 2, 3}
+		localChecksums = []uint32{4, 5, 6}
+		// Mismatch checksum of bar so should trigger repair of only bar.
+		peerChecksums = []uint32{4, 7, 6}
+		shardID       = uint32(0)
+		dbShard       = NewMockdatabaseShard(ctrl)
+	)
+
+	localTree, err := repair.NewHashTree(treeOpts)
+	require.NoError(t, err)
+	peerTree, err := repair.NewHashTree(treeOpts)
+	require.NoError(t, err)
+	localMetadata := block.NewFetchBlocksMetadataResults()
+	for i, id := range ids {
+		localTree.Add(ident.StringID(id), localChecksums[i])
+		peerTree.Add(ident.StringID(id), peerChecksums[i])
+
+		results := block.NewFetchBlockMetadataResults()
+		results.Add(block.NewFetchBlockMetadataResult(start,
+			sizes[i], &localChecksums[i], time.Time{}, nil))
+		localMetadata.Add(block.NewFetchBlocksMetadataResult(ident.StringID(id), nil, results))
+	}
+	localTree.Finalize()
+	peerTree.Finalize()
+
+	dbShard.EXPECT().ID().Return(shardID).AnyTimes()
+	dbShard.EXPECT().
+		FetchBlocksMetadataV2(any, start, end, any, nil, fetchOpts).
+		Return(localMetadata, nil, nil)
+	dbShard.EXPECT().FetchBlockHashTree(any, start, treeOpts).Return(localTree, nil)
+	dbShard.EXPECT().LoadBlocks(any).Return(nil)
+
+	var (
+		numNodesRequested  int
+		fetchedEntriesOnly = true
+	)
+	session.EXPECT().
+		FetchBlockHashTreeFromPeer(peer, namespaceID, shardID, start, any).
+		DoAndReturn(func(
+			_ topology.Host,
+			_ ident.ID,
+			_ uint32,
+			_ time.Time,
+			req client.BlockHashTreeRequest,
+		) (client.BlockHashTreeResult, error) {
+			hashes, err := peerTree.Level(req.Level, req.Nodes)
+			if err != nil {
+				return client.BlockHashTreeResult{}, err
+			}
+
+			result := client.BlockHashTreeResult{Hashes: hashes}
+			if !req.IncludeEntries {
+				numNodesRequested += len(req.Nodes)
+				return result, nil
+			}
+
+			for i, id := range ids {
+				leaf := treeOpts.Leaf(ident.StringID(id))
+				for _, node := range req.Nodes {
+					if leaf != node {
+						continue
+					}
+					if id != "bar" {
+						fetchedEntriesOnly = false
+					}
+					result.Entries = append(result.Entries, client.BlockHashTreeEntry{
+						ID:       ident.StringID(id),
+						Size:     sizes[i],
+						Checksum: peerChecksums[i],
+					})
+				}
+			}
+			return result, nil
+		}).
+		AnyTimes()
+
+	peerBlocksIter := client.NewMockPeerBlocksIter(ctrl)
+	peerBlocksIter.EXPECT().Next().Return(false)
+	nsMeta, err := namespace.NewMetadata(namespaceID, namespace.NewOptions())
+	require.NoError(t, err)
+	session.EXPECT().
+		FetchBlocksFromPeers(nsMeta, shardID, rpOpts.RepairConsistencyLevel(), any, any).
+		DoAndReturn(func(
+			_ namespace.Metadata,
+			_ uint32,
+			_ topology.ReadConsistencyLevel,
+			metadatas []block.ReplicaMetadata,
+			_ result.Options,
+		) (client.PeerBlocksIter, error) {
+			require.Equal(t, 1, len(metadatas))
+			require.Equal(t, "bar", metadatas[0].ID.String())
+			require.Equal(t, peer.ID(), metadatas[0].Host.ID())
+			return peerBlocksIter, nil
+		})
+
+	var resDiff repair.MetadataComparisonResult
+	repairer := newShardRepairer(opts, rpOpts).(shardRepairer)
+	repairer.recordFn = func(origin topology.Host, nsID ident.ID, shard databaseShard,
+		diffRes repair.MetadataComparisonResult) {
+		resDiff = diffRes
+	}
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	_, err = repairer.Repair(ctx, namespace.Context{ID: namespaceID}, nsMeta, repairTimeRange, dbShard)
+	require.NoError(t, err)
+
+	// Only the root and the children of mismatched nodes should be exchanged.
+	require.Equal(t, 1+treeOpts.Depth*treeOpts.Fanout, numNodesRequested)
+
+	checksumDiffSeries := resDiff.ChecksumDifferences.Series()
+	require.Equal(t, 1, checksumDiffSeries.Len())
+	series, exists := checksumDiffSeries.Get(ident.StringID("bar"))
+	require.True(t, exists)
+	blocks := series.Metadata.Blocks()
+	require.Equal(t, 1, len(blocks))
+	_, exists = blocks[xtime.ToUnixNano(start)]
+	require.True(t, exists)
+
+	// Series in leaves that match the peer are not compared.
+	barLeaf := treeOpts.Leaf(ident.StringID("bar"))
+	var expectedNumSeries int64
+	for _, id := range ids {
+		if treeOpts.Leaf(ident.StringID(id)) == barLeaf {
+			expectedNumSeries++
+		}
+	}
+	require.Equal(t, expectedNumSeries, resDiff.NumSeries)
+	if expectedNumSeries == 1 {
+		require.True(t, fetchedEntriesOnly)
+	}
+}
+
+func TestDatabaseShardRepairerRepairHashTreeFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		origin   = topology.NewHost("0", "addr0")
+		peer     = topology.NewHost("1", "addr1")
+		treeOpts = repair.HashTreeOptions{Fanout: 4, Depth: 2}
+		any      = gomock.Any()
+	)
+
+	topoMap := topology.NewMockMap(ctrl)
+	topoMap.EXPECT().RouteShardForEach(uint32(0), any).DoAndReturn(
+		func(id uint32, fn topology.RouteForEachFn) error {
+			fn(0, shard.NewShard(id).SetState(shard.Available), origin)
+			fn(1, shard.NewShard(id).SetState(shard.Available), peer)
+			return nil
+		}).AnyTimes()
+
+	session := client.NewMockAdminSession(ctrl)
+	session.EXPECT().Origin().Return(origin).AnyTimes()
+	session.EXPECT().TopologyMap().Return(topoMap, nil).AnyTimes()
+
+	mockClient := client.NewMockAdminClient(ctrl)
+	mockClient.EXPECT().DefaultAdminSession().Return(session, nil).AnyTimes()
+
+	var (
+		rpOpts = testRepairOptions(ctrl).
+			SetAdminClients([]client.AdminClient{mockClient}).
+			SetHashTreeRepairEnabled(true).
+			SetHashTreeOptions(treeOpts)
+		rtopts = defaultTestRetentionOpts
+		now    = time.Now()
+		opts   = DefaultTestOptions()
+	)
+	opts = opts.
+		SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time { return now })).
+		SetInstrumentOptions(opts.InstrumentOptions().SetMetricsScope(tally.NoopScope))
+
+	var (
+		namespaceID     = ident.StringID("testNamespace")
+		start           = now.Truncate(rtopts.BlockSize())
+		end             = start.Add(rtopts.BlockSize())
+		repairTimeRange = xtime.Range{Start: start, End: end}
+		fetchOpts       = block.FetchBlocksMetadataOptions{
+			IncludeSizes:     true,
+			IncludeChecksums: true,
+		}
+		shardID       = uint32(0)
+		size          = int64(1)
+		localChecksum = uint32(2)
+		peerChecksum  = uint32(3)
+		dbShard       = NewMockdatabaseShard(ctrl)
+	)
+
+	localTree, err := repair.NewHashTree(treeOpts)
+	require.NoError(t, err)
+	localTree.Add(ident.StringID("foo"), localChecksum)
+	localTree.Finalize()
+
+	localMetadata := block.NewFetchBlocksMetadataResults()
+	results := block.NewFetchBlockMetadataResults()
+	results.Add(block.NewFetchBlockMetadataResult(start, size, &localChecksum, time.Time{}, nil))
+	localMetadata.Add(block.NewFetchBlocksMetadataResult(ident.StringID("foo"), nil, results))
+
+	dbShard.EXPECT().ID().Return(shardID).AnyTimes()
+	dbShard.EXPECT().
+		FetchBlocksMetadataV2(any, start, end, any, nil, fetchOpts).
+		Return(localMetadata, nil, nil)
+	dbShard.EXPECT().FetchBlockHashTree(any, start, treeOpts).Return(localTree, nil)
+	dbShard.EXPECT().LoadBlocks(any).Return(nil)
+
+	// The peer does not support hash trees so the repair should fall back
+	// to comparing the metadata of every series.
+	session.EXPECT().
+		FetchBlockHashTreeFromPeer(peer, namespaceID, shardID, start, any).
+		Return(client.BlockHashTreeResult{}, errors.New("unknown method"))
+
+	peerIter := client.NewMockPeerBlockMetadataIter(ctrl)
+	gomock.InOrder(
+		peerIter.EXPECT().Next().Return(true),
+		peerIter.EXPECT().Current().Return(peer, block.NewMetadata(ident.StringID("foo"),
+			ident.Tags{}, start, size, &peerChecksum, time.Time{})),
+		peerIter.EXPECT().Next().Return(false),
+		peerIter.EXPECT().Err().Return(nil),
+	)
+	session.EXPECT().
+		FetchBlocksMetadataFromPeers(namespaceID, shardID, start, end,
+			rpOpts.RepairConsistencyLevel(), any).
+		Return(peerIter, nil)
+
+	peerBlocksIter := client.NewMockPeerBlocksIter(ctrl)
+	peerBlocksIter.EXPECT().Next().Return(false)
+	nsMeta, err := namespace.NewMetadata(namespaceID, namespace.NewOptions())
+	require.NoError(t, err)
+	session.EXPECT().
+		FetchBlocksFromPeers(nsMeta, shardID, rpOpts.RepairConsistencyLevel(), any, any).
+		DoAndReturn(func(
+			_ namespace.Metadata,
+			_ uint32,
+			_ topology.ReadConsistencyLevel,
+			metadatas []block.ReplicaMetadata,
+			_ result.Options,
+		) (client.PeerBlocksIter, error) {
+			require.Equal(t, 1, len(metadatas))
+			require.Equal(t, "foo", metadatas[0].ID.String())
+			require.Equal(t, peer.ID(), metadatas[0].Host.ID())
+			return peerBlocksIter, nil
+		})
+
+	var resDiff repair.MetadataComparisonResult
+	repairer := newShardRepairer(opts, rpOpts).(shardRepairer)
+	repairer.recordFn = func(origin topology.Host, nsID ident.ID, shard databaseShard,
+		diffRes repair.MetadataComparisonResult) {
+		resDiff = diffRes
+	}
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	_, err = repairer.Repair(ctx, namespace.Context{ID: namespaceID}, nsMeta, repairTimeRange, dbShard)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resDiff.NumSeries)
+	require.Equal(t, 1, resDiff.ChecksumDifferences.Series().Len())
+}
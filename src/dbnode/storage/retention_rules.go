@@ -0,0 +1,317 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/storage/series/lookup"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const retentionRulesFileName = "retention_rules.json"
+
+// shardRetentionRules tracks, for each block of a shard, the longest rule
+// retention period whose expired series have been removed from the block's
+// filesets by a cold flush.
+//
+// Expired series are filtered out of reads as soon as their retention period
+// passes, the compaction state is persisted to a file in the shard data
+// directory so that blocks are only rewritten once for each rule that expires
+// them. The state is discarded if the retention rules change.
+type shardRetentionRules struct {
+	sync.RWMutex
+
+	filePath    string
+	writer      shardStateFileWriter
+	fingerprint string
+	compacted   map[xtime.UnixNano]time.Duration
+}
+
+type retentionRulesFile struct {
+	Rules  string                    `json:"rules"`
+	Blocks []retentionRulesFileBlock `json:"blocks"`
+}
+
+type retentionRulesFileBlock struct {
+	Start           int64 `json:"start"`
+	RetentionPeriod int64 `json:"retentionPeriod"`
+}
+
+func newShardRetentionRules(
+	filePath string,
+	rules []namespace.RetentionRule,
+	opts fs.Options,
+) *shardRetentionRules {
+	return &shardRetentionRules{
+		filePath:    filePath,
+		writer:      newShardStateFileWriter(opts),
+		fingerprint: retentionRulesFingerprint(rules),
+		compacted:   make(map[xtime.UnixNano]time.Duration),
+	}
+}
+
+func shardRetentionRulesFilePath(
+	filePathPrefix string,
+	namespace ident.ID,
+	shard uint32,
+) string {
+	return path.Join(fs.ShardDataDirPath(filePathPrefix, namespace, shard),
+		retentionRulesFileName)
+}
+
+func retentionRulesFingerprint(rules []namespace.RetentionRule) string {
+	var b strings.Builder
+	for _, rule := range rules {
+		fmt.Fprintf(&b, "%s=%d;", rule.Filter, rule.RetentionPeriod.Nanoseconds())
+	}
+	return b.String()
+}
+
+// Load reads any previously persisted compaction state.
+func (r *shardRetentionRules) Load() error {
+	data, err := ioutil.ReadFile(r.filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var file retentionRulesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("unable to decode retention rules file %s: %v",
+			r.filePath, err)
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	r.compacted = make(map[xtime.UnixNano]time.Duration, len(file.Blocks))
+	if file.Rules != r.fingerprint {
+		// Blocks compacted for other rules need to be compacted again.
+		return nil
+	}
+	for _, b := range file.Blocks {
+		r.compacted[xtime.UnixNano(b.Start)] = time.Duration(b.RetentionPeriod)
+	}
+	return nil
+}
+
+// Compacted returns the longest rule retention period that a block has been
+// compacted for.
+func (r *shardRetentionRules) Compacted(blockStart xtime.UnixNano) time.Duration {
+	r.RLock()
+	defer r.RUnlock()
+	return r.compacted[blockStart]
+}
+
+// SetCompacted records that a block has been compacted for all rules with a
+// retention period no longer than the given period.
+func (r *shardRetentionRules) SetCompacted(
+	blockStart xtime.UnixNano,
+	retentionPeriod time.Duration,
+) error {
+	r.Lock()
+	defer r.Unlock()
+	if r.compacted[blockStart] >= retentionPeriod {
+		return nil
+	}
+	r.compacted[blockStart] = retentionPeriod
+	return r.persistWithLock()
+}
+
+// RemoveBefore removes the compaction state for blocks before the given time.
+func (r *shardRetentionRules) RemoveBefore(earliestToRetain time.Time) error {
+	r.Lock()
+	defer r.Unlock()
+	var changed bool
+	for blockStart := range r.compacted {
+		if blockStart.ToTime().Before(earliestToRetain) {
+			delete(r.compacted, blockStart)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return r.persistWithLock()
+}
+
+func (r *shardRetentionRules) persistWithLock() error {
+	if len(r.compacted) == 0 {
+		return r.writer.Remove(r.filePath)
+	}
+
+	file := retentionRulesFile{
+		Rules:  r.fingerprint,
+		Blocks: make([]retentionRulesFileBlock, 0, len(r.compacted)),
+	}
+	for blockStart, period := range r.compacted {
+		file.Blocks = append(file.Blocks, retentionRulesFileBlock{
+			Start:           int64(blockStart),
+			RetentionPeriod: period.Nanoseconds(),
+		})
+	}
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	return r.writer.Write(r.filePath, data)
+}
+
+// seriesRetentionStart returns the start of the retention period of a series
+// if it matches a retention rule.
+func (s *dbShard) seriesRetentionStart(entry *lookup.Entry) (time.Time, bool) {
+	if s.retentionRules == nil {
+		return time.Time{}, false
+	}
+
+	tags := ident.NewFieldsTagsIterator(entry.Series.Metadata().Fields)
+	period, ok := s.retentionRules.RetentionPeriod(tags)
+	tags.Close()
+	if !ok {
+		return time.Time{}, false
+	}
+
+	blockSize := s.namespace.Options().RetentionOptions().BlockSize()
+	return retention.FlushTimeStartForRetentionPeriod(period, blockSize,
+		s.nowFn()), true
+}
+
+// retentionRulesBlocksToCompact returns the warm flushed blocks that contain
+// series which have expired since the block was last compacted.
+func (s *dbShard) retentionRulesBlocksToCompact() ([]xtime.UnixNano, error) {
+	if s.retentionRules == nil {
+		return nil, nil
+	}
+
+	var (
+		ropts     = s.namespace.Options().RetentionOptions()
+		blockSize = ropts.BlockSize()
+		now       = s.nowFn()
+		result    []xtime.UnixNano
+	)
+	for blockStart := retention.FlushTimeStart(ropts, now); blockStart.Before(now); blockStart = blockStart.Add(blockSize) {
+		expired, ok := s.retentionRules.ExpiredRetentionPeriod(blockStart,
+			blockSize, now)
+		if !ok {
+			// Later blocks have not expired for any rule either.
+			break
+		}
+		bs := xtime.ToUnixNano(blockStart)
+		if s.retentionRulesState.Compacted(bs) >= expired {
+			continue
+		}
+		hasWarmFlushed, err := s.hasWarmFlushed(blockStart)
+		if err != nil {
+			return nil, err
+		}
+		if !hasWarmFlushed {
+			continue
+		}
+		result = append(result, bs)
+	}
+	return result, nil
+}
+
+// expiredRetentionPeriod returns the longest rule retention period for which
+// data in the block has expired.
+func (s *dbShard) expiredRetentionPeriod(blockStart time.Time) time.Duration {
+	if s.retentionRules == nil {
+		return 0
+	}
+	blockSize := s.namespace.Options().RetentionOptions().BlockSize()
+	expired, _ := s.retentionRules.ExpiredRetentionPeriod(blockStart,
+		blockSize, s.nowFn())
+	return expired
+}
+
+// retentionRulesFlushPreparer drops series that have expired according to
+// the retention rules as they are persisted for a single block.
+type retentionRulesFlushPreparer struct {
+	persist.FlushPreparer
+
+	rules   namespace.RetentionRulesMatcher
+	expired time.Duration
+}
+
+func (p retentionRulesFlushPreparer) PrepareData(
+	opts persist.DataPrepareOptions,
+) (persist.PreparedDataPersist, error) {
+	prepared, err := p.FlushPreparer.PrepareData(opts)
+	if err != nil {
+		return prepared, err
+	}
+
+	var (
+		persistFn = prepared.Persist
+		tagsIter  = ident.NewTagsIterator(ident.Tags{})
+	)
+	prepared.Persist = func(
+		metadata persist.Metadata,
+		segment ts.Segment,
+		checksum uint32,
+	) error {
+		tags, err := metadata.ResetOrReturnProvidedTagIterator(tagsIter)
+		if err != nil {
+			return err
+		}
+		// NB: duplicate since the metadata may own the iterator.
+		tags = tags.Duplicate()
+		period, ok := p.rules.RetentionPeriod(tags)
+		tags.Close()
+		if ok && period <= p.expired {
+			// The series has expired for this block.
+			metadata.Finalize()
+			return nil
+		}
+		return persistFn(metadata, segment, checksum)
+	}
+	return prepared, nil
+}
+
+func (s *dbShard) retentionRulesFlushPreparer(
+	flushPreparer persist.FlushPreparer,
+	expired time.Duration,
+) persist.FlushPreparer {
+	if s.retentionRules == nil || expired <= 0 {
+		return flushPreparer
+	}
+	return retentionRulesFlushPreparer{
+		FlushPreparer: flushPreparer,
+		rules:         s.retentionRules,
+		expired:       expired,
+	}
+}
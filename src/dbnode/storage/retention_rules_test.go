@@ -0,0 +1,187 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+var testRetentionRules = []namespace.RetentionRule{
+	{Filter: "env:dev", RetentionPeriod: 2 * time.Hour},
+}
+
+func TestShardRetentionRulesCompactionState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "retention_rules")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		filePath = shardRetentionRulesFilePath(dir, ident.StringID("ns"), 0)
+		t0       = xtime.ToUnixNano(time.Unix(0, 0).Add(100 * time.Hour))
+		t1       = t0 + xtime.UnixNano(2*time.Hour)
+		state    = newShardRetentionRules(filePath, testRetentionRules, fs.NewOptions())
+	)
+	require.NoError(t, state.Load())
+	require.Equal(t, time.Duration(0), state.Compacted(t0))
+
+	require.NoError(t, state.SetCompacted(t0, 2*time.Hour))
+	require.NoError(t, state.SetCompacted(t1, 2*time.Hour))
+	// Compacting for a shorter period does not regress the state.
+	require.NoError(t, state.SetCompacted(t0, time.Hour))
+	require.Equal(t, 2*time.Hour, state.Compacted(t0))
+
+	// Ensure the state is reloaded from disk.
+	loaded := newShardRetentionRules(filePath, testRetentionRules, fs.NewOptions())
+	require.NoError(t, loaded.Load())
+	require.Equal(t, 2*time.Hour, loaded.Compacted(t0))
+	require.Equal(t, 2*time.Hour, loaded.Compacted(t1))
+
+	// The state is discarded when the rules change.
+	changed := newShardRetentionRules(filePath, []namespace.RetentionRule{
+		{Filter: "env:dev", RetentionPeriod: time.Hour},
+	}, fs.NewOptions())
+	require.NoError(t, changed.Load())
+	require.Equal(t, time.Duration(0), changed.Compacted(t0))
+
+	require.NoError(t, loaded.RemoveBefore(t1.ToTime()))
+	require.Equal(t, time.Duration(0), loaded.Compacted(t0))
+	require.Equal(t, 2*time.Hour, loaded.Compacted(t1))
+
+	require.NoError(t, loaded.RemoveBefore(t1.ToTime().Add(time.Hour)))
+	_, err = os.Stat(filePath)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestShardReadEncodedHonorsRetentionRules(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		opts      = DefaultTestOptions()
+		blockSize = defaultTestRetentionOpts.BlockSize()
+		now       = time.Now().Truncate(blockSize).Add(time.Minute)
+	)
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return now
+	}))
+
+	md, err := namespace.NewMetadata(defaultTestNs1ID,
+		defaultTestNs1Opts.SetRetentionRules(testRetentionRules))
+	require.NoError(t, err)
+	seriesOpts := NewSeriesOptionsFromOptions(opts, md.Options().RetentionOptions())
+	shard := newDatabaseShard(md, 0, nil,
+		newNamespaceReaderManager(md, tally.NoopScope, opts),
+		&testIncreasingIndex{}, nil, testSeriesCardinalityLimiter(md),
+		true, opts, seriesOpts).(*dbShard)
+	defer shard.Close()
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	var (
+		start          = now.Add(-6 * time.Hour)
+		end            = now
+		retentionStart = now.Add(-2 * time.Hour).Truncate(blockSize)
+	)
+	for _, test := range []struct {
+		id    string
+		env   string
+		start time.Time
+	}{
+		{id: "dev", env: "dev", start: retentionStart},
+		{id: "prod", env: "prod", start: start},
+	} {
+		id := ident.StringID(test.id)
+		s := addMockSeries(ctrl, shard, id, ident.Tags{}, 0)
+		s.EXPECT().Metadata().Return(doc.Document{
+			ID:     id.Bytes(),
+			Fields: []doc.Field{{Name: []byte("env"), Value: []byte(test.env)}},
+		}).AnyTimes()
+		s.EXPECT().ReadEncoded(gomock.Any(), test.start, end, gomock.Any()).
+			Return(nil, nil)
+
+		_, err := shard.ReadEncoded(ctx, id, start, end, namespace.Context{})
+		require.NoError(t, err)
+	}
+
+	// Reads entirely past the retention of the series return nothing.
+	encoded, err := shard.ReadEncoded(ctx, ident.StringID("dev"),
+		start, retentionStart, namespace.Context{})
+	require.NoError(t, err)
+	require.Empty(t, encoded)
+}
+
+func TestRetentionRulesFlushPreparerDropsExpiredSeries(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var persisted []string
+	persistFn := func(metadata persist.Metadata, segment ts.Segment, checksum uint32) error {
+		persisted = append(persisted, string(metadata.BytesID()))
+		return nil
+	}
+
+	flushPreparer := persist.NewMockFlushPreparer(ctrl)
+	flushPreparer.EXPECT().PrepareData(gomock.Any()).
+		Return(persist.PreparedDataPersist{Persist: persistFn}, nil)
+
+	matcher, err := namespace.NewRetentionRulesMatcher(testRetentionRules)
+	require.NoError(t, err)
+	preparer := retentionRulesFlushPreparer{
+		FlushPreparer: flushPreparer,
+		rules:         matcher,
+		expired:       2 * time.Hour,
+	}
+	prepared, err := preparer.PrepareData(persist.DataPrepareOptions{})
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		id   string
+		tags ident.Tags
+	}{
+		{id: "dev", tags: ident.NewTags(ident.StringTag("env", "dev"))},
+		{id: "prod", tags: ident.NewTags(ident.StringTag("env", "prod"))},
+		{id: "none", tags: ident.Tags{}},
+	} {
+		metadata := persist.NewMetadataFromIDAndTags(ident.StringID(test.id),
+			test.tags, persist.MetadataOptions{})
+		require.NoError(t, prepared.Persist(metadata, ts.Segment{}, 0))
+	}
+
+	require.Equal(t, []string{"prod", "none"}, persisted)
+}
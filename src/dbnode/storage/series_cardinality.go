@@ -0,0 +1,283 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/series/lookup"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/uber-go/tally"
+	"go.uber.org/atomic"
+)
+
+const (
+	// defaultSeriesCardinalityMetricNames is the default number of metric
+	// names with the most series to report.
+	defaultSeriesCardinalityMetricNames = 10
+
+	// seriesCardinalityStripes is the number of stripes the series per
+	// metric name are counted in, each guarded by its own lock.
+	seriesCardinalityStripes = 64
+)
+
+// seriesCardinalityLimitExceededError is returned when a new series is
+// rejected because a cardinality limit of its namespace has been reached.
+type seriesCardinalityLimitExceededError struct {
+	namespace  string
+	limit      int64
+	metricName string
+}
+
+func (e seriesCardinalityLimitExceededError) Error() string {
+	if e.metricName == "" {
+		return fmt.Sprintf("new series rejected: namespace %s has reached "+
+			"max series limit of %d", e.namespace, e.limit)
+	}
+	return fmt.Sprintf("new series rejected: namespace %s has reached "+
+		"max series per metric name limit of %d for metric name %s",
+		e.namespace, e.limit, e.metricName)
+}
+
+// IsSeriesCardinalityLimitExceededError returns whether an error is the
+// result of a new series being rejected due to a cardinality limit.
+func IsSeriesCardinalityLimitExceededError(err error) bool {
+	_, ok := xerrors.GetInnerInvalidParamsError(err).(seriesCardinalityLimitExceededError)
+	return ok
+}
+
+// seriesCardinalityLimiter tracks the number of active series in a
+// namespace across all of its shards and rejects new series once a
+// cardinality limit of the namespace has been reached.
+//
+// Series are counted from when they are inserted into a shard until they
+// are expired from it, series per metric name are only counted when a
+// limit per metric name is set.
+//
+// The limiter is shared by all shards of the namespace, so the number of
+// series is counted atomically and the series per metric name are counted
+// in stripes keyed by the hash of the metric name, so that inserts of
+// different metric names do not contend on the same lock.
+type seriesCardinalityLimiter struct {
+	namespace     ident.ID
+	limits        namespace.CardinalityLimits
+	metricNameTag []byte
+	numSeries     *atomic.Int64
+	byMetricName  []*seriesCardinalityStripe
+	metrics       seriesCardinalityLimiterMetrics
+}
+
+type seriesCardinalityStripe struct {
+	sync.Mutex
+
+	counts map[string]int64
+}
+
+type seriesCardinalityLimiterMetrics struct {
+	numSeries                      tally.Gauge
+	rejectedMaxSeries              tally.Counter
+	rejectedMaxSeriesPerMetricName tally.Counter
+}
+
+func newSeriesCardinalityLimiterMetrics(
+	scope tally.Scope,
+) seriesCardinalityLimiterMetrics {
+	const rejectedName = "rejected"
+	return seriesCardinalityLimiterMetrics{
+		numSeries: scope.Gauge("num-series"),
+		rejectedMaxSeries: scope.Tagged(map[string]string{
+			"limit": "max-series",
+		}).Counter(rejectedName),
+		rejectedMaxSeriesPerMetricName: scope.Tagged(map[string]string{
+			"limit": "max-series-per-metric-name",
+		}).Counter(rejectedName),
+	}
+}
+
+func newSeriesCardinalityLimiter(
+	nsID ident.ID,
+	limits namespace.CardinalityLimits,
+	scope tally.Scope,
+) *seriesCardinalityLimiter {
+	l := &seriesCardinalityLimiter{
+		namespace:     nsID,
+		limits:        limits,
+		metricNameTag: []byte(limits.MetricNameTagOrDefault()),
+		numSeries:     atomic.NewInt64(0),
+		metrics:       newSeriesCardinalityLimiterMetrics(scope.SubScope("cardinality-limit")),
+	}
+	if limits.MaxSeriesPerMetricName > 0 {
+		l.byMetricName = make([]*seriesCardinalityStripe, seriesCardinalityStripes)
+		for i := range l.byMetricName {
+			l.byMetricName[i] = &seriesCardinalityStripe{counts: make(map[string]int64)}
+		}
+	}
+	return l
+}
+
+// Enabled returns whether new series are subject to cardinality limits.
+func (l *seriesCardinalityLimiter) Enabled() bool {
+	return l.limits.Enabled()
+}
+
+// Reserve counts a new series unless doing so would exceed a cardinality
+// limit, in which case an invalid params error is returned. A reserved
+// series that is not inserted must be released.
+func (l *seriesCardinalityLimiter) Reserve(entry *lookup.Entry) error {
+	name, hasName := l.metricName(entry)
+	if !hasName {
+		return l.reserveSeries()
+	}
+
+	// NB: the series of the metric name are checked first while holding the
+	// lock of its stripe so that the series is only counted towards the
+	// metric name once it has been counted towards the namespace.
+	stripe := l.stripe(name)
+	stripe.Lock()
+	defer stripe.Unlock()
+
+	if max := l.limits.MaxSeriesPerMetricName; stripe.counts[string(name)] >= max {
+		l.metrics.rejectedMaxSeriesPerMetricName.Inc(1)
+		return xerrors.NewInvalidParamsError(seriesCardinalityLimitExceededError{
+			namespace:  l.namespace.String(),
+			limit:      max,
+			metricName: string(name),
+		})
+	}
+	if err := l.reserveSeries(); err != nil {
+		return err
+	}
+
+	stripe.counts[string(name)]++
+	return nil
+}
+
+// reserveSeries counts a new series towards the namespace unless the
+// namespace has reached its max series limit.
+func (l *seriesCardinalityLimiter) reserveSeries() error {
+	max := l.limits.MaxSeries
+	for {
+		numSeries := l.numSeries.Load()
+		if max > 0 && numSeries >= max {
+			l.metrics.rejectedMaxSeries.Inc(1)
+			return xerrors.NewInvalidParamsError(seriesCardinalityLimitExceededError{
+				namespace: l.namespace.String(),
+				limit:     max,
+			})
+		}
+		if l.numSeries.CAS(numSeries, numSeries+1) {
+			l.metrics.numSeries.Update(float64(numSeries + 1))
+			return nil
+		}
+	}
+}
+
+// Add counts a series regardless of the cardinality limits, used for series
+// that must not be rejected such as those loaded while bootstrapping.
+func (l *seriesCardinalityLimiter) Add(entry *lookup.Entry) {
+	l.add(entry, 1)
+}
+
+// Release stops counting a series that was either reserved and never
+// inserted or that has been expired from its shard.
+func (l *seriesCardinalityLimiter) Release(entry *lookup.Entry) {
+	l.add(entry, -1)
+}
+
+func (l *seriesCardinalityLimiter) add(entry *lookup.Entry, delta int64) {
+	numSeries := l.numSeries.Add(delta)
+	l.metrics.numSeries.Update(float64(numSeries))
+
+	name, hasName := l.metricName(entry)
+	if !hasName {
+		return
+	}
+
+	stripe := l.stripe(name)
+	stripe.Lock()
+	count := stripe.counts[string(name)] + delta
+	if count <= 0 {
+		delete(stripe.counts, string(name))
+	} else {
+		stripe.counts[string(name)] = count
+	}
+	stripe.Unlock()
+}
+
+func (l *seriesCardinalityLimiter) stripe(name []byte) *seriesCardinalityStripe {
+	return l.byMetricName[xxhash.Sum64(name)%uint64(len(l.byMetricName))]
+}
+
+func (l *seriesCardinalityLimiter) metricName(entry *lookup.Entry) ([]byte, bool) {
+	if l.byMetricName == nil {
+		return nil, false
+	}
+	for _, field := range entry.Series.Metadata().Fields {
+		if bytes.Equal(field.Name, l.metricNameTag) {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Cardinality returns the number of active series against the cardinality
+// limits, along with the metric names with the most series if series are
+// counted per metric name.
+func (l *seriesCardinalityLimiter) Cardinality(maxMetricNames int) SeriesCardinality {
+	if maxMetricNames <= 0 {
+		maxMetricNames = defaultSeriesCardinalityMetricNames
+	}
+
+	result := SeriesCardinality{
+		Namespace: l.namespace,
+		Limits:    l.limits,
+		NumSeries: l.numSeries.Load(),
+	}
+	for _, stripe := range l.byMetricName {
+		stripe.Lock()
+		for name, count := range stripe.counts {
+			result.MetricNames = append(result.MetricNames, MetricNameSeriesCardinality{
+				MetricName: []byte(name),
+				NumSeries:  count,
+			})
+		}
+		stripe.Unlock()
+	}
+
+	sort.Slice(result.MetricNames, func(i, j int) bool {
+		if result.MetricNames[i].NumSeries != result.MetricNames[j].NumSeries {
+			return result.MetricNames[i].NumSeries > result.MetricNames[j].NumSeries
+		}
+		return bytes.Compare(result.MetricNames[i].MetricName,
+			result.MetricNames[j].MetricName) < 0
+	})
+	if len(result.MetricNames) > maxMetricNames {
+		result.MetricNames = result.MetricNames[:maxMetricNames]
+	}
+	return result
+}
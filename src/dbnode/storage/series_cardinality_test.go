@@ -0,0 +1,237 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/runtime"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/series"
+	"github.com/m3db/m3/src/dbnode/storage/series/lookup"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/x/context"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+	"go.uber.org/atomic"
+)
+
+func testSeriesCardinalityLimiter(md namespace.Metadata) *seriesCardinalityLimiter {
+	return newSeriesCardinalityLimiter(md.ID(),
+		md.Options().CardinalityLimits(), tally.NoopScope)
+}
+
+func testNoLimitsSeriesCardinalityLimiter() *seriesCardinalityLimiter {
+	return newSeriesCardinalityLimiter(defaultTestNs1ID,
+		namespace.CardinalityLimits{}, tally.NoopScope)
+}
+
+func TestSeriesCardinalityLimiterReserveAndRelease(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	limiter := newSeriesCardinalityLimiter(ident.StringID("metrics"),
+		namespace.CardinalityLimits{
+			MaxSeries:              3,
+			MaxSeriesPerMetricName: 2,
+			MetricNameTag:          "name",
+		}, tally.NoopScope)
+	require.True(t, limiter.Enabled())
+
+	newEntry := func(fields ...doc.Field) *lookup.Entry {
+		s := series.NewMockDatabaseSeries(ctrl)
+		s.EXPECT().Metadata().Return(doc.Document{Fields: fields}).AnyTimes()
+		return lookup.NewEntry(lookup.NewEntryOptions{Series: s})
+	}
+	nameField := func(name string) doc.Field {
+		return doc.Field{Name: []byte("name"), Value: []byte(name)}
+	}
+
+	cpu0 := newEntry(nameField("cpu"))
+	require.NoError(t, limiter.Reserve(cpu0))
+	require.NoError(t, limiter.Reserve(newEntry(nameField("cpu"))))
+
+	err := limiter.Reserve(newEntry(nameField("cpu")))
+	require.True(t, xerrors.IsInvalidParams(err))
+	require.True(t, IsSeriesCardinalityLimitExceededError(err))
+	require.EqualError(t, xerrors.GetInnerInvalidParamsError(err),
+		"new series rejected: namespace metrics has reached max series per "+
+			"metric name limit of 2 for metric name cpu")
+
+	// Series without a metric name only count towards the max series.
+	require.NoError(t, limiter.Reserve(newEntry()))
+
+	err = limiter.Reserve(newEntry(nameField("mem")))
+	require.True(t, IsSeriesCardinalityLimitExceededError(err))
+	require.EqualError(t, xerrors.GetInnerInvalidParamsError(err),
+		"new series rejected: namespace metrics has reached max series limit of 3")
+
+	// Series added regardless of the limits are still counted.
+	limiter.Add(newEntry(nameField("mem")))
+	require.Equal(t, SeriesCardinality{
+		Namespace: ident.StringID("metrics"),
+		Limits:    limiter.limits,
+		NumSeries: 4,
+		MetricNames: []MetricNameSeriesCardinality{
+			{MetricName: []byte("cpu"), NumSeries: 2},
+			{MetricName: []byte("mem"), NumSeries: 1},
+		},
+	}, limiter.Cardinality(0))
+	require.Len(t, limiter.Cardinality(1).MetricNames, 1)
+
+	// Releasing series makes room for new ones.
+	limiter.Release(cpu0)
+	limiter.Release(newEntry(nameField("mem")))
+	require.NoError(t, limiter.Reserve(newEntry(nameField("cpu"))))
+
+	cardinality := limiter.Cardinality(0)
+	require.Equal(t, int64(3), cardinality.NumSeries)
+	require.Equal(t, []MetricNameSeriesCardinality{
+		{MetricName: []byte("cpu"), NumSeries: 2},
+	}, cardinality.MetricNames)
+}
+
+func TestSeriesCardinalityLimiterConcurrentReserve(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		numMetricNames = 20
+		numWorkers     = 8
+		limiter        = newSeriesCardinalityLimiter(ident.StringID("metrics"),
+			namespace.CardinalityLimits{
+				MaxSeries:              100,
+				MaxSeriesPerMetricName: 10,
+				MetricNameTag:          "name",
+			}, tally.NoopScope)
+		entries = make([]*lookup.Entry, numMetricNames)
+	)
+	for i := range entries {
+		s := series.NewMockDatabaseSeries(ctrl)
+		s.EXPECT().Metadata().Return(doc.Document{Fields: []doc.Field{{
+			Name:  []byte("name"),
+			Value: []byte(fmt.Sprintf("metric-%d", i)),
+		}}}).AnyTimes()
+		entries[i] = lookup.NewEntry(lookup.NewEntryOptions{Series: s})
+	}
+
+	// Each worker attempts to reserve more series than the limits allow.
+	var (
+		wg       sync.WaitGroup
+		reserved = atomic.NewInt64(0)
+	)
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 2*numMetricNames*10; j++ {
+				if limiter.Reserve(entries[j%numMetricNames]) == nil {
+					reserved.Inc()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	cardinality := limiter.Cardinality(numMetricNames)
+	require.Equal(t, int64(100), reserved.Load())
+	require.Equal(t, int64(100), cardinality.NumSeries)
+
+	var total int64
+	for _, metricName := range cardinality.MetricNames {
+		require.True(t, metricName.NumSeries <= 10)
+		total += metricName.NumSeries
+	}
+	require.Equal(t, int64(100), total)
+}
+
+func TestShardWriteTaggedSeriesCardinalityLimits(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		opts       = DefaultTestOptions()
+		now        = time.Now()
+		blockStart = xtime.ToUnixNano(now.Truncate(namespace.NewIndexOptions().BlockSize()))
+		idx        = NewMockNamespaceIndex(ctrl)
+	)
+	idx.EXPECT().BlockStartForWriteTime(gomock.Any()).Return(blockStart).AnyTimes()
+	idx.EXPECT().WriteBatch(gomock.Any()).Do(func(batch *index.WriteBatch) {
+		for i, e := range batch.PendingEntries() {
+			e.OnIndexSeries.OnIndexSuccess(blockStart)
+			e.OnIndexSeries.OnIndexFinalize(blockStart)
+			batch.PendingEntries()[i].OnIndexSeries = nil
+		}
+	}).Return(nil).AnyTimes()
+
+	md, err := namespace.NewMetadata(defaultTestNs1ID, defaultTestNs1Opts.
+		SetCardinalityLimits(namespace.CardinalityLimits{
+			MaxSeries:              3,
+			MaxSeriesPerMetricName: 2,
+		}))
+	require.NoError(t, err)
+
+	limiter := testSeriesCardinalityLimiter(md)
+	seriesOpts := NewSeriesOptionsFromOptions(opts, md.Options().RetentionOptions())
+	shard := newDatabaseShard(md, 0, nil,
+		newNamespaceReaderManager(md, tally.NoopScope, opts),
+		&testIncreasingIndex{}, idx, limiter, false, opts, seriesOpts).(*dbShard)
+	shard.SetRuntimeOptions(runtime.NewOptions().SetWriteNewSeriesAsync(false))
+	defer shard.Close()
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	write := func(id, name string, value float64) error {
+		tags := ident.NewTags(
+			ident.StringTag("__name__", name),
+			ident.StringTag("id", id),
+		)
+		_, err := shard.WriteTagged(ctx, ident.StringID(id),
+			ident.NewTagsIterator(tags), now, value, xtime.Second, nil,
+			series.WriteOptions{})
+		return err
+	}
+
+	require.NoError(t, write("cpu.0", "cpu", 1))
+	require.NoError(t, write("cpu.1", "cpu", 1))
+	require.True(t, IsSeriesCardinalityLimitExceededError(write("cpu.2", "cpu", 1)))
+	require.NoError(t, write("mem.0", "mem", 1))
+	require.True(t, IsSeriesCardinalityLimitExceededError(write("disk.0", "disk", 1)))
+
+	// Writes to existing series are not affected by the limits.
+	require.NoError(t, write("cpu.0", "cpu", 2))
+	require.NoError(t, write("mem.0", "mem", 2))
+
+	require.Equal(t, int64(3), limiter.Cardinality(0).NumSeries)
+	_, exists := shard.lookup.Get(ident.StringID("cpu.2"))
+	require.False(t, exists)
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/storage/series/lookup"
@@ -164,6 +165,7 @@ type dbShard struct {
 	increasingIndex          increasingIndex
 	seriesPool               series.DatabaseSeriesPool
 	reverseIndex             NamespaceIndex
+	cardinalityLimiter       *seriesCardinalityLimiter
 	insertQueue              *dbShardInsertQueue
 	lookup                   *shardMap
 	list                     *list.List
@@ -184,6 +186,12 @@ type dbShard struct {
 	logger                   *zap.Logger
 	metrics                  dbShardMetrics
 	tileAggregator           TileAggregator
+	tombstones               *shardTombstones
+	tombstonesFilter         tombstonesFilter
+	hashTrees                *shardHashTrees
+	tenantLimits             limits.TenantLimits
+	retentionRules           namespace.RetentionRulesMatcher
+	retentionRulesState      *shardRetentionRules
 	ticking                  bool
 	shard                    uint32
 	coldWritesEnabled        bool
@@ -290,6 +298,7 @@ func newDatabaseShard(
 	namespaceReaderMgr databaseNamespaceReaderManager,
 	increasingIndex increasingIndex,
 	reverseIndex NamespaceIndex,
+	cardinalityLimiter *seriesCardinalityLimiter,
 	needsBootstrap bool,
 	opts Options,
 	seriesOpts series.Options,
@@ -308,6 +317,7 @@ func newDatabaseShard(
 		increasingIndex:      increasingIndex,
 		seriesPool:           opts.DatabaseSeriesPool(),
 		reverseIndex:         reverseIndex,
+		cardinalityLimiter:   cardinalityLimiter,
 		lookup:               newShardMap(shardMapOptions{}),
 		list:                 list.New(),
 		newMergerFn:          fs.NewMerger,
@@ -325,9 +335,12 @@ func newDatabaseShard(
 		logger:               opts.InstrumentOptions().Logger(),
 		metrics:              newDatabaseShardMetrics(shard, scope),
 		tileAggregator:       opts.TileAggregator(),
+		tombstonesFilter:     newTombstonesFilter(opts),
+		hashTrees:            newShardHashTrees(),
+		tenantLimits:         opts.IndexOptions().QueryLimits().TenantLimits(),
 	}
 	s.insertQueue = newDatabaseShardInsertQueue(s.insertSeriesBatch,
-		s.nowFn, scope, opts.InstrumentOptions().Logger())
+		cardinalityLimiter, s.nowFn, scope, opts.InstrumentOptions().Logger())
 
 	registerRuntimeOptionsListener := func(listener runtime.OptionsListener) {
 		elem := opts.RuntimeOptionsManager().RegisterListener(listener)
@@ -344,6 +357,38 @@ func newDatabaseShard(
 		s.bootstrapState = Bootstrapped
 	}
 
+	if tieringMgr := opts.TieringManager(); tieringMgr != nil {
+		// Tiered filesets must also be deleted from the secondary tier.
+		s.deleteFilesFn = tieringMgr.DeleteFiles
+	}
+
+	fsOpts := opts.CommitLogOptions().FilesystemOptions()
+	filePathPrefix := fsOpts.FilePathPrefix()
+	s.tombstones = newShardTombstones(shardTombstonesFilePath(filePathPrefix,
+		namespaceMetadata.ID(), shard), fsOpts)
+	if err := s.tombstones.Load(); err != nil {
+		s.logger.Error("could not load shard tombstones",
+			zap.Uint32("shard", shard), zap.Error(err))
+	}
+
+	retentionRules := namespaceMetadata.Options().RetentionRules()
+	if len(retentionRules) > 0 {
+		matcher, err := namespace.NewRetentionRulesMatcher(retentionRules)
+		if err != nil {
+			s.logger.Error("could not create retention rules matcher",
+				zap.Uint32("shard", shard), zap.Error(err))
+		} else {
+			s.retentionRulesState = newShardRetentionRules(
+				shardRetentionRulesFilePath(filePathPrefix, namespaceMetadata.ID(), shard),
+				retentionRules, fsOpts)
+			if err := s.retentionRulesState.Load(); err != nil {
+				s.logger.Error("could not load shard retention rules state",
+					zap.Uint32("shard", shard), zap.Error(err))
+			}
+			s.retentionRules = matcher
+		}
+	}
+
 	if blockRetriever != nil {
 		s.setBlockRetriever(blockRetriever)
 	}
@@ -875,6 +920,7 @@ func (s *dbShard) purgeExpiredSeries(expiredEntries []*lookup.Entry) {
 		// NB(xichen): if we get here, we are guaranteed that there can be
 		// no more reads/writes to this series while the lock is held, so it's
 		// safe to remove it.
+		s.cardinalityLimiter.Release(entry)
 		series.Close()
 		s.list.Remove(elem)
 		s.lookup.Delete(id)
@@ -927,6 +973,13 @@ func (s *dbShard) writeAndIndex(
 	}
 
 	writable := entry != nil
+	if !writable {
+		// Enforce the limit of series created by the tenant of the series.
+		tenant := s.tenantLimits.Tenant(tags)
+		if err := s.tenantLimits.SeriesCreatedLimit().Inc(tenant, 1); err != nil {
+			return SeriesWrite{}, err
+		}
+	}
 
 	// If no entry and we are not writing new series asynchronously.
 	if !writable && !opts.writeNewSeriesAsync {
@@ -1130,14 +1183,40 @@ func (s *dbShard) ReadEncoded(
 	}
 
 	if entry != nil {
-		return entry.Series.ReadEncoded(ctx, start, end, nsCtx)
+		// Data of series past their own retention is not returned, data on
+		// disk for series that are not in memory is filtered by the retriever.
+		if retentionStart, ok := s.seriesRetentionStart(entry); ok && start.Before(retentionStart) {
+			if !retentionStart.Before(end) {
+				return nil, nil
+			}
+			start = retentionStart
+		}
 	}
 
-	retriever := s.seriesBlockRetriever
-	onRetrieve := s.seriesOnRetrieveBlock
-	opts := s.seriesOpts
-	reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, nil, opts)
-	return reader.ReadEncoded(ctx, start, end, nsCtx)
+	var encoded [][]xio.BlockReader
+	if entry != nil {
+		encoded, err = entry.Series.ReadEncoded(ctx, start, end, nsCtx)
+	} else {
+		retriever := s.seriesBlockRetriever
+		onRetrieve := s.seriesOnRetrieveBlock
+		opts := s.seriesOpts
+		reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, nil, opts)
+		encoded, err = reader.ReadEncoded(ctx, start, end, nsCtx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.filterTombstonedEncoded(ctx, id, encoded, nsCtx)
+}
+
+func (s *dbShard) DeleteSeries(ids []ident.ID, start, end time.Time) (int64, error) {
+	// NB: the range end is inclusive to match the semantics of the
+	// Prometheus delete series API.
+	if err := s.tombstones.Add(ids, start, end.Add(time.Nanosecond)); err != nil {
+		return 0, err
+	}
+	s.hashTrees.invalidate()
+	return int64(len(ids)), nil
 }
 
 func (s *dbShard) FetchWideEntry(
@@ -1424,6 +1503,7 @@ func (s *dbShard) insertSeriesSync(
 	}
 
 	s.insertNewShardEntryWithLock(newEntry)
+	s.cardinalityLimiter.Add(newEntry)
 
 	// Track unlocking.
 	unlocked = true
@@ -1514,12 +1594,20 @@ func (s *dbShard) insertSeriesBatch(inserts []dbShardInsert) error {
 
 		if err == nil {
 			// Already inserted.
+			if inserts[i].opts.cardinalityReserved {
+				s.cardinalityLimiter.Release(inserts[i].entry)
+			}
 			continue
 		}
 
 		if err != errShardEntryNotFound {
 			// Shard is not taking inserts.
 			s.Unlock()
+			for j := i; j < len(inserts); j++ {
+				if inserts[j].opts.cardinalityReserved {
+					s.cardinalityLimiter.Release(inserts[j].entry)
+				}
+			}
 			// FOLLOWUP(prateek): is this an existing bug? why don't we need to release any ref's we've inc'd
 			// on entries in the loop before this point, i.e. in range [0, i). Otherwise, how are those entries
 			// going to get cleaned up?
@@ -1530,6 +1618,9 @@ func (s *dbShard) insertSeriesBatch(inserts []dbShardInsert) error {
 		// Insert still pending, perform the insert
 		entry = inserts[i].entry
 		s.insertNewShardEntryWithLock(entry)
+		if !inserts[i].opts.cardinalityReserved {
+			s.cardinalityLimiter.Add(entry)
+		}
 	}
 	s.Unlock()
 
@@ -1652,17 +1743,34 @@ func (s *dbShard) FetchBlocks(
 	}
 
 	if entry != nil {
-		return entry.Series.FetchBlocks(ctx, starts, nsCtx)
+		if retentionStart, ok := s.seriesRetentionStart(entry); ok {
+			retained := make([]time.Time, 0, len(starts))
+			for _, start := range starts {
+				if !start.Before(retentionStart) {
+					retained = append(retained, start)
+				}
+			}
+			starts = retained
+		}
 	}
 
-	retriever := s.seriesBlockRetriever
-	onRetrieve := s.seriesOnRetrieveBlock
-	opts := s.seriesOpts
-	// Nil for onRead callback because we don't want peer bootstrapping to impact
-	// the behavior of the LRU
-	var onReadCb block.OnReadBlock
-	reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, onReadCb, opts)
-	return reader.FetchBlocks(ctx, starts, nsCtx)
+	var results []block.FetchBlockResult
+	if entry != nil {
+		results, err = entry.Series.FetchBlocks(ctx, starts, nsCtx)
+	} else {
+		retriever := s.seriesBlockRetriever
+		onRetrieve := s.seriesOnRetrieveBlock
+		opts := s.seriesOpts
+		// Nil for onRead callback because we don't want peer bootstrapping to impact
+		// the behavior of the LRU
+		var onReadCb block.OnReadBlock
+		reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, onReadCb, opts)
+		results, err = reader.FetchBlocks(ctx, starts, nsCtx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.filterTombstonedFetchBlocks(ctx, id, results, nsCtx)
 }
 
 func (s *dbShard) FetchBlocksForColdFlush(
@@ -2119,6 +2227,10 @@ func (s *dbShard) LoadBlocks(
 		}
 	}
 
+	// Loaded blocks can change the contents of blocks that have already been
+	// flushed so any cached hash trees may no longer be valid.
+	s.hashTrees.invalidate()
+
 	return multiErr.FinalError()
 }
 
@@ -2249,6 +2361,11 @@ func (s *dbShard) WarmFlush(
 		DeleteIfExists: false,
 		FileSetType:    persist.FileSetFlushType,
 	}
+	// NB: tombstones are not retired after a warm flush since they still
+	// need to hide any data for the block that arrives as cold writes.
+	flushPreparer = s.tombstonesFlushPreparer(flushPreparer, blockStart, nsCtx)
+	flushPreparer = s.retentionRulesFlushPreparer(flushPreparer,
+		s.expiredRetentionPeriod(blockStart))
 	prepared, err := flushPreparer.PrepareData(prepareOpts)
 	if err != nil {
 		return s.markWarmFlushStateSuccessOrError(blockStart, err)
@@ -2362,7 +2479,37 @@ func (s *dbShard) ColdFlush(
 		return shardColdFlush{}, loopErr
 	}
 
-	if dirtySeries.Len() == 0 {
+	// Blocks with tombstones need to be rewritten even if they have no dirty
+	// series so that the deleted data is removed from disk.
+	blockSize := s.namespace.Options().RetentionOptions().BlockSize()
+	numTombstonedBlocks := 0
+	for blockStart := range s.tombstones.BlockStarts(blockSize) {
+		hasWarmFlushed, err := s.hasWarmFlushed(blockStart.ToTime())
+		if err != nil {
+			return shardColdFlush{}, err
+		}
+		if !hasWarmFlushed {
+			continue
+		}
+		if _, ok := dirtySeriesToWrite[blockStart]; !ok {
+			dirtySeriesToWrite[blockStart] = newIDList(idElementPool)
+		}
+		numTombstonedBlocks++
+	}
+
+	// Likewise blocks with series that have expired according to the
+	// retention rules are rewritten to remove the expired series.
+	expiredBlocks, err := s.retentionRulesBlocksToCompact()
+	if err != nil {
+		return shardColdFlush{}, err
+	}
+	for _, blockStart := range expiredBlocks {
+		if _, ok := dirtySeriesToWrite[blockStart]; !ok {
+			dirtySeriesToWrite[blockStart] = newIDList(idElementPool)
+		}
+	}
+
+	if dirtySeries.Len() == 0 && numTombstonedBlocks == 0 && len(expiredBlocks) == 0 {
 		// Early exit if there is nothing dirty to merge. dirtySeriesToWrite
 		// may be non-empty when dirtySeries is empty because we purposely
 		// leave empty seriesLists in the dirtySeriesToWrite map to avoid having
@@ -2398,7 +2545,11 @@ func (s *dbShard) ColdFlush(
 		}
 
 		nextVersion := coldVersion + 1
-		close, err := merger.Merge(fsID, mergeWithMem, nextVersion, flushPreparer, nsCtx,
+		tombstones := s.tombstones.Snapshot(startTime, blockSize)
+		preparer := s.tombstonesFlushPreparerWithSnapshot(flushPreparer, tombstones, nsCtx)
+		expired := s.expiredRetentionPeriod(startTime)
+		preparer = s.retentionRulesFlushPreparer(preparer, expired)
+		close, err := merger.Merge(fsID, mergeWithMem, nextVersion, preparer, nsCtx,
 			onFlushSeries)
 		if err != nil {
 			multiErr = multiErr.Add(err)
@@ -2408,6 +2559,8 @@ func (s *dbShard) ColdFlush(
 			startTime:   startTime,
 			nextVersion: nextVersion,
 			close:       close,
+			tombstones:  tombstones,
+			expired:     expired,
 		})
 	}
 	return flush, multiErr.FinalError()
@@ -2619,6 +2772,18 @@ func (s *dbShard) CleanupExpiredFileSets(earliestToRetain time.Time) error {
 			filePathPrefix, s.namespace.ID(), s.ID(), err)
 	}
 
+	if err := s.tombstones.RemoveBefore(earliestToRetain); err != nil {
+		return fmt.Errorf("encountered errors when removing expired tombstones for namespace %s shard %d: %v",
+			s.namespace.ID(), s.ID(), err)
+	}
+
+	if s.retentionRulesState != nil {
+		if err := s.retentionRulesState.RemoveBefore(earliestToRetain); err != nil {
+			return fmt.Errorf("encountered errors when removing expired retention rules state for namespace %s shard %d: %v",
+				s.namespace.ID(), s.ID(), err)
+		}
+	}
+
 	return s.deleteFilesFn(expired)
 }
 
@@ -2738,6 +2903,7 @@ func (s *dbShard) AggregateTiles(
 		BlockSize:           s.namespace.Options().RetentionOptions().BlockSize(),
 		VolumeIndex:         nextVolume,
 		PlannedRecordsCount: uint(maxEntries),
+		Compression:         s.namespace.Options().CompressionType(),
 	}
 	if err = writer.Open(writerOpenOpts); err != nil {
 		return 0, err
@@ -2867,6 +3033,8 @@ type shardColdFlushDone struct {
 	startTime   time.Time
 	nextVersion int
 	close       persist.DataCloser
+	tombstones  seriesTombstones
+	expired     time.Duration
 }
 
 type shardColdFlush struct {
@@ -2888,6 +3056,26 @@ func (s shardColdFlush) Done() error {
 		err := s.shard.finishWriting(startTime, nextVersion, false)
 		if err != nil {
 			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		// The cold writes are now part of the block so a cached hash tree
+		// of the block no longer reflects its contents.
+		s.shard.hashTrees.invalidateBlock(startTime)
+
+		// The deleted data is no longer on disk so the tombstones that were
+		// applied to this block can be retired.
+		if err := s.shard.tombstones.Remove(done.tombstones); err != nil {
+			multiErr = multiErr.Add(err)
+		}
+
+		// Likewise series that expired according to the retention rules.
+		if done.expired > 0 && s.shard.retentionRulesState != nil {
+			err := s.shard.retentionRulesState.SetCompacted(
+				xtime.ToUnixNano(startTime), done.expired)
+			if err != nil {
+				multiErr = multiErr.Add(err)
+			}
 		}
 	}
 	return multiErr.FinalError()
@@ -0,0 +1,250 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// shardHashTrees caches the hash trees of the flushed blocks of a shard, so
+// that repairs comparing trees with every peer, one level at a time, do not
+// rebuild the tree for each request. Trees of namespaces with cold writes
+// enabled can miss cold writes that arrived after they were built until the
+// block is cold flushed, at which point the tree of the block is dropped.
+type shardHashTrees struct {
+	sync.Mutex
+
+	trees map[xtime.UnixNano]cachedHashTree
+}
+
+type cachedHashTree struct {
+	tree        *repair.HashTree
+	coldVersion int
+}
+
+func newShardHashTrees() *shardHashTrees {
+	return &shardHashTrees{
+		trees: make(map[xtime.UnixNano]cachedHashTree),
+	}
+}
+
+func (t *shardHashTrees) get(
+	blockStart time.Time,
+	opts repair.HashTreeOptions,
+	coldVersion int,
+) (*repair.HashTree, bool) {
+	t.Lock()
+	cached, ok := t.trees[xtime.ToUnixNano(blockStart)]
+	t.Unlock()
+	if !ok || cached.coldVersion != coldVersion || cached.tree.Options() != opts {
+		return nil, false
+	}
+	return cached.tree, true
+}
+
+func (t *shardHashTrees) set(
+	blockStart time.Time,
+	tree *repair.HashTree,
+	coldVersion int,
+	earliest time.Time,
+) {
+	t.Lock()
+	for start := range t.trees {
+		// Drop the trees of blocks that have fallen out of retention.
+		if start.ToTime().Before(earliest) {
+			delete(t.trees, start)
+		}
+	}
+	t.trees[xtime.ToUnixNano(blockStart)] = cachedHashTree{
+		tree:        tree,
+		coldVersion: coldVersion,
+	}
+	t.Unlock()
+}
+
+func (t *shardHashTrees) invalidateBlock(blockStart time.Time) {
+	t.Lock()
+	delete(t.trees, xtime.ToUnixNano(blockStart))
+	t.Unlock()
+}
+
+func (t *shardHashTrees) invalidate() {
+	t.Lock()
+	t.trees = make(map[xtime.UnixNano]cachedHashTree)
+	t.Unlock()
+}
+
+func (s *dbShard) FetchBlockHashTree(
+	ctx context.Context,
+	blockStart time.Time,
+	opts repair.HashTreeOptions,
+) (*repair.HashTree, error) {
+	if err := s.validateHashTreeBlockStart(blockStart); err != nil {
+		return nil, err
+	}
+
+	coldVersion, cacheable := s.hashTreeCacheable(blockStart)
+	if cacheable {
+		if tree, ok := s.hashTrees.get(blockStart, opts, coldVersion); ok {
+			return tree, nil
+		}
+	}
+
+	tree, err := repair.NewHashTree(opts)
+	if err != nil {
+		return nil, err
+	}
+	err = s.forEachBlockChecksum(ctx, blockStart, func(id ident.ID, _ int64, checksum uint32) {
+		tree.Add(id, checksum)
+	})
+	if err != nil {
+		return nil, err
+	}
+	tree.Finalize()
+
+	if cacheable {
+		ropts := s.namespace.Options().RetentionOptions()
+		earliest := retention.FlushTimeStart(ropts, s.nowFn())
+		s.hashTrees.set(blockStart, tree, coldVersion, earliest)
+	}
+	return tree, nil
+}
+
+func (s *dbShard) FetchBlockHashTreeEntries(
+	ctx context.Context,
+	blockStart time.Time,
+	opts repair.HashTreeOptions,
+	leaves []int,
+) ([]repair.HashTreeEntry, error) {
+	if err := s.validateHashTreeBlockStart(blockStart); err != nil {
+		return nil, err
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[int]struct{}, len(leaves))
+	for _, leaf := range leaves {
+		wanted[leaf] = struct{}{}
+	}
+
+	var entries []repair.HashTreeEntry
+	err := s.forEachBlockChecksum(ctx, blockStart, func(id ident.ID, size int64, checksum uint32) {
+		if _, ok := wanted[opts.Leaf(id)]; !ok {
+			return
+		}
+		// Copy the ID since the metadata results are closed once iterated.
+		entries = append(entries, repair.HashTreeEntry{
+			ID:       ident.BytesID(append([]byte(nil), id.Bytes()...)),
+			Size:     size,
+			Checksum: checksum,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (s *dbShard) validateHashTreeBlockStart(blockStart time.Time) error {
+	blockSize := s.namespace.Options().RetentionOptions().BlockSize()
+	if !blockStart.Equal(blockStart.Truncate(blockSize)) {
+		return fmt.Errorf("hash tree block start %v is not aligned to block size %v",
+			blockStart, blockSize)
+	}
+	return nil
+}
+
+// hashTreeCacheable returns whether the hash tree of a block can be cached,
+// which is only the case once the block can no longer receive warm writes
+// and has been flushed, along with the cold flush version the tree is valid
+// for.
+func (s *dbShard) hashTreeCacheable(blockStart time.Time) (int, bool) {
+	ropts := s.namespace.Options().RetentionOptions()
+	writableEnd := blockStart.Add(ropts.BlockSize()).Add(ropts.BufferPast())
+	if !writableEnd.Before(s.nowFn()) {
+		return 0, false
+	}
+
+	state, err := s.FlushState(blockStart)
+	if err != nil || state.WarmStatus != fileOpSuccess {
+		return 0, false
+	}
+	return state.ColdVersionRetrievable, true
+}
+
+// forEachBlockChecksum calls fn with the size and checksum of every series
+// that has data in the block. Series without a checksum are skipped, the
+// same as the repair metadata comparison does, since they represent
+// unmerged data.
+func (s *dbShard) forEachBlockChecksum(
+	ctx context.Context,
+	blockStart time.Time,
+	fn func(id ident.ID, size int64, checksum uint32),
+) error {
+	var (
+		blockEnd = blockStart.Add(s.namespace.Options().RetentionOptions().BlockSize())
+		opts     = block.FetchBlocksMetadataOptions{
+			IncludeSizes:     true,
+			IncludeChecksums: true,
+		}
+		pageToken PageToken
+	)
+	for {
+		// As with repairs, keep paging until a nil page token is returned
+		// since a single call may not return all the metadata.
+		res, nextPageToken, err := s.FetchBlocksMetadataV2(ctx, blockStart, blockEnd,
+			math.MaxInt64, pageToken, opts)
+		if err != nil {
+			return err
+		}
+
+		if res != nil {
+			for _, result := range res.Results() {
+				if result.Blocks == nil {
+					continue
+				}
+				for _, b := range result.Blocks.Results() {
+					if b.Err != nil || b.Checksum == nil || !b.Start.Equal(blockStart) {
+						continue
+					}
+					fn(result.ID, b.Size, *b.Checksum)
+				}
+			}
+			res.Close()
+		}
+
+		if nextPageToken == nil {
+			return nil
+		}
+		pageToken = nextPageToken
+	}
+}
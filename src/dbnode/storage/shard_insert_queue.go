@@ -67,6 +67,7 @@ type dbShardInsertQueue struct {
 	nowFn              clock.NowFn
 	insertEntryBatchFn dbShardInsertEntryBatchFn
 	sleepFn            func(time.Duration)
+	cardinalityLimiter *seriesCardinalityLimiter
 
 	// rate limits, protected by mutex
 	insertBatchBackoff   time.Duration
@@ -124,6 +125,7 @@ type dbShardInsertEntryBatchFn func(inserts []dbShardInsert) error
 // 4x during floods of new series.
 func newDatabaseShardInsertQueue(
 	insertEntryBatchFn dbShardInsertEntryBatchFn,
+	cardinalityLimiter *seriesCardinalityLimiter,
 	nowFn clock.NowFn,
 	scope tally.Scope,
 	logger *zap.Logger,
@@ -134,6 +136,7 @@ func newDatabaseShardInsertQueue(
 		nowFn:              nowFn,
 		insertEntryBatchFn: insertEntryBatchFn,
 		sleepFn:            time.Sleep,
+		cardinalityLimiter: cardinalityLimiter,
 		currBatch:          currBatch,
 		// NB(r): Use 2 * num cores so that each CPU insert queue which
 		// is 1 per num CPU core can always enqueue a notification without
@@ -286,6 +289,15 @@ func (q *dbShardInsertQueue) Insert(insert dbShardInsert) (*sync.WaitGroup, erro
 				return nil, errNewSeriesInsertRateLimitExceeded
 			}
 		}
+
+		// NB: Only new series are subject to the rate limit so writes to
+		// existing series are never rejected by the cardinality limits.
+		if q.cardinalityLimiter.Enabled() {
+			if err := q.cardinalityLimiter.Reserve(insert.entry); err != nil {
+				return nil, err
+			}
+			insert.opts.cardinalityReserved = true
+		}
 	}
 
 	inserts := q.currBatch.insertsByCPUCore[xsync.CPUCore()]
@@ -342,6 +354,11 @@ type dbShardInsert struct {
 type dbShardInsertAsyncOptions struct {
 	skipRateLimit bool
 
+	// cardinalityReserved indicates the series has been counted against
+	// the cardinality limits of the namespace, if the series turns out to
+	// already be inserted the reservation is released.
+	cardinalityReserved bool
+
 	pendingWrite          dbShardPendingWrite
 	pendingRetrievedBlock dbShardPendingRetrievedBlock
 	pendingIndex          dbShardPendingIndex
@@ -66,7 +66,7 @@ func TestShardInsertQueueBatchBackoff(t *testing.T) {
 		insertWgs[len(inserts)-1].Done()
 		insertProgressWgs[len(inserts)-1].Wait()
 		return nil
-	}, func() time.Time {
+	}, testNoLimitsSeriesCardinalityLimiter(), func() time.Time {
 		timeLock.Lock()
 		defer timeLock.Unlock()
 		return currTime
@@ -144,7 +144,7 @@ func TestShardInsertQueueRateLimit(t *testing.T) {
 	)
 	q := newDatabaseShardInsertQueue(func(value []dbShardInsert) error {
 		return nil
-	}, func() time.Time {
+	}, testNoLimitsSeriesCardinalityLimiter(), func() time.Time {
 		timeLock.Lock()
 		defer timeLock.Unlock()
 		return currTime
@@ -209,7 +209,9 @@ func TestShardInsertQueueFlushedOnClose(t *testing.T) {
 	q := newDatabaseShardInsertQueue(func(value []dbShardInsert) error {
 		atomic.AddInt64(&numInsertObserved, int64(len(value)))
 		return nil
-	}, func() time.Time { return currTime }, tally.NoopScope, zap.NewNop())
+	}, testNoLimitsSeriesCardinalityLimiter(), func() time.Time {
+		return currTime
+	}, tally.NoopScope, zap.NewNop())
 
 	require.NoError(t, q.Start())
 
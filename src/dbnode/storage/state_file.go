@@ -0,0 +1,127 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package storage
+
+import (
+	"os"
+	"path"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+)
+
+// shardStateFileWriter writes the files that shards keep state in alongside
+// their filesets, such as tombstones, with the same file and directory modes
+// as the fs package uses for filesets.
+type shardStateFileWriter struct {
+	newFileMode      os.FileMode
+	newDirectoryMode os.FileMode
+}
+
+func newShardStateFileWriter(opts fs.Options) shardStateFileWriter {
+	return shardStateFileWriter{
+		newFileMode:      opts.NewFileMode(),
+		newDirectoryMode: opts.NewDirectoryMode(),
+	}
+}
+
+// Write atomically replaces the contents of a state file. The data is written
+// to a temporary file which is synced before it is renamed over the state
+// file, so that a crash never leaves a partially written state file behind,
+// and the directory is synced after the rename so that the rename is durable.
+func (w shardStateFileWriter) Write(filePath string, data []byte) error {
+	dir := path.Dir(filePath)
+	if err := os.MkdirAll(dir, w.newDirectoryMode); err != nil {
+		return err
+	}
+
+	tmpFilePath := filePath + ".tmp"
+	if err := w.writeAndSync(tmpFilePath,
+		os.O_WRONLY|os.O_CREATE|os.O_TRUNC, data); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFilePath, filePath); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// Append appends data to a state file and syncs it before returning.
+func (w shardStateFileWriter) Append(filePath string, data []byte) error {
+	dir := path.Dir(filePath)
+	if err := os.MkdirAll(dir, w.newDirectoryMode); err != nil {
+		return err
+	}
+
+	_, err := os.Stat(filePath)
+	created := os.IsNotExist(err)
+	if err := w.writeAndSync(filePath,
+		os.O_WRONLY|os.O_CREATE|os.O_APPEND, data); err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+	// Sync the directory so that a newly created file is durable.
+	return syncDir(dir)
+}
+
+// Remove removes a state file if it exists.
+func (w shardStateFileWriter) Remove(filePath string) error {
+	err := os.Remove(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return syncDir(path.Dir(filePath))
+}
+
+func (w shardStateFileWriter) writeAndSync(
+	filePath string,
+	flag int,
+	data []byte,
+) error {
+	f, err := os.OpenFile(filePath, flag, w.newFileMode)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := d.Sync(); err != nil {
+		d.Close()
+		return err
+	}
+	return d.Close()
+}
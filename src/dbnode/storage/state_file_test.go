@@ -0,0 +1,66 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardStateFileWriter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "state_file")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		filePath = path.Join(dir, "shard", "state.json")
+		writer   = newShardStateFileWriter(fs.NewOptions().
+				SetNewFileMode(0600).
+				SetNewDirectoryMode(0700))
+	)
+	require.NoError(t, writer.Write(filePath, []byte("foo")))
+	require.NoError(t, writer.Append(filePath, []byte("bar")))
+
+	data, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	require.Equal(t, "foobar", string(data))
+
+	info, err := os.Stat(filePath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	info, err = os.Stat(path.Dir(filePath))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	// The temporary file is renamed over the state file.
+	_, err = os.Stat(filePath + ".tmp")
+	require.True(t, os.IsNotExist(err))
+
+	require.NoError(t, writer.Remove(filePath))
+	require.NoError(t, writer.Remove(filePath))
+	_, err = os.Stat(filePath)
+	require.True(t, os.IsNotExist(err))
+}
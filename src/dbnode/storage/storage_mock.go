@@ -34,7 +34,9 @@ import (
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tier"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/sharding"
@@ -406,6 +408,51 @@ func (mr *MockDatabaseMockRecorder) FetchBlocksMetadataV2(ctx, namespace, shard,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlocksMetadataV2", reflect.TypeOf((*MockDatabase)(nil).FetchBlocksMetadataV2), ctx, namespace, shard, start, end, limit, pageToken, opts)
 }
 
+// FetchBlockHashTree mocks base method
+func (m *MockDatabase) FetchBlockHashTree(ctx context.Context, namespace ident.ID, shard uint32, blockStart time.Time, opts repair.HashTreeOptions) (*repair.HashTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBlockHashTree", ctx, namespace, shard, blockStart, opts)
+	ret0, _ := ret[0].(*repair.HashTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBlockHashTree indicates an expected call of FetchBlockHashTree
+func (mr *MockDatabaseMockRecorder) FetchBlockHashTree(ctx, namespace, shard, blockStart, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlockHashTree", reflect.TypeOf((*MockDatabase)(nil).FetchBlockHashTree), ctx, namespace, shard, blockStart, opts)
+}
+
+// FetchBlockHashTreeEntries mocks base method
+func (m *MockDatabase) FetchBlockHashTreeEntries(ctx context.Context, namespace ident.ID, shard uint32, blockStart time.Time, opts repair.HashTreeOptions, leaves []int) ([]repair.HashTreeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBlockHashTreeEntries", ctx, namespace, shard, blockStart, opts, leaves)
+	ret0, _ := ret[0].([]repair.HashTreeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBlockHashTreeEntries indicates an expected call of FetchBlockHashTreeEntries
+func (mr *MockDatabaseMockRecorder) FetchBlockHashTreeEntries(ctx, namespace, shard, blockStart, opts, leaves interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlockHashTreeEntries", reflect.TypeOf((*MockDatabase)(nil).FetchBlockHashTreeEntries), ctx, namespace, shard, blockStart, opts, leaves)
+}
+
+// SeriesCardinality mocks base method
+func (m *MockDatabase) SeriesCardinality(namespace ident.ID, maxMetricNames int) (SeriesCardinality, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeriesCardinality", namespace, maxMetricNames)
+	ret0, _ := ret[0].(SeriesCardinality)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SeriesCardinality indicates an expected call of SeriesCardinality
+func (mr *MockDatabaseMockRecorder) SeriesCardinality(namespace, maxMetricNames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeriesCardinality", reflect.TypeOf((*MockDatabase)(nil).SeriesCardinality), namespace, maxMetricNames)
+}
+
 // Bootstrap mocks base method
 func (m *MockDatabase) Bootstrap() error {
 	m.ctrl.T.Helper()
@@ -491,6 +538,21 @@ func (mr *MockDatabaseMockRecorder) Truncate(namespace interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Truncate", reflect.TypeOf((*MockDatabase)(nil).Truncate), namespace)
 }
 
+// DeleteSeries mocks base method
+func (m *MockDatabase) DeleteSeries(ctx context.Context, namespace ident.ID, ids []ident.ID, start, end time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, namespace, ids, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries
+func (mr *MockDatabaseMockRecorder) DeleteSeries(ctx, namespace, ids, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockDatabase)(nil).DeleteSeries), ctx, namespace, ids, start, end)
+}
+
 // BootstrapState mocks base method
 func (m *MockDatabase) BootstrapState() DatabaseBootstrapState {
 	m.ctrl.T.Helper()
@@ -535,6 +597,21 @@ func (mr *MockDatabaseMockRecorder) AggregateTiles(ctx, sourceNsID, targetNsID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateTiles", reflect.TypeOf((*MockDatabase)(nil).AggregateTiles), ctx, sourceNsID, targetNsID, opts)
 }
 
+// Backup mocks base method
+func (m *MockDatabase) Backup(opts BackupOptions) (backup.Manifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", opts)
+	ret0, _ := ret[0].(backup.Manifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backup indicates an expected call of Backup
+func (mr *MockDatabaseMockRecorder) Backup(opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockDatabase)(nil).Backup), opts)
+}
+
 // Mockdatabase is a mock of database interface
 type Mockdatabase struct {
 	ctrl     *gomock.Controller
@@ -845,6 +922,51 @@ func (mr *MockdatabaseMockRecorder) FetchBlocksMetadataV2(ctx, namespace, shard,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlocksMetadataV2", reflect.TypeOf((*Mockdatabase)(nil).FetchBlocksMetadataV2), ctx, namespace, shard, start, end, limit, pageToken, opts)
 }
 
+// FetchBlockHashTree mocks base method
+func (m *Mockdatabase) FetchBlockHashTree(ctx context.Context, namespace ident.ID, shard uint32, blockStart time.Time, opts repair.HashTreeOptions) (*repair.HashTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBlockHashTree", ctx, namespace, shard, blockStart, opts)
+	ret0, _ := ret[0].(*repair.HashTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBlockHashTree indicates an expected call of FetchBlockHashTree
+func (mr *MockdatabaseMockRecorder) FetchBlockHashTree(ctx, namespace, shard, blockStart, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlockHashTree", reflect.TypeOf((*Mockdatabase)(nil).FetchBlockHashTree), ctx, namespace, shard, blockStart, opts)
+}
+
+// FetchBlockHashTreeEntries mocks base method
+func (m *Mockdatabase) FetchBlockHashTreeEntries(ctx context.Context, namespace ident.ID, shard uint32, blockStart time.Time, opts repair.HashTreeOptions, leaves []int) ([]repair.HashTreeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBlockHashTreeEntries", ctx, namespace, shard, blockStart, opts, leaves)
+	ret0, _ := ret[0].([]repair.HashTreeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBlockHashTreeEntries indicates an expected call of FetchBlockHashTreeEntries
+func (mr *MockdatabaseMockRecorder) FetchBlockHashTreeEntries(ctx, namespace, shard, blockStart, opts, leaves interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlockHashTreeEntries", reflect.TypeOf((*Mockdatabase)(nil).FetchBlockHashTreeEntries), ctx, namespace, shard, blockStart, opts, leaves)
+}
+
+// SeriesCardinality mocks base method
+func (m *Mockdatabase) SeriesCardinality(namespace ident.ID, maxMetricNames int) (SeriesCardinality, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeriesCardinality", namespace, maxMetricNames)
+	ret0, _ := ret[0].(SeriesCardinality)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SeriesCardinality indicates an expected call of SeriesCardinality
+func (mr *MockdatabaseMockRecorder) SeriesCardinality(namespace, maxMetricNames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeriesCardinality", reflect.TypeOf((*Mockdatabase)(nil).SeriesCardinality), namespace, maxMetricNames)
+}
+
 // Bootstrap mocks base method
 func (m *Mockdatabase) Bootstrap() error {
 	m.ctrl.T.Helper()
@@ -930,6 +1052,21 @@ func (mr *MockdatabaseMockRecorder) Truncate(namespace interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Truncate", reflect.TypeOf((*Mockdatabase)(nil).Truncate), namespace)
 }
 
+// DeleteSeries mocks base method
+func (m *Mockdatabase) DeleteSeries(ctx context.Context, namespace ident.ID, ids []ident.ID, start, end time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, namespace, ids, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries
+func (mr *MockdatabaseMockRecorder) DeleteSeries(ctx, namespace, ids, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*Mockdatabase)(nil).DeleteSeries), ctx, namespace, ids, start, end)
+}
+
 // BootstrapState mocks base method
 func (m *Mockdatabase) BootstrapState() DatabaseBootstrapState {
 	m.ctrl.T.Helper()
@@ -974,6 +1111,21 @@ func (mr *MockdatabaseMockRecorder) AggregateTiles(ctx, sourceNsID, targetNsID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateTiles", reflect.TypeOf((*Mockdatabase)(nil).AggregateTiles), ctx, sourceNsID, targetNsID, opts)
 }
 
+// Backup mocks base method
+func (m *Mockdatabase) Backup(opts BackupOptions) (backup.Manifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", opts)
+	ret0, _ := ret[0].(backup.Manifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backup indicates an expected call of Backup
+func (mr *MockdatabaseMockRecorder) Backup(opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*Mockdatabase)(nil).Backup), opts)
+}
+
 // OwnedNamespaces mocks base method
 func (m *Mockdatabase) OwnedNamespaces() ([]databaseNamespace, error) {
 	m.ctrl.T.Helper()
@@ -1605,6 +1757,50 @@ func (mr *MockdatabaseNamespaceMockRecorder) FetchBlocksMetadataV2(ctx, shardID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlocksMetadataV2", reflect.TypeOf((*MockdatabaseNamespace)(nil).FetchBlocksMetadataV2), ctx, shardID, start, end, limit, pageToken, opts)
 }
 
+// FetchBlockHashTree mocks base method
+func (m *MockdatabaseNamespace) FetchBlockHashTree(ctx context.Context, shardID uint32, blockStart time.Time, opts repair.HashTreeOptions) (*repair.HashTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBlockHashTree", ctx, shardID, blockStart, opts)
+	ret0, _ := ret[0].(*repair.HashTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBlockHashTree indicates an expected call of FetchBlockHashTree
+func (mr *MockdatabaseNamespaceMockRecorder) FetchBlockHashTree(ctx, shardID, blockStart, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlockHashTree", reflect.TypeOf((*MockdatabaseNamespace)(nil).FetchBlockHashTree), ctx, shardID, blockStart, opts)
+}
+
+// FetchBlockHashTreeEntries mocks base method
+func (m *MockdatabaseNamespace) FetchBlockHashTreeEntries(ctx context.Context, shardID uint32, blockStart time.Time, opts repair.HashTreeOptions, leaves []int) ([]repair.HashTreeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBlockHashTreeEntries", ctx, shardID, blockStart, opts, leaves)
+	ret0, _ := ret[0].([]repair.HashTreeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBlockHashTreeEntries indicates an expected call of FetchBlockHashTreeEntries
+func (mr *MockdatabaseNamespaceMockRecorder) FetchBlockHashTreeEntries(ctx, shardID, blockStart, opts, leaves interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlockHashTreeEntries", reflect.TypeOf((*MockdatabaseNamespace)(nil).FetchBlockHashTreeEntries), ctx, shardID, blockStart, opts, leaves)
+}
+
+// SeriesCardinality mocks base method
+func (m *MockdatabaseNamespace) SeriesCardinality(maxMetricNames int) SeriesCardinality {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeriesCardinality", maxMetricNames)
+	ret0, _ := ret[0].(SeriesCardinality)
+	return ret0
+}
+
+// SeriesCardinality indicates an expected call of SeriesCardinality
+func (mr *MockdatabaseNamespaceMockRecorder) SeriesCardinality(maxMetricNames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeriesCardinality", reflect.TypeOf((*MockdatabaseNamespace)(nil).SeriesCardinality), maxMetricNames)
+}
+
 // PrepareBootstrap mocks base method
 func (m *MockdatabaseNamespace) PrepareBootstrap(ctx context.Context) ([]databaseShard, error) {
 	m.ctrl.T.Helper()
@@ -1720,6 +1916,21 @@ func (mr *MockdatabaseNamespaceMockRecorder) Truncate() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Truncate", reflect.TypeOf((*MockdatabaseNamespace)(nil).Truncate))
 }
 
+// DeleteSeries mocks base method
+func (m *MockdatabaseNamespace) DeleteSeries(ids []ident.ID, start, end time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ids, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries
+func (mr *MockdatabaseNamespaceMockRecorder) DeleteSeries(ids, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockdatabaseNamespace)(nil).DeleteSeries), ids, start, end)
+}
+
 // Repair mocks base method
 func (m *MockdatabaseNamespace) Repair(repairer databaseShardRepairer, tr time0.Range) error {
 	m.ctrl.T.Helper()
@@ -1822,6 +2033,21 @@ func (mr *MockdatabaseNamespaceMockRecorder) AggregateTiles(ctx, sourceNs, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateTiles", reflect.TypeOf((*MockdatabaseNamespace)(nil).AggregateTiles), ctx, sourceNs, opts)
 }
 
+// AggregateFlushedTiles mocks base method
+func (m *MockdatabaseNamespace) AggregateFlushedTiles(ctx context.Context, sourceNs databaseNamespace, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateFlushedTiles", ctx, sourceNs, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateFlushedTiles indicates an expected call of AggregateFlushedTiles
+func (mr *MockdatabaseNamespaceMockRecorder) AggregateFlushedTiles(ctx, sourceNs, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateFlushedTiles", reflect.TypeOf((*MockdatabaseNamespace)(nil).AggregateFlushedTiles), ctx, sourceNs, now)
+}
+
 // ReadableShardAt mocks base method
 func (m *MockdatabaseNamespace) ReadableShardAt(shardID uint32) (databaseShard, namespace.Context, error) {
 	m.ctrl.T.Helper()
@@ -2082,6 +2308,35 @@ func (mr *MockdatabaseShardMockRecorder) ReadEncoded(ctx, id, start, end, nsCtx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEncoded", reflect.TypeOf((*MockdatabaseShard)(nil).ReadEncoded), ctx, id, start, end, nsCtx)
 }
 
+// DeleteSeries mocks base method
+func (m *MockdatabaseShard) DeleteSeries(ids []ident.ID, start, end time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ids, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries
+func (mr *MockdatabaseShardMockRecorder) DeleteSeries(ids, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockdatabaseShard)(nil).DeleteSeries), ids, start, end)
+}
+
+// HasTombstones mocks base method
+func (m *MockdatabaseShard) HasTombstones() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasTombstones")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasTombstones indicates an expected call of HasTombstones
+func (mr *MockdatabaseShardMockRecorder) HasTombstones() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasTombstones", reflect.TypeOf((*MockdatabaseShard)(nil).HasTombstones))
+}
+
 // FetchWideEntry mocks base method
 func (m *MockdatabaseShard) FetchWideEntry(ctx context.Context, id ident.ID, blockStart time.Time, filter schema.WideEntryFilter, nsCtx namespace.Context) (block.StreamedWideEntry, error) {
 	m.ctrl.T.Helper()
@@ -2143,6 +2398,36 @@ func (mr *MockdatabaseShardMockRecorder) FetchBlocksMetadataV2(ctx, start, end,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlocksMetadataV2", reflect.TypeOf((*MockdatabaseShard)(nil).FetchBlocksMetadataV2), ctx, start, end, limit, pageToken, opts)
 }
 
+// FetchBlockHashTree mocks base method
+func (m *MockdatabaseShard) FetchBlockHashTree(ctx context.Context, blockStart time.Time, opts repair.HashTreeOptions) (*repair.HashTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBlockHashTree", ctx, blockStart, opts)
+	ret0, _ := ret[0].(*repair.HashTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBlockHashTree indicates an expected call of FetchBlockHashTree
+func (mr *MockdatabaseShardMockRecorder) FetchBlockHashTree(ctx, blockStart, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlockHashTree", reflect.TypeOf((*MockdatabaseShard)(nil).FetchBlockHashTree), ctx, blockStart, opts)
+}
+
+// FetchBlockHashTreeEntries mocks base method
+func (m *MockdatabaseShard) FetchBlockHashTreeEntries(ctx context.Context, blockStart time.Time, opts repair.HashTreeOptions, leaves []int) ([]repair.HashTreeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBlockHashTreeEntries", ctx, blockStart, opts, leaves)
+	ret0, _ := ret[0].([]repair.HashTreeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBlockHashTreeEntries indicates an expected call of FetchBlockHashTreeEntries
+func (mr *MockdatabaseShardMockRecorder) FetchBlockHashTreeEntries(ctx, blockStart, opts, leaves interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlockHashTreeEntries", reflect.TypeOf((*MockdatabaseShard)(nil).FetchBlockHashTreeEntries), ctx, blockStart, opts, leaves)
+}
+
 // PrepareBootstrap mocks base method
 func (m *MockdatabaseShard) PrepareBootstrap(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -3594,6 +3879,20 @@ func (mr *MockdatabaseMediatorMockRecorder) LastSuccessfulSnapshotStartTime() *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastSuccessfulSnapshotStartTime", reflect.TypeOf((*MockdatabaseMediator)(nil).LastSuccessfulSnapshotStartTime))
 }
 
+// WarmFlushAndSnapshot mocks base method
+func (m *MockdatabaseMediator) WarmFlushAndSnapshot() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WarmFlushAndSnapshot")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WarmFlushAndSnapshot indicates an expected call of WarmFlushAndSnapshot
+func (mr *MockdatabaseMediatorMockRecorder) WarmFlushAndSnapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WarmFlushAndSnapshot", reflect.TypeOf((*MockdatabaseMediator)(nil).WarmFlushAndSnapshot))
+}
+
 // MockOnColdFlush is a mock of OnColdFlush interface
 type MockOnColdFlush struct {
 	ctrl     *gomock.Controller
@@ -4182,6 +4481,34 @@ func (mr *MockOptionsMockRecorder) IndexClaimsManager() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexClaimsManager", reflect.TypeOf((*MockOptions)(nil).IndexClaimsManager))
 }
 
+// SetTieringManager mocks base method
+func (m *MockOptions) SetTieringManager(value tier.Manager) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTieringManager", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetTieringManager indicates an expected call of SetTieringManager
+func (mr *MockOptionsMockRecorder) SetTieringManager(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTieringManager", reflect.TypeOf((*MockOptions)(nil).SetTieringManager), value)
+}
+
+// TieringManager mocks base method
+func (m *MockOptions) TieringManager() tier.Manager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TieringManager")
+	ret0, _ := ret[0].(tier.Manager)
+	return ret0
+}
+
+// TieringManager indicates an expected call of TieringManager
+func (mr *MockOptionsMockRecorder) TieringManager() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TieringManager", reflect.TypeOf((*MockOptions)(nil).TieringManager))
+}
+
 // SetDatabaseBlockRetrieverManager mocks base method
 func (m *MockOptions) SetDatabaseBlockRetrieverManager(value block.DatabaseBlockRetrieverManager) Options {
 	m.ctrl.T.Helper()
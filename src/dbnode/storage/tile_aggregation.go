@@ -0,0 +1,502 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/context"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const tileAggregationFileName = "tile_aggregation.json"
+
+var errTileAggregatorNotConfigured = errors.New(
+	"tile aggregation requires a tile aggregator to be configured")
+
+// shardTileAggregationProgress tracks up to when the flushed blocks of a
+// source namespace have been aggregated into a shard of an aggregated
+// namespace.
+//
+// Progress is persisted to a file in the shard data directory of the
+// aggregated namespace after each aggregation so that scheduled aggregation
+// resumes where it left off after a restart. The cold version of each
+// aggregated source block is tracked as well so that blocks which receive
+// cold writes after they were aggregated are aggregated again. Progress is
+// discarded if the source namespace changes.
+type shardTileAggregationProgress struct {
+	sync.RWMutex
+
+	filePath        string
+	writer          shardStateFileWriter
+	sourceNamespace string
+	aggregatedUntil time.Time
+	coldVersions    map[xtime.UnixNano]int
+}
+
+type tileAggregationFile struct {
+	SourceNamespace string                     `json:"sourceNamespace"`
+	AggregatedUntil int64                      `json:"aggregatedUntil"`
+	Blocks          []tileAggregationFileBlock `json:"blocks,omitempty"`
+}
+
+type tileAggregationFileBlock struct {
+	Start       int64 `json:"start"`
+	ColdVersion int   `json:"coldVersion"`
+}
+
+func newShardTileAggregationProgress(
+	filePath string,
+	sourceNamespace string,
+	opts fs.Options,
+) *shardTileAggregationProgress {
+	return &shardTileAggregationProgress{
+		filePath:        filePath,
+		writer:          newShardStateFileWriter(opts),
+		sourceNamespace: sourceNamespace,
+		coldVersions:    make(map[xtime.UnixNano]int),
+	}
+}
+
+func shardTileAggregationFilePath(
+	filePathPrefix string,
+	namespace ident.ID,
+	shard uint32,
+) string {
+	return path.Join(fs.ShardDataDirPath(filePathPrefix, namespace, shard),
+		tileAggregationFileName)
+}
+
+// Load reads any previously persisted progress for the same source namespace.
+func (p *shardTileAggregationProgress) Load() error {
+	data, err := ioutil.ReadFile(p.filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var file tileAggregationFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("unable to decode tile aggregation file %s: %v",
+			p.filePath, err)
+	}
+
+	if file.SourceNamespace != p.sourceNamespace {
+		// Aggregating from a different source, start over.
+		return nil
+	}
+
+	p.Lock()
+	p.aggregatedUntil = time.Unix(0, file.AggregatedUntil)
+	p.coldVersions = make(map[xtime.UnixNano]int, len(file.Blocks))
+	for _, b := range file.Blocks {
+		p.coldVersions[xtime.UnixNano(b.Start)] = b.ColdVersion
+	}
+	p.Unlock()
+	return nil
+}
+
+// AggregatedUntil returns the time up to which source blocks have been
+// aggregated, zero if none have.
+func (p *shardTileAggregationProgress) AggregatedUntil() time.Time {
+	p.RLock()
+	defer p.RUnlock()
+	return p.aggregatedUntil
+}
+
+// ColdVersion returns the cold version of the source block when it was last
+// aggregated, zero if it had no cold writes flushed.
+func (p *shardTileAggregationProgress) ColdVersion(blockStart time.Time) int {
+	p.RLock()
+	defer p.RUnlock()
+	return p.coldVersions[xtime.ToUnixNano(blockStart)]
+}
+
+// SetAggregated records that source blocks have been aggregated up to the
+// given time with the given cold versions. Cold versions of blocks before the
+// earliest block that can be aggregated are discarded.
+func (p *shardTileAggregationProgress) SetAggregated(
+	until time.Time,
+	coldVersions map[xtime.UnixNano]int,
+	earliest time.Time,
+) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if until.After(p.aggregatedUntil) {
+		p.aggregatedUntil = until
+	}
+
+	for blockStart, version := range coldVersions {
+		p.coldVersions[blockStart] = version
+	}
+
+	for blockStart := range p.coldVersions {
+		if blockStart.ToTime().Before(earliest) {
+			delete(p.coldVersions, blockStart)
+		}
+	}
+
+	return p.persistWithLock()
+}
+
+func (p *shardTileAggregationProgress) persistWithLock() error {
+	file := tileAggregationFile{
+		SourceNamespace: p.sourceNamespace,
+		AggregatedUntil: p.aggregatedUntil.UnixNano(),
+	}
+	for blockStart, version := range p.coldVersions {
+		if version == 0 {
+			continue
+		}
+		file.Blocks = append(file.Blocks, tileAggregationFileBlock{
+			Start:       int64(blockStart),
+			ColdVersion: version,
+		})
+	}
+	sort.Slice(file.Blocks, func(i, j int) bool {
+		return file.Blocks[i].Start < file.Blocks[j].Start
+	})
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	return p.writer.Write(p.filePath, data)
+}
+
+func (n *dbNamespace) tileAggregationProgressFor(
+	shardID uint32,
+	sourceNs ident.ID,
+) (*shardTileAggregationProgress, error) {
+	n.tileAggregationLock.Lock()
+	defer n.tileAggregationLock.Unlock()
+
+	progress, ok := n.tileAggregationProgress[shardID]
+	if ok && progress.sourceNamespace == sourceNs.String() {
+		return progress, nil
+	}
+
+	fsOpts := n.opts.CommitLogOptions().FilesystemOptions()
+	progress = newShardTileAggregationProgress(
+		shardTileAggregationFilePath(fsOpts.FilePathPrefix(), n.id, shardID),
+		sourceNs.String(), fsOpts)
+	if err := progress.Load(); err != nil {
+		return nil, err
+	}
+
+	if n.tileAggregationProgress == nil {
+		n.tileAggregationProgress = make(map[uint32]*shardTileAggregationProgress)
+	}
+	n.tileAggregationProgress[shardID] = progress
+	return progress, nil
+}
+
+func (n *dbNamespace) AggregateFlushedTiles(
+	ctx context.Context,
+	sourceNs databaseNamespace,
+	now time.Time,
+) (int64, error) {
+	attrs, ok := namespace.TileAggregation(n.Metadata().Options().AggregationOptions())
+	if !ok {
+		return 0, nil
+	}
+
+	// NB: the default tile aggregator does not write any tiles, so without a
+	// configured aggregator progress would be recorded for empty blocks.
+	if _, ok := n.opts.TileAggregator().(*noopTileAggregator); ok {
+		return 0, errTileAggregatorNotConfigured
+	}
+
+	if n.BootstrapState() != Bootstrapped || sourceNs.BootstrapState() != Bootstrapped {
+		return 0, errNamespaceNotBootstrapped
+	}
+
+	var (
+		sourceRetentionOpts = sourceNs.Options().RetentionOptions()
+		targetRetentionOpts = n.Metadata().Options().RetentionOptions()
+		sourceBlockSize     = sourceRetentionOpts.BlockSize()
+		targetBlockSize     = targetRetentionOpts.BlockSize()
+		earliest            = retention.FlushTimeStart(sourceRetentionOpts, now)
+		latest              = retention.FlushTimeEnd(sourceRetentionOpts, now)
+	)
+	if targetBlockSize%sourceBlockSize != 0 {
+		return 0, fmt.Errorf(
+			"tile aggregation block size %s must be a multiple of source namespace %s block size %s",
+			targetBlockSize, sourceNs.ID(), sourceBlockSize)
+	}
+	if targetEarliest := retention.FlushTimeStart(targetRetentionOpts, now); targetEarliest.After(earliest) {
+		earliest = targetEarliest
+	}
+
+	var (
+		processedTileCount int64
+		onColdFlushNs      OnColdFlushNamespace
+		multiErr           xerrors.MultiError
+	)
+	for _, targetShard := range n.OwnedShards() {
+		progress, err := n.tileAggregationProgressFor(targetShard.ID(), sourceNs.ID())
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		sourceShard, _, err := sourceNs.ReadableShardAt(targetShard.ID())
+		if err != nil {
+			multiErr = multiErr.Add(fmt.Errorf(
+				"no matching shard in source namespace %s: %v", sourceNs.ID(), err))
+			continue
+		}
+
+		from := progress.AggregatedUntil()
+		if from.Before(earliest) {
+			from = earliest
+		}
+
+		// Only aggregate up to the first source block that is yet to be
+		// flushed so that progress never skips over a block.
+		until := from
+		for !until.After(latest) {
+			flushState, err := sourceShard.FlushState(until)
+			if err != nil || flushState.WarmStatus != fileOpSuccess {
+				break
+			}
+			until = until.Add(sourceBlockSize)
+		}
+
+		// Target blocks whose source blocks had cold writes flushed since
+		// they were aggregated are aggregated again, along with the target
+		// blocks of newly flushed source blocks.
+		targetBlockStarts := make(map[xtime.UnixNano]struct{})
+		for blockStart := earliest; blockStart.Before(from); blockStart = blockStart.Add(sourceBlockSize) {
+			flushState, err := sourceShard.FlushState(blockStart)
+			if err != nil {
+				continue
+			}
+			if flushState.ColdVersionRetrievable > progress.ColdVersion(blockStart) {
+				targetBlockStart := blockStart.Truncate(targetBlockSize)
+				targetBlockStarts[xtime.ToUnixNano(targetBlockStart)] = struct{}{}
+			}
+		}
+		for targetBlockStart := from.Truncate(targetBlockSize); targetBlockStart.Before(until); targetBlockStart = targetBlockStart.Add(targetBlockSize) {
+			targetBlockStarts[xtime.ToUnixNano(targetBlockStart)] = struct{}{}
+		}
+		if len(targetBlockStarts) == 0 {
+			continue
+		}
+
+		aggregateUntil := from
+		if until.After(aggregateUntil) {
+			aggregateUntil = until
+		}
+
+		// NB: a target block is always aggregated from its start so that the
+		// new volume includes the source blocks aggregated previously.
+		for _, targetBlockStart := range sortedBlockStarts(targetBlockStarts) {
+			end := targetBlockStart.Add(targetBlockSize)
+			if end.After(aggregateUntil) {
+				end = aggregateUntil
+			}
+
+			// Record the cold versions before aggregating so that cold
+			// writes flushed during the aggregation are aggregated again.
+			coldVersions := make(map[xtime.UnixNano]int)
+			for blockStart := targetBlockStart; blockStart.Before(end); blockStart = blockStart.Add(sourceBlockSize) {
+				if flushState, err := sourceShard.FlushState(blockStart); err == nil {
+					coldVersions[xtime.ToUnixNano(blockStart)] = flushState.ColdVersionRetrievable
+				}
+			}
+
+			count, err := n.aggregateFlushedShardTiles(ctx, sourceNs, sourceShard,
+				targetShard, &onColdFlushNs, targetBlockStart, end, attrs)
+			if err != nil {
+				multiErr = multiErr.Add(err)
+				break
+			}
+			processedTileCount += count
+
+			if err := progress.SetAggregated(end, coldVersions, earliest); err != nil {
+				multiErr = multiErr.Add(err)
+				break
+			}
+		}
+	}
+
+	if onColdFlushNs != nil {
+		if err := onColdFlushNs.Done(); err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+
+	return processedTileCount, multiErr.FinalError()
+}
+
+func sortedBlockStarts(blockStarts map[xtime.UnixNano]struct{}) []time.Time {
+	sorted := make([]time.Time, 0, len(blockStarts))
+	for blockStart := range blockStarts {
+		sorted = append(sorted, blockStart.ToTime())
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Before(sorted[j])
+	})
+	return sorted
+}
+
+func (n *dbNamespace) aggregateFlushedShardTiles(
+	ctx context.Context,
+	sourceNs databaseNamespace,
+	sourceShard, targetShard databaseShard,
+	onColdFlushNs *OnColdFlushNamespace,
+	start, end time.Time,
+	attrs namespace.AggregatedAttributes,
+) (int64, error) {
+	opts, err := NewAggregateTilesOptions(start, end, attrs.Resolution, n.id,
+		n.opts.InstrumentOptions())
+	if err != nil {
+		return 0, err
+	}
+	opts.DownsampleType = attrs.TileAggregation.DownsampleType
+
+	blockReaders, sourceBlockStarts, err := newSourceBlockReaders(sourceNs, start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	if *onColdFlushNs == nil {
+		if *onColdFlushNs, err = n.opts.OnColdFlush().ColdFlushNamespace(n); err != nil {
+			return 0, err
+		}
+	}
+
+	callStart := n.nowFn()
+	processedTileCount, err := n.aggregateShardTiles(ctx, sourceNs, sourceShard,
+		targetShard, blockReaders, sourceBlockStarts, *onColdFlushNs, opts)
+	n.metrics.aggregateTiles.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return processedTileCount, err
+}
+
+// tileAggregationManager aggregates the flushed blocks of source namespaces
+// into the namespaces that declare tile aggregation in their aggregation
+// options.
+type tileAggregationManager struct {
+	database database
+	opts     Options
+	log      *zap.Logger
+	metrics  tileAggregationManagerMetrics
+}
+
+type tileAggregationManagerMetrics struct {
+	processedTiles tally.Counter
+	success        tally.Counter
+	errors         tally.Counter
+}
+
+func newTileAggregationManagerMetrics(scope tally.Scope) tileAggregationManagerMetrics {
+	return tileAggregationManagerMetrics{
+		processedTiles: scope.Counter("processed-tiles"),
+		success:        scope.Counter("success"),
+		errors:         scope.Counter("errors"),
+	}
+}
+
+func newTileAggregationManager(
+	database database,
+	opts Options,
+) *tileAggregationManager {
+	instrumentOpts := opts.InstrumentOptions()
+	scope := instrumentOpts.MetricsScope().SubScope("tile-aggregation")
+	return &tileAggregationManager{
+		database: database,
+		opts:     opts,
+		log:      instrumentOpts.Logger(),
+		metrics:  newTileAggregationManagerMetrics(scope),
+	}
+}
+
+// Run aggregates tiles into each owned namespace with tile aggregation
+// enabled from its source namespace.
+func (m *tileAggregationManager) Run(t time.Time) error {
+	namespaces, err := m.database.OwnedNamespaces()
+	if err != nil {
+		return err
+	}
+
+	namespacesByID := make(map[string]databaseNamespace, len(namespaces))
+	for _, ns := range namespaces {
+		namespacesByID[ns.ID().String()] = ns
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, ns := range namespaces {
+		attrs, ok := namespace.TileAggregation(ns.Options().AggregationOptions())
+		if !ok {
+			continue
+		}
+
+		sourceNs, ok := namespacesByID[attrs.TileAggregation.SourceNamespace]
+		if !ok {
+			m.metrics.errors.Inc(1)
+			multiErr = multiErr.Add(fmt.Errorf(
+				"tile aggregation source namespace %s of namespace %s is not owned",
+				attrs.TileAggregation.SourceNamespace, ns.ID()))
+			continue
+		}
+
+		ctx := m.opts.ContextPool().Get()
+		processedTileCount, err := ns.AggregateFlushedTiles(ctx, sourceNs, t)
+		ctx.BlockingClose()
+
+		m.metrics.processedTiles.Inc(processedTileCount)
+		if err != nil {
+			m.metrics.errors.Inc(1)
+			multiErr = multiErr.Add(fmt.Errorf(
+				"tile aggregation into namespace %s failed: %v", ns.ID(), err))
+			continue
+		}
+		m.metrics.success.Inc(1)
+		if processedTileCount > 0 {
+			m.log.Info("aggregated flushed tiles",
+				zap.String("sourceNs", sourceNs.ID().String()),
+				zap.String("targetNs", ns.ID().String()),
+				zap.Int64("processedTiles", processedTileCount))
+		}
+	}
+
+	return multiErr.FinalError()
+}
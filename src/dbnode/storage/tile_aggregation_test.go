@@ -0,0 +1,255 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardTileAggregationProgress(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tile_aggregation")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		filePath = shardTileAggregationFilePath(dir, ident.StringID("target"), 0)
+		until    = time.Now().Truncate(time.Hour)
+		earliest = until.Add(-2 * time.Hour)
+	)
+
+	progress := newShardTileAggregationProgress(filePath, "source", fs.NewOptions())
+	require.NoError(t, progress.Load())
+	require.True(t, progress.AggregatedUntil().IsZero())
+	require.NoError(t, progress.SetAggregated(until, map[xtime.UnixNano]int{
+		xtime.ToUnixNano(earliest.Add(-time.Hour)): 1,
+		xtime.ToUnixNano(earliest):                 2,
+	}, earliest))
+
+	// Aggregating an earlier block again does not move progress back.
+	require.NoError(t, progress.SetAggregated(earliest.Add(time.Hour),
+		map[xtime.UnixNano]int{xtime.ToUnixNano(earliest): 3}, earliest))
+
+	// Progress is restored for the same source namespace, without the cold
+	// versions of blocks before the earliest block.
+	loaded := newShardTileAggregationProgress(filePath, "source", fs.NewOptions())
+	require.NoError(t, loaded.Load())
+	require.True(t, until.Equal(loaded.AggregatedUntil()))
+	assert.Equal(t, 0, loaded.ColdVersion(earliest.Add(-time.Hour)))
+	assert.Equal(t, 3, loaded.ColdVersion(earliest))
+
+	// Progress is discarded for a different source namespace.
+	loaded = newShardTileAggregationProgress(filePath, "other", fs.NewOptions())
+	require.NoError(t, loaded.Load())
+	require.True(t, loaded.AggregatedUntil().IsZero())
+}
+
+func TestNamespaceAggregateFlushedTiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	dir, err := ioutil.TempDir("", "tile_aggregation")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		sourceNsID      = ident.StringID("source")
+		targetNsID      = ident.StringID("target")
+		sourceBlockSize = time.Hour
+		targetBlockSize = 2 * time.Hour
+		start           = time.Now().Truncate(targetBlockSize)
+		now             = start.Add(4 * time.Hour)
+		flushedUntil    = start.Add(2 * time.Hour)
+	)
+
+	attrs, err := namespace.NewAggregatedAttributes(time.Minute, namespace.NewDownsampleOptions(false))
+	require.NoError(t, err)
+	attrs.TileAggregation = namespace.NewTileAggregationOptions(sourceNsID.String(),
+		namespace.MaxDownsampleType)
+
+	sourceNs, sourceCloser := newTestNamespaceWithIDOpts(t, sourceNsID, namespace.NewOptions().
+		SetRetentionOptions(namespace.NewOptions().RetentionOptions().
+			SetRetentionPeriod(4*time.Hour).SetBlockSize(sourceBlockSize)))
+	defer sourceCloser()
+	sourceNs.bootstrapState = Bootstrapped
+
+	targetNs, targetCloser := newTestNamespaceWithIDOpts(t, targetNsID, namespace.NewOptions().
+		SetColdWritesEnabled(true).
+		SetRetentionOptions(namespace.NewOptions().RetentionOptions().
+			SetRetentionPeriod(4*time.Hour).SetBlockSize(targetBlockSize)).
+		SetAggregationOptions(namespace.NewAggregationOptions().
+			SetAggregations([]namespace.Aggregation{namespace.NewAggregatedAggregation(attrs)})))
+	defer targetCloser()
+	targetNs.bootstrapState = Bootstrapped
+	fsOpts := targetNs.opts.CommitLogOptions().FilesystemOptions().SetFilePathPrefix(dir)
+	targetNs.opts = targetNs.opts.SetCommitLogOptions(
+		targetNs.opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+
+	// Scheduled aggregation fails without a tile aggregator configured.
+	_, err = targetNs.AggregateFlushedTiles(ctx, sourceNs, now)
+	require.Equal(t, errTileAggregatorNotConfigured, err)
+	targetNs.opts = targetNs.opts.SetTileAggregator(NewMockTileAggregator(ctrl))
+
+	mockOnColdFlushNs := NewMockOnColdFlushNamespace(ctrl)
+	mockOnColdFlushNs.EXPECT().Done().Return(nil).Times(3)
+	mockOnColdFlush := NewMockOnColdFlush(ctrl)
+	mockOnColdFlush.EXPECT().ColdFlushNamespace(gomock.Any()).Return(mockOnColdFlushNs, nil).Times(3)
+	targetNs.opts = targetNs.opts.SetOnColdFlush(mockOnColdFlush)
+
+	// Blocks of source shard 0 are flushed up to flushedUntil, no blocks of
+	// source shard 1 are flushed.
+	sourceShard0 := NewMockdatabaseShard(ctrl)
+	sourceShard1 := NewMockdatabaseShard(ctrl)
+	sourceNs.shards[0] = sourceShard0
+	sourceNs.shards[1] = sourceShard1
+	for _, sourceShard := range []*MockdatabaseShard{sourceShard0, sourceShard1} {
+		sourceShard.EXPECT().ID().Return(uint32(0)).AnyTimes()
+		sourceShard.EXPECT().IsBootstrapped().Return(true).AnyTimes()
+		sourceShard.EXPECT().LatestVolume(gomock.Any()).Return(0, nil).AnyTimes()
+	}
+	coldVersions := make(map[xtime.UnixNano]int)
+	sourceShard0.EXPECT().FlushState(gomock.Any()).DoAndReturn(
+		func(blockStart time.Time) (fileOpState, error) {
+			if blockStart.Before(flushedUntil) {
+				return fileOpState{
+					WarmStatus:             fileOpSuccess,
+					ColdVersionRetrievable: coldVersions[xtime.ToUnixNano(blockStart)],
+				}, nil
+			}
+			return fileOpState{WarmStatus: fileOpNotStarted}, nil
+		}).AnyTimes()
+	sourceShard1.EXPECT().FlushState(gomock.Any()).
+		Return(fileOpState{WarmStatus: fileOpNotStarted}, nil).AnyTimes()
+
+	targetShard0 := NewMockdatabaseShard(ctrl)
+	targetShard1 := NewMockdatabaseShard(ctrl)
+	targetNs.shards[0] = targetShard0
+	targetNs.shards[1] = targetShard1
+	targetShard0.EXPECT().ID().Return(uint32(0)).AnyTimes()
+	targetShard1.EXPECT().ID().Return(uint32(1)).AnyTimes()
+
+	var calls []AggregateTilesOptions
+	targetShard0.EXPECT().
+		AggregateTiles(ctx, sourceNs, targetNs, uint32(0), gomock.Len(2), gomock.Any(),
+			[]shardBlockVolume{{start, 0}, {start.Add(sourceBlockSize), 0}},
+			mockOnColdFlushNs, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_, _ Namespace,
+			_ uint32,
+			_ []fs.DataFileSetReader,
+			_ fs.StreamingWriter,
+			_ []shardBlockVolume,
+			_ persist.OnFlushSeries,
+			opts AggregateTilesOptions,
+		) (int64, error) {
+			calls = append(calls, opts)
+			return 3, nil
+		})
+
+	processedTileCount, err := targetNs.AggregateFlushedTiles(ctx, sourceNs, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), processedTileCount)
+	require.Len(t, calls, 1)
+	assert.True(t, start.Equal(calls[0].Start))
+	assert.True(t, flushedUntil.Equal(calls[0].End))
+	assert.Equal(t, time.Minute, calls[0].Step)
+	assert.Equal(t, namespace.MaxDownsampleType, calls[0].DownsampleType)
+
+	// Once the next source block is flushed only it is aggregated, into the
+	// next target block, resuming from the persisted progress.
+	flushedUntil = start.Add(3 * time.Hour)
+	targetNs.tileAggregationProgress = nil
+	targetShard0.EXPECT().
+		AggregateTiles(ctx, sourceNs, targetNs, uint32(0), gomock.Len(1), gomock.Any(),
+			[]shardBlockVolume{{start.Add(2 * time.Hour), 0}},
+			mockOnColdFlushNs, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_, _ Namespace,
+			_ uint32,
+			_ []fs.DataFileSetReader,
+			_ fs.StreamingWriter,
+			_ []shardBlockVolume,
+			_ persist.OnFlushSeries,
+			opts AggregateTilesOptions,
+		) (int64, error) {
+			calls = append(calls, opts)
+			return 1, nil
+		})
+
+	processedTileCount, err = targetNs.AggregateFlushedTiles(ctx, sourceNs, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), processedTileCount)
+	require.Len(t, calls, 2)
+	assert.True(t, start.Add(2*time.Hour).Equal(calls[1].Start))
+	assert.True(t, flushedUntil.Equal(calls[1].End))
+
+	// Once cold writes to an aggregated source block are flushed, its whole
+	// target block is aggregated again.
+	coldVersions[xtime.ToUnixNano(start.Add(sourceBlockSize))] = 1
+	targetShard0.EXPECT().
+		AggregateTiles(ctx, sourceNs, targetNs, uint32(0), gomock.Len(2), gomock.Any(),
+			[]shardBlockVolume{{start, 0}, {start.Add(sourceBlockSize), 0}},
+			mockOnColdFlushNs, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_, _ Namespace,
+			_ uint32,
+			_ []fs.DataFileSetReader,
+			_ fs.StreamingWriter,
+			_ []shardBlockVolume,
+			_ persist.OnFlushSeries,
+			opts AggregateTilesOptions,
+		) (int64, error) {
+			calls = append(calls, opts)
+			return 2, nil
+		})
+
+	processedTileCount, err = targetNs.AggregateFlushedTiles(ctx, sourceNs, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), processedTileCount)
+	require.Len(t, calls, 3)
+	assert.True(t, start.Equal(calls[2].Start))
+	assert.True(t, start.Add(targetBlockSize).Equal(calls[2].End))
+
+	// The cold version is recorded so the block is not aggregated again.
+	processedTileCount, err = targetNs.AggregateFlushedTiles(ctx, sourceNs, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), processedTileCount)
+	require.Len(t, calls, 3)
+}
@@ -0,0 +1,677 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	tombstonesFileName = "tombstones.log"
+	// tombstonesCompactMinLogEntries is the min number of entries the
+	// tombstones log grows to before it is compacted.
+	tombstonesCompactMinLogEntries = 1024
+)
+
+// seriesTombstones is a set of deleted time ranges keyed by series ID.
+type seriesTombstones map[string]xtime.Ranges
+
+// shardTombstones tracks the time ranges of series that have been deleted
+// from a shard but not yet physically removed from its filesets.
+//
+// Every change to the tombstones is appended to a log in the shard data
+// directory so that deletes survive restarts without rewriting all the
+// tombstones on each delete, the log is compacted once it has grown larger
+// than the tombstones it describes. Changes are only applied in memory once
+// they have been synced to the log. While a tombstone exists data in its
+// range is filtered out of reads and flushes, once a cold flush has
+// rewritten a block without the deleted data the tombstones for that block
+// are retired.
+type shardTombstones struct {
+	sync.RWMutex
+
+	filePath      string
+	writer        shardStateFileWriter
+	series        seriesTombstones
+	numLogEntries int
+}
+
+type tombstonesLogEntryType string
+
+const (
+	tombstonesLogEntryAdd          tombstonesLogEntryType = "add"
+	tombstonesLogEntryRemove       tombstonesLogEntryType = "remove"
+	tombstonesLogEntryRemoveBefore tombstonesLogEntryType = "removeBefore"
+)
+
+// tombstonesLogEntry is a single change appended to the tombstones log.
+type tombstonesLogEntry struct {
+	Type   tombstonesLogEntryType `json:"type"`
+	Series []tombstonesLogSeries  `json:"series,omitempty"`
+	Before int64                  `json:"before,omitempty"`
+}
+
+type tombstonesLogSeries struct {
+	ID     []byte               `json:"id"`
+	Ranges []tombstonesLogRange `json:"ranges"`
+}
+
+type tombstonesLogRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+func newShardTombstones(filePath string, opts fs.Options) *shardTombstones {
+	return &shardTombstones{
+		filePath: filePath,
+		writer:   newShardStateFileWriter(opts),
+		series:   make(seriesTombstones),
+	}
+}
+
+func shardTombstonesFilePath(
+	filePathPrefix string,
+	namespace ident.ID,
+	shard uint32,
+) string {
+	return path.Join(fs.ShardDataDirPath(filePathPrefix, namespace, shard),
+		tombstonesFileName)
+}
+
+// Load replays any previously persisted tombstones.
+func (t *shardTombstones) Load() error {
+	f, err := os.Open(t.filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	t.Lock()
+	defer t.Unlock()
+	t.series = make(seriesTombstones)
+	t.numLogEntries = 0
+
+	var (
+		decoder = json.NewDecoder(bufio.NewReader(f))
+		torn    bool
+	)
+	for {
+		var entry tombstonesLogEntry
+		err := decoder.Decode(&entry)
+		if err == io.EOF {
+			break
+		}
+		if err == io.ErrUnexpectedEOF {
+			// The last entry was only partially written before a crash, the
+			// delete it belonged to was never acknowledged so it is dropped.
+			torn = true
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("unable to decode tombstones log %s: %v",
+				t.filePath, err)
+		}
+		t.series.apply(entry)
+		t.numLogEntries++
+	}
+
+	if !torn {
+		return nil
+	}
+	// Rewrite the log so that new entries are not appended after the
+	// partially written one.
+	return t.compactWithLock(t.series)
+}
+
+// Add tombstones the range [start, end) for each of the series.
+func (t *shardTombstones) Add(ids []ident.ID, start, end time.Time) error {
+	tombstone := xtime.Range{Start: start, End: end}
+	if tombstone.IsEmpty() || len(ids) == 0 {
+		return nil
+	}
+
+	entry := tombstonesLogEntry{
+		Type:   tombstonesLogEntryAdd,
+		Series: make([]tombstonesLogSeries, 0, len(ids)),
+	}
+	for _, id := range ids {
+		entry.Series = append(entry.Series, tombstonesLogSeries{
+			ID:     id.Bytes(),
+			Ranges: []tombstonesLogRange{newTombstonesLogRange(tombstone)},
+		})
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	return t.commitWithLock(entry)
+}
+
+// IsEmpty returns whether there are no tombstones.
+func (t *shardTombstones) IsEmpty() bool {
+	t.RLock()
+	defer t.RUnlock()
+	return len(t.series) == 0
+}
+
+// SeriesRanges returns a copy of the tombstoned ranges for a series.
+func (t *shardTombstones) SeriesRanges(id ident.ID) (xtime.Ranges, bool) {
+	t.RLock()
+	defer t.RUnlock()
+	if len(t.series) == 0 {
+		return nil, false
+	}
+	ranges, ok := t.series[id.String()]
+	if !ok {
+		return nil, false
+	}
+	return ranges.Clone(), true
+}
+
+// BlockStarts returns the block starts that contain tombstoned data.
+func (t *shardTombstones) BlockStarts(
+	blockSize time.Duration,
+) map[xtime.UnixNano]struct{} {
+	t.RLock()
+	defer t.RUnlock()
+	blockStarts := make(map[xtime.UnixNano]struct{})
+	for _, ranges := range t.series {
+		for it := ranges.Iter(); it.Next(); {
+			r := it.Value()
+			for bs := r.Start.Truncate(blockSize); bs.Before(r.End); bs = bs.Add(blockSize) {
+				blockStarts[xtime.ToUnixNano(bs)] = struct{}{}
+			}
+		}
+	}
+	return blockStarts
+}
+
+// Snapshot returns the tombstones that fall within a block.
+func (t *shardTombstones) Snapshot(
+	blockStart time.Time,
+	blockSize time.Duration,
+) seriesTombstones {
+	blockRange := xtime.Range{Start: blockStart, End: blockStart.Add(blockSize)}
+
+	t.RLock()
+	defer t.RUnlock()
+	var snapshot seriesTombstones
+	for id, ranges := range t.series {
+		if !ranges.Overlaps(blockRange) {
+			continue
+		}
+		inBlock := xtime.NewRanges()
+		for it := ranges.Iter(); it.Next(); {
+			if r, ok := it.Value().Intersect(blockRange); ok {
+				inBlock.AddRange(r)
+			}
+		}
+		if snapshot == nil {
+			snapshot = make(seriesTombstones)
+		}
+		snapshot[id] = inBlock
+	}
+	return snapshot
+}
+
+// Remove retires tombstones previously returned by Snapshot, tombstones
+// added since the snapshot was taken are retained.
+func (t *shardTombstones) Remove(snapshot seriesTombstones) error {
+	if len(snapshot) == 0 {
+		return nil
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	return t.commitWithLock(tombstonesLogEntry{
+		Type:   tombstonesLogEntryRemove,
+		Series: newTombstonesLogSeries(snapshot),
+	})
+}
+
+// RemoveBefore retires all tombstones for data before the given time.
+func (t *shardTombstones) RemoveBefore(earliestToRetain time.Time) error {
+	entry := tombstonesLogEntry{
+		Type:   tombstonesLogEntryRemoveBefore,
+		Before: earliestToRetain.UnixNano(),
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	if !t.series.overlaps(xtime.Range{
+		Start: time.Unix(0, 0),
+		End:   earliestToRetain,
+	}) {
+		return nil
+	}
+	return t.commitWithLock(entry)
+}
+
+// commitWithLock appends an entry to the tombstones log and, once it has been
+// synced, applies it to the in memory tombstones so that tombstones are never
+// visible before they are durable. The log is compacted instead once it has
+// grown larger than the tombstones it describes so that the cost of each
+// delete does not depend on the number of tombstones.
+func (t *shardTombstones) commitWithLock(entry tombstonesLogEntry) error {
+	if len(t.series) == 0 ||
+		(t.numLogEntries >= tombstonesCompactMinLogEntries &&
+			t.numLogEntries >= len(t.series)) {
+		series := t.series.clone()
+		series.apply(entry)
+		if err := t.compactWithLock(series); err != nil {
+			return err
+		}
+		t.series = series
+		return nil
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := t.writer.Append(t.filePath, append(data, '\n')); err != nil {
+		return err
+	}
+	t.numLogEntries++
+
+	if !t.series.apply(entry) || len(t.series) > 0 {
+		return nil
+	}
+	// Every tombstone has been retired so the log is no longer needed.
+	return t.compactWithLock(t.series)
+}
+
+// compactWithLock rewrites the tombstones log as a single entry holding all
+// of the given tombstones.
+func (t *shardTombstones) compactWithLock(series seriesTombstones) error {
+	if len(series) == 0 {
+		if err := t.writer.Remove(t.filePath); err != nil {
+			return err
+		}
+		t.numLogEntries = 0
+		return nil
+	}
+
+	data, err := json.Marshal(tombstonesLogEntry{
+		Type:   tombstonesLogEntryAdd,
+		Series: newTombstonesLogSeries(series),
+	})
+	if err != nil {
+		return err
+	}
+	if err := t.writer.Write(t.filePath, append(data, '\n')); err != nil {
+		return err
+	}
+	t.numLogEntries = 1
+	return nil
+}
+
+// clone returns a deep copy of the tombstones.
+func (s seriesTombstones) clone() seriesTombstones {
+	cloned := make(seriesTombstones, len(s))
+	for id, ranges := range s {
+		cloned[id] = ranges.Clone()
+	}
+	return cloned
+}
+
+// overlaps returns whether any tombstone overlaps the given range.
+func (s seriesTombstones) overlaps(r xtime.Range) bool {
+	for _, ranges := range s {
+		if ranges.Overlaps(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply applies a log entry to the tombstones and returns whether they
+// changed.
+func (s seriesTombstones) apply(entry tombstonesLogEntry) bool {
+	switch entry.Type {
+	case tombstonesLogEntryAdd:
+		for _, e := range entry.Series {
+			ranges, ok := s[string(e.ID)]
+			if !ok {
+				ranges = xtime.NewRanges()
+				s[string(e.ID)] = ranges
+			}
+			for _, r := range e.Ranges {
+				ranges.AddRange(r.Range())
+			}
+		}
+		return len(entry.Series) > 0
+
+	case tombstonesLogEntryRemove:
+		var changed bool
+		for _, e := range entry.Series {
+			ranges, ok := s[string(e.ID)]
+			if !ok {
+				continue
+			}
+			changed = true
+			for _, r := range e.Ranges {
+				ranges.RemoveRange(r.Range())
+			}
+			if ranges.IsEmpty() {
+				delete(s, string(e.ID))
+			}
+		}
+		return changed
+
+	case tombstonesLogEntryRemoveBefore:
+		expired := xtime.Range{
+			Start: time.Unix(0, 0),
+			End:   time.Unix(0, entry.Before),
+		}
+		var changed bool
+		for id, ranges := range s {
+			if !ranges.Overlaps(expired) {
+				continue
+			}
+			changed = true
+			ranges.RemoveRange(expired)
+			if ranges.IsEmpty() {
+				delete(s, id)
+			}
+		}
+		return changed
+	}
+	return false
+}
+
+func newTombstonesLogSeries(tombstones seriesTombstones) []tombstonesLogSeries {
+	series := make([]tombstonesLogSeries, 0, len(tombstones))
+	for id, ranges := range tombstones {
+		s := tombstonesLogSeries{ID: []byte(id)}
+		for it := ranges.Iter(); it.Next(); {
+			s.Ranges = append(s.Ranges, newTombstonesLogRange(it.Value()))
+		}
+		series = append(series, s)
+	}
+	return series
+}
+
+func newTombstonesLogRange(r xtime.Range) tombstonesLogRange {
+	return tombstonesLogRange{
+		Start: r.Start.UnixNano(),
+		End:   r.End.UnixNano(),
+	}
+}
+
+func (r tombstonesLogRange) Range() xtime.Range {
+	return xtime.Range{
+		Start: time.Unix(0, r.Start),
+		End:   time.Unix(0, r.End),
+	}
+}
+
+// tombstonesFilter removes tombstoned datapoints from encoded data.
+type tombstonesFilter struct {
+	readerIteratorPool encoding.ReaderIteratorPool
+	encoderPool        encoding.EncoderPool
+}
+
+func newTombstonesFilter(opts Options) tombstonesFilter {
+	return tombstonesFilter{
+		readerIteratorPool: opts.ReaderIteratorPool(),
+		encoderPool:        opts.EncoderPool(),
+	}
+}
+
+// FilterSegment returns a segment without the datapoints covered by the
+// tombstones and whether it has any datapoints remaining.
+func (f tombstonesFilter) FilterSegment(
+	segment ts.Segment,
+	blockStart time.Time,
+	tombstones xtime.Ranges,
+	schema namespace.SchemaDescr,
+) (ts.Segment, bool, error) {
+	iter := f.readerIteratorPool.Get()
+	defer iter.Close()
+	iter.Reset(xio.NewSegmentReader(segment), schema)
+
+	var (
+		encoder = f.encoderPool.Get()
+		hasData bool
+	)
+	encoder.Reset(blockStart, segment.Len(), schema)
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if tombstonesContain(tombstones, dp.Timestamp) {
+			continue
+		}
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return ts.Segment{}, false, err
+		}
+		hasData = true
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return ts.Segment{}, false, err
+	}
+
+	if !hasData {
+		encoder.Close()
+		return ts.Segment{}, false, nil
+	}
+	return encoder.Discard(), true, nil
+}
+
+// FilterBlockReaders returns the block readers with tombstoned datapoints
+// removed, replacement readers are finalized when the context is closed.
+func (f tombstonesFilter) FilterBlockReaders(
+	ctx context.Context,
+	readers []xio.BlockReader,
+	tombstones xtime.Ranges,
+	schema namespace.SchemaDescr,
+) ([]xio.BlockReader, error) {
+	filtered := make([]xio.BlockReader, 0, len(readers))
+	for _, reader := range readers {
+		blockRange := xtime.Range{
+			Start: reader.Start,
+			End:   reader.Start.Add(reader.BlockSize),
+		}
+		if !tombstones.Overlaps(blockRange) {
+			filtered = append(filtered, reader)
+			continue
+		}
+
+		segment, err := reader.Segment()
+		if err != nil {
+			return nil, err
+		}
+		segment, hasData, err := f.FilterSegment(segment, reader.Start,
+			tombstones, schema)
+		if err != nil {
+			return nil, err
+		}
+		if !hasData {
+			continue
+		}
+
+		segmentReader := xio.NewSegmentReader(segment)
+		ctx.RegisterFinalizer(segmentReader)
+		filtered = append(filtered, xio.BlockReader{
+			SegmentReader: segmentReader,
+			Start:         reader.Start,
+			BlockSize:     reader.BlockSize,
+		})
+	}
+	return filtered, nil
+}
+
+func tombstonesContain(tombstones xtime.Ranges, t time.Time) bool {
+	for it := tombstones.Iter(); it.Next(); {
+		r := it.Value()
+		if !t.Before(r.Start) && t.Before(r.End) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *dbShard) filterTombstonedEncoded(
+	ctx context.Context,
+	id ident.ID,
+	encoded [][]xio.BlockReader,
+	nsCtx namespace.Context,
+) ([][]xio.BlockReader, error) {
+	tombstones, ok := s.tombstones.SeriesRanges(id)
+	if !ok {
+		return encoded, nil
+	}
+
+	filtered := make([][]xio.BlockReader, 0, len(encoded))
+	for _, readers := range encoded {
+		readers, err := s.tombstonesFilter.FilterBlockReaders(ctx, readers,
+			tombstones, nsCtx.Schema)
+		if err != nil {
+			return nil, err
+		}
+		if len(readers) == 0 {
+			continue
+		}
+		filtered = append(filtered, readers)
+	}
+	return filtered, nil
+}
+
+func (s *dbShard) filterTombstonedFetchBlocks(
+	ctx context.Context,
+	id ident.ID,
+	results []block.FetchBlockResult,
+	nsCtx namespace.Context,
+) ([]block.FetchBlockResult, error) {
+	tombstones, ok := s.tombstones.SeriesRanges(id)
+	if !ok {
+		return results, nil
+	}
+
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		readers, err := s.tombstonesFilter.FilterBlockReaders(ctx,
+			results[i].Blocks, tombstones, nsCtx.Schema)
+		if err != nil {
+			return nil, err
+		}
+		results[i].Blocks = readers
+	}
+	return results, nil
+}
+
+// tombstonesFlushPreparer filters tombstoned data out of series as they are
+// persisted for a single block.
+type tombstonesFlushPreparer struct {
+	persist.FlushPreparer
+
+	tombstones seriesTombstones
+	filter     tombstonesFilter
+	nsCtx      namespace.Context
+}
+
+func (p tombstonesFlushPreparer) PrepareData(
+	opts persist.DataPrepareOptions,
+) (persist.PreparedDataPersist, error) {
+	prepared, err := p.FlushPreparer.PrepareData(opts)
+	if err != nil || len(p.tombstones) == 0 {
+		return prepared, err
+	}
+
+	persistFn := prepared.Persist
+	prepared.Persist = func(
+		metadata persist.Metadata,
+		segment ts.Segment,
+		checksum uint32,
+	) error {
+		tombstones, ok := p.tombstones[string(metadata.BytesID())]
+		if !ok {
+			return persistFn(metadata, segment, checksum)
+		}
+
+		filtered, hasData, err := p.filter.FilterSegment(segment,
+			opts.BlockStart, tombstones, p.nsCtx.Schema)
+		if err != nil {
+			return err
+		}
+		if !hasData {
+			// Nothing left to write for this series.
+			metadata.Finalize()
+			return nil
+		}
+		defer filtered.Finalize()
+		return persistFn(metadata, filtered, filtered.CalculateChecksum())
+	}
+	return prepared, nil
+}
+
+func (s *dbShard) tombstonesFlushPreparer(
+	flushPreparer persist.FlushPreparer,
+	blockStart time.Time,
+	nsCtx namespace.Context,
+) persist.FlushPreparer {
+	blockSize := s.namespace.Options().RetentionOptions().BlockSize()
+	tombstones := s.tombstones.Snapshot(blockStart, blockSize)
+	return s.tombstonesFlushPreparerWithSnapshot(flushPreparer, tombstones, nsCtx)
+}
+
+func (s *dbShard) tombstonesFlushPreparerWithSnapshot(
+	flushPreparer persist.FlushPreparer,
+	tombstones seriesTombstones,
+	nsCtx namespace.Context,
+) persist.FlushPreparer {
+	if len(tombstones) == 0 {
+		return flushPreparer
+	}
+	return tombstonesFlushPreparer{
+		FlushPreparer: flushPreparer,
+		tombstones:    tombstones,
+		filter:        s.tombstonesFilter,
+		nsCtx:         nsCtx,
+	}
+}
+
+func (s *dbShard) HasTombstones() bool {
+	return !s.tombstones.IsEmpty()
+}
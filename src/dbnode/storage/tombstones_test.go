@@ -0,0 +1,360 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/storage/series"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestShardTombstones(t *testing.T) (*shardTombstones, func()) {
+	dir, err := ioutil.TempDir("", "tombstones")
+	require.NoError(t, err)
+	filePath := shardTombstonesFilePath(dir, ident.StringID("ns"), 0)
+	return newShardTombstones(filePath, fs.NewOptions()), func() { os.RemoveAll(dir) }
+}
+
+func requireTombstoneRanges(
+	t *testing.T,
+	expected []xtime.Range,
+	actual xtime.Ranges,
+) {
+	var ranges []xtime.Range
+	for it := actual.Iter(); it.Next(); {
+		ranges = append(ranges, it.Value())
+	}
+	require.Equal(t, len(expected), len(ranges))
+	for i := range expected {
+		require.True(t, expected[i].Equal(ranges[i]),
+			"expected %v, actual %v", expected[i], ranges[i])
+	}
+}
+
+func TestShardTombstonesSnapshotAndRemove(t *testing.T) {
+	tombstones, cleanup := newTestShardTombstones(t)
+	defer cleanup()
+
+	var (
+		blockSize = 2 * time.Hour
+		t0        = time.Unix(0, 0).Add(100 * blockSize)
+		t1        = t0.Add(blockSize)
+		start     = t0.Add(time.Hour)
+		end       = t1.Add(time.Hour)
+		foo       = ident.StringID("foo")
+		bar       = ident.StringID("bar")
+	)
+	require.True(t, tombstones.IsEmpty())
+	require.NoError(t, tombstones.Add([]ident.ID{foo, bar}, start, end))
+	require.False(t, tombstones.IsEmpty())
+
+	require.Equal(t, map[xtime.UnixNano]struct{}{
+		xtime.ToUnixNano(t0): {},
+		xtime.ToUnixNano(t1): {},
+	}, tombstones.BlockStarts(blockSize))
+
+	snapshot := tombstones.Snapshot(t0, blockSize)
+	require.Equal(t, 2, len(snapshot))
+	requireTombstoneRanges(t, []xtime.Range{{Start: start, End: t1}},
+		snapshot[foo.String()])
+
+	// Tombstones added after the snapshot must survive removing it.
+	later := xtime.Range{Start: t0, End: t0.Add(time.Minute)}
+	require.NoError(t, tombstones.Add([]ident.ID{foo}, later.Start, later.End))
+	require.NoError(t, tombstones.Remove(snapshot))
+
+	ranges, ok := tombstones.SeriesRanges(foo)
+	require.True(t, ok)
+	requireTombstoneRanges(t, []xtime.Range{later, {Start: t1, End: end}}, ranges)
+	ranges, ok = tombstones.SeriesRanges(bar)
+	require.True(t, ok)
+	requireTombstoneRanges(t, []xtime.Range{{Start: t1, End: end}}, ranges)
+
+	// Ensure tombstones are reloaded from disk.
+	loaded := newShardTombstones(tombstones.filePath, fs.NewOptions())
+	require.NoError(t, loaded.Load())
+	ranges, ok = loaded.SeriesRanges(foo)
+	require.True(t, ok)
+	requireTombstoneRanges(t, []xtime.Range{later, {Start: t1, End: end}}, ranges)
+
+	require.NoError(t, loaded.RemoveBefore(end))
+	require.True(t, loaded.IsEmpty())
+	_, err := os.Stat(loaded.filePath)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestShardTombstonesLoadMissingFile(t *testing.T) {
+	tombstones, cleanup := newTestShardTombstones(t)
+	defer cleanup()
+
+	require.NoError(t, tombstones.Load())
+	require.True(t, tombstones.IsEmpty())
+}
+
+func TestShardTombstonesLoadTornLogEntry(t *testing.T) {
+	tombstones, cleanup := newTestShardTombstones(t)
+	defer cleanup()
+
+	var (
+		start = time.Unix(0, 0).Add(time.Hour)
+		end   = start.Add(time.Hour)
+		foo   = ident.StringID("foo")
+		bar   = ident.StringID("bar")
+	)
+	require.NoError(t, tombstones.Add([]ident.ID{foo}, start, end))
+
+	// Simulate a crash midway through appending an entry.
+	f, err := os.OpenFile(tombstones.filePath, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte(`{"type":"add","series":[{"id":`))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	loaded := newShardTombstones(tombstones.filePath, fs.NewOptions())
+	require.NoError(t, loaded.Load())
+	ranges, ok := loaded.SeriesRanges(foo)
+	require.True(t, ok)
+	requireTombstoneRanges(t, []xtime.Range{{Start: start, End: end}}, ranges)
+
+	// Entries appended after the torn entry must be loaded.
+	require.NoError(t, loaded.Add([]ident.ID{bar}, start, end))
+	reloaded := newShardTombstones(tombstones.filePath, fs.NewOptions())
+	require.NoError(t, reloaded.Load())
+	_, ok = reloaded.SeriesRanges(foo)
+	require.True(t, ok)
+	_, ok = reloaded.SeriesRanges(bar)
+	require.True(t, ok)
+}
+
+func TestShardTombstonesAddNotAppliedIfNotDurable(t *testing.T) {
+	tombstones, cleanup := newTestShardTombstones(t)
+	defer cleanup()
+
+	var (
+		start = time.Unix(0, 0).Add(time.Hour)
+		end   = start.Add(time.Hour)
+		foo   = ident.StringID("foo")
+		bar   = ident.StringID("bar")
+	)
+	require.NoError(t, tombstones.Add([]ident.ID{foo}, start, end))
+
+	// Replace the log with a directory so that appending to it fails.
+	require.NoError(t, os.Remove(tombstones.filePath))
+	require.NoError(t, os.Mkdir(tombstones.filePath, 0755))
+	require.Error(t, tombstones.Add([]ident.ID{bar}, start, end))
+
+	_, ok := tombstones.SeriesRanges(bar)
+	require.False(t, ok)
+	_, ok = tombstones.SeriesRanges(foo)
+	require.True(t, ok)
+}
+
+func TestShardTombstonesCompactLog(t *testing.T) {
+	tombstones, cleanup := newTestShardTombstones(t)
+	defer cleanup()
+
+	var (
+		start = time.Unix(0, 0).Add(time.Hour)
+		foo   = ident.StringID("foo")
+	)
+	for i := 0; i < 2*tombstonesCompactMinLogEntries; i++ {
+		at := start.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, tombstones.Add([]ident.ID{foo}, at, at.Add(time.Second)))
+		require.True(t, tombstones.numLogEntries <= tombstonesCompactMinLogEntries)
+	}
+
+	loaded := newShardTombstones(tombstones.filePath, fs.NewOptions())
+	require.NoError(t, loaded.Load())
+	expected, ok := tombstones.SeriesRanges(foo)
+	require.True(t, ok)
+	ranges, ok := loaded.SeriesRanges(foo)
+	require.True(t, ok)
+	require.Equal(t, expected.Len(), ranges.Len())
+}
+
+func readTombstonesTestValues(
+	t *testing.T,
+	ctx context.Context,
+	shard *dbShard,
+	id string,
+	start, end time.Time,
+) []float64 {
+	encoded, err := shard.ReadEncoded(ctx, ident.StringID(id), start, end,
+		namespace.Context{})
+	require.NoError(t, err)
+
+	iter := shard.opts.MultiReaderIteratorPool().Get()
+	defer iter.Close()
+	iter.ResetSliceOfSlices(
+		xio.NewReaderSliceOfSlicesFromBlockReadersIterator(encoded), nil)
+
+	var values []float64
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		values = append(values, dp.Value)
+	}
+	require.NoError(t, iter.Err())
+	return values
+}
+
+func TestShardDeleteSeriesHidesReads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now().Truncate(time.Second)
+	opts := DefaultTestOptions()
+	fsOpts := opts.CommitLogOptions().FilesystemOptions().
+		SetFilePathPrefix(dir)
+	opts = opts.
+		SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+			return now
+		})).
+		SetCommitLogOptions(opts.CommitLogOptions().
+			SetFilesystemOptions(fsOpts))
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	for _, id := range []string{"foo", "bar"} {
+		for i := 3; i > 0; i-- {
+			_, err := shard.Write(ctx, ident.StringID(id),
+				now.Add(-time.Duration(i)*time.Second), float64(i),
+				xtime.Second, nil, series.WriteOptions{})
+			require.NoError(t, err)
+		}
+	}
+
+	start, end := now.Add(-time.Minute), now.Add(time.Minute)
+	require.Equal(t, []float64{3, 2, 1},
+		readTombstonesTestValues(t, ctx, shard, "foo", start, end))
+
+	// The end of the deleted range is inclusive.
+	deleteAt := now.Add(-2 * time.Second)
+	deleted, err := shard.DeleteSeries([]ident.ID{ident.StringID("foo")},
+		deleteAt, deleteAt)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+	require.True(t, shard.HasTombstones())
+
+	require.Equal(t, []float64{3, 1},
+		readTombstonesTestValues(t, ctx, shard, "foo", start, end))
+	require.Equal(t, []float64{3, 2, 1},
+		readTombstonesTestValues(t, ctx, shard, "bar", start, end))
+
+	// Deleting all of the data leaves nothing to read.
+	_, err = shard.DeleteSeries([]ident.ID{ident.StringID("foo")}, start, end)
+	require.NoError(t, err)
+	require.Empty(t, readTombstonesTestValues(t, ctx, shard, "foo", start, end))
+
+	// Tombstones survive the shard being recreated.
+	reopened := testDatabaseShard(t, opts)
+	defer reopened.Close()
+	require.True(t, reopened.HasTombstones())
+}
+
+func TestTombstonesFlushPreparerFiltersPersistedData(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	opts := DefaultTestOptions()
+	blockStart := time.Now().Truncate(2 * time.Hour)
+
+	encoder := opts.EncoderPool().Get()
+	encoder.Reset(blockStart, 0, nil)
+	for i := 0; i < 3; i++ {
+		dp := ts.Datapoint{
+			Timestamp: blockStart.Add(time.Duration(i) * time.Second),
+			Value:     float64(i),
+		}
+		require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+	}
+	segment := encoder.Discard()
+
+	var persisted map[string][]float64
+	persistFn := func(metadata persist.Metadata, segment ts.Segment, checksum uint32) error {
+		require.Equal(t, segment.CalculateChecksum(), checksum)
+		iter := opts.ReaderIteratorPool().Get()
+		defer iter.Close()
+		iter.Reset(xio.NewSegmentReader(segment), nil)
+		var values []float64
+		for iter.Next() {
+			dp, _, _ := iter.Current()
+			values = append(values, dp.Value)
+		}
+		require.NoError(t, iter.Err())
+		persisted[string(metadata.BytesID())] = values
+		return nil
+	}
+
+	flushPreparer := persist.NewMockFlushPreparer(ctrl)
+	flushPreparer.EXPECT().PrepareData(gomock.Any()).
+		Return(persist.PreparedDataPersist{Persist: persistFn}, nil)
+
+	preparer := tombstonesFlushPreparer{
+		FlushPreparer: flushPreparer,
+		tombstones: seriesTombstones{
+			"foo": xtime.NewRanges(xtime.Range{
+				Start: blockStart.Add(time.Second),
+				End:   blockStart.Add(2 * time.Second),
+			}),
+			"bar": xtime.NewRanges(xtime.Range{
+				Start: blockStart,
+				End:   blockStart.Add(time.Minute),
+			}),
+		},
+		filter: newTombstonesFilter(opts),
+	}
+	prepared, err := preparer.PrepareData(persist.DataPrepareOptions{
+		BlockStart: blockStart,
+	})
+	require.NoError(t, err)
+
+	persisted = make(map[string][]float64)
+	for _, id := range []string{"foo", "bar", "baz"} {
+		metadata := persist.NewMetadataFromIDAndTags(ident.StringID(id),
+			ident.Tags{}, persist.MetadataOptions{})
+		require.NoError(t, prepared.Persist(metadata, segment, segment.CalculateChecksum()))
+	}
+
+	require.Equal(t, map[string][]float64{
+		"foo": {0, 2},
+		"baz": {0, 1, 2},
+	}, persisted)
+}
@@ -30,7 +30,9 @@ import (
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tier"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/sharding"
@@ -42,6 +44,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/storage/series/lookup"
+	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/ts/writes"
 	"github.com/m3db/m3/src/dbnode/x/xio"
@@ -60,6 +63,24 @@ import (
 // PageToken is an opaque paging token.
 type PageToken []byte
 
+// SeriesCardinality is the number of active series in a namespace against
+// its cardinality limits.
+type SeriesCardinality struct {
+	Namespace ident.ID
+	Limits    namespace.CardinalityLimits
+	NumSeries int64
+	// MetricNames are the metric names with the most series, only set
+	// when series are limited per metric name.
+	MetricNames []MetricNameSeriesCardinality
+}
+
+// MetricNameSeriesCardinality is the number of active series in a namespace
+// sharing a metric name.
+type MetricNameSeriesCardinality struct {
+	MetricName []byte
+	NumSeries  int64
+}
+
 // IndexedErrorHandler can handle individual errors based on their index. It
 // is used primarily in cases where we need to handle errors in batches, but
 // want to avoid an intermediary allocation of []error.
@@ -221,6 +242,35 @@ type Database interface {
 		opts block.FetchBlocksMetadataOptions,
 	) (block.FetchBlocksMetadataResults, PageToken, error)
 
+	// FetchBlockHashTree returns the hash tree over the checksums of the
+	// series in a block of a shard.
+	FetchBlockHashTree(
+		ctx context.Context,
+		namespace ident.ID,
+		shard uint32,
+		blockStart time.Time,
+		opts repair.HashTreeOptions,
+	) (*repair.HashTree, error)
+
+	// FetchBlockHashTreeEntries returns the series checksums that make up
+	// the given leaves of the hash tree of a block of a shard.
+	FetchBlockHashTreeEntries(
+		ctx context.Context,
+		namespace ident.ID,
+		shard uint32,
+		blockStart time.Time,
+		opts repair.HashTreeOptions,
+		leaves []int,
+	) ([]repair.HashTreeEntry, error)
+
+	// SeriesCardinality returns the number of active series in a namespace
+	// against its cardinality limits, along with up to maxMetricNames metric
+	// names with the most series.
+	SeriesCardinality(
+		namespace ident.ID,
+		maxMetricNames int,
+	) (SeriesCardinality, error)
+
 	// Bootstrap bootstraps the database.
 	Bootstrap() error
 
@@ -241,6 +291,16 @@ type Database interface {
 	// Truncate truncates data for the given namespace.
 	Truncate(namespace ident.ID) (int64, error)
 
+	// DeleteSeries deletes data within [start, end] for the given series in
+	// the namespace, series in shards not owned by this node are ignored.
+	// Returns the number of series that data was deleted for.
+	DeleteSeries(
+		ctx context.Context,
+		namespace ident.ID,
+		ids []ident.ID,
+		start, end time.Time,
+	) (int64, error)
+
 	// BootstrapState captures and returns a snapshot of the databases'
 	// bootstrap state.
 	BootstrapState() DatabaseBootstrapState
@@ -250,6 +310,11 @@ type Database interface {
 
 	// AggregateTiles does large tile aggregation from source namespace to target namespace.
 	AggregateTiles(ctx context.Context, sourceNsID, targetNsID ident.ID, opts AggregateTilesOptions) (int64, error)
+
+	// Backup waits for a flush and snapshot that started after the backup was
+	// requested and then copies the filesets of the namespaces to the backup
+	// store with file operations disabled.
+	Backup(opts BackupOptions) (backup.Manifest, error)
 }
 
 // database is the internal database interface.
@@ -413,6 +478,30 @@ type databaseNamespace interface {
 		opts block.FetchBlocksMetadataOptions,
 	) (block.FetchBlocksMetadataResults, PageToken, error)
 
+	// FetchBlockHashTree returns the hash tree over the checksums of the
+	// series in a block of a shard.
+	FetchBlockHashTree(
+		ctx context.Context,
+		shardID uint32,
+		blockStart time.Time,
+		opts repair.HashTreeOptions,
+	) (*repair.HashTree, error)
+
+	// FetchBlockHashTreeEntries returns the series checksums that make up
+	// the given leaves of the hash tree of a block of a shard.
+	FetchBlockHashTreeEntries(
+		ctx context.Context,
+		shardID uint32,
+		blockStart time.Time,
+		opts repair.HashTreeOptions,
+		leaves []int,
+	) ([]repair.HashTreeEntry, error)
+
+	// SeriesCardinality returns the number of active series in the namespace
+	// against its cardinality limits, along with up to maxMetricNames metric
+	// names with the most series.
+	SeriesCardinality(maxMetricNames int) SeriesCardinality
+
 	// PrepareBootstrap prepares the namespace for bootstrapping by ensuring
 	// it's shards know which flushed files reside on disk, so that calls
 	// to series.LoadBlock(...) will succeed.
@@ -445,6 +534,10 @@ type databaseNamespace interface {
 	// Truncate truncates the in-memory data for this namespace.
 	Truncate() (int64, error)
 
+	// DeleteSeries deletes data within [start, end] for the given series,
+	// series in shards not owned by this namespace are ignored.
+	DeleteSeries(ids []ident.ID, start, end time.Time) (int64, error)
+
 	// Repair repairs the namespace data for a given time range
 	Repair(repairer databaseShardRepairer, tr xtime.Range) error
 
@@ -476,6 +569,15 @@ type databaseNamespace interface {
 		opts AggregateTilesOptions,
 	) (int64, error)
 
+	// AggregateFlushedTiles aggregates the tiles of source namespace blocks
+	// flushed since the last aggregation into this namespace, as configured by
+	// the tile aggregation options of the namespace.
+	AggregateFlushedTiles(
+		ctx context.Context,
+		sourceNs databaseNamespace,
+		now time.Time,
+	) (int64, error)
+
 	// ReadableShardAt returns a shard of this namespace by shardID.
 	ReadableShardAt(shardID uint32) (databaseShard, namespace.Context, error)
 }
@@ -554,6 +656,15 @@ type databaseShard interface {
 		nsCtx namespace.Context,
 	) ([][]xio.BlockReader, error)
 
+	// DeleteSeries tombstones data within [start, end] for the given series,
+	// the data is hidden from reads immediately and removed from disk by the
+	// next flush of each affected block.
+	DeleteSeries(ids []ident.ID, start, end time.Time) (int64, error)
+
+	// HasTombstones returns whether the shard has deleted data that has not
+	// yet been removed from disk.
+	HasTombstones() bool
+
 	// FetchWideEntry retrieves wide entry for an ID for the
 	// block at time start.
 	FetchWideEntry(
@@ -593,6 +704,24 @@ type databaseShard interface {
 		opts block.FetchBlocksMetadataOptions,
 	) (block.FetchBlocksMetadataResults, PageToken, error)
 
+	// FetchBlockHashTree returns the hash tree over the checksums of the
+	// series in a block, trees of blocks that can no longer change are
+	// cached.
+	FetchBlockHashTree(
+		ctx context.Context,
+		blockStart time.Time,
+		opts repair.HashTreeOptions,
+	) (*repair.HashTree, error)
+
+	// FetchBlockHashTreeEntries returns the series checksums that make up
+	// the given leaves of the hash tree of a block.
+	FetchBlockHashTreeEntries(
+		ctx context.Context,
+		blockStart time.Time,
+		opts repair.HashTreeOptions,
+		leaves []int,
+	) ([]repair.HashTreeEntry, error)
+
 	// PrepareBootstrap prepares the shard for bootstrapping by ensuring
 	// it knows which flushed files reside on disk.
 	PrepareBootstrap(ctx context.Context) error
@@ -994,6 +1123,10 @@ type databaseMediator interface {
 	// EnableFileOps enables file operations.
 	EnableFileOps()
 
+	// WarmFlushAndSnapshot synchronously performs a warm flush and snapshot,
+	// file operations should be disabled so none are performed concurrently.
+	WarmFlushAndSnapshot() error
+
 	// Tick performs a tick.
 	Tick(forceType forceType, startTime time.Time) error
 
@@ -1128,6 +1261,14 @@ type Options interface {
 	// IndexClaimsManager returns the index claims manager.
 	IndexClaimsManager() fs.IndexClaimsManager
 
+	// SetTieringManager sets the manager that moves filesets to a secondary
+	// tier, nil disables tiering.
+	SetTieringManager(value tier.Manager) Options
+
+	// TieringManager returns the manager that moves filesets to a secondary
+	// tier.
+	TieringManager() tier.Manager
+
 	// SetDatabaseBlockRetrieverManager sets the block retriever manager to
 	// use when bootstrapping retrievable blocks instead of blocks
 	// containing data.
@@ -1417,8 +1558,21 @@ type AggregateTilesOptions struct {
 	// Start and End specify the aggregation window.
 	Start, End time.Time
 	// Step is the downsampling step.
-	Step       time.Duration
-	InsOptions instrument.Options
+	Step time.Duration
+	// DownsampleType is how data points within each step are combined.
+	DownsampleType namespace.DownsampleType
+	InsOptions     instrument.Options
+}
+
+// BackupOptions is the options for backing up namespaces.
+type BackupOptions struct {
+	// Namespaces are the namespaces to backup, all namespaces are backed up
+	// if not set.
+	Namespaces []ident.ID
+	// Store is the store that the backup is written to.
+	Store backup.Store
+	// Topology is the topology recorded in the backup manifest, optional.
+	Topology topology.Map
 }
 
 // TileAggregator is the interface for AggregateTiles.
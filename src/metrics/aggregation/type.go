@@ -53,6 +53,7 @@ const (
 	P99
 	P999
 	P9999
+	Buckets
 
 	nextTypeID = iota
 )
@@ -76,28 +77,29 @@ var (
 
 	// ValidTypes is the list of all the valid aggregation types.
 	ValidTypes = map[Type]struct{}{
-		Last:   emptyStruct,
-		Min:    emptyStruct,
-		Max:    emptyStruct,
-		Mean:   emptyStruct,
-		Median: emptyStruct,
-		Count:  emptyStruct,
-		Sum:    emptyStruct,
-		SumSq:  emptyStruct,
-		Stdev:  emptyStruct,
-		P10:    emptyStruct,
-		P20:    emptyStruct,
-		P30:    emptyStruct,
-		P40:    emptyStruct,
-		P50:    emptyStruct,
-		P60:    emptyStruct,
-		P70:    emptyStruct,
-		P80:    emptyStruct,
-		P90:    emptyStruct,
-		P95:    emptyStruct,
-		P99:    emptyStruct,
-		P999:   emptyStruct,
-		P9999:  emptyStruct,
+		Last:    emptyStruct,
+		Min:     emptyStruct,
+		Max:     emptyStruct,
+		Mean:    emptyStruct,
+		Median:  emptyStruct,
+		Count:   emptyStruct,
+		Sum:     emptyStruct,
+		SumSq:   emptyStruct,
+		Stdev:   emptyStruct,
+		P10:     emptyStruct,
+		P20:     emptyStruct,
+		P30:     emptyStruct,
+		P40:     emptyStruct,
+		P50:     emptyStruct,
+		P60:     emptyStruct,
+		P70:     emptyStruct,
+		P80:     emptyStruct,
+		P90:     emptyStruct,
+		P95:     emptyStruct,
+		P99:     emptyStruct,
+		P999:    emptyStruct,
+		P9999:   emptyStruct,
+		Buckets: emptyStruct,
 	}
 
 	typeStringMap map[string]Type
@@ -149,13 +151,24 @@ func (a Type) IsValidForCounter() bool {
 // IsValidForTimer if an Type is valid for Timer.
 func (a Type) IsValidForTimer() bool {
 	switch a {
-	case Last:
+	case Last, Buckets:
 		return false
 	default:
 		return true
 	}
 }
 
+// IsValidForHistogram if an Type is valid for Histogram.
+func (a Type) IsValidForHistogram() bool {
+	switch a {
+	case Mean, Count, Sum, Buckets:
+		return true
+	default:
+		_, ok := a.Quantile()
+		return ok
+	}
+}
+
 // Quantile returns the quantile represented by the Type.
 func (a Type) Quantile() (float64, bool) {
 	switch a {
@@ -339,6 +352,16 @@ func (aggTypes Types) IsValidForTimer() bool {
 	return true
 }
 
+// IsValidForHistogram checks if the list of aggregation types is valid for Histogram.
+func (aggTypes Types) IsValidForHistogram() bool {
+	for _, aggType := range aggTypes {
+		if !aggType.IsValidForHistogram() {
+			return false
+		}
+	}
+	return true
+}
+
 // PooledQuantiles returns all the quantiles found in the list
 // of aggregation types. Using a floats pool if available.
 //
@@ -33,16 +33,25 @@ import (
 )
 
 func TestTypeIsValid(t *testing.T) {
-	require.True(t, P9999.IsValid())
-	require.False(t, Type(int(P9999)+1).IsValid())
+	require.True(t, Buckets.IsValid())
+	require.False(t, Type(int(Buckets)+1).IsValid())
 }
 
 func TestTypeMaxID(t *testing.T) {
-	require.Equal(t, maxTypeID, P9999.ID())
-	require.Equal(t, P9999, Type(maxTypeID))
+	require.Equal(t, maxTypeID, Buckets.ID())
+	require.Equal(t, Buckets, Type(maxTypeID))
 	require.Equal(t, maxTypeID, len(ValidTypes))
 }
 
+func TestTypeIsValidForHistogram(t *testing.T) {
+	require.True(t, Types{Count, Sum, Mean, Median, P99, Buckets}.IsValidForHistogram())
+	require.False(t, Types{Count, Max}.IsValidForHistogram())
+	require.False(t, Types{Last}.IsValidForHistogram())
+	require.False(t, Buckets.IsValidForTimer())
+	require.False(t, Buckets.IsValidForCounter())
+	require.False(t, Buckets.IsValidForGauge())
+}
+
 func TestTypeUnmarshalYAML(t *testing.T) {
 	inputs := []struct {
 		str         string
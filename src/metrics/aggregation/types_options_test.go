@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/x/pool"
 
 	"github.com/stretchr/testify/require"
@@ -36,10 +37,12 @@ func TestTypesOptionsValidateDefault(t *testing.T) {
 	require.Equal(t, defaultDefaultCounterAggregationTypes, o.DefaultCounterAggregationTypes())
 	require.Equal(t, defaultDefaultTimerAggregationTypes, o.DefaultTimerAggregationTypes())
 	require.Equal(t, defaultDefaultGaugeAggregationTypes, o.DefaultGaugeAggregationTypes())
+	require.Equal(t, defaultDefaultHistogramAggregationTypes, o.DefaultHistogramAggregationTypes())
 	require.NotNil(t, o.QuantileTypeStringFn())
 	require.NotNil(t, o.CounterTypeStringTransformFn())
 	require.NotNil(t, o.TimerTypeStringTransformFn())
 	require.NotNil(t, o.GaugeTypeStringTransformFn())
+	require.NotNil(t, o.HistogramTypeStringTransformFn())
 
 	// Validate derived options
 	opts := o.(*options)
@@ -47,6 +50,7 @@ func TestTypesOptionsValidateDefault(t *testing.T) {
 	require.Equal(t, typeStrings(nil), opts.counterTypeStrings)
 	require.Equal(t, typeStrings(nil), opts.timerTypeStrings)
 	require.Equal(t, typeStrings(nil), opts.gaugeTypeStrings)
+	require.Equal(t, typeStrings(nil), opts.histogramTypeStrings)
 }
 
 func TestOptionsSetDefaultCounterAggregationTypes(t *testing.T) {
@@ -71,6 +75,23 @@ func TestOptionsSetDefaultGaugeAggregationTypes(t *testing.T) {
 	require.Equal(t, typeStrings(nil), o.(*options).gaugeTypeStrings)
 }
 
+func TestOptionsSetDefaultHistogramAggregationTypes(t *testing.T) {
+	aggTypes := Types{Count, P99, Buckets}
+	o := NewTypesOptions().SetDefaultHistogramAggregationTypes(aggTypes)
+	require.Equal(t, aggTypes, o.DefaultHistogramAggregationTypes())
+	require.True(t, o.IsContainedInDefaultAggregationTypes(Buckets, metric.HistogramType))
+	require.False(t, o.IsContainedInDefaultAggregationTypes(Sum, metric.HistogramType))
+	require.Equal(t, typeStrings(nil), o.(*options).histogramTypeStrings)
+}
+
+func TestOptionsSetHistogramTypeStringTransformFn(t *testing.T) {
+	o := NewTypesOptions().SetHistogramTypeStringTransformFn(SuffixTransform)
+	require.Equal(t, []byte(".bucket"), o.TypeStringForHistogram(Buckets))
+	require.Equal(t, []byte(".p99"), o.TypeStringForHistogram(P99))
+	require.Equal(t, Buckets, o.TypeForHistogram([]byte(".bucket")))
+	require.Equal(t, []byte("bucket"), o.TypeStringForTimer(Buckets))
+}
+
 func TestOptionsSetTimerQuantileTypeStringFn(t *testing.T) {
 	fn := func(q float64) []byte { return []byte(fmt.Sprintf("%1.2f", q)) }
 	o := NewTypesOptions().SetQuantileTypeStringFn(fn)
@@ -450,28 +471,29 @@ func validateQuantiles(t *testing.T, o TypesOptions) {
 
 func typeStrings(overrides map[Type][]byte) [][]byte {
 	defaultTypeStrings := map[Type][]byte{
-		Last:   []byte("last"),
-		Min:    []byte("lower"),
-		Max:    []byte("upper"),
-		Mean:   []byte("mean"),
-		Median: []byte("median"),
-		Count:  []byte("count"),
-		Sum:    []byte("sum"),
-		SumSq:  []byte("sum_sq"),
-		Stdev:  []byte("stdev"),
-		P10:    []byte("p10"),
-		P20:    []byte("p20"),
-		P30:    []byte("p30"),
-		P40:    []byte("p40"),
-		P50:    []byte("p50"),
-		P60:    []byte("p60"),
-		P70:    []byte("p70"),
-		P80:    []byte("p80"),
-		P90:    []byte("p90"),
-		P95:    []byte("p95"),
-		P99:    []byte("p99"),
-		P999:   []byte("p999"),
-		P9999:  []byte("p9999"),
+		Last:    []byte("last"),
+		Min:     []byte("lower"),
+		Max:     []byte("upper"),
+		Mean:    []byte("mean"),
+		Median:  []byte("median"),
+		Count:   []byte("count"),
+		Sum:     []byte("sum"),
+		SumSq:   []byte("sum_sq"),
+		Stdev:   []byte("stdev"),
+		P10:     []byte("p10"),
+		P20:     []byte("p20"),
+		P30:     []byte("p30"),
+		P40:     []byte("p40"),
+		P50:     []byte("p50"),
+		P60:     []byte("p60"),
+		P70:     []byte("p70"),
+		P80:     []byte("p80"),
+		P90:     []byte("p90"),
+		P95:     []byte("p95"),
+		P99:     []byte("p99"),
+		P999:    []byte("p999"),
+		P9999:   []byte("p9999"),
+		Buckets: []byte("bucket"),
 	}
 	res := make([][]byte, maxTypeID+1)
 	for t, bstr := range defaultTypeStrings {
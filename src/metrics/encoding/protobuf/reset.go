@@ -50,6 +50,7 @@ func ReuseMetricWithMetadatasProto(pb *metricpb.MetricWithMetadatas) {
 	resetTimedMetricWithMetadataProto(pb.TimedMetricWithMetadata)
 	resetTimedMetricWithMetadatasProto(pb.TimedMetricWithMetadatas)
 	resetTimedMetricWithStoragePolicyProto(pb.TimedMetricWithStoragePolicy)
+	resetHistogramWithMetadatasProto(pb.HistogramWithMetadatas)
 }
 
 // ReuseAggregatedMetricProto allows for zero-alloc reuse of
@@ -118,6 +119,14 @@ func resetTimedMetricWithStoragePolicyProto(pb *metricpb.TimedMetricWithStorageP
 	pb.StoragePolicy.Reset()
 }
 
+func resetHistogramWithMetadatasProto(pb *metricpb.HistogramWithMetadatas) {
+	if pb == nil {
+		return
+	}
+	resetHistogram(&pb.Histogram)
+	resetMetadatas(&pb.Metadatas)
+}
+
 func resetCounter(pb *metricpb.Counter) {
 	if pb == nil {
 		return
@@ -142,6 +151,20 @@ func resetGauge(pb *metricpb.Gauge) {
 	pb.Value = 0.0
 }
 
+func resetHistogram(pb *metricpb.Histogram) {
+	if pb == nil {
+		return
+	}
+	pb.Id = pb.Id[:0]
+	pb.Schema = 0
+	pb.ZeroThreshold = 0
+	pb.ZeroCount = 0
+	pb.Count = 0
+	pb.Sum = 0
+	pb.PositiveBuckets = pb.PositiveBuckets[:0]
+	pb.NegativeBuckets = pb.NegativeBuckets[:0]
+}
+
 func resetForwardedMetric(pb *metricpb.ForwardedMetric) {
 	if pb == nil {
 		return
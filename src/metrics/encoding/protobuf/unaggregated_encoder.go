@@ -70,6 +70,7 @@ type unaggregatedEncoder struct {
 	tm   metricpb.TimedMetricWithMetadata
 	tms  metricpb.TimedMetricWithMetadatas
 	pm   metricpb.TimedMetricWithStoragePolicy
+	hm   metricpb.HistogramWithMetadatas
 	buf  []byte
 	used int
 
@@ -133,6 +134,8 @@ func (enc *unaggregatedEncoder) EncodeMessage(msg encoding.UnaggregatedMessageUn
 		return enc.encodeTimedMetricWithMetadatas(msg.TimedMetricWithMetadatas)
 	case encoding.PassthroughMetricWithMetadataType:
 		return enc.encodePassthroughMetricWithMetadata(msg.PassthroughMetricWithMetadata)
+	case encoding.HistogramWithMetadatasType:
+		return enc.encodeHistogramWithMetadatas(msg.HistogramWithMetadatas)
 	default:
 		return fmt.Errorf("unknown message type: %v", msg.Type)
 	}
@@ -215,6 +218,17 @@ func (enc *unaggregatedEncoder) encodePassthroughMetricWithMetadata(pm aggregate
 	return enc.encodeMetricWithMetadatas(mm)
 }
 
+func (enc *unaggregatedEncoder) encodeHistogramWithMetadatas(hm unaggregated.HistogramWithMetadatas) error {
+	if err := hm.ToProto(&enc.hm); err != nil {
+		return fmt.Errorf("histogram with metadatas proto conversion failed: %v", err)
+	}
+	mm := metricpb.MetricWithMetadatas{
+		Type:                   metricpb.MetricWithMetadatas_HISTOGRAM_WITH_METADATAS,
+		HistogramWithMetadatas: &enc.hm,
+	}
+	return enc.encodeMetricWithMetadatas(mm)
+}
+
 func (enc *unaggregatedEncoder) encodeMetricWithMetadatas(pb metricpb.MetricWithMetadatas) error {
 	msgSize := pb.Size()
 	if msgSize > enc.maxMessageSize {
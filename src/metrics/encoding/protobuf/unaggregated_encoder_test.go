@@ -32,6 +32,7 @@ import (
 	"github.com/m3db/m3/src/metrics/generated/proto/pipelinepb"
 	"github.com/m3db/m3/src/metrics/generated/proto/policypb"
 	"github.com/m3db/m3/src/metrics/generated/proto/transformationpb"
+	"github.com/m3db/m3/src/metrics/histogram"
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
@@ -72,6 +73,28 @@ var (
 		ID:    []byte("testGauge2"),
 		Value: 234231.345,
 	}
+	testHistogram1 = unaggregated.Histogram{
+		ID: []byte("testHistogram1"),
+		Value: histogram.Histogram{
+			Schema:          3,
+			Count:           6,
+			Sum:             12.5,
+			PositiveBuckets: []histogram.Bucket{{Index: 2, Count: 3}, {Index: 9, Count: 2}},
+			NegativeBuckets: []histogram.Bucket{{Index: 1, Count: 1}},
+		},
+	}
+	testHistogram2 = unaggregated.Histogram{
+		ID: []byte("testHistogram2"),
+		Value: histogram.Histogram{
+			Schema:          -1,
+			ZeroThreshold:   1e-3,
+			ZeroCount:       4,
+			Count:           9,
+			Sum:             -3.75,
+			PositiveBuckets: []histogram.Bucket{{Index: -3, Count: 1}},
+			NegativeBuckets: []histogram.Bucket{{Index: 4, Count: 2}, {Index: 5, Count: 2}},
+		},
+	}
 	testForwardedMetric1 = aggregated.ForwardedMetric{
 		Type:      metric.CounterType,
 		ID:        []byte("testForwardedMetric1"),
@@ -155,6 +155,9 @@ func (it *unaggregatedIterator) decodeMessage(size int) error {
 	case metricpb.MetricWithMetadatas_TIMED_METRIC_WITH_STORAGE_POLICY:
 		it.msg.Type = encoding.PassthroughMetricWithMetadataType
 		it.err = it.msg.PassthroughMetricWithMetadata.FromProto(it.pb.TimedMetricWithStoragePolicy)
+	case metricpb.MetricWithMetadatas_HISTOGRAM_WITH_METADATAS:
+		it.msg.Type = encoding.HistogramWithMetadatasType
+		it.err = it.msg.HistogramWithMetadatas.FromProto(it.pb.HistogramWithMetadatas)
 	default:
 		it.err = fmt.Errorf("unrecognized message type: %v", it.pb.Type)
 	}
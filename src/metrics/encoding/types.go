@@ -40,6 +40,7 @@ const (
 	TimedMetricWithMetadataType
 	TimedMetricWithMetadatasType
 	PassthroughMetricWithMetadataType
+	HistogramWithMetadatasType
 )
 
 // UnaggregatedMessageUnion is a union of different types of unaggregated messages.
@@ -55,6 +56,7 @@ type UnaggregatedMessageUnion struct {
 	TimedMetricWithMetadata       aggregated.TimedMetricWithMetadata
 	TimedMetricWithMetadatas      aggregated.TimedMetricWithMetadatas
 	PassthroughMetricWithMetadata aggregated.PassthroughMetricWithMetadata
+	HistogramWithMetadatas        unaggregated.HistogramWithMetadatas
 }
 
 // ByteReadScanner is capable of reading and scanning bytes.
@@ -75,6 +75,7 @@ const (
 	AggregationType_P99     AggregationType = 20
 	AggregationType_P999    AggregationType = 21
 	AggregationType_P9999   AggregationType = 22
+	AggregationType_BUCKETS AggregationType = 23
 )
 
 var AggregationType_name = map[int32]string{
@@ -101,6 +102,7 @@ var AggregationType_name = map[int32]string{
 	20: "P99",
 	21: "P999",
 	22: "P9999",
+	23: "BUCKETS",
 }
 var AggregationType_value = map[string]int32{
 	"UNKNOWN": 0,
@@ -126,6 +128,7 @@ var AggregationType_value = map[string]int32{
 	"P99":     20,
 	"P999":    21,
 	"P9999":   22,
+	"BUCKETS": 23,
 }
 
 func (x AggregationType) String() string {
@@ -39,6 +39,7 @@
 		TimedMetricWithStoragePolicy
 		AggregatedMetric
 		MetricWithMetadatas
+		HistogramWithMetadatas
 		PipelineMetadata
 		Metadata
 		StagedMetadata
@@ -51,6 +52,8 @@
 		TimedMetric
 		ForwardedMetric
 		Tag
+		HistogramBucket
+		Histogram
 */
 package metricpb
 
@@ -84,6 +87,7 @@ const (
 	MetricWithMetadatas_TIMED_METRIC_WITH_METADATA       MetricWithMetadatas_Type = 5
 	MetricWithMetadatas_TIMED_METRIC_WITH_METADATAS      MetricWithMetadatas_Type = 6
 	MetricWithMetadatas_TIMED_METRIC_WITH_STORAGE_POLICY MetricWithMetadatas_Type = 7
+	MetricWithMetadatas_HISTOGRAM_WITH_METADATAS         MetricWithMetadatas_Type = 8
 )
 
 var MetricWithMetadatas_Type_name = map[int32]string{
@@ -95,6 +99,7 @@ var MetricWithMetadatas_Type_name = map[int32]string{
 	5: "TIMED_METRIC_WITH_METADATA",
 	6: "TIMED_METRIC_WITH_METADATAS",
 	7: "TIMED_METRIC_WITH_STORAGE_POLICY",
+	8: "HISTOGRAM_WITH_METADATAS",
 }
 var MetricWithMetadatas_Type_value = map[string]int32{
 	"UNKNOWN":                          0,
@@ -105,6 +110,7 @@ var MetricWithMetadatas_Type_value = map[string]int32{
 	"TIMED_METRIC_WITH_METADATA":       5,
 	"TIMED_METRIC_WITH_METADATAS":      6,
 	"TIMED_METRIC_WITH_STORAGE_POLICY": 7,
+	"HISTOGRAM_WITH_METADATAS":         8,
 }
 
 func (x MetricWithMetadatas_Type) String() string {
@@ -327,6 +333,7 @@ type MetricWithMetadatas struct {
 	TimedMetricWithMetadata      *TimedMetricWithMetadata      `protobuf:"bytes,6,opt,name=timed_metric_with_metadata,json=timedMetricWithMetadata" json:"timed_metric_with_metadata,omitempty"`
 	TimedMetricWithMetadatas     *TimedMetricWithMetadatas     `protobuf:"bytes,7,opt,name=timed_metric_with_metadatas,json=timedMetricWithMetadatas" json:"timed_metric_with_metadatas,omitempty"`
 	TimedMetricWithStoragePolicy *TimedMetricWithStoragePolicy `protobuf:"bytes,8,opt,name=timed_metric_with_storage_policy,json=timedMetricWithStoragePolicy" json:"timed_metric_with_storage_policy,omitempty"`
+	HistogramWithMetadatas       *HistogramWithMetadatas       `protobuf:"bytes,9,opt,name=histogram_with_metadatas,json=histogramWithMetadatas" json:"histogram_with_metadatas,omitempty"`
 }
 
 func (m *MetricWithMetadatas) Reset()                    { *m = MetricWithMetadatas{} }
@@ -390,6 +397,37 @@ func (m *MetricWithMetadatas) GetTimedMetricWithStoragePolicy() *TimedMetricWith
 	return nil
 }
 
+func (m *MetricWithMetadatas) GetHistogramWithMetadatas() *HistogramWithMetadatas {
+	if m != nil {
+		return m.HistogramWithMetadatas
+	}
+	return nil
+}
+
+type HistogramWithMetadatas struct {
+	Histogram     Histogram           `protobuf:"bytes,1,opt,name=histogram" json:"histogram"`
+	Metadatas StagedMetadatas `protobuf:"bytes,2,opt,name=metadatas" json:"metadatas"`
+}
+
+func (m *HistogramWithMetadatas) Reset()                    { *m = HistogramWithMetadatas{} }
+func (m *HistogramWithMetadatas) String() string            { return proto.CompactTextString(m) }
+func (*HistogramWithMetadatas) ProtoMessage()               {}
+func (*HistogramWithMetadatas) Descriptor() ([]byte, []int) { return fileDescriptorComposite, []int{9} }
+
+func (m *HistogramWithMetadatas) GetHistogram() Histogram {
+	if m != nil {
+		return m.Histogram
+	}
+	return Histogram{}
+}
+
+func (m *HistogramWithMetadatas) GetMetadatas() StagedMetadatas {
+	if m != nil {
+		return m.Metadatas
+	}
+	return StagedMetadatas{}
+}
+
 func init() {
 	proto.RegisterType((*CounterWithMetadatas)(nil), "metricpb.CounterWithMetadatas")
 	proto.RegisterType((*BatchTimerWithMetadatas)(nil), "metricpb.BatchTimerWithMetadatas")
@@ -400,6 +438,7 @@ func init() {
 	proto.RegisterType((*TimedMetricWithStoragePolicy)(nil), "metricpb.TimedMetricWithStoragePolicy")
 	proto.RegisterType((*AggregatedMetric)(nil), "metricpb.AggregatedMetric")
 	proto.RegisterType((*MetricWithMetadatas)(nil), "metricpb.MetricWithMetadatas")
+	proto.RegisterType((*HistogramWithMetadatas)(nil), "metricpb.HistogramWithMetadatas")
 	proto.RegisterEnum("metricpb.MetricWithMetadatas_Type", MetricWithMetadatas_Type_name, MetricWithMetadatas_Type_value)
 }
 func (m *CounterWithMetadatas) Marshal() (dAtA []byte, err error) {
@@ -761,6 +800,50 @@ func (m *MetricWithMetadatas) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n22
 	}
+	if m.HistogramWithMetadatas != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintComposite(dAtA, i, uint64(m.HistogramWithMetadatas.Size()))
+		n25, err := m.HistogramWithMetadatas.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n25
+	}
+	return i, nil
+}
+
+func (m *HistogramWithMetadatas) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HistogramWithMetadatas) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintComposite(dAtA, i, uint64(m.Histogram.Size()))
+	n23, err := m.Histogram.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n23
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintComposite(dAtA, i, uint64(m.Metadatas.Size()))
+	n24, err := m.Metadatas.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n24
 	return i, nil
 }
 
@@ -888,6 +971,20 @@ func (m *MetricWithMetadatas) Size() (n int) {
 		l = m.TimedMetricWithStoragePolicy.Size()
 		n += 1 + l + sovComposite(uint64(l))
 	}
+	if m.HistogramWithMetadatas != nil {
+		l = m.HistogramWithMetadatas.Size()
+		n += 1 + l + sovComposite(uint64(l))
+	}
+	return n
+}
+
+func (m *HistogramWithMetadatas) Size() (n int) {
+	var l int
+	_ = l
+	l = m.Histogram.Size()
+	n += 1 + l + sovComposite(uint64(l))
+	l = m.Metadatas.Size()
+	n += 1 + l + sovComposite(uint64(l))
 	return n
 }
 
@@ -2052,6 +2149,149 @@ func (m *MetricWithMetadatas) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HistogramWithMetadatas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComposite
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthComposite
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HistogramWithMetadatas == nil {
+				m.HistogramWithMetadatas = &HistogramWithMetadatas{}
+			}
+			if err := m.HistogramWithMetadatas.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipComposite(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthComposite
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HistogramWithMetadatas) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowComposite
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HistogramWithMetadatas: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HistogramWithMetadatas: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Histogram", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComposite
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthComposite
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Histogram.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadatas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComposite
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthComposite
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Metadatas.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipComposite(dAtA[iNdEx:])
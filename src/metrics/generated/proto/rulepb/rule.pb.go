@@ -30,6 +30,7 @@ import aggregationpb "github.com/m3db/m3/src/metrics/generated/proto/aggregation
 import pipelinepb "github.com/m3db/m3/src/metrics/generated/proto/pipelinepb"
 import policypb "github.com/m3db/m3/src/metrics/generated/proto/policypb"
 import metricpb "github.com/m3db/m3/src/metrics/generated/proto/metricpb"
+import transformationpb "github.com/m3db/m3/src/metrics/generated/proto/transformationpb"
 
 import io "io"
 
@@ -44,13 +45,14 @@ type MappingRuleSnapshot struct {
 	CutoverNanos int64  `protobuf:"varint,3,opt,name=cutover_nanos,json=cutoverNanos,proto3" json:"cutover_nanos,omitempty"`
 	Filter       string `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
 	// TODO(xichen): remove this and mark the field number reserved once all mapping rules are updated in KV.
-	Policies           []*policypb.Policy              `protobuf:"bytes,5,rep,name=policies" json:"policies,omitempty"`
-	LastUpdatedAtNanos int64                           `protobuf:"varint,6,opt,name=last_updated_at_nanos,json=lastUpdatedAtNanos,proto3" json:"last_updated_at_nanos,omitempty"`
-	LastUpdatedBy      string                          `protobuf:"bytes,7,opt,name=last_updated_by,json=lastUpdatedBy,proto3" json:"last_updated_by,omitempty"`
-	AggregationTypes   []aggregationpb.AggregationType `protobuf:"varint,8,rep,packed,name=aggregation_types,json=aggregationTypes,enum=aggregationpb.AggregationType" json:"aggregation_types,omitempty"`
-	StoragePolicies    []*policypb.StoragePolicy       `protobuf:"bytes,9,rep,name=storage_policies,json=storagePolicies" json:"storage_policies,omitempty"`
-	DropPolicy         policypb.DropPolicy             `protobuf:"varint,10,opt,name=drop_policy,json=dropPolicy,proto3,enum=policypb.DropPolicy" json:"drop_policy,omitempty"`
-	Tags               []*metricpb.Tag                 `protobuf:"bytes,11,rep,name=tags" json:"tags,omitempty"`
+	Policies           []*policypb.Policy                    `protobuf:"bytes,5,rep,name=policies" json:"policies,omitempty"`
+	LastUpdatedAtNanos int64                                 `protobuf:"varint,6,opt,name=last_updated_at_nanos,json=lastUpdatedAtNanos,proto3" json:"last_updated_at_nanos,omitempty"`
+	LastUpdatedBy      string                                `protobuf:"bytes,7,opt,name=last_updated_by,json=lastUpdatedBy,proto3" json:"last_updated_by,omitempty"`
+	AggregationTypes   []aggregationpb.AggregationType       `protobuf:"varint,8,rep,packed,name=aggregation_types,json=aggregationTypes,enum=aggregationpb.AggregationType" json:"aggregation_types,omitempty"`
+	StoragePolicies    []*policypb.StoragePolicy             `protobuf:"bytes,9,rep,name=storage_policies,json=storagePolicies" json:"storage_policies,omitempty"`
+	DropPolicy         policypb.DropPolicy                   `protobuf:"varint,10,opt,name=drop_policy,json=dropPolicy,proto3,enum=policypb.DropPolicy" json:"drop_policy,omitempty"`
+	Tags               []*metricpb.Tag                       `protobuf:"bytes,11,rep,name=tags" json:"tags,omitempty"`
+	Transformations    []transformationpb.TransformationType `protobuf:"varint,12,rep,packed,name=transformations,enum=transformationpb.TransformationType" json:"transformations,omitempty"`
 }
 
 func (m *MappingRuleSnapshot) Reset()                    { *m = MappingRuleSnapshot{} }
@@ -135,6 +137,13 @@ func (m *MappingRuleSnapshot) GetTags() []*metricpb.Tag {
 	return nil
 }
 
+func (m *MappingRuleSnapshot) GetTransformations() []transformationpb.TransformationType {
+	if m != nil {
+		return m.Transformations
+	}
+	return nil
+}
+
 type MappingRule struct {
 	Uuid      string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
 	Snapshots []*MappingRuleSnapshot `protobuf:"bytes,2,rep,name=snapshots" json:"snapshots,omitempty"`
@@ -523,6 +532,23 @@ func (m *MappingRuleSnapshot) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.Transformations) > 0 {
+		dAtA4 := make([]byte, len(m.Transformations)*10)
+		var j3 int
+		for _, num := range m.Transformations {
+			for num >= 1<<7 {
+				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j3++
+			}
+			dAtA4[j3] = uint8(num)
+			j3++
+		}
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintRule(dAtA, i, uint64(j3))
+		i += copy(dAtA[i:], dAtA4[:j3])
+	}
 	return i, nil
 }
 
@@ -925,6 +951,13 @@ func (m *MappingRuleSnapshot) Size() (n int) {
 			n += 1 + l + sovRule(uint64(l))
 		}
 	}
+	if len(m.Transformations) > 0 {
+		l = 0
+		for _, e := range m.Transformations {
+			l += sovRule(uint64(e))
+		}
+		n += 1 + sovRule(uint64(l)) + l
+	}
 	return n
 }
 
@@ -1443,6 +1476,68 @@ func (m *MappingRuleSnapshot) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 12:
+			if wireType == 0 {
+				var v transformationpb.TransformationType
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRule
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (transformationpb.TransformationType(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Transformations = append(m.Transformations, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRule
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRule
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v transformationpb.TransformationType
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRule
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (transformationpb.TransformationType(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Transformations = append(m.Transformations, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Transformations", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRule(dAtA[iNdEx:])
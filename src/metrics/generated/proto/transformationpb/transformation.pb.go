@@ -25,6 +25,7 @@
 Package transformationpb is a generated protocol buffer package.
 
 It is generated from these files:
+
 	github.com/m3db/m3/src/metrics/generated/proto/transformationpb/transformation.proto
 
 It has these top-level messages:
@@ -49,12 +50,14 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type TransformationType int32
 
 const (
-	TransformationType_UNKNOWN   TransformationType = 0
-	TransformationType_ABSOLUTE  TransformationType = 1
-	TransformationType_PERSECOND TransformationType = 2
-	TransformationType_INCREASE  TransformationType = 3
-	TransformationType_ADD       TransformationType = 4
-	TransformationType_RESET     TransformationType = 5
+	TransformationType_UNKNOWN             TransformationType = 0
+	TransformationType_ABSOLUTE            TransformationType = 1
+	TransformationType_PERSECOND           TransformationType = 2
+	TransformationType_INCREASE            TransformationType = 3
+	TransformationType_ADD                 TransformationType = 4
+	TransformationType_RESET               TransformationType = 5
+	TransformationType_DELTA_TO_CUMULATIVE TransformationType = 6
+	TransformationType_CUMULATIVE_TO_DELTA TransformationType = 7
 )
 
 var TransformationType_name = map[int32]string{
@@ -64,14 +67,18 @@ var TransformationType_name = map[int32]string{
 	3: "INCREASE",
 	4: "ADD",
 	5: "RESET",
+	6: "DELTA_TO_CUMULATIVE",
+	7: "CUMULATIVE_TO_DELTA",
 }
 var TransformationType_value = map[string]int32{
-	"UNKNOWN":   0,
-	"ABSOLUTE":  1,
-	"PERSECOND": 2,
-	"INCREASE":  3,
-	"ADD":       4,
-	"RESET":     5,
+	"UNKNOWN":             0,
+	"ABSOLUTE":            1,
+	"PERSECOND":           2,
+	"INCREASE":            3,
+	"ADD":                 4,
+	"RESET":               5,
+	"DELTA_TO_CUMULATIVE": 6,
+	"CUMULATIVE_TO_DELTA": 7,
 }
 
 func (x TransformationType) String() string {
@@ -0,0 +1,483 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package histogram provides sparse histograms with exponential bucket
+// boundaries. Unlike quantile estimates, histograms with such buckets can be
+// merged across series and aggregator instances without losing accuracy
+// beyond the width of their buckets.
+package histogram
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+const (
+	// MinSchema is the smallest schema, with a growth factor of 2^16 between
+	// the boundaries of consecutive buckets.
+	MinSchema int32 = -4
+	// MaxSchema is the largest schema, with a growth factor of 2^(1/256)
+	// between the boundaries of consecutive buckets.
+	MaxSchema int32 = 8
+
+	// numFloatsHeader is the number of float64 values that an encoded
+	// histogram starts with before its buckets.
+	numFloatsHeader = 7
+)
+
+var (
+	bucketSuffixSeparator = []byte(".le=")
+	infUpperBound         = []byte("+Inf")
+
+	errFloatsTooShort = errors.New("encoded histogram is too short")
+)
+
+// Bucket is a histogram bucket. The bucket with index i of a histogram with
+// schema s counts the values in (base^(i-1), base^i] where base is 2^(2^-s),
+// or the negation of that range for negative buckets.
+type Bucket struct {
+	Index int32
+	Count uint64
+}
+
+// Histogram is a sparse histogram with exponential bucket boundaries, only
+// buckets with values are kept.
+type Histogram struct {
+	// Schema determines the boundaries of the buckets.
+	Schema int32
+	// ZeroThreshold is the boundary of the zero bucket, which counts the
+	// values whose absolute value is no larger than it.
+	ZeroThreshold float64
+	// ZeroCount is the number of values in the zero bucket.
+	ZeroCount uint64
+	// Count is the number of values, including NaN and infinite values which
+	// do not fall into any bucket.
+	Count uint64
+	// Sum is the sum of the values.
+	Sum float64
+	// PositiveBuckets are the buckets of positive values sorted by index.
+	PositiveBuckets []Bucket
+	// NegativeBuckets are the buckets of negative values sorted by index.
+	NegativeBuckets []Bucket
+}
+
+// CumulativeBucket is a bucket counting all values no larger than its upper
+// bound, as used by Prometheus histograms that are queried with
+// histogram_quantile.
+type CumulativeBucket struct {
+	UpperBound float64
+	Count      uint64
+}
+
+// BucketIndex returns the index of the bucket of the given schema that a
+// value larger than zero falls into.
+func BucketIndex(schema int32, v float64) int32 {
+	frac, exp := math.Frexp(v)
+	if schema > 0 {
+		// NB: powers of two are the upper boundary of a bucket, computing
+		// their index exactly avoids rounding errors of the logarithm.
+		if frac == 0.5 {
+			return int32(exp-1) << uint(schema)
+		}
+		return int32(math.Ceil(math.Log2(v) * math.Ldexp(1, int(schema))))
+	}
+	index := int32(exp)
+	if frac == 0.5 {
+		index--
+	}
+	offset := (int32(1) << uint(-schema)) - 1
+	return (index + offset) >> uint(-schema)
+}
+
+// UpperBound returns the upper boundary of the bucket of the given schema
+// with the given index.
+func UpperBound(schema int32, index int32) float64 {
+	if schema < 0 {
+		return math.Ldexp(1, int(index)<<uint(-schema))
+	}
+	return math.Exp2(float64(index) / math.Ldexp(1, int(schema)))
+}
+
+// Validate validates the histogram.
+func (h Histogram) Validate() error {
+	if h.Schema < MinSchema || h.Schema > MaxSchema {
+		return fmt.Errorf("invalid histogram schema %d, must be between %d and %d",
+			h.Schema, MinSchema, MaxSchema)
+	}
+	if !(h.ZeroThreshold >= 0) || math.IsInf(h.ZeroThreshold, 1) {
+		return fmt.Errorf("invalid histogram zero threshold %f", h.ZeroThreshold)
+	}
+	if err := validateBuckets(h.PositiveBuckets); err != nil {
+		return err
+	}
+	if err := validateBuckets(h.NegativeBuckets); err != nil {
+		return err
+	}
+	if n := h.bucketsCount(); n > h.Count {
+		return fmt.Errorf("histogram bucket counts %d exceed count %d", n, h.Count)
+	}
+	return nil
+}
+
+// Add adds a value to the histogram. NaN and infinite values are only
+// added to the count and the sum as they do not fall into any bucket.
+func (h *Histogram) Add(v float64) {
+	h.Count++
+	h.Sum += v
+	switch {
+	case math.IsNaN(v) || math.IsInf(v, 0):
+	case math.Abs(v) <= h.ZeroThreshold:
+		h.ZeroCount++
+	case v > 0:
+		h.PositiveBuckets = addToBuckets(h.PositiveBuckets, BucketIndex(h.Schema, v), 1)
+	default:
+		h.NegativeBuckets = addToBuckets(h.NegativeBuckets, BucketIndex(h.Schema, -v), 1)
+	}
+}
+
+// Merge merges another histogram into the histogram. If the histograms have
+// different schemas, the buckets of the histogram with the larger schema are
+// merged down to the smaller schema, and if they have different zero
+// thresholds the buckets within the larger threshold are merged into the zero
+// bucket.
+func (h *Histogram) Merge(other Histogram) {
+	if other.Schema < h.Schema {
+		h.Downscale(other.Schema)
+	}
+	if other.ZeroThreshold > h.ZeroThreshold {
+		h.widenZeroBucket(other.ZeroThreshold)
+	}
+	h.Count += other.Count
+	h.Sum += other.Sum
+	h.ZeroCount += other.ZeroCount
+
+	delta := other.Schema - h.Schema
+	for _, b := range other.PositiveBuckets {
+		index := downscaleIndex(b.Index, delta)
+		if h.ZeroThreshold > 0 && UpperBound(h.Schema, index) <= h.ZeroThreshold {
+			h.ZeroCount += b.Count
+			continue
+		}
+		h.PositiveBuckets = addToBuckets(h.PositiveBuckets, index, b.Count)
+	}
+	for _, b := range other.NegativeBuckets {
+		index := downscaleIndex(b.Index, delta)
+		if h.ZeroThreshold > 0 && UpperBound(h.Schema, index) <= h.ZeroThreshold {
+			h.ZeroCount += b.Count
+			continue
+		}
+		h.NegativeBuckets = addToBuckets(h.NegativeBuckets, index, b.Count)
+	}
+}
+
+// Downscale reduces the schema of the histogram to the given schema, merging
+// its buckets, it is a no-op if the schema is no smaller than the current one.
+func (h *Histogram) Downscale(schema int32) {
+	if schema >= h.Schema {
+		return
+	}
+	delta := h.Schema - schema
+	h.PositiveBuckets = downscaleBuckets(h.PositiveBuckets, delta)
+	h.NegativeBuckets = downscaleBuckets(h.NegativeBuckets, delta)
+	h.Schema = schema
+}
+
+// LimitBuckets reduces the schema of the histogram until it has no more than
+// the given number of buckets, or its schema is the min schema.
+func (h *Histogram) LimitBuckets(maxBuckets int) {
+	for len(h.PositiveBuckets)+len(h.NegativeBuckets) > maxBuckets && h.Schema > MinSchema {
+		h.Downscale(h.Schema - 1)
+	}
+}
+
+// Reset resets the histogram to an empty histogram with the given schema and
+// zero threshold, reusing its buckets.
+func (h *Histogram) Reset(schema int32, zeroThreshold float64) {
+	*h = Histogram{
+		Schema:          schema,
+		ZeroThreshold:   zeroThreshold,
+		PositiveBuckets: h.PositiveBuckets[:0],
+		NegativeBuckets: h.NegativeBuckets[:0],
+	}
+}
+
+// Quantile returns the q-quantile of the values in the buckets of the
+// histogram, linearly interpolating within the bucket the quantile falls
+// into like histogram_quantile, or NaN if the buckets are empty.
+func (h Histogram) Quantile(q float64) float64 {
+	total := h.bucketsCount()
+	switch {
+	case total == 0 || math.IsNaN(q):
+		return math.NaN()
+	case q < 0:
+		return math.Inf(-1)
+	case q > 1:
+		return math.Inf(1)
+	}
+
+	var (
+		rank   = q * float64(total)
+		cum    float64
+		result = math.NaN()
+	)
+	h.forEachBucket(func(lower, upper float64, count uint64) bool {
+		if next := cum + float64(count); next < rank {
+			cum = next
+			return true
+		}
+		result = lower + (upper-lower)*(rank-cum)/float64(count)
+		return false
+	})
+	return result
+}
+
+// AppendCumulativeBuckets appends the cumulative buckets of the histogram in
+// increasing order of upper bound to dst, ending with a +Inf bucket counting
+// all values. The lower boundary of each bucket is also appended, unless it
+// is the upper boundary of the previous bucket, so that interpolating between
+// the cumulative buckets stays within the boundaries of the buckets.
+func (h Histogram) AppendCumulativeBuckets(dst []CumulativeBucket) []CumulativeBucket {
+	var (
+		start = len(dst)
+		cum   uint64
+	)
+	h.forEachBucket(func(lower, upper float64, count uint64) bool {
+		if n := len(dst); lower != upper && (n == start || dst[n-1].UpperBound != lower) {
+			dst = append(dst, CumulativeBucket{UpperBound: lower, Count: cum})
+		}
+		cum += count
+		dst = append(dst, CumulativeBucket{UpperBound: upper, Count: cum})
+		return true
+	})
+
+	total := h.Count
+	if total < cum {
+		total = cum
+	}
+	if n := len(dst); n > start && math.IsInf(dst[n-1].UpperBound, 1) {
+		dst[n-1].Count = total
+		return dst
+	}
+	return append(dst, CumulativeBucket{UpperBound: math.Inf(1), Count: total})
+}
+
+// AppendFloats appends an encoding of the histogram as float64 values to dst,
+// so that histograms can be carried by the values of forwarded metrics.
+// NB: counts larger than 2^53 lose precision when encoded.
+func (h Histogram) AppendFloats(dst []float64) []float64 {
+	dst = append(dst,
+		float64(h.Schema),
+		h.ZeroThreshold,
+		float64(h.ZeroCount),
+		float64(h.Count),
+		h.Sum,
+		float64(len(h.PositiveBuckets)),
+		float64(len(h.NegativeBuckets)),
+	)
+	for _, b := range h.PositiveBuckets {
+		dst = append(dst, float64(b.Index), float64(b.Count))
+	}
+	for _, b := range h.NegativeBuckets {
+		dst = append(dst, float64(b.Index), float64(b.Count))
+	}
+	return dst
+}
+
+// ReadFloats reads a histogram encoded by AppendFloats from the head of the
+// values, returning the histogram and the values that follow it.
+func ReadFloats(values []float64) (Histogram, []float64, error) {
+	if len(values) < numFloatsHeader {
+		return Histogram{}, nil, errFloatsTooShort
+	}
+	var (
+		h = Histogram{
+			Schema:        int32(values[0]),
+			ZeroThreshold: values[1],
+			ZeroCount:     uint64(values[2]),
+			Count:         uint64(values[3]),
+			Sum:           values[4],
+		}
+		numPositive = int(values[5])
+		numNegative = int(values[6])
+	)
+	values = values[numFloatsHeader:]
+	if numPositive < 0 || numNegative < 0 || len(values) < 2*(numPositive+numNegative) {
+		return Histogram{}, nil, errFloatsTooShort
+	}
+	h.PositiveBuckets, values = readBuckets(values, numPositive)
+	h.NegativeBuckets, values = readBuckets(values, numNegative)
+	if err := h.Validate(); err != nil {
+		return Histogram{}, nil, err
+	}
+	return h, values, nil
+}
+
+// AppendBucketSuffix appends the suffix of the series of a cumulative bucket
+// with the given upper bound to dst, which is the type string of the bucket
+// aggregation followed by the upper bound.
+func AppendBucketSuffix(dst []byte, typeString []byte, upperBound float64) []byte {
+	dst = append(dst, typeString...)
+	dst = append(dst, bucketSuffixSeparator...)
+	return AppendUpperBound(dst, upperBound)
+}
+
+// ParseBucketSuffix splits the suffix of the series of a cumulative bucket
+// into the type string of the bucket aggregation and the upper bound,
+// returning false if the suffix is not the suffix of a bucket series.
+func ParseBucketSuffix(suffix []byte) (typeString []byte, upperBound []byte, ok bool) {
+	idx := bytes.LastIndex(suffix, bucketSuffixSeparator)
+	if idx < 0 {
+		return nil, nil, false
+	}
+	return suffix[:idx], suffix[idx+len(bucketSuffixSeparator):], true
+}
+
+// AppendUpperBound appends the upper bound of a cumulative bucket formatted
+// as the value of a Prometheus le label to dst.
+func AppendUpperBound(dst []byte, upperBound float64) []byte {
+	if math.IsInf(upperBound, 1) {
+		return append(dst, infUpperBound...)
+	}
+	return strconv.AppendFloat(dst, upperBound, 'g', -1, 64)
+}
+
+// forEachBucket calls fn with the boundaries and the count of each non empty
+// bucket in increasing order of boundaries until fn returns false.
+func (h Histogram) forEachBucket(fn func(lower, upper float64, count uint64) bool) {
+	for i := len(h.NegativeBuckets) - 1; i >= 0; i-- {
+		b := h.NegativeBuckets[i]
+		if b.Count == 0 {
+			continue
+		}
+		lower, upper := -UpperBound(h.Schema, b.Index), -UpperBound(h.Schema, b.Index-1)
+		if !fn(lower, upper, b.Count) {
+			return
+		}
+	}
+	if h.ZeroCount > 0 {
+		if !fn(-h.ZeroThreshold, h.ZeroThreshold, h.ZeroCount) {
+			return
+		}
+	}
+	for _, b := range h.PositiveBuckets {
+		if b.Count == 0 {
+			continue
+		}
+		lower, upper := UpperBound(h.Schema, b.Index-1), UpperBound(h.Schema, b.Index)
+		if !fn(lower, upper, b.Count) {
+			return
+		}
+	}
+}
+
+func (h Histogram) bucketsCount() uint64 {
+	n := h.ZeroCount
+	for _, b := range h.PositiveBuckets {
+		n += b.Count
+	}
+	for _, b := range h.NegativeBuckets {
+		n += b.Count
+	}
+	return n
+}
+
+// widenZeroBucket widens the zero bucket to the given threshold, merging the
+// buckets within it into the zero bucket.
+func (h *Histogram) widenZeroBucket(zeroThreshold float64) {
+	h.ZeroThreshold = zeroThreshold
+	h.PositiveBuckets = h.mergeIntoZeroBucket(h.PositiveBuckets)
+	h.NegativeBuckets = h.mergeIntoZeroBucket(h.NegativeBuckets)
+}
+
+func (h *Histogram) mergeIntoZeroBucket(buckets []Bucket) []Bucket {
+	n := 0
+	for n < len(buckets) && UpperBound(h.Schema, buckets[n].Index) <= h.ZeroThreshold {
+		h.ZeroCount += buckets[n].Count
+		n++
+	}
+	return append(buckets[:0], buckets[n:]...)
+}
+
+func validateBuckets(buckets []Bucket) error {
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i].Index <= buckets[i-1].Index {
+			return fmt.Errorf("histogram bucket indexes are not increasing: %d after %d",
+				buckets[i].Index, buckets[i-1].Index)
+		}
+	}
+	return nil
+}
+
+// addToBuckets adds the count to the bucket with the given index, inserting
+// the bucket if it does not exist yet.
+func addToBuckets(buckets []Bucket, index int32, count uint64) []Bucket {
+	// Optimize for values that fall into the last bucket.
+	n := len(buckets)
+	if n > 0 && buckets[n-1].Index == index {
+		buckets[n-1].Count += count
+		return buckets
+	}
+	idx := sort.Search(n, func(i int) bool { return buckets[i].Index >= index })
+	if idx < n && buckets[idx].Index == index {
+		buckets[idx].Count += count
+		return buckets
+	}
+	buckets = append(buckets, Bucket{})
+	copy(buckets[idx+1:], buckets[idx:n])
+	buckets[idx] = Bucket{Index: index, Count: count}
+	return buckets
+}
+
+// downscaleBuckets merges the buckets in place into the buckets of a schema
+// that is smaller by delta.
+func downscaleBuckets(buckets []Bucket, delta int32) []Bucket {
+	n := 0
+	for _, b := range buckets {
+		index := downscaleIndex(b.Index, delta)
+		if n > 0 && buckets[n-1].Index == index {
+			buckets[n-1].Count += b.Count
+			continue
+		}
+		buckets[n] = Bucket{Index: index, Count: b.Count}
+		n++
+	}
+	return buckets[:n]
+}
+
+// downscaleIndex returns the index of the bucket of a schema that is smaller
+// by delta that contains the bucket with the given index.
+func downscaleIndex(index int32, delta int32) int32 {
+	return ((index - 1) >> uint(delta)) + 1
+}
+
+func readBuckets(values []float64, n int) ([]Bucket, []float64) {
+	if n == 0 {
+		return nil, values
+	}
+	buckets := make([]Bucket, n)
+	for i := range buckets {
+		buckets[i] = Bucket{Index: int32(values[2*i]), Count: uint64(values[2*i+1])}
+	}
+	return buckets, values[2*n:]
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketIndex(t *testing.T) {
+	inputs := []struct {
+		schema   int32
+		value    float64
+		expected int32
+	}{
+		{schema: 0, value: 1, expected: 0},
+		{schema: 0, value: 1.5, expected: 1},
+		{schema: 0, value: 2, expected: 1},
+		{schema: 0, value: 3, expected: 2},
+		{schema: 0, value: 0.25, expected: -2},
+		{schema: -1, value: 3, expected: 1},
+		{schema: -1, value: 4, expected: 1},
+		{schema: -1, value: 5, expected: 2},
+		{schema: 1, value: 4, expected: 4},
+		{schema: 1, value: 1.5, expected: 2},
+		{schema: 3, value: 1000, expected: 80},
+	}
+	for _, input := range inputs {
+		index := BucketIndex(input.schema, input.value)
+		require.Equal(t, input.expected, index, "schema=%d, value=%f", input.schema, input.value)
+		require.True(t, input.value <= UpperBound(input.schema, index))
+		require.True(t, input.value > UpperBound(input.schema, index-1))
+	}
+}
+
+func TestHistogramAdd(t *testing.T) {
+	h := Histogram{Schema: 0, ZeroThreshold: 0.001}
+	for _, v := range []float64{3, 1, 4, 0, -1.5, math.NaN(), 3.5} {
+		h.Add(v)
+	}
+
+	require.Equal(t, uint64(7), h.Count)
+	require.True(t, math.IsNaN(h.Sum))
+	require.Equal(t, uint64(1), h.ZeroCount)
+	require.Equal(t, []Bucket{{Index: 0, Count: 1}, {Index: 2, Count: 3}}, h.PositiveBuckets)
+	require.Equal(t, []Bucket{{Index: 1, Count: 1}}, h.NegativeBuckets)
+	require.NoError(t, h.Validate())
+}
+
+func TestHistogramMergeDownscales(t *testing.T) {
+	var (
+		fine   = Histogram{Schema: 1}
+		coarse = Histogram{Schema: 0}
+	)
+	for _, v := range []float64{1, 1.2, 1.6, 3, 5} {
+		fine.Add(v)
+	}
+	for _, v := range []float64{1.5, 6} {
+		coarse.Add(v)
+	}
+
+	fine.Merge(coarse)
+	require.Equal(t, int32(0), fine.Schema)
+	require.Equal(t, uint64(7), fine.Count)
+	require.InDelta(t, 19.3, fine.Sum, 1e-9)
+	require.Equal(t, []Bucket{
+		{Index: 0, Count: 1},
+		{Index: 1, Count: 3},
+		{Index: 2, Count: 1},
+		{Index: 3, Count: 2},
+	}, fine.PositiveBuckets)
+
+	// Merging is exact once the schemas match, regardless of order.
+	expected := Histogram{Schema: 0}
+	for _, v := range []float64{1, 1.2, 1.6, 3, 5, 1.5, 6} {
+		expected.Add(v)
+	}
+	require.Equal(t, expected.PositiveBuckets, fine.PositiveBuckets)
+}
+
+func TestHistogramMergeWidensZeroBucket(t *testing.T) {
+	var (
+		h     = Histogram{Schema: 0}
+		other = Histogram{Schema: 0, ZeroThreshold: 1}
+	)
+	for _, v := range []float64{0.25, 0.75, 3, -0.5} {
+		h.Add(v)
+	}
+	other.Add(0.5)
+
+	h.Merge(other)
+	require.Equal(t, 1.0, h.ZeroThreshold)
+	require.Equal(t, uint64(4), h.ZeroCount)
+	require.Equal(t, []Bucket{{Index: 2, Count: 1}}, h.PositiveBuckets)
+	require.Empty(t, h.NegativeBuckets)
+	require.NoError(t, h.Validate())
+}
+
+func TestHistogramLimitBuckets(t *testing.T) {
+	h := Histogram{Schema: MaxSchema}
+	for v := 1.0; v < 1e6; v *= 1.1 {
+		h.Add(v)
+	}
+	count := h.Count
+
+	h.LimitBuckets(10)
+	require.True(t, len(h.PositiveBuckets) <= 10)
+	require.True(t, h.Schema < MaxSchema)
+	require.Equal(t, count, h.Count)
+	require.NoError(t, h.Validate())
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	h := Histogram{Schema: 0}
+	require.True(t, math.IsNaN(h.Quantile(0.5)))
+
+	for i := 0; i < 100; i++ {
+		h.Add(3)
+	}
+	// All values are in the bucket (2, 4].
+	require.Equal(t, 2.0, h.Quantile(0))
+	require.Equal(t, 3.0, h.Quantile(0.5))
+	require.Equal(t, 4.0, h.Quantile(1))
+	require.True(t, math.IsInf(h.Quantile(1.5), 1))
+
+	h.Add(-3)
+	require.Equal(t, -4.0, h.Quantile(0))
+}
+
+func TestHistogramAppendCumulativeBuckets(t *testing.T) {
+	h := Histogram{Schema: 0}
+	for _, v := range []float64{0, 1.5, 3, 3, 10, math.NaN()} {
+		h.Add(v)
+	}
+
+	require.Equal(t, []CumulativeBucket{
+		{UpperBound: 0, Count: 1},
+		{UpperBound: 1, Count: 1},
+		{UpperBound: 2, Count: 2},
+		{UpperBound: 4, Count: 4},
+		{UpperBound: 8, Count: 4},
+		{UpperBound: 16, Count: 5},
+		{UpperBound: math.Inf(1), Count: 6},
+	}, h.AppendCumulativeBuckets(nil))
+}
+
+func TestHistogramFloatsRoundTrip(t *testing.T) {
+	var (
+		first  = Histogram{Schema: 2, ZeroThreshold: 0.5}
+		second = Histogram{Schema: -1}
+	)
+	for _, v := range []float64{0.1, 2, 3, -7} {
+		first.Add(v)
+	}
+	second.Add(100)
+
+	values := first.AppendFloats(nil)
+	values = second.AppendFloats(values)
+
+	decoded, values, err := ReadFloats(values)
+	require.NoError(t, err)
+	require.Equal(t, first, decoded)
+	decoded, values, err = ReadFloats(values)
+	require.NoError(t, err)
+	require.Equal(t, second, decoded)
+	require.Empty(t, values)
+
+	_, _, err = ReadFloats(first.AppendFloats(nil)[:numFloatsHeader+1])
+	require.Error(t, err)
+}
+
+func TestBucketSuffixRoundTrip(t *testing.T) {
+	suffix := AppendBucketSuffix(nil, []byte("bucket"), 0.25)
+	require.Equal(t, "bucket.le=0.25", string(suffix))
+
+	typeString, upperBound, ok := ParseBucketSuffix(suffix)
+	require.True(t, ok)
+	require.Equal(t, "bucket", string(typeString))
+	require.Equal(t, "0.25", string(upperBound))
+
+	suffix = AppendBucketSuffix(nil, nil, math.Inf(1))
+	_, upperBound, ok = ParseBucketSuffix(suffix)
+	require.True(t, ok)
+	require.Equal(t, "+Inf", string(upperBound))
+
+	_, _, ok = ParseBucketSuffix([]byte("p99"))
+	require.False(t, ok)
+}
@@ -25,6 +25,7 @@ import (
 	"fmt"
 
 	"github.com/m3db/m3/src/metrics/generated/proto/metricpb"
+	"github.com/m3db/m3/src/metrics/histogram"
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/metric/id"
@@ -36,6 +37,7 @@ var (
 	errNilCounterWithMetadatasProto    = errors.New("nil counter with metadatas proto message")
 	errNilBatchTimerWithMetadatasProto = errors.New("nil batch timer with metadatas proto message")
 	errNilGaugeWithMetadatasProto      = errors.New("nil gauge with metadatas proto message")
+	errNilHistogramWithMetadatasProto  = errors.New("nil histogram with metadatas proto message")
 )
 
 // Counter is a counter containing the counter ID and the counter value.
@@ -119,6 +121,60 @@ func (g *Gauge) FromProto(pb metricpb.Gauge) {
 	g.Value = pb.Value
 }
 
+// Histogram is a native histogram containing the histogram ID and the
+// sparse exponential buckets observed since it was last reported.
+type Histogram struct {
+	ID    id.RawID
+	Value histogram.Histogram
+}
+
+// ToUnion converts the histogram to a metric union.
+func (h Histogram) ToUnion() MetricUnion {
+	return MetricUnion{
+		Type:         metric.HistogramType,
+		ID:           h.ID,
+		HistogramVal: h.Value,
+	}
+}
+
+// ToProto converts the histogram to a protobuf message in place.
+func (h Histogram) ToProto(pb *metricpb.Histogram) {
+	pb.Id = h.ID
+	pb.Schema = h.Value.Schema
+	pb.ZeroThreshold = h.Value.ZeroThreshold
+	pb.ZeroCount = h.Value.ZeroCount
+	pb.Count = h.Value.Count
+	pb.Sum = h.Value.Sum
+	pb.PositiveBuckets = bucketsToProto(pb.PositiveBuckets[:0], h.Value.PositiveBuckets)
+	pb.NegativeBuckets = bucketsToProto(pb.NegativeBuckets[:0], h.Value.NegativeBuckets)
+}
+
+// FromProto converts the protobuf message to a histogram in place.
+func (h *Histogram) FromProto(pb metricpb.Histogram) {
+	h.ID = pb.Id
+	h.Value.Schema = pb.Schema
+	h.Value.ZeroThreshold = pb.ZeroThreshold
+	h.Value.ZeroCount = pb.ZeroCount
+	h.Value.Count = pb.Count
+	h.Value.Sum = pb.Sum
+	h.Value.PositiveBuckets = bucketsFromProto(h.Value.PositiveBuckets[:0], pb.PositiveBuckets)
+	h.Value.NegativeBuckets = bucketsFromProto(h.Value.NegativeBuckets[:0], pb.NegativeBuckets)
+}
+
+func bucketsToProto(dst []metricpb.HistogramBucket, src []histogram.Bucket) []metricpb.HistogramBucket {
+	for _, b := range src {
+		dst = append(dst, metricpb.HistogramBucket{Index: b.Index, Count: b.Count})
+	}
+	return dst
+}
+
+func bucketsFromProto(dst []histogram.Bucket, src []metricpb.HistogramBucket) []histogram.Bucket {
+	for _, b := range src {
+		dst = append(dst, histogram.Bucket{Index: b.Index, Count: b.Count})
+	}
+	return dst
+}
+
 // CounterWithPoliciesList is a counter with applicable policies list.
 type CounterWithPoliciesList struct {
 	Counter
@@ -218,6 +274,33 @@ func (gm *GaugeWithMetadatas) FromProto(pb *metricpb.GaugeWithMetadatas) error {
 	return nil
 }
 
+// HistogramWithMetadatas is a histogram with applicable metadatas.
+type HistogramWithMetadatas struct {
+	Histogram
+	metadata.StagedMetadatas
+}
+
+// ToProto converts the histogram with metadatas to a protobuf message in place.
+func (hm HistogramWithMetadatas) ToProto(pb *metricpb.HistogramWithMetadatas) error {
+	if err := hm.StagedMetadatas.ToProto(&pb.Metadatas); err != nil {
+		return err
+	}
+	hm.Histogram.ToProto(&pb.Histogram)
+	return nil
+}
+
+// FromProto converts the protobuf message to a histogram with metadatas in place.
+func (hm *HistogramWithMetadatas) FromProto(pb *metricpb.HistogramWithMetadatas) error {
+	if pb == nil {
+		return errNilHistogramWithMetadatasProto
+	}
+	if err := hm.StagedMetadatas.FromProto(pb.Metadatas); err != nil {
+		return err
+	}
+	hm.Histogram.FromProto(pb.Histogram)
+	return nil
+}
+
 // MetricUnion is a union of different types of metrics, only one of which is valid
 // at any given time. The actual type of the metric depends on the type field,
 // which determines which value field is valid. Note that if the timer values are
@@ -229,6 +312,7 @@ type MetricUnion struct {
 	CounterVal    int64
 	BatchTimerVal []float64
 	GaugeVal      float64
+	HistogramVal  histogram.Histogram
 	TimerValPool  pool.FloatsPool
 }
 
@@ -243,6 +327,8 @@ func (m *MetricUnion) String() string {
 		return fmt.Sprintf("{type:%s,id:%s,value:%v}", m.Type, m.ID.String(), m.BatchTimerVal)
 	case metric.GaugeType:
 		return fmt.Sprintf("{type:%s,id:%s,value:%f}", m.Type, m.ID.String(), m.GaugeVal)
+	case metric.HistogramType:
+		return fmt.Sprintf("{type:%s,id:%s,value:%+v}", m.Type, m.ID.String(), m.HistogramVal)
 	default:
 		return fmt.Sprintf(
 			"{type:%d,id:%s,counterVal:%d,batchTimerVal:%v,gaugeVal:%f}",
@@ -262,3 +348,6 @@ func (m *MetricUnion) BatchTimer() BatchTimer { return BatchTimer{ID: m.ID, Valu
 
 // Gauge returns the gauge metric.
 func (m *MetricUnion) Gauge() Gauge { return Gauge{ID: m.ID, Value: m.GaugeVal} }
+
+// Histogram returns the histogram metric.
+func (m *MetricUnion) Histogram() Histogram { return Histogram{ID: m.ID, Value: m.HistogramVal} }
@@ -30,6 +30,7 @@ import (
 	"github.com/m3db/m3/src/metrics/generated/proto/pipelinepb"
 	"github.com/m3db/m3/src/metrics/generated/proto/policypb"
 	"github.com/m3db/m3/src/metrics/generated/proto/transformationpb"
+	"github.com/m3db/m3/src/metrics/histogram"
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/pipeline"
@@ -69,6 +70,23 @@ var (
 		ID:       []byte("testGauge"),
 		GaugeVal: 45.28,
 	}
+	testHistogram = Histogram{
+		ID: []byte("testHistogram"),
+		Value: histogram.Histogram{
+			Schema:          3,
+			ZeroThreshold:   1e-6,
+			ZeroCount:       2,
+			Count:           10,
+			Sum:             42.5,
+			PositiveBuckets: []histogram.Bucket{{Index: 1, Count: 3}, {Index: 7, Count: 4}},
+			NegativeBuckets: []histogram.Bucket{{Index: -2, Count: 1}},
+		},
+	}
+	testHistogramUnion = MetricUnion{
+		Type:         metric.HistogramType,
+		ID:           []byte("testHistogram"),
+		HistogramVal: testHistogram.Value,
+	}
 	testMetadatas = metadata.StagedMetadatas{
 		{
 			CutoverNanos: 1234,
@@ -163,6 +181,10 @@ var (
 		Gauge:           testGauge,
 		StagedMetadatas: testMetadatas,
 	}
+	testHistogramWithMetadatas = HistogramWithMetadatas{
+		Histogram:       testHistogram,
+		StagedMetadatas: testMetadatas,
+	}
 	testCounterProto = metricpb.Counter{
 		Id:    []byte("testCounter"),
 		Value: 1234,
@@ -175,6 +197,16 @@ var (
 		Id:    []byte("testGauge"),
 		Value: 45.28,
 	}
+	testHistogramProto = metricpb.Histogram{
+		Id:              []byte("testHistogram"),
+		Schema:          3,
+		ZeroThreshold:   1e-6,
+		ZeroCount:       2,
+		Count:           10,
+		Sum:             42.5,
+		PositiveBuckets: []metricpb.HistogramBucket{{Index: 1, Count: 3}, {Index: 7, Count: 4}},
+		NegativeBuckets: []metricpb.HistogramBucket{{Index: -2, Count: 1}},
+	}
 	testMetadatasProto = metricpb.StagedMetadatas{
 		Metadatas: []metricpb.StagedMetadata{
 			{
@@ -300,6 +332,10 @@ var (
 		Gauge:     testGaugeProto,
 		Metadatas: testMetadatasProto,
 	}
+	testHistogramWithMetadatasProto = metricpb.HistogramWithMetadatas{
+		Histogram: testHistogramProto,
+		Metadatas: testMetadatasProto,
+	}
 )
 
 func TestCounterToUnion(t *testing.T) {
@@ -380,6 +416,37 @@ func TestGaugeRoundTrip(t *testing.T) {
 	require.Equal(t, testGauge, c)
 }
 
+func TestHistogramToUnion(t *testing.T) {
+	require.Equal(t, testHistogramUnion, testHistogram.ToUnion())
+}
+
+func TestHistogramToProto(t *testing.T) {
+	var pb metricpb.Histogram
+	testHistogram.ToProto(&pb)
+	require.Equal(t, testHistogramProto, pb)
+}
+
+func TestHistogramFromProto(t *testing.T) {
+	var h Histogram
+	h.FromProto(testHistogramProto)
+	require.Equal(t, testHistogram, h)
+}
+
+func TestHistogramFromProtoReusesBuckets(t *testing.T) {
+	h := Histogram{
+		Value: histogram.Histogram{
+			PositiveBuckets: make([]histogram.Bucket, 0, 8),
+		},
+	}
+	h.FromProto(testHistogramProto)
+	require.Equal(t, testHistogram, h)
+	require.Equal(t, 8, cap(h.Value.PositiveBuckets))
+
+	h.FromProto(metricpb.Histogram{Id: []byte("empty")})
+	require.Equal(t, 0, len(h.Value.PositiveBuckets))
+	require.Equal(t, 0, len(h.Value.NegativeBuckets))
+}
+
 func TestCounterWithMetadatasToProto(t *testing.T) {
 	var pb metricpb.CounterWithMetadatas
 	require.NoError(t, testCounterWithMetadatas.ToProto(&pb))
@@ -514,3 +581,30 @@ func TestGaugeWithMetadatasRoundTrip(t *testing.T) {
 	require.NoError(t, g.FromProto(&pb))
 	require.Equal(t, testGaugeWithMetadatas, g)
 }
+
+func TestHistogramWithMetadatasToProto(t *testing.T) {
+	var pb metricpb.HistogramWithMetadatas
+	require.NoError(t, testHistogramWithMetadatas.ToProto(&pb))
+	require.Equal(t, testHistogramWithMetadatasProto, pb)
+}
+
+func TestHistogramWithMetadatasFromProto(t *testing.T) {
+	var h HistogramWithMetadatas
+	require.NoError(t, h.FromProto(&testHistogramWithMetadatasProto))
+	require.Equal(t, testHistogramWithMetadatas, h)
+}
+
+func TestHistogramWithMetadatasFromProtoNilProto(t *testing.T) {
+	var h HistogramWithMetadatas
+	require.Equal(t, errNilHistogramWithMetadatasProto, h.FromProto(nil))
+}
+
+func TestHistogramWithMetadatasRoundTrip(t *testing.T) {
+	var (
+		pb metricpb.HistogramWithMetadatas
+		h  HistogramWithMetadatas
+	)
+	require.NoError(t, testHistogramWithMetadatas.ToProto(&pb))
+	require.NoError(t, h.FromProto(&pb))
+	require.Equal(t, testHistogramWithMetadatas, h)
+}
@@ -279,6 +279,7 @@ func (as *activeRuleSet) mappingsForNonRollupID(
 		pipeline := metadata.PipelineMetadata{
 			AggregationID:   snapshot.aggregationID,
 			StoragePolicies: snapshot.storagePolicies.Clone(),
+			Pipeline:        snapshot.transformationPipeline(),
 			DropPolicy:      snapshot.dropPolicy,
 			Tags:            snapshot.tags,
 			GraphitePrefix:  snapshot.graphitePrefix,
@@ -1375,6 +1375,58 @@ func TestActiveRuleSetForwardMatchWithRollupRules(t *testing.T) {
 	}
 }
 
+func TestActiveRuleSetMappingRuleTransformations(t *testing.T) {
+	filter, err := filters.NewTagsFilter(
+		filters.TagFilterValueMap{"mtagName1": filters.FilterValue{Pattern: "mtagValue1"}},
+		filters.Conjunction,
+		testTagsFilterOptions(),
+	)
+	require.NoError(t, err)
+	mappingRules := []*mappingRule{
+		{
+			uuid: "transformedMappingRule",
+			snapshots: []*mappingRuleSnapshot{
+				{
+					name:          "transformedMappingRule.snapshot1",
+					cutoverNanos:  10000,
+					filter:        filter,
+					aggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+					storagePolicies: policy.StoragePolicies{
+						policy.NewStoragePolicy(10*time.Second, xtime.Second, 24*time.Hour),
+					},
+					transformations: []transformation.Type{transformation.DeltaToCumulative},
+				},
+			},
+		},
+	}
+	as := newActiveRuleSet(
+		0,
+		mappingRules,
+		nil,
+		testTagsFilterOptions(),
+		mockNewID,
+		nil,
+	)
+
+	res := as.mappingsForNonRollupID([]byte("mtagName1=mtagValue1"), 20000)
+	expected := []metadata.PipelineMetadata{
+		{
+			AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+			StoragePolicies: policy.StoragePolicies{
+				policy.NewStoragePolicy(10*time.Second, xtime.Second, 24*time.Hour),
+			},
+			Pipeline: applied.NewPipeline([]applied.OpUnion{
+				{
+					Type:           pipeline.TransformationOpType,
+					Transformation: pipeline.TransformationOp{Type: transformation.DeltaToCumulative},
+				},
+			}),
+		},
+	}
+	require.Equal(t, int64(10000), res.forExistingID.cutoverNanos)
+	require.True(t, cmp.Equal(expected, res.forExistingID.pipelines, testStagedMetadatasCmptOpts...))
+}
+
 func TestActiveRuleSetForwardMatchWithMappingRulesAndRollupRules(t *testing.T) {
 	inputs := []testMatchInput{
 		{
@@ -32,9 +32,13 @@ import (
 	"github.com/m3db/m3/src/metrics/generated/proto/metricpb"
 	"github.com/m3db/m3/src/metrics/generated/proto/policypb"
 	"github.com/m3db/m3/src/metrics/generated/proto/rulepb"
+	"github.com/m3db/m3/src/metrics/generated/proto/transformationpb"
 	"github.com/m3db/m3/src/metrics/metric"
+	mpipeline "github.com/m3db/m3/src/metrics/pipeline"
+	"github.com/m3db/m3/src/metrics/pipeline/applied"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/metrics/transformation"
 	"github.com/m3db/m3/src/query/models"
 
 	"github.com/pborman/uuid"
@@ -67,6 +71,7 @@ type mappingRuleSnapshot struct {
 	storagePolicies    policy.StoragePolicies
 	dropPolicy         policy.DropPolicy
 	tags               []models.Tag
+	transformations    []transformation.Type
 	graphitePrefix     [][]byte
 	lastUpdatedAtNanos int64
 	lastUpdatedBy      string
@@ -127,6 +132,16 @@ func newMappingRuleSnapshotFromProto(
 		return nil, err
 	}
 
+	var transformations []transformation.Type
+	if len(r.Transformations) > 0 {
+		transformations = make([]transformation.Type, len(r.Transformations))
+		for i, pb := range r.Transformations {
+			if err := transformations[i].FromProto(pb); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return newMappingRuleSnapshotFromFieldsInternal(
 		r.Name,
 		r.Tombstoned,
@@ -137,6 +152,7 @@ func newMappingRuleSnapshotFromProto(
 		storagePolicies,
 		policy.DropPolicy(r.DropPolicy),
 		models.TagsFromProto(r.Tags),
+		transformations,
 		r.LastUpdatedAtNanos,
 		r.LastUpdatedBy,
 	), nil
@@ -151,6 +167,7 @@ func newMappingRuleSnapshotFromFields(
 	storagePolicies policy.StoragePolicies,
 	dropPolicy policy.DropPolicy,
 	tags []models.Tag,
+	transformations []transformation.Type,
 	lastUpdatedAtNanos int64,
 	lastUpdatedBy string,
 ) (*mappingRuleSnapshot, error) {
@@ -167,6 +184,7 @@ func newMappingRuleSnapshotFromFields(
 		storagePolicies,
 		dropPolicy,
 		tags,
+		transformations,
 		lastUpdatedAtNanos,
 		lastUpdatedBy,
 	), nil
@@ -184,6 +202,7 @@ func newMappingRuleSnapshotFromFieldsInternal(
 	storagePolicies policy.StoragePolicies,
 	dropPolicy policy.DropPolicy,
 	tags []models.Tag,
+	transformations []transformation.Type,
 	lastUpdatedAtNanos int64,
 	lastUpdatedBy string,
 ) *mappingRuleSnapshot {
@@ -206,6 +225,7 @@ func newMappingRuleSnapshotFromFieldsInternal(
 		storagePolicies:    storagePolicies,
 		dropPolicy:         dropPolicy,
 		tags:               tags,
+		transformations:    transformations,
 		graphitePrefix:     graphitePrefix,
 		lastUpdatedAtNanos: lastUpdatedAtNanos,
 		lastUpdatedBy:      lastUpdatedBy,
@@ -219,6 +239,11 @@ func (mrs *mappingRuleSnapshot) clone() mappingRuleSnapshot {
 	}
 	tags := make([]models.Tag, len(mrs.tags))
 	copy(tags, mrs.tags)
+	var transformations []transformation.Type
+	if len(mrs.transformations) > 0 {
+		transformations = make([]transformation.Type, len(mrs.transformations))
+		copy(transformations, mrs.transformations)
+	}
 	return mappingRuleSnapshot{
 		name:               mrs.name,
 		tombstoned:         mrs.tombstoned,
@@ -229,11 +254,28 @@ func (mrs *mappingRuleSnapshot) clone() mappingRuleSnapshot {
 		storagePolicies:    mrs.storagePolicies.Clone(),
 		dropPolicy:         mrs.dropPolicy,
 		tags:               mrs.tags,
+		transformations:    transformations,
 		lastUpdatedAtNanos: mrs.lastUpdatedAtNanos,
 		lastUpdatedBy:      mrs.lastUpdatedBy,
 	}
 }
 
+// transformationPipeline returns the transformations applied to the metrics
+// matching the snapshot as a pipeline, which is empty if there are none.
+func (mrs *mappingRuleSnapshot) transformationPipeline() applied.Pipeline {
+	if len(mrs.transformations) == 0 {
+		return applied.DefaultPipeline
+	}
+	ops := make([]applied.OpUnion, 0, len(mrs.transformations))
+	for _, t := range mrs.transformations {
+		ops = append(ops, applied.OpUnion{
+			Type:           mpipeline.TransformationOpType,
+			Transformation: mpipeline.TransformationOp{Type: t},
+		})
+	}
+	return applied.NewPipeline(ops)
+}
+
 // proto returns the given MappingRuleSnapshot in protobuf form.
 func (mrs *mappingRuleSnapshot) proto() (*rulepb.MappingRuleSnapshot, error) {
 	aggTypes, err := mrs.aggregationID.Types()
@@ -252,6 +294,15 @@ func (mrs *mappingRuleSnapshot) proto() (*rulepb.MappingRuleSnapshot, error) {
 	for _, tag := range mrs.tags {
 		tags = append(tags, tag.ToProto())
 	}
+	var transformations []transformationpb.TransformationType
+	if len(mrs.transformations) > 0 {
+		transformations = make([]transformationpb.TransformationType, len(mrs.transformations))
+		for i, t := range mrs.transformations {
+			if err := t.ToProto(&transformations[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return &rulepb.MappingRuleSnapshot{
 		Name:               mrs.name,
 		Tombstoned:         mrs.tombstoned,
@@ -263,6 +314,7 @@ func (mrs *mappingRuleSnapshot) proto() (*rulepb.MappingRuleSnapshot, error) {
 		StoragePolicies:    storagePolicies,
 		DropPolicy:         policypb.DropPolicy(mrs.dropPolicy),
 		Tags:               tags,
+		Transformations:    transformations,
 	}, nil
 }
 
@@ -374,6 +426,7 @@ func (mc *mappingRule) addSnapshot(
 	storagePolicies policy.StoragePolicies,
 	dropPolicy policy.DropPolicy,
 	tags []models.Tag,
+	transformations []transformation.Type,
 	meta UpdateMetadata,
 ) error {
 	snapshot, err := newMappingRuleSnapshotFromFields(
@@ -385,6 +438,7 @@ func (mc *mappingRule) addSnapshot(
 		storagePolicies,
 		dropPolicy,
 		tags,
+		transformations,
 		meta.updatedAtNanos,
 		meta.updatedBy,
 	)
@@ -415,6 +469,7 @@ func (mc *mappingRule) markTombstoned(meta UpdateMetadata) error {
 	snapshot.aggregationID = aggregation.DefaultID
 	snapshot.storagePolicies = nil
 	snapshot.dropPolicy = 0
+	snapshot.transformations = nil
 	mc.snapshots = append(mc.snapshots, &snapshot)
 	return nil
 }
@@ -426,6 +481,7 @@ func (mc *mappingRule) revive(
 	storagePolicies policy.StoragePolicies,
 	dropPolicy policy.DropPolicy,
 	tags []models.Tag,
+	transformations []transformation.Type,
 	meta UpdateMetadata,
 ) error {
 	n, err := mc.name()
@@ -436,7 +492,7 @@ func (mc *mappingRule) revive(
 		return merrors.NewInvalidInputError(fmt.Sprintf("%s is not tombstoned", n))
 	}
 	return mc.addSnapshot(name, rawFilter, aggregationID, storagePolicies,
-		dropPolicy, tags, meta)
+		dropPolicy, tags, transformations, meta)
 }
 
 func (mc *mappingRule) activeIndex(timeNanos int64) int {
@@ -476,6 +532,7 @@ func (mc *mappingRule) mappingRuleView(snapshotIdx int) (view.MappingRule, error
 		Filter:              mrs.rawFilter,
 		AggregationID:       mrs.aggregationID,
 		StoragePolicies:     mrs.storagePolicies,
+		Transformations:     mrs.transformations,
 		LastUpdatedBy:       mrs.lastUpdatedBy,
 		LastUpdatedAtMillis: mrs.lastUpdatedAtNanos / nanosPerMilli,
 	}, nil
@@ -32,8 +32,12 @@ import (
 	"github.com/m3db/m3/src/metrics/generated/proto/metricpb"
 	"github.com/m3db/m3/src/metrics/generated/proto/policypb"
 	"github.com/m3db/m3/src/metrics/generated/proto/rulepb"
+	"github.com/m3db/m3/src/metrics/generated/proto/transformationpb"
+	"github.com/m3db/m3/src/metrics/pipeline"
+	"github.com/m3db/m3/src/metrics/pipeline/applied"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/metrics/transformation"
 	"github.com/m3db/m3/src/query/models"
 	xtime "github.com/m3db/m3/src/x/time"
 
@@ -463,6 +467,7 @@ func TestNewMappingRuleSnapshotFromFields(t *testing.T) {
 		testMappingRuleSnapshot3.storagePolicies,
 		testMappingRuleSnapshot3.dropPolicy,
 		testMappingRuleSnapshot3.tags,
+		testMappingRuleSnapshot3.transformations,
 		testMappingRuleSnapshot3.lastUpdatedAtNanos,
 		testMappingRuleSnapshot3.lastUpdatedBy,
 	)
@@ -487,6 +492,7 @@ func TestNewMappingRuleSnapshotFromFieldsValidationError(t *testing.T) {
 			nil,
 			policy.DropNone,
 			nil,
+			nil,
 			1234,
 			"test_user",
 		)
@@ -512,6 +518,48 @@ func TestMappingRuleSnapshotProto(t *testing.T) {
 	}
 }
 
+func TestMappingRuleSnapshotTransformationsRoundTripProto(t *testing.T) {
+	snapshot := testMappingRuleSnapshot3.clone()
+	snapshot.transformations = []transformation.Type{
+		transformation.CumulativeToDelta,
+		transformation.Absolute,
+	}
+
+	pb, err := snapshot.proto()
+	require.NoError(t, err)
+	require.Equal(t, []transformationpb.TransformationType{
+		transformationpb.TransformationType_CUMULATIVE_TO_DELTA,
+		transformationpb.TransformationType_ABSOLUTE,
+	}, pb.Transformations)
+
+	res, err := newMappingRuleSnapshotFromProto(pb, testTagsFilterOptions())
+	require.NoError(t, err)
+	require.Equal(t, snapshot.transformations, res.transformations)
+
+	expected := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type:           pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{Type: transformation.CumulativeToDelta},
+		},
+		{
+			Type:           pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{Type: transformation.Absolute},
+		},
+	})
+	require.True(t, expected.Equal(res.transformationPipeline()))
+	require.True(t, testMappingRuleSnapshot3.transformationPipeline().IsEmpty())
+}
+
+func TestNewMappingRuleSnapshotFromProtoInvalidTransformation(t *testing.T) {
+	pb, err := testMappingRuleSnapshot3.proto()
+	require.NoError(t, err)
+	pb.Transformations = []transformationpb.TransformationType{
+		transformationpb.TransformationType_UNKNOWN,
+	}
+	_, err = newMappingRuleSnapshotFromProto(pb, testTagsFilterOptions())
+	require.Error(t, err)
+}
+
 func TestNewMappingRuleFromProtoNilProto(t *testing.T) {
 	_, err := newMappingRuleFromProto(nil, testTagsFilterOptions())
 	require.Equal(t, errNilMappingRuleProto, err)
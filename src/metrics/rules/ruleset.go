@@ -355,6 +355,7 @@ func (rs *ruleSet) AddMappingRule(mrv view.MappingRule, meta UpdateMetadata) (st
 			mrv.StoragePolicies,
 			mrv.DropPolicy,
 			mrv.Tags,
+			mrv.Transformations,
 			meta,
 		); err != nil {
 			return "", xerrors.Wrap(err, fmt.Sprintf(ruleActionErrorFmt, "add", mrv.Name))
@@ -368,6 +369,7 @@ func (rs *ruleSet) AddMappingRule(mrv view.MappingRule, meta UpdateMetadata) (st
 			mrv.StoragePolicies,
 			mrv.DropPolicy,
 			mrv.Tags,
+			mrv.Transformations,
 			meta,
 		); err != nil {
 			return "", xerrors.Wrap(err, fmt.Sprintf(ruleActionErrorFmt, "revive", mrv.Name))
@@ -389,6 +391,7 @@ func (rs *ruleSet) UpdateMappingRule(mrv view.MappingRule, meta UpdateMetadata)
 		mrv.StoragePolicies,
 		mrv.DropPolicy,
 		mrv.Tags,
+		mrv.Transformations,
 		meta,
 	); err != nil {
 		return xerrors.Wrap(err, fmt.Sprintf(ruleActionErrorFmt, "update", mrv.Name))
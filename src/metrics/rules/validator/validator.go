@@ -33,6 +33,7 @@ import (
 	"github.com/m3db/m3/src/metrics/rules"
 	"github.com/m3db/m3/src/metrics/rules/validator/namespace"
 	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/metrics/transformation"
 )
 
 var (
@@ -144,6 +145,30 @@ func (v *validator) validateMappingRules(mrv []view.MappingRule) error {
 			if len(rule.StoragePolicies) != 0 {
 				return fmt.Errorf("mapping rule '%s' has a drop policy error: cannot specify storage policies", rule.Name)
 			}
+			if len(rule.Transformations) != 0 {
+				return fmt.Errorf("mapping rule '%s' has a drop policy error: cannot specify transformations", rule.Name)
+			}
+		}
+
+		// Validate the transformations.
+		if err := v.validateMappingRuleTransformations(rule.Transformations); err != nil {
+			return fmt.Errorf("mapping rule '%s' has invalid transformations %v: %v", rule.Name, rule.Transformations, err)
+		}
+	}
+	return nil
+}
+
+func (v *validator) validateMappingRuleTransformations(transformations []transformation.Type) error {
+	var transformationDerivativeOrder int
+	for i, transformationType := range transformations {
+		if err := validateTransformationOp(mpipeline.TransformationOp{Type: transformationType}); err != nil {
+			return fmt.Errorf("invalid transformation operation at index %d: %v", i, err)
+		}
+		if transformationType.IsBinaryTransform() {
+			transformationDerivativeOrder++
+			if transformationDerivativeOrder > v.opts.MaxTransformationDerivativeOrder() {
+				return fmt.Errorf("transformation derivative order is %d higher than supported %d", transformationDerivativeOrder, v.opts.MaxTransformationDerivativeOrder())
+			}
 		}
 	}
 	return nil
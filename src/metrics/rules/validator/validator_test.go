@@ -338,6 +338,74 @@ func TestValidatorValidateMappingRule(t *testing.T) {
 	require.NoError(t, validator.ValidateSnapshot(view))
 }
 
+func TestValidatorValidateMappingRuleTransformations(t *testing.T) {
+	view := view.RuleSet{
+		MappingRules: []view.MappingRule{
+			{
+				Name:            "snapshot1",
+				Filter:          testTypeTag + ":" + testCounterType,
+				StoragePolicies: testStoragePolicies(),
+				Transformations: []transformation.Type{transformation.CumulativeToDelta},
+			},
+		},
+	}
+
+	validator := NewValidator(testValidatorOptions())
+	require.NoError(t, validator.ValidateSnapshot(view))
+}
+
+func TestValidatorValidateMappingRuleInvalidTransformations(t *testing.T) {
+	inputs := []struct {
+		transformations []transformation.Type
+		expectedErr     string
+	}{
+		{
+			transformations: []transformation.Type{transformation.UnknownType},
+			expectedErr:     "invalid transformation type",
+		},
+		{
+			transformations: []transformation.Type{transformation.CumulativeToDelta, transformation.PerSecond},
+			expectedErr:     "transformation derivative order is 2 higher than supported 1",
+		},
+	}
+
+	for _, input := range inputs {
+		view := view.RuleSet{
+			MappingRules: []view.MappingRule{
+				{
+					Name:            "snapshot1",
+					Filter:          testTypeTag + ":" + testCounterType,
+					StoragePolicies: testStoragePolicies(),
+					Transformations: input.transformations,
+				},
+			},
+		}
+
+		validator := NewValidator(testValidatorOptions())
+		err := validator.ValidateSnapshot(view)
+		require.Error(t, err)
+		require.True(t, strings.Contains(err.Error(), input.expectedErr), err.Error())
+	}
+}
+
+func TestValidatorValidateMappingRuleDropPolicyWithTransformations(t *testing.T) {
+	view := view.RuleSet{
+		MappingRules: []view.MappingRule{
+			{
+				Name:            "snapshot1",
+				Filter:          "tag1:value1",
+				DropPolicy:      policy.DropMust,
+				Transformations: []transformation.Type{transformation.DeltaToCumulative},
+			},
+		},
+	}
+
+	validator := NewValidator(testValidatorOptions())
+	err := validator.ValidateSnapshot(view)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "cannot specify transformations"))
+}
+
 func TestValidatorValidateDuplicateRollupRules(t *testing.T) {
 	view := view.RuleSet{
 		RollupRules: []view.RollupRule{
@@ -23,6 +23,7 @@ package view
 import (
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/metrics/transformation"
 	"github.com/m3db/m3/src/query/models"
 )
 
@@ -37,6 +38,7 @@ type MappingRule struct {
 	StoragePolicies     policy.StoragePolicies `json:"storagePolicies"`
 	DropPolicy          policy.DropPolicy      `json:"dropPolicy"`
 	Tags                []models.Tag           `json:"tags"`
+	Transformations     []transformation.Type  `json:"transformations,omitempty"`
 	LastUpdatedBy       string                 `json:"lastUpdatedBy"`
 	LastUpdatedAtMillis int64                  `json:"lastUpdatedAtMillis"`
 }
@@ -54,7 +56,20 @@ func (m *MappingRule) Equal(other *MappingRule) bool {
 		m.Filter == other.Filter &&
 		m.AggregationID.Equal(other.AggregationID) &&
 		m.StoragePolicies.Equal(other.StoragePolicies) &&
-		m.DropPolicy == other.DropPolicy
+		m.DropPolicy == other.DropPolicy &&
+		transformationTypesEqual(m.Transformations, other.Transformations)
+}
+
+func transformationTypesEqual(a, b []transformation.Type) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 // MappingRules belonging to a ruleset indexed by uuid.
@@ -26,6 +26,7 @@ import (
 
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/metrics/transformation"
 	xtime "github.com/m3db/m3/src/x/time"
 
 	"github.com/stretchr/testify/require"
@@ -127,6 +128,26 @@ func TestMappingRuleNotEqual(t *testing.T) {
 			},
 			DropPolicy: policy.DropIfOnlyMatch,
 		},
+		{
+			ID:            "mr",
+			Name:          "foo",
+			Filter:        "filter",
+			AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+			StoragePolicies: policy.StoragePolicies{
+				policy.NewStoragePolicy(10*time.Second, xtime.Second, time.Hour),
+			},
+			Transformations: []transformation.Type{transformation.DeltaToCumulative},
+		},
+		{
+			ID:            "mr",
+			Name:          "foo",
+			Filter:        "filter",
+			AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+			StoragePolicies: policy.StoragePolicies{
+				policy.NewStoragePolicy(10*time.Second, xtime.Second, time.Hour),
+			},
+			Transformations: []transformation.Type{transformation.CumulativeToDelta},
+		},
 	}
 	for i := 0; i < len(rules); i++ {
 		for j := i + 1; j < len(rules); j++ {
@@ -34,6 +34,8 @@ var (
 	// taking reference to it each time when converting to iface).
 	transformPerSecondFn = BinaryTransformFn(perSecond)
 	transformIncreaseFn  = BinaryTransformFn(increase)
+
+	transformCumulativeToDeltaFn = BinaryTransformFn(cumulativeToDelta)
 )
 
 func transformPerSecond() BinaryTransform {
@@ -78,3 +80,24 @@ func increase(prev, curr Datapoint) Datapoint {
 	}
 	return Datapoint{TimeNanos: curr.TimeNanos, Value: diff}
 }
+
+func transformCumulativeToDelta() BinaryTransform {
+	return transformCumulativeToDeltaFn
+}
+
+// cumulativeToDelta converts a cumulative series into a series of deltas. A
+// value lower than the previous value is treated as a counter reset, in which
+// case the counter restarted from zero and the delta is the value itself.
+// Note:
+// * It skips NaN values, so the first value of a series has no delta.
+// * It assumes the timestamps are monotonically increasing, if not an empty
+//   datapoint is returned.
+func cumulativeToDelta(prev, curr Datapoint) Datapoint {
+	if prev.TimeNanos >= curr.TimeNanos || math.IsNaN(prev.Value) || math.IsNaN(curr.Value) {
+		return emptyDatapoint
+	}
+	if curr.Value < prev.Value {
+		return Datapoint{TimeNanos: curr.TimeNanos, Value: curr.Value}
+	}
+	return Datapoint{TimeNanos: curr.TimeNanos, Value: curr.Value - prev.Value}
+}
@@ -38,6 +38,8 @@ const (
 	Increase
 	Add
 	Reset
+	DeltaToCumulative
+	CumulativeToDelta
 )
 
 // IsValid checks if the transformation type is valid.
@@ -165,6 +167,10 @@ func (t Type) ToProto(pb *transformationpb.TransformationType) error {
 		*pb = transformationpb.TransformationType_ADD
 	case Reset:
 		*pb = transformationpb.TransformationType_RESET
+	case DeltaToCumulative:
+		*pb = transformationpb.TransformationType_DELTA_TO_CUMULATIVE
+	case CumulativeToDelta:
+		*pb = transformationpb.TransformationType_CUMULATIVE_TO_DELTA
 	default:
 		return fmt.Errorf("unknown transformation type: %v", t)
 	}
@@ -184,6 +190,10 @@ func (t *Type) FromProto(pb transformationpb.TransformationType) error {
 		*t = Add
 	case transformationpb.TransformationType_RESET:
 		*t = Reset
+	case transformationpb.TransformationType_DELTA_TO_CUMULATIVE:
+		*t = DeltaToCumulative
+	case transformationpb.TransformationType_CUMULATIVE_TO_DELTA:
+		*t = CumulativeToDelta
 	default:
 		return fmt.Errorf("unknown transformation type in proto: %v", pb)
 	}
@@ -272,12 +282,14 @@ func (o Op) UnaryMultiOutputTransform() (UnaryMultiOutputTransform, bool) {
 
 var (
 	unaryTransforms = map[Type]func() UnaryTransform{
-		Absolute: transformAbsolute,
-		Add:      transformAdd,
+		Absolute:          transformAbsolute,
+		Add:               transformAdd,
+		DeltaToCumulative: transformDeltaToCumulative,
 	}
 	binaryTransforms = map[Type]func() BinaryTransform{
-		PerSecond: transformPerSecond,
-		Increase:  transformIncrease,
+		PerSecond:         transformPerSecond,
+		Increase:          transformIncrease,
+		CumulativeToDelta: transformCumulativeToDelta,
 	}
 	unaryMultiOutputTransforms = map[Type]func() UnaryMultiOutputTransform{
 		Reset: transformReset,
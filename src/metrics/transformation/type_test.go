@@ -44,8 +44,10 @@ func TestIsUnaryTransform(t *testing.T) {
 		expected bool
 	}{
 		{typ: Absolute, expected: true},
+		{typ: DeltaToCumulative, expected: true},
 		{typ: UnknownType, expected: false},
 		{typ: PerSecond, expected: false},
+		{typ: CumulativeToDelta, expected: false},
 		{typ: Type(10000), expected: false},
 	}
 
@@ -60,8 +62,10 @@ func TestIsBinaryTransform(t *testing.T) {
 		expected bool
 	}{
 		{typ: PerSecond, expected: true},
+		{typ: CumulativeToDelta, expected: true},
 		{typ: UnknownType, expected: false},
 		{typ: Absolute, expected: false},
+		{typ: DeltaToCumulative, expected: false},
 		{typ: Type(10000), expected: false},
 	}
 
@@ -182,6 +186,8 @@ func TestTypeString(t *testing.T) {
 		{typ: UnknownType, expected: "UnknownType"},
 		{typ: Absolute, expected: "Absolute"},
 		{typ: PerSecond, expected: "PerSecond"},
+		{typ: DeltaToCumulative, expected: "DeltaToCumulative"},
+		{typ: CumulativeToDelta, expected: "CumulativeToDelta"},
 		{typ: Type(1000), expected: "Type(1000)"},
 	}
 
@@ -222,6 +228,18 @@ func TestTypeRoundTripProto(t *testing.T) {
 	require.Equal(t, testType, res)
 }
 
+func TestTypeRoundTripProtoTemporalityConversions(t *testing.T) {
+	for _, typ := range []Type{DeltaToCumulative, CumulativeToDelta} {
+		var (
+			pb  transformationpb.TransformationType
+			res Type
+		)
+		require.NoError(t, typ.ToProto(&pb))
+		require.NoError(t, res.FromProto(pb))
+		require.Equal(t, typ, res)
+	}
+}
+
 func TestTypeMarshalling(t *testing.T) {
 	cases := []struct {
 		Example      Type
@@ -230,6 +248,9 @@ func TestTypeMarshalling(t *testing.T) {
 	}{{
 		Example: Absolute,
 		Text:    "Absolute",
+	}, {
+		Example: CumulativeToDelta,
+		Text:    "CumulativeToDelta",
 	}}
 
 	t.Run("roundtrips", func(t *testing.T) {
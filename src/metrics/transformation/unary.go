@@ -52,3 +52,19 @@ func transformAdd() UnaryTransform {
 		return Datapoint{TimeNanos: dp.TimeNanos, Value: curr}
 	})
 }
+
+// transformDeltaToCumulative converts a series of deltas into a cumulative
+// series by keeping a running total of the deltas, so delta counters can be
+// stored alongside cumulative counters. Unlike add it is never rewritten to
+// reset since its output is meant to be cumulative.
+// Note:
+// * It treats NaN as zero value, i.e. 42 + NaN = 42.
+func transformDeltaToCumulative() UnaryTransform {
+	var total float64
+	return UnaryTransformFn(func(dp Datapoint) Datapoint {
+		if !math.IsNaN(dp.Value) {
+			total += dp.Value
+		}
+		return Datapoint{TimeNanos: dp.TimeNanos, Value: total}
+	})
+}
@@ -21,6 +21,7 @@
 package transformation
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -49,3 +50,36 @@ func TestAbsolute(t *testing.T) {
 		require.Equal(t, input.expected, absolute(input.dp))
 	}
 }
+
+func TestDeltaToCumulative(t *testing.T) {
+	inputs := []struct {
+		dp       Datapoint
+		expected Datapoint
+	}{
+		{
+			dp:       Datapoint{TimeNanos: 1000, Value: 3},
+			expected: Datapoint{TimeNanos: 1000, Value: 3},
+		},
+		{
+			dp:       Datapoint{TimeNanos: 2000, Value: math.NaN()},
+			expected: Datapoint{TimeNanos: 2000, Value: 3},
+		},
+		{
+			dp:       Datapoint{TimeNanos: 3000, Value: 4.5},
+			expected: Datapoint{TimeNanos: 3000, Value: 7.5},
+		},
+		{
+			dp:       Datapoint{TimeNanos: 4000, Value: -2},
+			expected: Datapoint{TimeNanos: 4000, Value: 5.5},
+		},
+	}
+
+	tf := transformDeltaToCumulative()
+	for _, input := range inputs {
+		require.Equal(t, input.expected, tf.Evaluate(input.dp))
+	}
+
+	// Each transform keeps its own running total.
+	require.Equal(t, Datapoint{TimeNanos: 5000, Value: 1},
+		transformDeltaToCumulative().Evaluate(Datapoint{TimeNanos: 5000, Value: 1}))
+}
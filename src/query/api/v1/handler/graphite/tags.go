@@ -0,0 +1,320 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	graphitestorage "github.com/m3db/m3/src/query/graphite/storage"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// TagsURL is the url for listing graphite tags.
+	TagsURL = handler.RoutePrefixV1 + "/graphite/tags"
+
+	// TagsAutoCompleteTagsURL is the url for auto completing graphite tag
+	// names.
+	TagsAutoCompleteTagsURL = TagsURL + "/autoComplete/tags"
+
+	// TagsAutoCompleteValuesURL is the url for auto completing graphite tag
+	// values.
+	TagsAutoCompleteValuesURL = TagsURL + "/autoComplete/values"
+
+	defaultTagsAutoCompleteLimit = 100
+)
+
+var (
+	// TagsHTTPMethods are the HTTP methods for the tags handlers.
+	TagsHTTPMethods = []string{http.MethodGet, http.MethodPost}
+)
+
+type tagsHandlerType uint
+
+const (
+	listTagsHandler tagsHandlerType = iota
+	autoCompleteTagsHandler
+	autoCompleteValuesHandler
+)
+
+type graphiteTagsHandler struct {
+	handlerType         tagsHandlerType
+	storage             storage.Storage
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	instrumentOpts      instrument.Options
+}
+
+// NewTagsHandler returns a new instance of the handler listing the tags of
+// graphite tagged series.
+func NewTagsHandler(opts options.HandlerOptions) http.Handler {
+	return newTagsHandler(opts, listTagsHandler)
+}
+
+// NewTagsAutoCompleteTagsHandler returns a new instance of the handler auto
+// completing the tag names of graphite tagged series.
+func NewTagsAutoCompleteTagsHandler(opts options.HandlerOptions) http.Handler {
+	return newTagsHandler(opts, autoCompleteTagsHandler)
+}
+
+// NewTagsAutoCompleteValuesHandler returns a new instance of the handler
+// auto completing the tag values of graphite tagged series.
+func NewTagsAutoCompleteValuesHandler(opts options.HandlerOptions) http.Handler {
+	return newTagsHandler(opts, autoCompleteValuesHandler)
+}
+
+func newTagsHandler(
+	opts options.HandlerOptions,
+	handlerType tagsHandlerType,
+) http.Handler {
+	return &graphiteTagsHandler{
+		handlerType:         handlerType,
+		storage:             opts.Storage(),
+		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
+		instrumentOpts:      opts.InstrumentOpts(),
+	}
+}
+
+func (h *graphiteTagsHandler) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	params, err := parseTagsParams(r, h.handlerType)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	opts, err := h.fetchOptionsBuilder.NewFetchOptions(r)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	result, err := h.storage.CompleteTags(ctx, params.query, opts)
+	if err != nil {
+		logger.Error("unable to complete tags", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	handleroptions.AddResponseHeaders(w, result.Metadata, opts)
+	if err := tagsResultsJSON(w, h.handlerType, params.results(result)); err != nil {
+		logger.Error("unable to write tags results", zap.Error(err))
+		xhttp.WriteError(w, err)
+	}
+}
+
+type tagsParams struct {
+	query   *storage.CompleteTagsQuery
+	tag     string
+	match   func(string) bool
+	exclude map[string]struct{}
+	limit   int
+}
+
+// parseTagsParams parses the params shared by the graphite tags endpoints:
+// from, until, limit and any number of seriesByTag style expr params which
+// restrict the series to complete from. Tags are filtered by the filter
+// regex, tagPrefix or valuePrefix param depending on the endpoint.
+func parseTagsParams(
+	r *http.Request,
+	handlerType tagsHandlerType,
+) (tagsParams, error) {
+	// NB: parsing from and until populates the request form, which is read
+	// directly for the repeated expr params below.
+	from, until, err := parseFromUntil(r)
+	if err != nil {
+		return tagsParams{}, err
+	}
+
+	params := tagsParams{
+		match:   func(string) bool { return true },
+		exclude: make(map[string]struct{}),
+	}
+	if handlerType != listTagsHandler {
+		params.limit = defaultTagsAutoCompleteLimit
+	}
+
+	if str := r.FormValue("limit"); str != "" {
+		limit, err := strconv.Atoi(str)
+		if err != nil || limit < 0 {
+			return tagsParams{}, xerrors.NewInvalidParamsError(
+				fmt.Errorf("invalid 'limit': %s", str))
+		}
+		params.limit = limit
+	}
+
+	matchers := models.Matchers{{
+		Type: models.MatchField,
+		Name: []byte(graphite.TaggedNameTag),
+	}}
+	exprs := make([]string, 0, len(r.Form["expr"])+len(r.Form["expr[]"]))
+	exprs = append(exprs, r.Form["expr"]...)
+	exprs = append(exprs, r.Form["expr[]"]...)
+	if len(exprs) > 0 {
+		matchers, err = graphitestorage.TranslateTagExpressionsToMatchers(exprs)
+		if err != nil {
+			return tagsParams{}, xerrors.NewInvalidParamsError(err)
+		}
+
+		for _, str := range exprs {
+			expr, err := graphite.ParseTagExpression(str)
+			if err != nil {
+				return tagsParams{}, xerrors.NewInvalidParamsError(err)
+			}
+			params.exclude[expr.Tag] = struct{}{}
+		}
+	}
+
+	params.query = &storage.CompleteTagsQuery{
+		CompleteNameOnly: true,
+		TagMatchers:      matchers,
+		Start:            from,
+		End:              until,
+	}
+
+	switch handlerType {
+	case listTagsHandler:
+		// NB: expressions only restrict the listed tags, they are not
+		// excluded from the results as when auto completing.
+		params.exclude = nil
+		if filter := r.FormValue("filter"); filter != "" {
+			re, err := regexp.Compile("^(?:" + filter + ")")
+			if err != nil {
+				return tagsParams{}, xerrors.NewInvalidParamsError(
+					fmt.Errorf("invalid 'filter': %v", err))
+			}
+			params.match = re.MatchString
+		}
+	case autoCompleteTagsHandler:
+		prefix := r.FormValue("tagPrefix")
+		params.match = func(tag string) bool {
+			return strings.HasPrefix(tag, prefix)
+		}
+	case autoCompleteValuesHandler:
+		params.tag = r.FormValue("tag")
+		if params.tag == "" {
+			return tagsParams{}, xerrors.NewInvalidParamsError(
+				errors.New("missing 'tag'"))
+		}
+
+		prefix := r.FormValue("valuePrefix")
+		params.match = func(value string) bool {
+			return strings.HasPrefix(value, prefix)
+		}
+		params.exclude = nil
+		params.query.CompleteNameOnly = false
+		params.query.FilterNameTags = [][]byte{[]byte(params.tag)}
+		params.query.TagMatchers = append(params.query.TagMatchers, models.Matcher{
+			Type: models.MatchField,
+			Name: []byte(params.tag),
+		})
+	}
+
+	return params, nil
+}
+
+// results returns the sorted tag names or values from the completed tags
+// which pass the params filters, truncated to the limit.
+func (p tagsParams) results(result *consolidators.CompleteTagsResult) []string {
+	var candidates []string
+	for _, tag := range result.CompletedTags {
+		if p.tag == "" {
+			candidates = append(candidates, string(tag.Name))
+			continue
+		}
+
+		if string(tag.Name) != p.tag {
+			continue
+		}
+		for _, value := range tag.Values {
+			candidates = append(candidates, string(value))
+		}
+	}
+
+	results := make([]string, 0, len(candidates))
+	seen := make(map[string]struct{}, len(candidates))
+	for _, candidate := range candidates {
+		if _, ok := p.exclude[candidate]; ok {
+			continue
+		}
+		if _, ok := seen[candidate]; ok || !p.match(candidate) {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		results = append(results, candidate)
+	}
+
+	sort.Strings(results)
+	if p.limit > 0 && len(results) > p.limit {
+		results = results[:p.limit]
+	}
+
+	return results
+}
+
+func tagsResultsJSON(
+	w http.ResponseWriter,
+	handlerType tagsHandlerType,
+	results []string,
+) error {
+	jw := json.NewWriter(w)
+	jw.BeginArray()
+
+	for _, result := range results {
+		if handlerType != listTagsHandler {
+			jw.WriteString(result)
+			continue
+		}
+
+		jw.BeginObject()
+		jw.BeginObjectField("tag")
+		jw.WriteString(result)
+		jw.EndObject()
+	}
+
+	jw.EndArray()
+	return jw.Close()
+}
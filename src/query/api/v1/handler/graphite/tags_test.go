@@ -0,0 +1,199 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTagsTestHandlerOptions(
+	t *testing.T,
+	store storage.Storage,
+) options.HandlerOptions {
+	builder, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{
+			Timeout: 15 * time.Second,
+		})
+	require.NoError(t, err)
+	return options.EmptyHandlerOptions().
+		SetFetchOptionsBuilder(builder).
+		SetStorage(store)
+}
+
+func serveTagsRequest(h http.Handler, params url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, TagsURL+"?"+params.Encode(), nil)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestTagsList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			q *storage.CompleteTagsQuery,
+			_ *storage.FetchOptions,
+		) (*consolidators.CompleteTagsResult, error) {
+			assert.True(t, q.CompleteNameOnly)
+			assert.Equal(t, models.Matchers{
+				{Type: models.MatchField, Name: b("name")},
+			}, q.TagMatchers)
+			return &consolidators.CompleteTagsResult{
+				CompleteNameOnly: true,
+				CompletedTags: []consolidators.CompletedTag{
+					{Name: b("name")}, {Name: b("host")}, {Name: b("dc")},
+					{Name: b("disk")},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	h := NewTagsHandler(newTagsTestHandlerOptions(t, store))
+	recorder := serveTagsRequest(h, url.Values{"filter": []string{"d"}})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `[{"tag":"dc"},{"tag":"disk"}]`, recorder.Body.String())
+}
+
+func TestTagsAutoCompleteTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			q *storage.CompleteTagsQuery,
+			_ *storage.FetchOptions,
+		) (*consolidators.CompleteTagsResult, error) {
+			assert.True(t, q.CompleteNameOnly)
+			assert.Equal(t, models.Matchers{
+				{Type: models.MatchEqual, Name: b("name"), Value: b("disk.used")},
+				{Type: models.MatchField, Name: b("name")},
+			}, q.TagMatchers)
+			return &consolidators.CompleteTagsResult{
+				CompleteNameOnly: true,
+				CompletedTags: []consolidators.CompletedTag{
+					{Name: b("name")}, {Name: b("host")}, {Name: b("dc")},
+					{Name: b("hostname")}, {Name: b("hosted")},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	h := NewTagsAutoCompleteTagsHandler(newTagsTestHandlerOptions(t, store))
+	recorder := serveTagsRequest(h, url.Values{
+		"expr":  []string{"name=disk.used"},
+		"limit": []string{"2"},
+	})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `["dc","host"]`, recorder.Body.String())
+}
+
+func TestTagsAutoCompleteValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			q *storage.CompleteTagsQuery,
+			_ *storage.FetchOptions,
+		) (*consolidators.CompleteTagsResult, error) {
+			assert.False(t, q.CompleteNameOnly)
+			assert.Equal(t, [][]byte{b("dc")}, q.FilterNameTags)
+			assert.Equal(t, models.Matchers{
+				{Type: models.MatchField, Name: b("name")},
+				{Type: models.MatchField, Name: b("dc")},
+			}, q.TagMatchers)
+			return &consolidators.CompleteTagsResult{
+				CompletedTags: []consolidators.CompletedTag{
+					{Name: b("dc"), Values: bs("us-west", "eu-west", "us-east")},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	h := NewTagsAutoCompleteValuesHandler(newTagsTestHandlerOptions(t, store))
+	recorder := serveTagsRequest(h, url.Values{
+		"tag":         []string{"dc"},
+		"valuePrefix": []string{"us-"},
+	})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `["us-east","us-west"]`, recorder.Body.String())
+}
+
+func TestTagsInvalidParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newTagsTestHandlerOptions(t, storage.NewMockStorage(ctrl))
+	for _, test := range []struct {
+		name    string
+		handler http.Handler
+		params  url.Values
+	}{
+		{
+			name:    "missing tag",
+			handler: NewTagsAutoCompleteValuesHandler(opts),
+		},
+		{
+			name:    "negative expression only",
+			handler: NewTagsAutoCompleteTagsHandler(opts),
+			params:  url.Values{"expr": []string{"dc!=east"}},
+		},
+		{
+			name:    "invalid limit",
+			handler: NewTagsAutoCompleteTagsHandler(opts),
+			params:  url.Values{"limit": []string{"foo"}},
+		},
+		{
+			name:    "invalid filter",
+			handler: NewTagsHandler(opts),
+			params:  url.Values{"filter": []string{"("}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := serveTagsRequest(test.handler, test.params)
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		})
+	}
+}
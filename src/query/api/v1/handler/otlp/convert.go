@@ -0,0 +1,662 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package otlp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	scopeNameLabel     = "otel_scope_name"
+	scopeVersionLabel  = "otel_scope_version"
+	jobLabel           = "job"
+	instanceLabel      = "instance"
+	bucketLabel        = "le"
+	quantileLabel      = "quantile"
+	bucketSuffix       = "_bucket"
+	sumSuffix          = "_sum"
+	countSuffix        = "_count"
+	serviceNameAttr    = "service.name"
+	serviceNSAttr      = "service.namespace"
+	serviceInstanceKey = "service.instance.id"
+)
+
+var (
+	errEmptyMetricName         = errors.New("metric name is empty")
+	errUnspecifiedTemporality  = errors.New("aggregation temporality is unspecified")
+	errMissingTimestamp        = errors.New("data point is missing a timestamp")
+	errMismatchedBucketCounts  = errors.New("bucket counts do not match explicit bounds")
+	errUnsupportedMetricFormat = errors.New("metric has no data")
+)
+
+// series is a single M3 series produced from an OTLP data point.
+type series struct {
+	tags       models.Tags
+	datapoints ts.Datapoints
+	attributes ts.SeriesAttributes
+	unit       xtime.Unit
+}
+
+// conversionResult is the set of series converted from an export request
+// along with the data points that had to be rejected.
+type conversionResult struct {
+	series   []series
+	rejected int64
+	err      xerrors.MultiError
+}
+
+// labels is the set of labels accumulated while walking from a resource
+// down to a single data point; later additions override earlier ones.
+type labels map[string]string
+
+func (l labels) clone() labels {
+	cloned := make(labels, len(l))
+	for k, v := range l {
+		cloned[k] = v
+	}
+	return cloned
+}
+
+func (l labels) addAttributes(attrs []*KeyValue) {
+	for _, attr := range attrs {
+		if attr == nil || attr.Key == "" {
+			continue
+		}
+		l[sanitizeLabelName(attr.Key)] = anyValueString(attr.Value)
+	}
+}
+
+func (l labels) toTags(name string, tagOpts models.TagOptions) models.Tags {
+	tags := models.NewTags(len(l)+1, tagOpts)
+	for k, v := range l {
+		if v == "" {
+			// Prometheus semantics treat empty label values as absent.
+			continue
+		}
+		tags = tags.AddTagWithoutNormalizing(models.Tag{
+			Name:  []byte(k),
+			Value: []byte(v),
+		})
+	}
+	tags = tags.AddTagWithoutNormalizing(models.Tag{
+		Name:  tagOpts.MetricName(),
+		Value: []byte(name),
+	})
+	return tags.Normalize()
+}
+
+// convertRequest converts an OTLP export request into M3 series. Data points
+// that cannot be converted are counted as rejected rather than failing the
+// whole request.
+func convertRequest(
+	req *ExportMetricsServiceRequest,
+	tagOpts models.TagOptions,
+) conversionResult {
+	result := conversionResult{err: xerrors.NewMultiError()}
+	for _, rm := range req.ResourceMetrics {
+		if rm == nil {
+			continue
+		}
+
+		resourceLabels := make(labels)
+		if rm.Resource != nil {
+			resourceLabels.addAttributes(rm.Resource.Attributes)
+			addJobAndInstance(resourceLabels, rm.Resource.Attributes)
+		}
+
+		for _, sm := range rm.ScopeMetrics {
+			if sm == nil {
+				continue
+			}
+
+			scopeLabels := resourceLabels.clone()
+			if scope := sm.Scope; scope != nil {
+				scopeLabels.addAttributes(scope.Attributes)
+				if scope.Name != "" {
+					scopeLabels[scopeNameLabel] = scope.Name
+				}
+				if scope.Version != "" {
+					scopeLabels[scopeVersionLabel] = scope.Version
+				}
+			}
+
+			for _, metric := range sm.Metrics {
+				if metric == nil {
+					continue
+				}
+				result.convertMetric(metric, scopeLabels, tagOpts)
+			}
+		}
+	}
+	return result
+}
+
+func addJobAndInstance(l labels, attrs []*KeyValue) {
+	var name, namespace, instance string
+	for _, attr := range attrs {
+		if attr == nil {
+			continue
+		}
+		switch attr.Key {
+		case serviceNameAttr:
+			name = anyValueString(attr.Value)
+		case serviceNSAttr:
+			namespace = anyValueString(attr.Value)
+		case serviceInstanceKey:
+			instance = anyValueString(attr.Value)
+		}
+	}
+
+	if _, ok := l[jobLabel]; !ok && name != "" {
+		if namespace != "" {
+			name = namespace + "/" + name
+		}
+		l[jobLabel] = name
+	}
+	if _, ok := l[instanceLabel]; !ok && instance != "" {
+		l[instanceLabel] = instance
+	}
+}
+
+func (r *conversionResult) reject(metric *Metric, n int, err error) {
+	r.rejected += int64(n)
+	r.err = r.err.Add(xerrors.NewInvalidParamsError(
+		fmt.Errorf("metric %q: %v", metric.Name, err)))
+}
+
+func (r *conversionResult) convertMetric(
+	metric *Metric,
+	scopeLabels labels,
+	tagOpts models.TagOptions,
+) {
+	name := sanitizeMetricName(metric.Name)
+	if name == "" {
+		r.reject(metric, numDataPoints(metric), errEmptyMetricName)
+		return
+	}
+
+	switch data := metric.Data.(type) {
+	case *Metric_Gauge:
+		attrs := ts.SeriesAttributes{
+			M3Type:   ts.M3MetricTypeGauge,
+			PromType: ts.PromMetricTypeGauge,
+		}
+		for _, dp := range data.Gauge.GetDataPoints() {
+			r.convertNumberDataPoint(metric, name, dp, attrs, scopeLabels, tagOpts)
+		}
+
+	case *Metric_Sum:
+		attrs, err := sumAttributes(data.Sum)
+		if err != nil {
+			r.reject(metric, len(data.Sum.GetDataPoints()), err)
+			return
+		}
+		for _, dp := range data.Sum.GetDataPoints() {
+			r.convertNumberDataPoint(metric, name, dp, attrs, scopeLabels, tagOpts)
+		}
+
+	case *Metric_Histogram:
+		attrs, err := histogramAttributes(data.Histogram.GetAggregationTemporality())
+		if err != nil {
+			r.reject(metric, len(data.Histogram.GetDataPoints()), err)
+			return
+		}
+		for _, dp := range data.Histogram.GetDataPoints() {
+			r.convertHistogramDataPoint(metric, name, dp, attrs, scopeLabels, tagOpts)
+		}
+
+	case *Metric_ExponentialHistogram:
+		attrs, err := histogramAttributes(data.ExponentialHistogram.GetAggregationTemporality())
+		if err != nil {
+			r.reject(metric, len(data.ExponentialHistogram.GetDataPoints()), err)
+			return
+		}
+		for _, dp := range data.ExponentialHistogram.GetDataPoints() {
+			r.convertExponentialHistogramDataPoint(metric, name, dp, attrs,
+				scopeLabels, tagOpts)
+		}
+
+	case *Metric_Summary:
+		attrs := ts.SeriesAttributes{
+			M3Type:            ts.M3MetricTypeGauge,
+			PromType:          ts.PromMetricTypeSummary,
+			HandleValueResets: true,
+		}
+		for _, dp := range data.Summary.GetDataPoints() {
+			r.convertSummaryDataPoint(metric, name, dp, attrs, scopeLabels, tagOpts)
+		}
+
+	default:
+		r.reject(metric, 0, errUnsupportedMetricFormat)
+	}
+}
+
+// sumAttributes returns the series attributes for a sum. Cumulative sums are
+// written as gauges so that downsampling keeps the last value, exactly as
+// Prometheus counters arriving via remote write are. Delta sums are written
+// as M3 counters so that downsampling sums the deltas within each
+// resolution window.
+func sumAttributes(sum *Sum) (ts.SeriesAttributes, error) {
+	promType := ts.PromMetricTypeGauge
+	if sum.GetIsMonotonic() {
+		promType = ts.PromMetricTypeCounter
+	}
+
+	switch sum.GetAggregationTemporality() {
+	case AggregationTemporalityCumulative:
+		return ts.SeriesAttributes{
+			M3Type:            ts.M3MetricTypeGauge,
+			PromType:          promType,
+			HandleValueResets: sum.GetIsMonotonic(),
+		}, nil
+	case AggregationTemporalityDelta:
+		return ts.SeriesAttributes{
+			M3Type:   ts.M3MetricTypeCounter,
+			PromType: promType,
+		}, nil
+	default:
+		return ts.SeriesAttributes{}, errUnspecifiedTemporality
+	}
+}
+
+// histogramAttributes returns the series attributes shared by the bucket,
+// sum and count series of a histogram.
+func histogramAttributes(
+	temporality AggregationTemporality,
+) (ts.SeriesAttributes, error) {
+	switch temporality {
+	case AggregationTemporalityCumulative:
+		return ts.SeriesAttributes{
+			M3Type:            ts.M3MetricTypeGauge,
+			PromType:          ts.PromMetricTypeHistogram,
+			HandleValueResets: true,
+		}, nil
+	case AggregationTemporalityDelta:
+		return ts.SeriesAttributes{
+			M3Type:   ts.M3MetricTypeCounter,
+			PromType: ts.PromMetricTypeHistogram,
+		}, nil
+	default:
+		return ts.SeriesAttributes{}, errUnspecifiedTemporality
+	}
+}
+
+func (r *conversionResult) convertNumberDataPoint(
+	metric *Metric,
+	name string,
+	dp *NumberDataPoint,
+	attrs ts.SeriesAttributes,
+	scopeLabels labels,
+	tagOpts models.TagOptions,
+) {
+	if dp == nil || dp.Flags&dataPointFlagNoRecordedValue != 0 {
+		return
+	}
+
+	t, err := dataPointTime(dp.TimeUnixNano)
+	if err != nil {
+		r.reject(metric, 1, err)
+		return
+	}
+
+	var value float64
+	switch v := dp.Value.(type) {
+	case *NumberDataPoint_AsDouble:
+		value = v.AsDouble
+	case *NumberDataPoint_AsInt:
+		value = float64(v.AsInt)
+	default:
+		r.reject(metric, 1, errors.New("number data point has no value"))
+		return
+	}
+
+	l := scopeLabels.clone()
+	l.addAttributes(dp.Attributes)
+	r.add(l.toTags(name, tagOpts), t, value, attrs)
+}
+
+func (r *conversionResult) convertHistogramDataPoint(
+	metric *Metric,
+	name string,
+	dp *HistogramDataPoint,
+	attrs ts.SeriesAttributes,
+	scopeLabels labels,
+	tagOpts models.TagOptions,
+) {
+	if dp == nil || dp.Flags&dataPointFlagNoRecordedValue != 0 {
+		return
+	}
+
+	t, err := dataPointTime(dp.TimeUnixNano)
+	if err != nil {
+		r.reject(metric, 1, err)
+		return
+	}
+
+	if len(dp.BucketCounts) > 0 &&
+		len(dp.BucketCounts) != len(dp.ExplicitBounds)+1 {
+		r.reject(metric, 1, errMismatchedBucketCounts)
+		return
+	}
+
+	l := scopeLabels.clone()
+	l.addAttributes(dp.Attributes)
+	r.addSumAndCount(l, name, t, dp.Sum, dp.Count, attrs, tagOpts)
+	if len(dp.BucketCounts) == 0 {
+		return
+	}
+
+	var cumulative uint64
+	for i, bound := range dp.ExplicitBounds {
+		cumulative += dp.BucketCounts[i]
+		r.addBucket(l, name, t, bound, cumulative, attrs, tagOpts)
+	}
+	r.addBucket(l, name, t, math.Inf(1), dp.Count, attrs, tagOpts)
+}
+
+// convertExponentialHistogramDataPoint converts an exponential histogram
+// into cumulative le buckets. Bucket index i of scale s covers the range
+// (base^i, base^(i+1)] where base is 2^(2^-s); negative buckets mirror this
+// around zero, and the zero bucket covers [-threshold, threshold].
+func (r *conversionResult) convertExponentialHistogramDataPoint(
+	metric *Metric,
+	name string,
+	dp *ExponentialHistogramDataPoint,
+	attrs ts.SeriesAttributes,
+	scopeLabels labels,
+	tagOpts models.TagOptions,
+) {
+	if dp == nil || dp.Flags&dataPointFlagNoRecordedValue != 0 {
+		return
+	}
+
+	t, err := dataPointTime(dp.TimeUnixNano)
+	if err != nil {
+		r.reject(metric, 1, err)
+		return
+	}
+
+	l := scopeLabels.clone()
+	l.addAttributes(dp.Attributes)
+	r.addSumAndCount(l, name, t, dp.Sum, dp.Count, attrs, tagOpts)
+
+	var (
+		cumulative uint64
+		scaleExp   = math.Exp2(-float64(dp.Scale))
+		lowerBound = func(index int32) float64 {
+			return math.Exp2(float64(index) * scaleExp)
+		}
+	)
+	if neg := dp.Negative; neg != nil {
+		for i := len(neg.BucketCounts) - 1; i >= 0; i-- {
+			cumulative += neg.BucketCounts[i]
+			bound := -lowerBound(neg.Offset + int32(i))
+			r.addBucket(l, name, t, bound, cumulative, attrs, tagOpts)
+		}
+	}
+
+	cumulative += dp.ZeroCount
+	r.addBucket(l, name, t, dp.ZeroThreshold, cumulative, attrs, tagOpts)
+
+	if pos := dp.Positive; pos != nil {
+		for i, count := range pos.BucketCounts {
+			cumulative += count
+			bound := lowerBound(pos.Offset + int32(i) + 1)
+			r.addBucket(l, name, t, bound, cumulative, attrs, tagOpts)
+		}
+	}
+	r.addBucket(l, name, t, math.Inf(1), dp.Count, attrs, tagOpts)
+}
+
+func (r *conversionResult) convertSummaryDataPoint(
+	metric *Metric,
+	name string,
+	dp *SummaryDataPoint,
+	attrs ts.SeriesAttributes,
+	scopeLabels labels,
+	tagOpts models.TagOptions,
+) {
+	if dp == nil || dp.Flags&dataPointFlagNoRecordedValue != 0 {
+		return
+	}
+
+	t, err := dataPointTime(dp.TimeUnixNano)
+	if err != nil {
+		r.reject(metric, 1, err)
+		return
+	}
+
+	l := scopeLabels.clone()
+	l.addAttributes(dp.Attributes)
+	sum := dp.Sum
+	r.addSumAndCount(l, name, t, &sum, dp.Count, attrs, tagOpts)
+
+	// Quantile values are point in time estimates and not cumulative.
+	quantileAttrs := attrs
+	quantileAttrs.HandleValueResets = false
+	for _, q := range dp.QuantileValues {
+		if q == nil {
+			continue
+		}
+		ql := l.clone()
+		ql[quantileLabel] = formatFloat(q.Quantile)
+		r.add(ql.toTags(name, tagOpts), t, q.Value, quantileAttrs)
+	}
+}
+
+func (r *conversionResult) addSumAndCount(
+	l labels,
+	name string,
+	t time.Time,
+	sum *float64,
+	count uint64,
+	attrs ts.SeriesAttributes,
+	tagOpts models.TagOptions,
+) {
+	if sum != nil {
+		r.add(l.toTags(name+sumSuffix, tagOpts), t, *sum, attrs)
+	}
+	r.add(l.toTags(name+countSuffix, tagOpts), t, float64(count), attrs)
+}
+
+func (r *conversionResult) addBucket(
+	l labels,
+	name string,
+	t time.Time,
+	bound float64,
+	count uint64,
+	attrs ts.SeriesAttributes,
+	tagOpts models.TagOptions,
+) {
+	bl := l.clone()
+	bl[bucketLabel] = formatFloat(bound)
+	r.add(bl.toTags(name+bucketSuffix, tagOpts), t, float64(count), attrs)
+}
+
+func (r *conversionResult) add(
+	tags models.Tags,
+	t time.Time,
+	value float64,
+	attrs ts.SeriesAttributes,
+) {
+	r.series = append(r.series, series{
+		tags:       tags,
+		datapoints: ts.Datapoints{{Timestamp: t, Value: value}},
+		attributes: attrs,
+		unit:       determineTimeUnit(t),
+	})
+}
+
+func numDataPoints(metric *Metric) int {
+	switch data := metric.Data.(type) {
+	case *Metric_Gauge:
+		return len(data.Gauge.GetDataPoints())
+	case *Metric_Sum:
+		return len(data.Sum.GetDataPoints())
+	case *Metric_Histogram:
+		return len(data.Histogram.GetDataPoints())
+	case *Metric_ExponentialHistogram:
+		return len(data.ExponentialHistogram.GetDataPoints())
+	case *Metric_Summary:
+		return len(data.Summary.GetDataPoints())
+	}
+	return 0
+}
+
+func dataPointTime(unixNanos uint64) (time.Time, error) {
+	if unixNanos == 0 {
+		return time.Time{}, errMissingTimestamp
+	}
+	return time.Unix(0, int64(unixNanos)), nil
+}
+
+func determineTimeUnit(t time.Time) xtime.Unit {
+	ns := t.UnixNano()
+	if ns%int64(time.Second) == 0 {
+		return xtime.Second
+	}
+	if ns%int64(time.Millisecond) == 0 {
+		return xtime.Millisecond
+	}
+	if ns%int64(time.Microsecond) == 0 {
+		return xtime.Microsecond
+	}
+	return xtime.Nanosecond
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// anyValueString renders an attribute value as a tag value, encoding
+// arrays and key value lists as JSON.
+func anyValueString(v *AnyValue) string {
+	if v == nil {
+		return ""
+	}
+	switch value := v.Value.(type) {
+	case *AnyValue_StringValue:
+		return value.StringValue
+	case *AnyValue_BoolValue:
+		return strconv.FormatBool(value.BoolValue)
+	case *AnyValue_IntValue:
+		return strconv.FormatInt(value.IntValue, 10)
+	case *AnyValue_DoubleValue:
+		return formatFloat(value.DoubleValue)
+	case *AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(value.BytesValue)
+	case *AnyValue_ArrayValue, *AnyValue_KvlistValue:
+		b, err := json.Marshal(anyValueInterface(v))
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+	return ""
+}
+
+func anyValueInterface(v *AnyValue) interface{} {
+	if v == nil {
+		return nil
+	}
+	switch value := v.Value.(type) {
+	case *AnyValue_StringValue:
+		return value.StringValue
+	case *AnyValue_BoolValue:
+		return value.BoolValue
+	case *AnyValue_IntValue:
+		return value.IntValue
+	case *AnyValue_DoubleValue:
+		if math.IsNaN(value.DoubleValue) || math.IsInf(value.DoubleValue, 0) {
+			return formatFloat(value.DoubleValue)
+		}
+		return value.DoubleValue
+	case *AnyValue_BytesValue:
+		return value.BytesValue
+	case *AnyValue_ArrayValue:
+		values := value.ArrayValue.GetValues()
+		result := make([]interface{}, 0, len(values))
+		for _, elem := range values {
+			result = append(result, anyValueInterface(elem))
+		}
+		return result
+	case *AnyValue_KvlistValue:
+		values := value.KvlistValue.GetValues()
+		result := make(map[string]interface{}, len(values))
+		for _, kv := range values {
+			if kv == nil {
+				continue
+			}
+			result[kv.Key] = anyValueInterface(kv.Value)
+		}
+		return result
+	}
+	return nil
+}
+
+// sanitizeMetricName converts an OTLP metric name into a valid Prometheus
+// metric name by replacing invalid characters with underscores.
+func sanitizeMetricName(name string) string {
+	return sanitize(name, true)
+}
+
+// sanitizeLabelName converts an OTLP attribute key into a valid Prometheus
+// label name by replacing invalid characters with underscores.
+func sanitizeLabelName(name string) string {
+	return sanitize(name, false)
+}
+
+func sanitize(name string, allowColons bool) string {
+	if name == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.Grow(len(name) + 1)
+	for i, r := range name {
+		valid := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' ||
+			(allowColons && r == ':') || (i > 0 && r >= '0' && r <= '9')
+		if i == 0 && r >= '0' && r <= '9' {
+			// Names may not start with a digit, so prefix rather than
+			// replace to keep the digit.
+			b.WriteByte('_')
+			valid = true
+		}
+		if !valid {
+			b.WriteByte('_')
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
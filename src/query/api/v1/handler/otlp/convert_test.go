@@ -0,0 +1,282 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package otlp
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTime = time.Unix(1600000000, 0)
+
+func stringValue(v string) *AnyValue {
+	return &AnyValue{Value: &AnyValue_StringValue{StringValue: v}}
+}
+
+func intValue(v int64) *AnyValue {
+	return &AnyValue{Value: &AnyValue_IntValue{IntValue: v}}
+}
+
+func newTestRequest(metrics ...*Metric) *ExportMetricsServiceRequest {
+	return &ExportMetricsServiceRequest{
+		ResourceMetrics: []*ResourceMetrics{
+			{
+				Resource: &Resource{
+					Attributes: []*KeyValue{
+						{Key: "service.name", Value: stringValue("api")},
+						{Key: "host", Value: stringValue("h1")},
+					},
+				},
+				ScopeMetrics: []*ScopeMetrics{
+					{
+						Scope:   &InstrumentationScope{Name: "lib", Version: "1.0"},
+						Metrics: metrics,
+					},
+				},
+			},
+		},
+	}
+}
+
+func seriesStrings(result conversionResult) []string {
+	strs := make([]string, 0, len(result.series))
+	for _, s := range result.series {
+		strs = append(strs, fmt.Sprintf("%s %v", s.tags.String(), s.datapoints[0].Value))
+	}
+	return strs
+}
+
+func TestConvertNumberMetrics(t *testing.T) {
+	req := newTestRequest(
+		&Metric{
+			Name: "http.requests",
+			Data: &Metric_Sum{Sum: &Sum{
+				AggregationTemporality: AggregationTemporalityCumulative,
+				IsMonotonic:            true,
+				DataPoints: []*NumberDataPoint{
+					{
+						Attributes: []*KeyValue{
+							{Key: "host", Value: stringValue("h2")},
+							{Key: "code", Value: intValue(200)},
+						},
+						TimeUnixNano: uint64(testTime.UnixNano()),
+						Value:        &NumberDataPoint_AsInt{AsInt: 42},
+					},
+				},
+			}},
+		},
+		&Metric{
+			Name: "queue.depth",
+			Data: &Metric_Gauge{Gauge: &Gauge{
+				DataPoints: []*NumberDataPoint{
+					{
+						TimeUnixNano: uint64(testTime.UnixNano()) + 1000,
+						Value:        &NumberDataPoint_AsDouble{AsDouble: 1.5},
+					},
+					{
+						// Points without a recorded value are skipped.
+						TimeUnixNano: uint64(testTime.UnixNano()),
+						Flags:        dataPointFlagNoRecordedValue,
+					},
+				},
+			}},
+		},
+		&Metric{
+			Name: "bytes.sent",
+			Data: &Metric_Sum{Sum: &Sum{
+				AggregationTemporality: AggregationTemporalityDelta,
+				IsMonotonic:            true,
+				DataPoints: []*NumberDataPoint{
+					{
+						TimeUnixNano: uint64(testTime.UnixNano()),
+						Value:        &NumberDataPoint_AsInt{AsInt: 7},
+					},
+				},
+			}},
+		},
+	)
+
+	result := convertRequest(req, models.NewTagOptions())
+	require.NoError(t, result.err.FinalError())
+	assert.Equal(t, int64(0), result.rejected)
+	assert.Equal(t, []string{
+		"__name__: http_requests, code: 200, host: h2, job: api, otel_scope_name: lib, otel_scope_version: 1.0, service_name: api 42",
+		"__name__: queue_depth, host: h1, job: api, otel_scope_name: lib, otel_scope_version: 1.0, service_name: api 1.5",
+		"__name__: bytes_sent, host: h1, job: api, otel_scope_name: lib, otel_scope_version: 1.0, service_name: api 7",
+	}, seriesStrings(result))
+
+	assert.Equal(t, ts.SeriesAttributes{
+		M3Type:            ts.M3MetricTypeGauge,
+		PromType:          ts.PromMetricTypeCounter,
+		HandleValueResets: true,
+	}, result.series[0].attributes)
+	assert.Equal(t, xtime.Second, result.series[0].unit)
+
+	assert.Equal(t, ts.SeriesAttributes{
+		M3Type:   ts.M3MetricTypeGauge,
+		PromType: ts.PromMetricTypeGauge,
+	}, result.series[1].attributes)
+	assert.Equal(t, xtime.Microsecond, result.series[1].unit)
+
+	assert.Equal(t, ts.SeriesAttributes{
+		M3Type:   ts.M3MetricTypeCounter,
+		PromType: ts.PromMetricTypeCounter,
+	}, result.series[2].attributes)
+}
+
+func TestConvertHistogram(t *testing.T) {
+	sum := 12.5
+	req := newTestRequest(&Metric{
+		Name: "latency",
+		Data: &Metric_Histogram{Histogram: &Histogram{
+			AggregationTemporality: AggregationTemporalityCumulative,
+			DataPoints: []*HistogramDataPoint{
+				{
+					TimeUnixNano:   uint64(testTime.UnixNano()),
+					Count:          6,
+					Sum:            &sum,
+					BucketCounts:   []uint64{1, 2, 3},
+					ExplicitBounds: []float64{0.1, 1},
+				},
+			},
+		}},
+	})
+
+	result := convertRequest(req, models.NewTagOptions())
+	require.NoError(t, result.err.FinalError())
+
+	common := "host: h1, job: api, otel_scope_name: lib, otel_scope_version: 1.0, service_name: api"
+	assert.Equal(t, []string{
+		"__name__: latency_sum, " + common + " 12.5",
+		"__name__: latency_count, " + common + " 6",
+		"__name__: latency_bucket, " + "host: h1, job: api, le: 0.1, otel_scope_name: lib, otel_scope_version: 1.0, service_name: api 1",
+		"__name__: latency_bucket, " + "host: h1, job: api, le: 1, otel_scope_name: lib, otel_scope_version: 1.0, service_name: api 3",
+		"__name__: latency_bucket, " + "host: h1, job: api, le: +Inf, otel_scope_name: lib, otel_scope_version: 1.0, service_name: api 6",
+	}, seriesStrings(result))
+
+	for _, s := range result.series {
+		assert.Equal(t, ts.PromMetricTypeHistogram, s.attributes.PromType)
+		assert.True(t, s.attributes.HandleValueResets)
+	}
+}
+
+func TestConvertExponentialHistogram(t *testing.T) {
+	req := newTestRequest(&Metric{
+		Name: "size",
+		Data: &Metric_ExponentialHistogram{ExponentialHistogram: &ExponentialHistogram{
+			AggregationTemporality: AggregationTemporalityDelta,
+			DataPoints: []*ExponentialHistogramDataPoint{
+				{
+					TimeUnixNano: uint64(testTime.UnixNano()),
+					Count:        10,
+					Scale:        0,
+					ZeroCount:    4,
+					Positive:     &Buckets{Offset: 0, BucketCounts: []uint64{1, 2}},
+					Negative:     &Buckets{Offset: 0, BucketCounts: []uint64{3}},
+				},
+			},
+		}},
+	})
+
+	result := convertRequest(req, models.NewTagOptions())
+	require.NoError(t, result.err.FinalError())
+
+	var buckets []string
+	for _, s := range result.series {
+		name, ok := s.tags.Name()
+		require.True(t, ok)
+		if string(name) != "size_bucket" {
+			continue
+		}
+		le, ok := s.tags.Get([]byte(bucketLabel))
+		require.True(t, ok)
+		buckets = append(buckets, fmt.Sprintf("%s=%v", le, s.datapoints[0].Value))
+		assert.Equal(t, ts.M3MetricTypeCounter, s.attributes.M3Type)
+	}
+
+	// Scale 0 uses a base of 2, so bucket i covers (2^i, 2^(i+1)].
+	assert.Equal(t, []string{"-1=3", "0=7", "2=8", "4=10", "+Inf=10"}, buckets)
+}
+
+func TestConvertRejectsInvalidDataPoints(t *testing.T) {
+	req := newTestRequest(
+		&Metric{
+			Name: "no.temporality",
+			Data: &Metric_Sum{Sum: &Sum{
+				DataPoints: []*NumberDataPoint{
+					{
+						TimeUnixNano: uint64(testTime.UnixNano()),
+						Value:        &NumberDataPoint_AsInt{AsInt: 1},
+					},
+				},
+			}},
+		},
+		&Metric{
+			Name: "bad.buckets",
+			Data: &Metric_Histogram{Histogram: &Histogram{
+				AggregationTemporality: AggregationTemporalityCumulative,
+				DataPoints: []*HistogramDataPoint{
+					{
+						TimeUnixNano:   uint64(testTime.UnixNano()),
+						BucketCounts:   []uint64{1},
+						ExplicitBounds: []float64{1, 2},
+					},
+				},
+			}},
+		},
+		&Metric{
+			Name: "ok",
+			Data: &Metric_Gauge{Gauge: &Gauge{
+				DataPoints: []*NumberDataPoint{
+					{Value: &NumberDataPoint_AsDouble{AsDouble: 1}},
+					{
+						TimeUnixNano: uint64(testTime.UnixNano()),
+						Value:        &NumberDataPoint_AsDouble{AsDouble: 2},
+					},
+				},
+			}},
+		},
+	)
+
+	result := convertRequest(req, models.NewTagOptions())
+	assert.Equal(t, int64(3), result.rejected)
+	require.Error(t, result.err.FinalError())
+	require.Len(t, result.series, 1)
+	assert.Equal(t, 2.0, result.series[0].datapoints[0].Value)
+}
+
+func TestSanitizeNames(t *testing.T) {
+	assert.Equal(t, "http_server_duration", sanitizeMetricName("http.server.duration"))
+	assert.Equal(t, "ns:metric_name", sanitizeMetricName("ns:metric-name"))
+	assert.Equal(t, "_2xx", sanitizeMetricName("2xx"))
+	assert.Equal(t, "k8s_pod_name", sanitizeLabelName("k8s.pod.name"))
+	assert.Equal(t, "ns_key", sanitizeLabelName("ns:key"))
+	assert.Equal(t, "+Inf", formatFloat(math.Inf(1)))
+}
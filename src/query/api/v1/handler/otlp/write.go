@@ -0,0 +1,374 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package otlp implements an OpenTelemetry protocol (OTLP/HTTP) metrics
+// receiver that writes through the same ingest path as Prometheus remote
+// write.
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"go.uber.org/zap"
+)
+
+const (
+	// OTLPWriteURL is the OTLP/HTTP metrics write handler URL.
+	OTLPWriteURL = handler.RoutePrefixV1 + "/otlp/v1/metrics"
+
+	// OTLPWriteHTTPMethod is the HTTP method used with this resource.
+	OTLPWriteHTTPMethod = http.MethodPost
+
+	headerContentEncoding = "Content-Encoding"
+	contentEncodingGzip   = "gzip"
+)
+
+var (
+	defaultValue = ingest.IterValue{
+		Tags:       models.EmptyTags(),
+		Attributes: ts.DefaultSeriesAttributes(),
+		Metadata:   ts.Metadata{},
+	}
+
+	errNoDataPointsAccepted = errors.New("no data points accepted")
+)
+
+type writeHandler struct {
+	handlerOpts      options.HandlerOptions
+	tagOpts          models.TagOptions
+	storeMetricsType bool
+}
+
+// NewOTLPWriteHandler returns a new OTLP/HTTP metrics write handler that
+// accepts both the binary protobuf and JSON encodings of an
+// ExportMetricsServiceRequest.
+func NewOTLPWriteHandler(opts options.HandlerOptions) http.Handler {
+	return &writeHandler{
+		handlerOpts:      opts,
+		tagOpts:          opts.TagOptions(),
+		storeMetricsType: opts.StoreMetricsType(),
+	}
+}
+
+func (h *writeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	contentType, err := parseContentType(r)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	req, err := parseRequest(r, contentType)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	result := convertRequest(req, h.tagOpts)
+	if len(result.series) == 0 && result.rejected > 0 {
+		err := fmt.Errorf("%v: %v", errNoDataPointsAccepted, result.err.FinalError())
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(err))
+		return
+	}
+
+	if len(result.series) > 0 {
+		iter := newSeriesIter(result.series, h.storeMetricsType)
+		batchErr := h.handlerOpts.DownsamplerAndWriter().
+			WriteBatch(r.Context(), iter, ingest.WriteOptions{})
+		if batchErr != nil {
+			h.writeBatchError(w, r, batchErr.Errors())
+			return
+		}
+	}
+
+	resp := &ExportMetricsServiceResponse{}
+	if result.rejected > 0 {
+		resp.PartialSuccess = &ExportMetricsPartialSuccess{
+			RejectedDataPoints: result.rejected,
+			ErrorMessage:       result.err.FinalError().Error(),
+		}
+	}
+
+	writeResponse(w, contentType, resp)
+}
+
+func (h *writeHandler) writeBatchError(
+	w http.ResponseWriter,
+	r *http.Request,
+	errs []error,
+) {
+	var (
+		lastRegularErr    string
+		lastBadRequestErr string
+		numRegular        int
+		numBadRequest     int
+	)
+	for _, err := range errs {
+		switch {
+		case client.IsBadRequestError(err):
+			numBadRequest++
+			lastBadRequestErr = err.Error()
+		case xerrors.IsInvalidParams(err):
+			numBadRequest++
+			lastBadRequestErr = err.Error()
+		default:
+			numRegular++
+			lastRegularErr = err.Error()
+		}
+	}
+
+	// OTLP clients retry on 5XX responses only, so only report a client
+	// error when every failure was caused by the request itself.
+	status := http.StatusInternalServerError
+	if numBadRequest == len(errs) {
+		status = http.StatusBadRequest
+	}
+
+	logger := logging.WithContext(r.Context(), h.handlerOpts.InstrumentOpts())
+	logger.Error("write error",
+		zap.String("remoteAddr", r.RemoteAddr),
+		zap.Int("httpResponseStatusCode", status),
+		zap.Int("numRegularErrors", numRegular),
+		zap.Int("numBadRequestErrors", numBadRequest),
+		zap.String("lastRegularError", lastRegularErr),
+		zap.String("lastBadRequestErr", lastBadRequestErr))
+
+	var resultErr string
+	if lastRegularErr != "" {
+		resultErr = fmt.Sprintf("retryable_errors: count=%d, last=%s",
+			numRegular, lastRegularErr)
+	}
+	if lastBadRequestErr != "" {
+		var sep string
+		if lastRegularErr != "" {
+			sep = ", "
+		}
+		resultErr = fmt.Sprintf("%s%sbad_request_errors: count=%d, last=%s",
+			resultErr, sep, numBadRequest, lastBadRequestErr)
+	}
+	xhttp.WriteError(w, xhttp.NewError(errors.New(resultErr), status))
+}
+
+func parseContentType(r *http.Request) (string, error) {
+	header := r.Header.Get(xhttp.HeaderContentType)
+	if header == "" {
+		return xhttp.ContentTypeProtobuf, nil
+	}
+
+	contentType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return "", xerrors.NewInvalidParamsError(err)
+	}
+
+	switch contentType {
+	case xhttp.ContentTypeProtobuf, xhttp.ContentTypeJSON:
+		return contentType, nil
+	default:
+		return "", xhttp.NewError(
+			fmt.Errorf("unsupported content type: %s", contentType),
+			http.StatusUnsupportedMediaType)
+	}
+}
+
+func parseRequest(
+	r *http.Request,
+	contentType string,
+) (*ExportMetricsServiceRequest, error) {
+	var body io.Reader = r.Body
+	switch encoding := r.Header.Get(headerContentEncoding); encoding {
+	case "":
+	case contentEncodingGzip:
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, xerrors.NewInvalidParamsError(err)
+		}
+		defer gz.Close()
+		body = gz
+	default:
+		return nil, xhttp.NewError(
+			fmt.Errorf("unsupported content encoding: %s", encoding),
+			http.StatusUnsupportedMediaType)
+	}
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, xerrors.NewInvalidParamsError(err)
+	}
+
+	req := &ExportMetricsServiceRequest{}
+	switch contentType {
+	case xhttp.ContentTypeJSON:
+		unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+		err = unmarshaler.Unmarshal(bytes.NewReader(data), req)
+	default:
+		err = proto.Unmarshal(data, req)
+	}
+	if err != nil {
+		return nil, xerrors.NewInvalidParamsError(
+			fmt.Errorf("unable to decode export request: %v", err))
+	}
+
+	return req, nil
+}
+
+func writeResponse(
+	w http.ResponseWriter,
+	contentType string,
+	resp *ExportMetricsServiceResponse,
+) {
+	var (
+		data []byte
+		err  error
+	)
+	switch contentType {
+	case xhttp.ContentTypeJSON:
+		var buf bytes.Buffer
+		err = (&jsonpb.Marshaler{}).Marshal(&buf, resp)
+		data = buf.Bytes()
+	default:
+		data, err = proto.Marshal(resp)
+	}
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set(xhttp.HeaderContentType, contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+type seriesIter struct {
+	idx        int
+	err        error
+	series     []series
+	metadatas  []ts.Metadata
+	annotation []byte
+
+	storeMetricsType bool
+}
+
+func newSeriesIter(series []series, storeMetricsType bool) *seriesIter {
+	return &seriesIter{
+		idx:              -1,
+		series:           series,
+		storeMetricsType: storeMetricsType,
+	}
+}
+
+func (i *seriesIter) Next() bool {
+	if i.err != nil {
+		return false
+	}
+
+	i.idx++
+	if i.idx >= len(i.series) {
+		return false
+	}
+
+	if !i.storeMetricsType {
+		return true
+	}
+
+	annotationPayload, err := storage.SeriesAttributesToAnnotationPayload(
+		i.series[i.idx].attributes)
+	if err != nil {
+		i.err = err
+		return false
+	}
+
+	i.annotation, err = annotationPayload.Marshal()
+	if err != nil {
+		i.err = err
+		return false
+	}
+
+	if len(i.annotation) == 0 {
+		i.annotation = nil
+	}
+
+	return true
+}
+
+func (i *seriesIter) Current() ingest.IterValue {
+	if i.idx < 0 || i.idx >= len(i.series) {
+		return defaultValue
+	}
+
+	s := i.series[i.idx]
+	value := ingest.IterValue{
+		Tags:       s.tags,
+		Datapoints: s.datapoints,
+		Attributes: s.attributes,
+		Unit:       s.unit,
+		Annotation: i.annotation,
+	}
+	if i.idx < len(i.metadatas) {
+		value.Metadata = i.metadatas[i.idx]
+	}
+	return value
+}
+
+func (i *seriesIter) Reset() error {
+	i.idx = -1
+	i.err = nil
+	i.annotation = nil
+	return nil
+}
+
+func (i *seriesIter) Error() error {
+	return i.err
+}
+
+func (i *seriesIter) SetCurrentMetadata(metadata ts.Metadata) {
+	if len(i.metadatas) == 0 {
+		i.metadatas = make([]ts.Metadata, len(i.series))
+	}
+	if i.idx < 0 || i.idx >= len(i.metadatas) {
+		return
+	}
+	i.metadatas[i.idx] = metadata
+}
+
+func (i *seriesIter) CurrentMetadata() ts.Metadata {
+	if len(i.metadatas) == 0 || i.idx < 0 || i.idx >= len(i.metadatas) {
+		return ts.Metadata{}
+	}
+	return i.metadatas[i.idx]
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xtest "github.com/m3db/m3/src/x/test"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeOptions(ds ingest.DownsamplerAndWriter) options.HandlerOptions {
+	return options.EmptyHandlerOptions().
+		SetNowFn(time.Now).
+		SetDownsamplerAndWriter(ds).
+		SetTagOptions(models.NewTagOptions())
+}
+
+func newGaugeRequest() *ExportMetricsServiceRequest {
+	return newTestRequest(&Metric{
+		Name: "queue.depth",
+		Data: &Metric_Gauge{Gauge: &Gauge{
+			DataPoints: []*NumberDataPoint{
+				{
+					TimeUnixNano: uint64(testTime.UnixNano()),
+					Value:        &NumberDataPoint_AsDouble{AsDouble: 3},
+				},
+			},
+		}},
+	})
+}
+
+func expectWrittenNames(
+	ds *ingest.MockDownsamplerAndWriter,
+	names *[]string,
+) {
+	ds.EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			iter ingest.DownsampleAndWriteIter,
+			_ ingest.WriteOptions,
+		) ingest.BatchError {
+			for iter.Next() {
+				name, _ := iter.Current().Tags.Name()
+				*names = append(*names, string(name))
+			}
+			return nil
+		})
+}
+
+func TestOTLPWriteProtobuf(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var names []string
+	ds := ingest.NewMockDownsamplerAndWriter(ctrl)
+	expectWrittenNames(ds, &names)
+
+	body, err := proto.Marshal(newGaugeRequest())
+	require.NoError(t, err)
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err = gz.Write(body)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	req := httptest.NewRequest(OTLPWriteHTTPMethod, OTLPWriteURL, &compressed)
+	req.Header.Set(xhttp.HeaderContentType, xhttp.ContentTypeProtobuf)
+	req.Header.Set(headerContentEncoding, contentEncodingGzip)
+
+	writer := httptest.NewRecorder()
+	NewOTLPWriteHandler(makeOptions(ds)).ServeHTTP(writer, req)
+
+	resp := writer.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, xhttp.ContentTypeProtobuf, resp.Header.Get(xhttp.HeaderContentType))
+	assert.Equal(t, []string{"queue_depth"}, names)
+
+	var exportResp ExportMetricsServiceResponse
+	require.NoError(t, proto.Unmarshal(writer.Body.Bytes(), &exportResp))
+	assert.Nil(t, exportResp.PartialSuccess)
+}
+
+func TestOTLPWriteJSON(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var names []string
+	ds := ingest.NewMockDownsamplerAndWriter(ctrl)
+	expectWrittenNames(ds, &names)
+
+	body := `{
+  "resourceMetrics": [{
+    "resource": {"attributes": [{"key": "service.name", "value": {"stringValue": "api"}}]},
+    "scopeMetrics": [{
+      "scope": {"name": "lib"},
+      "metrics": [
+        {
+          "name": "requests",
+          "unit": "1",
+          "sum": {
+            "aggregationTemporality": 2,
+            "isMonotonic": true,
+            "dataPoints": [{"timeUnixNano": "1600000000000000000", "asInt": "5"}]
+          }
+        },
+        {
+          "name": "no.time",
+          "gauge": {"dataPoints": [{"asDouble": 1}]}
+        }
+      ]
+    }]
+  }]
+}`
+	req := httptest.NewRequest(OTLPWriteHTTPMethod, OTLPWriteURL, strings.NewReader(body))
+	req.Header.Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	writer := httptest.NewRecorder()
+	NewOTLPWriteHandler(makeOptions(ds)).ServeHTTP(writer, req)
+
+	resp := writer.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"requests"}, names)
+
+	var exportResp ExportMetricsServiceResponse
+	require.NoError(t, jsonpb.Unmarshal(writer.Body, &exportResp))
+	require.NotNil(t, exportResp.PartialSuccess)
+	assert.Equal(t, int64(1), exportResp.PartialSuccess.RejectedDataPoints)
+	assert.Contains(t, exportResp.PartialSuccess.ErrorMessage, "no.time")
+}
+
+func TestOTLPWriteInvalidRequests(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewOTLPWriteHandler(makeOptions(ingest.NewMockDownsamplerAndWriter(ctrl)))
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{
+			name:        "unsupported content type",
+			contentType: "text/plain",
+			body:        "foo",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "malformed json",
+			contentType: xhttp.ContentTypeJSON,
+			body:        "{",
+			status:      http.StatusBadRequest,
+		},
+		{
+			name:        "all data points rejected",
+			contentType: xhttp.ContentTypeJSON,
+			body:        `{"resourceMetrics":[{"scopeMetrics":[{"metrics":[{"name":"a","gauge":{"dataPoints":[{"asDouble":1}]}}]}]}]}`,
+			status:      http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(OTLPWriteHTTPMethod, OTLPWriteURL,
+				strings.NewReader(tt.body))
+			req.Header.Set(xhttp.HeaderContentType, tt.contentType)
+
+			writer := httptest.NewRecorder()
+			handler.ServeHTTP(writer, req)
+			assert.Equal(t, tt.status, writer.Result().StatusCode)
+		})
+	}
+}
+
+func TestOTLPWriteBatchError(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	multiErr := xerrors.NewMultiError().Add(errors.New("an error"))
+	batchErr := ingest.BatchError(multiErr)
+
+	ds := ingest.NewMockDownsamplerAndWriter(ctrl)
+	ds.EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(batchErr)
+
+	body, err := proto.Marshal(newGaugeRequest())
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(OTLPWriteHTTPMethod, OTLPWriteURL, bytes.NewReader(body))
+	req.Header.Set(xhttp.HeaderContentType, xhttp.ContentTypeProtobuf)
+
+	writer := httptest.NewRecorder()
+	NewOTLPWriteHandler(makeOptions(ds)).ServeHTTP(writer, req)
+
+	resp := writer.Result()
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Contains(t, writer.Body.String(), "an error")
+}
@@ -49,6 +49,9 @@ const (
 	debugParam        = "debug"
 	endExclusiveParam = "end-exclusive"
 	blockTypeParam    = "block-type"
+	limitParam        = "limit"
+
+	statusSuccess = "success"
 
 	formatErrStr = "error parsing param: %s, error: %v"
 	nowTimeValue = "now"
@@ -222,6 +225,65 @@ func parseQuery(r *http.Request) (string, error) {
 	return queries[0], nil
 }
 
+// parseLimit parses an optional limit param, returning the default limit if
+// the param is not set.
+func parseLimit(r *http.Request, defaultLimit int) (int, error) {
+	limitVal := r.FormValue(limitParam)
+	if limitVal == "" {
+		return defaultLimit, nil
+	}
+
+	limit, err := strconv.Atoi(limitVal)
+	if err != nil {
+		return 0, xerrors.NewInvalidParamsError(
+			fmt.Errorf(formatErrStr, limitParam, err))
+	}
+
+	return limit, nil
+}
+
+// parseOptionalTimeRange parses optional start and end params, defaulting to
+// the given range ending now if they are not set.
+func parseOptionalTimeRange(
+	r *http.Request,
+	now time.Time,
+	defaultRange time.Duration,
+) (time.Time, time.Time, error) {
+	end, err := parseTime(r, endParam, now)
+	if err == errors.ErrNotFound {
+		end = now
+	} else if err != nil {
+		return time.Time{}, time.Time{}, xerrors.NewInvalidParamsError(
+			fmt.Errorf(formatErrStr, endParam, err))
+	}
+
+	start, err := parseTime(r, startParam, now)
+	if err == errors.ErrNotFound {
+		start = end.Add(-1 * defaultRange)
+	} else if err != nil {
+		return time.Time{}, time.Time{}, xerrors.NewInvalidParamsError(
+			fmt.Errorf(formatErrStr, startParam, err))
+	}
+
+	if start.After(end) {
+		err = fmt.Errorf("start (%s) must be before end (%s)", start, end)
+		return time.Time{}, time.Time{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	return start, end, nil
+}
+
+// promResponse is a successful response in the Prometheus HTTP API format.
+type promResponse struct {
+	Status   string      `json:"status"`
+	Data     interface{} `json:"data"`
+	Warnings []string    `json:"warnings,omitempty"`
+}
+
+func newPromResponse(data interface{}) promResponse {
+	return promResponse{Status: statusSuccess, Data: data}
+}
+
 func filterNaNSeries(
 	series []*ts.Series,
 	startInclusive time.Time,
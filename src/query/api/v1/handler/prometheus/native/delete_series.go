@@ -0,0 +1,204 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/headers"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PromDeleteSeriesURL is the url for the Prometheus delete series endpoint.
+	PromDeleteSeriesURL = handler.RoutePrefixV1 + "/admin/tsdb/delete_series"
+
+	// deleteSeriesBatchSize is the max number of series deleted per request
+	// to M3DB.
+	deleteSeriesBatchSize = 1024
+)
+
+var (
+	// PromDeleteSeriesHTTPMethods are the HTTP methods for this handler.
+	PromDeleteSeriesHTTPMethods = []string{http.MethodPost, http.MethodPut}
+
+	errNoClusters = errors.New("no M3DB clusters configured")
+)
+
+// PromDeleteSeriesHandler represents a handler for the delete series
+// endpoint.
+//
+// Series matching the selectors are resolved through the index and the data
+// within the time range is deleted from every M3DB cluster namespace. Deleted
+// data is hidden from reads immediately and removed from disk by the next
+// flush of each affected block. The time range is recorded as invalidated so
+// that cached query results which include the deleted data are recomputed.
+type PromDeleteSeriesHandler struct {
+	storage             storage.Storage
+	clusters            m3.Clusters
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	tagOptions          models.TagOptions
+	parseOpts           promql.ParseOptions
+	invalidations       *storage.Invalidations
+	instrumentOpts      instrument.Options
+}
+
+// NewPromDeleteSeriesHandler returns a new instance of handler.
+func NewPromDeleteSeriesHandler(opts options.HandlerOptions) http.Handler {
+	parseOpts := promql.NewParseOptions()
+	if engine := opts.Engine(); engine != nil {
+		parseOpts = engine.Options().ParseOptions()
+	}
+
+	return &PromDeleteSeriesHandler{
+		storage:             opts.Storage(),
+		clusters:            opts.Clusters(),
+		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
+		tagOptions:          opts.TagOptions(),
+		parseOpts:           parseOpts,
+		invalidations:       opts.Invalidations(),
+		instrumentOpts:      opts.InstrumentOpts(),
+	}
+}
+
+func (h *PromDeleteSeriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	if h.clusters == nil {
+		xhttp.WriteError(w, xhttp.NewError(errNoClusters, http.StatusBadRequest))
+		return
+	}
+
+	queries, err := prometheus.ParseSeriesMatchQuery(r, h.parseOpts, h.tagOptions)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	start, end := queries[0].Start, queries[0].End
+	if start.After(end) {
+		err := fmt.Errorf("start (%s) must be before end (%s)", start, end)
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(err))
+		return
+	}
+
+	opts, rErr := h.fetchOptionsBuilder.NewFetchOptions(r)
+	if rErr != nil {
+		xhttp.WriteError(w, rErr)
+		return
+	}
+
+	var (
+		ids  []ident.ID
+		seen = make(map[string]struct{})
+	)
+	for _, query := range queries {
+		result, err := h.storage.SearchSeries(ctx, query, opts)
+		if err != nil {
+			logger.Error("unable to get matched series", zap.Error(err))
+			xhttp.WriteError(w, err)
+			return
+		}
+
+		// Only delete when every matching series is known, otherwise
+		// the delete would silently only apply to some of the series.
+		if !result.Metadata.Exhaustive {
+			err := fmt.Errorf("too many series matched %s, increase the "+
+				"limit with the %s header or narrow the selector",
+				query.Raw, headers.LimitMaxSeriesHeader)
+			xhttp.WriteError(w, xerrors.NewInvalidParamsError(err))
+			return
+		}
+
+		for _, metric := range result.Metrics {
+			if _, ok := seen[string(metric.ID)]; ok {
+				continue
+			}
+			seen[string(metric.ID)] = struct{}{}
+			ids = append(ids, ident.BytesID(metric.ID))
+		}
+	}
+
+	// NB: invalidate once the deletes are applied, including when only some
+	// of them are, so results computed during the delete are also discarded.
+	if h.invalidations != nil {
+		defer h.invalidations.Invalidate(start, end)
+	}
+
+	var deleted int64
+	for _, ns := range h.clusters.ClusterNamespaces() {
+		session, ok := ns.Session().(client.AdminSession)
+		if !ok {
+			err := fmt.Errorf("session for namespace %s does not support "+
+				"deleting series", ns.NamespaceID())
+			xhttp.WriteError(w, err)
+			return
+		}
+
+		for i := 0; i < len(ids); i += deleteSeriesBatchSize {
+			batch := ids[i:minInt(i+deleteSeriesBatchSize, len(ids))]
+			n, err := session.DeleteSeries(ns.NamespaceID(), batch, start, end)
+			if err != nil {
+				logger.Error("unable to delete series",
+					zap.Stringer("namespace", ns.NamespaceID()), zap.Error(err))
+				xhttp.WriteError(w, err)
+				return
+			}
+			deleted += n
+		}
+	}
+
+	logger.Info("deleted series",
+		zap.Int("matched", len(ids)),
+		zap.Int64("deleted", deleted),
+		zap.Time("start", start),
+		zap.Time("end", end))
+
+	// NB: match the Prometheus API which responds with no content.
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeleteSeriesTestHandler(
+	t *testing.T,
+	store storage.Storage,
+	session client.Session,
+	invalidations *storage.Invalidations,
+) http.Handler {
+	fb, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{Timeout: 15 * time.Second})
+	require.NoError(t, err)
+
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("default"),
+		Session:     session,
+		Retention:   48 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	opts := options.EmptyHandlerOptions().
+		SetStorage(store).
+		SetClusters(clusters).
+		SetFetchOptionsBuilder(fb).
+		SetTagOptions(models.NewTagOptions()).
+		SetInvalidations(invalidations)
+	return NewPromDeleteSeriesHandler(opts)
+}
+
+func TestPromDeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		foo   = newTestMetric("__name__", "foo", "host", "a")
+		bar   = newTestMetric("__name__", "bar", "host", "a")
+		start = time.Unix(100, 0)
+		end   = time.Unix(200, 0)
+	)
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().SearchSeries(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			q *storage.FetchQuery,
+			_ *storage.FetchOptions,
+		) (*storage.SearchResults, error) {
+			assert.True(t, q.Start.Equal(start))
+			assert.True(t, q.End.Equal(end))
+			return &storage.SearchResults{
+				Metrics:  models.Metrics{foo, bar},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		}).Times(2)
+
+	session := client.NewMockAdminSession(ctrl)
+	session.EXPECT().
+		DeleteSeries(ident.NewIDMatcher("default"), gomock.Any(), start, end).
+		DoAndReturn(func(
+			_ ident.ID,
+			ids []ident.ID,
+			_, _ time.Time,
+		) (int64, error) {
+			// Series matched by both selectors are only deleted once.
+			require.Equal(t, 2, len(ids))
+			assert.Equal(t, string(foo.ID), ids[0].String())
+			assert.Equal(t, string(bar.ID), ids[1].String())
+			return 6, nil
+		})
+
+	var (
+		invalidations = storage.NewInvalidations(time.Hour, time.Now)
+		before        = time.Now()
+	)
+	h := newDeleteSeriesTestHandler(t, store, session, invalidations)
+	req := httptest.NewRequest(http.MethodPost,
+		"/admin/tsdb/delete_series?match[]=foo&match[]=%7Bhost%3D%22a%22%7D&start=100&end=200",
+		nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+
+	// The deleted time range is invalidated for results computed before the
+	// delete, but not for other time ranges.
+	assert.True(t, invalidations.InvalidatedSince(start, end, before))
+	assert.False(t, invalidations.InvalidatedSince(time.Unix(300, 0),
+		time.Unix(400, 0), before))
+}
+
+func TestPromDeleteSeriesNotExhaustive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := block.NewResultMetadata()
+	meta.Exhaustive = false
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().SearchSeries(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&storage.SearchResults{
+			Metrics:  models.Metrics{newTestMetric("__name__", "foo")},
+			Metadata: meta,
+		}, nil)
+
+	// No deletes should be issued for a partial set of series.
+	session := client.NewMockAdminSession(ctrl)
+
+	h := newDeleteSeriesTestHandler(t, store, session, nil)
+	req := httptest.NewRequest(http.MethodPost,
+		"/admin/tsdb/delete_series?match[]=foo", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestPromDeleteSeriesMissingMatchers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	session := client.NewMockAdminSession(ctrl)
+
+	h := newDeleteSeriesTestHandler(t, store, session, nil)
+	req := httptest.NewRequest(http.MethodPost, "/admin/tsdb/delete_series", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
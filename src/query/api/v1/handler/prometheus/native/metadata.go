@@ -0,0 +1,147 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PromMetadataURL is the url for the Prometheus metric metadata endpoint.
+	PromMetadataURL = handler.RoutePrefixV1 + "/metadata"
+
+	// PromMetadataHTTPMethod is the HTTP method used with this resource.
+	PromMetadataHTTPMethod = http.MethodGet
+
+	metricParam = "metric"
+
+	// unknownMetricType is the Prometheus metric type for metrics with no
+	// type information.
+	unknownMetricType = "unknown"
+)
+
+// metricMetadata is the metadata for a single metric.
+type metricMetadata struct {
+	Type string `json:"type"`
+	Help string `json:"help"`
+	Unit string `json:"unit"`
+}
+
+// PromMetadataHandler represents a handler for the metric metadata endpoint.
+//
+// NB: M3 does not store metric types, help text or units, so every metric
+// name known to the index is returned with an unknown type.
+type PromMetadataHandler struct {
+	storage             storage.Storage
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	tagOptions          models.TagOptions
+	nowFn               clock.NowFn
+	instrumentOpts      instrument.Options
+}
+
+// NewPromMetadataHandler returns a new instance of handler.
+func NewPromMetadataHandler(opts options.HandlerOptions) http.Handler {
+	return &PromMetadataHandler{
+		storage:             opts.Storage(),
+		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
+		tagOptions:          opts.TagOptions(),
+		nowFn:               opts.NowFn(),
+		instrumentOpts:      opts.InstrumentOpts(),
+	}
+}
+
+func (h *PromMetadataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	limit, err := parseLimit(r, -1)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	opts, rErr := h.fetchOptionsBuilder.NewFetchOptions(r)
+	if rErr != nil {
+		xhttp.WriteError(w, rErr)
+		return
+	}
+
+	metricName := h.tagOptions.MetricName()
+	matcher := models.Matcher{Type: models.MatchField, Name: metricName}
+	if metric := r.FormValue(metricParam); metric != "" {
+		matcher = models.Matcher{
+			Type:  models.MatchEqual,
+			Name:  metricName,
+			Value: []byte(metric),
+		}
+	}
+
+	query := &storage.CompleteTagsQuery{
+		CompleteNameOnly: false,
+		FilterNameTags:   [][]byte{metricName},
+		TagMatchers:      models.Matchers{matcher},
+
+		// NB: necessarily spans entire possible query range.
+		Start: time.Time{},
+		End:   h.nowFn(),
+	}
+
+	result, err := h.storage.CompleteTags(ctx, query, opts)
+	if err != nil {
+		logger.Error("unable to complete metric names", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	metadata := make(map[string][]metricMetadata)
+	for _, tag := range result.CompletedTags {
+		if !bytes.Equal(tag.Name, metricName) {
+			continue
+		}
+
+		for _, value := range tag.Values {
+			if limit >= 0 && len(metadata) >= limit {
+				break
+			}
+
+			metadata[string(value)] = []metricMetadata{{Type: unknownMetricType}}
+		}
+	}
+
+	handleroptions.AddResponseHeaders(w, result.Metadata, opts)
+	xhttp.WriteJSONResponse(w, newPromResponse(metadata), logger)
+}
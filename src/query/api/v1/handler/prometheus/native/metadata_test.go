@@ -0,0 +1,146 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMetadataTestHandler(
+	t *testing.T,
+	store storage.Storage,
+	now time.Time,
+) http.Handler {
+	fb, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{Timeout: 15 * time.Second})
+	require.NoError(t, err)
+
+	opts := options.EmptyHandlerOptions().
+		SetStorage(store).
+		SetFetchOptionsBuilder(fb).
+		SetTagOptions(models.NewTagOptions()).
+		SetNowFn(func() time.Time { return now })
+	return NewPromMetadataHandler(opts)
+}
+
+func TestPromMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	now := time.Now()
+	h := newMetadataTestHandler(t, store, now)
+
+	tests := []struct {
+		name    string
+		url     string
+		matcher models.Matcher
+		values  [][]byte
+		ex      string
+	}{
+		{
+			name:    "all metrics",
+			url:     "/metadata",
+			matcher: models.Matcher{Type: models.MatchField, Name: b("__name__")},
+			values:  [][]byte{b("bar"), b("foo")},
+			ex: `{"status":"success","data":{` +
+				`"bar":[{"type":"unknown","help":"","unit":""}],` +
+				`"foo":[{"type":"unknown","help":"","unit":""}]}}`,
+		},
+		{
+			name:    "limited metrics",
+			url:     "/metadata?limit=0",
+			matcher: models.Matcher{Type: models.MatchField, Name: b("__name__")},
+			values:  [][]byte{b("bar"), b("foo")},
+			ex:      `{"status":"success","data":{}}`,
+		},
+		{
+			name: "single metric",
+			url:  "/metadata?metric=foo",
+			matcher: models.Matcher{
+				Type:  models.MatchEqual,
+				Name:  b("__name__"),
+				Value: b("foo"),
+			},
+			values: [][]byte{b("foo")},
+			ex: `{"status":"success","data":{` +
+				`"foo":[{"type":"unknown","help":"","unit":""}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store.EXPECT().CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(
+					_ interface{},
+					q *storage.CompleteTagsQuery,
+					_ *storage.FetchOptions,
+				) (*consolidators.CompleteTagsResult, error) {
+					assert.False(t, q.CompleteNameOnly)
+					assert.Equal(t, [][]byte{b("__name__")}, q.FilterNameTags)
+					assert.Equal(t, models.Matchers{tt.matcher}, q.TagMatchers)
+					assert.True(t, q.End.Equal(now))
+					return &consolidators.CompleteTagsResult{
+						CompletedTags: []consolidators.CompletedTag{
+							{Name: b("__name__"), Values: tt.values},
+						},
+					}, nil
+				})
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			r, err := ioutil.ReadAll(w.Result().Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ex, string(r))
+		})
+	}
+}
+
+func TestPromMetadataInvalidLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	h := newMetadataTestHandler(t, store, time.Now())
+
+	req := httptest.NewRequest(http.MethodGet, "/metadata?limit=foo", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// PromQueryExemplarsURL is the url for the Prometheus exemplars endpoint.
+	PromQueryExemplarsURL = handler.RoutePrefixV1 + "/query_exemplars"
+
+	// defaultExemplarsRange is the default range to query exemplars over if no
+	// start or end is given.
+	defaultExemplarsRange = time.Hour
+)
+
+var (
+	// PromQueryExemplarsHTTPMethods are the HTTP methods for this handler.
+	PromQueryExemplarsHTTPMethods = []string{http.MethodGet, http.MethodPost}
+)
+
+// exemplarsResult is the set of exemplars for a single series.
+type exemplarsResult struct {
+	SeriesLabels map[string]string `json:"seriesLabels"`
+	Exemplars    []interface{}     `json:"exemplars"`
+}
+
+// PromQueryExemplarsHandler represents a handler for the exemplars endpoint.
+//
+// NB: M3 does not store exemplars, so valid queries always return an empty
+// result; this avoids errors in clients such as Grafana that request
+// exemplars alongside queries.
+type PromQueryExemplarsHandler struct {
+	engine         executor.Engine
+	tagOptions     models.TagOptions
+	nowFn          clock.NowFn
+	instrumentOpts instrument.Options
+}
+
+// NewPromQueryExemplarsHandler returns a new instance of handler.
+func NewPromQueryExemplarsHandler(opts options.HandlerOptions) http.Handler {
+	return &PromQueryExemplarsHandler{
+		engine:         opts.Engine(),
+		tagOptions:     opts.TagOptions(),
+		nowFn:          opts.NowFn(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *PromQueryExemplarsHandler) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	query, err := parseQuery(r)
+	if err != nil {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(
+			fmt.Errorf(formatErrStr, queryParam, err)))
+		return
+	}
+
+	parseOpts := promql.NewParseOptions()
+	if h.engine != nil {
+		parseOpts = h.engine.Options().ParseOptions()
+	}
+
+	if _, err := promql.Parse(query, time.Second, h.tagOptions,
+		parseOpts); err != nil {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(
+			fmt.Errorf(formatErrStr, queryParam, err)))
+		return
+	}
+
+	_, _, err = parseOptionalTimeRange(r, h.nowFn(), defaultExemplarsRange)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, newPromResponse([]exemplarsResult{}),
+		h.instrumentOpts.Logger())
+}
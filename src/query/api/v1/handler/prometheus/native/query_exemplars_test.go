@@ -0,0 +1,109 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromQueryExemplars(t *testing.T) {
+	opts := options.EmptyHandlerOptions().
+		SetTagOptions(models.NewTagOptions())
+	h := NewPromQueryExemplarsHandler(opts)
+
+	tests := []struct {
+		name   string
+		params url.Values
+		code   int
+	}{
+		{
+			name:   "valid query",
+			params: url.Values{"query": {`sum(rate(foo{bar="baz"}[1m]))`}},
+			code:   http.StatusOK,
+		},
+		{
+			name: "valid query with range",
+			params: url.Values{
+				"query": {"foo"},
+				"start": {"100"},
+				"end":   {"200"},
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:   "no query",
+			params: url.Values{},
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "invalid query",
+			params: url.Values{"query": {"sum(foo"}},
+			code:   http.StatusBadRequest,
+		},
+		{
+			name: "invalid range",
+			params: url.Values{
+				"query": {"foo"},
+				"start": {"200"},
+				"end":   {"100"},
+			},
+			code: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, method := range PromQueryExemplarsHTTPMethods {
+				var req *http.Request
+				if method == http.MethodGet {
+					req = httptest.NewRequest(method,
+						PromQueryExemplarsURL+"?"+tt.params.Encode(), nil)
+				} else {
+					req = httptest.NewRequest(method, PromQueryExemplarsURL,
+						strings.NewReader(tt.params.Encode()))
+					req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				}
+
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, req)
+				require.Equal(t, tt.code, w.Code, method)
+				if tt.code != http.StatusOK {
+					continue
+				}
+
+				r, err := ioutil.ReadAll(w.Result().Body)
+				require.NoError(t, err)
+				assert.Equal(t, `{"status":"success","data":[]}`, string(r))
+			}
+		})
+	}
+}
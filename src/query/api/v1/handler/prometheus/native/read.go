@@ -78,6 +78,7 @@ type promReadHandler struct {
 	instant         bool
 	promReadMetrics promReadMetrics
 	opts            options.HandlerOptions
+	cache           *resultCache
 }
 
 // NewPromReadHandler returns a new prometheus-compatible read handler.
@@ -104,6 +105,18 @@ func newHandler(opts options.HandlerOptions, instant bool) http.Handler {
 		opts:            opts,
 		instant:         instant,
 	}
+
+	if cfg := opts.Config().Query.Cache; cfg.Enabled && !instant {
+		cache, err := newResultCache(cfg, opts, taggedScope)
+		if err != nil {
+			opts.InstrumentOpts().Logger().Error(
+				"could not create result cache, results will not be cached",
+				zap.Error(err))
+		} else {
+			h.cache = cache
+		}
+	}
+
 	return h
 }
 
@@ -135,7 +148,16 @@ func (h *promReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	watcher := handler.NewResponseWriterCanceller(w, h.opts.InstrumentOpts())
 	parsedOptions.CancelWatcher = watcher
 
-	result, err := read(ctx, parsedOptions, h.opts)
+	var (
+		result ReadResult
+		err    error
+	)
+	if h.cache != nil {
+		fingerprint := resultCacheFingerprint(r, parsedOptions.Params)
+		result, err = h.cache.read(ctx, parsedOptions, fingerprint)
+	} else {
+		result, err = read(ctx, parsedOptions, h.opts)
+	}
 	if err != nil {
 		sp := xopentracing.SpanFromContextOrNoop(ctx)
 		sp.LogFields(opentracinglog.Error(err))
@@ -0,0 +1,521 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/x/headers"
+
+	"github.com/uber-go/tally"
+)
+
+// maxTime is used as the end of the data a query can depend on when the
+// query evaluates selectors at arbitrary times with the @ modifier.
+var maxTime = time.Unix(0, math.MaxInt64)
+
+// resultCache caches the results of range queries. Queries are split into
+// extents aligned to the split interval, and extents which are complete and
+// can no longer receive writes are cached so that only the uncached, most
+// recent extents of a repeated query are evaluated.
+//
+// NB: cached extents are only invalidated by series deleted through this
+// coordinator, since invalidations are not shared between coordinators.
+// Extents cached by other coordinators, or covering data deleted directly
+// from storage, are served until they expire after the cache TTL.
+type resultCache struct {
+	cfg     config.ResultCacheConfiguration
+	store   extentStore
+	opts    options.HandlerOptions
+	metrics resultCacheMetrics
+}
+
+type resultCacheMetrics struct {
+	hits        tally.Counter
+	misses      tally.Counter
+	evaluated   tally.Counter
+	invalidated tally.Counter
+	bypassed    tally.Counter
+}
+
+func newResultCacheMetrics(scope tally.Scope) resultCacheMetrics {
+	return resultCacheMetrics{
+		hits:        scope.Counter("hits"),
+		misses:      scope.Counter("misses"),
+		evaluated:   scope.Counter("evaluated"),
+		invalidated: scope.Counter("invalidated"),
+		bypassed:    scope.Counter("bypassed"),
+	}
+}
+
+func newResultCache(
+	cfg config.ResultCacheConfiguration,
+	opts options.HandlerOptions,
+	scope tally.Scope,
+) (*resultCache, error) {
+	scope = scope.SubScope("result-cache")
+
+	var (
+		store extentStore
+		err   error
+	)
+	switch storage := cfg.StorageOrDefault(); storage {
+	case config.MemoryResultCacheStorageType:
+		store = newMemoryExtentStore(cfg.MaxEntries, cfg.TTLOrDefault(),
+			opts.NowFn(), scope)
+	case config.DiskResultCacheStorageType:
+		store, err = newDiskExtentStore(cfg.Directory, cfg.TTLOrDefault(),
+			opts.NowFn())
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown result cache storage type: %s", storage)
+	}
+
+	return &resultCache{
+		cfg:     cfg,
+		store:   store,
+		opts:    opts,
+		metrics: newResultCacheMetrics(scope),
+	}, nil
+}
+
+// cutoff returns the time before which extents can be cached, which is when
+// data can no longer be written to any of the storage namespaces, or false
+// if a namespace accepts cold writes and so can be written to at any time.
+func (c *resultCache) cutoff() (time.Time, bool) {
+	var (
+		clusters  = c.opts.Clusters()
+		freshness time.Duration
+	)
+	if clusters == nil || len(clusters.ClusterNamespaces()) == 0 {
+		freshness = c.cfg.BufferPastOrDefault()
+	} else {
+		for _, ns := range clusters.ClusterNamespaces() {
+			opts := ns.Options()
+			if opts.ColdWritesEnabled() {
+				return time.Time{}, false
+			}
+
+			bufferPast := opts.BufferPast()
+			if bufferPast <= 0 {
+				bufferPast = c.cfg.BufferPastOrDefault()
+			}
+
+			if f := bufferPast + opts.Attributes().Resolution; f > freshness {
+				freshness = f
+			}
+		}
+	}
+
+	return c.opts.NowFn()().Add(-freshness), true
+}
+
+// invalidated returns true if data the extent depends on changed after the
+// extent was computed.
+func (c *resultCache) invalidated(extent cachedExtent, dataEnd time.Time) bool {
+	invalidations := c.opts.Invalidations()
+	if invalidations == nil {
+		return false
+	}
+
+	// NB: range selectors, offsets and lookback can depend on data from any
+	// time before the extent, so only the end of the data is bounded.
+	return invalidations.InvalidatedSince(time.Time{}, dataEnd,
+		extent.ComputedAt)
+}
+
+func (c *resultCache) read(
+	ctx context.Context,
+	parsed ParsedOptions,
+	fingerprint string,
+) (ReadResult, error) {
+	params := parsed.Params
+	cutoff, ok := c.cutoff()
+	// NB: queries which fail to parse are evaluated without the cache so
+	// that the parse error is returned as usual.
+	atModifier, queryBounds, err := queryAtModifiers(params.Query)
+	if !ok || err != nil || queryBounds {
+		c.metrics.bypassed.Inc(1)
+		return read(ctx, parsed, c.opts)
+	}
+
+	var (
+		interval = c.cfg.SplitIntervalOrDefault(params.Step)
+		extents  = splitQueryExtents(params, interval, cutoff)
+	)
+	if len(extents) == 0 || (len(extents) == 1 && !extents[0].cacheable) {
+		return read(ctx, parsed, c.opts)
+	}
+
+	// Detect clients closing connections once for all extents, rather than
+	// for each of the subqueries.
+	if parsed.CancelWatcher != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, params.Timeout)
+		defer cancel()
+		parsed.CancelWatcher.WatchForCancel(ctx, cancel)
+		parsed.CancelWatcher = nil
+	}
+
+	merger := newExtentMerger(params, c.opts.TagOptions())
+	for _, extent := range extents {
+		sub := parsed
+		sub.Params.Start = extent.start
+		sub.Params.End = extent.end
+		sub.Params.IncludeEnd = true
+
+		if !extent.cacheable {
+			c.metrics.evaluated.Inc(1)
+			result, err := read(ctx, sub, c.opts)
+			if err != nil {
+				return ReadResult{}, err
+			}
+
+			merger.add(newCachedExtent(result), result.Meta)
+			continue
+		}
+
+		key := fmt.Sprintf("%s;interval=%d;phase=%d;extent=%d", fingerprint,
+			interval, params.Start.UnixNano()%int64(params.Step), extent.index)
+		loaded := false
+		load := func(ctx context.Context) (cachedExtent, bool, error) {
+			loaded = true
+			// NB: record when the extent is computed before reading, so that
+			// data which changes during the read invalidates the extent.
+			computedAt := c.opts.NowFn()()
+			result, err := read(ctx, sub, c.opts)
+			if err != nil {
+				return cachedExtent{}, false, err
+			}
+
+			cached := newCachedExtent(result)
+			cached.ComputedAt = computedAt
+			return cached, cacheableResult(result.Meta), nil
+		}
+
+		cached, err := c.store.Get(ctx, key, load)
+		if err != nil {
+			return ReadResult{}, err
+		}
+
+		dataEnd := extent.end
+		if atModifier {
+			// NB: selectors using the @ modifier can depend on data at
+			// any time.
+			dataEnd = maxTime
+		}
+
+		if !loaded && c.invalidated(cached, dataEnd) {
+			c.metrics.invalidated.Inc(1)
+			var cacheable bool
+			cached, cacheable, err = load(ctx)
+			if err != nil {
+				return ReadResult{}, err
+			}
+
+			if cacheable {
+				c.store.Put(key, cached)
+			}
+		}
+
+		if loaded {
+			c.metrics.misses.Inc(1)
+		} else {
+			c.metrics.hits.Inc(1)
+		}
+
+		merger.add(cached, cached.meta())
+	}
+
+	result := merger.result()
+	result.Series = prometheus.FilterSeriesByOptions(result.Series, parsed.FetchOpts)
+	return result, nil
+}
+
+// queryAtModifiers returns whether the query uses the @ modifier, and whether
+// it uses the @ start() or @ end() modifiers, which evaluate selectors at the
+// bounds of the whole query and so cannot be evaluated per extent.
+func queryAtModifiers(query string) (bool, bool, error) {
+	kinds, err := promql.AtModifierKinds(query)
+	if err != nil {
+		return false, false, err
+	}
+
+	for _, kind := range kinds {
+		if kind == temporal.AtStart || kind == temporal.AtEnd {
+			return true, true, nil
+		}
+	}
+
+	return len(kinds) > 0, false, nil
+}
+
+// cacheableResult returns true if the result is complete, since partial
+// results should be re-evaluated rather than served from the cache.
+func cacheableResult(meta block.ResultMetadata) bool {
+	return meta.Exhaustive && len(meta.Warnings) == 0
+}
+
+// resultCacheFingerprint returns a key identifying the parameters of a range
+// query which affect its result, other than its time range.
+func resultCacheFingerprint(r *http.Request, params models.RequestParams) string {
+	keys := make([]string, 0, len(r.Form))
+	for k := range r.Form {
+		switch k {
+		case startParam, endParam, timeParam, endExclusiveParam,
+			handleroptions.TimeoutParam:
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%q;", k, r.Form[k])
+	}
+
+	names := make([]string, 0, len(r.Header))
+	for k := range r.Header {
+		if strings.HasPrefix(k, headers.M3HeaderPrefix) {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		fmt.Fprintf(&b, "%s=%q;", k, r.Header[k])
+	}
+
+	fmt.Fprintf(&b, "lookback=%d", params.LookbackDuration)
+	return b.String()
+}
+
+// queryExtent is a contiguous range of steps of a query which is evaluated
+// as a single subquery.
+type queryExtent struct {
+	// start and end are the first and last step of the extent.
+	start time.Time
+	end   time.Time
+	// index is the index of the split interval the extent covers.
+	index int64
+	// cacheable is set if the extent covers all steps of the split interval,
+	// and the split interval can no longer receive writes.
+	cacheable bool
+}
+
+// splitQueryExtents splits the steps of a query into extents by the given
+// interval, coalescing adjacent extents that cannot be cached.
+func splitQueryExtents(
+	params models.RequestParams,
+	interval time.Duration,
+	cutoff time.Time,
+) []queryExtent {
+	step := params.Step
+	if step <= 0 || interval < step {
+		return nil
+	}
+
+	var (
+		steps   = int(params.ExclusiveEnd().Sub(params.Start) / step)
+		extents []queryExtent
+	)
+	for k := 0; k < steps; {
+		var (
+			first       = params.Start.Add(time.Duration(k) * step)
+			index       = first.UnixNano() / int64(interval)
+			intervalEnd = time.Unix(0, (index+1)*int64(interval))
+			n           = int((intervalEnd.Sub(first)-1)/step) + 1
+		)
+		if k+n > steps {
+			n = steps - k
+		}
+
+		var (
+			last     = first.Add(time.Duration(n-1) * step)
+			complete = first.Add(-step).UnixNano()/int64(interval) < index &&
+				!last.Add(step).Before(intervalEnd)
+			extent = queryExtent{
+				start:     first,
+				end:       last,
+				index:     index,
+				cacheable: complete && !intervalEnd.After(cutoff),
+			}
+		)
+
+		k += n
+		if l := len(extents) - 1; l >= 0 && !extents[l].cacheable && !extent.cacheable {
+			extents[l].end = extent.end
+			continue
+		}
+
+		extents = append(extents, extent)
+	}
+
+	return extents
+}
+
+// cachedExtent is the result of evaluating an extent of a query.
+type cachedExtent struct {
+	Series      []cachedSeries
+	LocalOnly   bool
+	Resolutions []time.Duration
+	KeepNaNs    bool
+	BlockType   block.BlockType
+	ComputedAt  time.Time
+}
+
+type cachedSeries struct {
+	Name   []byte
+	Tags   []models.Tag
+	Start  time.Time
+	Values []float64
+}
+
+func newCachedExtent(result ReadResult) cachedExtent {
+	extent := cachedExtent{
+		Series:      make([]cachedSeries, 0, len(result.Series)),
+		LocalOnly:   result.Meta.LocalOnly,
+		Resolutions: result.Meta.Resolutions,
+		KeepNaNs:    result.Meta.KeepNaNs,
+		BlockType:   result.BlockType,
+	}
+
+	for _, s := range result.Series {
+		var (
+			vals   = s.Values()
+			series = cachedSeries{
+				Name:   s.Name(),
+				Tags:   s.Tags.Tags,
+				Values: make([]float64, 0, vals.Len()),
+			}
+		)
+		for i := 0; i < vals.Len(); i++ {
+			dp := vals.DatapointAt(i)
+			if i == 0 {
+				series.Start = dp.Timestamp
+			}
+			series.Values = append(series.Values, dp.Value)
+		}
+
+		extent.Series = append(extent.Series, series)
+	}
+
+	return extent
+}
+
+// meta returns the result metadata of a cached extent, which only contains
+// results that were exhaustive and had no warnings.
+func (e cachedExtent) meta() block.ResultMetadata {
+	meta := block.NewResultMetadata()
+	meta.LocalOnly = e.LocalOnly
+	meta.Resolutions = e.Resolutions
+	meta.KeepNaNs = e.KeepNaNs
+	return meta
+}
+
+// extentMerger merges the results of the extents of a query, matching series
+// across extents by their tags.
+type extentMerger struct {
+	params    models.RequestParams
+	steps     int
+	tagOpts   models.TagOptions
+	started   bool
+	meta      block.ResultMetadata
+	blockType block.BlockType
+	seriesIdx map[string]int
+	series    []*ts.Series
+	values    []ts.FixedResolutionMutableValues
+}
+
+func newExtentMerger(
+	params models.RequestParams,
+	tagOpts models.TagOptions,
+) *extentMerger {
+	return &extentMerger{
+		params:    params,
+		steps:     int(params.ExclusiveEnd().Sub(params.Start) / params.Step),
+		tagOpts:   tagOpts,
+		meta:      block.NewResultMetadata(),
+		blockType: block.BlockEmpty,
+		seriesIdx: make(map[string]int),
+	}
+}
+
+func (m *extentMerger) add(extent cachedExtent, meta block.ResultMetadata) {
+	if !m.started {
+		m.started = true
+		m.meta = meta
+		m.blockType = extent.BlockType
+	} else {
+		m.meta = m.meta.CombineMetadata(meta)
+	}
+
+	for _, s := range extent.Series {
+		tags := models.NewTags(len(s.Tags), m.tagOpts)
+		for _, tag := range s.Tags {
+			tags = tags.AddTagWithoutNormalizing(tag)
+		}
+
+		id := string(tags.ID())
+		idx, ok := m.seriesIdx[id]
+		if !ok {
+			idx = len(m.series)
+			m.seriesIdx[id] = idx
+			values := ts.NewFixedStepValues(m.params.Step, m.steps, math.NaN(),
+				m.params.Start)
+			m.values = append(m.values, values)
+			m.series = append(m.series, ts.NewSeries(s.Name, values, tags))
+		}
+
+		values := m.values[idx]
+		offset := int(s.Start.Sub(m.params.Start) / m.params.Step)
+		for i, v := range s.Values {
+			if j := offset + i; j >= 0 && j < m.steps {
+				values.SetValueAt(j, v)
+			}
+		}
+	}
+}
+
+func (m *extentMerger) result() ReadResult {
+	return ReadResult{
+		Series:    m.series,
+		Meta:      m.meta,
+		BlockType: m.blockType,
+	}
+}
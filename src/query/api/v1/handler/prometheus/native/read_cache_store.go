@@ -0,0 +1,233 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/m3db/m3/src/x/cache"
+	"github.com/m3db/m3/src/x/clock"
+
+	"github.com/uber-go/tally"
+)
+
+const diskExtentFileSuffix = ".extent"
+
+var errResultCacheDirectoryNotSet = errors.New("result cache directory not set")
+
+// extentLoadFn evaluates an extent, returning whether the result can be cached.
+type extentLoadFn func(ctx context.Context) (cachedExtent, bool, error)
+
+// extentStore stores the results of query extents.
+type extentStore interface {
+	// Get returns the extent for the key, loading and storing it if it is not
+	// cached or has expired.
+	Get(ctx context.Context, key string, load extentLoadFn) (cachedExtent, error)
+	// Put stores the extent for the key, replacing any cached extent.
+	Put(key string, extent cachedExtent)
+}
+
+type memoryExtentStore struct {
+	lru   *cache.LRU
+	nowFn clock.NowFn
+}
+
+func newMemoryExtentStore(
+	maxEntries int,
+	ttl time.Duration,
+	nowFn clock.NowFn,
+	scope tally.Scope,
+) extentStore {
+	return &memoryExtentStore{
+		lru: cache.NewLRU(&cache.LRUOptions{
+			MaxEntries: maxEntries,
+			TTL:        ttl,
+			Metrics:    scope,
+			Now:        nowFn,
+		}),
+		nowFn: nowFn,
+	}
+}
+
+func (s *memoryExtentStore) Get(
+	ctx context.Context,
+	key string,
+	load extentLoadFn,
+) (cachedExtent, error) {
+	value, err := s.lru.GetWithTTL(ctx, key, func(
+		ctx context.Context,
+		_ string,
+	) (interface{}, time.Time, error) {
+		extent, cacheable, err := load(ctx)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		var expiresAt time.Time
+		if !cacheable {
+			// NB: expire immediately so that the extent is evaluated again
+			// by the next query.
+			expiresAt = s.nowFn()
+		}
+
+		return extent, expiresAt, nil
+	})
+	if err != nil {
+		return cachedExtent{}, err
+	}
+
+	return value.(cachedExtent), nil
+}
+
+func (s *memoryExtentStore) Put(key string, extent cachedExtent) {
+	s.lru.Put(key, extent)
+}
+
+type diskExtentStore struct {
+	dir   string
+	ttl   time.Duration
+	nowFn clock.NowFn
+}
+
+func newDiskExtentStore(
+	dir string,
+	ttl time.Duration,
+	nowFn clock.NowFn,
+) (extentStore, error) {
+	if dir == "" {
+		return nil, errResultCacheDirectoryNotSet
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	s := &diskExtentStore{
+		dir:   dir,
+		ttl:   ttl,
+		nowFn: nowFn,
+	}
+
+	// Remove any extents which expired while the coordinator was not running.
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range files {
+		if filepath.Ext(f.Name()) == diskExtentFileSuffix && s.expired(f) {
+			_ = os.Remove(filepath.Join(dir, f.Name()))
+		}
+	}
+
+	return s, nil
+}
+
+func (s *diskExtentStore) expired(info os.FileInfo) bool {
+	return !info.ModTime().Add(s.ttl).After(s.nowFn())
+}
+
+func (s *diskExtentStore) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(hash[:])+diskExtentFileSuffix)
+}
+
+func (s *diskExtentStore) Get(
+	ctx context.Context,
+	key string,
+	load extentLoadFn,
+) (cachedExtent, error) {
+	path := s.path(key)
+	if extent, ok := s.read(path); ok {
+		return extent, nil
+	}
+
+	extent, cacheable, err := load(ctx)
+	if err != nil || !cacheable {
+		return extent, err
+	}
+
+	// NB: failing to cache an extent does not fail the query.
+	_ = s.write(path, extent)
+	return extent, nil
+}
+
+func (s *diskExtentStore) Put(key string, extent cachedExtent) {
+	// NB: failing to cache an extent does not fail the query.
+	_ = s.write(s.path(key), extent)
+}
+
+func (s *diskExtentStore) read(path string) (cachedExtent, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return cachedExtent{}, false
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return cachedExtent{}, false
+	}
+
+	if s.expired(info) {
+		_ = os.Remove(path)
+		return cachedExtent{}, false
+	}
+
+	var extent cachedExtent
+	if err := gob.NewDecoder(f).Decode(&extent); err != nil {
+		_ = os.Remove(path)
+		return cachedExtent{}, false
+	}
+
+	return extent, true
+}
+
+func (s *diskExtentStore) write(path string, extent cachedExtent) error {
+	f, err := ioutil.TempFile(s.dir, "tmp-")
+	if err != nil {
+		return err
+	}
+
+	if err := gob.NewEncoder(f).Encode(extent); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	// Rename the written file into place so concurrent reads never observe
+	// a partially written extent.
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,321 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+var resultCacheTestStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestSplitQueryExtents(t *testing.T) {
+	at := func(d time.Duration) time.Time {
+		return resultCacheTestStart.Add(d)
+	}
+
+	index := resultCacheTestStart.UnixNano() / int64(time.Hour)
+	params := models.RequestParams{
+		Start:      at(30 * time.Minute),
+		End:        at(3*time.Hour + 45*time.Minute),
+		Step:       15 * time.Minute,
+		IncludeEnd: true,
+	}
+
+	extents := splitQueryExtents(params, time.Hour, at(3*time.Hour+10*time.Minute))
+	assert.Equal(t, []queryExtent{
+		{start: at(30 * time.Minute), end: at(45 * time.Minute), index: index},
+		{
+			start:     at(time.Hour),
+			end:       at(time.Hour + 45*time.Minute),
+			index:     index + 1,
+			cacheable: true,
+		},
+		{
+			start:     at(2 * time.Hour),
+			end:       at(2*time.Hour + 45*time.Minute),
+			index:     index + 2,
+			cacheable: true,
+		},
+		{
+			start: at(3 * time.Hour),
+			end:   at(3*time.Hour + 45*time.Minute),
+			index: index + 3,
+		},
+	}, extents)
+
+	// Extents which cannot be cached are coalesced.
+	extents = splitQueryExtents(params, time.Hour, at(0))
+	assert.Equal(t, []queryExtent{
+		{
+			start: at(30 * time.Minute),
+			end:   at(3*time.Hour + 45*time.Minute),
+			index: index,
+		},
+	}, extents)
+}
+
+func TestResultCacheReadMemory(t *testing.T) {
+	testResultCacheRead(t, config.ResultCacheConfiguration{
+		Storage: config.MemoryResultCacheStorageType,
+	})
+}
+
+func TestResultCacheReadDisk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "result-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	testResultCacheRead(t, config.ResultCacheConfiguration{
+		Storage:   config.DiskResultCacheStorageType,
+		Directory: dir,
+	})
+}
+
+func testResultCacheRead(t *testing.T, cfg config.ResultCacheConfiguration) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var evaluated []models.RequestParams
+	engine := executor.NewMockEngine(ctrl)
+	engine.EXPECT().Options().Return(executor.NewEngineOptions()).AnyTimes()
+	engine.EXPECT().
+		ExecuteExpr(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ parser.Parser,
+			_ *executor.QueryOptions,
+			_ *storage.FetchOptions,
+			params models.RequestParams,
+		) (block.Block, error) {
+			evaluated = append(evaluated, params)
+			bounds := models.Bounds{
+				Start:    params.Start,
+				Duration: params.ExclusiveEnd().Sub(params.Start),
+				StepSize: params.Step,
+			}
+
+			values := make([]float64, 0, bounds.Steps())
+			for i := 0; i < bounds.Steps(); i++ {
+				values = append(values, float64(bounds.Start.Add(
+					time.Duration(i)*bounds.StepSize).Unix()))
+			}
+
+			return test.NewBlockFromValuesWithSeriesMeta(bounds,
+				test.NewSeriesMeta("foo", 1), [][]float64{values}), nil
+		}).
+		AnyTimes()
+
+	setup := newTestSetup(t, engine)
+	now := resultCacheTestStart.Add(3*time.Hour + 10*time.Minute)
+	nowFn := func() time.Time { return now }
+	interval := time.Hour
+	bufferPast := time.Duration(0)
+	cfg.Enabled = true
+	cfg.SplitInterval = &interval
+	cfg.BufferPast = &bufferPast
+	invalidations := storage.NewInvalidations(time.Hour, nowFn)
+	opts := setup.options.
+		SetNowFn(nowFn).
+		SetInvalidations(invalidations)
+
+	cache, err := newResultCache(cfg, opts, tally.NoopScope)
+	require.NoError(t, err)
+
+	// NB: an @ in a label value is not an @ modifier, so does not prevent
+	// the query from being cached or invalidated by range.
+	params := models.RequestParams{
+		Start:      resultCacheTestStart.Add(30 * time.Minute),
+		End:        resultCacheTestStart.Add(3*time.Hour + 45*time.Minute),
+		Now:        now,
+		Step:       15 * time.Minute,
+		Query:      `foo{email="a@b"}`,
+		IncludeEnd: true,
+		Timeout:    time.Minute,
+	}
+	parsed := ParsedOptions{
+		QueryOpts: setup.QueryOpts,
+		FetchOpts: setup.FetchOpts,
+		Params:    params,
+	}
+
+	verify := func(result ReadResult) {
+		require.Equal(t, 1, len(result.Series))
+		vals := result.Series[0].Values()
+		require.Equal(t, 14, vals.Len())
+		for i := 0; i < vals.Len(); i++ {
+			expected := params.Start.Add(time.Duration(i) * params.Step)
+			dp := vals.DatapointAt(i)
+			assert.Equal(t, expected, dp.Timestamp)
+			assert.Equal(t, float64(expected.Unix()), dp.Value)
+		}
+	}
+
+	result, err := cache.read(context.Background(), parsed, "foo")
+	require.NoError(t, err)
+	verify(result)
+	require.Equal(t, 4, len(evaluated))
+
+	// Only the extents which could not be cached are evaluated again.
+	evaluated = evaluated[:0]
+	result, err = cache.read(context.Background(), parsed, "foo")
+	require.NoError(t, err)
+	verify(result)
+	require.Equal(t, 2, len(evaluated))
+	assert.Equal(t, params.Start, evaluated[0].Start)
+	assert.Equal(t, resultCacheTestStart.Add(3*time.Hour), evaluated[1].Start)
+	assert.Equal(t, params.End, evaluated[1].End)
+
+	// Invalidating data after the query does not affect cached extents.
+	invalidations.Invalidate(params.End.Add(time.Hour), params.End.Add(2*time.Hour))
+	evaluated = evaluated[:0]
+	result, err = cache.read(context.Background(), parsed, "foo")
+	require.NoError(t, err)
+	verify(result)
+	require.Equal(t, 2, len(evaluated))
+
+	// Cached extents which include invalidated data are evaluated again.
+	invalidations.Invalidate(params.Start, params.Start.Add(time.Hour))
+	now = now.Add(time.Second)
+	evaluated = evaluated[:0]
+	result, err = cache.read(context.Background(), parsed, "foo")
+	require.NoError(t, err)
+	verify(result)
+	require.Equal(t, 4, len(evaluated))
+
+	// And cached again once evaluated after the invalidation.
+	evaluated = evaluated[:0]
+	result, err = cache.read(context.Background(), parsed, "foo")
+	require.NoError(t, err)
+	verify(result)
+	require.Equal(t, 2, len(evaluated))
+}
+
+func TestResultCacheReadBypass(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var evaluated []models.RequestParams
+	engine := executor.NewMockEngine(ctrl)
+	engine.EXPECT().Options().Return(executor.NewEngineOptions()).AnyTimes()
+	engine.EXPECT().
+		ExecuteExpr(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ parser.Parser,
+			_ *executor.QueryOptions,
+			_ *storage.FetchOptions,
+			params models.RequestParams,
+		) (block.Block, error) {
+			evaluated = append(evaluated, params)
+			bounds := models.Bounds{
+				Start:    params.Start,
+				Duration: params.ExclusiveEnd().Sub(params.Start),
+				StepSize: params.Step,
+			}
+			return test.NewBlockFromValues(bounds, [][]float64{}), nil
+		}).
+		AnyTimes()
+
+	coldWrites, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID:       ident.StringID("default"),
+		Session:           client.NewMockSession(ctrl),
+		Retention:         48 * time.Hour,
+		ColdWritesEnabled: true,
+	})
+	require.NoError(t, err)
+
+	setup := newTestSetup(t, engine)
+	now := resultCacheTestStart.Add(3*time.Hour + 10*time.Minute)
+	interval := time.Hour
+	bufferPast := time.Duration(0)
+	cfg := config.ResultCacheConfiguration{
+		Enabled:       true,
+		SplitInterval: &interval,
+		BufferPast:    &bufferPast,
+	}
+
+	params := models.RequestParams{
+		Start:      resultCacheTestStart.Add(30 * time.Minute),
+		End:        resultCacheTestStart.Add(3*time.Hour + 45*time.Minute),
+		Now:        now,
+		Step:       15 * time.Minute,
+		IncludeEnd: true,
+		Timeout:    time.Minute,
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		clusters m3.Clusters
+	}{
+		{name: "at start", query: "foo @ start()"},
+		{name: "at end", query: "rate(foo[5m] @ end())"},
+		{name: "cold writes", query: "foo", clusters: coldWrites},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := setup.options.
+				SetNowFn(func() time.Time { return now }).
+				SetClusters(tt.clusters)
+			cache, err := newResultCache(cfg, opts, tally.NoopScope)
+			require.NoError(t, err)
+
+			parsed := ParsedOptions{
+				QueryOpts: setup.QueryOpts,
+				FetchOpts: setup.FetchOpts,
+				Params:    params,
+			}
+			parsed.Params.Query = tt.query
+
+			// The whole query is evaluated with its original bounds each time.
+			for i := 0; i < 2; i++ {
+				evaluated = evaluated[:0]
+				_, err = cache.read(context.Background(), parsed, tt.query)
+				require.NoError(t, err)
+				require.Equal(t, 1, len(evaluated))
+				assert.Equal(t, params.Start, evaluated[0].Start)
+				assert.Equal(t, params.End, evaluated[0].End)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PromBuildInfoURL is the url for the Prometheus build info endpoint.
+	PromBuildInfoURL = handler.RoutePrefixV1 + "/status/buildinfo"
+
+	// PromBuildInfoHTTPMethod is the HTTP method used with this resource.
+	PromBuildInfoHTTPMethod = http.MethodGet
+
+	// PromTSDBStatusURL is the url for the Prometheus TSDB status endpoint.
+	PromTSDBStatusURL = handler.RoutePrefixV1 + "/status/tsdb"
+
+	// PromTSDBStatusHTTPMethod is the HTTP method used with this resource.
+	PromTSDBStatusHTTPMethod = http.MethodGet
+
+	// defaultTSDBStatusLimit is the default number of entries returned for each
+	// of the TSDB status cardinality lists.
+	defaultTSDBStatusLimit = 10
+	// defaultTSDBStatusRange is the default range of the index to compute TSDB
+	// status over, equivalent to the Prometheus head block.
+	defaultTSDBStatusRange = 2 * time.Hour
+	// defaultTSDBStatusSeriesLimit is the max number of series that series
+	// counts are computed from, which bounds the memory used by the request
+	// regardless of the number of series in the index.
+	defaultTSDBStatusSeriesLimit = 10000
+)
+
+// buildInfo is the build information of the running binary.
+type buildInfo struct {
+	Version   string `json:"version"`
+	Revision  string `json:"revision"`
+	Branch    string `json:"branch"`
+	BuildUser string `json:"buildUser"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+}
+
+type promBuildInfoHandler struct {
+	instrumentOpts instrument.Options
+}
+
+// NewPromBuildInfoHandler returns a new instance of handler.
+func NewPromBuildInfoHandler(opts options.HandlerOptions) http.Handler {
+	return &promBuildInfoHandler{
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *promBuildInfoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	xhttp.WriteJSONResponse(w, newPromResponse(buildInfo{
+		Version:   instrument.Version,
+		Revision:  instrument.Revision,
+		Branch:    instrument.Branch,
+		BuildDate: instrument.BuildDate,
+		GoVersion: runtime.Version(),
+	}), h.instrumentOpts.Logger())
+}
+
+// tsdbStat is a single cardinality statistic.
+type tsdbStat struct {
+	Name  string `json:"name"`
+	Value uint64 `json:"value"`
+}
+
+// tsdbHeadStats describes the series in the queried index range.
+type tsdbHeadStats struct {
+	NumSeries  uint64 `json:"numSeries"`
+	ChunkCount int64  `json:"chunkCount"`
+	MinTime    int64  `json:"minTime"`
+	MaxTime    int64  `json:"maxTime"`
+}
+
+// tsdbStatus is the cardinality status of the index.
+type tsdbStatus struct {
+	HeadStats                   tsdbHeadStats `json:"headStats"`
+	SeriesCountByMetricName     []tsdbStat    `json:"seriesCountByMetricName"`
+	LabelValueCountByLabelName  []tsdbStat    `json:"labelValueCountByLabelName"`
+	MemoryInBytesByLabelName    []tsdbStat    `json:"memoryInBytesByLabelName"`
+	SeriesCountByLabelValuePair []tsdbStat    `json:"seriesCountByLabelValuePair"`
+}
+
+// PromTSDBStatusHandler represents a handler for the TSDB status endpoint,
+// which returns the top label and metric name cardinalities in the index.
+type PromTSDBStatusHandler struct {
+	storage             storage.Storage
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	tagOptions          models.TagOptions
+	nowFn               clock.NowFn
+	instrumentOpts      instrument.Options
+}
+
+// NewPromTSDBStatusHandler returns a new instance of handler.
+func NewPromTSDBStatusHandler(opts options.HandlerOptions) http.Handler {
+	return &PromTSDBStatusHandler{
+		storage:             opts.Storage(),
+		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
+		tagOptions:          opts.TagOptions(),
+		nowFn:               opts.NowFn(),
+		instrumentOpts:      opts.InstrumentOpts(),
+	}
+}
+
+func (h *PromTSDBStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	start, end, err := parseOptionalTimeRange(r, h.nowFn(),
+		defaultTSDBStatusRange)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	limit, err := parseLimit(r, defaultTSDBStatusLimit)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	// NB: the limit param is the number of top stats to return rather than a
+	// series limit, so it is removed before building the fetch options.
+	fetchReq := r.Clone(r.Context())
+	fetchReq.Form.Del(limitParam)
+	opts, rErr := h.fetchOptionsBuilder.NewFetchOptions(fetchReq)
+	if rErr != nil {
+		xhttp.WriteError(w, rErr)
+		return
+	}
+
+	matchers := models.Matchers{{Type: models.MatchAll}}
+	tagsResult, err := h.storage.CompleteTags(ctx, &storage.CompleteTagsQuery{
+		CompleteNameOnly: false,
+		TagMatchers:      matchers,
+		Start:            start,
+		End:              end,
+	}, opts)
+	if err != nil {
+		logger.Error("unable to complete tags", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	// NB: label value cardinalities are computed by the index aggregate query
+	// above, whereas series counts require fetching the series so they are
+	// computed from a bounded number of series.
+	seriesOpts := opts.Clone()
+	if seriesOpts.SeriesLimit <= 0 || seriesOpts.SeriesLimit > defaultTSDBStatusSeriesLimit {
+		seriesOpts.SeriesLimit = defaultTSDBStatusSeriesLimit
+	}
+
+	seriesResult, err := h.storage.SearchSeries(ctx, &storage.FetchQuery{
+		TagMatchers: matchers,
+		Start:       start,
+		End:         end,
+	}, seriesOpts)
+	if err != nil {
+		logger.Error("unable to search series", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	var (
+		valueCounts   = make(map[string]uint64, len(tagsResult.CompletedTags))
+		valueBytes    = make(map[string]uint64, len(tagsResult.CompletedTags))
+		metricCounts  = make(map[string]uint64)
+		labelCounts   = make(map[string]uint64)
+		metricNameTag = h.tagOptions.MetricName()
+	)
+
+	for _, tag := range tagsResult.CompletedTags {
+		name := string(tag.Name)
+		valueCounts[name] = uint64(len(tag.Values))
+		for _, value := range tag.Values {
+			valueBytes[name] += uint64(len(value))
+		}
+	}
+
+	for _, metric := range seriesResult.Metrics {
+		for _, tag := range metric.Tags.Tags {
+			if bytes.Equal(tag.Name, metricNameTag) {
+				metricCounts[string(tag.Value)]++
+			}
+
+			labelCounts[string(tag.Name)+"="+string(tag.Value)]++
+		}
+	}
+
+	status := tsdbStatus{
+		HeadStats: tsdbHeadStats{
+			NumSeries: uint64(len(seriesResult.Metrics)),
+			MinTime:   start.UnixNano() / int64(time.Millisecond),
+			MaxTime:   end.UnixNano() / int64(time.Millisecond),
+		},
+		SeriesCountByMetricName:     topTSDBStats(metricCounts, limit),
+		LabelValueCountByLabelName:  topTSDBStats(valueCounts, limit),
+		MemoryInBytesByLabelName:    topTSDBStats(valueBytes, limit),
+		SeriesCountByLabelValuePair: topTSDBStats(labelCounts, limit),
+	}
+
+	// Mark any partial counts rather than returning them as if they were complete.
+	resp := newPromResponse(status)
+	if !tagsResult.Metadata.Exhaustive {
+		resp.Warnings = append(resp.Warnings,
+			"label value counts are not exhaustive")
+	}
+	if !seriesResult.Metadata.Exhaustive {
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf(
+			"series counts are computed from %d series and are not exhaustive",
+			len(seriesResult.Metrics)))
+	}
+
+	meta := tagsResult.Metadata.CombineMetadata(seriesResult.Metadata)
+	handleroptions.AddResponseHeaders(w, meta, opts)
+	xhttp.WriteJSONResponse(w, resp, logger)
+}
+
+// topTSDBStats returns the given number of stats with the highest values,
+// sorted by value then name.
+func topTSDBStats(stats map[string]uint64, limit int) []tsdbStat {
+	result := make([]tsdbStat, 0, len(stats))
+	for name, value := range stats {
+		result = append(result, tsdbStat{Name: name, Value: value})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Value != result[j].Value {
+			return result[i].Value > result[j].Value
+		}
+
+		return result[i].Name < result[j].Name
+	})
+
+	if limit >= 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromBuildInfo(t *testing.T) {
+	h := NewPromBuildInfoHandler(options.EmptyHandlerOptions())
+	req := httptest.NewRequest(http.MethodGet, PromBuildInfoURL, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Status string    `json:"status"`
+		Data   buildInfo `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, statusSuccess, resp.Status)
+	assert.Equal(t, buildInfo{
+		Version:   instrument.Version,
+		Revision:  instrument.Revision,
+		Branch:    instrument.Branch,
+		BuildDate: instrument.BuildDate,
+		GoVersion: runtime.Version(),
+	}, resp.Data)
+}
+
+func newTestMetric(tags ...string) models.Metric {
+	metricTags := models.NewTags(len(tags)/2, models.NewTagOptions())
+	for i := 0; i < len(tags); i += 2 {
+		metricTags = metricTags.AddTag(models.Tag{
+			Name:  b(tags[i]),
+			Value: b(tags[i+1]),
+		})
+	}
+
+	return models.Metric{ID: metricTags.ID(), Tags: metricTags}
+}
+
+func TestPromTSDBStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	now := time.Now().Truncate(time.Millisecond)
+	start := now.Add(-1 * defaultTSDBStatusRange)
+
+	store.EXPECT().CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			q *storage.CompleteTagsQuery,
+			_ *storage.FetchOptions,
+		) (*consolidators.CompleteTagsResult, error) {
+			assert.False(t, q.CompleteNameOnly)
+			assert.True(t, q.Start.Equal(start))
+			assert.True(t, q.End.Equal(now))
+			return &consolidators.CompleteTagsResult{
+				CompletedTags: []consolidators.CompletedTag{
+					{Name: b("__name__"), Values: [][]byte{b("bar"), b("foo")}},
+					{Name: b("host"), Values: [][]byte{b("a"), b("b"), b("c")}},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	store.EXPECT().SearchSeries(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			q *storage.FetchQuery,
+			o *storage.FetchOptions,
+		) (*storage.SearchResults, error) {
+			assert.True(t, q.Start.Equal(start))
+			assert.True(t, q.End.Equal(now))
+			assert.Equal(t, defaultTSDBStatusSeriesLimit, o.SeriesLimit)
+			return &storage.SearchResults{
+				Metrics: models.Metrics{
+					newTestMetric("__name__", "foo", "host", "a"),
+					newTestMetric("__name__", "foo", "host", "b"),
+					newTestMetric("__name__", "foo", "host", "c"),
+					newTestMetric("__name__", "bar", "host", "a"),
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	fb, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{Timeout: 15 * time.Second})
+	require.NoError(t, err)
+
+	opts := options.EmptyHandlerOptions().
+		SetStorage(store).
+		SetFetchOptionsBuilder(fb).
+		SetTagOptions(models.NewTagOptions()).
+		SetNowFn(func() time.Time { return now })
+	h := NewPromTSDBStatusHandler(opts)
+
+	req := httptest.NewRequest(http.MethodGet, PromTSDBStatusURL+"?limit=2", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Status   string     `json:"status"`
+		Data     tsdbStatus `json:"data"`
+		Warnings []string   `json:"warnings"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, statusSuccess, resp.Status)
+	assert.Empty(t, resp.Warnings)
+	assert.Equal(t, tsdbStatus{
+		HeadStats: tsdbHeadStats{
+			NumSeries: 4,
+			MinTime:   start.UnixNano() / int64(time.Millisecond),
+			MaxTime:   now.UnixNano() / int64(time.Millisecond),
+		},
+		SeriesCountByMetricName: []tsdbStat{
+			{Name: "foo", Value: 3},
+			{Name: "bar", Value: 1},
+		},
+		LabelValueCountByLabelName: []tsdbStat{
+			{Name: "host", Value: 3},
+			{Name: "__name__", Value: 2},
+		},
+		MemoryInBytesByLabelName: []tsdbStat{
+			{Name: "__name__", Value: 6},
+			{Name: "host", Value: 3},
+		},
+		SeriesCountByLabelValuePair: []tsdbStat{
+			{Name: "__name__=foo", Value: 3},
+			{Name: "host=a", Value: 2},
+		},
+	}, resp.Data)
+}
+
+func TestPromTSDBStatusNotExhaustive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notExhaustive := block.NewResultMetadata()
+	notExhaustive.Exhaustive = false
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&consolidators.CompleteTagsResult{
+			CompletedTags: []consolidators.CompletedTag{
+				{Name: b("__name__"), Values: [][]byte{b("foo")}},
+			},
+			Metadata: notExhaustive,
+		}, nil)
+
+	store.EXPECT().SearchSeries(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			_ *storage.FetchQuery,
+			o *storage.FetchOptions,
+		) (*storage.SearchResults, error) {
+			assert.Equal(t, 1, o.SeriesLimit)
+			return &storage.SearchResults{
+				Metrics:  models.Metrics{newTestMetric("__name__", "foo")},
+				Metadata: notExhaustive,
+			}, nil
+		})
+
+	fb, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{
+			Limits:  handleroptions.FetchOptionsBuilderLimitsOptions{SeriesLimit: 1},
+			Timeout: 15 * time.Second,
+		})
+	require.NoError(t, err)
+
+	opts := options.EmptyHandlerOptions().
+		SetStorage(store).
+		SetFetchOptionsBuilder(fb).
+		SetTagOptions(models.NewTagOptions())
+	h := NewPromTSDBStatusHandler(opts)
+
+	req := httptest.NewRequest(http.MethodGet, PromTSDBStatusURL, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Status   string     `json:"status"`
+		Data     tsdbStatus `json:"data"`
+		Warnings []string   `json:"warnings"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, statusSuccess, resp.Status)
+	assert.Equal(t, uint64(1), resp.Data.HeadStats.NumSeries)
+	assert.Equal(t, []string{
+		"label value counts are not exhaustive",
+		"series counts are computed from 1 series and are not exhaustive",
+	}, resp.Warnings)
+}
+
+func TestPromTSDBStatusInvalidTimeRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	opts := options.EmptyHandlerOptions().
+		SetStorage(store).
+		SetTagOptions(models.NewTagOptions())
+	h := NewPromTSDBStatusHandler(opts)
+
+	req := httptest.NewRequest(http.MethodGet,
+		PromTSDBStatusURL+"?start=100&end=10", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTopTSDBStats(t *testing.T) {
+	stats := map[string]uint64{"a": 1, "b": 3, "c": 3, "d": 2}
+	assert.Equal(t, []tsdbStat{
+		{Name: "b", Value: 3},
+		{Name: "c", Value: 3},
+		{Name: "d", Value: 2},
+		{Name: "a", Value: 1},
+	}, topTSDBStats(stats, -1))
+	assert.Equal(t, []tsdbStat{
+		{Name: "b", Value: 3},
+		{Name: "c", Value: 3},
+	}, topTSDBStats(stats, 2))
+	assert.Equal(t, []tsdbStat{}, topTSDBStats(stats, 0))
+}
@@ -340,8 +340,12 @@ func (h *PromWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			lastBadRequestErr string
 			numRegular        int
 			numBadRequest     int
+			numTenantLimited  int
 		)
 		for _, err := range errs {
+			if client.IsTenantLimitExceededError(err) {
+				numTenantLimited++
+			}
 			switch {
 			case client.IsBadRequestError(err):
 				numBadRequest++
@@ -357,6 +361,11 @@ func (h *PromWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 		var status int
 		switch {
+		case numTenantLimited == len(errs):
+			// All writes were rejected due to tenant limits, signal the
+			// client to back off.
+			status = http.StatusTooManyRequests
+			h.metrics.writeErrorsClient.Inc(1)
 		case numBadRequest == len(errs):
 			status = http.StatusBadRequest
 			h.metrics.writeErrorsClient.Inc(1)
@@ -35,6 +35,7 @@ import (
 	m3json "github.com/m3db/m3/src/query/api/v1/handler/json"
 	"github.com/m3db/m3/src/query/api/v1/handler/namespace"
 	"github.com/m3db/m3/src/query/api/v1/handler/openapi"
+	"github.com/m3db/m3/src/query/api/v1/handler/otlp"
 	"github.com/m3db/m3/src/query/api/v1/handler/placement"
 	"github.com/m3db/m3/src/query/api/v1/handler/prom"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
@@ -270,6 +271,16 @@ func (h *Handler) RegisterRoutes() error {
 		return err
 	}
 
+	// OTLP/HTTP metrics write endpoint.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    otlp.OTLPWriteURL,
+		Handler: otlp.NewOTLPWriteHandler(h.options),
+		Methods: methods(otlp.OTLPWriteHTTPMethod),
+		// Register with no response logging for write calls since so frequent.
+	}, logging.WithNoResponseLog()); err != nil {
+		return err
+	}
+
 	// Native M3 search and write endpoints.
 	if err := h.registry.Register(queryhttp.RegisterOptions{
 		Path:    handler.SearchURL,
@@ -311,6 +322,47 @@ func (h *Handler) RegisterRoutes() error {
 		return err
 	}
 
+	// Prometheus metadata and status endpoints.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    native.PromMetadataURL,
+		Handler: native.NewPromMetadataHandler(h.options),
+		Methods: methods(native.PromMetadataHTTPMethod),
+	}); err != nil {
+		return err
+	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    native.PromBuildInfoURL,
+		Handler: native.NewPromBuildInfoHandler(h.options),
+		Methods: methods(native.PromBuildInfoHTTPMethod),
+	}); err != nil {
+		return err
+	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    native.PromTSDBStatusURL,
+		Handler: native.NewPromTSDBStatusHandler(h.options),
+		Methods: methods(native.PromTSDBStatusHTTPMethod),
+	}); err != nil {
+		return err
+	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    native.PromQueryExemplarsURL,
+		Handler: native.NewPromQueryExemplarsHandler(h.options),
+		Methods: native.PromQueryExemplarsHTTPMethods,
+	}); err != nil {
+		return err
+	}
+
+	// Prometheus admin endpoints, series deletion requires M3DB storage.
+	if h.options.Clusters() != nil {
+		if err := h.registry.Register(queryhttp.RegisterOptions{
+			Path:    native.PromDeleteSeriesURL,
+			Handler: native.NewPromDeleteSeriesHandler(h.options),
+			Methods: native.PromDeleteSeriesHTTPMethods,
+		}); err != nil {
+			return err
+		}
+	}
+
 	// Query parse endpoints.
 	if err := h.registry.Register(queryhttp.RegisterOptions{
 		Path:    native.PromParseURL,
@@ -351,6 +403,27 @@ func (h *Handler) RegisterRoutes() error {
 	}); err != nil {
 		return err
 	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    graphite.TagsURL,
+		Handler: graphite.NewTagsHandler(h.options),
+		Methods: graphite.TagsHTTPMethods,
+	}); err != nil {
+		return err
+	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    graphite.TagsAutoCompleteTagsURL,
+		Handler: graphite.NewTagsAutoCompleteTagsHandler(h.options),
+		Methods: graphite.TagsHTTPMethods,
+	}); err != nil {
+		return err
+	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    graphite.TagsAutoCompleteValuesURL,
+		Handler: graphite.NewTagsAutoCompleteValuesHandler(h.options),
+		Methods: graphite.TagsHTTPMethods,
+	}); err != nil {
+		return err
+	}
 
 	placementOpts, err := h.placementOpts()
 	if err != nil {
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"testing"
 	"time"
 
@@ -282,6 +283,34 @@ func TestHealthGet(t *testing.T) {
 	assert.True(t, result > 0)
 }
 
+func TestPromBuildInfoGet(t *testing.T) {
+	req := httptest.NewRequest("GET", native.PromBuildInfoURL, nil)
+	res := httptest.NewRecorder()
+	ctrl := gomock.NewController(t)
+	storage, _ := m3.NewStorageAndSession(t, ctrl)
+
+	h, err := setupHandler(storage)
+	require.NoError(t, err, "unable to setup handler")
+	h.RegisterRoutes()
+
+	h.Router().ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code)
+
+	response := &struct {
+		Status string `json:"status"`
+		Data   struct {
+			GoVersion string `json:"goVersion"`
+		} `json:"data"`
+	}{}
+
+	err = json.NewDecoder(res.Body).Decode(response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, runtime.Version(), response.Data.GoVersion)
+}
+
 func TestCORSMiddleware(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	s, _ := m3.NewStorageAndSession(t, ctrl)
@@ -215,6 +215,13 @@ type HandlerOptions interface {
 	SetNamespaceValidator(NamespaceValidator) HandlerOptions
 	// NamespaceValidator returns the NamespaceValidator.
 	NamespaceValidator() NamespaceValidator
+
+	// SetInvalidations sets the invalidations of stored data.
+	SetInvalidations(value *storage.Invalidations) HandlerOptions
+	// Invalidations returns the invalidations of stored data, which are
+	// recorded when data changes through this coordinator so cached query
+	// results can be discarded.
+	Invalidations() *storage.Invalidations
 }
 
 // HandlerOptions represents handler options.
@@ -243,6 +250,7 @@ type handlerOptions struct {
 	m3dbOpts              m3db.Options
 	namespaceValidator    NamespaceValidator
 	storeMetricsType      bool
+	invalidations         *storage.Invalidations
 }
 
 // EmptyHandlerOptions returns  default handler options.
@@ -251,6 +259,8 @@ func EmptyHandlerOptions() HandlerOptions {
 		instrumentOpts: instrument.NewOptions(),
 		nowFn:          time.Now,
 		m3dbOpts:       m3db.NewOptions(),
+		invalidations: storage.NewInvalidations(
+			config.ResultCacheConfiguration{}.TTLOrDefault(), time.Now),
 	}
 }
 
@@ -305,6 +315,8 @@ func NewHandlerOptions(
 		m3dbOpts:              m3dbOpts,
 		storeMetricsType:      storeMetricsType,
 		namespaceValidator:    validators.NamespaceValidator,
+		invalidations: storage.NewInvalidations(
+			cfg.Query.Cache.TTLOrDefault(), time.Now),
 	}, nil
 }
 
@@ -567,6 +579,17 @@ func (o *handlerOptions) NamespaceValidator() NamespaceValidator {
 	return o.namespaceValidator
 }
 
+func (o *handlerOptions) SetInvalidations(
+	value *storage.Invalidations) HandlerOptions {
+	opts := *o
+	opts.invalidations = value
+	return &opts
+}
+
+func (o *handlerOptions) Invalidations() *storage.Invalidations {
+	return o.invalidations
+}
+
 // NamespaceValidator defines namespace validation logics.
 type NamespaceValidator interface {
 	// ValidateNewNamespace gets invoked when creating a new namespace.
@@ -0,0 +1,242 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package block
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+)
+
+var (
+	errSeriesChunkStepIter   = errors.New("step iteration is not supported on series chunks")
+	errSeriesChunkOutOfOrder = errors.New("series chunks must be iterated in order")
+)
+
+// DatapointIter iterates over the datapoints of a single series in time order.
+type DatapointIter interface {
+	// Next moves to the next datapoint, returning false when done.
+	Next() bool
+	// Current returns the current datapoint.
+	Current() ts.Datapoint
+	// Err returns any error encountered during iteration.
+	Err() error
+}
+
+// NewSeriesChunks splits the series of a block into consecutive chunks of at
+// most chunkSteps steps that share a single pass over the datapoints of each
+// series. Each chunk holds the datapoints of its steps as well as those within
+// the overlap before its first step, which are carried over from the previous
+// chunk, so that operations over a range of at most the overlap can be
+// evaluated for each chunk independently.
+//
+// NB: only the series iterators of the chunks are supported, they must be
+// iterated in order and the chunks are valid until the block is closed. The
+// datapoints of a chunk are read when it is first iterated, and released when
+// it is closed.
+func NewSeriesChunks(
+	meta Metadata,
+	seriesMetas []SeriesMeta,
+	iters []DatapointIter,
+	chunkSteps int,
+	overlap time.Duration,
+) ([]Block, error) {
+	if chunkSteps <= 0 {
+		return nil, fmt.Errorf("invalid chunk steps: %d", chunkSteps)
+	}
+
+	if len(seriesMetas) != len(iters) {
+		return nil, fmt.Errorf("mismatched series metas and iterators: %d != %d",
+			len(seriesMetas), len(iters))
+	}
+
+	var (
+		bounds  = meta.Bounds
+		steps   = bounds.Steps()
+		chunker = &seriesChunker{
+			seriesMetas: seriesMetas,
+			iters:       iters,
+			overlap:     overlap,
+			previous:    make([]ts.Datapoints, len(iters)),
+			pending:     make([]ts.Datapoint, len(iters)),
+			hasPending:  make([]bool, len(iters)),
+		}
+		chunks = make([]Block, 0, (steps+chunkSteps-1)/chunkSteps)
+	)
+	for start := 0; start < steps; start += chunkSteps {
+		chunkMeta := meta
+		count := chunkSteps
+		if start+count > steps {
+			count = steps - start
+		}
+
+		chunkMeta.Bounds = models.Bounds{
+			Start:    bounds.Start.Add(time.Duration(start) * bounds.StepSize),
+			Duration: time.Duration(count) * bounds.StepSize,
+			StepSize: bounds.StepSize,
+		}
+
+		chunks = append(chunks, &seriesChunk{
+			meta:    chunkMeta,
+			info:    NewWrappedBlockInfo(BlockLazy, NewBlockInfo(BlockUnconsolidated)),
+			chunker: chunker,
+			index:   len(chunks),
+		})
+	}
+
+	return chunks, nil
+}
+
+// seriesChunker reads the datapoints of each series for consecutive chunks.
+type seriesChunker struct {
+	seriesMetas []SeriesMeta
+	iters       []DatapointIter
+	overlap     time.Duration
+	// loaded is the number of chunks read so far.
+	loaded int
+	// previous holds the datapoints of each series in the previous chunk.
+	previous []ts.Datapoints
+	// pending holds the first datapoint of each series after the previous
+	// chunk, which has been read from the iterator but not yet used.
+	pending    []ts.Datapoint
+	hasPending []bool
+}
+
+// load reads the datapoints of each series for the chunk with the given
+// bounds, which must directly follow the previously loaded chunk.
+func (c *seriesChunker) load(
+	index int,
+	bounds models.Bounds,
+) ([]UnconsolidatedSeries, error) {
+	if index != c.loaded {
+		return nil, errSeriesChunkOutOfOrder
+	}
+
+	c.loaded++
+	var (
+		from   = bounds.Start.Add(-c.overlap)
+		to     = bounds.End().Add(-bounds.StepSize)
+		series = make([]UnconsolidatedSeries, 0, len(c.iters))
+	)
+	for i, iter := range c.iters {
+		var datapoints ts.Datapoints
+		for _, dp := range c.previous[i] {
+			if !dp.Timestamp.Before(from) {
+				datapoints = append(datapoints, dp)
+			}
+		}
+
+		if c.hasPending[i] && !c.pending[i].Timestamp.After(to) {
+			datapoints = append(datapoints, c.pending[i])
+			c.hasPending[i] = false
+		}
+
+		for !c.hasPending[i] && iter.Next() {
+			dp := iter.Current()
+			if dp.Timestamp.Before(from) {
+				continue
+			}
+
+			if dp.Timestamp.After(to) {
+				c.pending[i] = dp
+				c.hasPending[i] = true
+				break
+			}
+
+			datapoints = append(datapoints, dp)
+		}
+
+		if err := iter.Err(); err != nil {
+			return nil, err
+		}
+
+		c.previous[i] = datapoints
+		series = append(series, NewUnconsolidatedSeries(datapoints,
+			c.seriesMetas[i], UnconsolidatedSeriesStats{}))
+	}
+
+	return series, nil
+}
+
+// seriesChunk is a block spanning consecutive steps of the series of a block,
+// which are read when the chunk is first iterated.
+type seriesChunk struct {
+	meta    Metadata
+	info    BlockInfo
+	chunker *seriesChunker
+	index   int
+	block   Block
+}
+
+func (c *seriesChunk) loadBlock() (Block, error) {
+	if c.block != nil {
+		return c.block, nil
+	}
+
+	series, err := c.chunker.load(c.index, c.meta.Bounds)
+	if err != nil {
+		return nil, err
+	}
+
+	c.block = NewUnconsolidatedBlock(c.meta, series)
+	return c.block, nil
+}
+
+func (c *seriesChunk) StepIter() (StepIter, error) {
+	return nil, errSeriesChunkStepIter
+}
+
+func (c *seriesChunk) SeriesIter() (SeriesIter, error) {
+	bl, err := c.loadBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return bl.SeriesIter()
+}
+
+func (c *seriesChunk) MultiSeriesIter(concurrency int) ([]SeriesIterBatch, error) {
+	bl, err := c.loadBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return bl.MultiSeriesIter(concurrency)
+}
+
+func (c *seriesChunk) Meta() Metadata {
+	return c.meta
+}
+
+func (c *seriesChunk) Info() BlockInfo {
+	return c.info
+}
+
+// Close releases the datapoints of the chunk, other than those carried over
+// to the next chunk; the resources of the chunk are otherwise held by the
+// block it was split from.
+func (c *seriesChunk) Close() error {
+	c.block = nil
+	return nil
+}
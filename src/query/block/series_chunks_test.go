@@ -0,0 +1,133 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDatapointIter struct {
+	idx        int
+	datapoints ts.Datapoints
+}
+
+func (it *testDatapointIter) Next() bool {
+	it.idx++
+	return it.idx < len(it.datapoints)
+}
+
+func (it *testDatapointIter) Current() ts.Datapoint {
+	return it.datapoints[it.idx]
+}
+
+func (it *testDatapointIter) Err() error {
+	return nil
+}
+
+func TestNewSeriesChunks(t *testing.T) {
+	var (
+		start = time.Unix(1000, 0)
+		step  = 10 * time.Second
+		meta  = Metadata{
+			Bounds: models.Bounds{
+				Start:    start,
+				Duration: 5 * step,
+				StepSize: step,
+			},
+			Tags: models.NewTags(0, models.NewTagOptions()),
+		}
+	)
+
+	var datapoints ts.Datapoints
+	for i := -2; i < 10; i++ {
+		datapoints = append(datapoints, ts.Datapoint{
+			Timestamp: start.Add(time.Duration(i) * 5 * time.Second),
+			Value:     float64(i),
+		})
+	}
+
+	chunks, err := NewSeriesChunks(meta, buildTestSeriesMeta("a"),
+		[]DatapointIter{&testDatapointIter{idx: -1, datapoints: datapoints}},
+		2, 15*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(chunks))
+
+	// NB: each chunk holds the datapoints of its steps, and those within the
+	// overlap before its first step.
+	expected := [][]float64{
+		{-2, -1, 0, 1, 2},
+		{1, 2, 3, 4, 5, 6},
+		{5, 6, 7, 8},
+	}
+
+	for i, chunk := range chunks {
+		bounds := chunk.Meta().Bounds
+		assert.Equal(t, start.Add(time.Duration(2*i)*step), bounds.Start)
+		assert.Equal(t, step, bounds.StepSize)
+
+		_, err := chunk.StepIter()
+		assert.Error(t, err)
+
+		iter, err := chunk.SeriesIter()
+		require.NoError(t, err)
+		assert.Equal(t, buildTestSeriesMeta("a"), iter.SeriesMeta())
+		require.True(t, iter.Next())
+
+		var values []float64
+		for _, dp := range iter.Current().Datapoints() {
+			values = append(values, dp.Value)
+		}
+
+		assert.Equal(t, expected[i], values)
+		assert.False(t, iter.Next())
+		require.NoError(t, iter.Err())
+		require.NoError(t, chunk.Close())
+	}
+
+	_, err = NewSeriesChunks(meta, buildTestSeriesMeta("a"), nil, 2, 0)
+	assert.Error(t, err)
+}
+
+func TestNewSeriesChunksOutOfOrder(t *testing.T) {
+	meta := Metadata{
+		Bounds: models.Bounds{
+			Start:    time.Unix(1000, 0),
+			Duration: 4 * time.Second,
+			StepSize: time.Second,
+		},
+	}
+
+	chunks, err := NewSeriesChunks(meta, buildTestSeriesMeta("a"),
+		[]DatapointIter{&testDatapointIter{idx: -1}}, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(chunks))
+
+	// NB: chunks share a single pass over the series.
+	_, err = chunks[1].SeriesIter()
+	assert.Error(t, err)
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package block
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+var (
+	errStepChunkSeriesIter  = errors.New("series iteration is not supported on step chunks")
+	errStepChunkStepIterSet = errors.New("step chunk has already been iterated")
+)
+
+// NewStepChunks splits a block into consecutive chunks of at most chunkSteps
+// steps that share a single step iterator over the block, so the steps of the
+// block are only read once regardless of the number of chunks.
+//
+// NB: only the step iterators of the chunks are supported, they must be
+// iterated in order and the chunks are valid until the block is closed.
+func NewStepChunks(b Block, chunkSteps int) ([]Block, error) {
+	if chunkSteps <= 0 {
+		return nil, fmt.Errorf("invalid chunk steps: %d", chunkSteps)
+	}
+
+	iter, err := b.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		meta   = b.Meta()
+		bounds = meta.Bounds
+		steps  = iter.StepCount()
+		chunks = make([]Block, 0, (steps+chunkSteps-1)/chunkSteps)
+	)
+	for start := 0; start < steps; start += chunkSteps {
+		chunkMeta := meta
+		count := chunkSteps
+		if start+count > steps {
+			count = steps - start
+		}
+
+		chunkMeta.Bounds = models.Bounds{
+			Start:    bounds.Start.Add(time.Duration(start) * bounds.StepSize),
+			Duration: time.Duration(count) * bounds.StepSize,
+			StepSize: bounds.StepSize,
+		}
+
+		chunks = append(chunks, &stepChunk{
+			meta:  chunkMeta,
+			info:  NewWrappedBlockInfo(BlockLazy, b.Info()),
+			iter:  iter,
+			steps: count,
+			last:  start+count >= steps,
+		})
+	}
+
+	if len(chunks) == 0 {
+		iter.Close()
+	}
+
+	return chunks, nil
+}
+
+// stepChunk is a block spanning consecutive steps of a shared step iterator.
+type stepChunk struct {
+	meta     Metadata
+	info     BlockInfo
+	iter     StepIter
+	steps    int
+	last     bool
+	iterated bool
+}
+
+func (c *stepChunk) StepIter() (StepIter, error) {
+	if c.iterated {
+		return nil, errStepChunkStepIterSet
+	}
+
+	c.iterated = true
+	return &stepChunkIter{
+		iter:      c.iter,
+		steps:     c.steps,
+		remaining: c.steps,
+		last:      c.last,
+	}, nil
+}
+
+func (c *stepChunk) SeriesIter() (SeriesIter, error) {
+	return nil, errStepChunkSeriesIter
+}
+
+func (c *stepChunk) MultiSeriesIter(_ int) ([]SeriesIterBatch, error) {
+	return nil, errStepChunkSeriesIter
+}
+
+func (c *stepChunk) Meta() Metadata {
+	return c.meta
+}
+
+func (c *stepChunk) Info() BlockInfo {
+	return c.info
+}
+
+// Close is a no-op as the resources of the chunk are held by the block it
+// was split from.
+func (c *stepChunk) Close() error {
+	return nil
+}
+
+type stepChunkIter struct {
+	iter      StepIter
+	steps     int
+	remaining int
+	last      bool
+}
+
+func (it *stepChunkIter) Next() bool {
+	if it.remaining == 0 {
+		return false
+	}
+
+	if !it.iter.Next() {
+		return false
+	}
+
+	it.remaining--
+	return true
+}
+
+func (it *stepChunkIter) Err() error {
+	return it.iter.Err()
+}
+
+func (it *stepChunkIter) SeriesMeta() []SeriesMeta {
+	return it.iter.SeriesMeta()
+}
+
+func (it *stepChunkIter) StepCount() int {
+	return it.steps
+}
+
+func (it *stepChunkIter) Current() Step {
+	return it.iter.Current()
+}
+
+func (it *stepChunkIter) Close() {
+	if it.last {
+		it.iter.Close()
+	}
+}
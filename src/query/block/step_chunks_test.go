@@ -0,0 +1,89 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStepChunks(t *testing.T) {
+	var (
+		start = time.Unix(1000, 0)
+		step  = time.Second
+		meta  = Metadata{
+			Bounds: models.Bounds{
+				Start:    start,
+				Duration: 5 * step,
+				StepSize: step,
+			},
+			Tags: models.NewTags(0, models.NewTagOptions()),
+		}
+	)
+
+	builder := NewColumnBlockBuilder(models.NoopQueryContext(), meta,
+		buildTestSeriesMeta("a"))
+	require.NoError(t, builder.AddCols(5))
+	for i := 0; i < 5; i++ {
+		require.NoError(t, builder.AppendValue(i, float64(i)))
+	}
+
+	bl := builder.Build()
+	defer bl.Close()
+
+	chunks, err := NewStepChunks(bl, 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(chunks))
+
+	var values []float64
+	for i, chunk := range chunks {
+		bounds := chunk.Meta().Bounds
+		assert.Equal(t, start.Add(time.Duration(2*i)*step), bounds.Start)
+		assert.Equal(t, step, bounds.StepSize)
+
+		_, err := chunk.SeriesIter()
+		assert.Error(t, err)
+
+		iter, err := chunk.StepIter()
+		require.NoError(t, err)
+		assert.Equal(t, bounds.Steps(), iter.StepCount())
+		assert.Equal(t, buildTestSeriesMeta("a"), iter.SeriesMeta())
+		for iter.Next() {
+			values = append(values, iter.Current().Values()...)
+		}
+
+		require.NoError(t, iter.Err())
+		iter.Close()
+
+		// NB: chunks share a single pass over the block.
+		_, err = chunk.StepIter()
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, []float64{0, 1, 2, 3, 4}, values)
+
+	_, err = NewStepChunks(bl, 0)
+	assert.Error(t, err)
+}
@@ -47,6 +47,8 @@ const (
 	BlockContainer
 	// BlockEmpty is a block with metadata but no series or values.
 	BlockEmpty
+	// BlockUnconsolidated is a block of in-memory unconsolidated series.
+	BlockUnconsolidated
 	// BlockTest is a block used for testing only.
 	BlockTest
 )
@@ -77,6 +79,23 @@ type AccumulatorBlock interface {
 	AddBlock(bl Block) error
 }
 
+// StepChunker is implemented by blocks that can be split into consecutive
+// time chunks which are read in a single pass over the block.
+type StepChunker interface {
+	// StepChunks splits the block into consecutive chunks of at most
+	// chunkSteps steps.
+	StepChunks(chunkSteps int) ([]Block, error)
+}
+
+// SeriesChunker is implemented by blocks whose series can be split into
+// consecutive time chunks which are read in a single pass over the block,
+// where each chunk also holds the datapoints within the overlap before it.
+type SeriesChunker interface {
+	// SeriesChunks splits the block into consecutive chunks of at most
+	// chunkSteps steps, overlapping the previous chunk by the given duration.
+	SeriesChunks(chunkSteps int, overlap time.Duration) ([]Block, error)
+}
+
 // SeriesMeta is metadata data for the series.
 type SeriesMeta struct {
 	Tags models.Tags
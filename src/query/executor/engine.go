@@ -63,6 +63,8 @@ type engineMetrics struct {
 	compilingHist tally.Histogram
 	planningHist  tally.Histogram
 	executingHist tally.Histogram
+
+	streamingQueries tally.Counter
 }
 
 type counterWithDecrement struct {
@@ -96,6 +98,8 @@ func newEngineMetrics(scope tally.Scope) *engineMetrics {
 		compilingHist: scope.Histogram(compiling.durationString(), durationBuckets),
 		planningHist:  scope.Histogram(planning.durationString(), durationBuckets),
 		executingHist: scope.Histogram(executing.durationString(), durationBuckets),
+
+		streamingQueries: scope.Counter("streaming_queries"),
 	}
 }
 
@@ -126,6 +130,11 @@ func (e *engine) ExecuteExpr(
 		return nil, err
 	}
 
+	if chunkSteps := e.streamingChunkSteps(nodes); chunkSteps > 0 {
+		pp.StreamingChunkSteps = chunkSteps
+		e.metrics.streamingQueries.Inc(1)
+	}
+
 	state, err := req.generateExecutionState(ctx, pp)
 	if err != nil {
 		return nil, err
@@ -29,10 +29,11 @@ import (
 )
 
 type engineOptions struct {
-	instrumentOpts   instrument.Options
-	store            storage.Storage
-	parseOptions     promql.ParseOptions
-	lookbackDuration time.Duration
+	instrumentOpts      instrument.Options
+	store               storage.Storage
+	parseOptions        promql.ParseOptions
+	lookbackDuration    time.Duration
+	streamingChunkSteps int
 }
 
 // NewEngineOptions returns a new instance of options used to create an engine.
@@ -72,6 +73,16 @@ func (o *engineOptions) SetLookbackDuration(v time.Duration) EngineOptions {
 	return &opts
 }
 
+func (o *engineOptions) StreamingChunkSteps() int {
+	return o.streamingChunkSteps
+}
+
+func (o *engineOptions) SetStreamingChunkSteps(v int) EngineOptions {
+	opts := *o
+	opts.streamingChunkSteps = v
+	return &opts
+}
+
 func (o *engineOptions) ParseOptions() promql.ParseOptions {
 	return o.parseOptions
 }
@@ -107,11 +107,15 @@ func GenerateExecutionState(
 	}
 
 	options, err := transform.NewOptions(transform.OptionsParams{
-		FetchOptions:      fetchOpts,
-		TimeSpec:          pplan.TimeSpec,
-		Debug:             pplan.Debug,
-		BlockType:         pplan.BlockType,
-		InstrumentOptions: instrumentOpts,
+		FetchOptions:        fetchOpts,
+		TimeSpec:            pplan.TimeSpec,
+		QueryStart:          pplan.QueryStart,
+		QueryEnd:            pplan.QueryEnd,
+		LookbackDuration:    pplan.LookbackDuration,
+		Debug:               pplan.Debug,
+		BlockType:           pplan.BlockType,
+		StreamingChunkSteps: pplan.StreamingChunkSteps,
+		InstrumentOptions:   instrumentOpts,
 	})
 	if err != nil {
 		return nil, err
@@ -127,6 +131,10 @@ func GenerateExecutionState(
 	}
 
 	sink := newResultNode()
+	if pplan.StreamingChunkSteps > 0 {
+		sink = newChunkedResultNode(pplan.TimeSpec)
+	}
+
 	state.sink = sink
 	controller.AddTransform(sink)
 
@@ -161,6 +169,15 @@ func (s *ExecutionState) createNode(
 
 	transformNode, controller := CreateTransform(step.ID(),
 		transformParams, options)
+
+	// NB: operations such as subqueries evaluate their parents over a different
+	// time range and resolution than they are evaluated over themselves.
+	parentOptions := options
+	if timeSpecOp, ok := transformParams.(transform.TimeSpecOp); ok {
+		parentOptions = options.SetTimeSpec(
+			timeSpecOp.ParentTimeSpec(options))
+	}
+
 	for _, parentID := range step.Parents {
 		parentStep, ok := s.plan.Step(parentID)
 		if !ok {
@@ -168,7 +185,7 @@ func (s *ExecutionState) createNode(
 				"%s, node: %s", parentID, step.ID())
 		}
 
-		parentController, err := s.createNode(parentStep, options)
+		parentController, err := s.createNode(parentStep, parentOptions)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,264 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executor
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+// streamingChunkSteps returns the number of steps that the blocks fetched by
+// a query are streamed through its transforms in, or zero if the query has to
+// process fetched blocks at once.
+//
+// NB: only queries with a single fetch and transforms that are evaluated
+// independently at each step, or over the range of a range selector, are
+// streamed, as the output of other transforms at a step depends on other
+// steps or on the blocks of other sources. Range selectors are streamed in
+// chunks of raw datapoints that overlap by the range, so they must be
+// consumed by a windowed transform.
+func (e *engine) streamingChunkSteps(nodes parser.Nodes) int {
+	chunkSteps := e.opts.StreamingChunkSteps()
+	if chunkSteps <= 0 {
+		return 0
+	}
+
+	var (
+		fetches  int
+		windowed int
+		ranged   bool
+	)
+	for _, node := range nodes {
+		switch op := node.Op.(type) {
+		case SourceParams:
+			fetches++
+			if boundOp, ok := op.(transform.BoundOp); ok {
+				ranged = boundOp.Bounds().Range > 0
+			}
+		case transform.WindowedOp:
+			windowed++
+		case transform.StepwiseOp:
+		default:
+			return 0
+		}
+	}
+
+	if fetches != 1 || windowed > 1 || ranged != (windowed == 1) {
+		return 0
+	}
+
+	return chunkSteps
+}
+
+// chunkedResultNode is the sink of a streamed query, which merges the result
+// blocks of each chunk into the final result as they are processed.
+//
+// NB: the merged result only holds the values of the output series, which are
+// required in full to render the response, while the fetched data and the
+// blocks materialized by transforms only span a single chunk at a time.
+type chunkedResultNode struct {
+	sync.Mutex
+
+	err      error
+	queryCtx *models.QueryContext
+	result   *chunkedResult
+}
+
+func newChunkedResultNode(timeSpec transform.TimeSpec) sink {
+	return &chunkedResultNode{
+		result: newChunkedResult(timeSpec.Start, timeSpec.Step),
+	}
+}
+
+func (r *chunkedResultNode) Process(
+	queryCtx *models.QueryContext,
+	_ parser.NodeID,
+	bl block.Block,
+) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+
+	r.queryCtx = queryCtx
+	r.err = r.result.add(bl)
+	return r.err
+}
+
+func (r *chunkedResultNode) closeWithError(err error) {
+	r.Lock()
+	defer r.Unlock()
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *chunkedResultNode) getValue() (block.Block, error) {
+	r.Lock()
+	defer r.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return r.result.build(r.queryCtx)
+}
+
+// chunkedResult merges the result blocks of consecutive chunks of a query
+// into a single result, matching series across chunks by their tags.
+type chunkedResult struct {
+	step       time.Duration
+	start      time.Time
+	started    bool
+	tagOpts    models.TagOptions
+	resultMeta block.ResultMetadata
+	seriesIdx  map[string]int
+	seriesMeta []block.SeriesMeta
+	// columns holds the values of each step, indexed by series.
+	columns [][]float64
+}
+
+func newChunkedResult(start time.Time, step time.Duration) *chunkedResult {
+	return &chunkedResult{
+		start:      start,
+		step:       step,
+		tagOpts:    models.NewTagOptions(),
+		resultMeta: block.NewResultMetadata(),
+		seriesIdx:  make(map[string]int),
+	}
+}
+
+// add merges the steps of a chunk result block that were not already merged
+// from a previous chunk.
+func (r *chunkedResult) add(bl block.Block) error {
+	meta := bl.Meta()
+	if !r.started {
+		r.started = true
+		r.start = meta.Bounds.Start
+		if opts := meta.Tags.Opts; opts != nil {
+			r.tagOpts = opts
+		}
+		r.resultMeta = meta.ResultMetadata
+	} else {
+		r.resultMeta = r.resultMeta.CombineMetadata(meta.ResultMetadata)
+	}
+
+	iter, err := bl.StepIter()
+	if err != nil {
+		return err
+	}
+
+	defer iter.Close()
+	seriesMetas := iter.SeriesMeta()
+	rows := make([]int, 0, len(seriesMetas))
+	for _, seriesMeta := range seriesMetas {
+		// NB: tags common to all series of a block are held by the block, and
+		// may differ between chunks.
+		tags := seriesMeta.Tags.AddTags(meta.Tags.Tags)
+		id := string(tags.ID())
+		row, ok := r.seriesIdx[id]
+		if !ok {
+			row = len(r.seriesMeta)
+			r.seriesIdx[id] = row
+			r.seriesMeta = append(r.seriesMeta, block.SeriesMeta{
+				Name: seriesMeta.Name,
+				Tags: tags,
+			})
+			for i := range r.columns {
+				r.columns[i] = append(r.columns[i], math.NaN())
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	// NB: the step index is derived from the bounds of the block rather than
+	// the time of each step, as block types differ in the time they report
+	// for each step.
+	idx := int(meta.Bounds.Start.Sub(r.start) / r.step)
+	for ; iter.Next(); idx++ {
+		if idx < len(r.columns) {
+			continue
+		}
+
+		for len(r.columns) < idx {
+			r.columns = append(r.columns, r.nanColumn())
+		}
+
+		column := r.nanColumn()
+		for i, v := range iter.Current().Values() {
+			column[rows[i]] = v
+		}
+
+		r.columns = append(r.columns, column)
+	}
+
+	return iter.Err()
+}
+
+func (r *chunkedResult) nanColumn() []float64 {
+	column := make([]float64, len(r.seriesMeta))
+	for i := range column {
+		column[i] = math.NaN()
+	}
+
+	return column
+}
+
+// build builds the merged result block, holding the full tags of each series.
+func (r *chunkedResult) build(queryCtx *models.QueryContext) (block.Block, error) {
+	meta := block.Metadata{
+		Bounds: models.Bounds{
+			Start:    r.start,
+			Duration: time.Duration(len(r.columns)) * r.step,
+			StepSize: r.step,
+		},
+		Tags:           models.NewTags(0, r.tagOpts),
+		ResultMetadata: r.resultMeta,
+	}
+
+	if len(r.columns) == 0 {
+		return block.NewEmptyBlock(meta), nil
+	}
+
+	builder := block.NewColumnBlockBuilder(queryCtx, meta, r.seriesMeta)
+	if err := builder.AddCols(len(r.columns)); err != nil {
+		return nil, err
+	}
+
+	for i, column := range r.columns {
+		if err := builder.AppendValues(i, column); err != nil {
+			return nil, err
+		}
+
+		// Release merged values as they are copied into the block.
+		r.columns[i] = nil
+	}
+
+	return builder.Build(), nil
+}
@@ -0,0 +1,441 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executor
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/x/instrument"
+	xtest "github.com/m3db/m3/src/x/test"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamingChunkSteps(t *testing.T) {
+	engine := NewEngine(NewEngineOptions().
+		SetStreamingChunkSteps(10).
+		SetInstrumentOptions(instrument.NewOptions())).(*engine)
+
+	tests := []struct {
+		query    string
+		streamed bool
+	}{
+		{query: "foo", streamed: true},
+		{query: "sum(foo)", streamed: true},
+		{query: "abs(sum by (a) (foo))", streamed: true},
+		{query: `label_replace(foo, "a", "$1", "b", "(.*)")`, streamed: true},
+		{query: "rate(foo[1m])", streamed: true},
+		{query: "sum(rate(foo[1m]))", streamed: true},
+		{query: "rate(foo[5m:1m])", streamed: false},
+		{query: "foo @ end()", streamed: false},
+		{query: "foo * 2", streamed: false},
+		{query: "foo + bar", streamed: false},
+		{query: "vector(1)", streamed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			parser, err := promql.Parse(tt.query, time.Second,
+				models.NewTagOptions(), promql.NewParseOptions())
+			require.NoError(t, err)
+
+			nodes, _, err := parser.DAG()
+			require.NoError(t, err)
+
+			expected := 0
+			if tt.streamed {
+				expected = 10
+			}
+
+			assert.Equal(t, expected, engine.streamingChunkSteps(nodes))
+		})
+	}
+}
+
+func TestChunkedResultMergesSeriesAcrossChunks(t *testing.T) {
+	var (
+		start = time.Unix(1000, 0)
+		step  = time.Second
+		metas = test.NewSeriesMeta("a", 2)
+		nan   = math.NaN()
+	)
+
+	first := test.NewBlockFromValuesWithSeriesMeta(models.Bounds{
+		Start:    start,
+		Duration: 3 * step,
+		StepSize: step,
+	}, metas[:1], [][]float64{{1, 2, 3}})
+
+	// NB: series may only be present in some of the chunks.
+	second := test.NewBlockFromValuesWithSeriesMeta(models.Bounds{
+		Start:    start.Add(3 * step),
+		Duration: 2 * step,
+		StepSize: step,
+	}, []block.SeriesMeta{metas[1], metas[0]}, [][]float64{
+		{20, 30},
+		{4, 5},
+	})
+
+	result := newChunkedResult(start, step)
+	require.NoError(t, result.add(first))
+	require.NoError(t, result.add(second))
+
+	bl, err := result.build(models.NoopQueryContext())
+	require.NoError(t, err)
+
+	meta := bl.Meta()
+	assert.Equal(t, start, meta.Bounds.Start)
+	assert.Equal(t, 5, meta.Bounds.Steps())
+
+	names, values := blockValues(t, bl)
+	assert.Equal(t, []string{"a0", "a1"}, names)
+	test.EqualsWithNans(t, []float64{1, 2, 3, 4, 5}, values[0])
+	test.EqualsWithNans(t, []float64{nan, nan, nan, 20, 30}, values[1])
+}
+
+func TestExecuteExprStreamed(t *testing.T) {
+	var (
+		now   = time.Now().Truncate(time.Hour)
+		start = now.Add(-time.Minute)
+		step  = time.Second
+	)
+
+	for _, query := range []string{"foo", "sum(foo)", "avg(foo)", "abs(foo)", "foo * 2"} {
+		t.Run(query, func(t *testing.T) {
+			expectedFetch, expectedNames, expected := executeWithChunkSteps(t,
+				query, start, now, step, 0, newTestColumnBlock)
+			for _, chunkSteps := range []int{1, 7, 60} {
+				fetch, names, values := executeWithChunkSteps(t, query,
+					start, now, step, chunkSteps, newTestColumnBlock)
+
+				// NB: streamed queries fetch the full range of the query once.
+				assert.True(t, expectedFetch.Start.Equal(fetch.Start))
+				assert.True(t, expectedFetch.End.Equal(fetch.End))
+
+				assert.Equal(t, expectedNames, names)
+				require.Equal(t, len(expected), len(values))
+				for i := range expected {
+					test.EqualsWithNans(t, expected[i], values[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteExprStreamedRange(t *testing.T) {
+	var (
+		now      = time.Now().Truncate(time.Hour)
+		start    = now.Add(-6 * time.Hour)
+		step     = time.Minute
+		interval = 15 * time.Second
+		rng      = 5 * time.Minute
+		series   = 2
+	)
+
+	peak := 0
+	newBlock := func(query *storage.FetchQuery) block.Block {
+		return newTestSeriesChunkableBlock(query, interval, series, &peak)
+	}
+
+	for _, query := range []string{"rate(foo[5m])", "sum(max_over_time(foo[5m]))"} {
+		t.Run(query, func(t *testing.T) {
+			peak = 0
+			_, expectedNames, expected := executeWithChunkSteps(t, query,
+				start, now, step, 0, newBlock)
+
+			for _, chunkSteps := range []int{1, 7, 60} {
+				peak = 0
+				_, names, values := executeWithChunkSteps(t, query,
+					start, now, step, chunkSteps, newBlock)
+
+				assert.Equal(t, expectedNames, names)
+				require.Equal(t, len(expected), len(values))
+				for i := range expected {
+					test.EqualsWithNans(t, expected[i], values[i])
+				}
+
+				// NB: the datapoints held at once are bounded by the chunk
+				// size and the range rather than the range of the query.
+				chunkPoints := int((time.Duration(chunkSteps)*step+rng)/interval) + 1
+				assert.True(t, peak > 0)
+				assert.True(t, peak <= series*chunkPoints,
+					"peak %d exceeds %d", peak, series*chunkPoints)
+			}
+		})
+	}
+}
+
+// chunkableBlock is a block that can be streamed in chunks, like the encoded
+// blocks fetched from M3DB.
+type chunkableBlock struct {
+	block.Block
+}
+
+func (b chunkableBlock) StepChunks(chunkSteps int) ([]block.Block, error) {
+	return block.NewStepChunks(b.Block, chunkSteps)
+}
+
+func newTestColumnBlock(query *storage.FetchQuery) block.Block {
+	bounds := models.Bounds{
+		Start:    query.Start,
+		Duration: query.End.Sub(query.Start),
+		StepSize: query.Interval,
+	}
+
+	values := make([][]float64, 2)
+	for i := 0; i < bounds.Steps(); i++ {
+		seconds := float64(query.Start.Add(time.Duration(i) * bounds.StepSize).Unix())
+		values[0] = append(values[0], math.Mod(seconds, 17))
+		values[1] = append(values[1], math.Mod(seconds, 5))
+	}
+
+	bl := test.NewBlockFromValuesWithSeriesMeta(bounds,
+		test.NewSeriesMeta("foo", 2), values)
+	return chunkableBlock{Block: bl}
+}
+
+// seriesChunkableBlock is a block of raw datapoints that can be streamed in
+// overlapping chunks, like the encoded blocks fetched from M3DB, which records
+// the largest number of datapoints held by any of its chunks.
+type seriesChunkableBlock struct {
+	block.Block
+	series []block.UnconsolidatedSeries
+	peak   *int
+}
+
+func newTestSeriesChunkableBlock(
+	query *storage.FetchQuery,
+	interval time.Duration,
+	numSeries int,
+	peak *int,
+) block.Block {
+	var (
+		metas  = test.NewSeriesMeta("foo", numSeries)
+		series = make([]block.UnconsolidatedSeries, 0, numSeries)
+	)
+	for i, meta := range metas {
+		var datapoints ts.Datapoints
+		for at := query.Start; !at.After(query.End); at = at.Add(interval) {
+			datapoints = append(datapoints, ts.Datapoint{
+				Timestamp: at,
+				Value:     math.Mod(float64(at.Unix()), float64(300*(i+1))),
+			})
+		}
+
+		series = append(series, block.NewUnconsolidatedSeries(datapoints, meta,
+			block.UnconsolidatedSeriesStats{}))
+	}
+
+	meta := block.Metadata{
+		Bounds: models.Bounds{
+			Start:    query.Start,
+			Duration: query.End.Sub(query.Start),
+			StepSize: query.Interval,
+		},
+		Tags:           models.NewTags(0, models.NewTagOptions()),
+		ResultMetadata: block.NewResultMetadata(),
+	}
+
+	return seriesChunkableBlock{
+		Block:  block.NewUnconsolidatedBlock(meta, series),
+		series: series,
+		peak:   peak,
+	}
+}
+
+func (b seriesChunkableBlock) SeriesChunks(
+	chunkSteps int,
+	overlap time.Duration,
+) ([]block.Block, error) {
+	var (
+		metas = make([]block.SeriesMeta, 0, len(b.series))
+		iters = make([]block.DatapointIter, 0, len(b.series))
+	)
+	for _, s := range b.series {
+		metas = append(metas, s.Meta)
+		iters = append(iters, &sliceDatapointIter{
+			idx:        -1,
+			datapoints: s.Datapoints(),
+		})
+	}
+
+	chunks, err := block.NewSeriesChunks(b.Meta(), metas, iters, chunkSteps,
+		overlap)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, chunk := range chunks {
+		chunks[i] = peakRecordingBlock{Block: chunk, peak: b.peak}
+	}
+
+	return chunks, nil
+}
+
+type sliceDatapointIter struct {
+	idx        int
+	datapoints ts.Datapoints
+}
+
+func (it *sliceDatapointIter) Next() bool {
+	it.idx++
+	return it.idx < len(it.datapoints)
+}
+
+func (it *sliceDatapointIter) Current() ts.Datapoint {
+	return it.datapoints[it.idx]
+}
+
+func (it *sliceDatapointIter) Err() error {
+	return nil
+}
+
+// peakRecordingBlock records the largest number of datapoints held by any
+// of the chunks it wraps.
+type peakRecordingBlock struct {
+	block.Block
+	peak *int
+}
+
+func (b peakRecordingBlock) record() error {
+	iter, err := b.Block.SeriesIter()
+	if err != nil {
+		return err
+	}
+
+	defer iter.Close()
+	count := 0
+	for iter.Next() {
+		count += len(iter.Current().Datapoints())
+	}
+
+	if count > *b.peak {
+		*b.peak = count
+	}
+
+	return iter.Err()
+}
+
+func (b peakRecordingBlock) SeriesIter() (block.SeriesIter, error) {
+	if err := b.record(); err != nil {
+		return nil, err
+	}
+
+	return b.Block.SeriesIter()
+}
+
+func (b peakRecordingBlock) MultiSeriesIter(
+	concurrency int,
+) ([]block.SeriesIterBatch, error) {
+	if err := b.record(); err != nil {
+		return nil, err
+	}
+
+	return b.Block.MultiSeriesIter(concurrency)
+}
+
+func executeWithChunkSteps(
+	t *testing.T,
+	query string,
+	start, end time.Time,
+	step time.Duration,
+	chunkSteps int,
+	newBlock func(query *storage.FetchQuery) block.Block,
+) (*storage.FetchQuery, []string, [][]float64) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var fetched *storage.FetchQuery
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().FetchBlocks(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			query *storage.FetchQuery,
+			_ *storage.FetchOptions,
+		) (block.Result, error) {
+			fetched = query
+			return block.Result{
+				Blocks:   []block.Block{newBlock(query)},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	engine := NewEngine(NewEngineOptions().
+		SetStore(store).
+		SetLookbackDuration(defaultLookbackDuration).
+		SetStreamingChunkSteps(chunkSteps).
+		SetInstrumentOptions(instrument.NewOptions()))
+
+	parser, err := promql.Parse(query, step, models.NewTagOptions(),
+		promql.NewParseOptions())
+	require.NoError(t, err)
+
+	bl, err := engine.ExecuteExpr(context.Background(), parser,
+		&QueryOptions{}, storage.NewFetchOptions(), models.RequestParams{
+			Start:            start,
+			End:              end,
+			Now:              end,
+			Step:             step,
+			IncludeEnd:       true,
+			LookbackDuration: defaultLookbackDuration,
+		})
+	require.NoError(t, err)
+	defer bl.Close()
+
+	names, values := blockValues(t, bl)
+	return fetched, names, values
+}
+
+// blockValues returns the names and values of each series in a block.
+func blockValues(t *testing.T, bl block.Block) ([]string, [][]float64) {
+	iter, err := bl.StepIter()
+	require.NoError(t, err)
+	defer iter.Close()
+
+	var (
+		metas  = iter.SeriesMeta()
+		names  = make([]string, 0, len(metas))
+		values = make([][]float64, len(metas))
+	)
+	for _, meta := range metas {
+		names = append(names, string(meta.Name))
+	}
+
+	for iter.Next() {
+		for i, v := range iter.Current().Values() {
+			values[i] = append(values[i], v)
+		}
+	}
+
+	require.NoError(t, iter.Err())
+	return names, values
+}
@@ -40,18 +40,31 @@ var (
 type Options struct {
 	fetchOpts         *storage.FetchOptions
 	timeSpec          TimeSpec
+	queryStart        time.Time
+	queryEnd          time.Time
+	lookbackDuration  time.Duration
 	debug             bool
 	blockType         models.FetchedBlockType
+	chunkSteps        int
 	instrumentOptions instrument.Options
 }
 
 // OptionsParams are the parameters used to create Options.
 type OptionsParams struct {
-	FetchOptions      *storage.FetchOptions
-	TimeSpec          TimeSpec
-	Debug             bool
-	BlockType         models.FetchedBlockType
-	InstrumentOptions instrument.Options
+	FetchOptions *storage.FetchOptions
+	TimeSpec     TimeSpec
+	// QueryStart is the start of the query as requested, which unlike the
+	// TimeSpec start is not adjusted to account for lookback.
+	QueryStart time.Time
+	// QueryEnd is the inclusive end of the query as requested.
+	QueryEnd         time.Time
+	LookbackDuration time.Duration
+	Debug            bool
+	BlockType        models.FetchedBlockType
+	// StreamingChunkSteps is the number of steps that fetched blocks are
+	// streamed through the transforms in, zero to process them at once.
+	StreamingChunkSteps int
+	InstrumentOptions   instrument.Options
 }
 
 // NewOptions enforces that fields are set when options is created.
@@ -65,8 +78,12 @@ func NewOptions(p OptionsParams) (Options, error) {
 	return Options{
 		fetchOpts:         p.FetchOptions,
 		timeSpec:          p.TimeSpec,
+		queryStart:        p.QueryStart,
+		queryEnd:          p.QueryEnd,
+		lookbackDuration:  p.LookbackDuration,
 		debug:             p.Debug,
 		blockType:         p.BlockType,
+		chunkSteps:        p.StreamingChunkSteps,
 		instrumentOptions: p.InstrumentOptions,
 	}, nil
 }
@@ -81,6 +98,27 @@ func (o Options) TimeSpec() TimeSpec {
 	return o.timeSpec
 }
 
+// SetTimeSpec returns a copy of the options with the given TimeSpec.
+func (o Options) SetTimeSpec(timeSpec TimeSpec) Options {
+	o.timeSpec = timeSpec
+	return o
+}
+
+// QueryStart returns the start of the query as requested.
+func (o Options) QueryStart() time.Time {
+	return o.queryStart
+}
+
+// QueryEnd returns the inclusive end of the query as requested.
+func (o Options) QueryEnd() time.Time {
+	return o.queryEnd
+}
+
+// LookbackDuration returns the lookback duration for the query.
+func (o Options) LookbackDuration() time.Duration {
+	return o.lookbackDuration
+}
+
 // Debug returns the Debug option.
 func (o Options) Debug() bool {
 	return o.debug
@@ -91,6 +129,12 @@ func (o Options) BlockType() models.FetchedBlockType {
 	return o.blockType
 }
 
+// StreamingChunkSteps returns the number of steps that fetched blocks are
+// streamed through the transforms in, zero if they are processed at once.
+func (o Options) StreamingChunkSteps() int {
+	return o.chunkSteps
+}
+
 // InstrumentOptions returns the InstrumentOptions option.
 func (o Options) InstrumentOptions() instrument.Options {
 	return o.instrumentOptions
@@ -149,6 +193,29 @@ type BoundOp interface {
 	Bounds() BoundSpec
 }
 
+// TimeSpecOp is an operation that evaluates its parents over a different time
+// range and step than the one it is itself evaluated over, e.g. a subquery.
+type TimeSpecOp interface {
+	// ParentTimeSpec returns the time spec to evaluate parent operations with,
+	// given the options that this operation is evaluated with.
+	ParentTimeSpec(opts Options) TimeSpec
+}
+
+// StepwiseOp is an operation whose output at each step only depends on its
+// input at the same step, which allows blocks to be streamed through it in
+// consecutive time chunks.
+type StepwiseOp interface {
+	Stepwise()
+}
+
+// WindowedOp is an operation whose output at each step only depends on its
+// input within the range of the range selector it is applied to, which allows
+// blocks to be streamed through it in consecutive time chunks that overlap by
+// that range.
+type WindowedOp interface {
+	Windowed()
+}
+
 // BoundSpec is the boundary specification for an operation.
 type BoundSpec struct {
 	// Range is the time range for the operation.
@@ -78,6 +78,14 @@ type EngineOptions interface {
 	// SetLookbackDuration sets the query lookback duration.
 	SetLookbackDuration(time.Duration) EngineOptions
 
+	// StreamingChunkSteps returns the number of steps per chunk that fetched
+	// blocks are streamed through queries in, zero if blocks are processed at
+	// once.
+	StreamingChunkSteps() int
+	// SetStreamingChunkSteps sets the number of steps per chunk that fetched
+	// blocks are streamed through queries in, zero to process blocks at once.
+	SetStreamingChunkSteps(int) EngineOptions
+
 	// ParseOptions returns the parse options.
 	ParseOptions() promql.ParseOptions
 	// SetParseOptions sets the parse options.
@@ -41,6 +41,7 @@ var aggregationFunctions = map[string]aggregationFn{
 	StandardDeviationType: stddevFn,
 	StandardVarianceType:  varianceFn,
 	CountType:             countFn,
+	GroupType:             groupFn,
 }
 
 // NodeParams contains additional parameters required for aggregation ops.
@@ -90,6 +91,9 @@ func (o baseOp) String() string {
 	return fmt.Sprintf("type: %s", o.OpType())
 }
 
+// Stepwise marks the operation as evaluated independently at each step.
+func (o baseOp) Stepwise() {}
+
 // Node creates an execution node.
 func (o baseOp) Node(
 	controller *transform.Controller,
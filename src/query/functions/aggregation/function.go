@@ -41,6 +41,8 @@ const (
 	StandardVarianceType = "var"
 	// CountType counts all non nan elements in a list of series.
 	CountType = "count"
+	// GroupType returns 1 for each group containing any non nan elements.
+	GroupType = "group"
 )
 
 func absentFn(values []float64, bucket []int) float64 {
@@ -163,3 +165,13 @@ func countFn(values []float64, bucket []int) float64 {
 	_, count := sumAndCount(values, bucket)
 	return count
 }
+
+func groupFn(values []float64, bucket []int) float64 {
+	for _, idx := range bucket {
+		if !math.IsNaN(values[idx]) {
+			return 1
+		}
+	}
+
+	return math.NaN()
+}
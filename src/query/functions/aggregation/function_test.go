@@ -51,6 +51,7 @@ var fnTest = []struct {
 			{StandardDeviationType, stddevFn, []float64{}},
 			{StandardVarianceType, varianceFn, []float64{}},
 			{CountType, countFn, []float64{}},
+			{GroupType, groupFn, []float64{}},
 		},
 	},
 	{
@@ -62,6 +63,7 @@ var fnTest = []struct {
 			{StandardDeviationType, stddevFn, []float64{0}},
 			{StandardVarianceType, varianceFn, []float64{0}},
 			{CountType, countFn, []float64{1}},
+			{GroupType, groupFn, []float64{1}},
 		},
 	},
 	{
@@ -73,6 +75,7 @@ var fnTest = []struct {
 			{StandardDeviationType, stddevFn, []float64{0.55}},
 			{StandardVarianceType, varianceFn, []float64{0.3025}},
 			{CountType, countFn, []float64{2}},
+			{GroupType, groupFn, []float64{1}},
 		},
 	},
 	{
@@ -84,6 +87,7 @@ var fnTest = []struct {
 			{StandardDeviationType, stddevFn, []float64{0, 0}},
 			{StandardVarianceType, varianceFn, []float64{0, 0}},
 			{CountType, countFn, []float64{1, 1}},
+			{GroupType, groupFn, []float64{1, 1}},
 		},
 	},
 	{
@@ -95,6 +99,7 @@ var fnTest = []struct {
 			{StandardDeviationType, stddevFn, []float64{2}},
 			{StandardVarianceType, varianceFn, []float64{4}},
 			{CountType, countFn, []float64{6}},
+			{GroupType, groupFn, []float64{1}},
 		},
 	},
 	{
@@ -109,6 +114,7 @@ var fnTest = []struct {
 			{StandardDeviationType, stddevFn, []float64{2, 36.73403}},
 			{StandardVarianceType, varianceFn, []float64{4, 1349.38889}},
 			{CountType, countFn, []float64{6, 6}},
+			{GroupType, groupFn, []float64{1, 1}},
 		},
 	},
 	{
@@ -122,6 +128,7 @@ var fnTest = []struct {
 			{StandardDeviationType, stddevFn, []float64{2.44949}},
 			{StandardVarianceType, varianceFn, []float64{6}},
 			{CountType, countFn, []float64{4}},
+			{GroupType, groupFn, []float64{1}},
 			{AbsentType, absentFn, []float64{nan}},
 		},
 	},
@@ -136,6 +143,7 @@ var fnTest = []struct {
 			{StandardDeviationType, stddevFn, []float64{nan}},
 			{StandardVarianceType, varianceFn, []float64{nan}},
 			{CountType, countFn, []float64{0}},
+			{GroupType, groupFn, []float64{nan}},
 			{AbsentType, absentFn, []float64{1}},
 		},
 	},
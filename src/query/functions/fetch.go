@@ -26,6 +26,7 @@ import (
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/temporal"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/storage"
@@ -46,6 +47,9 @@ type FetchOp struct {
 	Range    time.Duration
 	Offset   time.Duration
 	Matchers models.Matchers
+	// At pins the evaluation time of the fetched series, as with the
+	// Prometheus @ modifier, if set.
+	At *temporal.AtModifier
 }
 
 // FetchNode is a fetch execution node.
@@ -53,6 +57,7 @@ type FetchOp struct {
 type FetchNode struct {
 	debug          bool
 	blockType      models.FetchedBlockType
+	chunkSteps     int
 	op             FetchOp
 	controller     *transform.Controller
 	storage        storage.Storage
@@ -76,6 +81,12 @@ func (o FetchOp) Bounds() transform.BoundSpec {
 
 // String is the string representation for this operation.
 func (o FetchOp) String() string {
+	if o.At != nil {
+		return fmt.Sprintf("type: %s. name: %s, range: %v, offset: %v, "+
+			"at: %v, matchers: %v", o.OpType(), o.Name, o.Range, o.Offset,
+			*o.At, o.Matchers)
+	}
+
 	return fmt.Sprintf("type: %s. name: %s, range: %v, offset: %v, matchers: %v",
 		o.OpType(), o.Name, o.Range, o.Offset, o.Matchers)
 }
@@ -86,14 +97,22 @@ func (o FetchOp) Node(
 	storage storage.Storage,
 	options transform.Options,
 ) parser.Source {
+	timespec := options.TimeSpec()
+	if o.At != nil {
+		// NB: a pinned fetch covers the lookback or range ending at the pinned
+		// evaluation time rather than the query range.
+		timespec = o.At.TimeSpec(options, o.Range)
+	}
+
 	return &FetchNode{
 		op:             o,
 		controller:     controller,
 		storage:        storage,
 		fetchOpts:      options.FetchOptions(),
-		timespec:       options.TimeSpec(),
+		timespec:       timespec,
 		debug:          options.Debug(),
 		blockType:      options.BlockType(),
+		chunkSteps:     options.StreamingChunkSteps(),
 		instrumentOpts: options.InstrumentOptions(),
 	}
 }
@@ -141,6 +160,20 @@ func (n *FetchNode) Execute(queryCtx *models.QueryContext) error {
 			}
 		}
 
+		chunked, err := n.processChunks(queryCtx, block)
+		if err != nil {
+			block.Close()
+			// Fail on first error
+			return err
+		}
+
+		if chunked {
+			// NB: the chunks are consumed as they are processed, so the block is
+			// no longer referenced by the downstream steps.
+			block.Close()
+			continue
+		}
+
 		if err := n.controller.Process(queryCtx, block); err != nil {
 			block.Close()
 			// Fail on first error
@@ -154,11 +187,64 @@ func (n *FetchNode) Execute(queryCtx *models.QueryContext) error {
 		// NB: Until block closing is implemented correctly, this handles closing
 		// encoded iterators when there are additional processing steps, as these
 		// steps will not properly close the block. If there are no additional steps
-		// beyond the fetch, the read handler will close blocks.
-		if n.controller.HasMultipleOperations() {
+		// beyond the fetch, the read handler will close blocks. Streamed queries
+		// always copy results out of their blocks, so blocks are closed here.
+		if n.chunkSteps > 0 || n.controller.HasMultipleOperations() {
 			block.Close()
 		}
 	}
 
 	return nil
 }
+
+// processChunks streams the block through the downstream steps as
+// consecutive time chunks, returning false if the block is not streamed in
+// chunks and should be processed at once.
+//
+// NB: range selectors are evaluated over the raw datapoints of each series,
+// so their chunks overlap by the range of the selector, while other
+// selectors are consolidated into chunks of steps.
+func (n *FetchNode) processChunks(
+	queryCtx *models.QueryContext,
+	bl block.Block,
+) (bool, error) {
+	if n.chunkSteps <= 0 {
+		return false, nil
+	}
+
+	var (
+		chunks []block.Block
+		err    error
+	)
+	if n.op.Range > 0 {
+		chunker, ok := bl.(block.SeriesChunker)
+		if !ok {
+			return false, nil
+		}
+
+		chunks, err = chunker.SeriesChunks(n.chunkSteps, n.op.Range)
+	} else {
+		chunker, ok := bl.(block.StepChunker)
+		if !ok {
+			return false, nil
+		}
+
+		chunks, err = chunker.StepChunks(n.chunkSteps)
+	}
+
+	if err != nil {
+		return true, err
+	}
+
+	for _, chunk := range chunks {
+		if err := queryCtx.Ctx.Err(); err != nil {
+			return true, err
+		}
+
+		if err := n.controller.Process(queryCtx, chunk); err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
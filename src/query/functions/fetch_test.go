@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/temporal"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/storage"
@@ -122,6 +123,57 @@ func TestOffsetFetch(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPinnedFetch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now().Truncate(time.Minute)
+	at := now.Add(-1 * time.Hour)
+
+	store := storage.NewMockStorage(ctrl)
+	op := &FetchOp{
+		Range:  5 * time.Minute,
+		Offset: -1 * time.Minute,
+		At: &temporal.AtModifier{
+			Kind:      temporal.AtTimestamp,
+			Timestamp: at,
+		},
+	}
+
+	opts := transformtest.Options(t, transform.OptionsParams{
+		TimeSpec: transform.TimeSpec{
+			Start: now.Add(-10 * time.Minute),
+			End:   now,
+			Now:   now,
+			Step:  time.Minute,
+		},
+		LookbackDuration: time.Minute,
+	})
+
+	// NB: the fetch covers the range ending at the pinned evaluation time,
+	// shifted forward by the negative offset.
+	qMatcher := &predicateMatcher{
+		name: "query",
+		fn: func(i interface{}) bool {
+			q, ok := i.(*storage.FetchQuery)
+			if !ok {
+				return false
+			}
+
+			return q.Start.Equal(at.Add(-4*time.Minute)) &&
+				q.End.Equal(at.Add(2*time.Minute))
+		},
+	}
+
+	store.EXPECT().FetchBlocks(gomock.Any(), qMatcher, gomock.Any())
+
+	c, _ := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.Node(c, store, opts)
+
+	err := node.Execute(models.NoopQueryContext())
+	require.NoError(t, err)
+}
+
 func TestFetchWithRestrictFetch(t *testing.T) {
 	values, bounds := test.GenerateValuesAndBounds(nil, nil)
 	b := test.NewBlockFromValues(bounds, values)
@@ -22,6 +22,7 @@ package lazy
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
@@ -48,6 +49,25 @@ func NewLazyOp(
 	}, nil
 }
 
+// NewOffsetOp creates a new lazy operation shifting incoming data point
+// timestamps and metadata by the given offset; the offset may be negative,
+// as with a negative Prometheus offset modifier.
+func NewOffsetOp(offset time.Duration) (parser.Params, error) {
+	var (
+		tt = func(t time.Time) time.Time { return t.Add(offset) }
+		mt = func(meta block.Metadata) block.Metadata {
+			meta.Bounds.Start = meta.Bounds.Start.Add(offset)
+			return meta
+		}
+	)
+
+	lazyOpts := block.NewLazyOptions().
+		SetTimeTransform(tt).
+		SetMetaTransform(mt)
+
+	return NewLazyOp(OffsetType, lazyOpts)
+}
+
 // baseOp stores required properties for the baseOp
 type baseOp struct {
 	opType   string
@@ -62,6 +82,9 @@ func (o baseOp) String() string {
 	return fmt.Sprintf("type: %s", o.opType)
 }
 
+// Stepwise marks the operation as evaluated independently at each step.
+func (o baseOp) Stepwise() {}
+
 func (o baseOp) Node(
 	controller *transform.Controller,
 	_ transform.Options,
@@ -94,6 +94,41 @@ func TestOffsetOp(t *testing.T) {
 	assert.Equal(t, now.Add(offset), actual.Time())
 }
 
+func TestNegativeOffsetOp(t *testing.T) {
+	offset := -1 * time.Minute
+	op, err := NewOffsetOp(offset)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "offset", op.OpType())
+
+	base, ok := op.(baseOp)
+	require.True(t, ok)
+
+	node := base.Node(nil, transform.Options{})
+	n, ok := node.(*baseNode)
+	require.True(t, ok)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	b := block.NewMockBlock(ctrl)
+
+	now := time.Now()
+	bl := n.processBlock(b)
+	b.EXPECT().Meta().Return(buildMeta(now))
+	assert.Equal(t, now.Add(offset), bl.Meta().Bounds.Start)
+
+	it := block.NewMockStepIter(ctrl)
+	b.EXPECT().StepIter().Return(it, nil)
+
+	iter, err := bl.StepIter()
+	require.NoError(t, err)
+
+	step := block.NewMockStep(ctrl)
+	step.EXPECT().Time().Return(now)
+	it.EXPECT().Current().Return(step)
+	assert.Equal(t, now.Add(offset), iter.Current().Time())
+}
+
 func TestUnaryOp(t *testing.T) {
 	offset := time.Duration(0)
 	op, err := NewLazyOp(UnaryType, testLazyOpts(offset, -1.0))
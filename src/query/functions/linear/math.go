@@ -60,6 +60,54 @@ const (
 
 	// Log10Type calculates the decimal logarithm for values.
 	Log10Type = "log10"
+
+	// SgnType returns the sign of all values: 1 if positive, -1 if negative
+	// and 0 if zero.
+	SgnType = "sgn"
+
+	// Trigonometric functions operate on radians.
+
+	// SinType calculates the sine of all values.
+	SinType = "sin"
+
+	// CosType calculates the cosine of all values.
+	CosType = "cos"
+
+	// TanType calculates the tangent of all values.
+	TanType = "tan"
+
+	// AsinType calculates the arcsine of all values.
+	AsinType = "asin"
+
+	// AcosType calculates the arccosine of all values.
+	AcosType = "acos"
+
+	// AtanType calculates the arctangent of all values.
+	AtanType = "atan"
+
+	// SinhType calculates the hyperbolic sine of all values.
+	SinhType = "sinh"
+
+	// CoshType calculates the hyperbolic cosine of all values.
+	CoshType = "cosh"
+
+	// TanhType calculates the hyperbolic tangent of all values.
+	TanhType = "tanh"
+
+	// AsinhType calculates the inverse hyperbolic sine of all values.
+	AsinhType = "asinh"
+
+	// AcoshType calculates the inverse hyperbolic cosine of all values.
+	AcoshType = "acosh"
+
+	// AtanhType calculates the inverse hyperbolic tangent of all values.
+	AtanhType = "atanh"
+
+	// DegType converts all values from radians to degrees.
+	DegType = "deg"
+
+	// RadType converts all values from degrees to radians.
+	RadType = "rad"
 )
 
 var (
@@ -72,9 +120,44 @@ var (
 		LnType:    math.Log,
 		Log2Type:  math.Log2,
 		Log10Type: math.Log10,
+		SgnType:   sgn,
+		SinType:   math.Sin,
+		CosType:   math.Cos,
+		TanType:   math.Tan,
+		AsinType:  math.Asin,
+		AcosType:  math.Acos,
+		AtanType:  math.Atan,
+		SinhType:  math.Sinh,
+		CoshType:  math.Cosh,
+		TanhType:  math.Tanh,
+		AsinhType: math.Asinh,
+		AcoshType: math.Acosh,
+		AtanhType: math.Atanh,
+		DegType:   deg,
+		RadType:   rad,
 	}
 )
 
+func sgn(v float64) float64 {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+
+	// NB: this preserves NaN values.
+	return v
+}
+
+func deg(v float64) float64 {
+	return v * 180 / math.Pi
+}
+
+func rad(v float64) float64 {
+	return v * math.Pi / 180
+}
+
 // NewMathOp creates a new math op based on the type.
 func NewMathOp(opType string) (parser.Params, error) {
 	if fn, ok := mathFuncs[opType]; ok {
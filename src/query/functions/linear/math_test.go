@@ -246,6 +246,86 @@ func TestSqrtWithSomeValues(t *testing.T) {
 	test.EqualsWithNans(t, expected, sink.Values)
 }
 
+func TestSgn(t *testing.T) {
+	v := [][]float64{
+		{-2, math.NaN(), 0, 3, math.Inf(-1)},
+		{math.NaN(), 6, -0.5, math.Inf(1), 9},
+	}
+
+	values, bounds := test.GenerateValuesAndBounds(v, nil)
+	block := test.NewBlockFromValues(bounds, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	mathOp, err := NewMathOp(SgnType)
+	require.NoError(t, err)
+
+	op, ok := mathOp.(transform.Params)
+	require.True(t, ok)
+
+	node := op.Node(c, transform.Options{})
+	err = node.Process(models.NoopQueryContext(), parser.NodeID(0), block)
+	require.NoError(t, err)
+	expected := [][]float64{
+		{-1, math.NaN(), 0, 1, -1},
+		{math.NaN(), 1, -1, 1, 1},
+	}
+
+	assert.Len(t, sink.Values, 2)
+	test.EqualsWithNans(t, expected, sink.Values)
+}
+
+func TestTrigonometricFunctions(t *testing.T) {
+	v := [][]float64{
+		{0, math.NaN(), 0.5, 1, math.Pi},
+		{math.NaN(), -1, -0.5, 2, 180},
+	}
+
+	tests := []struct {
+		opType string
+		fn     func(float64) float64
+	}{
+		{SinType, math.Sin},
+		{CosType, math.Cos},
+		{TanType, math.Tan},
+		{AsinType, math.Asin},
+		{AcosType, math.Acos},
+		{AtanType, math.Atan},
+		{SinhType, math.Sinh},
+		{CoshType, math.Cosh},
+		{TanhType, math.Tanh},
+		{AsinhType, math.Asinh},
+		{AcoshType, math.Acosh},
+		{AtanhType, math.Atanh},
+		{DegType, func(x float64) float64 { return x * 180 / math.Pi }},
+		{RadType, func(x float64) float64 { return x * math.Pi / 180 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.opType, func(t *testing.T) {
+			values, bounds := test.GenerateValuesAndBounds(v, nil)
+			block := test.NewBlockFromValues(bounds, values)
+			c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+			mathOp, err := NewMathOp(tt.opType)
+			require.NoError(t, err)
+
+			op, ok := mathOp.(transform.Params)
+			require.True(t, ok)
+
+			node := op.Node(c, transform.Options{})
+			err = node.Process(models.NoopQueryContext(), parser.NodeID(0), block)
+			require.NoError(t, err)
+			expected := expectedMathVals(values, tt.fn)
+			assert.Len(t, sink.Values, 2)
+			test.EqualsWithNans(t, expected, sink.Values)
+		})
+	}
+}
+
+func TestDegAndRadKnownValues(t *testing.T) {
+	assert.InDelta(t, 180.0, deg(math.Pi), 1e-9)
+	assert.InDelta(t, math.Pi/2, rad(90), 1e-9)
+	assert.Equal(t, 0.0, deg(0))
+}
+
 func TestNonExistentFunc(t *testing.T) {
 	_, err := NewMathOp("nonexistent_func")
 	require.Error(t, err)
@@ -0,0 +1,182 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scalar
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// ToScalarType converts a vector to a scalar, as with the scalar()
+	// function. The value at each step is the value of the single series with
+	// a value at that step, or NaN if there is not exactly one such series.
+	ToScalarType = "to_scalar"
+
+	// ToVectorType converts a scalar to a vector with no tags, as with the
+	// vector() function.
+	ToVectorType = "to_vector"
+)
+
+// NewToScalarOp creates an operation converting a vector to a scalar.
+func NewToScalarOp(tagOptions models.TagOptions) (parser.Params, error) {
+	return newConversionOp(ToScalarType, tagOptions), nil
+}
+
+// NewToVectorOp creates an operation converting a scalar to a vector.
+func NewToVectorOp(tagOptions models.TagOptions) (parser.Params, error) {
+	return newConversionOp(ToVectorType, tagOptions), nil
+}
+
+type conversionOp struct {
+	opType     string
+	tagOptions models.TagOptions
+}
+
+func newConversionOp(opType string, tagOptions models.TagOptions) conversionOp {
+	return conversionOp{
+		opType:     opType,
+		tagOptions: tagOptions,
+	}
+}
+
+// OpType for the operator.
+func (o conversionOp) OpType() string {
+	return o.opType
+}
+
+// String representation.
+func (o conversionOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node.
+func (o conversionOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &conversionNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+type conversionNode struct {
+	op         conversionOp
+	controller *transform.Controller
+}
+
+func (n *conversionNode) Params() parser.Params {
+	return n.op
+}
+
+// Process the block.
+func (n *conversionNode) Process(
+	queryCtx *models.QueryContext,
+	ID parser.NodeID,
+	b block.Block,
+) error {
+	return transform.ProcessSimpleBlock(n, n.controller, queryCtx, ID, b)
+}
+
+func (n *conversionNode) ProcessBlock(
+	queryCtx *models.QueryContext,
+	ID parser.NodeID,
+	b block.Block,
+) (block.Block, error) {
+	// NB: constant scalars are already scalars, and are left as they are when
+	// converted to scalars.
+	if n.op.opType == ToScalarType && b.Info().Type() == block.BlockScalar {
+		return b, nil
+	}
+
+	iter, err := b.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	defer iter.Close()
+	if n.op.opType == ToVectorType && len(iter.SeriesMeta()) != 1 {
+		return nil, fmt.Errorf("%s expects a single series, got %d",
+			n.op.opType, len(iter.SeriesMeta()))
+	}
+
+	meta := b.Meta()
+	meta.Tags = models.NewTags(0, n.op.tagOptions)
+	seriesMeta := []block.SeriesMeta{
+		block.SeriesMeta{
+			Tags: models.NewTags(0, n.op.tagOptions),
+			Name: []byte{},
+		},
+	}
+
+	builder, err := n.controller.BlockBuilder(queryCtx, meta, seriesMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := builder.AddCols(iter.StepCount()); err != nil {
+		return nil, err
+	}
+
+	for index := 0; iter.Next(); index++ {
+		value := toScalar(iter.Current().Values())
+		if err := builder.AppendValue(index, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	if n.op.opType == ToScalarType {
+		// NB: time blocks are matched against every series in binary operations,
+		// which is the behavior expected of scalars.
+		return builder.BuildAsType(block.BlockTime), nil
+	}
+
+	return builder.Build(), nil
+}
+
+// toScalar returns the single non-NaN value from the given values, or NaN if
+// there is not exactly one.
+func toScalar(values []float64) float64 {
+	result := math.NaN()
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		if !math.IsNaN(result) {
+			return math.NaN()
+		}
+
+		result = v
+	}
+
+	return result
+}
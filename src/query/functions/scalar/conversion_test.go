@@ -0,0 +1,103 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scalar
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToScalar(t *testing.T) {
+	nan := math.NaN()
+	values, bounds := test.GenerateValuesAndBounds([][]float64{
+		{1, nan, 3, nan, nan},
+		{nan, nan, 5, 6, nan},
+	}, nil)
+
+	b := test.NewBlockFromValues(bounds, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	op, err := NewToScalarOp(models.NewTagOptions())
+	require.NoError(t, err)
+
+	node := op.(transform.Params).Node(c, transform.Options{})
+	err = node.Process(models.NoopQueryContext(), parser.NodeID(0), b)
+	require.NoError(t, err)
+
+	test.EqualsWithNans(t, [][]float64{{1, nan, nan, 6, nan}}, sink.Values)
+	assert.Equal(t, block.BlockTime, sink.Info.Type())
+	require.Len(t, sink.Metas, 1)
+	assert.Equal(t, 0, sink.Metas[0].Tags.Len())
+}
+
+func TestToScalarLeavesScalarsUnchanged(t *testing.T) {
+	_, bounds := test.GenerateValuesAndBounds(nil, nil)
+	b := block.NewScalar(4, block.Metadata{
+		Bounds:         bounds,
+		Tags:           models.NewTags(0, models.NewTagOptions()),
+		ResultMetadata: block.NewResultMetadata(),
+	})
+
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	op, err := NewToScalarOp(models.NewTagOptions())
+	require.NoError(t, err)
+
+	node := op.(transform.Params).Node(c, transform.Options{})
+	err = node.Process(models.NoopQueryContext(), parser.NodeID(0), b)
+	require.NoError(t, err)
+	assert.Equal(t, block.BlockScalar, sink.Info.Type())
+}
+
+func TestToVector(t *testing.T) {
+	bounds := models.Bounds{
+		Start:    time.Unix(0, 0),
+		Duration: 3 * time.Minute,
+		StepSize: time.Minute,
+	}
+
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	op, err := NewToVectorOp(models.NewTagOptions())
+	require.NoError(t, err)
+
+	node := op.(transform.Params).Node(c, transform.Options{})
+	b := test.NewBlockFromValues(bounds, [][]float64{{0, 60, 120}})
+	err = node.Process(models.NoopQueryContext(), parser.NodeID(0), b)
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]float64{{0, 60, 120}}, sink.Values)
+	assert.NotEqual(t, block.BlockTime, sink.Info.Type())
+	require.Len(t, sink.Metas, 1)
+	assert.Equal(t, 0, sink.Metas[0].Tags.Len())
+
+	b = test.NewBlockFromValues(bounds, [][]float64{{1, 2, 3}, {4, 5, 6}})
+	err = node.Process(models.NoopQueryContext(), parser.NodeID(0), b)
+	require.Error(t, err)
+}
@@ -42,6 +42,9 @@ const (
 	// NB: this does not actually return the current time, but the time at
 	// which the expression is to be evaluated.
 	TimeType = "time"
+
+	// PiType returns the value of pi.
+	PiType = "pi"
 )
 
 // ScalarOp is a scalar operation representing a constant.
@@ -21,6 +21,8 @@
 package scalar
 
 import (
+	"time"
+
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/models"
@@ -96,7 +98,8 @@ func (n *timeNode) Execute(queryCtx *models.QueryContext) error {
 			return err
 		}
 
-		timeVal := float64(t.Unix())
+		// NB: time() has sub-second precision, e.g. time() at 1ms is 0.001.
+		timeVal := float64(t.UnixNano()) / float64(time.Second)
 		if err := builder.AppendValue(i, timeVal); err != nil {
 			return err
 		}
@@ -36,6 +36,28 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestTimeSubSecondPrecision(t *testing.T) {
+	var (
+		start   = time.Unix(0, int64(time.Millisecond))
+		c, sink = executor.NewControllerWithSink(parser.NodeID(0))
+	)
+
+	op, err := NewTimeOp(models.NewTagOptions())
+	require.NoError(t, err)
+
+	node := op.(*timeOp).Node(c, transformtest.Options(t, transform.OptionsParams{
+		TimeSpec: transform.TimeSpec{
+			Start: start,
+			End:   start.Add(2 * time.Second),
+			Step:  time.Second,
+		},
+	}))
+
+	require.NoError(t, node.Execute(models.NoopQueryContext()))
+	require.Len(t, sink.Values, 1)
+	assert.Equal(t, []float64{0.001, 1.001}, sink.Values[0])
+}
+
 func TestTime(t *testing.T) {
 	_, bounds := test.GenerateValuesAndBounds(nil, nil)
 	c, sink := executor.NewControllerWithSink(parser.NodeID(0))
@@ -60,7 +82,9 @@ func TestTime(t *testing.T) {
 	assert.Equal(t, block.BlockTime, sink.Info.Type())
 
 	for i, vals := range sink.Values {
-		assert.Equal(t, float64(start.Add(time.Duration(i)*step).Unix()), vals[0])
+		expected := float64(start.Add(time.Duration(i)*step).UnixNano()) /
+			float64(time.Second)
+		assert.Equal(t, expected, vals[0])
 	}
 
 	resultMeta := sink.Meta.ResultMetadata
@@ -75,6 +75,9 @@ func (o baseOp) String() string {
 	return fmt.Sprintf("type: %s", o.OpType())
 }
 
+// Stepwise marks the operation as evaluated independently at each step.
+func (o baseOp) Stepwise() {}
+
 // Node creates a tag execution node.
 func (o baseOp) Node(
 	controller *transform.Controller,
@@ -213,6 +213,54 @@ var aggregationTestCases = []testCase{
 			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
 		},
 	},
+	{
+		name:   "last_over_time",
+		opType: LastType,
+		vals: [][]float64{
+			{nan, 1, nan, 3, nan, nan, nan, nan, nan, nan},
+			{5, nan, nan, nan, nan, nan, nan, nan, nan, 9},
+		},
+		expected: [][]float64{
+			{nan, 1, 1, 3, 3, 3, 3, 3, nan, nan},
+			{5, 5, 5, 5, 5, nan, nan, nan, nan, 9},
+		},
+	},
+	{
+		name:   "last_over_time all NaNs",
+		opType: LastType,
+		vals: [][]float64{
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+		},
+		expected: [][]float64{
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+		},
+	},
+	{
+		name:   "present_over_time",
+		opType: PresentType,
+		vals: [][]float64{
+			{nan, 1, nan, 3, nan, nan, nan, nan, nan, nan},
+			{5, nan, nan, nan, nan, nan, nan, nan, nan, 9},
+		},
+		expected: [][]float64{
+			{nan, 1, 1, 1, 1, 1, 1, 1, nan, nan},
+			{1, 1, 1, 1, 1, nan, nan, nan, nan, 1},
+		},
+	},
+	{
+		name:   "present_over_time all NaNs",
+		opType: PresentType,
+		vals: [][]float64{
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+		},
+		expected: [][]float64{
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+		},
+	},
 	{
 		name:   "quantile_over_time",
 		opType: QuantileType,
@@ -0,0 +1,250 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+// AtType pins the evaluation time of an expression, as with the Prometheus
+// @ modifier, e.g. rate(x[5m] @ 1609746000). The pinned value is repeated at
+// every step of the enclosing expression.
+const AtType = "at"
+
+// AtKind describes how the evaluation time of an @ modifier is resolved.
+type AtKind uint8
+
+const (
+	// AtTimestamp pins evaluation to a fixed timestamp.
+	AtTimestamp AtKind = iota
+	// AtStart pins evaluation to the start of the query, i.e. @ start().
+	AtStart
+	// AtEnd pins evaluation to the end of the query, i.e. @ end().
+	AtEnd
+)
+
+func (k AtKind) String() string {
+	switch k {
+	case AtTimestamp:
+		return "timestamp"
+	case AtStart:
+		return "start()"
+	case AtEnd:
+		return "end()"
+	}
+
+	return "unknown"
+}
+
+// AtModifier pins the evaluation time of an expression, as with the
+// Prometheus @ modifier.
+type AtModifier struct {
+	// Kind is how the pinned evaluation time is resolved.
+	Kind AtKind
+	// Timestamp is the pinned evaluation time for the AtTimestamp kind.
+	Timestamp time.Time
+}
+
+func (m AtModifier) String() string {
+	if m.Kind == AtTimestamp {
+		return m.Timestamp.String()
+	}
+
+	return m.Kind.String()
+}
+
+// EvaluationTime returns the pinned evaluation time for a query evaluated
+// with the given options.
+func (m AtModifier) EvaluationTime(opts transform.Options) time.Time {
+	switch m.Kind {
+	case AtStart:
+		return opts.QueryStart()
+	case AtEnd:
+		return opts.QueryEnd()
+	default:
+		return m.Timestamp
+	}
+}
+
+// TimeSpec returns the time spec to evaluate the pinned expression with, given
+// the largest range used within it; the final step of this time spec falls on
+// the pinned evaluation time.
+func (m AtModifier) TimeSpec(
+	opts transform.Options,
+	rng time.Duration,
+) transform.TimeSpec {
+	var (
+		timeSpec = opts.TimeSpec()
+		step     = timeSpec.Step
+		at       = m.EvaluationTime(opts)
+		lookback = opts.LookbackDuration()
+	)
+
+	if rng > lookback {
+		lookback = rng
+	}
+
+	// NB: align the lookback to the step so that a step falls exactly on the
+	// pinned evaluation time.
+	if rem := lookback % step; rem != 0 {
+		lookback += step - rem
+	}
+
+	return transform.TimeSpec{
+		Start: at.Add(-1 * lookback),
+		End:   at.Add(step),
+		Now:   timeSpec.Now,
+		Step:  step,
+	}
+}
+
+type atOp struct {
+	at         AtModifier
+	innerRange time.Duration
+}
+
+// NewAtOp creates a new operation pinning the evaluation time of its parents.
+// The timestamp is only used for the AtTimestamp kind. The inner range is the
+// largest range used by any operation within the pinned expression, and is
+// used to ensure enough data is fetched to evaluate it at the pinned time.
+func NewAtOp(
+	kind AtKind,
+	timestamp time.Time,
+	innerRange time.Duration,
+) (transform.Params, error) {
+	switch kind {
+	case AtTimestamp, AtStart, AtEnd:
+	default:
+		return nil, fmt.Errorf("unknown @ modifier kind: %d", kind)
+	}
+
+	return atOp{
+		at: AtModifier{
+			Kind:      kind,
+			Timestamp: timestamp,
+		},
+		innerRange: innerRange,
+	}, nil
+}
+
+func (o atOp) OpType() string {
+	return AtType
+}
+
+func (o atOp) String() string {
+	return fmt.Sprintf("type: %s, timestamp: %v", o.OpType(), o.at)
+}
+
+// ParentTimeSpec returns the time spec to evaluate the pinned expression with;
+// the final step of this time spec falls on the pinned evaluation time.
+func (o atOp) ParentTimeSpec(opts transform.Options) transform.TimeSpec {
+	return o.at.TimeSpec(opts, o.innerRange)
+}
+
+// Node creates an execution node.
+func (o atOp) Node(
+	controller *transform.Controller,
+	opts transform.Options,
+) transform.OpNode {
+	return &atNode{
+		op:         o,
+		controller: controller,
+		timeSpec:   opts.TimeSpec(),
+	}
+}
+
+type atNode struct {
+	op         atOp
+	controller *transform.Controller
+	timeSpec   transform.TimeSpec
+}
+
+func (n *atNode) Params() parser.Params {
+	return n.op
+}
+
+// Process repeats the values of the pinned expression at its final step for
+// every step of the enclosing expression.
+func (n *atNode) Process(
+	queryCtx *models.QueryContext,
+	_ parser.NodeID,
+	b block.Block,
+) error {
+	sp, _ := opentracing.StartSpanFromContext(queryCtx.Ctx, n.op.OpType())
+	defer sp.Finish()
+
+	it, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	var values []float64
+	for it.Next() {
+		// NB: step values may be reused by the iterator, so copy them out.
+		values = append(values[:0], it.Current().Values()...)
+	}
+
+	err = it.Err()
+	seriesMetas := it.SeriesMeta()
+	it.Close()
+	if err != nil {
+		return err
+	}
+
+	if len(values) != len(seriesMetas) {
+		return fmt.Errorf("mismatch in pinned values and series, values: %d, "+
+			"series: %d", len(values), len(seriesMetas))
+	}
+
+	meta := b.Meta()
+	meta.Bounds = n.timeSpec.Bounds()
+	builder, err := n.controller.BlockBuilder(queryCtx, meta, seriesMetas)
+	if err != nil {
+		return err
+	}
+
+	steps := meta.Bounds.Steps()
+	if err := builder.AddCols(steps); err != nil {
+		return err
+	}
+
+	for i := 0; i < steps; i++ {
+		if err := builder.AppendValues(i, values); err != nil {
+			return err
+		}
+	}
+
+	if err := b.Close(); err != nil {
+		return err
+	}
+
+	bl := builder.Build()
+	defer bl.Close()
+	return n.controller.Process(queryCtx, bl)
+}
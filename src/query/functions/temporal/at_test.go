@@ -0,0 +1,123 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+	"github.com/m3db/m3/src/query/test/transformtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtOpParentTimeSpec(t *testing.T) {
+	var (
+		start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		end   = start.Add(time.Hour)
+		at    = start.Add(-time.Hour)
+		opts  = transformtest.Options(t, transform.OptionsParams{
+			TimeSpec: transform.TimeSpec{
+				Start: start.Add(-10 * time.Minute),
+				End:   end.Add(time.Minute),
+				Step:  time.Minute,
+			},
+			QueryStart:       start,
+			QueryEnd:         end,
+			LookbackDuration: 5 * time.Minute,
+		})
+	)
+
+	tests := []struct {
+		kind       AtKind
+		innerRange time.Duration
+		expected   time.Time
+		lookback   time.Duration
+	}{
+		{kind: AtTimestamp, expected: at, lookback: 5 * time.Minute},
+		{kind: AtStart, expected: start, lookback: 5 * time.Minute},
+		{
+			kind:       AtEnd,
+			innerRange: 90 * time.Second,
+			expected:   end,
+			lookback:   5 * time.Minute,
+		},
+		{
+			kind:       AtEnd,
+			innerRange: 10*time.Minute + time.Second,
+			expected:   end,
+			lookback:   11 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind.String(), func(t *testing.T) {
+			op, err := NewAtOp(tt.kind, at, tt.innerRange)
+			require.NoError(t, err)
+			timeSpecOp, ok := op.(transform.TimeSpecOp)
+			require.True(t, ok)
+
+			spec := timeSpecOp.ParentTimeSpec(opts)
+			assert.Equal(t, tt.expected.Add(-tt.lookback), spec.Start)
+			assert.Equal(t, tt.expected.Add(time.Minute), spec.End)
+			assert.Equal(t, time.Minute, spec.Step)
+		})
+	}
+
+	_, err := NewAtOp(AtKind(10), at, 0)
+	require.Error(t, err)
+}
+
+func TestAtOpRepeatsPinnedValues(t *testing.T) {
+	var (
+		start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		inner = test.NewBlockFromValues(models.Bounds{
+			Start:    start,
+			Duration: 3 * time.Minute,
+			StepSize: time.Minute,
+		}, [][]float64{{1, 2, 3}, {4, 5, 6}})
+		opts = transformtest.Options(t, transform.OptionsParams{
+			TimeSpec: transform.TimeSpec{
+				Start: start.Add(time.Hour),
+				End:   start.Add(time.Hour + 4*time.Minute),
+				Step:  time.Minute,
+			},
+		})
+	)
+
+	op, err := NewAtOp(AtTimestamp, start.Add(2*time.Minute), 0)
+	require.NoError(t, err)
+
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.Node(c, opts)
+	err = node.Process(models.NoopQueryContext(), parser.NodeID(0), inner)
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]float64{{3, 3, 3, 3}, {6, 6, 6, 6}}, sink.Values)
+	assert.Equal(t, start.Add(time.Hour), sink.Meta.Bounds.Start)
+	assert.Equal(t, 4, sink.Meta.Bounds.Steps())
+}
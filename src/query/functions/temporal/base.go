@@ -85,6 +85,9 @@ func (o baseOp) String() string {
 	return fmt.Sprintf("type: %s, duration: %v", o.OpType(), o.duration)
 }
 
+// Windowed marks the operation as evaluated over the range before each step.
+func (o baseOp) Windowed() {}
+
 // Node creates an execution node.
 func (o baseOp) Node(
 	controller *transform.Controller,
@@ -133,6 +136,9 @@ func (c *baseNode) Process(
 		stepSize:    xtime.UnixNano(bounds.StepSize),
 		steps:       bounds.Steps(),
 		resultMeta:  resultMeta,
+		// NB: last_over_time returns values of the original series, so the
+		// series keep their names.
+		keepName: c.op.operatorType == LastType,
 	}
 
 	concurrency := runtime.NumCPU()
@@ -167,6 +173,7 @@ type blockMeta struct {
 	queryCtx    *models.QueryContext
 	steps       int
 	resultMeta  block.ResultMetadata
+	keepName    bool
 }
 
 func (c *baseNode) batchProcess(
@@ -277,8 +284,10 @@ func parallelProcess(
 
 		// rename series to exclude their __name__ tag as
 		// part of function processing.
-		seriesMeta.Tags = seriesMeta.Tags.WithoutName()
-		seriesMeta.Name = seriesMeta.Tags.ID()
+		if !blockMeta.keepName {
+			seriesMeta.Tags = seriesMeta.Tags.WithoutName()
+			seriesMeta.Name = seriesMeta.Tags.ID()
+		}
 		values = values[:0]
 		for i := 0; i < blockMeta.steps; i++ {
 			iterBounds := iterationBounds{
@@ -344,7 +353,13 @@ func (c *baseNode) singleProcess(
 
 	// rename series to exclude their __name__ tag as part of function processing.
 	resultSeriesMeta := make([]block.SeriesMeta, 0, len(seriesIter.SeriesMeta()))
+	keepName := m.keepName
 	for _, m := range seriesIter.SeriesMeta() {
+		if keepName {
+			resultSeriesMeta = append(resultSeriesMeta, m)
+			continue
+		}
+
 		tags := m.Tags.WithoutName()
 		resultSeriesMeta = append(resultSeriesMeta, block.SeriesMeta{
 			Name: tags.ID(),
@@ -153,6 +153,11 @@ func testTemporalFunc(t *testing.T, opGen opGenerator, tests []testCase) {
 				// NB: name should be dropped from series tags, and the name
 				// should be the updated ID.
 				expectedSeriesMetas := []block.SeriesMeta{metaOne, metaTwo}
+				if tt.opType == LastType {
+					// NB: last_over_time keeps the original series names.
+					expectedSeriesMetas = seriesMetas
+				}
+
 				require.Equal(t, expectedSeriesMetas, sink.Metas)
 			})
 		}
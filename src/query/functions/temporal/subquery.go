@@ -0,0 +1,208 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/ts"
+
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+// SubqueryType evaluates an instant vector expression over a range at a given
+// resolution, yielding a range vector that temporal functions can consume,
+// e.g. max_over_time(rate(x[5m])[1h:1m]).
+const SubqueryType = "subquery"
+
+type subqueryOp struct {
+	rng        time.Duration
+	step       time.Duration
+	offset     time.Duration
+	innerRange time.Duration
+}
+
+// NewSubqueryOp creates a new subquery operation, evaluating its parents at
+// the given step, offset by the given duration. The inner range is the
+// largest range used by any operation within the subquery, and is used to
+// ensure enough data is fetched to fill the first subquery window.
+func NewSubqueryOp(
+	rng time.Duration,
+	step time.Duration,
+	offset time.Duration,
+	innerRange time.Duration,
+) (transform.Params, error) {
+	if rng <= 0 {
+		return nil, fmt.Errorf("subquery range must be positive, received: %v", rng)
+	}
+
+	if step <= 0 {
+		return nil, fmt.Errorf("subquery step must be positive, received: %v", step)
+	}
+
+	return subqueryOp{
+		rng:        rng,
+		step:       step,
+		offset:     offset,
+		innerRange: innerRange,
+	}, nil
+}
+
+func (o subqueryOp) OpType() string {
+	return SubqueryType
+}
+
+func (o subqueryOp) String() string {
+	return fmt.Sprintf("type: %s, range: %v, step: %v, offset: %v",
+		o.OpType(), o.rng, o.step, o.offset)
+}
+
+// Bounds returns the bounds for this operation.
+func (o subqueryOp) Bounds() transform.BoundSpec {
+	return transform.BoundSpec{
+		Range:  o.rng + o.innerRange,
+		Offset: o.offset,
+	}
+}
+
+// ParentTimeSpec returns the time spec to evaluate the subquery expression
+// with, given the options of the enclosing expression. The subquery expression
+// is evaluated from one subquery range before the start of the enclosing
+// expression, so that its first step sees a full range window.
+func (o subqueryOp) ParentTimeSpec(opts transform.Options) transform.TimeSpec {
+	var (
+		timeSpec = opts.TimeSpec()
+		start    = timeSpec.Start.Add(-1 * (o.offset + o.rng)).UnixNano()
+		step     = int64(o.step)
+	)
+
+	// NB: Prometheus aligns subquery evaluation timestamps to absolute multiples
+	// of the subquery step rather than to the start of the enclosing query,
+	// starting from the first aligned timestamp within the subquery range.
+	if rem := start % step; rem != 0 {
+		if rem < 0 {
+			rem += step
+		}
+
+		start += step - rem
+	}
+
+	return transform.TimeSpec{
+		Start: time.Unix(0, start),
+		End:   timeSpec.End.Add(-1 * o.offset),
+		Now:   timeSpec.Now,
+		Step:  o.step,
+	}
+}
+
+// Node creates an execution node.
+func (o subqueryOp) Node(
+	controller *transform.Controller,
+	opts transform.Options,
+) transform.OpNode {
+	return &subqueryNode{
+		op:         o,
+		controller: controller,
+		timeSpec:   opts.TimeSpec(),
+	}
+}
+
+type subqueryNode struct {
+	op         subqueryOp
+	controller *transform.Controller
+	timeSpec   transform.TimeSpec
+}
+
+func (n *subqueryNode) Params() parser.Params {
+	return n.op
+}
+
+// Process converts the evaluated subquery expression into an unconsolidated
+// block over the time range of the enclosing expression.
+func (n *subqueryNode) Process(
+	queryCtx *models.QueryContext,
+	_ parser.NodeID,
+	b block.Block,
+) error {
+	sp, _ := opentracing.StartSpanFromContext(queryCtx.Ctx, n.op.OpType())
+	defer sp.Finish()
+
+	nextBlock, err := n.processBlock(b)
+	if err != nil {
+		return err
+	}
+
+	if err := b.Close(); err != nil {
+		return err
+	}
+
+	return n.controller.Process(queryCtx, nextBlock)
+}
+
+func (n *subqueryNode) processBlock(b block.Block) (block.Block, error) {
+	it, err := b.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	defer it.Close()
+	var (
+		seriesMetas = it.SeriesMeta()
+		datapoints  = make([]ts.Datapoints, len(seriesMetas))
+	)
+
+	for it.Next() {
+		step := it.Current()
+		t := step.Time().Add(n.op.offset)
+		for i, v := range step.Values() {
+			// NB: steps without a value in the subquery expression are absent
+			// from the resulting range vector, rather than being NaN points.
+			if math.IsNaN(v) {
+				continue
+			}
+
+			datapoints[i] = append(datapoints[i], ts.Datapoint{
+				Timestamp: t,
+				Value:     v,
+			})
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	series := make([]block.UnconsolidatedSeries, 0, len(seriesMetas))
+	for i, meta := range seriesMetas {
+		series = append(series, block.NewUnconsolidatedSeries(datapoints[i],
+			meta, block.UnconsolidatedSeriesStats{}))
+	}
+
+	meta := b.Meta()
+	meta.Bounds = n.timeSpec.Bounds()
+	return block.NewUnconsolidatedBlock(meta, series), nil
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+	"github.com/m3db/m3/src/query/test/transformtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubqueryOpValidation(t *testing.T) {
+	_, err := NewSubqueryOp(0, time.Minute, 0, 0)
+	require.Error(t, err)
+
+	_, err = NewSubqueryOp(time.Hour, 0, 0, 0)
+	require.Error(t, err)
+
+	op, err := NewSubqueryOp(time.Hour, time.Minute, time.Minute, 5*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, SubqueryType, op.OpType())
+	assert.Equal(t, "type: subquery, range: 1h0m0s, step: 1m0s, offset: 1m0s",
+		op.String())
+
+	boundOp, ok := op.(transform.BoundOp)
+	require.True(t, ok)
+	assert.Equal(t, transform.BoundSpec{
+		Range:  time.Hour + 5*time.Minute,
+		Offset: time.Minute,
+	}, boundOp.Bounds())
+}
+
+func TestSubqueryParentTimeSpec(t *testing.T) {
+	op, err := NewSubqueryOp(time.Hour, time.Minute, 2*time.Minute, 0)
+	require.NoError(t, err)
+
+	timeSpecOp, ok := op.(transform.TimeSpecOp)
+	require.True(t, ok)
+
+	start := time.Date(2020, 1, 1, 0, 10, 30, 0, time.UTC)
+	now := start.Add(time.Hour)
+	spec := timeSpecOp.ParentTimeSpec(transformtest.Options(t,
+		transform.OptionsParams{
+			TimeSpec: transform.TimeSpec{
+				Start: start,
+				End:   start.Add(10 * time.Minute),
+				Now:   now,
+				Step:  15 * time.Second,
+			},
+		}))
+
+	// NB: start should be offset by the offset and the range, then aligned to
+	// the first subquery step within the range.
+	expectedStart := time.Date(2019, 12, 31, 23, 9, 0, 0, time.UTC)
+	assert.True(t, expectedStart.Equal(spec.Start))
+	assert.True(t, start.Add(8*time.Minute).Equal(spec.End))
+	assert.Equal(t, now, spec.Now)
+	assert.Equal(t, time.Minute, spec.Step)
+}
+
+func TestSubqueryFeedsTemporalFunction(t *testing.T) {
+	var (
+		nan   = math.NaN()
+		start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		// NB: the subquery expression is evaluated from one subquery range
+		// before the start of the enclosing expression.
+		inner = test.NewBlockFromValues(models.Bounds{
+			Start:    start.Add(-5 * time.Minute),
+			Duration: 15 * time.Minute,
+			StepSize: time.Minute,
+		}, [][]float64{
+			{0, 1, 2, nan, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14},
+			{14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+		})
+		opts = transformtest.Options(t, transform.OptionsParams{
+			TimeSpec: transform.TimeSpec{
+				Start: start,
+				End:   start.Add(10 * time.Minute),
+				Step:  5 * time.Minute,
+			},
+		})
+	)
+
+	maxOp, err := NewAggOp([]interface{}{5 * time.Minute}, MaxType)
+	require.NoError(t, err)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(2))
+	maxNode := maxOp.Node(c, opts)
+
+	sqOp, err := NewSubqueryOp(5*time.Minute, time.Minute, 0, 0)
+	require.NoError(t, err)
+	parentSpec := sqOp.(transform.TimeSpecOp).ParentTimeSpec(opts)
+	require.True(t, start.Add(-5*time.Minute).Equal(parentSpec.Start))
+	sqController := &transform.Controller{ID: parser.NodeID(1)}
+	sqController.AddTransform(maxNode)
+	sqNode := sqOp.Node(sqController, opts)
+
+	err = sqNode.Process(models.NoopQueryContext(), parser.NodeID(0), inner)
+	require.NoError(t, err)
+
+	// NB: max_over_time at each step covers the inclusive window
+	// [t-5m, t] of subquery results, as in Prometheus.
+	assert.Equal(t, [][]float64{{5, 10}, {14, 9}}, sink.Values)
+	assert.Equal(t, 5*time.Minute, sink.Meta.Bounds.StepSize)
+	assert.Equal(t, start, sink.Meta.Bounds.Start)
+}
+
+func TestSubqueryProcessBlockOffset(t *testing.T) {
+	var (
+		start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		inner = test.NewBlockFromValues(models.Bounds{
+			Start:    start,
+			Duration: 3 * time.Minute,
+			StepSize: time.Minute,
+		}, [][]float64{{1, 2, 3}})
+		opts = transformtest.Options(t, transform.OptionsParams{
+			TimeSpec: transform.TimeSpec{
+				Start: start,
+				End:   start.Add(time.Hour),
+				Step:  time.Hour,
+			},
+		})
+	)
+
+	op, err := NewSubqueryOp(time.Hour, time.Minute, time.Hour, 0)
+	require.NoError(t, err)
+	node, ok := op.Node(nil, opts).(*subqueryNode)
+	require.True(t, ok)
+
+	bl, err := node.processBlock(inner)
+	require.NoError(t, err)
+	assert.Equal(t, block.BlockUnconsolidated, bl.Info().Type())
+
+	it, err := bl.SeriesIter()
+	require.NoError(t, err)
+	require.True(t, it.Next())
+
+	dps := it.Current().Datapoints()
+	require.Equal(t, 3, len(dps))
+	for i, dp := range dps {
+		assert.Equal(t, float64(i+1), dp.Value)
+		assert.True(t, start.Add(time.Hour+time.Duration(i)*time.Minute).
+			Equal(dp.Timestamp))
+	}
+
+	assert.False(t, it.Next())
+	require.NoError(t, it.Err())
+}
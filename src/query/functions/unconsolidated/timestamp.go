@@ -70,17 +70,19 @@ func newTimestampOp(opType string) timestampOp {
 // Node creates an execution node
 func (o timestampOp) Node(
 	controller *transform.Controller,
-	_ transform.Options,
+	opts transform.Options,
 ) transform.OpNode {
 	return &timestampNode{
 		op:         o,
 		controller: controller,
+		lookback:   opts.LookbackDuration(),
 	}
 }
 
 type timestampNode struct {
 	op         timestampOp
 	controller *transform.Controller
+	lookback   time.Duration
 }
 
 func (n *timestampNode) Params() parser.Params {
@@ -101,18 +103,28 @@ func (n *timestampNode) ProcessBlock(
 	ID parser.NodeID,
 	b block.Block,
 ) (block.Block, error) {
+	// NB: if the block has unconsolidated series, e.g. for a fetch, use the
+	// timestamps of the underlying datapoints. Otherwise, the values have been
+	// computed at each step, so use the timestamp of the step.
+	if n.lookback > 0 {
+		if iter, err := b.SeriesIter(); err == nil {
+			return n.processSeries(queryCtx, b.Meta(), iter)
+		}
+	}
+
 	iter, err := b.StepIter()
 	if err != nil {
 		return nil, err
 	}
 
-	builder, err := n.controller.BlockBuilder(queryCtx, b.Meta(), iter.SeriesMeta())
+	seriesMetas := withoutNames(iter.SeriesMeta())
+	builder, err := n.controller.BlockBuilder(queryCtx, b.Meta(), seriesMetas)
 	if err != nil {
 		return nil, err
 	}
 
 	count := iter.StepCount()
-	seriesCount := len(iter.SeriesMeta())
+	seriesCount := len(seriesMetas)
 	if err = builder.AddCols(count); err != nil {
 		return nil, err
 	}
@@ -142,3 +154,77 @@ func (n *timestampNode) ProcessBlock(
 
 	return builder.Build(), nil
 }
+
+// processSeries uses the timestamp of the latest datapoint within the lookback
+// duration of each step, matching the datapoint that a selector would return
+// for that step.
+func (n *timestampNode) processSeries(
+	queryCtx *models.QueryContext,
+	meta block.Metadata,
+	iter block.SeriesIter,
+) (block.Block, error) {
+	defer iter.Close()
+
+	seriesMetas := withoutNames(iter.SeriesMeta())
+	builder, err := n.controller.BlockBuilder(queryCtx, meta, seriesMetas)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		bounds = meta.Bounds
+		steps  = bounds.Steps()
+	)
+
+	if err = builder.AddCols(steps); err != nil {
+		return nil, err
+	}
+
+	for iter.Next() {
+		var (
+			datapoints = iter.Current().Datapoints()
+			idx        = 0
+		)
+
+		for i := 0; i < steps; i++ {
+			stepTime := bounds.Start.Add(time.Duration(i) * bounds.StepSize)
+			for idx < len(datapoints) && !datapoints[idx].Timestamp.After(stepTime) {
+				idx++
+			}
+
+			value := math.NaN()
+			if idx > 0 {
+				dp := datapoints[idx-1]
+				if !math.IsNaN(dp.Value) &&
+					!dp.Timestamp.Before(stepTime.Add(-1*n.lookback)) {
+					value = float64(dp.Timestamp.UnixNano()) / float64(time.Second)
+				}
+			}
+
+			if err := builder.AppendValue(i, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = iter.Err(); err != nil {
+		return nil, err
+	}
+
+	return builder.Build(), nil
+}
+
+// withoutNames removes the metric name from the given series metadata, as the
+// result of timestamp is no longer the original metric.
+func withoutNames(metas []block.SeriesMeta) []block.SeriesMeta {
+	results := make([]block.SeriesMeta, 0, len(metas))
+	for _, m := range metas {
+		tags := m.Tags.WithoutName()
+		results = append(results, block.SeriesMeta{
+			Name: tags.ID(),
+			Tags: tags,
+		})
+	}
+
+	return results
+}
@@ -21,14 +21,18 @@
 package unconsolidated
 
 import (
+	"math"
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/test"
 	"github.com/m3db/m3/src/query/test/executor"
+	"github.com/m3db/m3/src/query/test/transformtest"
+	"github.com/m3db/m3/src/query/ts"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,3 +57,58 @@ func TestTimestamp(t *testing.T) {
 		}
 	}
 }
+
+func TestTimestampUsesDatapointTimestamps(t *testing.T) {
+	var (
+		start  = time.Unix(0, 0)
+		bounds = models.Bounds{
+			Start:    start,
+			Duration: 20 * time.Second,
+			StepSize: 5 * time.Second,
+		}
+
+		seriesMetas = test.NewSeriesMeta("foo", 1)
+		meta        = block.Metadata{
+			Bounds:         bounds,
+			Tags:           models.NewTags(0, models.NewTagOptions()),
+			ResultMetadata: block.NewResultMetadata(),
+		}
+	)
+
+	datapoints := ts.Datapoints{
+		{Timestamp: start, Value: 1},
+		{Timestamp: start.Add(10 * time.Second), Value: 1},
+	}
+
+	tests := []struct {
+		name     string
+		lookback time.Duration
+		expected []float64
+	}{
+		{"long lookback", time.Minute, []float64{0, 0, 10, 10}},
+		{"short lookback", 4 * time.Second, []float64{0, math.NaN(), 10, math.NaN()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := block.NewUnconsolidatedBlock(meta, []block.UnconsolidatedSeries{
+				block.NewUnconsolidatedSeries(datapoints, seriesMetas[0],
+					block.UnconsolidatedSeriesStats{}),
+			})
+
+			c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+			opts := transformtest.Options(t, transform.OptionsParams{
+				LookbackDuration: tt.lookback,
+			})
+
+			node := newTimestampOp(TimestampType).Node(c, opts)
+			err := node.Process(models.NoopQueryContext(), parser.NodeID(0), b)
+			require.NoError(t, err)
+
+			test.EqualsWithNans(t, [][]float64{tt.expected}, sink.Values)
+			require.Len(t, sink.Metas, 1)
+			_, hasName := sink.Metas[0].Tags.Name()
+			assert.False(t, hasName)
+		})
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/m3db/m3/src/query/graphite/errors"
+)
+
+const (
+	// TaggedNameTag is the reserved tag that holds the metric path of a
+	// Graphite 1.1 tagged series, i.e. "foo.bar" for "foo.bar;dc=east".
+	TaggedNameTag = "name"
+
+	tagSeparator      = ";"
+	tagValueSeparator = "="
+	// Characters that are not allowed in tag names.
+	invalidTagNameChars = ";!^="
+)
+
+// Tag is a single Graphite tag.
+type Tag struct {
+	Name  string
+	Value string
+}
+
+// IsTaggedName returns true if the series name is a Graphite tagged series
+// name of the form path;tag1=value1;tag2=value2.
+func IsTaggedName(name string) bool {
+	return strings.Contains(name, tagSeparator)
+}
+
+// ParseTaggedName parses a Graphite tagged series name of the form
+// path;tag1=value1;tag2=value2 into its path and tags, sorted by tag name.
+func ParseTaggedName(name string) (string, []Tag, error) {
+	parts := strings.Split(name, tagSeparator)
+	path := parts[0]
+	if path == "" {
+		return "", nil, errors.NewInvalidParamsError(
+			fmt.Errorf("tagged series has an empty path: %s", name))
+	}
+
+	tags := make([]Tag, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		idx := strings.Index(part, tagValueSeparator)
+		if idx < 0 {
+			return "", nil, errors.NewInvalidParamsError(
+				fmt.Errorf("tag %q has no value in series: %s", part, name))
+		}
+
+		tag := Tag{Name: part[:idx], Value: part[idx+1:]}
+		if err := ValidateTag(tag); err != nil {
+			return "", nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+	for i := 1; i < len(tags); i++ {
+		if tags[i-1].Name == tags[i].Name {
+			return "", nil, errors.NewInvalidParamsError(
+				fmt.Errorf("tag %s appears more than once in series: %s",
+					tags[i].Name, name))
+		}
+	}
+
+	return path, tags, nil
+}
+
+// ValidateTag validates a tag according to the Graphite tag rules: names
+// must be non-empty and not contain any of ";!^=", values must be non-empty,
+// must not contain ";" and must not start with "~". The reserved name tag
+// may not be set explicitly.
+func ValidateTag(tag Tag) error {
+	if tag.Name == "" || strings.ContainsAny(tag.Name, invalidTagNameChars) {
+		return errors.NewInvalidParamsError(
+			fmt.Errorf("invalid tag name: %q", tag.Name))
+	}
+	if tag.Name == TaggedNameTag {
+		return errors.NewInvalidParamsError(
+			fmt.Errorf("tag name %q is reserved", TaggedNameTag))
+	}
+	if tag.Value == "" || strings.Contains(tag.Value, tagSeparator) ||
+		strings.HasPrefix(tag.Value, "~") {
+		return errors.NewInvalidParamsError(
+			fmt.Errorf("invalid value for tag %s: %q", tag.Name, tag.Value))
+	}
+	return nil
+}
+
+// SeriesTags returns the tags of a series by name, including the reserved
+// name tag. Series that are not tagged, including the output of functions
+// that rename series, only have the name tag set to their full name.
+func SeriesTags(name string) map[string]string {
+	if IsTaggedName(name) {
+		if path, tags, err := ParseTaggedName(name); err == nil {
+			result := make(map[string]string, len(tags)+1)
+			result[TaggedNameTag] = path
+			for _, tag := range tags {
+				result[tag.Name] = tag.Value
+			}
+			return result
+		}
+	}
+
+	return map[string]string{TaggedNameTag: name}
+}
+
+// TaggedName formats a path and set of tags as a canonical Graphite tagged
+// series name, with tags sorted by name.
+func TaggedName(path string, tags map[string]string) string {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		if name == TaggedNameTag {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(path)
+	for _, name := range names {
+		b.WriteString(tagSeparator)
+		b.WriteString(name)
+		b.WriteString(tagValueSeparator)
+		b.WriteString(tags[name])
+	}
+	return b.String()
+}
+
+// TagOperator is the comparison operator of a tag expression.
+type TagOperator int
+
+const (
+	// TagOperatorEqual matches tags equal to the value; an empty value
+	// matches series that do not have the tag.
+	TagOperatorEqual TagOperator = iota
+	// TagOperatorNotEqual matches tags not equal to the value; an empty
+	// value matches series that have the tag.
+	TagOperatorNotEqual
+	// TagOperatorMatch matches tags whose value matches the regular
+	// expression, anchored at the start of the value.
+	TagOperatorMatch
+	// TagOperatorNotMatch matches tags whose value does not match the
+	// regular expression, anchored at the start of the value.
+	TagOperatorNotMatch
+)
+
+// TagExpression is a single seriesByTag expression such as "dc=east" or
+// "name=~foo\..*".
+type TagExpression struct {
+	Tag      string
+	Operator TagOperator
+	Value    string
+}
+
+// ParseTagExpression parses a seriesByTag expression of the form
+// tag=spec, tag!=spec, tag=~regex or tag!=~regex.
+func ParseTagExpression(expr string) (TagExpression, error) {
+	idx := strings.Index(expr, tagValueSeparator)
+	if idx <= 0 {
+		return TagExpression{}, errors.NewInvalidParamsError(
+			fmt.Errorf("invalid tag expression: %q", expr))
+	}
+
+	var (
+		tag   = expr[:idx]
+		value = expr[idx+1:]
+		op    = TagOperatorEqual
+	)
+	if strings.HasSuffix(tag, "!") {
+		tag = tag[:len(tag)-1]
+		op = TagOperatorNotEqual
+	}
+	if strings.HasPrefix(value, "~") {
+		value = value[1:]
+		if op == TagOperatorEqual {
+			op = TagOperatorMatch
+		} else {
+			op = TagOperatorNotMatch
+		}
+	}
+
+	if tag == "" || strings.ContainsAny(tag, invalidTagNameChars) {
+		return TagExpression{}, errors.NewInvalidParamsError(
+			fmt.Errorf("invalid tag expression: %q", expr))
+	}
+
+	return TagExpression{Tag: tag, Operator: op, Value: value}, nil
+}
+
+// IsPositive returns true if the expression can only match series that
+// have the tag set, which Graphite requires of at least one expression in
+// every seriesByTag call.
+func (e TagExpression) IsPositive() bool {
+	switch e.Operator {
+	case TagOperatorEqual, TagOperatorMatch:
+		return e.Value != ""
+	}
+	return false
+}
+
+const seriesByTagPrefix = "seriesByTag("
+
+// SeriesByTagQuery formats tag expressions as a seriesByTag query, which is
+// used both as the path expression of the fetched series and as the query
+// passed to storage.
+func SeriesByTagQuery(exprs []string) string {
+	var b strings.Builder
+	b.WriteString(seriesByTagPrefix)
+	for i, expr := range exprs {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		quote := byte('\'')
+		if strings.IndexByte(expr, quote) >= 0 {
+			quote = '"'
+		}
+		b.WriteByte(quote)
+		b.WriteString(expr)
+		b.WriteByte(quote)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// ParseSeriesByTagQuery parses a query generated by SeriesByTagQuery back
+// into its tag expressions, returning false if the query is not a
+// seriesByTag query.
+func ParseSeriesByTagQuery(query string) ([]string, bool) {
+	if !strings.HasPrefix(query, seriesByTagPrefix) ||
+		!strings.HasSuffix(query, ")") {
+		return nil, false
+	}
+
+	var (
+		args  = query[len(seriesByTagPrefix) : len(query)-1]
+		exprs []string
+	)
+	for len(args) > 0 {
+		quote := args[0]
+		if quote != '\'' && quote != '"' {
+			return nil, false
+		}
+
+		end := strings.IndexByte(args[1:], quote)
+		if end < 0 {
+			return nil, false
+		}
+
+		exprs = append(exprs, args[1:end+1])
+		args = args[end+2:]
+		if len(args) > 0 {
+			if args[0] != ',' {
+				return nil, false
+			}
+			args = args[1:]
+		}
+	}
+
+	return exprs, len(exprs) > 0
+}
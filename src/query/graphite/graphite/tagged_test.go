@@ -0,0 +1,101 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTaggedName(t *testing.T) {
+	path, tags, err := ParseTaggedName("foo.bar;host=a;dc=east")
+	require.NoError(t, err)
+	assert.Equal(t, "foo.bar", path)
+	assert.Equal(t, []Tag{{Name: "dc", Value: "east"}, {Name: "host", Value: "a"}}, tags)
+
+	for _, invalid := range []string{
+		";dc=east",
+		"foo;dc",
+		"foo;dc=",
+		"foo;dc=~east",
+		"foo;d!c=east",
+		"foo;name=bar",
+		"foo;dc=east;dc=west",
+	} {
+		_, _, err := ParseTaggedName(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestTaggedNameRoundTrip(t *testing.T) {
+	name := TaggedName("foo.bar", map[string]string{
+		"host":        "a",
+		"dc":          "east",
+		TaggedNameTag: "ignored",
+	})
+	assert.Equal(t, "foo.bar;dc=east;host=a", name)
+	assert.Equal(t, map[string]string{
+		TaggedNameTag: "foo.bar",
+		"dc":          "east",
+		"host":        "a",
+	}, SeriesTags(name))
+	assert.Equal(t, map[string]string{TaggedNameTag: "foo.bar"}, SeriesTags("foo.bar"))
+}
+
+func TestParseTagExpression(t *testing.T) {
+	for _, test := range []struct {
+		expr     string
+		expected TagExpression
+		positive bool
+	}{
+		{"dc=east", TagExpression{"dc", TagOperatorEqual, "east"}, true},
+		{"dc=", TagExpression{"dc", TagOperatorEqual, ""}, false},
+		{"dc!=east", TagExpression{"dc", TagOperatorNotEqual, "east"}, false},
+		{"name=~foo.*", TagExpression{"name", TagOperatorMatch, "foo.*"}, true},
+		{"name!=~foo.*", TagExpression{"name", TagOperatorNotMatch, "foo.*"}, false},
+		{"dc==east", TagExpression{"dc", TagOperatorEqual, "=east"}, true},
+	} {
+		actual, err := ParseTagExpression(test.expr)
+		require.NoError(t, err, test.expr)
+		assert.Equal(t, test.expected, actual, test.expr)
+		assert.Equal(t, test.positive, actual.IsPositive(), test.expr)
+	}
+
+	for _, invalid := range []string{"dc", "=east", "!=east", "d;c=east"} {
+		_, err := ParseTagExpression(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestSeriesByTagQueryRoundTrip(t *testing.T) {
+	exprs := []string{"name=~foo.{1,2}", "dc!='east'"}
+	query := SeriesByTagQuery(exprs)
+	assert.Equal(t, `seriesByTag('name=~foo.{1,2}',"dc!='east'")`, query)
+
+	parsed, ok := ParseSeriesByTagQuery(query)
+	require.True(t, ok)
+	assert.Equal(t, exprs, parsed)
+
+	_, ok = ParseSeriesByTagQuery("foo.bar.*")
+	assert.False(t, ok)
+}
@@ -276,7 +276,18 @@ func divideSeriesLists(ctx *common.Context, dividendSeriesList, divisorSeriesLis
 // This function can be used with aggregation functionsL average (or avg), avg_zero,
 // median, sum (or total), min, max, diff, stddev, count,
 // range (or rangeOf), multiply & last (or current).
-func aggregate(ctx *common.Context, series singlePathSpec, fname string) (ts.SeriesList, error) {
+// Datapoints where the ratio of non-null values across the series is below
+// xFilesFactor are null.
+func aggregate(ctx *common.Context, series singlePathSpec, fname string, xFilesFactor float64) (ts.SeriesList, error) {
+	result, err := aggregateWithFunc(ctx, series, fname)
+	if err != nil {
+		return ts.NewSeriesList(), err
+	}
+
+	return applyXFilesFactor(ctx, ts.SeriesList(series), result, xFilesFactor)
+}
+
+func aggregateWithFunc(ctx *common.Context, series singlePathSpec, fname string) (ts.SeriesList, error) {
 	switch fname {
 	case emptyFnName, sumFnName, sumSeriesFnName, totalFnName:
 		return sumSeries(ctx, multiplePathSpecs(series))
@@ -307,6 +318,125 @@ func aggregate(ctx *common.Context, series singlePathSpec, fname string) (ts.Ser
 	}
 }
 
+// applyXFilesFactor nulls the datapoints of an aggregated series where the
+// ratio of non-null values across the normalized inputs is below xFilesFactor.
+func applyXFilesFactor(
+	ctx *common.Context,
+	inputs ts.SeriesList,
+	aggregated ts.SeriesList,
+	xFilesFactor float64,
+) (ts.SeriesList, error) {
+	if xFilesFactor <= 0 || len(inputs.Values) == 0 || len(aggregated.Values) != 1 {
+		return aggregated, nil
+	}
+
+	normalized, _, _, _, err := common.Normalize(ctx, inputs)
+	if err != nil {
+		return ts.NewSeriesList(), err
+	}
+
+	series := aggregated.Values[0]
+	vals := ts.NewValues(ctx, series.MillisPerStep(), series.Len())
+	for i := 0; i < series.Len(); i++ {
+		nans := 0
+		for _, in := range normalized.Values {
+			if i >= in.Len() || math.IsNaN(in.ValueAt(i)) {
+				nans++
+			}
+		}
+
+		total := len(normalized.Values)
+		if nans < total && effectiveXFF(total, nans, xFilesFactor) {
+			vals.SetValueAt(i, series.ValueAt(i))
+		}
+	}
+
+	aggregated.Values = []*ts.Series{series.DerivedSeries(series.StartTime(), vals)}
+	return aggregated, nil
+}
+
+// aggregateSeriesLists iterates over two lists of series and aggregates each
+// pair of series at the same position with the given function, returning a
+// single list of the aggregated series.
+//
+//    &target=aggregateSeriesLists(mining.{carbon,graphite,diamond}.extracted,mining.{carbon,graphite,diamond}.shipped,'sum')
+//
+// Datapoints where the ratio of non-null values of a pair is below
+// xFilesFactor are null.
+func aggregateSeriesLists(
+	ctx *common.Context,
+	seriesListFirstPos singlePathSpec,
+	seriesListSecondPos singlePathSpec,
+	fname string,
+	xFilesFactor float64,
+) (ts.SeriesList, error) {
+	if len(seriesListFirstPos.Values) != len(seriesListSecondPos.Values) {
+		return ts.NewSeriesList(), errors.NewInvalidParamsError(fmt.Errorf(
+			"series lists must have the same length, first=%d, second=%d",
+			len(seriesListFirstPos.Values), len(seriesListSecondPos.Values)))
+	}
+
+	results := make([]*ts.Series, 0, len(seriesListFirstPos.Values))
+	for i, first := range seriesListFirstPos.Values {
+		second := seriesListSecondPos.Values[i]
+		pair := singlePathSpec{
+			Values:   []*ts.Series{first, second},
+			Metadata: seriesListFirstPos.Metadata,
+		}
+		aggregated, err := aggregate(ctx, pair, fname, xFilesFactor)
+		if err != nil {
+			return ts.NewSeriesList(), err
+		}
+
+		name := fmt.Sprintf("%sSeries(%s,%s)", fname, first.Name(), second.Name())
+		for _, series := range aggregated.Values {
+			results = append(results, series.RenamedTo(name))
+		}
+	}
+
+	r := ts.SeriesList(seriesListFirstPos)
+	r.Metadata = r.Metadata.CombineMetadata(seriesListSecondPos.Metadata)
+	r.Values = results
+	return r, nil
+}
+
+// powSeries takes two or more series and pows their points. A constant line
+// may be used.
+//
+//    &target=powSeries(Server.instance01.app.requests, Server.instance01.app.replies)
+func powSeries(ctx *common.Context, series multiplePathSpecs) (ts.SeriesList, error) {
+	if len(series.Values) == 0 {
+		return ts.NewSeriesList(), nil
+	}
+
+	normalized, start, end, millisPerStep, err := common.Normalize(ctx, ts.SeriesList(series))
+	if err != nil {
+		return ts.NewSeriesList(), err
+	}
+
+	names := make([]string, 0, len(normalized.Values))
+	for _, s := range series.Values {
+		names = append(names, s.Name())
+	}
+
+	numSteps := ts.NumSteps(start, end, millisPerStep)
+	values := ts.NewValues(ctx, millisPerStep, numSteps)
+	for i := 0; i < numSteps; i++ {
+		value := normalized.Values[0].ValueAt(i)
+		for _, s := range normalized.Values[1:] {
+			value = safePow(value, s.ValueAt(i))
+		}
+		values.SetValueAt(i, value)
+	}
+
+	name := fmt.Sprintf("powSeries(%s)", strings.Join(names, ","))
+	output := ts.NewSeries(ctx, name, start, values)
+	return ts.SeriesList{
+		Values:   []*ts.Series{output},
+		Metadata: series.Metadata,
+	}, nil
+}
+
 // averageSeriesWithWildcards splits the given set of series into sub-groupings
 // based on wildcard matches in the hierarchy, then averages the values in each
 // grouping
@@ -632,7 +762,7 @@ func groupByNodes(ctx *common.Context, seriesList singlePathSpec, fname string,
 	if len(metaSeries) == 0 {
 		// if nodes is an empty slice or every node in nodes exceeds the number
 		// of parts in each series, just treat it like aggregate
-		return aggregate(ctx, seriesList, fname)
+		return aggregate(ctx, seriesList, fname, 0)
 	}
 
 	return applyFnToMetaSeries(ctx, seriesList, metaSeries, fname)
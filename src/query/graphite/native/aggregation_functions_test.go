@@ -168,11 +168,11 @@ func TestStdDevSeries(t *testing.T) {
 
 func TestAggregate(t *testing.T) {
 	testAggregatedSeries(t, func(ctx *common.Context, series multiplePathSpecs) (ts.SeriesList, error) {
-		return aggregate(ctx, singlePathSpec(series), "sum")
+		return aggregate(ctx, singlePathSpec(series), "sum", 0)
 	}, 15.0, 28.0, 30.0, 17.0, "invalid sum value for step %d")
 
 	testAggregatedSeries(t, func(ctx *common.Context, series multiplePathSpecs) (ts.SeriesList, error) {
-		return aggregate(ctx, singlePathSpec(series), "maxSeries")
+		return aggregate(ctx, singlePathSpec(series), "maxSeries", 0)
 	}, 15.0, 15.0, 17.0, 17.0, "invalid max value for step %d")
 }
 
@@ -203,7 +203,7 @@ func TestAggregateSeriesMedian(t *testing.T) {
 	}
 	result, err := aggregate(ctx, singlePathSpec{
 		Values: inputs,
-	}, "median")
+	}, "median", 0)
 	require.NoError(t, err)
 	common.CompareOutputsAndExpected(t, 60000, start, expectedResults, result.Values)
 }
@@ -852,3 +852,16 @@ func TestCountSeries(t *testing.T) {
 	common.CompareOutputsAndExpected(t, input[1].MillisPerStep(), input[1].StartTime(),
 		[]common.TestSeries{expected}, results.Values)
 }
+
+func TestAggregateSeriesListsLengthMismatch(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer ctx.Close()
+
+	series := ts.NewSeries(ctx, "foo", ctx.StartTime,
+		common.NewTestSeriesValues(ctx, 60000, []float64{1, 2, 3}))
+	_, err := aggregateSeriesLists(ctx,
+		singlePathSpec{Values: []*ts.Series{series}},
+		singlePathSpec{Values: []*ts.Series{series, series}},
+		"sum", 0)
+	require.Error(t, err)
+}
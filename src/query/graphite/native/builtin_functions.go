@@ -28,6 +28,7 @@ import (
 	"regexp"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -332,6 +333,56 @@ func timeShift(
 	}, nil
 }
 
+// timeStack takes one metric or a wildcard seriesList, followed by a quoted
+// string with the length of time (see timeShift for details on the format),
+// followed by a start multiplier and end multiplier. Draws the selected
+// metrics shifted in time by each multiple of the time unit in the range
+// [timeShiftStart, timeShiftEnd), stacked on the current time range.
+func timeStack(
+	ctx *common.Context,
+	input singlePathSpec,
+	timeShiftUnit string,
+	timeShiftStart int,
+	timeShiftEnd int,
+) (ts.SeriesList, error) {
+	if len(input.Values) == 0 {
+		return ts.SeriesList(input), nil
+	}
+
+	if !(strings.HasPrefix(timeShiftUnit, "+") || strings.HasPrefix(timeShiftUnit, "-")) {
+		timeShiftUnit = "-" + timeShiftUnit
+	}
+
+	delta, err := common.ParseInterval(timeShiftUnit)
+	if err != nil {
+		return ts.NewSeriesList(), errors.NewInvalidParamsError(fmt.Errorf(
+			"invalid timeStack parameter %s: %v", timeShiftUnit, err))
+	}
+
+	var (
+		target = input.Values[0].Specification
+		r      = ts.SeriesList(input)
+	)
+	r.Values = nil
+	for shift := timeShiftStart; shift < timeShiftEnd; shift++ {
+		innerDelta := delta * time.Duration(shift)
+		opts := common.NewChildContextOptions()
+		opts.AdjustTimeRange(innerDelta, innerDelta, 0, 0)
+		shifted, err := evaluateTarget(ctx.NewChildContext(opts), target)
+		if err != nil {
+			return ts.NewSeriesList(), err
+		}
+
+		for _, series := range shifted.Values {
+			name := fmt.Sprintf("timeShift(%s, %s, %d)", series.Name(), timeShiftUnit, shift)
+			r.Values = append(r.Values, series.Shift(-1*innerDelta).RenamedTo(name))
+		}
+		r.Metadata = r.Metadata.CombineMetadata(shifted.Metadata)
+	}
+
+	return r, nil
+}
+
 // delay shifts all samples later by an integer number of steps. This can be used
 // for custom derivative calculations, among other things. Note: this will pad
 // the early end of the data with NaN for every step shifted. delay complements
@@ -465,6 +516,89 @@ func offset(ctx *common.Context, input singlePathSpec, factor float64) (ts.Serie
 	)
 }
 
+// safePow raises a to the power of b, returning NaN if either is NaN rather
+// than an infinite or complex result.
+func safePow(a, b float64) float64 {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.NaN()
+	}
+
+	v := math.Pow(a, b)
+	if math.IsInf(v, 0) {
+		return math.NaN()
+	}
+	return v
+}
+
+// formatFloat formats a float the way graphite names series with float
+// arguments, always including a decimal point.
+func formatFloat(v float64) string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// pow raises each datapoint of a collection of time series to the power of
+// the given factor.
+func pow(ctx *common.Context, input singlePathSpec, factor float64) (ts.SeriesList, error) {
+	return transform(
+		ctx,
+		input,
+		func(fname string) string {
+			return fmt.Sprintf("pow(%s,%s)", fname, formatFloat(factor))
+		},
+		common.MaintainNaNTransformer(func(v float64) float64 {
+			return safePow(v, factor)
+		}),
+	)
+}
+
+// invert takes one metric or a wildcard seriesList, and inverts each
+// datapoint (i.e. 1/x). Datapoints of zero are null.
+func invert(ctx *common.Context, input singlePathSpec) (ts.SeriesList, error) {
+	return transform(ctx,
+		input,
+		func(fname string) string { return fmt.Sprintf(wrappingFmt, "invert", fname) },
+		common.MaintainNaNTransformer(func(v float64) float64 {
+			return safePow(v, -1)
+		}))
+}
+
+// minMax applies min-max normalization to each series, scaling its values to
+// the range [0, 1]. Series with a single distinct value are scaled to 0.
+func minMax(ctx *common.Context, input singlePathSpec) (ts.SeriesList, error) {
+	output := make([]*ts.Series, 0, len(input.Values))
+	for _, series := range input.Values {
+		var (
+			min      = series.SafeMin()
+			max      = series.SafeMax()
+			numSteps = series.Len()
+			vals     = ts.NewValues(ctx, series.MillisPerStep(), numSteps)
+		)
+		for i := 0; i < numSteps; i++ {
+			v := series.ValueAt(i)
+			if math.IsNaN(v) {
+				continue
+			}
+
+			if max == min {
+				vals.SetValueAt(i, 0)
+			} else {
+				vals.SetValueAt(i, (v-min)/(max-min))
+			}
+		}
+
+		name := fmt.Sprintf(wrappingFmt, "minMax", series.Name())
+		output = append(output, ts.NewSeries(ctx, name, series.StartTime(), vals))
+	}
+
+	r := ts.SeriesList(input)
+	r.Values = output
+	return r, nil
+}
+
 // transform converts values in a timeseries according to the valueTransformer.
 func transform(ctx *common.Context, input singlePathSpec,
 	fname func(inputName string) string, fn common.TransformFunc) (ts.SeriesList, error) {
@@ -1952,6 +2086,60 @@ func removeBelowPercentile(ctx *common.Context, seriesList singlePathSpec, perce
 		common.LessThan)
 }
 
+// removeBetweenPercentile removes series which have no datapoints outside of
+// the nth and (100-n)th percentiles of the datapoints of all series at the
+// same position.
+func removeBetweenPercentile(ctx *common.Context, seriesList singlePathSpec, percentile float64) (ts.SeriesList, error) {
+	if percentile < 0.0 || percentile > 100.0 {
+		return ts.NewSeriesList(), common.ErrInvalidPercentile(percentile)
+	}
+	if percentile < 50 {
+		percentile = 100 - percentile
+	}
+
+	numSteps := 0
+	for _, series := range seriesList.Values {
+		if series.Len() > numSteps {
+			numSteps = series.Len()
+		}
+	}
+
+	var (
+		lows   = make([]float64, numSteps)
+		highs  = make([]float64, numSteps)
+		column = make([]float64, 0, len(seriesList.Values))
+	)
+	for i := 0; i < numSteps; i++ {
+		column = column[:0]
+		for _, series := range seriesList.Values {
+			if i < series.Len() {
+				column = append(column, series.ValueAt(i))
+			}
+		}
+		lows[i] = common.GetPercentile(column, 100-percentile, false)
+		highs[i] = common.GetPercentile(column, percentile, false)
+	}
+
+	filtered := make([]*ts.Series, 0, len(seriesList.Values))
+	for _, series := range seriesList.Values {
+		for i := 0; i < series.Len(); i++ {
+			v := series.ValueAt(i)
+			if math.IsNaN(v) || math.IsNaN(lows[i]) {
+				continue
+			}
+
+			if !(lows[i] < v && v < highs[i]) {
+				filtered = append(filtered, series)
+				break
+			}
+		}
+	}
+
+	r := ts.SeriesList(seriesList)
+	r.Values = filtered
+	return r, nil
+}
+
 // randomWalkFunction returns a random walk starting at 0.
 // Note: step has a unit of seconds.
 func randomWalkFunction(ctx *common.Context, name string, step int) (ts.SeriesList, error) {
@@ -2163,6 +2351,116 @@ func movingMin(ctx *common.Context, input singlePathSpec, windowSize genericInte
 	return newMovingBinaryTransform(ctx, input, windowSize, "movingMin", xFilesFactor, movingMinHelper)
 }
 
+// windowAggregations are the aggregation functions supported by
+// movingWindow, applied to the non-null values of each window.
+var windowAggregations = map[string]func(values []float64) float64{
+	averageFnName: windowAverage,
+	avgFnName:     windowAverage,
+	"avg_zero":    windowAverage,
+	medianFnName: func(values []float64) float64 {
+		sort.Float64s(values)
+		mid := len(values) / 2
+		if len(values)%2 == 0 {
+			return (values[mid-1] + values[mid]) / 2
+		}
+		return values[mid]
+	},
+	sumFnName:   windowSum,
+	totalFnName: windowSum,
+	minFnName: func(values []float64) float64 {
+		min, _ := common.SafeMin(values)
+		return min
+	},
+	maxFnName: func(values []float64) float64 {
+		max, _ := common.SafeMax(values)
+		return max
+	},
+	diffFnName: func(values []float64) float64 {
+		diff := values[0]
+		for _, v := range values[1:] {
+			diff -= v
+		}
+		return diff
+	},
+	stddevFnName: func(values []float64) float64 {
+		avg := windowAverage(values)
+		sum := 0.0
+		for _, v := range values {
+			sum += (v - avg) * (v - avg)
+		}
+		return math.Sqrt(sum / float64(len(values)))
+	},
+	countFnName:   func(values []float64) float64 { return float64(len(values)) },
+	rangeFnName:   windowRange,
+	rangeOfFnName: windowRange,
+	multiplyFnName: func(values []float64) float64 {
+		product := 1.0
+		for _, v := range values {
+			product *= v
+		}
+		return product
+	},
+	lastFnName:    windowLast,
+	currentFnName: windowLast,
+}
+
+func windowSum(values []float64) float64 {
+	sum, _ := common.SafeSum(values)
+	return sum
+}
+
+func windowAverage(values []float64) float64 {
+	return windowSum(values) / float64(len(values))
+}
+
+func windowRange(values []float64) float64 {
+	min, _ := common.SafeMin(values)
+	max, _ := common.SafeMax(values)
+	return max - min
+}
+
+func windowLast(values []float64) float64 {
+	return values[len(values)-1]
+}
+
+// movingWindow graphs a moving aggregation of a metric (or metrics) over a
+// fixed number of past points, or a time interval, using any of the
+// aggregation functions supported by aggregate.
+//
+//    &target=movingWindow(Server.instance01.threads.busy,10,'sum')
+//    &target=movingWindow(Server.instance*.threads.idle,'5min','median',0.5)
+func movingWindow(
+	ctx *common.Context,
+	input singlePathSpec,
+	windowSize genericInterface,
+	fname string,
+	xFilesFactor float64,
+) (*binaryContextShifter, error) {
+	aggregation, ok := windowAggregations[fname]
+	if !ok {
+		return nil, errors.NewInvalidParamsError(fmt.Errorf("invalid func %s", fname))
+	}
+
+	var nonNull []float64
+	impl := func(window []float64, vals ts.MutableValues, windowPoints int, i int, xFilesFactor float64) {
+		nonNull = nonNull[:0]
+		for _, v := range window {
+			if !math.IsNaN(v) {
+				nonNull = append(nonNull, v)
+			}
+		}
+
+		nans := windowPoints - len(nonNull)
+		if nans < windowPoints && effectiveXFF(windowPoints, nans, xFilesFactor) {
+			vals.SetValueAt(i, aggregation(nonNull))
+		}
+	}
+
+	// NB: named after the aggregation as graphite does, i.e. movingSum.
+	name := "moving" + strings.ToUpper(fname[:1]) + strings.ToLower(fname[1:])
+	return newMovingBinaryTransform(ctx, input, windowSize, name, xFilesFactor, impl)
+}
+
 // legendValue takes one metric or a wildcard seriesList and a string in quotes.
 // Appends a value to the metric name in the legend.  Currently one or several of:
 // "last", "avg", "total", "min", "max".
@@ -2356,19 +2654,196 @@ func threshold(ctx *common.Context, value float64, label string, color string) (
 	return ts.NewSeriesListWithSeries(series), nil
 }
 
+// unique takes an arbitrary number of seriesLists and returns unique series,
+// filtered by name.
+func unique(_ *common.Context, seriesLists multiplePathSpecs) (ts.SeriesList, error) {
+	var (
+		seen     = make(map[string]struct{}, len(seriesLists.Values))
+		filtered = make([]*ts.Series, 0, len(seriesLists.Values))
+	)
+	for _, series := range seriesLists.Values {
+		if _, ok := seen[series.Name()]; ok {
+			continue
+		}
+
+		seen[series.Name()] = struct{}{}
+		filtered = append(filtered, series)
+	}
+
+	r := ts.SeriesList(seriesLists)
+	r.Values = filtered
+	return r, nil
+}
+
+// linearRegression graphs the linear regression function by the least
+// squares method. Takes one metric or a wildcard seriesList, followed by an
+// optional quoted string with the time to start and end the source data the
+// regression is computed from, which default to the time range of the query.
+//
+//    &target=linearRegression(Server.instance01.threads.busy, '-1d')
+//    &target=linearRegression(Server.instance*.threads.busy, "00:00 20140101","11:59 20140630")
+func linearRegression(
+	ctx *common.Context,
+	input singlePathSpec,
+	startSourceAt string,
+	endSourceAt string,
+) (ts.SeriesList, error) {
+	var (
+		now                     = time.Now()
+		tzOffsetForAbsoluteTime time.Duration
+		sourceStart             = ctx.StartTime
+		sourceEnd               = ctx.EndTime
+		err                     error
+	)
+	if startSourceAt != "" {
+		sourceStart, err = graphite.ParseTime(startSourceAt, now, tzOffsetForAbsoluteTime)
+		if err != nil {
+			return ts.NewSeriesList(), errors.NewInvalidParamsError(err)
+		}
+	}
+	if endSourceAt != "" {
+		sourceEnd, err = graphite.ParseTime(endSourceAt, now, tzOffsetForAbsoluteTime)
+		if err != nil {
+			return ts.NewSeriesList(), errors.NewInvalidParamsError(err)
+		}
+	}
+
+	sources := make(map[string]*ts.Series, len(input.Values))
+	if sourceStart.Equal(ctx.StartTime) && sourceEnd.Equal(ctx.EndTime) {
+		for _, series := range input.Values {
+			sources[series.Name()] = series
+		}
+	} else {
+		opts := common.NewChildContextOptions()
+		opts.AdjustTimeRange(sourceStart.Sub(ctx.StartTime), sourceEnd.Sub(ctx.EndTime), 0, 0)
+		sourceCtx := ctx.NewChildContext(opts)
+
+		evaluated := make(map[string]struct{}, len(input.Values))
+		for _, series := range input.Values {
+			if _, ok := evaluated[series.Specification]; ok {
+				continue
+			}
+
+			evaluated[series.Specification] = struct{}{}
+			sourceList, err := evaluateTarget(sourceCtx, series.Specification)
+			if err != nil {
+				return ts.NewSeriesList(), err
+			}
+			for _, source := range sourceList.Values {
+				sources[source.Name()] = source
+			}
+		}
+	}
+
+	results := make([]*ts.Series, 0, len(input.Values))
+	for _, series := range input.Values {
+		source, ok := sources[series.Name()]
+		if !ok {
+			continue
+		}
+
+		factor, offset, ok := linearRegressionAnalysis(source)
+		if !ok {
+			continue
+		}
+
+		var (
+			numSteps       = series.Len()
+			vals           = ts.NewValues(ctx, series.MillisPerStep(), numSteps)
+			startSeconds   = float64(series.StartTime().Unix())
+			secondsPerStep = float64(series.MillisPerStep()) / millisPerSecond
+		)
+		for i := 0; i < numSteps; i++ {
+			vals.SetValueAt(i, offset+(startSeconds+float64(i)*secondsPerStep)*factor)
+		}
+
+		name := fmt.Sprintf("linearRegression(%s, %d, %d)",
+			series.Name(), sourceStart.Unix(), sourceEnd.Unix())
+		results = append(results, ts.NewSeries(ctx, name, series.StartTime(), vals))
+	}
+
+	r := ts.SeriesList(input)
+	r.Values = results
+	return r, nil
+}
+
+// linearRegressionAnalysis returns the factor and offset of the least squares
+// linear regression of a series, with time in seconds.
+func linearRegressionAnalysis(series *ts.Series) (float64, float64, bool) {
+	var n, sumI, sumV, sumII, sumIV float64
+	for i := 0; i < series.Len(); i++ {
+		v := series.ValueAt(i)
+		if math.IsNaN(v) {
+			continue
+		}
+
+		idx := float64(i)
+		n++
+		sumI += idx
+		sumV += v
+		sumII += idx * idx
+		sumIV += idx * v
+	}
+
+	denominator := n*sumII - sumI*sumI
+	if denominator == 0 {
+		return 0, 0, false
+	}
+
+	secondsPerStep := float64(series.MillisPerStep()) / millisPerSecond
+	factor := (n*sumIV - sumI*sumV) / denominator / secondsPerStep
+	offset := (sumII*sumV-sumIV*sumI)/denominator - factor*float64(series.StartTime().Unix())
+	return factor, offset, true
+}
+
+// verticalLine draws a vertical line at the designated timestamp with an
+// optional label. The timestamp must be within the time range of the query.
+//
+//    &target=verticalLine("12:3420131108","event","blue")
+func verticalLine(ctx *common.Context, timestamp string, label string, _ string) (ts.SeriesList, error) {
+	var (
+		now                     = time.Now()
+		tzOffsetForAbsoluteTime time.Duration
+	)
+	t, err := graphite.ParseTime(timestamp, now, tzOffsetForAbsoluteTime)
+	if err != nil {
+		return ts.NewSeriesList(), errors.NewInvalidParamsError(err)
+	}
+
+	if t.Before(ctx.StartTime) {
+		return ts.NewSeriesList(), errors.NewInvalidParamsError(fmt.Errorf(
+			"verticalLine timestamp %s exists before start of range", timestamp))
+	}
+	if t.After(ctx.EndTime) {
+		return ts.NewSeriesList(), errors.NewInvalidParamsError(fmt.Errorf(
+			"verticalLine timestamp %s exists after end of range", timestamp))
+	}
+
+	// NB: graphite draws the line as two points at the same timestamp, this
+	// uses the smallest step with second precision instead.
+	vals := ts.NewConstantValues(ctx, 1, 2, millisPerSecond)
+	return ts.NewSeriesListWithSeries(ts.NewSeries(ctx, label, t, vals)), nil
+}
+
 func init() {
 	// functions - in alpha ordering
 	MustRegisterFunction(absolute)
-	MustRegisterFunction(aggregate)
+	MustRegisterFunction(aggregate).WithDefaultParams(map[uint8]interface{}{
+		3: 0.0, // xFilesFactor
+	})
 	MustRegisterFunction(aggregateLine).WithDefaultParams(map[uint8]interface{}{
 		2: "avg", // f
 	})
+	MustRegisterFunction(aggregateSeriesLists).WithDefaultParams(map[uint8]interface{}{
+		4: 0.0, // xFilesFactor
+	})
 	MustRegisterFunction(aggregateWithWildcards).WithDefaultParams(map[uint8]interface{}{
 		3: -1, // positions
 	})
 	MustRegisterFunction(alias)
 	MustRegisterFunction(aliasByMetric)
 	MustRegisterFunction(aliasByNode)
+	MustRegisterFunction(aliasByTags)
 	MustRegisterFunction(aliasSub)
 	MustRegisterFunction(applyByNode).WithDefaultParams(map[uint8]interface{}{
 		4: "", // newName
@@ -2406,6 +2881,7 @@ func init() {
 		3: "average", // fname
 	})
 	MustRegisterFunction(groupByNodes)
+	MustRegisterFunction(groupByTags)
 	MustRegisterFunction(highest).WithDefaultParams(map[uint8]interface{}{
 		2: 1,         // n,
 		3: "average", // f
@@ -2420,6 +2896,7 @@ func init() {
 	MustRegisterFunction(identity)
 	MustRegisterFunction(integral)
 	MustRegisterFunction(integralByInterval)
+	MustRegisterFunction(invert)
 	MustRegisterFunction(interpolate).WithDefaultParams(map[uint8]interface{}{
 		2: -1, // limit
 	})
@@ -2429,6 +2906,10 @@ func init() {
 	})
 	MustRegisterFunction(legendValue)
 	MustRegisterFunction(limit)
+	MustRegisterFunction(linearRegression).WithDefaultParams(map[uint8]interface{}{
+		2: "", // startSourceAt
+		3: "", // endSourceAt
+	})
 	MustRegisterFunction(logarithm).WithDefaultParams(map[uint8]interface{}{
 		2: 10, // base
 	})
@@ -2440,6 +2921,7 @@ func init() {
 	MustRegisterFunction(lowestCurrent)
 	MustRegisterFunction(maxSeries)
 	MustRegisterFunction(maximumAbove)
+	MustRegisterFunction(minMax)
 	MustRegisterFunction(minSeries)
 	MustRegisterFunction(minimumAbove)
 	MustRegisterFunction(mostDeviant)
@@ -2458,6 +2940,10 @@ func init() {
 	MustRegisterFunction(movingMin).WithDefaultParams(map[uint8]interface{}{
 		3: 0.0, // XFilesFactor
 	})
+	MustRegisterFunction(movingWindow).WithDefaultParams(map[uint8]interface{}{
+		3: "average", // fname
+		4: 0.0,       // XFilesFactor
+	})
 	MustRegisterFunction(multiplySeries)
 	MustRegisterFunction(nonNegativeDerivative).WithDefaultParams(map[uint8]interface{}{
 		2: math.NaN(), // maxValue
@@ -2468,6 +2954,8 @@ func init() {
 	MustRegisterFunction(percentileOfSeries).WithDefaultParams(map[uint8]interface{}{
 		3: false, // interpolate
 	})
+	MustRegisterFunction(pow)
+	MustRegisterFunction(powSeries)
 	MustRegisterFunction(perSecond).WithDefaultParams(map[uint8]interface{}{
 		2: math.NaN(), // maxValue
 	})
@@ -2479,9 +2967,11 @@ func init() {
 	MustRegisterFunction(removeAboveValue)
 	MustRegisterFunction(removeBelowPercentile)
 	MustRegisterFunction(removeBelowValue)
+	MustRegisterFunction(removeBetweenPercentile)
 	MustRegisterFunction(removeEmptySeries)
 	MustRegisterFunction(scale)
 	MustRegisterFunction(scaleToSeconds)
+	MustRegisterFunction(seriesByTag)
 	MustRegisterFunction(sortByMaxima)
 	MustRegisterFunction(sortByMinima)
 	MustRegisterFunction(sortByName)
@@ -2519,18 +3009,27 @@ func init() {
 		3: true,  // resetEnd
 		4: false, // alignDst
 	})
+	MustRegisterFunction(timeStack).WithDefaultParams(map[uint8]interface{}{
+		2: "1d", // timeShiftUnit
+		3: 0,    // timeShiftStart
+		4: 7,    // timeShiftEnd
+	})
 	MustRegisterFunction(timeSlice).WithDefaultParams(map[uint8]interface{}{
 		3: "now", // endTime
 	})
 	MustRegisterFunction(transformNull).WithDefaultParams(map[uint8]interface{}{
 		2: 0.0, // defaultValue
 	})
+	MustRegisterFunction(unique)
 	MustRegisterFunction(useSeriesAbove)
+	MustRegisterFunction(verticalLine).WithDefaultParams(map[uint8]interface{}{
+		2: "", // label
+		3: "", // color
+	})
 	MustRegisterFunction(weightedAverage)
 
 	// alias functions - in alpha ordering
 	MustRegisterAliasedFunction("abs", absolute)
-	MustRegisterAliasedFunction("aliasByTags", aliasByNode)
 	MustRegisterAliasedFunction("avg", averageSeries)
 	MustRegisterAliasedFunction("log", logarithm)
 	MustRegisterAliasedFunction("max", maxSeries)
@@ -3466,12 +3466,51 @@ func TestThreshold(t *testing.T) {
 	require.Equal(t, "1.000", results[0].Name())
 }
 
+func TestSafePow(t *testing.T) {
+	assert.Equal(t, 8.0, safePow(2, 3))
+	assert.Equal(t, 0.5, safePow(2, -1))
+	assert.True(t, math.IsNaN(safePow(0, -1)))
+	assert.True(t, math.IsNaN(safePow(-8, 0.5)))
+	assert.True(t, math.IsNaN(safePow(math.NaN(), 0)))
+	assert.True(t, math.IsNaN(safePow(1, math.NaN())))
+}
+
+func TestRemoveBetweenPercentileErrors(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer ctx.Close()
+
+	for _, percentile := range []float64{-1, 101} {
+		_, err := removeBetweenPercentile(ctx, singlePathSpec{}, percentile)
+		require.Error(t, err)
+	}
+}
+
+func TestMovingWindowError(t *testing.T) {
+	testMovingFunctionError(t, "movingWindow(foo.bar.baz, '30s', 'foo')")
+	testMovingFunctionError(t, "movingWindow(foo.bar.baz, '-30s')")
+	testMovingFunctionError(t, "movingWindow(foo.bar.baz, 0)")
+}
+
+func TestVerticalLineOutOfRange(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer ctx.Close()
+
+	before := fmt.Sprint(ctx.StartTime.Add(-time.Minute).Unix())
+	_, err := verticalLine(ctx, before, "", "")
+	require.Error(t, err)
+
+	after := fmt.Sprint(ctx.EndTime.Add(time.Minute).Unix())
+	_, err = verticalLine(ctx, after, "", "")
+	require.Error(t, err)
+}
+
 func TestFunctionsRegistered(t *testing.T) {
 	fnames := []string{
 		"abs",
 		"absolute",
 		"aggregate",
 		"aggregateLine",
+		"aggregateSeriesLists",
 		"alias",
 		"aliasByMetric",
 		"aliasByNode",
@@ -3503,6 +3542,7 @@ func TestFunctionsRegistered(t *testing.T) {
 		"group",
 		"groupByNode",
 		"groupByNodes",
+		"groupByTags",
 		"highest",
 		"highestAverage",
 		"highestCurrent",
@@ -3515,10 +3555,12 @@ func TestFunctionsRegistered(t *testing.T) {
 		"integral",
 		"integralByInterval",
 		"interpolate",
+		"invert",
 		"isNonNull",
 		"keepLastValue",
 		"legendValue",
 		"limit",
+		"linearRegression",
 		"log",
 		"logarithm",
 		"lowest",
@@ -3528,6 +3570,7 @@ func TestFunctionsRegistered(t *testing.T) {
 		"maxSeries",
 		"maximumAbove",
 		"min",
+		"minMax",
 		"minSeries",
 		"minimumAbove",
 		"mostDeviant",
@@ -3536,12 +3579,15 @@ func TestFunctionsRegistered(t *testing.T) {
 		"movingSum",
 		"movingMax",
 		"movingMin",
+		"movingWindow",
 		"multiplySeries",
 		"nonNegativeDerivative",
 		"nPercentile",
 		"offset",
 		"offsetToZero",
 		"perSecond",
+		"pow",
+		"powSeries",
 		"randomWalk",
 		"randomWalkFunction",
 		"rangeOfSeries",
@@ -3549,9 +3595,11 @@ func TestFunctionsRegistered(t *testing.T) {
 		"removeAboveValue",
 		"removeBelowPercentile",
 		"removeBelowValue",
+		"removeBetweenPercentile",
 		"removeEmptySeries",
 		"scale",
 		"scaleToSeconds",
+		"seriesByTag",
 		"smartSummarize",
 		"sortByMaxima",
 		"sortByMinima",
@@ -3569,8 +3617,11 @@ func TestFunctionsRegistered(t *testing.T) {
 		"timeFunction",
 		"timeShift",
 		"timeSlice",
+		"timeStack",
 		"transformNull",
+		"unique",
 		"useSeriesAbove",
+		"verticalLine",
 		"weightedAverage",
 	}
 
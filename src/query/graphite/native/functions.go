@@ -196,6 +196,7 @@ var (
 	singlePathSpecType          = reflect.TypeOf(singlePathSpec{})
 	multiplePathSpecsType       = reflect.TypeOf(multiplePathSpecs{})
 	interfaceType               = reflect.TypeOf([]genericInterface{}).Elem()
+	interfaceSliceType          = reflect.SliceOf(interfaceType)
 	float64Type                 = reflect.TypeOf(float64(100))
 	float64SliceType            = reflect.SliceOf(float64Type)
 	intType                     = reflect.TypeOf(int(0))
@@ -217,7 +218,8 @@ var (
 		seriesListType,
 		singlePathSpecType,
 		multiplePathSpecsType,
-		interfaceType, // only for function parameters
+		interfaceType,      // only for function parameters
+		interfaceSliceType, // only for function parameters
 		float64Type,
 		float64SliceType,
 		intType,
@@ -0,0 +1,164 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/graphite/common"
+	xctx "github.com/m3db/m3/src/query/graphite/context"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/graphite/storage"
+	xtest "github.com/m3db/m3/src/query/graphite/testing"
+	"github.com/m3db/m3/src/query/graphite/ts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// graphiteWebGolden is a set of targets evaluated over fixed input series,
+// with the expected output in the graphite-web render JSON format.
+type graphiteWebGolden struct {
+	From   int64                   `json:"from"`
+	Until  int64                   `json:"until"`
+	Step   int64                   `json:"step"`
+	Series []graphiteWebInput      `json:"series"`
+	Cases  []graphiteWebGoldenCase `json:"cases"`
+}
+
+type graphiteWebInput struct {
+	Name   string     `json:"name"`
+	Start  int64      `json:"start"`
+	Values []*float64 `json:"values"`
+}
+
+type graphiteWebGoldenCase struct {
+	Target   string              `json:"target"`
+	Expected []graphiteWebOutput `json:"expected"`
+}
+
+type graphiteWebOutput struct {
+	Target     string        `json:"target"`
+	Datapoints [][2]*float64 `json:"datapoints"`
+}
+
+// graphiteWebStorage serves the golden input series for any aligned time
+// range, so that functions fetching shifted or expanded ranges see the same
+// data graphite-web would.
+type graphiteWebStorage struct {
+	step   int64
+	series []graphiteWebInput
+}
+
+func (s *graphiteWebStorage) FetchByQuery(
+	ctx xctx.Context,
+	query string,
+	opts storage.FetchOptions,
+) (*storage.FetchResult, error) {
+	pattern, _, err := graphite.GlobToRegexPattern(query)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile("^" + string(pattern) + "$")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		start         = opts.StartTime.Unix()
+		end           = opts.EndTime.Unix()
+		millisPerStep = int(s.step * millisPerSecond)
+		seriesList    []*ts.Series
+	)
+	for _, input := range s.series {
+		if !re.MatchString(input.Name) {
+			continue
+		}
+
+		numSteps := int((end - start) / s.step)
+		vals := ts.NewValues(ctx, millisPerStep, numSteps)
+		for i := 0; i < numSteps; i++ {
+			idx := (start + int64(i)*s.step - input.Start) / s.step
+			if idx >= 0 && idx < int64(len(input.Values)) && input.Values[idx] != nil {
+				vals.SetValueAt(i, *input.Values[idx])
+			}
+		}
+		seriesList = append(seriesList, ts.NewSeries(ctx, input.Name, opts.StartTime, vals))
+	}
+
+	return storage.NewFetchResult(ctx, seriesList, block.NewResultMetadata()), nil
+}
+
+// TestGraphiteWebFunctions checks function output against the output of
+// graphite-web 1.1 for the same targets and input series, see
+// testdata/README.md for how the expected output is generated.
+func TestGraphiteWebFunctions(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/graphite_web_functions.json")
+	require.NoError(t, err)
+
+	var golden graphiteWebGolden
+	require.NoError(t, json.Unmarshal(data, &golden))
+
+	engine := NewEngine(&graphiteWebStorage{
+		step:   golden.Step,
+		series: golden.Series,
+	})
+	for _, test := range golden.Cases {
+		t.Run(test.Target, func(t *testing.T) {
+			ctx := common.NewContext(common.ContextOptions{
+				Start:  time.Unix(golden.From, 0),
+				End:    time.Unix(golden.Until, 0),
+				Engine: engine,
+			})
+			defer ctx.Close()
+
+			expr, err := engine.Compile(test.Target)
+			require.NoError(t, err)
+			res, err := expr.Execute(ctx)
+			require.NoError(t, err)
+
+			require.Equal(t, len(test.Expected), res.Len())
+			for i, expected := range test.Expected {
+				actual := res.Values[i]
+				require.Equal(t, expected.Target, actual.Name())
+				require.Equal(t, len(expected.Datapoints), actual.Len(), expected.Target)
+				for j, dp := range expected.Datapoints {
+					step := time.Duration(j*actual.MillisPerStep()) * time.Millisecond
+					assert.Equal(t, int64(*dp[1]), actual.StartTime().Add(step).Unix(),
+						"%s: invalid timestamp for %d", expected.Target, j)
+
+					value := math.NaN()
+					if dp[0] != nil {
+						value = *dp[0]
+					}
+					xtest.InDeltaWithNaNs(t, value, actual.ValueAt(j), 0.0001,
+						"%s: invalid value for %d", expected.Target, j)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/graphite/common"
+	"github.com/m3db/m3/src/query/graphite/errors"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/graphite/storage"
+	"github.com/m3db/m3/src/query/graphite/ts"
+)
+
+// seriesByTag returns the tagged series matching all of the given tag
+// expressions, each of which is one of tag=value, tag!=value, tag=~regex or
+// tag!=~regex. At least one expression must match a non-empty value.
+//
+//	&target=seriesByTag('name=disk.used', 'dc=~us-.*', 'host!=web01')
+func seriesByTag(ctx *common.Context, tagExpressions ...string) (ts.SeriesList, error) {
+	if len(tagExpressions) == 0 {
+		return ts.NewSeriesList(), errors.NewInvalidParamsError(
+			errors.New("seriesByTag requires at least one tag expression"))
+	}
+
+	positive := false
+	for _, str := range tagExpressions {
+		expr, err := graphite.ParseTagExpression(str)
+		if err != nil {
+			return ts.NewSeriesList(), err
+		}
+		positive = positive || expr.IsPositive()
+	}
+	if !positive {
+		return ts.NewSeriesList(), errors.NewInvalidParamsError(fmt.Errorf(
+			"seriesByTag requires at least one positive tag expression: %v",
+			tagExpressions))
+	}
+
+	var (
+		begin = time.Now()
+		query = graphite.SeriesByTagQuery(tagExpressions)
+		opts  = storage.FetchOptions{
+			StartTime: ctx.StartTime,
+			EndTime:   ctx.EndTime,
+			DataOptions: storage.DataOptions{
+				Timeout: ctx.Timeout,
+				Limit:   ctx.Limit,
+			},
+			Source: ctx.Source,
+		}
+	)
+	result, err := ctx.Engine.FetchByQuery(ctx, query, opts)
+	if err != nil {
+		return ts.NewSeriesList(), err
+	}
+
+	if ctx.TracingEnabled() {
+		ctx.Trace(common.Trace{
+			ActivityName: fmt.Sprintf("fetch %s", query),
+			Duration:     time.Since(begin),
+			Outputs:      common.TraceStats{NumSeries: len(result.SeriesList)},
+		})
+	}
+
+	for _, r := range result.SeriesList {
+		r.Specification = query
+	}
+
+	return ts.SeriesList{
+		Values:   result.SeriesList,
+		Metadata: result.Metadata,
+	}, nil
+}
+
+// seriesTags returns the graphite tags of a series, taken from the innermost
+// metric expression of its name, ignoring any further function arguments.
+func seriesTags(series *ts.Series) map[string]string {
+	name := series.Name()
+	if metricExpr, ok := findFirstMetricExpression(name); ok {
+		name = metricExpr
+		if idx := strings.IndexByte(name, ','); idx >= 0 {
+			name = name[:idx]
+		}
+	}
+	return graphite.SeriesTags(name)
+}
+
+// groupByTags takes a serieslist and maps an aggregation function to
+// subgroups of series which share the same values for the given tags.
+//
+//	&target=groupByTags(seriesByTag('name=cpu', 'dc=~us-.*'), 'sum', 'dc')
+//
+// Would return one series per dc, named like sum;dc=us-east. If name is one
+// of the tags the series are grouped by their name tag instead of being
+// named after the aggregation function.
+func groupByTags(ctx *common.Context, seriesList singlePathSpec, fname string, tags ...string) (ts.SeriesList, error) {
+	if len(tags) == 0 {
+		return ts.NewSeriesList(), errors.NewInvalidParamsError(
+			errors.New("groupByTags requires at least one tag"))
+	}
+
+	metaSeries := make(map[string][]*ts.Series)
+	for _, s := range seriesList.Values {
+		var (
+			values    = seriesTags(s)
+			groupTags = make(map[string]string, len(tags))
+			name      = fname
+		)
+		for _, tag := range tags {
+			if tag == graphite.TaggedNameTag {
+				name = values[graphite.TaggedNameTag]
+				continue
+			}
+			groupTags[tag] = values[tag]
+		}
+
+		key := graphite.TaggedName(name, groupTags)
+		metaSeries[key] = append(metaSeries[key], s)
+	}
+
+	return applyFnToMetaSeries(ctx, seriesList, metaSeries, fname)
+}
+
+// aliasByTags renames series using a combination of their tags. Numeric
+// arguments select nodes of the series name, while strings select the value
+// of the given tag.
+//
+//	&target=aliasByTags(seriesByTag('name=disk.used'), 1, 'dc')
+func aliasByTags(ctx *common.Context, seriesList singlePathSpec, nodes ...genericInterface) (ts.SeriesList, error) {
+	renamed := make([]*ts.Series, 0, len(seriesList.Values))
+	for _, s := range seriesList.Values {
+		var (
+			tags  = seriesTags(s)
+			parts = strings.Split(tags[graphite.TaggedNameTag], ".")
+			names = make([]string, 0, len(nodes))
+		)
+		for _, node := range nodes {
+			switch n := node.(type) {
+			case string:
+				if v, ok := tags[n]; ok {
+					names = append(names, v)
+				}
+			case float64:
+				if part, ok := nodePart(parts, int(n)); ok {
+					names = append(names, part)
+				}
+			case int:
+				if part, ok := nodePart(parts, n); ok {
+					names = append(names, part)
+				}
+			default:
+				return ts.NewSeriesList(), errors.NewInvalidParamsError(fmt.Errorf(
+					"aliasByTags expects tag names or node numbers, received %v", node))
+			}
+		}
+		renamed = append(renamed, s.RenamedTo(strings.Join(names, ".")))
+	}
+
+	r := ts.SeriesList(seriesList)
+	r.Values = renamed
+	return r, nil
+}
+
+func nodePart(parts []string, n int) (string, bool) {
+	if n < 0 {
+		n = len(parts) + n
+	}
+	if n < 0 || n >= len(parts) {
+		return "", false
+	}
+	return parts[n], true
+}
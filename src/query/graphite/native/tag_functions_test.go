@@ -0,0 +1,169 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/graphite/common"
+	"github.com/m3db/m3/src/query/graphite/storage"
+	"github.com/m3db/m3/src/query/graphite/ts"
+	xgomock "github.com/m3db/m3/src/x/test"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeriesByTag(t *testing.T) {
+	var (
+		ctrl      = xgomock.NewController(t)
+		store     = storage.NewMockStorage(ctrl)
+		engine    = NewEngine(store)
+		endTime   = time.Now().Truncate(time.Hour)
+		startTime = endTime.Add(-2 * time.Minute)
+		ctx       = common.NewContext(common.ContextOptions{
+			Start:  startTime,
+			End:    endTime,
+			Engine: engine,
+		})
+		query = `seriesByTag('name=disk.used','dc=~us-.*')`
+	)
+	defer ctrl.Finish()
+	defer ctx.Close()
+
+	store.EXPECT().FetchByQuery(gomock.Any(), query, gomock.Any()).Return(
+		&storage.FetchResult{SeriesList: []*ts.Series{
+			ts.NewSeries(ctx, "disk.used;dc=us-east", startTime,
+				common.NewTestSeriesValues(ctx, 60000, []float64{1, 2})),
+		}}, nil)
+
+	expr, err := engine.Compile(`seriesByTag('name=disk.used', 'dc=~us-.*')`)
+	require.NoError(t, err)
+
+	res, err := expr.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Len())
+	assert.Equal(t, "disk.used;dc=us-east", res.Values[0].Name())
+	assert.Equal(t, query, res.Values[0].Specification)
+
+	for _, invalid := range []string{
+		`seriesByTag()`,
+		`seriesByTag('dc!=us-east')`,
+		`seriesByTag('name=')`,
+		`seriesByTag('dc')`,
+	} {
+		expr, err := engine.Compile(invalid)
+		if err != nil {
+			continue
+		}
+		_, err = expr.Execute(ctx)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestGroupByTags(t *testing.T) {
+	var (
+		start, _ = time.Parse(time.RFC1123, "Mon, 27 Jul 2015 19:41:19 GMT")
+		end, _   = time.Parse(time.RFC1123, "Mon, 27 Jul 2015 19:43:19 GMT")
+		ctx      = common.NewContext(common.ContextOptions{Start: start, End: end})
+		inputs   = []*ts.Series{
+			ts.NewSeries(ctx, "cpu;dc=east;host=a", start,
+				ts.NewConstantValues(ctx, 2, 12, 10000)),
+			ts.NewSeries(ctx, "cpu;dc=east;host=b", start,
+				ts.NewConstantValues(ctx, 4, 12, 10000)),
+			ts.NewSeries(ctx, "cpu;dc=west;host=c", start,
+				ts.NewConstantValues(ctx, 6, 12, 10000)),
+			ts.NewSeries(ctx, "mem;dc=west;host=c", start,
+				ts.NewConstantValues(ctx, 8, 12, 10000)),
+		}
+	)
+	defer ctx.Close()
+
+	type result struct {
+		name      string
+		sumOfVals float64
+	}
+
+	tests := []struct {
+		fname           string
+		tags            []string
+		expectedResults []result
+	}{
+		{"sum", []string{"dc"}, []result{
+			{"sum;dc=east", (2 + 4) * 12},
+			{"sum;dc=west", (6 + 8) * 12},
+		}},
+		{"max", []string{"dc", "name"}, []result{
+			{"cpu;dc=east", 4 * 12},
+			{"cpu;dc=west", 6 * 12},
+			{"mem;dc=west", 8 * 12},
+		}},
+		{"average", []string{"env"}, []result{
+			{"average;env=", ((2 + 4 + 6 + 8) / 4) * 12},
+		}},
+	}
+
+	for _, test := range tests {
+		outSeries, err := groupByTags(ctx, singlePathSpec{
+			Values: inputs,
+		}, test.fname, test.tags...)
+		require.NoError(t, err)
+		require.Equal(t, len(test.expectedResults), len(outSeries.Values))
+
+		outSeries, _ = sortByName(ctx, singlePathSpec(outSeries))
+
+		for i, expected := range test.expectedResults {
+			series := outSeries.Values[i]
+			assert.Equal(t, expected.name, series.Name(),
+				"wrong name for %v %s (%d)", test.tags, test.fname, i)
+			assert.Equal(t, expected.sumOfVals, series.SafeSum(),
+				"wrong result for %v %s (%d)", test.tags, test.fname, i)
+		}
+	}
+
+	_, err := groupByTags(ctx, singlePathSpec{Values: inputs}, "sum")
+	require.Error(t, err)
+}
+
+func TestAliasByTags(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer ctx.Close()
+
+	now := time.Now()
+	values := ts.NewConstantValues(ctx, 10.0, 1000, 10)
+	series := []*ts.Series{
+		ts.NewSeries(ctx, "disk.used;dc=east;host=a", now, values),
+		ts.NewSeries(ctx, "scale(disk.free;dc=west;host=b,2)", now, values),
+	}
+
+	results, err := aliasByTags(ctx, singlePathSpec{
+		Values: series,
+	}, float64(1), "host", -2, "missing", float64(10))
+	require.NoError(t, err)
+	require.Equal(t, 2, results.Len())
+	assert.Equal(t, "used.a.disk", results.Values[0].Name())
+	assert.Equal(t, "free.b.disk", results.Values[1].Name())
+
+	_, err = aliasByTags(ctx, singlePathSpec{Values: series}, true)
+	require.Error(t, err)
+}
@@ -0,0 +1,75 @@
+#!/usr/bin/env bash
+
+# Regenerates the expected output of each case in graphite_web_functions.json
+# by rendering its target with graphite-web over the same input series. See
+# README.md for details.
+
+set -xe
+
+DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+GOLDEN=$DIR/graphite_web_functions.json
+GRAPHITE_IMAGE=${GRAPHITE_IMAGE:-graphiteapp/graphite-statsd:1.1.7-11}
+CONTAINER=graphite-web-golden
+HTTP_PORT=${HTTP_PORT:-18080}
+CARBON_PORT=${CARBON_PORT:-12003}
+WORK_DIR=$(mktemp -d)
+
+# Think of this as a defer func() in golang
+function defer {
+  docker rm -f $CONTAINER > /dev/null 2>&1 || true
+  rm -rf $WORK_DIR
+}
+trap defer EXIT
+
+# A single archive at the golden step that still covers the fixed timestamps
+# of the input series, so that graphite-web never serves rolled up values.
+STEP=$(jq .step $GOLDEN)
+cat > $WORK_DIR/storage-schemas.conf <<EOF
+[golden]
+pattern = .*
+retentions = ${STEP}s:20y
+EOF
+
+docker run -d --name $CONTAINER \
+  -p $HTTP_PORT:80 -p $CARBON_PORT:2003 \
+  -v $WORK_DIR/storage-schemas.conf:/opt/graphite/conf/storage-schemas.conf \
+  $GRAPHITE_IMAGE
+
+until curl -sSf "http://localhost:$HTTP_PORT/render?target=none&format=json" > /dev/null; do
+  sleep 1
+done
+
+echo "Write input series over the carbon plaintext protocol"
+jq -r '.step as $step | .series[] | .name as $name | .start as $start |
+  .values | to_entries[] | select(.value != null) |
+  "\($name) \(.value) \($start + .key * $step)"' $GOLDEN \
+  > /dev/tcp/localhost/$CARBON_PORT
+
+FROM=$(jq .from $GOLDEN)
+UNTIL=$(jq .until $GOLDEN)
+NUM_SERIES=$(jq '.series | length' $GOLDEN)
+
+# NB: whisper rounds both from and until up to the next step and treats until
+# as exclusive, so request one second before each bound to render the steps
+# in [from, until) as the M3 engine does.
+function render {
+  curl -sSfG "http://localhost:$HTTP_PORT/render" \
+    --data-urlencode "target=$1" \
+    --data-urlencode "from=$(($FROM-1))" \
+    --data-urlencode "until=$(($UNTIL-1))" \
+    --data-urlencode "format=json"
+}
+
+echo "Wait for carbon to persist every input series"
+until test "$(render '*.*.*' | jq length)" = "$NUM_SERIES"; do
+  sleep 1
+done
+
+NUM_CASES=$(jq '.cases | length' $GOLDEN)
+for i in $(seq 0 $(($NUM_CASES-1))); do
+  TARGET=$(jq -r ".cases[$i].target" $GOLDEN)
+  render "$TARGET" | jq -c '[.[] | {target, datapoints}]' > $WORK_DIR/expected.json
+  jq --argjson i $i --slurpfile expected $WORK_DIR/expected.json \
+    '.cases[$i].expected = $expected[0]' $GOLDEN > $WORK_DIR/golden.json
+  mv $WORK_DIR/golden.json $GOLDEN
+done
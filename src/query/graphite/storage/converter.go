@@ -65,3 +65,49 @@ func matcherTerminator(count int) models.Matcher {
 		Name: graphite.TagName(count),
 	}
 }
+
+func convertTagExpressionToMatcher(
+	expr graphite.TagExpression,
+) models.Matcher {
+	var (
+		name  = []byte(expr.Tag)
+		value = []byte(expr.Value)
+	)
+	switch expr.Operator {
+	case graphite.TagOperatorEqual:
+		if len(value) == 0 {
+			return models.Matcher{Type: models.MatchNotField, Name: name}
+		}
+		return models.Matcher{Type: models.MatchEqual, Name: name, Value: value}
+	case graphite.TagOperatorNotEqual:
+		if len(value) == 0 {
+			return models.Matcher{Type: models.MatchField, Name: name}
+		}
+		return models.Matcher{Type: models.MatchNotEqual, Name: name, Value: value}
+	case graphite.TagOperatorMatch:
+		return models.Matcher{
+			Type:  models.MatchRegexp,
+			Name:  name,
+			Value: tagRegexPattern(expr.Value),
+		}
+	default:
+		return models.Matcher{
+			Type:  models.MatchNotRegexp,
+			Name:  name,
+			Value: tagRegexPattern(expr.Value),
+		}
+	}
+}
+
+// tagRegexPattern converts a graphite tag regex, which is only anchored at
+// the start of the value, to an M3 index regex which is fully anchored.
+func tagRegexPattern(re string) []byte {
+	return []byte("(?:" + re + ").*")
+}
+
+func taggedSeriesMatcher() models.Matcher {
+	return models.Matcher{
+		Type: models.MatchField,
+		Name: []byte(graphite.TaggedNameTag),
+	}
+}
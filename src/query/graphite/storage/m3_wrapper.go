@@ -117,6 +117,36 @@ func TranslateQueryToMatchersWithTerminator(
 	return matchers, nil
 }
 
+// TranslateTagExpressionsToMatchers converts graphite seriesByTag tag
+// expressions to tag matcher pairs, restricted to tagged series.
+func TranslateTagExpressionsToMatchers(
+	exprs []string,
+) (models.Matchers, error) {
+	var (
+		matchers = make(models.Matchers, 0, len(exprs)+1)
+		positive bool
+	)
+	for _, str := range exprs {
+		expr, err := graphite.ParseTagExpression(str)
+		if err != nil {
+			return nil, err
+		}
+
+		positive = positive || expr.IsPositive()
+		matchers = append(matchers, convertTagExpressionToMatcher(expr))
+	}
+
+	if !positive {
+		return nil, fmt.Errorf(
+			"seriesByTag requires at least one positive tag expression: %v", exprs)
+	}
+
+	// Only tagged series have a name tag, this ensures plain graphite paths
+	// are never returned by a tag query.
+	matchers = append(matchers, taggedSeriesMatcher())
+	return matchers, nil
+}
+
 // GetQueryTerminatorTagName will return the name for the terminator matcher in
 // the given pattern. This is useful for filtering out any additional results.
 func GetQueryTerminatorTagName(query string) []byte {
@@ -129,7 +159,15 @@ func translateQuery(
 	fetchOpts FetchOptions,
 	opts M3WrappedStorageOptions,
 ) (*storage.FetchQuery, error) {
-	matchers, err := TranslateQueryToMatchersWithTerminator(query)
+	var (
+		matchers models.Matchers
+		err      error
+	)
+	if exprs, ok := graphite.ParseSeriesByTagQuery(query); ok {
+		matchers, err = TranslateTagExpressionsToMatchers(exprs)
+	} else {
+		matchers, err = TranslateQueryToMatchersWithTerminator(query)
+	}
 	if err != nil {
 		return nil, err
 	}
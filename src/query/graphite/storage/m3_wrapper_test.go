@@ -81,6 +81,34 @@ func TestTranslateQuery(t *testing.T) {
 	assert.Equal(t, expected, matchers)
 }
 
+func TestTranslateSeriesByTagQuery(t *testing.T) {
+	query := graphite.SeriesByTagQuery([]string{
+		"name=foo.bar", "dc!=east", "host=~web", "env!=~dev", "role=",
+	})
+	end := time.Now()
+	opts := FetchOptions{
+		StartTime: end.Add(-time.Hour),
+		EndTime:   end,
+	}
+
+	translated, err := translateQuery(query, opts, M3WrappedStorageOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, query, translated.Raw)
+	expected := models.Matchers{
+		{Type: models.MatchEqual, Name: []byte("name"), Value: []byte("foo.bar")},
+		{Type: models.MatchNotEqual, Name: []byte("dc"), Value: []byte("east")},
+		{Type: models.MatchRegexp, Name: []byte("host"), Value: []byte("(?:web).*")},
+		{Type: models.MatchNotRegexp, Name: []byte("env"), Value: []byte("(?:dev).*")},
+		{Type: models.MatchNotField, Name: []byte("role")},
+		{Type: models.MatchField, Name: []byte("name")},
+	}
+	assert.Equal(t, expected, translated.TagMatchers)
+
+	query = graphite.SeriesByTagQuery([]string{"dc!=east"})
+	_, err = translateQuery(query, opts, M3WrappedStorageOptions{})
+	require.Error(t, err)
+}
+
 func TestTranslateQueryTrailingDot(t *testing.T) {
 	query := `foo.`
 	end := time.Now()
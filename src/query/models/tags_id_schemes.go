@@ -21,6 +21,10 @@
 package models
 
 import (
+	"bytes"
+	"sort"
+	"sync"
+
 	"github.com/m3db/m3/src/query/models/strconv"
 	"github.com/m3db/m3/src/query/util/writer"
 )
@@ -218,6 +222,10 @@ func idLenGraphite(t Tags) int {
 }
 
 func graphiteID(t Tags) []byte {
+	if isGraphiteTagged(t) {
+		return graphiteTaggedID(t)
+	}
+
 	// TODO: pool these bytes.
 	id := make([]byte, idLenGraphite(t))
 	idx := 0
@@ -231,3 +239,76 @@ func graphiteID(t Tags) []byte {
 	copy(id[idx:], t.Tags[lastIndex].Value)
 	return id
 }
+
+// isGraphiteTagged returns true if any of the tags is not a Graphite path
+// tag, meaning the tags describe a Graphite 1.1 tagged series.
+func isGraphiteTagged(t Tags) bool {
+	for _, tag := range t.Tags {
+		if !bytes.HasPrefix(tag.Name, graphitePathTagPrefix) ||
+			!bytes.HasSuffix(tag.Name, graphitePathTagSuffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// graphiteTaggedTags are the tags of a Graphite tagged series other than the
+// name tag, sorted by name when generating the series name.
+type graphiteTaggedTags struct {
+	tags []Tag
+}
+
+func (t *graphiteTaggedTags) Len() int      { return len(t.tags) }
+func (t *graphiteTaggedTags) Swap(i, j int) { t.tags[i], t.tags[j] = t.tags[j], t.tags[i] }
+func (t *graphiteTaggedTags) Less(i, j int) bool {
+	return bytes.Compare(t.tags[i].Name, t.tags[j].Name) < 0
+}
+
+var graphiteTaggedTagsPool = sync.Pool{
+	New: func() interface{} {
+		return &graphiteTaggedTags{}
+	},
+}
+
+// graphiteTaggedID generates the canonical Graphite tagged series name,
+// i.e. the value of the name tag followed by the remaining tags sorted by
+// name: path;t1=v1;t2=v2.
+func graphiteTaggedID(t Tags) []byte {
+	var (
+		path   []byte
+		others = graphiteTaggedTagsPool.Get().(*graphiteTaggedTags)
+		idLen  int
+	)
+	for _, tag := range t.Tags {
+		if bytes.Equal(tag.Name, graphiteTaggedNameTag) {
+			path = tag.Value
+			idLen += len(tag.Value)
+			continue
+		}
+
+		others.tags = append(others.tags, tag)
+		idLen += len(tag.Name) + len(tag.Value) + 2 // account for separators
+	}
+
+	sort.Sort(others)
+
+	// NB: the ID is retained by the caller, e.g. as the last computed ID of
+	// the tags, so only the scratch tags used to sort the tags are pooled.
+	id := make([]byte, 0, idLen)
+	id = append(id, path...)
+	for _, tag := range others.tags {
+		id = append(id, graphiteTag)
+		id = append(id, tag.Name...)
+		id = append(id, eq)
+		id = append(id, tag.Value...)
+	}
+
+	// Release references to the tag bytes before returning to the pool.
+	for i := range others.tags {
+		others.tags[i] = Tag{}
+	}
+	others.tags = others.tags[:0]
+	graphiteTaggedTagsPool.Put(others)
+	return id
+}
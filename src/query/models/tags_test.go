@@ -76,6 +76,26 @@ func TestLongTagNewIDOutOfOrderGraphite(t *testing.T) {
 	assert.Equal(t, []byte("v0.v1.v2.v3.v4.v5.v6.v7.v8.v9.v10.v11.v12"), actual)
 }
 
+func TestTaggedGraphiteID(t *testing.T) {
+	opts := NewTagOptions().SetIDSchemeType(TypeGraphite)
+	tags := NewTags(3, opts).AddTags([]Tag{
+		{Name: []byte("host"), Value: []byte("a")},
+		{Name: []byte("name"), Value: []byte("foo.bar")},
+		{Name: []byte("dc"), Value: []byte("east")},
+	})
+
+	assert.Equal(t, []byte("foo.bar;dc=east;host=a"), tags.ID())
+	require.NoError(t, tags.Validate())
+
+	// Ensure the pooled scratch tags from the previous ID are not reused.
+	other := NewTags(2, opts).AddTags([]Tag{
+		{Name: []byte("name"), Value: []byte("baz")},
+		{Name: []byte("env"), Value: []byte("prod")},
+	})
+	assert.Equal(t, []byte("baz;env=prod"), other.ID())
+	assert.Equal(t, []byte("foo.bar;dc=east;host=a"), tags.ID())
+}
+
 func TestLongTagNewIDOutOfOrderQuotedWithEscape(t *testing.T) {
 	tags := testLongTagIDOutOfOrder(t, TypeQuoted)
 	tags = tags.AddTag(Tag{Name: []byte(`t5""`), Value: []byte(`v"5`)})
@@ -27,6 +27,7 @@ import (
 // Separators for tags.
 const (
 	graphiteSep  = byte('.')
+	graphiteTag  = byte(';')
 	sep          = byte(',')
 	finish       = byte('!')
 	eq           = byte('=')
@@ -34,6 +35,16 @@ const (
 	rightBracket = byte('}')
 )
 
+var (
+	// graphitePathTagPrefix and graphitePathTagSuffix delimit the tag names
+	// generated for each node of a Graphite path, e.g. __g0__.
+	graphitePathTagPrefix = []byte("__g")
+	graphitePathTagSuffix = []byte("__")
+	// graphiteTaggedNameTag is the reserved tag holding the path of a
+	// Graphite tagged series.
+	graphiteTaggedNameTag = []byte("name")
+)
+
 // IDSchemeType determines the scheme for generating
 // series IDs based on their tags.
 type IDSchemeType uint16
@@ -61,6 +72,9 @@ const (
 	// ingestion path, as it ignores tag names and is very prone to collisions if
 	// used on non-graphite data.
 	// {__g0__:v1},{__g1__:v2} -> v1.v2
+	// Graphite tagged series carry their path in the reserved name tag and
+	// are represented in the canonical Graphite 1.1 tagged form instead.
+	// {name:v1.v2},{t1:v3} -> v1.v2;t1=v3
 	//
 	// NB: when TypeGraphite is specified, tags are ordered numerically rather
 	// than lexically.
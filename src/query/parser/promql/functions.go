@@ -0,0 +1,238 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package promql
+
+import (
+	"strings"
+
+	"github.com/m3db/m3/src/query/functions/aggregation"
+	"github.com/m3db/m3/src/query/functions/scalar"
+	"github.com/m3db/m3/src/query/parser"
+
+	pql "github.com/prometheus/prometheus/promql/parser"
+)
+
+// NB: the vendored Prometheus parser predates a number of PromQL functions,
+// so they are registered with the parser here to allow them to be parsed.
+var additionalFunctions = []*pql.Function{
+	newFunction("last_over_time", pql.ValueTypeVector, pql.ValueTypeMatrix),
+	newFunction("present_over_time", pql.ValueTypeVector, pql.ValueTypeMatrix),
+	newFunction("sgn", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("sin", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("cos", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("tan", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("asin", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("acos", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("atan", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("sinh", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("cosh", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("tanh", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("asinh", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("acosh", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("atanh", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("deg", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("rad", pql.ValueTypeVector, pql.ValueTypeVector),
+	newFunction("pi", pql.ValueTypeScalar),
+}
+
+func init() {
+	for _, fn := range additionalFunctions {
+		if _, ok := pql.Functions[fn.Name]; !ok {
+			pql.Functions[fn.Name] = fn
+		}
+	}
+}
+
+func newFunction(
+	name string,
+	returnType pql.ValueType,
+	argTypes ...pql.ValueType,
+) *pql.Function {
+	return &pql.Function{
+		Name:       name,
+		ArgTypes:   argTypes,
+		ReturnType: returnType,
+	}
+}
+
+const (
+	groupKeyword = "group"
+	countKeyword = "count"
+)
+
+// extractGroupAggregations replaces any group aggregations in the query, which
+// the vendored Prometheus parser does not support, with count aggregations.
+// The positions of the replaced aggregations are returned so that they can be
+// converted back to group aggregations when walking the parsed expression.
+//
+// NB: the aggregation keywords are the same length, so that the positions of
+// any other expressions in the query are unaffected.
+func extractGroupAggregations(query string) (string, map[pql.Pos]struct{}) {
+	var (
+		rewritten []byte
+		positions map[pql.Pos]struct{}
+		depth     int
+	)
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			i = skipString(query, i)
+			continue
+		case c == '#':
+			i = skipComment(query, i)
+			continue
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		case depth == 0 && isGroupAggregationAt(query, i):
+			if rewritten == nil {
+				rewritten = []byte(query)
+				positions = make(map[pql.Pos]struct{})
+			}
+
+			copy(rewritten[i:], countKeyword)
+			positions[pql.Pos(i)] = struct{}{}
+			i += len(groupKeyword)
+			continue
+		}
+
+		i++
+	}
+
+	if rewritten == nil {
+		return query, nil
+	}
+
+	return string(rewritten), positions
+}
+
+// isGroupAggregationAt returns true if there is a group aggregation at the
+// given index, rather than e.g. a label named group in a grouping.
+func isGroupAggregationAt(query string, idx int) bool {
+	if !isKeywordAt(query, idx, groupKeyword) {
+		return false
+	}
+
+	idx = skipSpace(query, idx+len(groupKeyword))
+	if idx < len(query) && query[idx] == '(' {
+		return true
+	}
+
+	return isKeywordAt(query, idx, "by") || isKeywordAt(query, idx, "without")
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == ':' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// isKeywordAt returns true if the given keyword appears at the given index as
+// a standalone word, ignoring case.
+func isKeywordAt(query string, idx int, keyword string) bool {
+	end := idx + len(keyword)
+	if idx < 0 || end > len(query) {
+		return false
+	}
+
+	if !strings.EqualFold(query[idx:end], keyword) {
+		return false
+	}
+
+	if idx > 0 && isIdentifierChar(query[idx-1]) {
+		return false
+	}
+
+	return end == len(query) || !isIdentifierChar(query[end])
+}
+
+func skipSpace(query string, idx int) int {
+	for idx < len(query) {
+		switch query[idx] {
+		case ' ', '\t', '\n', '\r':
+			idx++
+		default:
+			return idx
+		}
+	}
+
+	return idx
+}
+
+// skipString returns the index after the string literal starting at idx.
+func skipString(query string, idx int) int {
+	quote := query[idx]
+	for i := idx + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			return i + 1
+		}
+	}
+
+	return len(query)
+}
+
+// skipComment returns the index after the comment starting at idx.
+func skipComment(query string, idx int) int {
+	if end := strings.IndexByte(query[idx:], '\n'); end >= 0 {
+		return idx + end + 1
+	}
+
+	return len(query)
+}
+
+func (p *parseState) newAggregationOperator(
+	expr *pql.AggregateExpr,
+) (parser.Params, error) {
+	if _, ok := p.groupAggregations[expr.PosRange.Start]; ok {
+		return aggregation.NewAggregationOp(aggregation.GroupType,
+			newAggregationNodeParams(expr))
+	}
+
+	return NewAggregationOperator(expr)
+}
+
+// isConstantExpr returns true if the expression can be resolved to a constant
+// when parsing.
+func isConstantExpr(expr pql.Expr) bool {
+	switch n := expr.(type) {
+	case *pql.NumberLiteral:
+		return true
+	case *pql.ParenExpr:
+		return isConstantExpr(n.Expr)
+	case *pql.BinaryExpr:
+		return isConstantExpr(n.LHS) && isConstantExpr(n.RHS)
+	case *pql.Call:
+		if n.Func.Name != scalar.ScalarType && n.Func.Name != scalar.VectorType {
+			return false
+		}
+
+		return len(n.Args) == 1 && isConstantExpr(n.Args[0])
+	}
+
+	return false
+}
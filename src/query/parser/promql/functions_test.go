@@ -0,0 +1,86 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package promql
+
+import (
+	"testing"
+
+	pql "github.com/prometheus/prometheus/promql/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractGroupAggregations(t *testing.T) {
+	tests := []struct {
+		query     string
+		expected  string
+		positions []pql.Pos
+	}{
+		{
+			query:    "sum(up)",
+			expected: "sum(up)",
+		},
+		{
+			query:     "group(up)",
+			expected:  "count(up)",
+			positions: []pql.Pos{0},
+		},
+		{
+			query:     "group by (group) (up{group=\"a\"}) + GROUP without(x)(up)",
+			expected:  "count by (group) (up{group=\"a\"}) + count without(x)(up)",
+			positions: []pql.Pos{0, 35},
+		},
+		{
+			query:    "sum by (group) (up) * on(group) group_left(group) up",
+			expected: "sum by (group) (up) * on(group) group_left(group) up",
+		},
+		{
+			query:    `label_replace(up, "group", "group(x)", "a", "b")`,
+			expected: `label_replace(up, "group", "group(x)", "a", "b")`,
+		},
+		{
+			query:    "rate(group[5m]) + group",
+			expected: "rate(group[5m]) + group",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			actual, positions := extractGroupAggregations(tt.query)
+			assert.Equal(t, tt.expected, actual)
+			require.Equal(t, len(tt.positions), len(positions))
+			for _, pos := range tt.positions {
+				_, ok := positions[pos]
+				assert.True(t, ok, "missing position %d", pos)
+			}
+		})
+	}
+}
+
+func TestAdditionalFunctionsParse(t *testing.T) {
+	for _, fn := range additionalFunctions {
+		_, ok := pql.Functions[fn.Name]
+		assert.True(t, ok, "function %s not registered", fn.Name)
+	}
+
+	_, err := pql.ParseExpr("sgn(up) + last_over_time(up[5m]) * pi()")
+	require.NoError(t, err)
+}
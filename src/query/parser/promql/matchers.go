@@ -22,6 +22,7 @@ package promql
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/functions"
@@ -41,9 +42,11 @@ import (
 	promql "github.com/prometheus/prometheus/promql/parser"
 )
 
-// NewSelectorFromVector creates a new fetchop.
+// NewSelectorFromVector creates a new fetchop, pinned to the evaluation time
+// of the given @ modifier if set.
 func NewSelectorFromVector(
 	n *promql.VectorSelector,
+	at *temporal.AtModifier,
 	tagOpts models.TagOptions,
 ) (parser.Params, error) {
 	matchers, err := LabelMatchersToModelMatcher(n.LabelMatchers, tagOpts)
@@ -55,12 +58,15 @@ func NewSelectorFromVector(
 		Name:     n.Name,
 		Offset:   n.Offset,
 		Matchers: matchers,
+		At:       at,
 	}, nil
 }
 
-// NewSelectorFromMatrix creates a new fetchop.
+// NewSelectorFromMatrix creates a new fetchop, pinned to the evaluation time
+// of the given @ modifier if set.
 func NewSelectorFromMatrix(
 	n *promql.MatrixSelector,
+	at *temporal.AtModifier,
 	tagOpts models.TagOptions,
 ) (parser.Params, error) {
 	vectorSelector := n.VectorSelector.(*promql.VectorSelector)
@@ -74,22 +80,14 @@ func NewSelectorFromMatrix(
 		Offset:   vectorSelector.Offset,
 		Matchers: matchers,
 		Range:    n.Range,
+		At:       at,
 	}, nil
 }
 
 // NewAggregationOperator creates a new aggregation operator based on the type.
 func NewAggregationOperator(expr *promql.AggregateExpr) (parser.Params, error) {
 	opType := expr.Op
-	byteMatchers := make([][]byte, len(expr.Grouping))
-	for i, grouping := range expr.Grouping {
-		byteMatchers[i] = []byte(grouping)
-	}
-
-	nodeInformation := aggregation.NodeParams{
-		MatchingTags: byteMatchers,
-		Without:      expr.Without,
-	}
-
+	nodeInformation := newAggregationNodeParams(expr)
 	op := getAggOpType(opType)
 	switch op {
 	case common.UnknownOpType:
@@ -124,6 +122,18 @@ func NewAggregationOperator(expr *promql.AggregateExpr) (parser.Params, error) {
 	return aggregation.NewAggregationOp(op, nodeInformation)
 }
 
+func newAggregationNodeParams(expr *promql.AggregateExpr) aggregation.NodeParams {
+	byteMatchers := make([][]byte, len(expr.Grouping))
+	for i, grouping := range expr.Grouping {
+		byteMatchers[i] = []byte(grouping)
+	}
+
+	return aggregation.NodeParams{
+		MatchingTags: byteMatchers,
+		Without:      expr.Without,
+	}
+}
+
 func unwrapParenExpr(expr promql.Expr) promql.Expr {
 	for {
 		if paren, ok := expr.(*promql.ParenExpr); ok {
@@ -235,7 +245,12 @@ func NewFunctionExpr(
 	switch name {
 	case linear.AbsType, linear.CeilType, linear.ExpType,
 		linear.FloorType, linear.LnType, linear.Log10Type,
-		linear.Log2Type, linear.SqrtType:
+		linear.Log2Type, linear.SqrtType, linear.SgnType,
+		linear.SinType, linear.CosType, linear.TanType,
+		linear.AsinType, linear.AcosType, linear.AtanType,
+		linear.SinhType, linear.CoshType, linear.TanhType,
+		linear.AsinhType, linear.AcoshType, linear.AtanhType,
+		linear.DegType, linear.RadType:
 		p, err = linear.NewMathOp(name)
 		return p, true, err
 
@@ -261,10 +276,16 @@ func NewFunctionExpr(
 
 	case temporal.AvgType, temporal.CountType, temporal.MinType,
 		temporal.MaxType, temporal.SumType, temporal.StdDevType,
-		temporal.StdVarType:
+		temporal.StdVarType, temporal.LastType, temporal.PresentType:
 		p, err = temporal.NewAggOp(argValues, name)
 		return p, true, err
 
+	case temporal.AbsentType:
+		// NB: absent_over_time is evaluated as an absent aggregation over the
+		// result of present_over_time, which is added when walking the call.
+		p, err = temporal.NewAggOp(argValues, temporal.PresentType)
+		return p, true, err
+
 	case temporal.QuantileType:
 		p, err = temporal.NewQuantileOp(argValues, name)
 		return p, true, err
@@ -295,13 +316,17 @@ func NewFunctionExpr(
 		p, err = scalar.NewTimeOp(tagOptions)
 		return p, true, err
 
-	case linear.SortType, linear.SortDescType:
-		p, err = linear.NewSortOp(name)
+	case scalar.PiType:
+		p, err = scalar.NewScalarOp(math.Pi, tagOptions)
 		return p, true, err
 
-	// NB: no-ops.
 	case scalar.ScalarType:
-		return nil, false, err
+		p, err = scalar.NewToScalarOp(tagOptions)
+		return p, true, err
+
+	case linear.SortType, linear.SortDescType:
+		p, err = linear.NewSortOp(name)
+		return p, true, err
 
 	default:
 		return nil, false, fmt.Errorf("function not supported: %s", name)
@@ -0,0 +1,435 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package promql
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/functions/temporal"
+
+	"github.com/prometheus/common/model"
+	pql "github.com/prometheus/prometheus/promql/parser"
+)
+
+// NB: the vendored Prometheus parser predates both the @ modifier and negative
+// offsets, and so its selectors have no Timestamp, StartOrEnd or
+// OriginalOffset fields. Instead, these modifiers are lexed with the
+// Prometheus lexer and blanked out of the query before it is parsed, which
+// leaves the positions of the rest of the query unchanged. Each modifier is
+// then attached by position to the selector or subquery it follows in the
+// parsed expression.
+
+var (
+	errAtModifierSyntax = errors.New(
+		"invalid @ modifier, expected timestamp, start() or end()")
+	errAtModifierRange = errors.New("@ modifier timestamp out of range")
+	errAtModifierNode  = errors.New("@ modifier must be preceded by an " +
+		"instant vector selector or range vector selector or a subquery")
+	errAtModifierSetMultipleTimes = errors.New(
+		"@ <timestamp> may not be set multiple times")
+	errOffsetModifierNode = errors.New("offset modifier must be preceded by " +
+		"an instant vector selector or range vector selector or a subquery")
+	errOffsetSetMultipleTimes = errors.New("offset may not be set multiple times")
+)
+
+// evalModifiers are the evaluation time modifiers for a selector or subquery.
+type evalModifiers struct {
+	offset time.Duration
+	at     *temporal.AtModifier
+}
+
+// extractedModifier is a modifier extracted from a query, along with its
+// position in the query.
+type extractedModifier struct {
+	posRange pql.PositionRange
+	offset   time.Duration
+	at       *temporal.AtModifier
+}
+
+// extractModifiers blanks out any modifiers in the query that the Prometheus
+// parser does not support, returning the blanked query and the extracted
+// modifiers in the order they appear in the query.
+func extractModifiers(query string) (string, []extractedModifier, error) {
+	var (
+		blanked   = query
+		modifiers []extractedModifier
+	)
+
+	for {
+		mod, ok, err := nextModifier(query, blanked)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if !ok {
+			return blanked, modifiers, nil
+		}
+
+		modifiers = append(modifiers, mod)
+		blanked = blankRange(blanked, mod.posRange)
+	}
+}
+
+// AtModifierKinds returns the kinds of the @ modifiers used in the query, in
+// the order they appear in it. Only the modifiers are lexed, so the rest of
+// the query is not validated.
+func AtModifierKinds(query string) ([]temporal.AtKind, error) {
+	_, modifiers, err := extractModifiers(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var kinds []temporal.AtKind
+	for _, mod := range modifiers {
+		if mod.at != nil {
+			kinds = append(kinds, mod.at.Kind)
+		}
+	}
+
+	return kinds, nil
+}
+
+// nextModifier lexes the blanked query and returns the first modifier in it
+// that the Prometheus parser does not support, if any.
+func nextModifier(
+	query string,
+	blanked string,
+) (extractedModifier, bool, error) {
+	var (
+		lexer                  = pql.Lex(blanked)
+		prev                   [2]pql.Item
+		braceOpen, bracketOpen bool
+	)
+
+	for {
+		var item pql.Item
+		lexer.NextItem(&item)
+		switch item.Typ {
+		case pql.EOF:
+			return extractedModifier{}, false, nil
+
+		case pql.COMMENT:
+			continue
+
+		case pql.LEFT_BRACE, pql.RIGHT_BRACE:
+			braceOpen = item.Typ == pql.LEFT_BRACE
+
+		case pql.LEFT_BRACKET, pql.RIGHT_BRACKET:
+			bracketOpen = item.Typ == pql.LEFT_BRACKET
+
+		case pql.DURATION:
+			if prev[0].Typ != pql.OFFSET || prev[1].Typ != pql.SUB {
+				break
+			}
+
+			posRange := pql.PositionRange{
+				Start: prev[0].Pos,
+				End:   item.Pos + pql.Pos(len(item.Val)),
+			}
+
+			offset, err := model.ParseDuration(item.Val)
+			if err != nil {
+				return extractedModifier{}, false, newParseErr(query, posRange, err)
+			}
+
+			return extractedModifier{
+				posRange: posRange,
+				offset:   -1 * time.Duration(offset),
+			}, true, nil
+
+		case pql.ERROR:
+			pos := int(item.Pos)
+			if braceOpen || bracketOpen || pos >= len(blanked) || blanked[pos] != '@' {
+				// NB: leave any other lexing errors to the parser to report.
+				return extractedModifier{}, false, nil
+			}
+
+			mod, err := parseAtModifier(query, blanked, pos)
+			if err != nil {
+				return extractedModifier{}, false, err
+			}
+
+			return mod, true, nil
+		}
+
+		prev[0], prev[1] = prev[1], item
+	}
+}
+
+// parseAtModifier parses the @ modifier at the given position of the blanked
+// query.
+func parseAtModifier(
+	query string,
+	blanked string,
+	pos int,
+) (extractedModifier, error) {
+	var (
+		base  = pql.Pos(pos + 1)
+		lexer = pql.Lex(blanked[base:])
+		last  pql.Item
+	)
+
+	next := func() pql.Item {
+		for {
+			lexer.NextItem(&last)
+			if last.Typ != pql.COMMENT {
+				last.Pos += base
+				return last
+			}
+		}
+	}
+
+	posRange := func() pql.PositionRange {
+		end := last.Pos
+		if last.Typ != pql.ERROR && last.Typ != pql.EOF {
+			end += pql.Pos(len(last.Val))
+		}
+
+		return pql.PositionRange{Start: pql.Pos(pos), End: end}
+	}
+
+	item, sign := next(), ""
+	if item.Typ == pql.ADD || item.Typ == pql.SUB {
+		sign, item = item.Val, next()
+	}
+
+	switch {
+	case item.Typ == pql.NUMBER:
+		ts, err := parseAtTimestamp(sign + item.Val)
+		if err != nil {
+			return extractedModifier{}, newParseErr(query, posRange(), err)
+		}
+
+		return extractedModifier{
+			posRange: posRange(),
+			at: &temporal.AtModifier{
+				Kind:      temporal.AtTimestamp,
+				Timestamp: ts,
+			},
+		}, nil
+
+	case item.Typ == pql.IDENTIFIER && sign == "":
+		var kind temporal.AtKind
+		switch strings.ToLower(item.Val) {
+		case "start":
+			kind = temporal.AtStart
+		case "end":
+			kind = temporal.AtEnd
+		default:
+			return extractedModifier{}, newParseErr(query, posRange(),
+				errAtModifierSyntax)
+		}
+
+		if next().Typ != pql.LEFT_PAREN || next().Typ != pql.RIGHT_PAREN {
+			return extractedModifier{}, newParseErr(query, posRange(),
+				errAtModifierSyntax)
+		}
+
+		return extractedModifier{
+			posRange: posRange(),
+			at:       &temporal.AtModifier{Kind: kind},
+		}, nil
+	}
+
+	return extractedModifier{}, newParseErr(query, posRange(),
+		errAtModifierSyntax)
+}
+
+// parseAtTimestamp parses an @ modifier timestamp in seconds, rounded to the
+// nearest millisecond as with Prometheus.
+func parseAtTimestamp(s string) (time.Time, error) {
+	secs, err := strconv.ParseFloat(s, 64)
+	if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+		return time.Time{}, errAtModifierRange
+	}
+
+	if err != nil {
+		// NB: the lexer also accepts hexadecimal and octal integers.
+		n, intErr := strconv.ParseInt(s, 0, 64)
+		if intErr != nil {
+			return time.Time{}, errAtModifierSyntax
+		}
+
+		secs = float64(n)
+	}
+
+	ms := math.Round(secs * 1000)
+	if math.IsNaN(ms) ||
+		math.Abs(ms) > float64(math.MaxInt64/int64(time.Millisecond)) {
+		return time.Time{}, errAtModifierRange
+	}
+
+	return time.Unix(0, int64(ms)*int64(time.Millisecond)), nil
+}
+
+// attachModifiers attaches the modifiers extracted from the query to the
+// selectors and subqueries they follow in the expression parsed from the
+// blanked query.
+func attachModifiers(
+	query string,
+	blanked string,
+	expr pql.Expr,
+	extracted []extractedModifier,
+) (map[pql.Node]evalModifiers, error) {
+	if len(extracted) == 0 {
+		return nil, nil
+	}
+
+	var (
+		lexer = pql.Lex(blanked)
+		ends  []pql.Pos
+		nodes []pql.Node
+	)
+
+	for {
+		var item pql.Item
+		lexer.NextItem(&item)
+		if item.Typ == pql.EOF || item.Typ == pql.ERROR {
+			break
+		}
+
+		if item.Typ != pql.COMMENT {
+			ends = append(ends, item.Pos+pql.Pos(len(item.Val)))
+		}
+	}
+
+	pql.Inspect(expr, func(node pql.Node, _ []pql.Node) error {
+		switch node.(type) {
+		case *pql.VectorSelector, *pql.MatrixSelector, *pql.SubqueryExpr:
+			nodes = append(nodes, node)
+		}
+
+		return nil
+	})
+
+	modifiers := make(map[pql.Node]evalModifiers, len(extracted))
+	for _, mod := range extracted {
+		// NB: a modifier modifies the smallest selector or subquery that ends
+		// with the item preceding it.
+		var end pql.Pos
+		for _, e := range ends {
+			if e > mod.posRange.Start {
+				break
+			}
+
+			end = e
+		}
+
+		var (
+			node     pql.Node
+			nodeSize pql.Pos
+		)
+
+		for _, n := range nodes {
+			r := n.PositionRange()
+			if r.Start < end && end <= r.End &&
+				(node == nil || r.End-r.Start < nodeSize) {
+				node, nodeSize = n, r.End-r.Start
+			}
+		}
+
+		if mod.at != nil {
+			if node == nil {
+				return nil, newParseErr(query, mod.posRange, errAtModifierNode)
+			}
+
+			mods := modifiers[node]
+			if mods.at != nil {
+				return nil, newParseErr(query, mod.posRange,
+					errAtModifierSetMultipleTimes)
+			}
+
+			mods.at = mod.at
+			modifiers[node] = mods
+			continue
+		}
+
+		if node == nil {
+			return nil, newParseErr(query, mod.posRange, errOffsetModifierNode)
+		}
+
+		mods := modifiers[node]
+		if mods.offset != 0 || parsedOffset(node) != 0 {
+			return nil, newParseErr(query, mod.posRange,
+				errOffsetSetMultipleTimes)
+		}
+
+		mods.offset = mod.offset
+		modifiers[node] = mods
+	}
+
+	return modifiers, nil
+}
+
+// parsedOffset returns the offset of a selector or subquery as parsed by the
+// Prometheus parser.
+func parsedOffset(node pql.Node) time.Duration {
+	switch n := node.(type) {
+	case *pql.VectorSelector:
+		return n.Offset
+	case *pql.MatrixSelector:
+		if vs, ok := n.VectorSelector.(*pql.VectorSelector); ok {
+			return vs.Offset
+		}
+	case *pql.SubqueryExpr:
+		return n.Offset
+	}
+
+	return 0
+}
+
+// modifiersFor returns the evaluation modifiers for a selector or subquery,
+// given the offset parsed by the Prometheus parser.
+func (p *parseState) modifiersFor(
+	node pql.Node,
+	offset time.Duration,
+) evalModifiers {
+	mods := p.modifiers[node]
+	if offset != 0 {
+		mods.offset = offset
+	}
+
+	return mods
+}
+
+// blankRange replaces the given range of the query with spaces, keeping any
+// line breaks so that error positions are unaffected.
+func blankRange(query string, posRange pql.PositionRange) string {
+	b := []byte(query)
+	for i := posRange.Start; i < posRange.End; i++ {
+		if b[i] != '\n' {
+			b[i] = ' '
+		}
+	}
+
+	return string(b)
+}
+
+func newParseErr(query string, posRange pql.PositionRange, err error) error {
+	return &pql.ParseErr{
+		PositionRange: posRange,
+		Err:           err,
+		Query:         query,
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package promql
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/models"
+
+	pql "github.com/prometheus/prometheus/promql/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func blank(s string) string {
+	return strings.Repeat(" ", len(s))
+}
+
+func TestExtractModifiers(t *testing.T) {
+	type modifier struct {
+		text   string
+		offset time.Duration
+		at     *temporal.AtModifier
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		expected  string
+		modifiers []modifier
+	}{
+		{
+			name:     "no modifiers",
+			query:    `sum(rate(foo{bar="baz"}[5m] offset 1h))`,
+			expected: `sum(rate(foo{bar="baz"}[5m] offset 1h))`,
+		},
+		{
+			name:     "negative offset",
+			query:    `foo offset -5m`,
+			expected: `foo ` + blank(`offset -5m`),
+			modifiers: []modifier{
+				{text: `offset -5m`, offset: -5 * time.Minute},
+			},
+		},
+		{
+			name:     "at timestamp",
+			query:    `rate(foo[5m] @ 1609746000.5)`,
+			expected: `rate(foo[5m] ` + blank(`@ 1609746000.5`) + `)`,
+			modifiers: []modifier{
+				{
+					text: `@ 1609746000.5`,
+					at: &temporal.AtModifier{
+						Kind:      temporal.AtTimestamp,
+						Timestamp: time.Unix(1609746000, int64(500*time.Millisecond)),
+					},
+				},
+			},
+		},
+		{
+			name:  "at start and end with offsets",
+			query: `foo @ start() offset 1h - bar offset -90s @ end ( )`,
+			expected: `foo ` + blank(`@ start()`) + ` offset 1h - bar ` +
+				blank(`offset -90s`) + ` ` + blank(`@ end ( )`),
+			modifiers: []modifier{
+				{text: `@ start()`, at: &temporal.AtModifier{Kind: temporal.AtStart}},
+				{text: `offset -90s`, offset: -90 * time.Second},
+				{text: `@ end ( )`, at: &temporal.AtModifier{Kind: temporal.AtEnd}},
+			},
+		},
+		{
+			name:     "modifiers in strings and matchers are ignored",
+			query:    `foo{bar="@ 10", offset="x"} offset 5m + label_replace(baz, "a", "@", "b", "c")`,
+			expected: `foo{bar="@ 10", offset="x"} offset 5m + label_replace(baz, "a", "@", "b", "c")`,
+		},
+		{
+			name:     "modifiers in comments are ignored",
+			query:    "foo # @ 10 offset -5m\n@ -10",
+			expected: "foo # @ 10 offset -5m\n" + blank(`@ -10`),
+			modifiers: []modifier{
+				{
+					text: `@ -10`,
+					at: &temporal.AtModifier{
+						Kind:      temporal.AtTimestamp,
+						Timestamp: time.Unix(-10, 0),
+					},
+				},
+			},
+		},
+		{
+			name:     "offset label in grouping is ignored",
+			query:    `sum by (offset) (foo @ 100)`,
+			expected: `sum by (offset) (foo ` + blank(`@ 100`) + `)`,
+			modifiers: []modifier{
+				{
+					text: `@ 100`,
+					at: &temporal.AtModifier{
+						Kind:      temporal.AtTimestamp,
+						Timestamp: time.Unix(100, 0),
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, modifiers, err := extractModifiers(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+			require.Equal(t, len(tt.modifiers), len(modifiers))
+			for i, expected := range tt.modifiers {
+				r := modifiers[i].posRange
+				assert.Equal(t, expected.text, tt.query[r.Start:r.End])
+				assert.Equal(t, expected.offset, modifiers[i].offset)
+				if expected.at == nil {
+					assert.Nil(t, modifiers[i].at)
+					continue
+				}
+
+				require.NotNil(t, modifiers[i].at)
+				assert.Equal(t, expected.at.Kind, modifiers[i].at.Kind)
+				assert.True(t, expected.at.Timestamp.Equal(modifiers[i].at.Timestamp))
+			}
+		})
+	}
+}
+
+func TestAttachModifiers(t *testing.T) {
+	q := "sum(rate(foo[5m] @ 100 offset -1m)) + bar offset 1m @ end() +\n" +
+		"  max_over_time(baz[10m:1m] offset -1h)"
+	p, err := Parse(q, time.Second, models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+
+	modifiers := p.(*promParser).modifiers
+	require.Len(t, modifiers, 3)
+	for node, mods := range modifiers {
+		switch n := node.(type) {
+		case *pql.MatrixSelector:
+			assert.Equal(t, "foo[5m]", n.String())
+			assert.Equal(t, -time.Minute, mods.offset)
+			require.NotNil(t, mods.at)
+			assert.True(t, time.Unix(100, 0).Equal(mods.at.Timestamp))
+		case *pql.VectorSelector:
+			assert.Equal(t, "bar offset 1m", n.String())
+			assert.Equal(t, time.Duration(0), mods.offset)
+			require.NotNil(t, mods.at)
+			assert.Equal(t, temporal.AtEnd, mods.at.Kind)
+		case *pql.SubqueryExpr:
+			assert.Equal(t, "baz[10m:1m]", n.String())
+			assert.Equal(t, -time.Hour, mods.offset)
+			assert.Nil(t, mods.at)
+		default:
+			require.FailNow(t, "unexpected node", "%T", node)
+		}
+	}
+}
+
+func TestAtModifierKinds(t *testing.T) {
+	for _, tt := range []struct {
+		query    string
+		expected []temporal.AtKind
+	}{
+		{query: `foo`},
+		{query: `foo{email="a@b"}`},
+		{query: `foo # @ end()`},
+		{query: `foo @ 10`, expected: []temporal.AtKind{temporal.AtTimestamp}},
+		{
+			query: `rate(foo{email="a@b"}[5m] @ end()) / bar @ start()`,
+			expected: []temporal.AtKind{
+				temporal.AtEnd,
+				temporal.AtStart,
+			},
+		},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			kinds, err := AtModifierKinds(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, kinds)
+		})
+	}
+
+	_, err := AtModifierKinds(`foo @ bar`)
+	require.Error(t, err)
+}
+
+func TestParseModifiersErrors(t *testing.T) {
+	for _, tt := range []struct {
+		query    string
+		expected string
+	}{
+		{
+			query: `foo @ bar`,
+			expected: "1:5: parse error: invalid @ modifier, " +
+				"expected timestamp, start() or end()",
+		},
+		{
+			query: `foo @ start(`,
+			expected: "1:5: parse error: invalid @ modifier, " +
+				"expected timestamp, start() or end()",
+		},
+		{
+			query:    `foo @ 1e400`,
+			expected: "1:5: parse error: @ modifier timestamp out of range",
+		},
+		{
+			query:    `foo @ 10 @ 20`,
+			expected: "1:10: parse error: @ <timestamp> may not be set multiple times",
+		},
+		{
+			query:    `foo offset 5m offset -5m`,
+			expected: "1:15: parse error: offset may not be set multiple times",
+		},
+		{
+			query: `rate(foo[5m]) @ 10`,
+			expected: "1:15: parse error: @ modifier must be preceded by an " +
+				"instant vector selector or range vector selector or a subquery",
+		},
+		{
+			query: "sum(foo)\n  offset -5m",
+			expected: "2:3: parse error: offset modifier must be preceded by " +
+				"an instant vector selector or range vector selector or a subquery",
+		},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query, time.Second, models.NewTagOptions(),
+				NewParseOptions())
+			require.Error(t, err)
+			assert.Equal(t, tt.expected, err.Error())
+		})
+	}
+}
+
+func TestAdjustNegativeOffset(t *testing.T) {
+	assert.Equal(t, -2*time.Minute, adjustOffset(-61*time.Second, time.Minute))
+	assert.Equal(t, -time.Minute, adjustOffset(-time.Minute, time.Minute))
+	assert.Equal(t, 2*time.Minute, adjustOffset(61*time.Second, time.Minute))
+}
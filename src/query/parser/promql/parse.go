@@ -21,13 +21,17 @@
 package promql
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/aggregation"
 	"github.com/m3db/m3/src/query/functions/binary"
 	"github.com/m3db/m3/src/query/functions/lazy"
 	"github.com/m3db/m3/src/query/functions/scalar"
+	"github.com/m3db/m3/src/query/functions/temporal"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 
@@ -37,6 +41,8 @@ import (
 type promParser struct {
 	stepSize          time.Duration
 	expr              pql.Expr
+	modifiers         map[pql.Node]evalModifiers
+	groupAggregations map[pql.Pos]struct{}
 	tagOpts           models.TagOptions
 	parseFunctionExpr ParseFunctionExpr
 }
@@ -48,14 +54,28 @@ func Parse(
 	tagOpts models.TagOptions,
 	parseOptions ParseOptions,
 ) (parser.Parser, error) {
+	blanked, extracted, err := extractModifiers(q)
+	if err != nil {
+		return nil, err
+	}
+
+	rewritten, groupAggregations := extractGroupAggregations(blanked)
+
 	fn := parseOptions.ParseFn()
-	expr, err := fn(q)
+	expr, err := fn(rewritten)
+	if err != nil {
+		return nil, err
+	}
+
+	modifiers, err := attachModifiers(q, blanked, expr, extracted)
 	if err != nil {
 		return nil, err
 	}
 
 	return &promParser{
 		expr:              expr,
+		modifiers:         modifiers,
+		groupAggregations: groupAggregations,
 		stepSize:          stepSize,
 		tagOpts:           tagOpts,
 		parseFunctionExpr: parseOptions.FunctionParseExpr(),
@@ -65,6 +85,8 @@ func Parse(
 func (p *promParser) DAG() (parser.Nodes, parser.Edges, error) {
 	state := &parseState{
 		stepSize:          p.stepSize,
+		modifiers:         p.modifiers,
+		groupAggregations: p.groupAggregations,
 		tagOpts:           p.tagOpts,
 		parseFunctionExpr: p.parseFunctionExpr,
 	}
@@ -74,6 +96,11 @@ func (p *promParser) DAG() (parser.Nodes, parser.Edges, error) {
 		return nil, nil, err
 	}
 
+	if state.pendingAt != nil {
+		return nil, nil, errors.New("@ modifier on a range vector is only " +
+			"supported as a function argument")
+	}
+
 	return state.transforms, state.edges, nil
 }
 
@@ -85,8 +112,13 @@ type parseState struct {
 	stepSize          time.Duration
 	edges             parser.Edges
 	transforms        parser.Nodes
+	modifiers         map[pql.Node]evalModifiers
+	groupAggregations map[pql.Pos]struct{}
 	tagOpts           models.TagOptions
 	parseFunctionExpr ParseFunctionExpr
+	// pendingAt is an @ modifier on a range vector, which is applied to the
+	// function consuming the range vector instead.
+	pendingAt *evalModifiers
 }
 
 func (p *parseState) lastTransformID() parser.NodeID {
@@ -101,6 +133,17 @@ func (p *parseState) transformLen() int {
 	return len(p.transforms)
 }
 
+// addTransformWithParent adds a transform for the given operation, with the
+// last added transform as its parent.
+func (p *parseState) addTransformWithParent(op parser.Params) {
+	opTransform := parser.NewTransformFromOperation(op, p.transformLen())
+	p.edges = append(p.edges, parser.Edge{
+		ParentID: p.lastTransformID(),
+		ChildID:  opTransform.ID,
+	})
+	p.transforms = append(p.transforms, opTransform)
+}
+
 func (p *parseState) addLazyUnaryTransform(unaryOp string) error {
 	// NB: if unary type is "+", we do not apply any offsets.
 	if unaryOp == binary.PlusType {
@@ -126,26 +169,35 @@ func (p *parseState) addLazyUnaryTransform(unaryOp string) error {
 }
 
 func (p *parseState) addLazyOffsetTransform(offset time.Duration) error {
-	// NB: if offset is <= 0, we do not apply any offsets.
+	// NB: if offset is 0, we do not apply any offsets.
 	if offset == 0 {
 		return nil
-	} else if offset < 0 {
-		return fmt.Errorf("offset must be positive, received: %v", offset)
 	}
 
-	var (
-		tt = func(t time.Time) time.Time { return t.Add(offset) }
-		mt = func(meta block.Metadata) block.Metadata {
-			meta.Bounds.Start = meta.Bounds.Start.Add(offset)
-			return meta
-		}
-	)
+	op, err := lazy.NewOffsetOp(offset)
+	if err != nil {
+		return err
+	}
+
+	opTransform := parser.NewTransformFromOperation(op, p.transformLen())
+	p.edges = append(p.edges, parser.Edge{
+		ParentID: p.lastTransformID(),
+		ChildID:  opTransform.ID,
+	})
+	p.transforms = append(p.transforms, opTransform)
 
-	lazyOpts := block.NewLazyOptions().
-		SetTimeTransform(tt).
-		SetMetaTransform(mt)
+	return nil
+}
 
-	op, err := lazy.NewLazyOp(lazy.OffsetType, lazyOpts)
+// addAtTransform pins the expression added to the DAG from the given transform
+// index onwards to the evaluation time of the given @ modifier, if any.
+func (p *parseState) addAtTransform(mods evalModifiers, startIdx int) error {
+	if mods.at == nil {
+		return nil
+	}
+
+	op, err := temporal.NewAtOp(mods.at.Kind, mods.at.Timestamp,
+		p.maxRangeSince(startIdx))
 	if err != nil {
 		return err
 	}
@@ -156,7 +208,33 @@ func (p *parseState) addLazyOffsetTransform(offset time.Duration) error {
 		ChildID:  opTransform.ID,
 	})
 	p.transforms = append(p.transforms, opTransform)
+	return nil
+}
+
+// addPendingAtTransform applies any pending @ modifier on a range vector
+// argument to the function call added from the given transform index onwards.
+func (p *parseState) addPendingAtTransform(startIdx int) error {
+	if p.pendingAt == nil {
+		return nil
+	}
+
+	mods := *p.pendingAt
+	p.pendingAt = nil
+	return p.addAtTransform(mods, startIdx)
+}
 
+// setPendingAt marks the given modifiers as pending if they contain an @
+// modifier; this is used for range vectors, which cannot be pinned directly.
+func (p *parseState) setPendingAt(mods evalModifiers) error {
+	if mods.at == nil {
+		return nil
+	}
+
+	if p.pendingAt != nil {
+		return errors.New("multiple @ modifiers on range vector arguments")
+	}
+
+	p.pendingAt = &mods
 	return nil
 }
 
@@ -168,10 +246,69 @@ func adjustOffset(offset time.Duration, step time.Duration) time.Duration {
 	}
 
 	// NB: Prometheus rounds offsets up to step size, e.g. a 61 second offset with
-	// a 1 minute stepsize gets rounded to a 2 minute offset.
+	// a 1 minute stepsize gets rounded to a 2 minute offset. Negative offsets are
+	// similarly rounded away from zero.
+	if offset < 0 {
+		return offset - step - align
+	}
+
 	return offset + step - align
 }
 
+// maxRangeSince returns the largest range of any bound operation added to the
+// DAG from the given transform index onwards.
+func (p *parseState) maxRangeSince(idx int) time.Duration {
+	var maxRange time.Duration
+	for _, t := range p.transforms[idx:] {
+		boundOp, ok := t.Op.(transform.BoundOp)
+		if !ok {
+			continue
+		}
+
+		if r := boundOp.Bounds().Range; r > maxRange {
+			maxRange = r
+		}
+	}
+
+	return maxRange
+}
+
+func (p *parseState) walkSubquery(n *pql.SubqueryExpr) error {
+	step := n.Step
+	if step == 0 {
+		// NB: Prometheus uses the global evaluation interval if the subquery step
+		// is omitted; use the step of the enclosing expression instead.
+		step = p.stepSize
+	}
+
+	mods := p.modifiersFor(n, n.Offset)
+
+	// NB: the subquery expression is evaluated at the subquery step, so any
+	// offsets within it should be aligned to that step.
+	outerStep, startIdx := p.stepSize, p.transformLen()
+	p.stepSize = step
+	err := p.walk(n.Expr)
+	p.stepSize = outerStep
+	if err != nil {
+		return err
+	}
+
+	offset := adjustOffset(mods.offset, p.stepSize)
+	op, err := temporal.NewSubqueryOp(n.Range, step, offset,
+		p.maxRangeSince(startIdx))
+	if err != nil {
+		return err
+	}
+
+	opTransform := parser.NewTransformFromOperation(op, p.transformLen())
+	p.edges = append(p.edges, parser.Edge{
+		ParentID: p.lastTransformID(),
+		ChildID:  opTransform.ID,
+	})
+	p.transforms = append(p.transforms, opTransform)
+	return p.setPendingAt(mods)
+}
+
 func (p *parseState) walk(node pql.Node) error {
 	if node == nil {
 		return nil
@@ -184,7 +321,7 @@ func (p *parseState) walk(node pql.Node) error {
 			return err
 		}
 
-		op, err := NewAggregationOperator(n)
+		op, err := p.newAggregationOperator(n)
 		if err != nil {
 			return err
 		}
@@ -199,10 +336,12 @@ func (p *parseState) walk(node pql.Node) error {
 		return nil
 
 	case *pql.MatrixSelector:
-		// Align offset to stepSize.
 		vectorSelector := n.VectorSelector.(*pql.VectorSelector)
-		vectorSelector.Offset = adjustOffset(vectorSelector.Offset, p.stepSize)
-		operation, err := NewSelectorFromMatrix(n, p.tagOpts)
+		mods := p.modifiersFor(n, vectorSelector.Offset)
+
+		// Align offset to stepSize.
+		vectorSelector.Offset = adjustOffset(mods.offset, p.stepSize)
+		operation, err := NewSelectorFromMatrix(n, mods.at, p.tagOpts)
 		if err != nil {
 			return err
 		}
@@ -211,24 +350,40 @@ func (p *parseState) walk(node pql.Node) error {
 			p.transforms,
 			parser.NewTransformFromOperation(operation, p.transformLen()),
 		)
-		return p.addLazyOffsetTransform(vectorSelector.Offset)
+
+		if err := p.addLazyOffsetTransform(vectorSelector.Offset); err != nil {
+			return err
+		}
+
+		return p.setPendingAt(mods)
 
 	case *pql.VectorSelector:
+		mods := p.modifiersFor(n, n.Offset)
+
 		// Align offset to stepSize.
-		n.Offset = adjustOffset(n.Offset, p.stepSize)
-		operation, err := NewSelectorFromVector(n, p.tagOpts)
+		n.Offset = adjustOffset(mods.offset, p.stepSize)
+		operation, err := NewSelectorFromVector(n, mods.at, p.tagOpts)
 		if err != nil {
 			return err
 		}
 
+		startIdx := p.transformLen()
 		p.transforms = append(
 			p.transforms,
 			parser.NewTransformFromOperation(operation, p.transformLen()),
 		)
 
-		return p.addLazyOffsetTransform(n.Offset)
+		if err := p.addLazyOffsetTransform(n.Offset); err != nil {
+			return err
+		}
+
+		return p.addAtTransform(mods, startIdx)
+
+	case *pql.SubqueryExpr:
+		return p.walkSubquery(n)
 
 	case *pql.Call:
+		startIdx := p.transformLen()
 		if n.Func.Name == scalar.VectorType {
 			if len(n.Args) != 1 {
 				return fmt.Errorf(
@@ -237,18 +392,34 @@ func (p *parseState) walk(node pql.Node) error {
 				)
 			}
 
-			val, err := resolveScalarArgument(n.Args[0])
-			if err != nil {
+			if isConstantExpr(n.Args[0]) {
+				val, err := resolveScalarArgument(n.Args[0])
+				if err != nil {
+					return err
+				}
+
+				op, err := scalar.NewScalarOp(val, p.tagOpts)
+				if err != nil {
+					return err
+				}
+
+				opTransform := parser.NewTransformFromOperation(op, p.transformLen())
+				p.transforms = append(p.transforms, opTransform)
+				return nil
+			}
+
+			// NB: non-constant scalars, e.g. vector(time()), are evaluated and
+			// then converted to a vector.
+			if err := p.walk(n.Args[0]); err != nil {
 				return err
 			}
 
-			op, err := scalar.NewScalarOp(val, p.tagOpts)
+			op, err := scalar.NewToVectorOp(p.tagOpts)
 			if err != nil {
 				return err
 			}
 
-			opTransform := parser.NewTransformFromOperation(op, p.transformLen())
-			p.transforms = append(p.transforms, opTransform)
+			p.addTransformWithParent(op)
 			return nil
 		}
 
@@ -300,7 +471,10 @@ func (p *parseState) walk(node pql.Node) error {
 			} else if argType == pql.ValueTypeString {
 				stringValues = append(stringValues, expr.(*pql.StringLiteral).Val)
 			} else {
-				if e, ok := expr.(*pql.MatrixSelector); ok {
+				switch e := expr.(type) {
+				case *pql.MatrixSelector:
+					argValues = append(argValues, e.Range)
+				case *pql.SubqueryExpr:
 					argValues = append(argValues, e.Range)
 				}
 
@@ -334,11 +508,12 @@ func (p *parseState) walk(node pql.Node) error {
 		}
 
 		if !ok {
-			return nil
+			return p.addPendingAtTransform(startIdx)
 		}
 
 		opTransform := parser.NewTransformFromOperation(op, p.transformLen())
-		if op.OpType() != scalar.TimeType {
+		// NB: scalar sources such as time() and pi() have no parents.
+		if op.OpType() != scalar.TimeType && op.OpType() != scalar.ScalarType {
 			p.edges = append(p.edges, parser.Edge{
 				ParentID: p.lastTransformID(),
 				ChildID:  opTransform.ID,
@@ -346,7 +521,11 @@ func (p *parseState) walk(node pql.Node) error {
 		}
 
 		p.transforms = append(p.transforms, opTransform)
-		return nil
+		if n.Func.Name == temporal.AbsentType {
+			p.addTransformWithParent(aggregation.NewAbsentOp())
+		}
+
+		return p.addPendingAtTransform(startIdx)
 
 	case *pql.BinaryExpr:
 		err := p.walk(n.LHS)
@@ -22,9 +22,11 @@ package promql
 
 import (
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/functions"
 	"github.com/m3db/m3/src/query/functions/aggregation"
 	"github.com/m3db/m3/src/query/functions/binary"
@@ -77,9 +79,83 @@ func TestDAGWithOffset(t *testing.T) {
 		"offset should be the child")
 }
 
-func TestInvalidOffset(t *testing.T) {
+func TestDAGWithNegativeOffset(t *testing.T) {
 	q := "up offset -2m"
-	_, err := Parse(q, time.Second, models.NewTagOptions(), NewParseOptions())
+	p, err := Parse(q, time.Second, models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 2)
+	fetch, ok := transforms[0].Op.(functions.FetchOp)
+	require.True(t, ok)
+	assert.Equal(t, -2*time.Minute, fetch.Offset)
+	assert.Equal(t, lazy.OffsetType, transforms[1].Op.OpType())
+	assert.Len(t, edges, 1)
+}
+
+func TestDAGWithAtModifier(t *testing.T) {
+	q := "up offset 1m @ 1609746000"
+	p, err := Parse(q, time.Second, models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 3)
+	fetch, ok := transforms[0].Op.(functions.FetchOp)
+	require.True(t, ok)
+	assert.Equal(t, time.Minute, fetch.Offset)
+	require.NotNil(t, fetch.At)
+	assert.True(t, time.Unix(1609746000, 0).Equal(fetch.At.Timestamp))
+	assert.Equal(t, lazy.OffsetType, transforms[1].Op.OpType())
+	assert.Equal(t, temporal.AtType, transforms[2].Op.OpType())
+	assert.Equal(t, "type: at, timestamp: "+time.Unix(1609746000, 0).String(),
+		transforms[2].Op.String())
+	require.Len(t, edges, 2)
+	assert.Equal(t, parser.NodeID("1"), edges[1].ParentID)
+	assert.Equal(t, parser.NodeID("2"), edges[1].ChildID)
+}
+
+func TestDAGWithAtModifierOnRangeVector(t *testing.T) {
+	q := "sum(rate(up[5m] @ end()))"
+	p, err := Parse(q, time.Second, models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 4)
+	assert.Equal(t, functions.FetchType, transforms[0].Op.OpType())
+	assert.Equal(t, temporal.RateType, transforms[1].Op.OpType())
+	assert.Equal(t, temporal.AtType, transforms[2].Op.OpType())
+	assert.Equal(t, "type: at, timestamp: end()", transforms[2].Op.String())
+	assert.Equal(t, aggregation.SumType, transforms[3].Op.OpType())
+	require.Len(t, edges, 3)
+	for i, edge := range edges {
+		assert.Equal(t, parser.NodeID(fmt.Sprint(i)), edge.ParentID)
+		assert.Equal(t, parser.NodeID(fmt.Sprint(i+1)), edge.ChildID)
+	}
+}
+
+func TestDAGWithAtModifierAndNegativeOffsetOnRangeVector(t *testing.T) {
+	q := "rate(up[5m] @ end() offset -1m)"
+	p, err := Parse(q, time.Second, models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+	transforms, _, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 4)
+	fetch, ok := transforms[0].Op.(functions.FetchOp)
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Minute, fetch.Range)
+	assert.Equal(t, -time.Minute, fetch.Offset)
+	require.NotNil(t, fetch.At)
+	assert.Equal(t, temporal.AtEnd, fetch.At.Kind)
+	assert.Equal(t, lazy.OffsetType, transforms[1].Op.OpType())
+	assert.Equal(t, temporal.RateType, transforms[2].Op.OpType())
+	assert.Equal(t, temporal.AtType, transforms[3].Op.OpType())
+}
+
+func TestDAGWithAtModifierOnBareRangeVectorErrors(t *testing.T) {
+	q := "up[5m] @ start()"
+	p, err := Parse(q, time.Second, models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+	_, _, err = p.DAG()
 	require.Error(t, err)
 }
 
@@ -156,6 +232,9 @@ var aggregateParseTests = []struct {
 	{"count_values(\"some_name\", up)", aggregation.CountValuesType},
 
 	{"absent(up)", aggregation.AbsentType},
+	{"group(up)", aggregation.GroupType},
+	{"group by (t) (up)", aggregation.GroupType},
+	{"GROUP(up) without (t)", aggregation.GroupType},
 }
 
 func TestAggregateParses(t *testing.T) {
@@ -221,6 +300,21 @@ var linearParseTests = []struct {
 	{"log2(up)", linear.Log2Type},
 	{"log10(up)", linear.Log10Type},
 	{"sqrt(up)", linear.SqrtType},
+	{"sgn(up)", linear.SgnType},
+	{"sin(up)", linear.SinType},
+	{"cos(up)", linear.CosType},
+	{"tan(up)", linear.TanType},
+	{"asin(up)", linear.AsinType},
+	{"acos(up)", linear.AcosType},
+	{"atan(up)", linear.AtanType},
+	{"sinh(up)", linear.SinhType},
+	{"cosh(up)", linear.CoshType},
+	{"tanh(up)", linear.TanhType},
+	{"asinh(up)", linear.AsinhType},
+	{"acosh(up)", linear.AcoshType},
+	{"atanh(up)", linear.AtanhType},
+	{"deg(up)", linear.DegType},
+	{"rad(up)", linear.RadType},
 	{"round(up)", linear.RoundType},
 	{"round(up, 10)", linear.RoundType},
 
@@ -319,16 +413,19 @@ func TestScalar(t *testing.T) {
 	require.NoError(t, err)
 	transforms, edges, err := p.DAG()
 	require.NoError(t, err)
-	assert.Len(t, transforms, 1)
+	require.Len(t, transforms, 2)
 	assert.Equal(t, transforms[0].Op.OpType(), functions.FetchType)
 	assert.Equal(t, transforms[0].ID, parser.NodeID("0"))
-	assert.Len(t, edges, 0)
+	assert.Equal(t, transforms[1].Op.OpType(), scalar.ToScalarType)
+	assert.Equal(t, transforms[1].ID, parser.NodeID("1"))
+	require.Len(t, edges, 1)
+	assert.Equal(t, edges[0].ParentID, parser.NodeID("0"))
+	assert.Equal(t, edges[0].ChildID, parser.NodeID("1"))
 }
 
 func TestVector(t *testing.T) {
 	vectorExprs := []string{
 		"vector(12)",
-		"vector(scalar(up))",
 		"vector(12 - scalar(vector(100)-2))",
 	}
 
@@ -347,6 +444,74 @@ func TestVector(t *testing.T) {
 	}
 }
 
+var nonConstantVectorTests = []struct {
+	q             string
+	expectedTypes []string
+}{
+	{
+		"vector(scalar(up))",
+		[]string{functions.FetchType, scalar.ToScalarType, scalar.ToVectorType},
+	},
+	{
+		"vector(time())",
+		[]string{scalar.TimeType, scalar.ToVectorType},
+	},
+}
+
+func TestNonConstantVector(t *testing.T) {
+	for _, tt := range nonConstantVectorTests {
+		t.Run(tt.q, func(t *testing.T) {
+			p, err := Parse(tt.q, time.Second,
+				models.NewTagOptions(), NewParseOptions())
+			require.NoError(t, err)
+			transforms, edges, err := p.DAG()
+			require.NoError(t, err)
+			require.Len(t, transforms, len(tt.expectedTypes))
+			for i, expected := range tt.expectedTypes {
+				assert.Equal(t, expected, transforms[i].Op.OpType())
+			}
+
+			require.Len(t, edges, len(tt.expectedTypes)-1)
+			last := len(tt.expectedTypes) - 1
+			assert.Equal(t, transforms[last-1].ID, edges[last-1].ParentID)
+			assert.Equal(t, transforms[last].ID, edges[last-1].ChildID)
+		})
+	}
+}
+
+func TestPiParse(t *testing.T) {
+	p, err := Parse("up * pi()", time.Second,
+		models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 3)
+	assert.Equal(t, functions.FetchType, transforms[0].Op.OpType())
+	assert.Equal(t, scalar.ScalarType, transforms[1].Op.OpType())
+	op, ok := transforms[1].Op.(*scalar.ScalarOp)
+	require.True(t, ok)
+	assert.Equal(t, math.Pi, op.Value())
+	assert.Equal(t, binary.MultiplyType, transforms[2].Op.OpType())
+	assert.Len(t, edges, 2)
+}
+
+func TestAbsentOverTimeParse(t *testing.T) {
+	p, err := Parse("absent_over_time(up[5m])", time.Second,
+		models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 3)
+	assert.Equal(t, functions.FetchType, transforms[0].Op.OpType())
+	assert.Equal(t, temporal.PresentType, transforms[1].Op.OpType())
+	assert.Equal(t, aggregation.AbsentType, transforms[2].Op.OpType())
+	require.Len(t, edges, 2)
+	assert.Equal(t, parser.NodeID("0"), edges[0].ParentID)
+	assert.Equal(t, parser.NodeID("1"), edges[0].ChildID)
+	assert.Equal(t, parser.NodeID("1"), edges[1].ParentID)
+	assert.Equal(t, parser.NodeID("2"), edges[1].ChildID)
+}
+
 func TestTimeTypeParse(t *testing.T) {
 	q := "time()"
 	p, err := Parse(q, time.Second, models.NewTagOptions(), NewParseOptions())
@@ -470,6 +635,8 @@ var temporalParseTests = []struct {
 	{"stddev_over_time(up[5m])", temporal.StdDevType},
 	{"stdvar_over_time(up[5m])", temporal.StdVarType},
 	{"quantile_over_time(0.2, up[5m])", temporal.QuantileType},
+	{"last_over_time(up[5m])", temporal.LastType},
+	{"present_over_time(up[5m])", temporal.PresentType},
 	{"irate(up[5m])", temporal.IRateType},
 	{"idelta(up[5m])", temporal.IDeltaType},
 	{"rate(up[5m])", temporal.RateType},
@@ -502,6 +669,57 @@ func TestTemporalParses(t *testing.T) {
 	}
 }
 
+func TestSubqueryParses(t *testing.T) {
+	q := "max_over_time(rate(http_requests_total[5m])[1h:1m])"
+	p, err := Parse(q, time.Second, models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 4)
+	assert.Equal(t, functions.FetchType, transforms[0].Op.OpType())
+	assert.Equal(t, temporal.RateType, transforms[1].Op.OpType())
+	assert.Equal(t, temporal.SubqueryType, transforms[2].Op.OpType())
+	assert.Equal(t, temporal.MaxType, transforms[3].Op.OpType())
+	require.Len(t, edges, 3)
+	for i, edge := range edges {
+		assert.Equal(t, parser.NodeID(fmt.Sprint(i)), edge.ParentID)
+		assert.Equal(t, parser.NodeID(fmt.Sprint(i+1)), edge.ChildID)
+	}
+
+	// NB: subquery bounds should account for the range of the inner rate.
+	boundOp, ok := transforms[2].Op.(transform.BoundOp)
+	require.True(t, ok)
+	assert.Equal(t, time.Hour+5*time.Minute, boundOp.Bounds().Range)
+}
+
+func TestSubqueryDefaultStep(t *testing.T) {
+	q := "avg_over_time(up[30m:] offset 90s)"
+	p, err := Parse(q, time.Minute, models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+	transforms, _, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 3)
+	assert.Equal(t, temporal.SubqueryType, transforms[1].Op.OpType())
+	assert.Equal(t, "type: subquery, range: 30m0s, step: 1m0s, offset: 2m0s",
+		transforms[1].Op.String())
+}
+
+func TestNestedSubqueryParses(t *testing.T) {
+	q := "max_over_time(deriv(rate(foo[1m])[5m:1m])[1h:5m])"
+	p, err := Parse(q, time.Minute, models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+	transforms, _, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 6)
+	assert.Equal(t, temporal.SubqueryType, transforms[2].Op.OpType())
+	assert.Equal(t, temporal.DerivType, transforms[3].Op.OpType())
+	assert.Equal(t, temporal.SubqueryType, transforms[4].Op.OpType())
+
+	boundOp, ok := transforms[4].Op.(transform.BoundOp)
+	require.True(t, ok)
+	assert.Equal(t, time.Hour+6*time.Minute, boundOp.Bounds().Range)
+}
+
 var tagParseTests = []struct {
 	q            string
 	expectedType string
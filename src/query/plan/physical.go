@@ -35,9 +35,14 @@ type PhysicalPlan struct {
 	pipeline         []parser.NodeID // Ordered list of steps to be performed
 	ResultStep       ResultOp
 	TimeSpec         transform.TimeSpec
+	QueryStart       time.Time
+	QueryEnd         time.Time
 	Debug            bool
 	BlockType        models.FetchedBlockType
 	LookbackDuration time.Duration
+	// StreamingChunkSteps is the number of steps that fetched blocks are
+	// streamed through the plan in, zero to process them at once.
+	StreamingChunkSteps int
 }
 
 // ResultOp is responsible for delivering results to the clients.
@@ -70,6 +75,8 @@ func NewPhysicalPlan(
 			Now:   params.Now,
 			Step:  params.Step,
 		},
+		QueryStart:       params.Start,
+		QueryEnd:         params.End,
 		Debug:            params.Debug,
 		BlockType:        params.BlockType,
 		LookbackDuration: params.LookbackDuration,
@@ -428,6 +428,7 @@ func Run(runOpts RunOptions) {
 	engineOpts := executor.NewEngineOptions().
 		SetStore(backendStorage).
 		SetLookbackDuration(*cfg.LookbackDuration).
+		SetStreamingChunkSteps(cfg.Query.Streaming.ChunkStepsOrDefault()).
 		SetInstrumentOptions(instrumentOptions.
 			SetMetricsScope(instrumentOptions.MetricsScope().SubScope("engine")))
 	if fn := runOpts.CustomPromQLParseFunction; fn != nil {
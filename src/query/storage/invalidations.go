@@ -0,0 +1,98 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/x/clock"
+)
+
+// Invalidations records the time ranges of stored data which changed after it
+// was written, such as when series are deleted, so that results computed over
+// those time ranges before the change can be recognized as stale.
+type Invalidations struct {
+	sync.RWMutex
+
+	maxAge  time.Duration
+	nowFn   clock.NowFn
+	entries []invalidation
+}
+
+type invalidation struct {
+	start time.Time
+	end   time.Time
+	at    time.Time
+}
+
+// NewInvalidations returns a new set of invalidations which are retained for
+// the max age, which should be at least as long as any result computed over
+// the invalidated data can be retained.
+func NewInvalidations(maxAge time.Duration, nowFn clock.NowFn) *Invalidations {
+	return &Invalidations{
+		maxAge: maxAge,
+		nowFn:  nowFn,
+	}
+}
+
+// Invalidate records that the data between start and end changed.
+func (i *Invalidations) Invalidate(start, end time.Time) {
+	now := i.nowFn()
+
+	i.Lock()
+	defer i.Unlock()
+
+	expired := 0
+	for _, e := range i.entries {
+		if !e.at.Add(i.maxAge).Before(now) {
+			break
+		}
+		expired++
+	}
+
+	i.entries = append(i.entries[expired:], invalidation{
+		start: start,
+		end:   end,
+		at:    now,
+	})
+}
+
+// InvalidatedSince returns true if any of the data between start and end
+// changed at or after the given time.
+func (i *Invalidations) InvalidatedSince(start, end, since time.Time) bool {
+	i.RLock()
+	defer i.RUnlock()
+
+	// NB: entries are ordered by the time they were recorded, so iterate
+	// backwards to stop at the first entry recorded before since.
+	for j := len(i.entries) - 1; j >= 0; j-- {
+		e := i.entries[j]
+		if e.at.Before(since) {
+			return false
+		}
+		if !e.start.After(end) && !e.end.Before(start) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidations(t *testing.T) {
+	var (
+		start = time.Unix(1000, 0)
+		now   = start
+	)
+	invalidations := NewInvalidations(time.Minute, func() time.Time { return now })
+
+	invalidations.Invalidate(start.Add(10*time.Second), start.Add(20*time.Second))
+	assert.True(t, invalidations.InvalidatedSince(start, start.Add(10*time.Second), start))
+	assert.True(t, invalidations.InvalidatedSince(start.Add(15*time.Second),
+		start.Add(time.Minute), start))
+	assert.False(t, invalidations.InvalidatedSince(start, start.Add(5*time.Second), start))
+	assert.False(t, invalidations.InvalidatedSince(start.Add(30*time.Second),
+		start.Add(time.Minute), start))
+
+	// Invalidations recorded before the given time are ignored.
+	now = start.Add(time.Second)
+	assert.False(t, invalidations.InvalidatedSince(start, start.Add(time.Minute), now))
+
+	// Invalidations older than the max age are removed.
+	now = start.Add(2 * time.Minute)
+	invalidations.Invalidate(start, start.Add(time.Second))
+	assert.Equal(t, 1, len(invalidations.entries))
+	assert.True(t, invalidations.InvalidatedSince(start, start.Add(time.Second), now))
+}
@@ -81,8 +81,10 @@ type ClusterNamespace interface {
 type ClusterNamespaceOptions struct {
 	// Note: Don't allow direct access, as we want to provide defaults
 	// and/or error if call to access a field is not relevant/correct.
-	attributes storagemetadata.Attributes
-	downsample *ClusterNamespaceDownsampleOptions
+	attributes        storagemetadata.Attributes
+	downsample        *ClusterNamespaceDownsampleOptions
+	bufferPast        time.Duration
+	coldWritesEnabled bool
 }
 
 // Attributes returns the storage attributes of the cluster namespace.
@@ -90,6 +92,18 @@ func (o ClusterNamespaceOptions) Attributes() storagemetadata.Attributes {
 	return o.attributes
 }
 
+// BufferPast returns how far in the past writes are accepted by the cluster
+// namespace, or zero if it is not known.
+func (o ClusterNamespaceOptions) BufferPast() time.Duration {
+	return o.bufferPast
+}
+
+// ColdWritesEnabled returns whether the cluster namespace accepts writes
+// older than its buffer past.
+func (o ClusterNamespaceOptions) ColdWritesEnabled() bool {
+	return o.coldWritesEnabled
+}
+
 // DownsampleOptions returns the downsample options for a cluster namespace,
 // which is only valid if the namespace is an aggregated cluster namespace.
 func (o ClusterNamespaceOptions) DownsampleOptions() (
@@ -149,9 +163,11 @@ func (n ClusterNamespaces) NumAggregatedClusterNamespaces() int {
 // UnaggregatedClusterNamespaceDefinition is the definition for the
 // cluster namespace that holds unaggregated metrics data.
 type UnaggregatedClusterNamespaceDefinition struct {
-	NamespaceID ident.ID
-	Session     client.Session
-	Retention   time.Duration
+	NamespaceID       ident.ID
+	Session           client.Session
+	Retention         time.Duration
+	BufferPast        time.Duration
+	ColdWritesEnabled bool
 }
 
 // Validate will validate the cluster namespace definition.
@@ -172,11 +188,13 @@ func (def UnaggregatedClusterNamespaceDefinition) Validate() error {
 // cluster namespace that holds aggregated metrics data at a
 // specific retention and resolution.
 type AggregatedClusterNamespaceDefinition struct {
-	NamespaceID ident.ID
-	Session     client.Session
-	Retention   time.Duration
-	Resolution  time.Duration
-	Downsample  *ClusterNamespaceDownsampleOptions
+	NamespaceID       ident.ID
+	Session           client.Session
+	Retention         time.Duration
+	Resolution        time.Duration
+	Downsample        *ClusterNamespaceDownsampleOptions
+	BufferPast        time.Duration
+	ColdWritesEnabled bool
 }
 
 // Validate validates the cluster namespace definition.
@@ -329,6 +347,8 @@ func newUnaggregatedClusterNamespace(
 				MetricsType: storagemetadata.UnaggregatedMetricsType,
 				Retention:   def.Retention,
 			},
+			bufferPast:        def.BufferPast,
+			coldWritesEnabled: def.ColdWritesEnabled,
 		},
 		session: def.Session,
 	}, nil
@@ -352,7 +372,9 @@ func newAggregatedClusterNamespace(
 				Retention:   def.Retention,
 				Resolution:  def.Resolution,
 			},
-			downsample: def.Downsample,
+			downsample:        def.Downsample,
+			bufferPast:        def.BufferPast,
+			coldWritesEnabled: def.ColdWritesEnabled,
 		},
 		session: def.Session,
 	}, nil
@@ -285,15 +285,19 @@ func toClusterNamespaces(clusterCfg DynamicClusterNamespaceConfiguration, md nam
 				Downsample: &ClusterNamespaceDownsampleOptions{
 					All: agg.Attributes.DownsampleOptions.All,
 				},
+				BufferPast:        retOpts.BufferPast(),
+				ColdWritesEnabled: md.Options().ColdWritesEnabled(),
 			})
 			if err != nil {
 				return nil, err
 			}
 		} else {
 			clusterNamespace, err = newUnaggregatedClusterNamespace(UnaggregatedClusterNamespaceDefinition{
-				NamespaceID: md.ID(),
-				Session:     clusterCfg.session,
-				Retention:   retOpts.RetentionPeriod(),
+				NamespaceID:       md.ID(),
+				Session:           clusterCfg.session,
+				Retention:         retOpts.RetentionPeriod(),
+				BufferPast:        retOpts.BufferPast(),
+				ColdWritesEnabled: md.Options().ColdWritesEnabled(),
 			})
 			if err != nil {
 				return nil, err
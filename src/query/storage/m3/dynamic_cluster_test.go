@@ -141,6 +141,7 @@ func TestDynamicClustersInitialization(t *testing.T) {
 	defer clusters.Close()
 
 	requireClusterNamespace(t, clusters, defaultTestNs2ID, ClusterNamespaceOptions{
+		bufferPast: 10 * time.Minute,
 		attributes: storagemetadata.Attributes{
 			MetricsType: storagemetadata.AggregatedMetricsType,
 			Retention:   48 * time.Hour,
@@ -150,6 +151,7 @@ func TestDynamicClustersInitialization(t *testing.T) {
 	})
 
 	requireClusterNamespace(t, clusters, defaultTestNs1ID, ClusterNamespaceOptions{
+		bufferPast: 10 * time.Minute,
 		attributes: storagemetadata.Attributes{
 			MetricsType: storagemetadata.UnaggregatedMetricsType,
 			Retention:   48 * time.Hour,
@@ -185,6 +187,7 @@ func TestDynamicClustersWithUpdates(t *testing.T) {
 	defer clusters.Close()
 
 	requireClusterNamespace(t, clusters, defaultTestNs2ID, ClusterNamespaceOptions{
+		bufferPast: 10 * time.Minute,
 		attributes: storagemetadata.Attributes{
 			MetricsType: storagemetadata.AggregatedMetricsType,
 			Retention:   48 * time.Hour,
@@ -194,6 +197,7 @@ func TestDynamicClustersWithUpdates(t *testing.T) {
 	})
 
 	requireClusterNamespace(t, clusters, defaultTestNs1ID, ClusterNamespaceOptions{
+		bufferPast: 10 * time.Minute,
 		attributes: storagemetadata.Attributes{
 			MetricsType: storagemetadata.UnaggregatedMetricsType,
 			Retention:   48 * time.Hour,
@@ -218,6 +222,7 @@ func TestDynamicClustersWithUpdates(t *testing.T) {
 
 	require.True(t, xclock.WaitUntil(func() bool {
 		found := assertClusterNamespace(clusters, defaultTestNs2ID, ClusterNamespaceOptions{
+			bufferPast: 10 * time.Minute,
 			attributes: storagemetadata.Attributes{
 				MetricsType: storagemetadata.AggregatedMetricsType,
 				Retention:   48 * time.Hour,
@@ -290,6 +295,7 @@ func TestDynamicClustersWithMultipleInitializers(t *testing.T) {
 	defer clusters.Close()
 
 	requireClusterNamespace(t, clusters, defaultTestNs2ID, ClusterNamespaceOptions{
+		bufferPast: 10 * time.Minute,
 		attributes: storagemetadata.Attributes{
 			MetricsType: storagemetadata.AggregatedMetricsType,
 			Retention:   48 * time.Hour,
@@ -299,6 +305,7 @@ func TestDynamicClustersWithMultipleInitializers(t *testing.T) {
 	})
 
 	requireClusterNamespace(t, clusters, fooNsID, ClusterNamespaceOptions{
+		bufferPast: 10 * time.Minute,
 		attributes: storagemetadata.Attributes{
 			MetricsType: storagemetadata.AggregatedMetricsType,
 			Retention:   48 * time.Hour,
@@ -308,6 +315,7 @@ func TestDynamicClustersWithMultipleInitializers(t *testing.T) {
 	})
 
 	requireClusterNamespace(t, clusters, barNsID, ClusterNamespaceOptions{
+		bufferPast: 10 * time.Minute,
 		attributes: storagemetadata.Attributes{
 			MetricsType: storagemetadata.AggregatedMetricsType,
 			Retention:   48 * time.Hour,
@@ -317,6 +325,7 @@ func TestDynamicClustersWithMultipleInitializers(t *testing.T) {
 	})
 
 	requireClusterNamespace(t, clusters, defaultTestNs1ID, ClusterNamespaceOptions{
+		bufferPast: 10 * time.Minute,
 		attributes: storagemetadata.Attributes{
 			MetricsType: storagemetadata.UnaggregatedMetricsType,
 			Retention:   48 * time.Hour,
@@ -359,6 +368,7 @@ func TestDynamicClustersNonReadyNamespace(t *testing.T) {
 	defer clusters.Close()
 
 	requireClusterNamespace(t, clusters, defaultTestNs1ID, ClusterNamespaceOptions{
+		bufferPast: 10 * time.Minute,
 		attributes: storagemetadata.Attributes{
 			MetricsType: storagemetadata.UnaggregatedMetricsType,
 			Retention:   48 * time.Hour,
@@ -400,6 +410,7 @@ func TestDynamicClustersEmptyNamespacesThenUpdates(t *testing.T) {
 
 	require.True(t, xclock.WaitUntil(func() bool {
 		found := assertClusterNamespace(clusters, defaultTestNs2ID, ClusterNamespaceOptions{
+			bufferPast: 10 * time.Minute,
 			attributes: storagemetadata.Attributes{
 				MetricsType: storagemetadata.AggregatedMetricsType,
 				Retention:   48 * time.Hour,
@@ -409,6 +420,7 @@ func TestDynamicClustersEmptyNamespacesThenUpdates(t *testing.T) {
 		})
 
 		found = found && assertClusterNamespace(clusters, defaultTestNs1ID, ClusterNamespaceOptions{
+			bufferPast: 10 * time.Minute,
 			attributes: storagemetadata.Attributes{
 				MetricsType: storagemetadata.UnaggregatedMetricsType,
 				Retention:   48 * time.Hour,
@@ -49,6 +49,8 @@ import (
 
 	cparser "github.com/m3db/m3/src/cmd/services/m3comparator/main/parser"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/models"
+	m3promql "github.com/m3db/m3/src/query/parser/promql"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/common/model"
@@ -145,6 +147,14 @@ func (t *Test) parseLoad(lines []string, i int) (int, *loadCmd, error) {
 	return i, cmd, nil
 }
 
+// parseExpr validates a query with the M3 PromQL parser, which supports
+// functions and aggregations that the Prometheus parser does not.
+func parseExpr(expr string) error {
+	_, err := m3promql.Parse(expr, time.Second,
+		models.NewTagOptions(), m3promql.NewParseOptions())
+	return err
+}
+
 func (t *Test) parseEval(lines []string, i int) (int, *evalCmd, error) {
 	if !patEvalInstant.MatchString(lines[i]) {
 		return i, nil, raise(i, "invalid evaluation command. (eval[_fail|_ordered] instant [at <offset:duration>] <query>")
@@ -155,7 +165,7 @@ func (t *Test) parseEval(lines []string, i int) (int, *evalCmd, error) {
 		at   = parts[2]
 		expr = parts[3]
 	)
-	_, err := parser.ParseExpr(expr)
+	err := parseExpr(expr)
 	if err != nil {
 		if perr, ok := err.(*parser.ParseErr); ok {
 			perr.LineOffset = i
@@ -508,13 +518,12 @@ func (t *Test) exec(tc testCommand) error {
 		return cmd.append()
 
 	case *evalCmd:
-		expr, err := parser.ParseExpr(cmd.expr)
-		if err != nil {
+		if err := parseExpr(cmd.expr); err != nil {
 			return err
 		}
 
 		t := time.Unix(0, startingTime+(cmd.start.Unix()*1000000000))
-		bodyBytes, err := cmd.m3query.query(expr.String(), t)
+		bodyBytes, err := cmd.m3query.query(cmd.expr, t)
 		if err != nil {
 			if cmd.fail {
 				return nil
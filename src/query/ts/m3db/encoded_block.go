@@ -27,6 +27,7 @@ import (
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/query/ts"
 	tsconsolidators "github.com/m3db/m3/src/query/ts/m3db/consolidators"
 )
 
@@ -128,3 +129,46 @@ func (b *encodedBlock) Meta() block.Metadata {
 func (b *encodedBlock) Info() block.BlockInfo {
 	return block.NewBlockInfo(block.BlockM3TSZCompressed)
 }
+
+// StepChunks splits the block into consecutive chunks of steps, which are
+// consolidated in a single pass over the series iterators of the block.
+func (b *encodedBlock) StepChunks(chunkSteps int) ([]block.Block, error) {
+	return block.NewStepChunks(b, chunkSteps)
+}
+
+// SeriesChunks splits the series of the block into consecutive chunks of
+// steps, which are decoded in a single pass over the series iterators of the
+// block.
+func (b *encodedBlock) SeriesChunks(
+	chunkSteps int,
+	overlap time.Duration,
+) ([]block.Block, error) {
+	iters := make([]block.DatapointIter, 0, len(b.seriesBlockIterators))
+	for _, iter := range b.seriesBlockIterators {
+		iters = append(iters, encodedDatapointIter{iter: iter})
+	}
+
+	return block.NewSeriesChunks(b.meta, b.seriesMetas, iters, chunkSteps,
+		overlap)
+}
+
+// encodedDatapointIter iterates over the decoded datapoints of a series.
+type encodedDatapointIter struct {
+	iter encoding.SeriesIterator
+}
+
+func (it encodedDatapointIter) Next() bool {
+	return it.iter.Next()
+}
+
+func (it encodedDatapointIter) Current() ts.Datapoint {
+	dp, _, _ := it.iter.Current()
+	return ts.Datapoint{
+		Timestamp: dp.Timestamp,
+		Value:     dp.Value,
+	}
+}
+
+func (it encodedDatapointIter) Err() error {
+	return it.iter.Err()
+}
@@ -183,6 +183,52 @@ func TestConsolidatedStepIteratorMinuteLookbackSequential(t *testing.T) {
 	testConsolidatedStepIteratorMinuteLookback(t, false)
 }
 
+func TestConsolidatedStepIteratorChunked(t *testing.T) {
+	// NB: chunks share a single pass over the series iterators, so lookback
+	// across chunk boundaries must yield the same steps as the whole block.
+	for _, chunkSteps := range []int{1, 4, 100} {
+		for _, tt := range consolidatedStepIteratorTests {
+			opts := newTestOptions().
+				SetLookbackDuration(1 * time.Minute).
+				SetSplitSeriesByBlock(false)
+			require.NoError(t, opts.Validate())
+			opts = withPool(t, opts)
+
+			blocks, bounds := generateBlocks(t, tt.stepSize, opts)
+			j := 0
+			for _, bl := range blocks {
+				chunker, ok := bl.(block.StepChunker)
+				require.True(t, ok)
+				chunks, err := chunker.StepChunks(chunkSteps)
+				require.NoError(t, err)
+
+				steps := 0
+				for _, chunk := range chunks {
+					chunkBounds := chunk.Meta().Bounds
+					assert.Equal(t, bounds.Start.Add(time.Duration(steps)*bounds.StepSize),
+						chunkBounds.Start)
+
+					iter, err := chunk.StepIter()
+					require.NoError(t, err)
+					assert.Equal(t, chunkBounds.Steps(), iter.StepCount())
+					for iter.Next() {
+						test.EqualsWithNans(t, tt.expected[j], iter.Current().Values())
+						j++
+					}
+
+					require.NoError(t, iter.Err())
+					iter.Close()
+					steps += chunkBounds.Steps()
+				}
+
+				require.NoError(t, bl.Close())
+			}
+
+			assert.Equal(t, len(tt.expected), j, tt.name)
+		}
+	}
+}
+
 var consolidatedStepIteratorTestsSplitByBlock = []struct {
 	name     string
 	stepSize time.Duration